@@ -25,6 +25,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/flogging"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
@@ -95,3 +96,28 @@ func (*ServerAdmin) SetModuleLogLevel(ctx context.Context, request *pb.LogLevelR
 
 	return logResponse, err
 }
+
+// ListChaincodes returns the status of the chaincodes running on the peer
+func (*ServerAdmin) ListChaincodes(context.Context, *empty.Empty) (*pb.ChaincodeStatusList, error) {
+	return &pb.ChaincodeStatusList{Chaincodes: chaincode.ListChaincodes()}, nil
+}
+
+// StopChaincode stops a chaincode running on the peer
+func (*ServerAdmin) StopChaincode(ctx context.Context, request *pb.ChaincodeRequest) (*pb.ChaincodeStatus, error) {
+	status, err := chaincode.StopChaincode(ctx, request.ChainID, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("returning status: %s", status)
+	return status, nil
+}
+
+// StartChaincode launches a chaincode deployed on the peer
+func (*ServerAdmin) StartChaincode(ctx context.Context, request *pb.ChaincodeRequest) (*pb.ChaincodeStatus, error) {
+	status, err := chaincode.StartChaincode(request.ChainID, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf("returning status: %s", status)
+	return status, nil
+}
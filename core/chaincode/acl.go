@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// ACLDeniedErr is returned when the creator of a proposal doesn't satisfy the ACL of a chaincode
+type ACLDeniedErr string
+
+func (a ACLDeniedErr) Error() string {
+	return fmt.Sprintf("access denied: %s", string(a))
+}
+
+// UnmarshalACL unmarshals the ACL of a chaincode as stored by LCCC. An empty ACL is nil
+func UnmarshalACL(aclBytes []byte) (*pb.ChaincodeACL, error) {
+	if len(aclBytes) == 0 {
+		return nil, nil
+	}
+	acl := &pb.ChaincodeACL{}
+	if err := proto.Unmarshal(aclBytes, acl); err != nil {
+		return nil, fmt.Errorf("invalid chaincode ACL: %s", err)
+	}
+	return acl, nil
+}
+
+// CheckACL returns nil if the creator of signedProp satisfies the policy of function in
+// acl. A nil acl, or one with neither a policy for function nor a default policy, lets
+// anyone invoke function
+func CheckACL(acl *pb.ChaincodeACL, function string, signedProp *pb.SignedProposal, creator []byte) error {
+	if acl == nil {
+		return nil
+	}
+	policy, ok := acl.Functions[function]
+	if !ok {
+		policy = acl.Default
+	}
+	if policy == nil {
+		return nil
+	}
+	return checkPolicy(policy, signedProp, creator, fmt.Sprintf("the creator cannot invoke %s", function))
+}
+
+// CheckACLAdmins returns nil if the creator of signedProp satisfies the admins policy of acl,
+// that is if it can update acl. Without an admins policy, the ACL cannot be updated
+func CheckACLAdmins(acl *pb.ChaincodeACL, signedProp *pb.SignedProposal, creator []byte) error {
+	if acl == nil || acl.Admins == nil {
+		return ACLDeniedErr("the ACL has no admins")
+	}
+	return checkPolicy(acl.Admins, signedProp, creator, "the creator is not an admin of the chaincode")
+}
+
+// checkPolicy evaluates policy against the signature of the proposal by its creator
+func checkPolicy(policy *cb.SignaturePolicyEnvelope, signedProp *pb.SignedProposal, creator []byte, reason string) error {
+	evaluator, err := cauthdsl.NewSignaturePolicyEvaluator(policy, cauthdsl.NewMSPCryptoHelper(msp.GetManager()))
+	if err != nil {
+		return fmt.Errorf("invalid ACL policy: %s", err)
+	}
+	if !evaluator.Authenticate(signedProp.ProposalBytes, [][]byte{creator}, [][]byte{signedProp.Signature}) {
+		return ACLDeniedErr(reason)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/util"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+)
+
+func signedProposalForACL(t *testing.T) (*pb.SignedProposal, []byte) {
+	creator, err := signer.Serialize()
+	if err != nil {
+		t.Fatalf("Could not serialize the signer: %s", err)
+	}
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_GOLANG, ChaincodeID: &pb.ChaincodeID{Name: "mycc"}, CtorMsg: &pb.ChaincodeInput{Args: [][]byte{[]byte("transfer")}}}}
+	prop, err := putils.CreateProposalFromCIS(util.GenerateUUID(), cis, creator)
+	if err != nil {
+		t.Fatalf("Could not create the proposal: %s", err)
+	}
+	signedProp, err := putils.GetSignedProposal(prop, signer)
+	if err != nil {
+		t.Fatalf("Could not sign the proposal: %s", err)
+	}
+	return signedProp, creator
+}
+
+func TestCheckACL(t *testing.T) {
+	signedProp, creator := signedProposalForACL(t)
+	signedByCreator := cauthdsl.Envelope(cauthdsl.SignedBy(0), [][]byte{creator})
+
+	if err := CheckACL(nil, "transfer", signedProp, creator); err != nil {
+		t.Fatalf("Expected a chaincode without ACL to be invokable, got %s", err)
+	}
+
+	acl := &pb.ChaincodeACL{
+		Functions: map[string]*cb.SignaturePolicyEnvelope{"transfer": signedByCreator, "burn": cauthdsl.RejectAllPolicy},
+	}
+	if err := CheckACL(acl, "transfer", signedProp, creator); err != nil {
+		t.Fatalf("Expected the creator to satisfy the policy of transfer, got %s", err)
+	}
+	if err := CheckACL(acl, "burn", signedProp, creator); err == nil {
+		t.Fatal("Expected the creator to be denied burn")
+	} else if _, ok := err.(ACLDeniedErr); !ok {
+		t.Fatalf("Expected an ACLDeniedErr, got %s", err)
+	}
+	if err := CheckACL(acl, "query", signedProp, creator); err != nil {
+		t.Fatalf("Expected a function without policy nor default to be invokable, got %s", err)
+	}
+
+	acl.Default = cauthdsl.RejectAllPolicy
+	if err := CheckACL(acl, "query", signedProp, creator); err == nil {
+		t.Fatal("Expected the default policy to deny query")
+	}
+
+	// the signature must be the creator's signature of the proposal
+	signedProp.Signature = []byte("bad signature")
+	if err := CheckACL(acl, "transfer", signedProp, creator); err == nil {
+		t.Fatal("Expected a bad signature to be denied")
+	}
+}
+
+func TestCheckACLAdmins(t *testing.T) {
+	signedProp, creator := signedProposalForACL(t)
+
+	if err := CheckACLAdmins(&pb.ChaincodeACL{}, signedProp, creator); err == nil {
+		t.Fatal("Expected an ACL without admins not to be updatable")
+	}
+	acl := &pb.ChaincodeACL{Admins: cauthdsl.Envelope(cauthdsl.SignedBy(0), [][]byte{creator})}
+	if err := CheckACLAdmins(acl, signedProp, creator); err != nil {
+		t.Fatalf("Expected the creator to be an admin, got %s", err)
+	}
+	acl.Admins = cauthdsl.RejectAllPolicy
+	if err := CheckACLAdmins(acl, signedProp, creator); err == nil {
+		t.Fatal("Expected the creator not to be an admin")
+	}
+}
+
+func TestUnmarshalACL(t *testing.T) {
+	if acl, err := UnmarshalACL(nil); acl != nil || err != nil {
+		t.Fatalf("Expected an empty ACL to be nil, got %v (%v)", acl, err)
+	}
+	if _, err := UnmarshalACL([]byte("bad acl")); err == nil {
+		t.Fatal("Expected a bad ACL to fail")
+	}
+	b, _ := proto.Marshal(&pb.ChaincodeACL{Default: cauthdsl.AcceptAllPolicy})
+	if acl, err := UnmarshalACL(b); err != nil || acl.Default == nil {
+		t.Fatalf("Expected the ACL to be unmarshaled, got %v (%v)", acl, err)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/flogging"
+	"github.com/hyperledger/fabric/metrics"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -48,12 +49,21 @@ const (
 	DevModeUserRunsChaincode       string = "dev"
 	chaincodeStartupTimeoutDefault int    = 5000
 	chaincodeInstallPathDefault    string = "/opt/gopath/bin/"
+	chaincodeRestartBackoffDefault int    = 1000
 	peerAddressDefault             string = "0.0.0.0:7051"
 
 	//TXSimulatorKey is used to attach ledger simulation context
 	TXSimulatorKey string = "txsimulatorkey"
+
+	//SignedProposalKey is used to attach the signed proposal the chaincode is
+	//invoked for, so that it can be passed on to the chaincode
+	SignedProposalKey string = "signedproposalkey"
 )
 
+// executeTimeouts counts the transactions and queries for which the chaincode did not respond in time
+var executeTimeouts = metrics.NewCounter("chaincode_execute_timeouts_total",
+	"Number of chaincode executions which timed out", "chain", "chaincode")
+
 // chains is a map between different blockchains and their ChaincodeSupport.
 //this needs to be a first class, top-level object... for now, lets just have a placeholder
 var chains map[ChainName]*ChaincodeSupport
@@ -75,6 +85,16 @@ func getTxSimulator(context context.Context) ledger.TxSimulator {
 //This is where the VM that's running the chaincode would hook in
 type chaincodeRTEnv struct {
 	handler *Handler
+	//the deployment spec the container was launched with, nil if the chaincode
+	//was not launched by the peer (ie, in development mode)
+	cds *pb.ChaincodeDeploymentSpec
+	//set once the chaincode is launched and ready, a container which dies before
+	//is not restarted
+	ready bool
+	//the last time a transaction was executed by the chaincode
+	lastActivity time.Time
+	//number of times the chaincode was restarted after its container died
+	restarts int
 }
 
 // runningChaincodes contains maps of chaincodeIDs to their chaincodeRTEs
@@ -82,20 +102,32 @@ type runningChaincodes struct {
 	sync.RWMutex
 	// chaincode environment for each chaincode
 	chaincodeMap map[string]*chaincodeRTEnv
+	// chaincodes whose container died and which are waiting to be relaunched
+	restarting map[string]*chaincodeRestart
 }
 
-// GetChain returns the chaincode support for a given chain
+// GetChain returns the chaincode support for a given chain, nil if the peer has not joined the chain
 func GetChain(name ChainName) *ChaincodeSupport {
 	return chains[name]
 }
 
+//getChaincodeSupport returns the chaincode support executing chaincode ccname on the given chain.
+//System chaincodes run once in the peer, with the chaincode support of the default chain, and
+//access the ledger of the chain through the simulator of the transaction
+func getChaincodeSupport(chainname ChainName, ccname string) *ChaincodeSupport {
+	if IsSysCC(ccname) {
+		return chains[DefaultChain]
+	}
+	return chains[chainname]
+}
+
 //call this under lock
-func (chaincodeSupport *ChaincodeSupport) preLaunchSetup(chaincode string) chan bool {
+func (chaincodeSupport *ChaincodeSupport) preLaunchSetup(chaincode string, cds *pb.ChaincodeDeploymentSpec) chan bool {
 	//register placeholder Handler. This will be transferred in registerHandler
 	//NOTE: from this point, existence of handler for this chaincode means the chaincode
 	//is in the process of getting started (or has been started)
 	notfy := make(chan bool, 1)
-	chaincodeSupport.runningChaincodes.chaincodeMap[chaincode] = &chaincodeRTEnv{handler: &Handler{readyNotify: notfy}}
+	chaincodeSupport.runningChaincodes.chaincodeMap[chaincode] = &chaincodeRTEnv{handler: &Handler{readyNotify: notfy}, cds: cds, lastActivity: time.Now()}
 	return notfy
 }
 
@@ -110,7 +142,7 @@ func NewChaincodeSupport(chainname ChainName, getPeerEndpoint func() (*pb.PeerEn
 	pnid := viper.GetString("peer.networkId")
 	pid := viper.GetString("peer.id")
 
-	s := &ChaincodeSupport{name: chainname, runningChaincodes: &runningChaincodes{chaincodeMap: make(map[string]*chaincodeRTEnv)}, peerNetworkID: pnid, peerID: pid}
+	s := &ChaincodeSupport{name: chainname, runningChaincodes: &runningChaincodes{chaincodeMap: make(map[string]*chaincodeRTEnv), restarting: make(map[string]*chaincodeRestart)}, peerNetworkID: pnid, peerID: pid, stop: make(chan struct{})}
 
 	//initialize global chain
 	chains[chainname] = s
@@ -175,6 +207,22 @@ func NewChaincodeSupport(chainname ChainName, getPeerEndpoint func() (*pb.PeerEn
 		s.chaincodeLogLevel = flogging.DefaultLoggingLevel().String()
 	}
 
+	s.idleTimeout = time.Duration(viper.GetInt("chaincode.idletimeout")) * time.Second
+	s.maxContainers = viper.GetInt("chaincode.maxcontainers")
+	s.restartBackoff = time.Duration(viper.GetInt("chaincode.restartbackoff")) * time.Millisecond
+	if s.restartBackoff <= 0 {
+		s.restartBackoff = time.Duration(chaincodeRestartBackoffDefault) * time.Millisecond
+	}
+	s.restartMaxBackoff = time.Duration(viper.GetInt("chaincode.restartmaxbackoff")) * time.Millisecond
+	if s.restartMaxBackoff < s.restartBackoff {
+		s.restartMaxBackoff = s.restartBackoff
+	}
+
+	//the idle and unresponsive chaincodes are only looked for if they can be detected
+	if s.idleTimeout > 0 || s.keepalive > 0 {
+		go s.monitor()
+	}
+
 	return s
 }
 
@@ -200,6 +248,13 @@ type ChaincodeSupport struct {
 	peerTLSSvrHostOrd    string
 	keepalive            time.Duration
 	chaincodeLogLevel    string
+	idleTimeout          time.Duration
+	maxContainers        int
+	restartBackoff       time.Duration
+	restartMaxBackoff    time.Duration
+	//closed on shutdown to stop monitoring the chaincodes
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 // DuplicateChaincodeHandlerError returned if attempt to register same chaincodeID while a stream already exists.
@@ -261,12 +316,24 @@ func (chaincodeSupport *ChaincodeSupport) deregisterHandler(chaincodehandler *Ha
 	chaincodeLogger.Debugf("Deregister handler: %s", key)
 	chaincodeSupport.runningChaincodes.Lock()
 	defer chaincodeSupport.runningChaincodes.Unlock()
-	if _, ok := chaincodeSupport.chaincodeHasBeenLaunched(key); !ok {
+	chrte, ok := chaincodeSupport.chaincodeHasBeenLaunched(key)
+	if !ok {
 		// Handler NOT found
 		return fmt.Errorf("Error deregistering handler, could not find handler with key: %s", key)
 	}
+	if chrte.handler != chaincodehandler {
+		//the stream of a previous instance of the chaincode ended after it was launched again
+		chaincodeLogger.Debugf("handler with key %s has been replaced, nothing to deregister", key)
+		return nil
+	}
 	delete(chaincodeSupport.runningChaincodes.chaincodeMap, key)
 	chaincodeLogger.Debugf("Deregistered handler with key: %s", key)
+
+	//the chaincode was not stopped by the peer (Stop forgets the chaincode first), its container died
+	if chrte.ready && chrte.inContainer() && !chaincodeSupport.userRunsCC {
+		chaincodeLogger.Warningf("chaincode %s on chain %s ended unexpectedly, restarting it", key, chaincodeSupport.name)
+		chaincodeSupport.scheduleRestart(key, chrte)
+	}
 	return nil
 }
 
@@ -305,9 +372,25 @@ func (chaincodeSupport *ChaincodeSupport) sendInitOrReady(context context.Contex
 	return err
 }
 
+//the name with which a chaincode launched by the peer registers: the chain is part of the name
+//so that the registration is routed to the chaincode support of the chain
+func (chaincodeSupport *ChaincodeSupport) getRegistrationName(cID *pb.ChaincodeID) string {
+	return cID.Name + chainSeparator + string(chaincodeSupport.name)
+}
+
+//the ID of the container of a chaincode: the chaincodes of the default chain are not qualified
+//with the chain, so that they keep the containers they had before the peer had chains
+func (chaincodeSupport *ChaincodeSupport) getCCID(spec *pb.ChaincodeSpec) ccintf.CCID {
+	ccid := ccintf.CCID{ChaincodeSpec: spec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID}
+	if chaincodeSupport.name != DefaultChain {
+		ccid.ChainID = string(chaincodeSupport.name)
+	}
+	return ccid
+}
+
 //get args and env given chaincodeID
 func (chaincodeSupport *ChaincodeSupport) getArgsAndEnv(cID *pb.ChaincodeID, cLang pb.ChaincodeSpec_Type) (args []string, envs []string, err error) {
-	envs = []string{"CORE_CHAINCODE_ID_NAME=" + cID.Name}
+	envs = []string{"CORE_CHAINCODE_ID_NAME=" + chaincodeSupport.getRegistrationName(cID)}
 	//if TLS is enabled, pass TLS material to chaincode
 	if chaincodeSupport.peerTLS {
 		envs = append(envs, "CORE_PEER_TLS_ENABLED=true")
@@ -332,7 +415,7 @@ func (chaincodeSupport *ChaincodeSupport) getArgsAndEnv(cID *pb.ChaincodeID, cLa
 		//TODO add security args
 		args = strings.Split(
 			fmt.Sprintf("/root/Chaincode/bin/runChaincode -a %s -i %s",
-				chaincodeSupport.peerAddress, cID.Name),
+				chaincodeSupport.peerAddress, chaincodeSupport.getRegistrationName(cID)),
 			" ")
 		if chaincodeSupport.peerTLS {
 			args = append(args, " -s")
@@ -351,18 +434,10 @@ func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.
 		return false, fmt.Errorf("chaincode name not set")
 	}
 
-	chaincodeSupport.runningChaincodes.Lock()
-	var ok bool
-	//if its in the map, there must be a connected stream...nothing to do
-	if _, ok = chaincodeSupport.chaincodeHasBeenLaunched(chaincode); ok {
-		chaincodeLogger.Debugf("chaincode is running and ready: %s", chaincode)
-		chaincodeSupport.runningChaincodes.Unlock()
-		return true, nil
+	notfy, alreadyRunning, err := chaincodeSupport.recordLaunch(chaincode, cds)
+	if err != nil || alreadyRunning {
+		return alreadyRunning, err
 	}
-	alreadyRunning := false
-
-	notfy := chaincodeSupport.preLaunchSetup(chaincode)
-	chaincodeSupport.runningChaincodes.Unlock()
 
 	//launch the chaincode
 
@@ -375,7 +450,7 @@ func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.
 
 	vmtype, _ := chaincodeSupport.getVMType(cds)
 
-	sir := container.StartImageReq{CCID: ccintf.CCID{ChaincodeSpec: cds.ChaincodeSpec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID}, Reader: targz, Args: args, Env: env}
+	sir := container.StartImageReq{CCID: chaincodeSupport.getCCID(cds.ChaincodeSpec), Reader: targz, Args: args, Env: env}
 
 	ipcCtxt := context.WithValue(ctxt, ccintf.GetCCHandlerKey(), chaincodeSupport)
 
@@ -417,29 +492,24 @@ func (chaincodeSupport *ChaincodeSupport) Stop(context context.Context, cds *pb.
 		return fmt.Errorf("chaincode name not set")
 	}
 
+	//forget the chaincode before stopping it, so that the end of its stream
+	//is not taken for a crash of the container
+	chaincodeSupport.runningChaincodes.Lock()
+	_, launched := chaincodeSupport.chaincodeHasBeenLaunched(chaincode)
+	delete(chaincodeSupport.runningChaincodes.chaincodeMap, chaincode)
+	chaincodeSupport.runningChaincodes.Unlock()
+
 	//stop the chaincode
-	sir := container.StopImageReq{CCID: ccintf.CCID{ChaincodeSpec: cds.ChaincodeSpec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID}, Timeout: 0}
+	sir := container.StopImageReq{CCID: chaincodeSupport.getCCID(cds.ChaincodeSpec), Timeout: 0}
 
 	vmtype, _ := chaincodeSupport.getVMType(cds)
 
 	_, err := container.VMCProcess(context, vmtype, sir)
-	if err != nil {
-		err = fmt.Errorf("Error stopping container: %s", err)
-		//but proceed to cleanup
-	}
-
-	chaincodeSupport.runningChaincodes.Lock()
-	if _, ok := chaincodeSupport.chaincodeHasBeenLaunched(chaincode); !ok {
-		//nothing to do
-		chaincodeSupport.runningChaincodes.Unlock()
-		return nil
+	if err != nil && launched {
+		return fmt.Errorf("Error stopping container: %s", err)
 	}
 
-	delete(chaincodeSupport.runningChaincodes.chaincodeMap, chaincode)
-
-	chaincodeSupport.runningChaincodes.Unlock()
-
-	return err
+	return nil
 }
 
 // Launch will launch the chaincode if not running (if running return nil) and will wait for handler of the chaincode to get into FSM ready state.
@@ -500,7 +570,7 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, txid s
 		var depPayload []byte
 
 		//hopefully we are restarting from existing image and the deployed transaction exists
-		depPayload, err = GetCDSFromLCCC(context, txid, prop, string(DefaultChain), chaincode)
+		depPayload, err = GetCDSFromLCCC(context, txid, prop, string(chaincodeSupport.name), chaincode)
 		if err != nil {
 			return cID, cMsg, fmt.Errorf("Could not get deployment transaction from LCCC for %s - %s", chaincode, err)
 		}
@@ -539,6 +609,12 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, txid s
 			if errIgnore != nil {
 				chaincodeLogger.Errorf("stop failed %s(%s)", errIgnore, err)
 			}
+		} else {
+			chaincodeSupport.runningChaincodes.Lock()
+			if chrte, ok := chaincodeSupport.chaincodeHasBeenLaunched(chaincode); ok {
+				chrte.ready = true
+			}
+			chaincodeSupport.runningChaincodes.Unlock()
 		}
 		chaincodeLogger.Debug("sending init completed")
 	}
@@ -583,7 +659,7 @@ func (chaincodeSupport *ChaincodeSupport) Deploy(context context.Context, cds *p
 	}
 
 	var targz io.Reader = bytes.NewBuffer(cds.CodePackage)
-	cir := &container.CreateImageReq{CCID: ccintf.CCID{ChaincodeSpec: cds.ChaincodeSpec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID}, Args: args, Reader: targz, Env: envs}
+	cir := &container.CreateImageReq{CCID: chaincodeSupport.getCCID(cds.ChaincodeSpec), Args: args, Reader: targz, Env: envs}
 
 	vmtype, _ := chaincodeSupport.getVMType(cds)
 
@@ -628,6 +704,7 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, chaincod
 		chaincodeLogger.Debugf("cannot execute-chaincode is not running: %s", chaincode)
 		return nil, fmt.Errorf("Cannot execute transaction or query for %s", chaincode)
 	}
+	chrte.lastActivity = time.Now()
 	chaincodeSupport.runningChaincodes.Unlock()
 
 	var notfy chan *pb.ChaincodeMessage
@@ -642,6 +719,7 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, chaincod
 		//are typically treated as error
 	case <-time.After(timeout):
 		err = fmt.Errorf("Timeout expired while executing transaction")
+		executeTimeouts.Inc(string(chaincodeSupport.name), chaincode)
 	}
 
 	//our responsibility to delete transaction context if sendExecuteMessage succeeded
@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"golang.org/x/net/context"
+)
+
+func TestGetChaincodeSupport(t *testing.T) {
+	chain1 := &ChaincodeSupport{name: "chain1"}
+	chains["chain1"] = chain1
+	defer delete(chains, "chain1")
+
+	if cs := getChaincodeSupport("chain1", "mycc"); cs != chain1 {
+		t.Fatalf("Expected mycc to be executed on chain1, got %v", cs)
+	}
+	if cs := getChaincodeSupport("chain1", "lccc"); cs != chains[DefaultChain] {
+		t.Fatalf("Expected lccc to be executed with the chaincode support of the default chain, got %v", cs)
+	}
+	if cs := getChaincodeSupport("chain2", "mycc"); cs != nil {
+		t.Fatalf("Expected no chaincode support for a chain which is not joined, got %v", cs)
+	}
+	if _, _, err := ExecuteChaincode(context.Background(), "txid", nil, "chain2", "mycc", nil); err == nil {
+		t.Fatal("Expected the execution of a chaincode on a chain which is not joined to fail")
+	}
+
+	name := chain1.getRegistrationName(&pb.ChaincodeID{Name: "mycc"})
+	if chaincode, chain := splitChaincodeName(name); chaincode != "mycc" || chain != "chain1" {
+		t.Fatalf("Expected mycc to register on chain1, got %s", name)
+	}
+}
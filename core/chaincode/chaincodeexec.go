@@ -36,10 +36,25 @@ func createCIS(ccname string, args [][]byte) (*pb.ChaincodeInvocationSpec, error
 
 // GetCDSFromLCCC gets chaincode deployment spec from LCCC
 func GetCDSFromLCCC(ctxt context.Context, txid string, prop *pb.Proposal, chainID string, chaincodeID string) ([]byte, error) {
-	payload, _, err := ExecuteChaincode(ctxt, txid, prop, string(DefaultChain), "lccc", [][]byte{[]byte("getdepspec"), []byte(chainID), []byte(chaincodeID)})
+	payload, _, err := ExecuteChaincode(ctxt, txid, prop, chainID, "lccc", [][]byte{[]byte("getdepspec"), []byte(chainID), []byte(chaincodeID)})
 	return payload, err
 }
 
+// GetVersionFromLCCC gets the version of a deployed chaincode from LCCC
+func GetVersionFromLCCC(ctxt context.Context, txid string, prop *pb.Proposal, chainID string, chaincodeID string) (string, error) {
+	payload, _, err := ExecuteChaincode(ctxt, txid, prop, chainID, "lccc", [][]byte{[]byte(GETCCINFO), []byte(chainID), []byte(chaincodeID)})
+	return string(payload), err
+}
+
+// GetACLFromLCCC gets the ACL of a chaincode from LCCC, nil if the chaincode was deployed without one
+func GetACLFromLCCC(ctxt context.Context, txid string, prop *pb.Proposal, chainID string, chaincodeID string) (*pb.ChaincodeACL, error) {
+	payload, _, err := ExecuteChaincode(ctxt, txid, prop, chainID, "lccc", [][]byte{[]byte(GETACL), []byte(chainID), []byte(chaincodeID)})
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalACL(payload)
+}
+
 // ExecuteChaincode executes a given chaincode given chaincode name and arguments
 func ExecuteChaincode(ctxt context.Context, txid string, prop *pb.Proposal, chainname string, ccname string, args [][]byte) ([]byte, *pb.ChaincodeEvent, error) {
 	var spec *pb.ChaincodeInvocationSpec
@@ -47,8 +62,13 @@ func ExecuteChaincode(ctxt context.Context, txid string, prop *pb.Proposal, chai
 	var b []byte
 	var ccevent *pb.ChaincodeEvent
 
+	chaincodeSupport := getChaincodeSupport(ChainName(chainname), ccname)
+	if chaincodeSupport == nil {
+		return nil, nil, fmt.Errorf("Chain %s not found", chainname)
+	}
+
 	spec, err = createCIS(ccname, args)
-	b, ccevent, err = Execute(ctxt, GetChain(ChainName(chainname)), txid, prop, spec)
+	b, ccevent, err = Execute(ctxt, chaincodeSupport, txid, prop, spec)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Error deploying chaincode: %s", err)
 	}
@@ -97,6 +97,7 @@ func initPeer() (net.Listener, error) {
 
 func finitPeer(lis net.Listener) {
 	if lis != nil {
+		Shutdown()
 		deRegisterSysCCs()
 		ledgername := string(DefaultChain)
 		if lgr := kvledger.GetLedger(ledgername); lgr != nil {
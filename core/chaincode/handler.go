@@ -25,8 +25,10 @@ import (
 	"github.com/golang/protobuf/proto"
 	ccintf "github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/util"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/looplab/fsm"
 	"github.com/op/go-logging"
 	"golang.org/x/net/context"
@@ -60,6 +62,10 @@ type transactionContext struct {
 	rangeQueryIteratorMap map[string]ledger.ResultsIterator
 
 	txsimulator ledger.TxSimulator
+
+	// the proposal as signed by its creator, nil if the chaincode is not
+	// invoked for a signed proposal (e.g. when deploying system chaincodes)
+	signedProp *pb.SignedProposal
 }
 
 type nextStateInfo struct {
@@ -90,6 +96,9 @@ type Handler struct {
 
 	// used to do Send after making sure the state transition is complete
 	nextState chan *nextStateInfo
+
+	// the last time a message was received from the chaincode
+	lastHeard time.Time
 }
 
 func shorttxid(txid string) string {
@@ -122,6 +131,7 @@ func (handler *Handler) createTxContext(ctxt context.Context, txid string, prop
 		rangeQueryIteratorMap: make(map[string]ledger.ResultsIterator)}
 	handler.txCtxs[txid] = txctx
 	txctx.txsimulator = getTxSimulator(ctxt)
+	txctx.signedProp, _ = ctxt.Value(SignedProposalKey).(*pb.SignedProposal)
 
 	return txctx, nil
 }
@@ -183,6 +193,26 @@ func (handler *Handler) deregister() error {
 	return nil
 }
 
+//heard records that a message was received from the chaincode
+func (handler *Handler) heard() {
+	handler.Lock()
+	defer handler.Unlock()
+	handler.lastHeard = time.Now()
+}
+
+func (handler *Handler) getLastHeard() time.Time {
+	handler.RLock()
+	defer handler.RUnlock()
+	return handler.lastHeard
+}
+
+//busy returns true if the chaincode is executing transactions
+func (handler *Handler) busy() bool {
+	handler.RLock()
+	defer handler.RUnlock()
+	return len(handler.txCtxs) > 0
+}
+
 func (handler *Handler) triggerNextState(msg *pb.ChaincodeMessage, send bool) {
 	handler.nextState <- &nextStateInfo{msg, send}
 }
@@ -234,6 +264,7 @@ func (handler *Handler) processStream() error {
 				return err
 			}
 			chaincodeLogger.Debugf("[%s]Received message %s from shim", shorttxid(in.Txid), in.Type.String())
+			handler.heard()
 			if in.Type.String() == pb.ChaincodeMessage_ERROR.String() {
 				chaincodeLogger.Errorf("Got error: %s", string(in.Payload))
 			}
@@ -243,8 +274,8 @@ func (handler *Handler) processStream() error {
 
 			if in.Type == pb.ChaincodeMessage_KEEPALIVE {
 				chaincodeLogger.Debug("Received KEEPALIVE Response")
-				// Received a keep alive message, we don't do anything with it for now
-				// and it does not touch the state machine
+				// Received a keep alive message, it only tells that the chaincode is
+				// alive (see heard above) and it does not touch the state machine
 				continue
 			}
 		case nsInfo = <-handler.nextState:
@@ -261,7 +292,7 @@ func (handler *Handler) processStream() error {
 				continue
 			}
 
-			//TODO we could use this to hook into container lifecycle (kill the chaincode if not in use, etc)
+			//a chaincode which does not answer the keepalives is restarted, see ChaincodeSupport.monitor
 			kaerr := handler.serialSend(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_KEEPALIVE})
 			if kaerr != nil {
 				chaincodeLogger.Errorf("Error sending keepalive, err=%s", kaerr)
@@ -445,6 +476,20 @@ func (handler *Handler) beforeRegisterEvent(e *fsm.Event, state string) {
 		return
 	}
 
+	// A chaincode launched by the peer registers as name/chain, route it to the chaincodeSupport
+	// of its chain. A chaincode registering with just its name (as in development mode) is on the
+	// chain of the chaincodeSupport which received the stream
+	if name, chain := splitChaincodeName(chaincodeID.Name); chain != "" {
+		chaincodeSupport := GetChain(ChainName(chain))
+		if chaincodeSupport == nil {
+			e.Cancel(fmt.Errorf("Error registering chaincode %s, chain %s not found", name, chain))
+			handler.notifyDuringStartup(false)
+			return
+		}
+		handler.chaincodeSupport = chaincodeSupport
+		chaincodeID.Name = name
+	}
+
 	// Now register with the chaincodeSupport
 	handler.ChaincodeID = chaincodeID
 	err = handler.chaincodeSupport.registerHandler(handler)
@@ -938,19 +983,42 @@ func (handler *Handler) enterBusyState(e *fsm.Event, state string) {
 				return
 			}
 
-			// Get the chaincodeID to invoke
-			newChaincodeID := chaincodeSpec.ChaincodeID.Name
+			// Get the chaincodeID to invoke, which is of the form name/chain for a chaincode on another chain
+			newChaincodeID, calledChain := splitChaincodeName(chaincodeSpec.ChaincodeID.Name)
+			chaincodeSpec.ChaincodeID.Name = newChaincodeID
 			chaincodeLogger.Debugf("[%s] C-call-C %s", shorttxid(msg.Txid), newChaincodeID)
+			if err = checkCalledChaincode(newChaincodeID, chaincodeSpec); err != nil {
+				chaincodeLogger.Debugf("[%s]Rejected invocation of %s. Sending %s", shorttxid(msg.Txid), newChaincodeID, pb.ChaincodeMessage_ERROR)
+				triggerNextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid}
+				return
+			}
 
 			txContext := handler.getTxContext(msg.Txid)
+			chaincodeSupport, txsim, envErr := handler.getCalledChaincodeEnv(txContext, newChaincodeID, calledChain)
+			if envErr != nil {
+				payload := []byte(envErr.Error())
+				chaincodeLogger.Debugf("[%s]Failed to invoke chaincode on chain %s. Sending %s", shorttxid(msg.Txid), calledChain, pb.ChaincodeMessage_ERROR)
+				triggerNextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: payload, Txid: msg.Txid}
+				return
+			}
+			if txsim != txContext.txsimulator {
+				defer txsim.Done()
+			}
+			if err = handler.checkCalledChaincodeACL(msg.Txid, txContext, calledChain, chaincodeSpec, txsim); err != nil {
+				chaincodeLogger.Debugf("[%s]Denied invocation of %s. Sending %s", shorttxid(msg.Txid), newChaincodeID, pb.ChaincodeMessage_ERROR)
+				triggerNextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid}
+				return
+			}
+
 			ctxt := context.Background()
-			ctxt = context.WithValue(ctxt, TXSimulatorKey, txContext.txsimulator)
+			ctxt = context.WithValue(ctxt, TXSimulatorKey, txsim)
+			ctxt = context.WithValue(ctxt, SignedProposalKey, txContext.signedProp)
 
 			// Create the invocation spec
 			chaincodeInvocationSpec := &pb.ChaincodeInvocationSpec{ChaincodeSpec: chaincodeSpec}
 
 			// Launch the new chaincode if not already running
-			_, chaincodeInput, launchErr := handler.chaincodeSupport.Launch(ctxt, msg.Txid, txContext.proposal, chaincodeInvocationSpec)
+			_, chaincodeInput, launchErr := chaincodeSupport.Launch(ctxt, msg.Txid, txContext.proposal, chaincodeInvocationSpec)
 			if launchErr != nil {
 				payload := []byte(launchErr.Error())
 				chaincodeLogger.Debugf("[%s]Failed to launch invoked chaincode. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
@@ -964,7 +1032,7 @@ func (handler *Handler) enterBusyState(e *fsm.Event, state string) {
 			ccMsg, _ := createTransactionMessage(msg.Txid, chaincodeInput)
 
 			// Execute the chaincode
-			response, execErr := handler.chaincodeSupport.Execute(ctxt, newChaincodeID, ccMsg, timeout, txContext.proposal)
+			response, execErr := chaincodeSupport.Execute(ctxt, newChaincodeID, ccMsg, timeout, txContext.proposal)
 
 			//payload is marshalled and send to the calling chaincode's shim which unmarshals and
 			//sends it to chaincode
@@ -1038,10 +1106,16 @@ func (handler *Handler) enterEndState(e *fsm.Event, state string) {
 	e.Cancel(fmt.Errorf("Entered end state"))
 }
 
-func (handler *Handler) setChaincodeProposal(prop *pb.Proposal, msg *pb.ChaincodeMessage) error {
+// setChaincodeProposal passes the parts of the proposal the chaincode can access
+// (i.e., the transient map and the signed proposal) with the message
+func (handler *Handler) setChaincodeProposal(txctx *transactionContext, msg *pb.ChaincodeMessage) error {
 	chaincodeLogger.Debug("setting chaincode proposal...")
-	if prop != nil {
-		chaincodeLogger.Debug("TODO pass Proposal to chaincode...")
+	if txctx.proposal != nil {
+		cpp, err := putils.GetChaincodeProposalPayload(txctx.proposal.Payload)
+		if err != nil {
+			return fmt.Errorf("Failed to extract the chaincode proposal payload: %s", err)
+		}
+		msg.ProposalContext = &pb.ChaincodeProposalContext{Transient: cpp.TransientMap, SignedProposal: txctx.signedProp}
 	}
 	return nil
 }
@@ -1076,7 +1150,7 @@ func (handler *Handler) initOrReady(ctxt context.Context, txid string, prop *pb.
 	}
 
 	//if security is disabled the context elements will just be nil
-	if err := handler.setChaincodeProposal(prop, ccMsg); err != nil {
+	if err := handler.setChaincodeProposal(txctx, ccMsg); err != nil {
 		return nil, err
 	}
 
@@ -1085,6 +1159,77 @@ func (handler *Handler) initOrReady(ctxt context.Context, txid string, prop *pb.
 	return notfy, nil
 }
 
+// checkCalledChaincode rejects chaincode-to-chaincode calls to the lccc functions that change
+// chaincode state. The admins of a chaincode are only checked by the endorser for the top-level
+// proposal, so a deploy or setacl issued by another chaincode would skip that check
+func checkCalledChaincode(calledName string, spec *pb.ChaincodeSpec) error {
+	if calledName != "lccc" || spec.CtorMsg == nil || len(spec.CtorMsg.Args) == 0 {
+		return nil
+	}
+	function := string(spec.CtorMsg.Args[0])
+	if function == DEPLOY || function == SETACL {
+		return fmt.Errorf("lccc %s cannot be invoked from a chaincode", function)
+	}
+	return nil
+}
+
+// getCalledChaincodeEnv returns the ChaincodeSupport and the simulator with which a chaincode
+// invoked on calledChain is executed. A chaincode on another chain is executed read-only, with a
+// simulator of its own that must be released by the caller once the invocation is completed
+func (handler *Handler) getCalledChaincodeEnv(txContext *transactionContext, calledName string, calledChain string) (*ChaincodeSupport, ledger.TxSimulator, error) {
+	if calledChain == "" || ChainName(calledChain) == handler.chaincodeSupport.name {
+		return getChaincodeSupport(handler.chaincodeSupport.name, calledName), txContext.txsimulator, nil
+	}
+
+	chaincodeSupport := getChaincodeSupport(ChainName(calledChain), calledName)
+	if chaincodeSupport == nil {
+		return nil, nil, fmt.Errorf("Chain %s not found", calledChain)
+	}
+	qe, err := kvledger.GetLedger(calledChain).NewQueryExecutor()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to query chain %s: %s", calledChain, err)
+	}
+	return chaincodeSupport, newReadOnlyTxSimulator(qe, calledChain), nil
+}
+
+// checkCalledChaincodeACL checks the creator of the proposal against the ACL of a chaincode invoked
+// on calledChain by another chaincode, as the endorser does for the chaincode the proposal invokes.
+// The ACL of the callee thus also restricts who reads the state of another chain through it
+func (handler *Handler) checkCalledChaincodeACL(txid string, txContext *transactionContext, calledChain string, spec *pb.ChaincodeSpec, txsim ledger.TxSimulator) error {
+	calledName := spec.ChaincodeID.Name
+	if IsSysCC(calledName) {
+		return nil
+	}
+	if calledChain == "" {
+		calledChain = string(handler.chaincodeSupport.name)
+	}
+
+	ctxt := context.WithValue(context.Background(), TXSimulatorKey, txsim)
+	acl, err := GetACLFromLCCC(ctxt, txid, txContext.proposal, calledChain, calledName)
+	if err != nil {
+		return err
+	}
+	if acl == nil {
+		return nil
+	}
+
+	if txContext.signedProp == nil {
+		return ACLDeniedErr(fmt.Sprintf("%s cannot be invoked without a signed proposal", calledName))
+	}
+	hdr, err := putils.GetHeader(txContext.proposal.Header)
+	if err != nil {
+		return err
+	}
+	if hdr.SignatureHeader == nil {
+		return fmt.Errorf("The proposal has no signature header")
+	}
+	var function string
+	if spec.CtorMsg != nil && len(spec.CtorMsg.Args) > 0 {
+		function = string(spec.CtorMsg.Args[0])
+	}
+	return CheckACL(acl, function, txContext.signedProp, hdr.SignatureHeader.Creator)
+}
+
 // Handles request to query another chaincode
 func (handler *Handler) handleQueryChaincode(msg *pb.ChaincodeMessage) {
 	go func() {
@@ -1117,18 +1262,41 @@ func (handler *Handler) handleQueryChaincode(msg *pb.ChaincodeMessage) {
 			return
 		}
 
-		// Get the chaincodeID to invoke
-		newChaincodeID := chaincodeSpec.ChaincodeID.Name
+		// Get the chaincodeID to invoke, which is of the form name/chain for a chaincode on another chain
+		newChaincodeID, calledChain := splitChaincodeName(chaincodeSpec.ChaincodeID.Name)
+		chaincodeSpec.ChaincodeID.Name = newChaincodeID
+		if err := checkCalledChaincode(newChaincodeID, chaincodeSpec); err != nil {
+			chaincodeLogger.Debugf("[%s]Rejected query of %s. Sending %s", shorttxid(msg.Txid), newChaincodeID, pb.ChaincodeMessage_ERROR)
+			serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid}
+			return
+		}
 
 		// Create the invocation spec
 		chaincodeInvocationSpec := &pb.ChaincodeInvocationSpec{ChaincodeSpec: chaincodeSpec}
 
 		txContext := handler.getTxContext(msg.Txid)
+		chaincodeSupport, txsim, envErr := handler.getCalledChaincodeEnv(txContext, newChaincodeID, calledChain)
+		if envErr != nil {
+			payload := []byte(envErr.Error())
+			chaincodeLogger.Debugf("[%s]Failed to query chaincode on chain %s. Sending %s", shorttxid(msg.Txid), calledChain, pb.ChaincodeMessage_ERROR)
+			serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: payload, Txid: msg.Txid}
+			return
+		}
+		if txsim != txContext.txsimulator {
+			defer txsim.Done()
+		}
+		if err := handler.checkCalledChaincodeACL(msg.Txid, txContext, calledChain, chaincodeSpec, txsim); err != nil {
+			chaincodeLogger.Debugf("[%s]Denied query of %s. Sending %s", shorttxid(msg.Txid), newChaincodeID, pb.ChaincodeMessage_ERROR)
+			serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid}
+			return
+		}
+
 		ctxt := context.Background()
-		ctxt = context.WithValue(ctxt, TXSimulatorKey, txContext.txsimulator)
+		ctxt = context.WithValue(ctxt, TXSimulatorKey, txsim)
+		ctxt = context.WithValue(ctxt, SignedProposalKey, txContext.signedProp)
 
 		// Launch the new chaincode if not already running
-		_, chaincodeInput, launchErr := handler.chaincodeSupport.Launch(ctxt, msg.Txid, txContext.proposal, chaincodeInvocationSpec)
+		_, chaincodeInput, launchErr := chaincodeSupport.Launch(ctxt, msg.Txid, txContext.proposal, chaincodeInvocationSpec)
 		if launchErr != nil {
 			payload := []byte(launchErr.Error())
 			chaincodeLogger.Debugf("[%s]Failed to launch invoked chaincode. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
@@ -1144,7 +1312,7 @@ func (handler *Handler) handleQueryChaincode(msg *pb.ChaincodeMessage) {
 
 		// Query the chaincode
 		//NOTE: when confidential C-call-C is understood, transaction should have the correct sec context for enc/dec
-		response, execErr := handler.chaincodeSupport.Execute(ctxt, newChaincodeID, ccMsg, timeout, txContext.proposal)
+		response, execErr := chaincodeSupport.Execute(ctxt, newChaincodeID, ccMsg, timeout, txContext.proposal)
 
 		if execErr != nil {
 			// Send error msg back to chaincode and trigger event
@@ -1230,7 +1398,7 @@ func (handler *Handler) sendExecuteMessage(ctxt context.Context, msg *pb.Chainco
 	handler.markIsTransaction(msg.Txid, true)
 
 	//if security is disabled the context elements will just be nil
-	if err := handler.setChaincodeProposal(prop, msg); err != nil {
+	if err := handler.setChaincodeProposal(txctx, msg); err != nil {
 		return nil, err
 	}
 
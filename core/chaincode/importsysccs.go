@@ -62,3 +62,13 @@ func deRegisterSysCCs() {
 		deregisterSysCC(sysCC)
 	}
 }
+
+//IsSysCC returns true if name is the name of a system chaincode
+func IsSysCC(name string) bool {
+	for _, sysCC := range systemChaincodes {
+		if sysCC.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -18,6 +18,7 @@ package chaincode
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
@@ -25,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/util"
+	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/op/go-logging"
 	"golang.org/x/net/context"
@@ -32,10 +34,11 @@ import (
 
 //The life cycle system chaincode manages chaincodes deployed
 //on this peer. It manages chaincodes via Invoke proposals.
-//     "Args":["deploy",<ChaincodeDeploymentSpec>]
+//     "Args":["deploy",<ChaincodeDeploymentSpec>,<ChaincodeACL>]
 //     "Args":["upgrade",<ChaincodeDeploymentSpec>]
 //     "Args":["stop",<ChaincodeInvocationSpec>]
 //     "Args":["start",<ChaincodeInvocationSpec>]
+//     "Args":["setacl",<chain>,<chaincode>,<ChaincodeACL>]
 
 var logger = logging.MustGetLogger("lccc")
 
@@ -43,11 +46,17 @@ const (
 	//CHAINCODETABLE prefix for chaincode tables
 	CHAINCODETABLE = "chaincodes"
 
+	//ENDORSEMENTPOLICYKEY prefix for the keys of endorsement policies
+	ENDORSEMENTPOLICYKEY = "endorsementpolicy"
+
 	//chaincode lifecyle commands
 
 	//DEPLOY deploy command
 	DEPLOY = "deploy"
 
+	//SETACL update the ACL of a chaincode
+	SETACL = "setacl"
+
 	//chaincode query commands
 
 	//GETCCINFO get chaincode
@@ -56,6 +65,9 @@ const (
 	//GETDEPSPEC get ChaincodeDeploymentSpec
 	GETDEPSPEC = "getdepspec"
 
+	//GETACL get ChaincodeACL
+	GETACL = "getacl"
+
 	//characters used in chaincodenamespace
 	specialChars = "/:[]${}"
 )
@@ -147,14 +159,17 @@ func (lccc *LifeCycleSysCC) createChaincodeTable(stub shim.ChaincodeStubInterfac
 	nameColDef := shim.ColumnDefinition{Name: "name",
 		Type: shim.ColumnDefinition_STRING, Key: true}
 	versColDef := shim.ColumnDefinition{Name: "version",
-		Type: shim.ColumnDefinition_INT32, Key: false}
+		Type: shim.ColumnDefinition_STRING, Key: false}
 
 	//QUESTION - Should code be separately maintained ?
 	codeDef := shim.ColumnDefinition{Name: "code",
 		Type: shim.ColumnDefinition_BYTES, Key: false}
+	aclDef := shim.ColumnDefinition{Name: "acl",
+		Type: shim.ColumnDefinition_BYTES, Key: false}
 	colDefs = append(colDefs, &nameColDef)
 	colDefs = append(colDefs, &versColDef)
 	colDefs = append(colDefs, &codeDef)
+	colDefs = append(colDefs, &aclDef)
 	return stub.CreateTable(cctable, colDefs)
 }
 
@@ -165,7 +180,10 @@ func (lccc *LifeCycleSysCC) register(stub shim.ChaincodeStubInterface, name stri
 	ccname := CHAINCODETABLE + "-" + name
 
 	row, err := stub.GetTable(ccname)
-	if err == nil && row != nil { //table exists, do nothing
+	if err == nil && row != nil { //table exists, only bring it to the current format
+		if err = lccc.migrateChaincodeTable(stub, ccname, row); err != nil {
+			return err
+		}
 		return AlreadyRegisteredErr(name)
 	}
 
@@ -176,17 +194,77 @@ func (lccc *LifeCycleSysCC) register(stub shim.ChaincodeStubInterface, name stri
 	return err
 }
 
+//isLegacyChaincodeTable checks whether a chaincode table was created by a
+//previous version of LCCC, with an INT32 version column and no acl column
+func isLegacyChaincodeTable(table *shim.Table) bool {
+	colDefs := table.ColumnDefinitions
+	return len(colDefs) < 4 || colDefs[1].Type != shim.ColumnDefinition_STRING
+}
+
+//upgradeChaincodeRow converts a row of a chaincode table created by a previous
+//version of LCCC to the current format: the INT32 version becomes a string
+//and the chaincode gets an empty ACL. Rows in the current format are returned
+//as is
+func upgradeChaincodeRow(row shim.Row) shim.Row {
+	if len(row.Columns) < 3 {
+		return row
+	}
+	columns := []*shim.Column{row.Columns[0], row.Columns[1], row.Columns[2]}
+	if version, ok := row.Columns[1].Value.(*shim.Column_Int32); ok {
+		columns[1] = &shim.Column{Value: &shim.Column_String_{String_: strconv.Itoa(int(version.Int32))}}
+	}
+	if len(row.Columns) > 3 {
+		columns = append(columns, row.Columns[3])
+	} else {
+		columns = append(columns, &shim.Column{Value: &shim.Column_Bytes{Bytes: nil}})
+	}
+	return shim.Row{Columns: columns}
+}
+
+//migrateChaincodeTable rewrites a chaincode table created by a previous version
+//of LCCC, and its rows, in the current format before it is written to
+func (lccc *LifeCycleSysCC) migrateChaincodeTable(stub shim.ChaincodeStubInterface, cctable string, table *shim.Table) error {
+	if !isLegacyChaincodeTable(table) {
+		return nil
+	}
+	logger.Infof("Migrating chaincode table %s", cctable)
+
+	rowChan, err := stub.GetRows(cctable, nil)
+	if err != nil {
+		return fmt.Errorf("migration of %s failed. %s", cctable, err)
+	}
+	var rows []shim.Row
+	for row := range rowChan {
+		rows = append(rows, upgradeChaincodeRow(row))
+	}
+
+	if err = stub.DeleteTable(cctable); err != nil {
+		return fmt.Errorf("migration of %s failed. %s", cctable, err)
+	}
+	if err = lccc.createChaincodeTable(stub, cctable); err != nil {
+		return fmt.Errorf("migration of %s failed. %s", cctable, err)
+	}
+	for _, row := range rows {
+		if _, err = stub.InsertRow(cctable, row); err != nil {
+			return fmt.Errorf("migration of %s failed. %s", cctable, err)
+		}
+	}
+	return nil
+}
+
 //create the chaincode on the given chain
-func (lccc *LifeCycleSysCC) createChaincode(stub shim.ChaincodeStubInterface, chainname string, ccname string, cccode []byte) (*shim.Row, error) {
+func (lccc *LifeCycleSysCC) createChaincode(stub shim.ChaincodeStubInterface, chainname string, ccname string, version string, cccode []byte, acl []byte) (*shim.Row, error) {
 	var columns []*shim.Column
 
 	nameCol := shim.Column{Value: &shim.Column_String_{String_: ccname}}
-	versCol := shim.Column{Value: &shim.Column_Int32{Int32: 0}}
+	versCol := shim.Column{Value: &shim.Column_String_{String_: version}}
 	codeCol := shim.Column{Value: &shim.Column_Bytes{Bytes: cccode}}
+	aclCol := shim.Column{Value: &shim.Column_Bytes{Bytes: acl}}
 
 	columns = append(columns, &nameCol)
 	columns = append(columns, &versCol)
 	columns = append(columns, &codeCol)
+	columns = append(columns, &aclCol)
 
 	row := &shim.Row{Columns: columns}
 	_, err := stub.InsertRow(CHAINCODETABLE+"-"+chainname, *row)
@@ -208,11 +286,51 @@ func (lccc *LifeCycleSysCC) getChaincode(stub shim.ChaincodeStubInterface, chain
 	}
 
 	if len(row.Columns) > 0 {
-		return row, true, nil
+		//the table may not be migrated yet if it was never written to
+		return upgradeChaincodeRow(row), true, nil
 	}
 	return row, false, nil
 }
 
+//getACL returns the ACL of a chaincode, nil if it was deployed without one
+func (lccc *LifeCycleSysCC) getACL(ccrow shim.Row) []byte {
+	if len(ccrow.Columns) < 4 {
+		return nil
+	}
+	return ccrow.Columns[3].GetBytes()
+}
+
+//replace the ACL of the chaincode on the given chain. Only the admins of the
+//chaincode, as defined by its current ACL, can replace it: they are checked by
+//the endorser which has the signed proposal. The handler rejects a setacl
+//invoked by another chaincode, which would not go through that check
+func (lccc *LifeCycleSysCC) executeSetACL(stub shim.ChaincodeStubInterface, chainname string, ccname string, acl []byte) error {
+	if _, err := UnmarshalACL(acl); err != nil {
+		return err
+	}
+
+	cctable := CHAINCODETABLE + "-" + chainname
+	if table, err := stub.GetTable(cctable); err == nil {
+		if err = lccc.migrateChaincodeTable(stub, cctable, table); err != nil {
+			return err
+		}
+	}
+
+	ccrow, exists, err := lccc.getChaincode(stub, chainname, ccname)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return TXNotFoundErr(chainname + "/" + ccname)
+	}
+
+	columns := []*shim.Column{ccrow.Columns[0], ccrow.Columns[1], ccrow.Columns[2], {Value: &shim.Column_Bytes{Bytes: acl}}}
+	if _, err = stub.ReplaceRow(CHAINCODETABLE+"-"+chainname, shim.Row{Columns: columns}); err != nil {
+		return fmt.Errorf("update of chaincode ACL failed. %s", err)
+	}
+	return nil
+}
+
 //getChaincodeDeploymentSpec returns a ChaincodeDeploymentSpec given args
 func (lccc *LifeCycleSysCC) getChaincodeDeploymentSpec(code []byte) (*pb.ChaincodeDeploymentSpec, error) {
 	cds := &pb.ChaincodeDeploymentSpec{}
@@ -282,8 +400,10 @@ func (lccc *LifeCycleSysCC) deploy(stub shim.ChaincodeStubInterface, chainname s
 
 	ctxt = context.WithValue(ctxt, TXSimulatorKey, dummytxsim)
 
-	//TODO - create chaincode support for chainname, for now use DefaultChain
 	chaincodeSupport := GetChain(ChainName(chainname))
+	if chaincodeSupport == nil {
+		return fmt.Errorf("Chain %s not found", chainname)
+	}
 
 	_, err = chaincodeSupport.Deploy(ctxt, cds)
 	if err != nil {
@@ -306,7 +426,7 @@ func (lccc *LifeCycleSysCC) deploy(stub shim.ChaincodeStubInterface, chainname s
 }
 
 //this implements "deploy" Invoke transaction
-func (lccc *LifeCycleSysCC) executeDeploy(stub shim.ChaincodeStubInterface, chainname string, code []byte) error {
+func (lccc *LifeCycleSysCC) executeDeploy(stub shim.ChaincodeStubInterface, chainname string, code []byte, acl []byte) error {
 	//lazy creation of chaincode table for chainname...its possible
 	//there are chains without chaincodes
 	if err := lccc.register(stub, chainname); err != nil {
@@ -326,7 +446,11 @@ func (lccc *LifeCycleSysCC) executeDeploy(stub shim.ChaincodeStubInterface, chai
 		return InvalidChaincodeNameErr(cds.ChaincodeSpec.ChaincodeID.Name)
 	}
 
-	if err = lccc.acl(stub, DefaultChain, cds); err != nil {
+	if err = lccc.acl(stub, ChainName(chainname), cds); err != nil {
+		return err
+	}
+
+	if _, err = UnmarshalACL(acl); err != nil {
 		return err
 	}
 
@@ -343,9 +467,60 @@ func (lccc *LifeCycleSysCC) executeDeploy(stub shim.ChaincodeStubInterface, chai
 		 *}
 		 **/
 
-	_, err = lccc.createChaincode(stub, chainname, cds.ChaincodeSpec.ChaincodeID.Name, code)
+	_, err = lccc.createChaincode(stub, chainname, cds.ChaincodeSpec.ChaincodeID.Name, cds.ChaincodeSpec.ChaincodeID.Version, code, acl)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return lccc.putEndorsementPolicy(stub, chainname, cds)
+}
+
+//the key of the endorsement policy of a chaincode. Chaincode names cannot
+//contain ':', so the key identifies both names
+func endorsementPolicyKey(chainname string, ccname string) string {
+	return ENDORSEMENTPOLICYKEY + ":" + ccname + ":" + chainname
+}
+
+// EndorsementPolicyKeyNames returns the chain and the chaincode of the key of an
+// endorsement policy in the state of LCCC, and false if key is not such a key
+func EndorsementPolicyKeyNames(key string) (string, string, bool) {
+	parts := strings.SplitN(key, ":", 3)
+	if len(parts) != 3 || parts[0] != ENDORSEMENTPOLICYKEY {
+		return "", "", false
+	}
+	return parts[2], parts[1], true
+}
+
+//record the endorsement policy of the deployment spec under its own key, so
+//that the committer can read it from the state without going through the
+//chaincode table
+func (lccc *LifeCycleSysCC) putEndorsementPolicy(stub shim.ChaincodeStubInterface, chainname string, cds *pb.ChaincodeDeploymentSpec) error {
+	if cds.EndorsementPolicy == nil {
+		return nil
+	}
+
+	policy, err := proto.Marshal(cds.EndorsementPolicy)
+	if err != nil {
+		return InvalidDeploymentSpecErr(err.Error())
+	}
+
+	return stub.PutState(endorsementPolicyKey(chainname, cds.ChaincodeSpec.ChaincodeID.Name), policy)
+}
+
+// GetEndorsementPolicy returns the endorsement policy LCCC recorded for the
+// chaincode on the chain in the state read by qe, nil if the chaincode was
+// deployed without one
+func GetEndorsementPolicy(qe ledger.QueryExecutor, chainname string, ccname string) (*cb.SignaturePolicyEnvelope, error) {
+	policyBytes, err := qe.GetState("lccc", endorsementPolicyKey(chainname, ccname))
+	if err != nil || policyBytes == nil {
+		return nil, err
+	}
+
+	policy := &cb.SignaturePolicyEnvelope{}
+	if err = proto.Unmarshal(policyBytes, policy); err != nil {
+		return nil, fmt.Errorf("Invalid endorsement policy of %s/%s: %s", chainname, ccname, err)
+	}
+	return policy, nil
 }
 
 //-------------- the chaincode stub interface implementation ----------
@@ -355,11 +530,13 @@ func (lccc *LifeCycleSysCC) Init(stub shim.ChaincodeStubInterface) ([]byte, erro
 	return nil, nil
 }
 
-// Invoke implements lifecycle functions "deploy", "start", "stop", "upgrade".
-// Deploy's arguments -  {[]byte("deploy"), []byte(<chainname>), <unmarshalled pb.ChaincodeDeploymentSpec>}
+// Invoke implements lifecycle functions "deploy", "start", "stop", "upgrade", "setacl".
+// Deploy's arguments -  {[]byte("deploy"), []byte(<chainname>), <unmarshalled pb.ChaincodeDeploymentSpec>, [<unmarshalled pb.ChaincodeACL>]}
+// Set ACL's arguments -  {[]byte("setacl"), []byte(<chainname>), []byte(<chaincodename>), <unmarshalled pb.ChaincodeACL>}
 //
 // Invoke also implements some query-like functions
 // Get chaincode arguments -  {[]byte("getid"), []byte(<chainname>), []byte(<chaincodename>)}
+// Get ACL arguments -  {[]byte("getacl"), []byte(<chainname>), []byte(<chaincodename>)}
 func (lccc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) ([]byte, error) {
 	args := stub.GetArgs()
 	if len(args) < 1 {
@@ -370,7 +547,7 @@ func (lccc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) ([]byte, er
 
 	switch function {
 	case DEPLOY:
-		if len(args) != 3 {
+		if len(args) != 3 && len(args) != 4 {
 			return nil, InvalidArgsLenErr(len(args))
 		}
 
@@ -385,10 +562,24 @@ func (lccc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) ([]byte, er
 		//bytes corresponding to deployment spec
 		code := args[2]
 
-		err := lccc.executeDeploy(stub, chainname, code)
+		//bytes corresponding to the optional ACL
+		var acl []byte
+		if len(args) == 4 {
+			acl = args[3]
+		}
+
+		err := lccc.executeDeploy(stub, chainname, code, acl)
+
+		return nil, err
+	case SETACL:
+		if len(args) != 4 {
+			return nil, InvalidArgsLenErr(len(args))
+		}
+
+		err := lccc.executeSetACL(stub, string(args[1]), string(args[2]), args[3])
 
 		return nil, err
-	case GETCCINFO, GETDEPSPEC:
+	case GETCCINFO, GETDEPSPEC, GETACL:
 		if len(args) != 3 {
 			return nil, InvalidArgsLenErr(len(args))
 		}
@@ -403,8 +594,11 @@ func (lccc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) ([]byte, er
 			return nil, TXNotFoundErr(chain + "/" + ccname)
 		}
 
-		if function == GETCCINFO {
+		switch function {
+		case GETCCINFO:
 			return []byte(ccrow.Columns[1].GetString_()), nil
+		case GETACL:
+			return lccc.getACL(ccrow), nil
 		}
 		return ccrow.Columns[2].GetBytes(), nil
 	}
@@ -16,12 +16,14 @@ limitations under the License.
 package chaincode
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"google.golang.org/grpc"
 )
@@ -80,6 +82,199 @@ func TestDeploy(t *testing.T) {
 	}
 }
 
+//TestDeployWithEndorsementPolicy tests that deploy records the endorsement policy of the chaincode
+func TestDeployWithEndorsementPolicy(t *testing.T) {
+	initialize()
+
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")})
+	if err != nil {
+		t.FailNow()
+	}
+	cds.EndorsementPolicy = cauthdsl.RejectAllPolicy
+	b, err := proto.Marshal(cds)
+	if err != nil {
+		t.FailNow()
+	}
+
+	args := [][]byte{[]byte(DEPLOY), []byte("test"), b}
+	if _, err := stub.MockInvoke("1", args); err != nil {
+		t.Fatalf("Deploy failed: %s", err)
+	}
+	if policy := stub.State[endorsementPolicyKey("test", "example02")]; !bytes.Equal(policy, cauthdsl.MarshaledRejectAllPolicy) {
+		t.Fatalf("Expected the endorsement policy to be recorded, got %x", policy)
+	}
+	if _, exists := stub.State[endorsementPolicyKey("test2", "example02")]; exists {
+		t.Fatalf("Expected no endorsement policy on another chain")
+	}
+}
+
+//TestDeployWithACL tests the deploy function with the ACL of the chaincode
+func TestDeployWithACL(t *testing.T) {
+	initialize()
+
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")})
+	var b []byte
+	if b, err = proto.Marshal(cds); err != nil || b == nil {
+		t.FailNow()
+	}
+
+	// a bad ACL fails the deploy
+	args := [][]byte{[]byte(DEPLOY), []byte("test"), b, []byte("bad acl")}
+	if _, err := stub.MockInvoke("1", args); err == nil {
+		t.FailNow()
+	}
+
+	acl, _ := proto.Marshal(&pb.ChaincodeACL{Default: cauthdsl.RejectAllPolicy})
+	args = [][]byte{[]byte(DEPLOY), []byte("test"), b, acl}
+	if _, err := stub.MockInvoke("1", args); err != nil {
+		t.FailNow()
+	}
+
+	args = [][]byte{[]byte(GETACL), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name)}
+	if storedACL, err := stub.MockInvoke("1", args); err != nil || !bytes.Equal(storedACL, acl) {
+		t.Fatalf("Expected the ACL to be stored, got %v (%v)", storedACL, err)
+	}
+}
+
+//TestSetACL tests the update of the ACL of a chaincode
+func TestSetACL(t *testing.T) {
+	initialize()
+
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")})
+	var b []byte
+	if b, err = proto.Marshal(cds); err != nil || b == nil {
+		t.FailNow()
+	}
+
+	acl, _ := proto.Marshal(&pb.ChaincodeACL{Default: cauthdsl.AcceptAllPolicy})
+	args := [][]byte{[]byte(SETACL), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name), acl}
+	if _, err := stub.MockInvoke("1", args); err == nil {
+		t.Fatal("Expected the ACL of a chaincode not deployed not to be set")
+	}
+
+	args = [][]byte{[]byte(DEPLOY), []byte("test"), b}
+	if _, err := stub.MockInvoke("1", args); err != nil {
+		t.FailNow()
+	}
+
+	args = [][]byte{[]byte(GETACL), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name)}
+	if storedACL, err := stub.MockInvoke("1", args); err != nil || len(storedACL) != 0 {
+		t.Fatalf("Expected the chaincode to have no ACL, got %v (%v)", storedACL, err)
+	}
+
+	args = [][]byte{[]byte(SETACL), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name), acl}
+	if _, err := stub.MockInvoke("1", args); err != nil {
+		t.Fatalf("Expected the ACL to be set, got %s", err)
+	}
+
+	args = [][]byte{[]byte(GETACL), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name)}
+	if storedACL, err := stub.MockInvoke("1", args); err != nil || !bytes.Equal(storedACL, acl) {
+		t.Fatalf("Expected the ACL to be updated, got %v (%v)", storedACL, err)
+	}
+}
+
+//TestMigrateChaincodeTable tests that the chaincode tables created by a previous version of lccc,
+//with an INT32 version column and no acl column, are still read and are migrated when written to
+func TestMigrateChaincodeTable(t *testing.T) {
+	initialize()
+
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")})
+	var b []byte
+	if b, err = proto.Marshal(cds); err != nil || b == nil {
+		t.FailNow()
+	}
+
+	stub.MockTransactionStart("0")
+	legacyColDefs := []*shim.ColumnDefinition{
+		{Name: "name", Type: shim.ColumnDefinition_STRING, Key: true},
+		{Name: "version", Type: shim.ColumnDefinition_INT32, Key: false},
+		{Name: "code", Type: shim.ColumnDefinition_BYTES, Key: false},
+	}
+	if err = stub.CreateTable(CHAINCODETABLE+"-test", legacyColDefs); err != nil {
+		t.Fatalf("Could not create the legacy table: %s", err)
+	}
+	legacyRow := shim.Row{Columns: []*shim.Column{
+		{Value: &shim.Column_String_{String_: cds.ChaincodeSpec.ChaincodeID.Name}},
+		{Value: &shim.Column_Int32{Int32: 0}},
+		{Value: &shim.Column_Bytes{Bytes: b}},
+	}}
+	if _, err = stub.InsertRow(CHAINCODETABLE+"-test", legacyRow); err != nil {
+		t.Fatalf("Could not insert the legacy row: %s", err)
+	}
+	stub.MockTransactionEnd("0")
+
+	args := [][]byte{[]byte(GETCCINFO), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name)}
+	if version, err := stub.MockInvoke("1", args); err != nil || string(version) != "0" {
+		t.Fatalf("Expected the version of the legacy chaincode to be 0, got %s (%v)", version, err)
+	}
+
+	acl, _ := proto.Marshal(&pb.ChaincodeACL{Default: cauthdsl.AcceptAllPolicy})
+	args = [][]byte{[]byte(SETACL), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name), acl}
+	if _, err := stub.MockInvoke("1", args); err != nil {
+		t.Fatalf("Expected the ACL of the legacy chaincode to be set, got %s", err)
+	}
+
+	table, err := stub.GetTable(CHAINCODETABLE + "-test")
+	if err != nil || isLegacyChaincodeTable(table) {
+		t.Fatalf("Expected the table to be migrated, got %v (%v)", table, err)
+	}
+
+	args = [][]byte{[]byte(GETACL), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name)}
+	if storedACL, err := stub.MockInvoke("1", args); err != nil || !bytes.Equal(storedACL, acl) {
+		t.Fatalf("Expected the ACL to be updated, got %v (%v)", storedACL, err)
+	}
+	args = [][]byte{[]byte(GETDEPSPEC), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name)}
+	if code, err := stub.MockInvoke("1", args); err != nil || !bytes.Equal(code, b) {
+		t.Fatalf("Expected the deployment spec to be kept, got %v", err)
+	}
+
+	cds2, err := constructDeploymentSpec("example02-2", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")})
+	if b, err = proto.Marshal(cds2); err != nil || b == nil {
+		t.FailNow()
+	}
+	args = [][]byte{[]byte(DEPLOY), []byte("test"), b}
+	if _, err := stub.MockInvoke("1", args); err != nil {
+		t.Fatalf("Expected a chaincode to be deployed on the migrated table, got %s", err)
+	}
+}
+
+//TestCheckCalledChaincode tests that lccc deploy and setacl cannot be invoked from a chaincode
+func TestCheckCalledChaincode(t *testing.T) {
+	spec := func(name string, args ...string) *pb.ChaincodeSpec {
+		input := &pb.ChaincodeInput{}
+		for _, arg := range args {
+			input.Args = append(input.Args, []byte(arg))
+		}
+		return &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: name}, CtorMsg: input}
+	}
+
+	for _, function := range []string{DEPLOY, SETACL} {
+		if err := checkCalledChaincode("lccc", spec("lccc", function, "test", "example02")); err == nil {
+			t.Fatalf("Expected lccc %s to be rejected in a chaincode-to-chaincode call", function)
+		}
+	}
+	for _, function := range []string{GETACL, GETCCINFO, GETDEPSPEC} {
+		if err := checkCalledChaincode("lccc", spec("lccc", function, "test", "example02")); err != nil {
+			t.Fatalf("Expected lccc %s to be allowed, got %s", function, err)
+		}
+	}
+	if err := checkCalledChaincode("example02", spec("example02", SETACL)); err != nil {
+		t.Fatalf("Expected a user chaincode function to be allowed, got %s", err)
+	}
+}
+
 //TestInvalidCodeDeploy tests the deploy function with invalid code package
 func TestInvalidCodeDeploy(t *testing.T) {
 	initialize()
@@ -169,6 +364,35 @@ func TestCheckCC(t *testing.T) {
 	}
 }
 
+//TestGetVersion tests that the version of a deployed chaincode is returned by getid
+func TestGetVersion(t *testing.T) {
+	initialize()
+
+	scc := new(LifeCycleSysCC)
+	stub := shim.NewMockStub("lccc", scc)
+
+	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")})
+	cds.ChaincodeSpec.ChaincodeID.Version = "1.0"
+	var b []byte
+	if b, err = proto.Marshal(cds); err != nil || b == nil {
+		t.FailNow()
+	}
+
+	args := [][]byte{[]byte(DEPLOY), []byte("test"), b}
+	if _, err := stub.MockInvoke("1", args); err != nil {
+		t.FailNow()
+	}
+
+	args = [][]byte{[]byte(GETCCINFO), []byte("test"), []byte(cds.ChaincodeSpec.ChaincodeID.Name)}
+	version, err := stub.MockInvoke("1", args)
+	if err != nil {
+		t.FailNow()
+	}
+	if string(version) != "1.0" {
+		t.Fatalf("Expected version 1.0, got %s", version)
+	}
+}
+
 //TestMultipleDeploy tests deploying multiple chaincodes
 func TestMultipleDeploy(t *testing.T) {
 	initialize()
@@ -0,0 +1,341 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// lifecycleCheckInterval is the interval at which the running chaincodes are checked for
+// idleness and health
+var lifecycleCheckInterval = time.Second
+
+// a chaincode from which nothing was received for unhealthyKeepalives keepalive intervals
+// is considered dead and it is restarted
+const unhealthyKeepalives = 3
+
+// chaincodeRestart is a chaincode whose container died, waiting to be relaunched
+type chaincodeRestart struct {
+	cds      *pb.ChaincodeDeploymentSpec
+	restarts int
+}
+
+// inContainer returns true if the chaincode runs in a container launched by the peer
+func (chrte *chaincodeRTEnv) inContainer() bool {
+	return chrte.cds != nil && chrte.cds.ExecEnv != pb.ChaincodeDeploymentSpec_SYSTEM
+}
+
+// containerLaunchLock serializes the launches of chaincodes in containers on all the chains, so
+// that concurrent launches cannot all see fewer than chaincode.maxcontainers running containers
+var containerLaunchLock sync.Mutex
+
+// recordLaunch records the launch of a chaincode and returns the channel notified when it
+// registers, unless it is already running. The launch of a chaincode in a container is refused
+// when the maximum number of containers run on all the chains
+func (chaincodeSupport *ChaincodeSupport) recordLaunch(chaincode string, cds *pb.ChaincodeDeploymentSpec) (chan bool, bool, error) {
+	running := 0
+	limited := cds.ExecEnv != pb.ChaincodeDeploymentSpec_SYSTEM && chaincodeSupport.maxContainers > 0
+	if limited {
+		//the containers are counted before taking the lock of the chain, which counting takes
+		containerLaunchLock.Lock()
+		defer containerLaunchLock.Unlock()
+		running = runningContainers()
+	}
+
+	chaincodeSupport.runningChaincodes.Lock()
+	defer chaincodeSupport.runningChaincodes.Unlock()
+	//if its in the map, there must be a connected stream...nothing to do
+	if _, ok := chaincodeSupport.chaincodeHasBeenLaunched(chaincode); ok {
+		chaincodeLogger.Debugf("chaincode is running and ready: %s", chaincode)
+		return nil, true, nil
+	}
+	if limited && running >= chaincodeSupport.maxContainers {
+		return nil, false, fmt.Errorf("cannot launch %s, the maximum number of running chaincodes (%d) is reached", chaincode, chaincodeSupport.maxContainers)
+	}
+	return chaincodeSupport.preLaunchSetup(chaincode, cds), false, nil
+}
+
+// runningContainers returns the number of chaincode containers running on all the chains
+func runningContainers() int {
+	n := 0
+	for _, chaincodeSupport := range chains {
+		chaincodeSupport.runningChaincodes.RLock()
+		for _, chrte := range chaincodeSupport.runningChaincodes.chaincodeMap {
+			if chrte.inContainer() {
+				n++
+			}
+		}
+		chaincodeSupport.runningChaincodes.RUnlock()
+	}
+	return n
+}
+
+// scheduleRestart relaunches the chaincode after a backoff. Call this under lock
+func (chaincodeSupport *ChaincodeSupport) scheduleRestart(chaincode string, chrte *chaincodeRTEnv) {
+	if _, ok := chaincodeSupport.runningChaincodes.restarting[chaincode]; ok {
+		return
+	}
+	chaincodeSupport.runningChaincodes.restarting[chaincode] = &chaincodeRestart{cds: chrte.cds, restarts: chrte.restarts + 1}
+	go chaincodeSupport.relaunch(chaincode)
+}
+
+// relaunch relaunches a chaincode whose container died, doubling the backoff after each failed
+// attempt. It gives up when the chaincode is stopped, or when it was launched by an invocation
+func (chaincodeSupport *ChaincodeSupport) relaunch(chaincode string) {
+	backoff := chaincodeSupport.restartBackoff
+	for {
+		time.Sleep(backoff)
+
+		chaincodeSupport.runningChaincodes.Lock()
+		restart, ok := chaincodeSupport.runningChaincodes.restarting[chaincode]
+		if _, launched := chaincodeSupport.chaincodeHasBeenLaunched(chaincode); !ok || launched {
+			delete(chaincodeSupport.runningChaincodes.restarting, chaincode)
+			chaincodeSupport.runningChaincodes.Unlock()
+			return
+		}
+		chaincodeSupport.runningChaincodes.Unlock()
+
+		err := chaincodeSupport.start(restart.cds.ChaincodeSpec.ChaincodeID)
+
+		chaincodeSupport.runningChaincodes.Lock()
+		if err == nil {
+			if chrte, ok := chaincodeSupport.chaincodeHasBeenLaunched(chaincode); ok {
+				chrte.restarts = restart.restarts
+			}
+			delete(chaincodeSupport.runningChaincodes.restarting, chaincode)
+			chaincodeSupport.runningChaincodes.Unlock()
+			chaincodeLogger.Infof("chaincode %s on chain %s restarted", chaincode, chaincodeSupport.name)
+			return
+		}
+		chaincodeSupport.runningChaincodes.Unlock()
+
+		chaincodeLogger.Errorf("failed to restart chaincode %s on chain %s, retrying in %s: %s", chaincode, chaincodeSupport.name, 2*backoff, err)
+		if backoff *= 2; backoff > chaincodeSupport.restartMaxBackoff {
+			backoff = chaincodeSupport.restartMaxBackoff
+		}
+	}
+}
+
+// start launches a deployed chaincode, without initializing it
+func (chaincodeSupport *ChaincodeSupport) start(cID *pb.ChaincodeID) error {
+	// the deployment spec of the chaincode is read from LCCC
+	txsim, err := kvledger.GetLedger(string(chaincodeSupport.name)).NewTxSimulator()
+	if err != nil {
+		return err
+	}
+	defer txsim.Done()
+
+	ctxt := context.WithValue(context.Background(), TXSimulatorKey, txsim)
+	spec := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: cID.Name}}}
+	_, _, err = chaincodeSupport.Launch(ctxt, util.GenerateUUID(), nil, spec)
+	return err
+}
+
+// monitor periodically looks for idle and unresponsive chaincodes, until the chaincode support
+// is shut down
+func (chaincodeSupport *ChaincodeSupport) monitor() {
+	ticker := time.NewTicker(lifecycleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			chaincodeSupport.checkChaincodes(time.Now())
+		case <-chaincodeSupport.stop:
+			return
+		}
+	}
+}
+
+// shutdown stops monitoring the chaincodes of the chain
+func (chaincodeSupport *ChaincodeSupport) shutdown() {
+	chaincodeSupport.stopOnce.Do(func() { close(chaincodeSupport.stop) })
+}
+
+// Shutdown stops monitoring the chaincodes of all the chains joined by the peer
+func Shutdown() {
+	for _, chaincodeSupport := range chains {
+		chaincodeSupport.shutdown()
+	}
+}
+
+// findIdleAndUnhealthy returns the chaincodes which have not executed a transaction for
+// idleTimeout and the ones which do not answer the keepalives
+func (chaincodeSupport *ChaincodeSupport) findIdleAndUnhealthy(now time.Time) (idle []*chaincodeRTEnv, unhealthy []*chaincodeRTEnv) {
+	chaincodeSupport.runningChaincodes.RLock()
+	for _, chrte := range chaincodeSupport.runningChaincodes.chaincodeMap {
+		if !chrte.ready || !chrte.inContainer() {
+			continue
+		}
+		if chaincodeSupport.isUnhealthy(chrte, now) {
+			unhealthy = append(unhealthy, chrte)
+		} else if chaincodeSupport.idleTimeout > 0 && now.Sub(chrte.lastActivity) > chaincodeSupport.idleTimeout && !chrte.handler.busy() {
+			idle = append(idle, chrte)
+		}
+	}
+	chaincodeSupport.runningChaincodes.RUnlock()
+	return idle, unhealthy
+}
+
+// checkChaincodes stops the idle chaincodes and restarts the unresponsive ones
+func (chaincodeSupport *ChaincodeSupport) checkChaincodes(now time.Time) {
+	idle, unhealthy := chaincodeSupport.findIdleAndUnhealthy(now)
+	for _, chrte := range idle {
+		chaincodeLogger.Infof("stopping chaincode %s on chain %s, idle since %s", chrte.cds.ChaincodeSpec.ChaincodeID.Name, chaincodeSupport.name, chrte.lastActivity)
+		if err := chaincodeSupport.Stop(context.Background(), chrte.cds); err != nil {
+			chaincodeLogger.Errorf("failed to stop idle chaincode %s: %s", chrte.cds.ChaincodeSpec.ChaincodeID.Name, err)
+		}
+	}
+	for _, chrte := range unhealthy {
+		chaincode := chrte.cds.ChaincodeSpec.ChaincodeID.Name
+		chaincodeLogger.Warningf("chaincode %s on chain %s does not answer the keepalives, restarting it", chaincode, chaincodeSupport.name)
+		if err := chaincodeSupport.Stop(context.Background(), chrte.cds); err != nil {
+			chaincodeLogger.Errorf("failed to stop unresponsive chaincode %s: %s", chaincode, err)
+		}
+		chaincodeSupport.runningChaincodes.Lock()
+		chaincodeSupport.scheduleRestart(chaincode, chrte)
+		chaincodeSupport.runningChaincodes.Unlock()
+	}
+}
+
+func (chaincodeSupport *ChaincodeSupport) isUnhealthy(chrte *chaincodeRTEnv, now time.Time) bool {
+	return chaincodeSupport.keepalive > 0 && chrte.handler.registered && now.Sub(chrte.handler.getLastHeard()) > unhealthyKeepalives*chaincodeSupport.keepalive
+}
+
+// statuses returns the status of the chaincodes running on the chain or being restarted
+func (chaincodeSupport *ChaincodeSupport) statuses() []*pb.ChaincodeStatus {
+	now := time.Now()
+	var statuses []*pb.ChaincodeStatus
+	chaincodeSupport.runningChaincodes.RLock()
+	defer chaincodeSupport.runningChaincodes.RUnlock()
+	for name, chrte := range chaincodeSupport.runningChaincodes.chaincodeMap {
+		status := &pb.ChaincodeStatus{ChainID: string(chaincodeSupport.name), Name: name, State: pb.ChaincodeStatus_RUNNING, Restarts: int32(chrte.restarts), LastActivity: chrte.lastActivity.Unix()}
+		if chrte.cds != nil {
+			status.Version = chrte.cds.ChaincodeSpec.ChaincodeID.Version
+		}
+		if !chrte.handler.registered {
+			status.State = pb.ChaincodeStatus_LAUNCHING
+		} else if chaincodeSupport.isUnhealthy(chrte, now) {
+			status.State = pb.ChaincodeStatus_UNHEALTHY
+		}
+		statuses = append(statuses, status)
+	}
+	for name, restart := range chaincodeSupport.runningChaincodes.restarting {
+		if _, ok := chaincodeSupport.runningChaincodes.chaincodeMap[name]; ok {
+			continue
+		}
+		statuses = append(statuses, &pb.ChaincodeStatus{ChainID: string(chaincodeSupport.name), Name: name, Version: restart.cds.ChaincodeSpec.ChaincodeID.Version, State: pb.ChaincodeStatus_RESTARTING, Restarts: int32(restart.restarts)})
+	}
+	return statuses
+}
+
+func (chaincodeSupport *ChaincodeSupport) status(chaincode string) *pb.ChaincodeStatus {
+	for _, status := range chaincodeSupport.statuses() {
+		if status.Name == chaincode {
+			return status
+		}
+	}
+	return &pb.ChaincodeStatus{ChainID: string(chaincodeSupport.name), Name: chaincode, State: pb.ChaincodeStatus_STOPPED}
+}
+
+func getUserChaincodeSupport(chainID string, chaincode string) (*ChaincodeSupport, error) {
+	if chainID == "" {
+		chainID = string(DefaultChain)
+	}
+	if IsSysCC(chaincode) {
+		return nil, fmt.Errorf("%s is a system chaincode", chaincode)
+	}
+	chaincodeSupport := GetChain(ChainName(chainID))
+	if chaincodeSupport == nil {
+		return nil, fmt.Errorf("Chain %s not found", chainID)
+	}
+	return chaincodeSupport, nil
+}
+
+// ListChaincodes returns the status of the chaincodes running, or being restarted, on all the
+// chains joined by the peer
+func ListChaincodes() []*pb.ChaincodeStatus {
+	var statuses []*pb.ChaincodeStatus
+	for _, chaincodeSupport := range chains {
+		statuses = append(statuses, chaincodeSupport.statuses()...)
+	}
+	sort.Sort(chaincodeStatusSorter(statuses))
+	return statuses
+}
+
+// StopChaincode stops a chaincode running on the given chain. It is not restarted, it is launched
+// again by its next invocation or by StartChaincode
+func StopChaincode(ctxt context.Context, chainID string, chaincode string) (*pb.ChaincodeStatus, error) {
+	chaincodeSupport, err := getUserChaincodeSupport(chainID, chaincode)
+	if err != nil {
+		return nil, err
+	}
+
+	chaincodeSupport.runningChaincodes.Lock()
+	var cds *pb.ChaincodeDeploymentSpec
+	if chrte, ok := chaincodeSupport.chaincodeHasBeenLaunched(chaincode); ok {
+		cds = chrte.cds
+	}
+	delete(chaincodeSupport.runningChaincodes.restarting, chaincode)
+	chaincodeSupport.runningChaincodes.Unlock()
+
+	if cds == nil {
+		return nil, fmt.Errorf("chaincode %s is not running on chain %s", chaincode, chaincodeSupport.name)
+	}
+	if err = chaincodeSupport.Stop(ctxt, cds); err != nil {
+		return nil, err
+	}
+	return chaincodeSupport.status(chaincode), nil
+}
+
+// StartChaincode launches a chaincode deployed on the given chain
+func StartChaincode(chainID string, chaincode string) (*pb.ChaincodeStatus, error) {
+	chaincodeSupport, err := getUserChaincodeSupport(chainID, chaincode)
+	if err != nil {
+		return nil, err
+	}
+	if err = chaincodeSupport.start(&pb.ChaincodeID{Name: chaincode}); err != nil {
+		return nil, err
+	}
+	return chaincodeSupport.status(chaincode), nil
+}
+
+// chaincodeStatusSorter sorts the statuses by chain, then by chaincode
+type chaincodeStatusSorter []*pb.ChaincodeStatus
+
+func (s chaincodeStatusSorter) Len() int {
+	return len(s)
+}
+
+func (s chaincodeStatusSorter) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s chaincodeStatusSorter) Less(i, j int) bool {
+	if s[i].ChainID != s[j].ChainID {
+		return s[i].ChainID < s[j].ChainID
+	}
+	return s[i].Name < s[j].Name
+}
@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"golang.org/x/net/context"
+)
+
+func newTestChaincodeSupport(name ChainName) *ChaincodeSupport {
+	return &ChaincodeSupport{
+		name:              name,
+		runningChaincodes: &runningChaincodes{chaincodeMap: make(map[string]*chaincodeRTEnv), restarting: make(map[string]*chaincodeRestart)},
+		keepalive:         time.Second,
+		idleTimeout:       time.Minute,
+		restartBackoff:    time.Hour,
+		restartMaxBackoff: time.Hour,
+		stop:              make(chan struct{}),
+	}
+}
+
+func newTestChaincodeRTEnv(name string, execEnv pb.ChaincodeDeploymentSpec_ExecutionEnvironment, lastHeard time.Time, lastActivity time.Time) *chaincodeRTEnv {
+	cds := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: name, Version: "1"}}, ExecEnv: execEnv}
+	handler := &Handler{ChaincodeID: cds.ChaincodeSpec.ChaincodeID, registered: true, lastHeard: lastHeard, txCtxs: make(map[string]*transactionContext)}
+	return &chaincodeRTEnv{handler: handler, cds: cds, ready: true, lastActivity: lastActivity}
+}
+
+func TestChaincodeStatuses(t *testing.T) {
+	now := time.Now()
+	cs := newTestChaincodeSupport("lifecyclechain")
+	cs.runningChaincodes.chaincodeMap["running"] = newTestChaincodeRTEnv("running", pb.ChaincodeDeploymentSpec_DOCKER, now, now)
+	cs.runningChaincodes.chaincodeMap["unhealthy"] = newTestChaincodeRTEnv("unhealthy", pb.ChaincodeDeploymentSpec_DOCKER, now.Add(-time.Minute), now)
+	cs.runningChaincodes.chaincodeMap["launching"] = &chaincodeRTEnv{handler: &Handler{}}
+	cs.runningChaincodes.restarting["restarting"] = &chaincodeRestart{cds: cs.runningChaincodes.chaincodeMap["running"].cds, restarts: 2}
+	chains["lifecyclechain"] = cs
+	defer delete(chains, "lifecyclechain")
+
+	expected := map[string]pb.ChaincodeStatus_State{
+		"launching":  pb.ChaincodeStatus_LAUNCHING,
+		"restarting": pb.ChaincodeStatus_RESTARTING,
+		"running":    pb.ChaincodeStatus_RUNNING,
+		"unhealthy":  pb.ChaincodeStatus_UNHEALTHY,
+	}
+	var names []string
+	for _, status := range ListChaincodes() {
+		if status.ChainID != "lifecyclechain" {
+			continue
+		}
+		names = append(names, status.Name)
+		if status.State != expected[status.Name] {
+			t.Fatalf("Expected %s to be %s, got %s", status.Name, expected[status.Name], status.State)
+		}
+	}
+	if len(names) != 4 || names[0] != "launching" || names[3] != "unhealthy" {
+		t.Fatalf("Expected the chaincodes sorted by name, got %v", names)
+	}
+
+	if status := cs.status("notrunning"); status.State != pb.ChaincodeStatus_STOPPED {
+		t.Fatalf("Expected a chaincode which is not running to be stopped, got %s", status.State)
+	}
+	if _, err := StopChaincode(context.Background(), "lifecyclechain", "lccc"); err == nil {
+		t.Fatal("Expected stopping a system chaincode to fail")
+	}
+	if _, err := StartChaincode("nochain", "mycc"); err == nil {
+		t.Fatal("Expected starting a chaincode on a chain which is not joined to fail")
+	}
+}
+
+func TestFindIdleAndUnhealthy(t *testing.T) {
+	now := time.Now()
+	cs := newTestChaincodeSupport("lifecyclechain")
+	cs.runningChaincodes.chaincodeMap["active"] = newTestChaincodeRTEnv("active", pb.ChaincodeDeploymentSpec_DOCKER, now, now)
+	cs.runningChaincodes.chaincodeMap["idle"] = newTestChaincodeRTEnv("idle", pb.ChaincodeDeploymentSpec_DOCKER, now, now.Add(-time.Hour))
+	cs.runningChaincodes.chaincodeMap["busy"] = newTestChaincodeRTEnv("busy", pb.ChaincodeDeploymentSpec_DOCKER, now, now.Add(-time.Hour))
+	cs.runningChaincodes.chaincodeMap["busy"].handler.txCtxs["txid"] = &transactionContext{}
+	cs.runningChaincodes.chaincodeMap["dead"] = newTestChaincodeRTEnv("dead", pb.ChaincodeDeploymentSpec_DOCKER, now.Add(-time.Minute), now)
+	cs.runningChaincodes.chaincodeMap["system"] = newTestChaincodeRTEnv("system", pb.ChaincodeDeploymentSpec_SYSTEM, now.Add(-time.Minute), now.Add(-time.Hour))
+
+	idle, unhealthy := cs.findIdleAndUnhealthy(now)
+	if len(idle) != 1 || idle[0].cds.ChaincodeSpec.ChaincodeID.Name != "idle" {
+		t.Fatalf("Expected only the idle chaincode to be stopped, got %v", idle)
+	}
+	if len(unhealthy) != 1 || unhealthy[0].cds.ChaincodeSpec.ChaincodeID.Name != "dead" {
+		t.Fatalf("Expected only the dead chaincode to be restarted, got %v", unhealthy)
+	}
+
+	cs.idleTimeout = 0
+	cs.keepalive = 0
+	if idle, unhealthy = cs.findIdleAndUnhealthy(now); len(idle) != 0 || len(unhealthy) != 0 {
+		t.Fatalf("Expected no chaincode to be stopped with idletimeout and keepalive off, got %v and %v", idle, unhealthy)
+	}
+}
+
+func TestRunningContainers(t *testing.T) {
+	now := time.Now()
+	cs := newTestChaincodeSupport("lifecyclechain")
+	cs.runningChaincodes.chaincodeMap["cc1"] = newTestChaincodeRTEnv("cc1", pb.ChaincodeDeploymentSpec_DOCKER, now, now)
+	cs.runningChaincodes.chaincodeMap["cc2"] = newTestChaincodeRTEnv("cc2", pb.ChaincodeDeploymentSpec_DOCKER, now, now)
+	cs.runningChaincodes.chaincodeMap["system"] = newTestChaincodeRTEnv("system", pb.ChaincodeDeploymentSpec_SYSTEM, now, now)
+
+	before := runningContainers()
+	chains["lifecyclechain"] = cs
+	defer delete(chains, "lifecyclechain")
+	if n := runningContainers() - before; n != 2 {
+		t.Fatalf("Expected 2 running containers, got %d", n)
+	}
+}
+
+func TestRecordLaunchEnforcesMaxContainers(t *testing.T) {
+	cs := newTestChaincodeSupport("lifecyclechain")
+	cs.maxContainers = runningContainers() + 1
+	chains["lifecyclechain"] = cs
+	defer delete(chains, "lifecyclechain")
+
+	const launches = 20
+	var wg sync.WaitGroup
+	recorded := make(chan string, launches)
+	for i := 0; i < launches; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			cds := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: name}}, ExecEnv: pb.ChaincodeDeploymentSpec_DOCKER}
+			if _, _, err := cs.recordLaunch(name, cds); err == nil {
+				recorded <- name
+			}
+		}(fmt.Sprintf("cc%d", i))
+	}
+	wg.Wait()
+	close(recorded)
+
+	if n := len(recorded); n != 1 {
+		t.Fatalf("Expected a single launch to be recorded, got %d", n)
+	}
+	name := <-recorded
+	if _, running, err := cs.recordLaunch(name, cs.runningChaincodes.chaincodeMap[name].cds); err != nil || !running {
+		t.Fatalf("Expected %s to be running, got %t and %v", name, running, err)
+	}
+	system := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: "system"}}, ExecEnv: pb.ChaincodeDeploymentSpec_SYSTEM}
+	if _, _, err := cs.recordLaunch("system", system); err != nil {
+		t.Fatalf("Expected system chaincodes not to count against the maximum, got %s", err)
+	}
+}
+
+func TestDeregisterRestartsDeadContainers(t *testing.T) {
+	now := time.Now()
+	cs := newTestChaincodeSupport("lifecyclechain")
+	crashed := newTestChaincodeRTEnv("crashed", pb.ChaincodeDeploymentSpec_DOCKER, now, now)
+	cs.runningChaincodes.chaincodeMap["crashed"] = crashed
+	starting := newTestChaincodeRTEnv("starting", pb.ChaincodeDeploymentSpec_DOCKER, now, now)
+	starting.ready = false
+	cs.runningChaincodes.chaincodeMap["starting"] = starting
+	replaced := newTestChaincodeRTEnv("replaced", pb.ChaincodeDeploymentSpec_DOCKER, now, now)
+	cs.runningChaincodes.chaincodeMap["replaced"] = replaced
+
+	if err := cs.deregisterHandler(crashed.handler); err != nil {
+		t.Fatalf("Error deregistering handler: %s", err)
+	}
+	if restart, ok := cs.runningChaincodes.restarting["crashed"]; !ok || restart.restarts != 1 {
+		t.Fatalf("Expected the crashed chaincode to be restarted, got %v", restart)
+	}
+
+	if err := cs.deregisterHandler(starting.handler); err != nil {
+		t.Fatalf("Error deregistering handler: %s", err)
+	}
+	if _, ok := cs.runningChaincodes.restarting["starting"]; ok {
+		t.Fatal("Expected a chaincode which died before being ready not to be restarted")
+	}
+
+	previous := &Handler{ChaincodeID: replaced.cds.ChaincodeSpec.ChaincodeID}
+	if err := cs.deregisterHandler(previous); err != nil {
+		t.Fatalf("Error deregistering handler: %s", err)
+	}
+	if _, ok := cs.runningChaincodes.chaincodeMap["replaced"]; !ok {
+		t.Fatal("Expected the end of a replaced handler not to deregister the chaincode")
+	}
+}
+
+func TestMonitorStopsOnShutdown(t *testing.T) {
+	cs := newTestChaincodeSupport("lifecyclechain")
+	done := make(chan struct{})
+	go func() {
+		cs.monitor()
+		close(done)
+	}()
+
+	cs.shutdown()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the monitor to stop on shutdown")
+	}
+	// shutting down again is harmless
+	cs.shutdown()
+}
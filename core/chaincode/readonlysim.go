@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+// chainSeparator separates the name of a chaincode from the name of its chain
+// when a chaincode invokes a chaincode deployed on another chain
+const chainSeparator = "/"
+
+// splitChaincodeName splits a chaincode name of the form name/chain into the name
+// of the chaincode and the name of its chain. The chain is empty if it isn't given
+func splitChaincodeName(chaincodeName string) (string, string) {
+	i := strings.LastIndex(chaincodeName, chainSeparator)
+	if i == -1 {
+		return chaincodeName, ""
+	}
+	return chaincodeName[:i], chaincodeName[i+1:]
+}
+
+// readOnlyTxSimulator is a ledger.TxSimulator which reads through a QueryExecutor
+// and rejects all the writes. It is used to execute a chaincode invoked on another
+// chain: its reads are not recorded in the read-write set of the transaction, thus
+// they are not validated when the transaction is committed
+type readOnlyTxSimulator struct {
+	ledger.QueryExecutor
+	chain string
+}
+
+func newReadOnlyTxSimulator(qe ledger.QueryExecutor, chain string) ledger.TxSimulator {
+	return &readOnlyTxSimulator{QueryExecutor: qe, chain: chain}
+}
+
+func (sim *readOnlyTxSimulator) errReadOnly() error {
+	return fmt.Errorf("Chain %s is read-only when invoked from another chain", sim.chain)
+}
+
+// SetState rejects the write
+func (sim *readOnlyTxSimulator) SetState(namespace string, key string, value []byte) error {
+	return sim.errReadOnly()
+}
+
+// DeleteState rejects the write
+func (sim *readOnlyTxSimulator) DeleteState(namespace string, key string) error {
+	return sim.errReadOnly()
+}
+
+// SetStateMultipleKeys rejects the writes
+func (sim *readOnlyTxSimulator) SetStateMultipleKeys(namespace string, kvs map[string][]byte) error {
+	return sim.errReadOnly()
+}
+
+// ExecuteUpdate rejects the update
+func (sim *readOnlyTxSimulator) ExecuteUpdate(query string) error {
+	return sim.errReadOnly()
+}
+
+// GetTxSimulationResults returns an error, as the reads of a read-only simulator are not part of any transaction
+func (sim *readOnlyTxSimulator) GetTxSimulationResults() ([]byte, error) {
+	return nil, fmt.Errorf("Chain %s has no simulation results when invoked from another chain", sim.chain)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+type mockQueryExecutor struct {
+	ledger.QueryExecutor
+	state map[string][]byte
+}
+
+func (qe *mockQueryExecutor) GetState(namespace string, key string) ([]byte, error) {
+	return qe.state[namespace+"/"+key], nil
+}
+
+func TestSplitChaincodeName(t *testing.T) {
+	for _, test := range []struct{ name, chaincode, chain string }{
+		{"mycc", "mycc", ""},
+		{"mycc/chain1", "mycc", "chain1"},
+		{"mycc/", "mycc", ""},
+	} {
+		chaincode, chain := splitChaincodeName(test.name)
+		if chaincode != test.chaincode || chain != test.chain {
+			t.Fatalf("Expected %s to be split in (%s, %s), got (%s, %s)", test.name, test.chaincode, test.chain, chaincode, chain)
+		}
+	}
+}
+
+func TestReadOnlyTxSimulator(t *testing.T) {
+	qe := &mockQueryExecutor{state: map[string][]byte{"mycc/price": []byte("100")}}
+	txsim := newReadOnlyTxSimulator(qe, "chain1")
+
+	value, err := txsim.GetState("mycc", "price")
+	if err != nil || string(value) != "100" {
+		t.Fatalf("Expected to read the state of the chain, got %s (%v)", value, err)
+	}
+	if err := txsim.SetState("mycc", "price", []byte("200")); err == nil {
+		t.Fatal("Expected SetState to be rejected")
+	}
+	if err := txsim.DeleteState("mycc", "price"); err == nil {
+		t.Fatal("Expected DeleteState to be rejected")
+	}
+	if err := txsim.SetStateMultipleKeys("mycc", map[string][]byte{"price": []byte("200")}); err == nil {
+		t.Fatal("Expected SetStateMultipleKeys to be rejected")
+	}
+	if _, err := txsim.GetTxSimulationResults(); err == nil {
+		t.Fatal("Expected GetTxSimulationResults to fail")
+	}
+}
@@ -20,6 +20,8 @@ package shim
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"flag"
 	"fmt"
@@ -34,6 +36,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim/crypto/ecdsa"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/util"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
@@ -51,6 +54,8 @@ type ChaincodeStub struct {
 	chaincodeEvent *pb.ChaincodeEvent
 	args           [][]byte
 	handler        *Handler
+	transient      map[string][]byte
+	signedProposal *pb.SignedProposal
 }
 
 // Peer address derived from command line or env var
@@ -258,17 +263,19 @@ func chatWithPeer(chaincodename string, stream PeerChaincodeStream, cc Chaincode
 // -- init stub ---
 // ChaincodeInvocation functionality
 
-func (stub *ChaincodeStub) init(handler *Handler, txid string, input *pb.ChaincodeInput) {
+func (stub *ChaincodeStub) init(handler *Handler, txid string, input *pb.ChaincodeInput, proposalContext *pb.ChaincodeProposalContext) {
 	stub.TxID = txid
 	stub.args = input.Args
 	stub.handler = handler
+	stub.transient = proposalContext.GetTransient()
+	stub.signedProposal = proposalContext.GetSignedProposal()
 }
 
 func InitTestStub(funargs ...string) *ChaincodeStub {
 	stub := ChaincodeStub{}
 	allargs := util.ToChaincodeArgs(funargs...)
 	newCI := &pb.ChaincodeInput{Args: allargs}
-	stub.init(&Handler{}, "TEST-txid", newCI)
+	stub.init(&Handler{}, "TEST-txid", newCI, nil)
 	return &stub
 }
 
@@ -276,6 +283,12 @@ func (stub *ChaincodeStub) GetTxID() string {
 	return stub.TxID
 }
 
+// GetTransient returns the transient map of the proposal, which holds data
+// that is available to the chaincode but is not part of the transaction
+func (stub *ChaincodeStub) GetTransient() (map[string][]byte, error) {
+	return stub.transient, nil
+}
+
 // --------- Security functions ----------
 //CHAINCODE SEC INTERFACE FUNCS TOBE IMPLEMENTED BY ANGELO
 
@@ -283,7 +296,9 @@ func (stub *ChaincodeStub) GetTxID() string {
 
 // InvokeChaincode locally calls the specified chaincode `Invoke` using the
 // same transaction context; that is, chaincode calling chaincode doesn't
-// create a new transaction message.
+// create a new transaction message. A chaincode deployed on another chain is
+// addressed as `name/chain`: it is invoked read-only, it cannot write to its
+// chain and its reads are not validated when the transaction is committed.
 func (stub *ChaincodeStub) InvokeChaincode(chaincodeName string, args [][]byte) ([]byte, error) {
 	return stub.handler.handleInvokeChaincode(chaincodeName, args, stub.TxID)
 }
@@ -631,21 +646,24 @@ func getRowsInternal(stub ChaincodeStubInterface, tableName string, key []Column
 	if err != nil {
 		return nil, fmt.Errorf("Error fetching rows: %s", err)
 	}
-	defer iter.Close()
 
 	rows := make(chan Row)
 
+	// the iterator is only closed once the rows are read
 	go func() {
+		defer iter.Close()
 		for iter.HasNext() {
 			_, rowBytes, err := iter.Next()
 			if err != nil {
 				close(rows)
+				return
 			}
 
 			var row Row
 			err = proto.Unmarshal(rowBytes, &row)
 			if err != nil {
 				close(rows)
+				return
 			}
 
 			rows <- row
@@ -688,32 +706,88 @@ func (stub *ChaincodeStub) VerifySignature(certificate, signature, message []byt
 	return sv.Verify(certificate, signature, message)
 }
 
-// GetCallerCertificate returns caller certificate
+// getProposal decodes the proposal the chaincode is invoked for and its header
+func (stub *ChaincodeStub) getProposal() (*pb.Proposal, *common.Header, error) {
+	if stub.signedProposal == nil {
+		return nil, nil, errors.New("The chaincode is not invoked for a signed proposal")
+	}
+	prop := &pb.Proposal{}
+	if err := proto.Unmarshal(stub.signedProposal.ProposalBytes, prop); err != nil {
+		return nil, nil, fmt.Errorf("Could not decode the proposal: %s", err)
+	}
+	hdr := &common.Header{}
+	if err := proto.Unmarshal(prop.Header, hdr); err != nil {
+		return nil, nil, fmt.Errorf("Could not decode the proposal header: %s", err)
+	}
+	if hdr.ChainHeader == nil || hdr.SignatureHeader == nil {
+		return nil, nil, errors.New("The proposal header is incomplete")
+	}
+	return prop, hdr, nil
+}
+
+// GetCreator returns the identity of the creator of the proposal, serialized
+// by its MSP
+func (stub *ChaincodeStub) GetCreator() ([]byte, error) {
+	_, hdr, err := stub.getProposal()
+	if err != nil {
+		return nil, err
+	}
+	return hdr.SignatureHeader.Creator, nil
+}
+
+// GetCallerCertificate returns caller certificate, which is the serialized
+// identity of the creator of the proposal (see GetCreator)
 func (stub *ChaincodeStub) GetCallerCertificate() ([]byte, error) {
-	return nil, nil
+	return stub.GetCreator()
 }
 
-// GetCallerMetadata returns caller metadata
+// GetCallerMetadata returns caller metadata, which is the extension of the
+// proposal
 func (stub *ChaincodeStub) GetCallerMetadata() ([]byte, error) {
-	return nil, nil
+	prop, _, err := stub.getProposal()
+	if err != nil {
+		return nil, err
+	}
+	return prop.Extension, nil
 }
 
-// GetBinding returns the transaction binding
+// GetBinding returns the transaction binding, that is the SHA2-256 hash of
+// the nonce, the creator and the epoch (8 bytes, big endian) of the proposal.
+// It ties the proposal to its creator so that it cannot be replayed by
+// someone else
 func (stub *ChaincodeStub) GetBinding() ([]byte, error) {
-	return nil, nil
+	_, hdr, err := stub.getProposal()
+	if err != nil {
+		return nil, err
+	}
+	epoch := make([]byte, 8)
+	binary.BigEndian.PutUint64(epoch, hdr.ChainHeader.Epoch)
+	h := sha256.New()
+	h.Write(hdr.SignatureHeader.Nonce)
+	h.Write(hdr.SignatureHeader.Creator)
+	h.Write(epoch)
+	return h.Sum(nil), nil
 }
 
-// GetPayload returns transaction payload, which is a `ChaincodeSpec` defined
-// in fabric/protos/chaincode.proto
+// GetPayload returns transaction payload, which is a `ChaincodeProposalPayload`
+// defined in fabric/protos/peer/chaincode_proposal.proto
 func (stub *ChaincodeStub) GetPayload() ([]byte, error) {
-	return nil, nil
+	prop, _, err := stub.getProposal()
+	if err != nil {
+		return nil, err
+	}
+	return prop.Payload, nil
 }
 
-// GetTxTimestamp returns transaction created timestamp, which is currently
-// taken from the peer receiving the transaction. Note that this timestamp
-// may not be the same with the other peers' time.
+// GetTxTimestamp returns transaction created timestamp, which is taken from
+// the header of the proposal and set by its creator. Note that this timestamp
+// may not be the same with the peers' time.
 func (stub *ChaincodeStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
-	return nil, nil
+	_, hdr, err := stub.getProposal()
+	if err != nil {
+		return nil, err
+	}
+	return hdr.ChainHeader.Timestamp, nil
 }
 
 func getTable(stub ChaincodeStubInterface, tableName string) (*Table, error) {
@@ -216,7 +216,7 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage) {
 		// Call chaincode's Run
 		// Create the ChaincodeStub which the chaincode can use to callback
 		stub := new(ChaincodeStub)
-		stub.init(handler, msg.Txid, input)
+		stub.init(handler, msg.Txid, input, msg.ProposalContext)
 		res, err := handler.cc.Init(stub)
 
 		// delete isTransaction entry
@@ -283,7 +283,7 @@ func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage) {
 		// Call chaincode's Run
 		// Create the ChaincodeStub which the chaincode can use to callback
 		stub := new(ChaincodeStub)
-		stub.init(handler, msg.Txid, input)
+		stub.init(handler, msg.Txid, input, msg.ProposalContext)
 		res, err := handler.cc.Invoke(stub)
 
 		// delete isTransaction entry
@@ -49,9 +49,16 @@ type ChaincodeStubInterface interface {
 	// Get the transaction ID
 	GetTxID() string
 
+	// GetTransient returns the transient map of the proposal. It holds data
+	// (e.g. cryptographic material) passed to the chaincode during endorsement
+	// which is never included in the transaction nor stored in the ledger
+	GetTransient() (map[string][]byte, error)
+
 	// InvokeChaincode locally calls the specified chaincode `Invoke` using the
 	// same transaction context; that is, chaincode calling chaincode doesn't
-	// create a new transaction message.
+	// create a new transaction message. A chaincode deployed on another chain is
+	// addressed as `name/chain`: it is invoked read-only, it cannot write to its
+	// chain and its reads are not validated when the transaction is committed.
 	InvokeChaincode(chaincodeName string, args [][]byte) ([]byte, error)
 
 	// GetState returns the byte array value specified by the `key`.
@@ -133,22 +140,29 @@ type ChaincodeStubInterface interface {
 	// correct and `false` otherwise
 	VerifySignature(certificate, signature, message []byte) (bool, error)
 
-	// GetCallerCertificate returns caller certificate
+	// GetCreator returns the identity of the creator of the proposal, as
+	// serialized by its MSP
+	GetCreator() ([]byte, error)
+
+	// GetCallerCertificate returns caller certificate, which is the serialized
+	// identity of the creator of the proposal
 	GetCallerCertificate() ([]byte, error)
 
-	// GetCallerMetadata returns caller metadata
+	// GetCallerMetadata returns caller metadata, which is the extension of the
+	// proposal
 	GetCallerMetadata() ([]byte, error)
 
-	// GetBinding returns the transaction binding
+	// GetBinding returns the transaction binding, which ties the proposal to
+	// its creator
 	GetBinding() ([]byte, error)
 
-	// GetPayload returns transaction payload, which is a `ChaincodeSpec` defined
-	// in fabric/protos/chaincode.proto
+	// GetPayload returns transaction payload, which is a `ChaincodeProposalPayload`
+	// defined in fabric/protos/peer/chaincode_proposal.proto
 	GetPayload() ([]byte, error)
 
-	// GetTxTimestamp returns transaction created timestamp, which is currently
-	// taken from the peer receiving the transaction. Note that this timestamp
-	// may not be the same with the other peers' time.
+	// GetTxTimestamp returns transaction created timestamp, which is taken
+	// from the header of the proposal and set by its creator. Note that this
+	// timestamp may not be the same with the peers' time.
 	GetTxTimestamp() (*timestamp.Timestamp, error)
 
 	// SetEvent saves the event to be sent when a transaction is made part of a block
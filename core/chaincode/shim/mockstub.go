@@ -56,12 +56,24 @@ type MockStub struct {
 	// stores a transaction uuid while being Invoked / Deployed
 	// TODO if a chaincode uses recursion this may need to be a stack of TxIDs or possibly a reference counting map
 	TxID string
+
+	// transient map of the proposal the stub is being Invoked / Deployed with
+	transient map[string][]byte
+
+	// creator and timestamp of the proposal, set with MockSetCreator and MockSetTxTimestamp
+	creator   []byte
+	timestamp *timestamp.Timestamp
 }
 
 func (stub *MockStub) GetTxID() string {
 	return stub.TxID
 }
 
+// GetTransient returns the transient map set with MockInvokeWithTransient
+func (stub *MockStub) GetTransient() (map[string][]byte, error) {
+	return stub.transient, nil
+}
+
 func (stub *MockStub) GetArgs() [][]byte {
 	return stub.args
 }
@@ -98,6 +110,17 @@ func (stub *MockStub) MockTransactionEnd(uuid string) {
 	stub.TxID = ""
 }
 
+// MockSetCreator sets the serialized identity returned by GetCreator and
+// GetCallerCertificate
+func (stub *MockStub) MockSetCreator(creator []byte) {
+	stub.creator = creator
+}
+
+// MockSetTxTimestamp sets the timestamp returned by GetTxTimestamp
+func (stub *MockStub) MockSetTxTimestamp(ts *timestamp.Timestamp) {
+	stub.timestamp = ts
+}
+
 // Register a peer chaincode with this MockStub
 // invokableChaincodeName is the name or hash of the peer
 // otherStub is a MockStub of the peer, already intialised
@@ -123,6 +146,13 @@ func (stub *MockStub) MockInvoke(uuid string, args [][]byte) ([]byte, error) {
 	return bytes, err
 }
 
+// Invoke this chaincode with a transient map, also starts and ends a transaction.
+func (stub *MockStub) MockInvokeWithTransient(uuid string, args [][]byte, transient map[string][]byte) ([]byte, error) {
+	stub.transient = transient
+	defer func() { stub.transient = nil }()
+	return stub.MockInvoke(uuid, args)
+}
+
 // GetState retrieves the value for a given key from the ledger
 func (stub *MockStub) GetState(key string) ([]byte, error) {
 	value := stub.State[key]
@@ -256,7 +286,8 @@ func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte) ([]by
 	otherStub := stub.Invokables[chaincodeName]
 	mockLogger.Debug("MockStub", stub.Name, "Invoking peer chaincode", otherStub.Name, args)
 	//	function, strings := getFuncArgs(args)
-	bytes, err := otherStub.MockInvoke(stub.TxID, args)
+	// the called chaincode sees the same proposal, hence the same transient map
+	bytes, err := otherStub.MockInvokeWithTransient(stub.TxID, args, stub.transient)
 	mockLogger.Debug("MockStub", stub.Name, "Invoked peer chaincode", otherStub.Name, "got", bytes, err)
 	return bytes, err
 }
@@ -281,9 +312,14 @@ func (stub *MockStub) VerifySignature(certificate, signature, message []byte) (b
 	return false, nil
 }
 
-// Not implemented
+// GetCreator returns the creator set with MockSetCreator
+func (stub *MockStub) GetCreator() ([]byte, error) {
+	return stub.creator, nil
+}
+
+// GetCallerCertificate returns the creator set with MockSetCreator
 func (stub *MockStub) GetCallerCertificate() ([]byte, error) {
-	return nil, nil
+	return stub.creator, nil
 }
 
 // Not implemented
@@ -301,9 +337,9 @@ func (stub *MockStub) GetPayload() ([]byte, error) {
 	return nil, nil
 }
 
-// Not implemented
+// GetTxTimestamp returns the timestamp set with MockSetTxTimestamp
 func (stub *MockStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
-	return nil, nil
+	return stub.timestamp, nil
 }
 
 // Not implemented
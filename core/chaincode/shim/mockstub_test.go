@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/spf13/viper"
 )
 
@@ -190,3 +191,47 @@ func TestSetChaincodeLoggingLevel(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+type transientCC struct{}
+
+func (t *transientCC) Init(stub ChaincodeStubInterface) ([]byte, error) {
+	return nil, nil
+}
+
+// Invoke returns the value of the transient map entry named by the first argument
+func (t *transientCC) Invoke(stub ChaincodeStubInterface) ([]byte, error) {
+	transient, err := stub.GetTransient()
+	if err != nil {
+		return nil, err
+	}
+	return transient[string(stub.GetArgs()[0])], nil
+}
+
+func TestMockTransient(t *testing.T) {
+	stub := NewMockStub("transientTest", &transientCC{})
+	res, err := stub.MockInvokeWithTransient("txid1", [][]byte{[]byte("key")}, map[string][]byte{"key": []byte("secret")})
+	if err != nil || string(res) != "secret" {
+		t.Fatalf("Expected the transient value, got %s (%v)", res, err)
+	}
+
+	// the transient map only lives for the duration of the invocation
+	res, err = stub.MockInvoke("txid2", [][]byte{[]byte("key")})
+	if err != nil || res != nil {
+		t.Fatalf("Expected no transient value, got %s (%v)", res, err)
+	}
+}
+
+func TestMockCreator(t *testing.T) {
+	stub := NewMockStub("creatorTest", &transientCC{})
+	stub.MockSetCreator([]byte("creator"))
+	stub.MockSetTxTimestamp(&timestamp.Timestamp{Seconds: 1480000000})
+
+	creator, err := stub.GetCreator()
+	if err != nil || string(creator) != "creator" {
+		t.Fatalf("Expected the creator, got %s (%v)", creator, err)
+	}
+	ts, err := stub.GetTxTimestamp()
+	if err != nil || ts.Seconds != 1480000000 {
+		t.Fatalf("Expected the timestamp, got %v (%v)", ts, err)
+	}
+}
@@ -17,9 +17,14 @@ limitations under the License.
 package shim
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/op/go-logging"
 )
 
@@ -134,3 +139,48 @@ func TestChaincodeLogging(t *testing.T) {
 		t.Errorf("'bar' should be enabled for LogCritical")
 	}
 }
+
+// TestProposalContext tests that the stub exposes the signed proposal passed
+// by the peer
+func TestProposalContext(t *testing.T) {
+	stub := InitTestStub("invoke")
+	if _, err := stub.GetCreator(); err == nil {
+		t.Fatal("Expected an error without a signed proposal")
+	}
+
+	ts := &timestamp.Timestamp{Seconds: 1480000000}
+	hdr := &common.Header{
+		ChainHeader:     &common.ChainHeader{Timestamp: ts, Epoch: 1},
+		SignatureHeader: &common.SignatureHeader{Creator: []byte("creator"), Nonce: []byte("nonce")},
+	}
+	hdrBytes, _ := proto.Marshal(hdr)
+	propBytes, _ := proto.Marshal(&pb.Proposal{Header: hdrBytes, Payload: []byte("payload")})
+	signedProp := &pb.SignedProposal{ProposalBytes: propBytes, Signature: []byte("signature")}
+	stub.init(&Handler{}, "TEST-txid", &pb.ChaincodeInput{}, &pb.ChaincodeProposalContext{SignedProposal: signedProp})
+
+	creator, err := stub.GetCreator()
+	if err != nil || string(creator) != "creator" {
+		t.Fatalf("Expected the creator of the proposal, got %s (%v)", creator, err)
+	}
+	payload, err := stub.GetPayload()
+	if err != nil || string(payload) != "payload" {
+		t.Fatalf("Expected the payload of the proposal, got %s (%v)", payload, err)
+	}
+	txts, err := stub.GetTxTimestamp()
+	if err != nil || !proto.Equal(txts, ts) {
+		t.Fatalf("Expected the timestamp of the proposal, got %v (%v)", txts, err)
+	}
+
+	// the binding changes with the epoch
+	binding, err := stub.GetBinding()
+	if err != nil || len(binding) != 32 {
+		t.Fatalf("Expected a binding, got %x (%v)", binding, err)
+	}
+	hdr.ChainHeader.Epoch = 2
+	hdrBytes, _ = proto.Marshal(hdr)
+	signedProp.ProposalBytes, _ = proto.Marshal(&pb.Proposal{Header: hdrBytes})
+	other, err := stub.GetBinding()
+	if err != nil || bytes.Equal(binding, other) {
+		t.Fatalf("Expected a different binding, got %x (%v)", other, err)
+	}
+}
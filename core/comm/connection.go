@@ -82,3 +82,36 @@ func InitTLSForPeer() credentials.TransportCredentials {
 	}
 	return creds
 }
+
+// OrdererTLSEnabled returns true if the connections to the orderer use TLS
+func OrdererTLSEnabled() bool {
+	return viper.GetBool("peer.committer.ledger.tls.enabled")
+}
+
+// InitTLSForOrderer returns TLS credentials for the connections to the orderer,
+// with the peer's certificate for mutual TLS when one is configured
+func InitTLSForOrderer() (credentials.TransportCredentials, error) {
+	var rootCAs []string
+	if rootCA := viper.GetString("peer.committer.ledger.tls.rootcert.file"); rootCA != "" {
+		rootCAs = append(rootCAs, rootCA)
+	}
+	return NewClientCredentials(
+		rootCAs,
+		viper.GetString("peer.committer.ledger.tls.cert.file"),
+		viper.GetString("peer.committer.ledger.tls.key.file"),
+		viper.GetString("peer.committer.ledger.tls.serverhostoverride"))
+}
+
+// NewOrdererClientConnection returns a new grpc.ClientConn to the orderer at ordererAddress,
+// using TLS as configured in peer.committer.ledger.tls
+func NewOrdererClientConnection(ordererAddress string) (*grpc.ClientConn, error) {
+	if !OrdererTLSEnabled() {
+		return NewClientConnectionWithAddress(ordererAddress, true, false, nil)
+	}
+
+	creds, err := InitTLSForOrderer()
+	if err != nil {
+		return nil, err
+	}
+	return NewClientConnectionWithAddress(ordererAddress, true, true, creds)
+}
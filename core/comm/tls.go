@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package comm
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Client authentication modes of the server
+const (
+	// ClientAuthNone does not request a certificate from the clients
+	ClientAuthNone = "none"
+	// ClientAuthOptional verifies the certificate of the clients which present one
+	ClientAuthOptional = "optional"
+	// ClientAuthRequired rejects the clients which do not present a valid certificate
+	ClientAuthRequired = "required"
+)
+
+// NewServerCredentials returns the TLS credentials of a server presenting the
+// certificate and key in certFile and keyFile. The certificates of the clients
+// are verified against the root CAs in the PEM files clientRootCAFiles
+// according to clientAuth, one of the ClientAuth* modes.
+func NewServerCredentials(certFile, keyFile string, clientRootCAFiles []string, clientAuth string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading the server certificate: %s", err)
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	switch clientAuth {
+	case "", ClientAuthNone:
+		config.ClientAuth = tls.NoClientCert
+	case ClientAuthOptional:
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequired:
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("Unknown client authentication mode %s", clientAuth)
+	}
+
+	if config.ClientAuth != tls.NoClientCert {
+		if len(clientRootCAFiles) == 0 {
+			return nil, fmt.Errorf("Client authentication %s requires client root CAs", clientAuth)
+		}
+		if config.ClientCAs, err = loadCertPool(clientRootCAFiles); err != nil {
+			return nil, err
+		}
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+// NewClientCredentials returns the TLS credentials of a client verifying the
+// server against the root CAs in the PEM files rootCAFiles, or the system roots
+// if there are none, and expecting serverNameOverride as the server name if set.
+// The client presents the certificate and key in certFile and keyFile, if set,
+// for mutual TLS.
+func NewClientCredentials(rootCAFiles []string, certFile, keyFile, serverNameOverride string) (credentials.TransportCredentials, error) {
+	config := &tls.Config{ServerName: serverNameOverride}
+
+	if len(rootCAFiles) != 0 {
+		var err error
+		if config.RootCAs, err = loadCertPool(rootCAFiles); err != nil {
+			return nil, err
+		}
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading the client certificate: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(config), nil
+}
+
+func loadCertPool(files []string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	for _, file := range files {
+		pem, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading root CA file: %s", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("No certificate found in root CA file %s", file)
+		}
+	}
+	return pool, nil
+}
+
+// ClientIdentity describes the client of the stream whose context is ctx: the subject
+// of the certificate it authenticated with, if any, and its address
+func ClientIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown client"
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+		subject := tlsInfo.State.PeerCertificates[0].Subject
+		return fmt.Sprintf("%s (O=%v, OU=%v) at %s", subject.CommonName, subject.Organization, subject.OrganizationalUnit, p.Addr)
+	}
+	return fmt.Sprintf("unauthenticated client at %s", p.Addr)
+}
+
+// LogClientIdentity is a stream interceptor logging the identity of the client of each stream
+func LogClientIdentity(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	commLogger.Infof("Starting %s stream for %s", info.FullMethod, ClientIdentity(ss.Context()))
+	return handler(srv, ss)
+}
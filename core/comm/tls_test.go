@@ -0,0 +1,223 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package comm
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/crypto/primitives/testutil"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type testFiles struct {
+	dir                             string
+	caCert                          string
+	serverCert, serverKey           string
+	clientCert, clientKey           string
+	otherClientCert, otherClientKey string
+}
+
+// writeTestCert writes a certificate signed by parent, or self-signed if nil, and its key
+func writeTestCert(t *testing.T, dir, name string, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	cert, key := testutil.NewCert(t, template, parent, parentKey)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error marshaling key: %s", err)
+	}
+	ioutil.WriteFile(filepath.Join(dir, name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0600)
+	ioutil.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	return cert, key
+}
+
+func newTestFiles(t *testing.T) *testFiles {
+	dir, err := ioutil.TempDir("", "ordererTLS")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+
+	ca, caKey := writeTestCert(t, dir, "ca", &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	writeTestCert(t, dir, "server", &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "orderer"},
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	writeTestCert(t, dir, "client", &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "peer0", Organization: []string{"org1"}},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	// A client certificate from a CA the server does not trust
+	writeTestCert(t, dir, "other", &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "intruder"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, nil, nil)
+
+	path := func(name string) string { return filepath.Join(dir, name) }
+	return &testFiles{
+		dir:             dir,
+		caCert:          path("ca.pem"),
+		serverCert:      path("server.pem"),
+		serverKey:       path("server.key"),
+		clientCert:      path("client.pem"),
+		clientKey:       path("client.key"),
+		otherClientCert: path("other.pem"),
+		otherClientKey:  path("other.key"),
+	}
+}
+
+// identityServer reports the identity of the client of each Deliver stream
+type identityServer struct {
+	identities chan string
+}
+
+func (s *identityServer) Broadcast(srv ab.AtomicBroadcast_BroadcastServer) error {
+	return nil
+}
+
+func (s *identityServer) Deliver(srv ab.AtomicBroadcast_DeliverServer) error {
+	s.identities <- ClientIdentity(srv.Context())
+	return nil
+}
+
+func startTestServer(t *testing.T, creds credentials.TransportCredentials) (string, *identityServer, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error listening: %s", err)
+	}
+	srv := grpc.NewServer(grpc.Creds(creds), grpc.StreamInterceptor(LogClientIdentity))
+	is := &identityServer{identities: make(chan string, 1)}
+	ab.RegisterAtomicBroadcastServer(srv, is)
+	go srv.Serve(lis)
+	return lis.Addr().String(), is, srv.Stop
+}
+
+// clientIdentity opens a Deliver stream and returns the identity the server saw,
+// or the empty string if the stream could not be established
+func clientIdentity(t *testing.T, addr string, is *identityServer, creds credentials.TransportCredentials) string {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds), grpc.WithBlock(), grpc.WithTimeout(time.Second))
+	if err != nil {
+		return ""
+	}
+	defer conn.Close()
+
+	stream, err := ab.NewAtomicBroadcastClient(conn).Deliver(context.Background())
+	if err != nil {
+		return ""
+	}
+	stream.Recv()
+
+	select {
+	case id := <-is.identities:
+		return id
+	case <-time.After(time.Second):
+		return ""
+	}
+}
+
+func TestClientAuthRequired(t *testing.T) {
+	files := newTestFiles(t)
+	defer os.RemoveAll(files.dir)
+
+	serverCreds, err := NewServerCredentials(files.serverCert, files.serverKey, []string{files.caCert}, ClientAuthRequired)
+	if err != nil {
+		t.Fatalf("Error creating server credentials: %s", err)
+	}
+	addr, is, stop := startTestServer(t, serverCreds)
+	defer stop()
+
+	clientCreds, err := NewClientCredentials([]string{files.caCert}, files.clientCert, files.clientKey, "")
+	if err != nil {
+		t.Fatalf("Error creating client credentials: %s", err)
+	}
+	if id := clientIdentity(t, addr, is, clientCreds); !strings.HasPrefix(id, "peer0 (O=[org1]") {
+		t.Fatalf("Expected the client to be authenticated as peer0, got %q", id)
+	}
+
+	anonymousCreds, _ := NewClientCredentials([]string{files.caCert}, "", "", "")
+	if id := clientIdentity(t, addr, is, anonymousCreds); id != "" {
+		t.Fatalf("A client without certificate should have been rejected, got %q", id)
+	}
+
+	untrustedCreds, _ := NewClientCredentials([]string{files.caCert}, files.otherClientCert, files.otherClientKey, "")
+	if id := clientIdentity(t, addr, is, untrustedCreds); id != "" {
+		t.Fatalf("A client with an untrusted certificate should have been rejected, got %q", id)
+	}
+}
+
+func TestClientAuthOptional(t *testing.T) {
+	files := newTestFiles(t)
+	defer os.RemoveAll(files.dir)
+
+	serverCreds, err := NewServerCredentials(files.serverCert, files.serverKey, []string{files.caCert}, ClientAuthOptional)
+	if err != nil {
+		t.Fatalf("Error creating server credentials: %s", err)
+	}
+	addr, is, stop := startTestServer(t, serverCreds)
+	defer stop()
+
+	clientCreds, _ := NewClientCredentials([]string{files.caCert}, files.clientCert, files.clientKey, "")
+	if id := clientIdentity(t, addr, is, clientCreds); !strings.HasPrefix(id, "peer0") {
+		t.Fatalf("Expected the client to be authenticated as peer0, got %q", id)
+	}
+
+	anonymousCreds, _ := NewClientCredentials([]string{files.caCert}, "", "", "")
+	if id := clientIdentity(t, addr, is, anonymousCreds); !strings.HasPrefix(id, "unauthenticated client") {
+		t.Fatalf("Expected an unauthenticated client, got %q", id)
+	}
+
+	// The server is verified by the client
+	otherCreds, _ := NewClientCredentials([]string{files.otherClientCert}, "", "", "")
+	if id := clientIdentity(t, addr, is, otherCreds); id != "" {
+		t.Fatalf("The client should not have trusted the server, got %q", id)
+	}
+}
+
+func TestInvalidServerCredentials(t *testing.T) {
+	files := newTestFiles(t)
+	defer os.RemoveAll(files.dir)
+
+	if _, err := NewServerCredentials(files.serverCert, files.serverKey, nil, ClientAuthRequired); err == nil {
+		t.Fatal("Client authentication without client root CAs should fail")
+	}
+	if _, err := NewServerCredentials(files.serverCert, files.serverKey, []string{files.caCert}, "sometimes"); err == nil {
+		t.Fatal("An unknown client authentication mode should fail")
+	}
+	if _, err := NewServerCredentials(files.serverCert, files.clientKey, nil, ClientAuthNone); err == nil {
+		t.Fatal("A mismatched certificate and key should fail")
+	}
+	if _, err := NewServerCredentials(files.serverCert, files.serverKey, []string{files.serverKey}, ClientAuthRequired); err == nil {
+		t.Fatal("A client root CA file without certificate should fail")
+	}
+}
@@ -17,8 +17,12 @@ limitations under the License.
 package committer
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
 )
 
@@ -89,3 +93,44 @@ func (lc *LedgerCommitter) GetBlocks(blockSeqs []uint64) []*pb.Block2 {
 func (lc *LedgerCommitter) Close() {
 	lc.ledger.Close()
 }
+
+// ToBlock2 returns the block to commit for a block of the ordering service. It
+// holds the transactions of the block which are properly formed, properly signed
+// and in which the security chain binding proposal to endorsements to tx holds.
+// The validity of the endorsements is checked when the block is committed. The
+// block of the ordering service is kept with it, to be served to the other peers
+func ToBlock2(block *common.Block) *pb.Block2 {
+	block2 := &pb.Block2{}
+	ordererBlock, err := proto.Marshal(block)
+	if err != nil {
+		logger.Errorf("Cannot marshal block %s", err)
+	} else {
+		block2.OrdererBlock = ordererBlock
+	}
+	if block.Data == nil {
+		return block2
+	}
+	for _, d := range block.Data.Data {
+		if d == nil {
+			logger.Warningf("Nil tx in block %d", block.Header.Number)
+			continue
+		}
+		env, err := putils.GetEnvelopeFromBlock(d)
+		if err != nil {
+			logger.Errorf("Error getting tx from block(%s)", err)
+			continue
+		}
+		if _, err = peer.ValidateTransaction(env); err != nil {
+			// TODO: this code needs to receive a bit more attention and discussion: it's not clear what it means if a transaction which causes a failure in validation is just dropped on the floor
+			logger.Errorf("Invalid transaction, error %s", err)
+			continue
+		}
+		t, err := proto.Marshal(env)
+		if err != nil {
+			logger.Errorf("Cannot marshal transaction %s", err)
+			continue
+		}
+		block2.Transactions = append(block2.Transactions, t)
+	}
+	return block2
+}
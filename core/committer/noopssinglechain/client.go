@@ -17,23 +17,27 @@ limitations under the License.
 package noopssinglechain
 
 import (
+	"bytes"
 	"fmt"
-	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/committer"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	gproto "github.com/hyperledger/fabric/gossip/proto"
+	"github.com/hyperledger/fabric/gossip/state"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/blocksig"
+	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
-	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
-
-	"github.com/hyperledger/fabric/core/peer"
-	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 var logger *logging.Logger // package-level logger
@@ -49,44 +53,161 @@ type DeliverService struct {
 	windowSize     uint64
 	unAcknowledged uint64
 	committer      *committer.LedgerCommitter
+	// verifier checks the signatures of the blocks, it is created from the genesis block
+	verifier *blocksig.Verifier
+	// the chain joined with a genesis block file, and the hash of its header
+	chainID     []byte
+	genesisHash []byte
+	// the gossip component and state provider the blocks are disseminated
+	// and committed with, once StartGossip is called
+	gossip gossip.Gossip
+	state  state.GossipStateProvider
 }
 
 // NewDeliverService construction function to create and initilize
-// delivery service instance
-func NewDeliverService() *DeliverService {
+// delivery service instance of the default chain. It returns nil if the
+// committer is disabled, and an error if it is enabled but cannot be created
+func NewDeliverService() (*DeliverService, error) {
 	if viper.GetBool("peer.committer.enabled") {
 		logger.Infof("Creating committer for single noops endorser")
 
-		var opts []grpc.DialOption
-		opts = append(opts, grpc.WithInsecure())
-		opts = append(opts, grpc.WithTimeout(3*time.Second))
-		opts = append(opts, grpc.WithBlock())
-		endpoint := viper.GetString("peer.committer.ledger.orderer")
-		conn, err := grpc.Dial(endpoint, opts...)
-		if err != nil {
-			logger.Errorf("Cannot dial to %s, because of %s", endpoint, err)
-			return nil
+		// The blocks are only trusted if their signatures satisfy the policy of a
+		// genesis block known in advance, never one received from the orderer
+		genesisFile := viper.GetString("peer.committer.ledger.genesisBlock")
+		if genesisFile == "" {
+			return nil, fmt.Errorf("peer.committer.ledger.genesisBlock is not set, set it to the genesis block of the chain to join or disable peer.committer.enabled")
 		}
-		var abc orderer.AtomicBroadcast_DeliverClient
-		abc, err = orderer.NewAtomicBroadcastClient(conn).Deliver(context.TODO())
+
+		deliverService, err := newDeliverService(string(chaincode.DefaultChain), genesisFile)
 		if err != nil {
-			logger.Errorf("Unable to initialize atomic broadcast, due to %s", err)
-			return nil
+			return nil, fmt.Errorf("Cannot create the committer of the default chain, due to %s", err)
 		}
+		return deliverService, nil
+	}
+	logger.Infof("Committer disabled")
+	return nil, nil
+}
 
-		deliverService := &DeliverService{
-			// Atomic Broadcast Deliver Clienet
-			client: abc,
-			// Instance of RawLedger
-			committer:  committer.NewLedgerCommitter(kvledger.GetLedger(string(chaincode.DefaultChain))),
-			windowSize: 10,
+// NewChainDeliverServices creates the delivery services of the chains joined in addition
+// to the default chain, keyed by chain ID. There is one per genesis block file listed in
+// peer.committer.ledger.chainGenesisBlocks whose chain is in peer.chains, and the blocks of
+// each chain are committed to the ledger named after the chain as they are read from the
+// orderers. Blocks of these chains are not disseminated through gossip
+func NewChainDeliverServices() map[string]*DeliverService {
+	deliverServices := make(map[string]*DeliverService)
+	if !viper.GetBool("peer.committer.enabled") {
+		return deliverServices
+	}
+	joined := make(map[string]bool)
+	for _, chainID := range viper.GetStringSlice("peer.chains") {
+		joined[chainID] = true
+	}
+	for _, genesisFile := range viper.GetStringSlice("peer.committer.ledger.chainGenesisBlocks") {
+		genesisBlock, err := file.New(genesisFile).GenesisBlock()
+		if err != nil {
+			logger.Errorf("Cannot read the genesis block %s, due to %s", genesisFile, err)
+			continue
+		}
+		chainID, err := genesisChainID(genesisBlock)
+		if err != nil {
+			logger.Errorf("Invalid genesis block %s: %s", genesisFile, err)
+			continue
+		}
+		if !joined[chainID] || chainID == string(chaincode.DefaultChain) {
+			logger.Errorf("Genesis block %s is not of a chain of peer.chains", genesisFile)
+			continue
+		}
+		deliverService, err := newDeliverService(chainID, genesisFile)
+		if err != nil {
+			logger.Errorf("Cannot create the committer of chain %s, due to %s", chainID, err)
+			continue
 		}
-		return deliverService
+		deliverServices[chainID] = deliverService
 	}
-	logger.Infof("Committer disabled")
+	return deliverServices
+}
+
+// newDeliverService creates a delivery service committing the blocks of the chain of the
+// genesis block in genesisFile to the ledger named ledgerName
+func newDeliverService(ledgerName string, genesisFile string) (*DeliverService, error) {
+	endpoint := viper.GetString("peer.committer.ledger.orderer")
+	conn, err := comm.NewOrdererClientConnection(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot dial to %s, because of %s", endpoint, err)
+	}
+	abc, err := orderer.NewAtomicBroadcastClient(conn).Deliver(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("Unable to initialize atomic broadcast, due to %s", err)
+	}
+
+	lgr := kvledger.GetLedger(ledgerName)
+	lgr.SetTxValidator(committer.NewMSPTxValidator(&lcccPolicies{ledger: lgr}))
+
+	deliverService := &DeliverService{
+		// Atomic Broadcast Deliver Clienet
+		client: abc,
+		// Instance of RawLedger
+		committer:  committer.NewLedgerCommitter(lgr),
+		windowSize: 10,
+	}
+	if err = deliverService.joinChain(genesisFile); err != nil {
+		return nil, fmt.Errorf("Unable to join the chain of genesis block %s, due to %s", genesisFile, err)
+	}
+	return deliverService, nil
+}
+
+// lcccPolicies reads the endorsement policies recorded by LCCC in the
+// committed state of the ledger
+type lcccPolicies struct {
+	ledger *kvledger.KVLedger
+}
+
+// EndorsementPolicy implements method in interface `committer.EndorsementPolicyProvider`
+func (p *lcccPolicies) EndorsementPolicy(chainID string, ccName string) (*common.SignaturePolicyEnvelope, error) {
+	qe, err := p.ledger.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+	return chaincode.GetEndorsementPolicy(qe, chainID, ccName)
+}
+
+// joinChain reads the blocks of the chain whose genesis block, as written by the genesis
+// generation tool, is in genesisFile
+func (d *DeliverService) joinChain(genesisFile string) error {
+	genesisBlock, err := file.New(genesisFile).GenesisBlock()
+	if err != nil {
+		return err
+	}
+	chainID, err := genesisChainID(genesisBlock)
+	if err != nil {
+		return err
+	}
+	if d.verifier, err = blocksig.NewVerifierFromGenesisBlock(genesisBlock, cauthdsl.NewMSPCryptoHelper(msp.GetManager())); err != nil {
+		return fmt.Errorf("Invalid genesis block: %s", err)
+	}
+	d.chainID = []byte(chainID)
+	d.genesisHash = genesisBlock.Header.Hash()
+	logger.Infof("Joining chain %s", d.chainID)
 	return nil
 }
 
+// genesisChainID returns the ID of the chain of a genesis block
+func genesisChainID(genesisBlock *common.Block) (string, error) {
+	envelope, err := util.ExtractEnvelope(genesisBlock, 0)
+	if err != nil {
+		return "", err
+	}
+	payload, err := util.ExtractPayload(envelope)
+	if err != nil {
+		return "", err
+	}
+	if payload.Header == nil || payload.Header.ChainHeader == nil {
+		return "", fmt.Errorf("Genesis block has no chain header")
+	}
+	return string(payload.Header.ChainHeader.ChainID), nil
+}
+
 // Start the delivery service to read the block via delivery
 // protocol from the orderers
 func (d *DeliverService) Start() error {
@@ -104,6 +225,7 @@ func (d *DeliverService) seekOldest() error {
 			Seek: &orderer.SeekInfo{
 				Start:      orderer.SeekInfo_OLDEST,
 				WindowSize: d.windowSize,
+				ChainID:    d.chainID,
 			},
 		},
 	})
@@ -124,39 +246,23 @@ func (d *DeliverService) readUntilClose() {
 			}
 			fmt.Println("Got error ", t)
 		case *orderer.DeliverResponse_Block:
-			block := &pb.Block2{}
-			for _, d := range t.Block.Data.Data {
-				if d != nil {
-					if env, err := putils.GetEnvelopeFromBlock(d); err != nil {
-						fmt.Printf("Error getting tx from block(%s)\n", err)
-					} else if env != nil {
-						// validate the transaction: here we check that the transaction
-						// is properly formed, properly signed and that the security
-						// chain binding proposal to endorsements to tx holds. We do
-						// NOT check the validity of endorsements, though. That's a
-						// job for VSCC below
-						_, err := peer.ValidateTransaction(env)
-						if err != nil {
-							// TODO: this code needs to receive a bit more attention and discussion: it's not clear what it means if a transaction which causes a failure in validation is just dropped on the floor
-							logger.Errorf("Invalid transaction, error %s", err)
-						} else {
-							// TODO: call VSCC now
-							if t, err := proto.Marshal(env); err == nil {
-								block.Transactions = append(block.Transactions, t)
-							} else {
-								fmt.Printf("Cannot marshal transactoins %s\n", err)
-							}
-						}
-					} else {
-						fmt.Printf("Nil tx from block\n")
-					}
-				}
+			if err = d.verifyBlock(t.Block); err != nil {
+				logger.Errorf("Rejecting block from the ordering service: %s", err)
+				return
 			}
-			// Once block is constructed need to commit into the ledger
-			if err = d.committer.CommitBlock(block); err != nil {
-				fmt.Printf("Got error while committing(%s)\n", err)
+			if d.state != nil {
+				// The block is committed by the state provider of gossip
+				if err = d.disseminateBlock(t.Block); err != nil {
+					logger.Errorf("Got error while disseminating(%s)", err)
+				}
 			} else {
-				fmt.Printf("Commit success, created a block!\n")
+				block := committer.ToBlock2(t.Block)
+				// Once block is constructed need to commit into the ledger
+				if err = d.committer.CommitBlock(block); err != nil {
+					fmt.Printf("Got error while committing(%s)\n", err)
+				} else {
+					fmt.Printf("Commit success, created a block!\n")
+				}
 			}
 
 			d.unAcknowledged++
@@ -180,3 +286,36 @@ func (d *DeliverService) readUntilClose() {
 		}
 	}
 }
+
+// disseminateBlock gossips a block of the ordering service to the other peers,
+// and hands it to the state provider to commit. Blocks are numbered from 1 in
+// the ledger and from 0 by the ordering service
+func (d *DeliverService) disseminateBlock(block *common.Block) error {
+	data, err := proto.Marshal(block)
+	if err != nil {
+		return err
+	}
+	payload := &gproto.Payload{Data: data, SeqNum: block.Header.Number + 1}
+	d.gossip.Gossip(&gproto.GossipMessage{
+		Content: &gproto.GossipMessage_DataMsg{
+			DataMsg: &gproto.DataMessage{Payload: payload},
+		},
+	})
+	return d.state.AddPayload(payload)
+}
+
+// verifyBlock checks that the block is signed according to the orderer policy
+// of the chain. The genesis block is not signed, the orderer must deliver the
+// one the chain was joined with
+func (d *DeliverService) verifyBlock(block *common.Block) error {
+	if block.Header == nil {
+		return fmt.Errorf("Block has no header")
+	}
+	if block.Header.Number == 0 {
+		if !bytes.Equal(block.Header.Hash(), d.genesisHash) {
+			return fmt.Errorf("Genesis block does not match the one the chain was joined with")
+		}
+		return nil
+	}
+	return d.verifier.Verify(block)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noopssinglechain
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/integration"
+	"github.com/hyperledger/fabric/gossip/state"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/spf13/viper"
+)
+
+// StartGossip starts a gossip component listening on endpoint, which joins the peers at bootPeers.
+// The blocks read from the ordering service are then disseminated to the peers, and committed
+// along with those they disseminate
+func (d *DeliverService) StartGossip(endpoint string, bootPeers ...string) error {
+	mcs, err := newGossipCryptoService(d)
+	if err != nil {
+		return err
+	}
+	g, c, err := integration.NewSecureGossipComponent(endpoint, mcs, mspRootCAs, bootPeers...)
+	if err != nil {
+		return err
+	}
+	stateProvider := state.NewGossipStateProvider(g, c, d.committer, mcs)
+	if stateProvider == nil {
+		g.Stop()
+		return fmt.Errorf("Failed creating the state provider of gossip")
+	}
+	d.gossip = g
+	d.state = stateProvider
+	logger.Infof("Gossip started on %s", endpoint)
+	return nil
+}
+
+// mspRootCAs returns the root certificates of the MSPs of the peer, which
+// the TLS certificates of the peers gossip connects with must be issued by
+func mspRootCAs() *x509.CertPool {
+	pool := x509.NewCertPool()
+	msps, err := msp.GetManager().EnlistedMSPs()
+	if err != nil {
+		logger.Errorf("Failed getting the MSPs of the peer: %s", err)
+		return pool
+	}
+	for _, m := range msps {
+		for _, root := range m.GetRootCerts() {
+			raw, err := root.Serialize()
+			if err != nil {
+				continue
+			}
+			if certs, err := x509.ParseCertificates(raw); err == nil && len(certs) > 0 {
+				pool.AddCert(certs[0])
+			}
+		}
+	}
+	return pool
+}
+
+// gossipCryptoService is the api.MessageCryptoService of the gossip component of the peer.
+// The identities of the peers are their TLS certificates, validated by the MSP of the peer,
+// and the blocks are verified as the ones read from the ordering service
+type gossipCryptoService struct {
+	deliver *DeliverService
+	// the TLS certificate and key of the peer
+	cert     *x509.Certificate
+	identity api.PeerIdentityType
+	signer   crypto.Signer
+}
+
+func newGossipCryptoService(d *DeliverService) (*gossipCryptoService, error) {
+	keyPair, err := tls.LoadX509KeyPair(viper.GetString("peer.tls.cert.file"), viper.GetString("peer.tls.key.file"))
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading the TLS certificate of the peer: %s", err)
+	}
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+	signer, isSigner := keyPair.PrivateKey.(crypto.Signer)
+	if !isSigner {
+		return nil, fmt.Errorf("Unsupported TLS key type %T", keyPair.PrivateKey)
+	}
+	return &gossipCryptoService{
+		deliver:  d,
+		cert:     cert,
+		identity: api.PeerIdentityType(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+		signer:   signer,
+	}, nil
+}
+
+// GetPKIidOfCert returns the hash of the identity of a peer
+func (cs *gossipCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) gcommon.PKIidType {
+	digest := sha256.Sum256(peerIdentity)
+	return digest[:]
+}
+
+// VerifyBlock checks that a block of the ordering service is signed according to
+// the orderer policy of the chain, as the blocks read from the ordering service
+func (cs *gossipCryptoService) VerifyBlock(signedBlock api.SignedBlock) error {
+	block, isBlock := signedBlock.(*common.Block)
+	if !isBlock {
+		return fmt.Errorf("Not a block of the ordering service: %T", signedBlock)
+	}
+	return cs.deliver.verifyBlock(block)
+}
+
+// Sign signs msg with the TLS key of the peer
+func (cs *gossipCryptoService) Sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return cs.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// Verify checks that signature is a signature of message by the TLS key of the peer of peerIdentity,
+// or of this peer if peerIdentity is nil
+func (cs *gossipCryptoService) Verify(peerIdentity api.PeerIdentityType, signature, message []byte) error {
+	cert := cs.cert
+	if peerIdentity != nil {
+		var err error
+		if cert, err = identityToCert(peerIdentity); err != nil {
+			return err
+		}
+	}
+	algorithm := x509.SHA256WithRSA
+	if _, isECDSA := cert.PublicKey.(*ecdsa.PublicKey); isECDSA {
+		algorithm = x509.ECDSAWithSHA256
+	}
+	return cert.CheckSignature(algorithm, message, signature)
+}
+
+// ValidateIdentity checks that the TLS certificate of a peer is valid according to the MSP of the peer
+func (cs *gossipCryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
+	cert, err := identityToCert(peerIdentity)
+	if err != nil {
+		return err
+	}
+	id, err := msp.GetManager().DeserializeIdentity(cert.Raw)
+	if err != nil {
+		return err
+	}
+	valid, err := id.Validate()
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("The TLS certificate of the peer is not valid")
+	}
+	return nil
+}
+
+// identityToCert parses the identity of a peer, which is its PEM encoded TLS certificate
+func identityToCert(peerIdentity api.PeerIdentityType) (*x509.Certificate, error) {
+	block, _ := pem.Decode(peerIdentity)
+	if block == nil {
+		return nil, fmt.Errorf("The identity is not a PEM encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,263 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package committer
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// lcccName is the name of the lifecycle system chaincode, whose namespace holds
+// the endorsement policies
+const lcccName = "lccc"
+
+// EndorsementPolicyProvider returns the endorsement policies of the chaincodes
+type EndorsementPolicyProvider interface {
+	// EndorsementPolicy returns the endorsement policy of the chaincode on the
+	// chain, nil if the chaincode has none
+	EndorsementPolicy(chainID string, ccName string) (*common.SignaturePolicyEnvelope, error)
+}
+
+// MSPTxValidator implements `txmgmt.TxValidator`. It verifies the creator's
+// signature over the transaction and evaluates the endorsement policy of the
+// chaincodes it invokes or writes against the endorsements, verified using the
+// MSP manager. A transaction touching only chaincodes without an endorsement
+// policy is accepted if it carries at least one endorsement and all of its
+// endorsements are valid
+type MSPTxValidator struct {
+	policies EndorsementPolicyProvider
+	ch       cauthdsl.CryptoHelper
+}
+
+// NewMSPTxValidator is a factory function to create an instance of the validator
+// evaluating the endorsement policies returned by policies
+func NewMSPTxValidator(policies EndorsementPolicyProvider) *MSPTxValidator {
+	return &MSPTxValidator{policies: policies, ch: endorsementCryptoHelper{}}
+}
+
+// ValidateTx implements method in interface `txmgmt.TxValidator`
+func (v *MSPTxValidator) ValidateTx(env *common.Envelope) (bool, pb.InvalidTransaction_Cause, error) {
+	actions, err := peer.ValidateTransaction(env)
+	if err != nil {
+		logger.Debugf("Invalid transaction: %s", err)
+		return false, pb.InvalidTransaction_BadCreatorSignature, nil
+	}
+
+	// ValidateTransaction succeeded, so the payload and its header are well formed
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return false, pb.InvalidTransaction_BadCreatorSignature, nil
+	}
+
+	for _, act := range actions {
+		valid, err := v.validateEndorsements(string(payload.Header.ChainHeader.ChainID), act)
+		if err != nil {
+			return false, 0, err
+		}
+		if !valid {
+			return false, pb.InvalidTransaction_EndorsementPolicyFailure, nil
+		}
+	}
+	return true, 0, nil
+}
+
+// validateEndorsements returns true if the endorsements of act satisfy the
+// endorsement policies of the chaincodes it invokes or writes. It
+// returns an error if a policy cannot be read
+func (v *MSPTxValidator) validateEndorsements(chainID string, act *pb.TransactionAction) (bool, error) {
+	cap, err := utils.GetChaincodeActionPayload(act.Payload)
+	if err != nil {
+		logger.Debugf("Invalid chaincode action: %s", err)
+		return false, nil
+	}
+	if cap.Action == nil || len(cap.Action.Endorsements) == 0 {
+		logger.Debugf("Invalid endorsements: no endorsements")
+		return false, nil
+	}
+
+	ccNames, err := endorsedChaincodes(chainID, cap.Action)
+	if err != nil {
+		logger.Debugf("Invalid chaincode action: %s", err)
+		return false, nil
+	}
+
+	if len(ccNames) == 0 {
+		return v.verifyEndorsements(cap.Action), nil
+	}
+	for _, ccName := range ccNames {
+		valid, err := v.satisfiesPolicy(chainID, ccName, cap.Action)
+		if err != nil || !valid {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// satisfiesPolicy returns true if the endorsements of action satisfy the
+// endorsement policy of ccName, or are all valid if ccName has no policy
+func (v *MSPTxValidator) satisfiesPolicy(chainID string, ccName string, action *pb.ChaincodeEndorsedAction) (bool, error) {
+	policy, err := v.policies.EndorsementPolicy(chainID, ccName)
+	if err != nil {
+		return false, fmt.Errorf("Cannot read the endorsement policy of %s: %s", ccName, err)
+	}
+
+	if policy == nil {
+		return v.verifyEndorsements(action), nil
+	}
+
+	evaluator, err := cauthdsl.NewSignaturePolicyEvaluator(policy, v.ch)
+	if err != nil {
+		logger.Debugf("Invalid endorsement policy of %s: %s", ccName, err)
+		return false, nil
+	}
+
+	ids := make([][]byte, len(action.Endorsements))
+	signatures := make([][]byte, len(action.Endorsements))
+	for i, endorsement := range action.Endorsements {
+		ids[i] = endorsement.Endorser
+		signatures[i] = endorsement.Signature
+	}
+	if !evaluator.Authenticate(action.ProposalResponsePayload, ids, signatures) {
+		logger.Debugf("Invalid endorsements: the endorsement policy of %s is not satisfied", ccName)
+		return false, nil
+	}
+	return true, nil
+}
+
+// verifyEndorsements returns true if all the endorsements of action are valid
+func (v *MSPTxValidator) verifyEndorsements(action *pb.ChaincodeEndorsedAction) bool {
+	for _, endorsement := range action.Endorsements {
+		if !v.ch.VerifySignature(action.ProposalResponsePayload, endorsement.Endorser, endorsement.Signature) {
+			logger.Debugf("Invalid endorsements: invalid endorser or signature")
+			return false
+		}
+	}
+	return true
+}
+
+// endorsedChaincodes returns the names of the chaincodes whose endorsement
+// policies apply to the endorsed action: the chaincode of the ChaincodeAction
+// if it names one, and every namespace its read-write set writes, as the
+// endorsers choose the name. The proposal payload cannot be used as
+// transactions may only carry its hash. Only an action of LCCC may write into
+// the namespace of LCCC, and only the endorsement policies of chaincodes of
+// chainID, whose current policies must then be satisfied as well
+func endorsedChaincodes(chainID string, action *pb.ChaincodeEndorsedAction) ([]string, error) {
+	prp, err := utils.GetProposalResponsePayload(action.ProposalResponsePayload)
+	if err != nil {
+		return nil, err
+	}
+	ccAction, err := utils.GetChaincodeAction(prp.Extension)
+	if err != nil {
+		return nil, err
+	}
+	var actionCC string
+	if ccAction.ChaincodeID != nil {
+		actionCC = ccAction.ChaincodeID.Name
+	}
+
+	rwSet := &txmgmt.TxReadWriteSet{}
+	if err = rwSet.Unmarshal(ccAction.Results); err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal the read-write set: %s", err)
+	}
+	ccNames := []string{}
+	if actionCC != "" {
+		ccNames = append(ccNames, actionCC)
+	}
+	for _, nsRWSet := range rwSet.NsRWs {
+		if len(nsRWSet.Writes) == 0 {
+			continue
+		}
+		if nsRWSet.NameSpace == lcccName {
+			policyCCs, err := lcccWrites(chainID, actionCC, nsRWSet.Writes)
+			if err != nil {
+				return nil, err
+			}
+			ccNames = append(ccNames, policyCCs...)
+		}
+		if nsRWSet.NameSpace != actionCC {
+			ccNames = append(ccNames, nsRWSet.NameSpace)
+		}
+	}
+	return ccNames, nil
+}
+
+// lcccWrites checks that the writes into the namespace of LCCC come from an
+// action of LCCC, and returns the chaincodes whose endorsement policies they set
+func lcccWrites(chainID string, actionCC string, writes []*txmgmt.KVWrite) ([]string, error) {
+	if actionCC != lcccName {
+		return nil, fmt.Errorf("Chaincode action of %q writes into the namespace of %s", actionCC, lcccName)
+	}
+	ccNames := []string{}
+	for _, write := range writes {
+		policyChain, ccName, isPolicy := chaincode.EndorsementPolicyKeyNames(write.Key)
+		if !isPolicy {
+			continue
+		}
+		if policyChain != chainID {
+			return nil, fmt.Errorf("Chaincode action sets the endorsement policy of %s on chain %s", ccName, policyChain)
+		}
+		ccNames = append(ccNames, ccName)
+	}
+	return ccNames, nil
+}
+
+// endorsementCryptoHelper verifies endorsements with the MSP manager. An
+// endorser signs the proposal response payload followed by its identity
+type endorsementCryptoHelper struct{}
+
+// VerifySignature returns true if endorser is a valid identity whose signature
+// of prespBytes is signature
+func (endorsementCryptoHelper) VerifySignature(prespBytes []byte, endorser []byte, signature []byte) bool {
+	end, err := msp.GetManager().DeserializeIdentity(endorser)
+	if err != nil {
+		return false
+	}
+	if valid, err := end.Validate(); err != nil || !valid {
+		return false
+	}
+	msg := append(append([]byte{}, prespBytes...), endorser...)
+	valid, err := end.Verify(msg, signature)
+	return err == nil && valid
+}
+
+// SatisfiesPrincipal returns true if the MSP manager matches endorser against principal
+func (endorsementCryptoHelper) SatisfiesPrincipal(endorser []byte, principal *common.MSPPrincipal) bool {
+	end, err := msp.GetManager().DeserializeIdentity(endorser)
+	if err != nil {
+		return false
+	}
+	return msp.GetManager().SatisfiesPrincipal(end, principal) == nil
+}
+
+// Signer returns the certificate of endorser, so that an endorser counts once whichever
+// intermediate CAs its endorsements carry
+func (endorsementCryptoHelper) Signer(endorser []byte) []byte {
+	cert, err := msp.SignerCertificate(endorser)
+	if err != nil {
+		return endorser
+	}
+	return cert
+}
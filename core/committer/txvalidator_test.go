@@ -0,0 +1,239 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package committer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// mockPolicies returns the policies of its map, or err
+type mockPolicies struct {
+	policies map[string]*common.SignaturePolicyEnvelope
+	err      error
+}
+
+func (mp *mockPolicies) EndorsementPolicy(chainID string, ccName string) (*common.SignaturePolicyEnvelope, error) {
+	return mp.policies[chainID+"/"+ccName], mp.err
+}
+
+// mockCryptoHelper accepts a signature if it is the id of the signer followed by the message
+type mockCryptoHelper struct{}
+
+func (mockCryptoHelper) VerifySignature(msg []byte, id []byte, signature []byte) bool {
+	return bytes.Equal(signature, append(append([]byte{}, id...), msg...))
+}
+
+// endorsedAction returns the action of a transaction built with CreateSignedTx, invoking
+// ccName with results in the namespaces and endorsed by the endorsers, whose last
+// endorsement has a bad signature if tamper is set
+func endorsedAction(t *testing.T, ccName string, namespaces []string, tamper bool, endorsers ...string) *pb.TransactionAction {
+	rwSet := &txmgmt.TxReadWriteSet{}
+	for _, ns := range namespaces {
+		rwSet.NsRWs = append(rwSet.NsRWs, &txmgmt.NsReadWriteSet{NameSpace: ns, Writes: []*txmgmt.KVWrite{txmgmt.NewKVWrite("key", []byte("value"))}})
+	}
+	return endorsedResults(t, ccName, false, rwSet, tamper, endorsers...)
+}
+
+// endorsedResults returns the action of a transaction built with CreateSignedTx, invoking
+// ccName with the results of rwSet, whose ChaincodeAction names ccName if named is set
+func endorsedResults(t *testing.T, ccName string, named bool, rwSet *txmgmt.TxReadWriteSet, tamper bool, endorsers ...string) *pb.TransactionAction {
+	results, err := rwSet.Marshal()
+	if err != nil {
+		t.Fatalf("Cannot marshal the read-write set: %s", err)
+	}
+
+	signer, err := msp.NewNoopMsp().GetSigningIdentity(nil)
+	if err != nil {
+		t.Fatalf("Cannot get the noop signer: %s", err)
+	}
+	creator, err := signer.Serialize()
+	if err != nil {
+		t.Fatalf("Cannot serialize the noop signer: %s", err)
+	}
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: ccName}, CtorMsg: &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke")}}}}
+	prop, err := utils.CreateChaincodeProposal("txid", cis, creator)
+	if err != nil {
+		t.Fatalf("Cannot create the proposal: %s", err)
+	}
+
+	// a transaction needs a response, the endorsements are stripped below if there are no endorsers
+	respEndorsers := endorsers
+	if len(respEndorsers) == 0 {
+		respEndorsers = []string{"stripped"}
+	}
+	var resps []*pb.ProposalResponse
+	for _, endorser := range respEndorsers {
+		resp, err := utils.ConstructUnsignedProposalResponse(prop.Header, prop.Payload, results, nil, nil)
+		if err != nil {
+			t.Fatalf("Cannot create the proposal response: %s", err)
+		}
+		if named {
+			resp.Payload = nameChaincodeAction(t, resp.Payload, ccName)
+		}
+		resp.Endorsement = &pb.Endorsement{Endorser: []byte(endorser), Signature: append([]byte(endorser), resp.Payload...)}
+		resps = append(resps, resp)
+	}
+	if tamper {
+		resps[len(resps)-1].Endorsement.Signature = []byte("tampered")
+	}
+
+	env, err := utils.CreateSignedTx(prop, signer, resps...)
+	if err != nil {
+		t.Fatalf("Cannot create the transaction: %s", err)
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		t.Fatalf("Cannot get the payload of the transaction: %s", err)
+	}
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		t.Fatalf("Cannot get the transaction: %s", err)
+	}
+	act := tx.Actions[0]
+
+	if len(endorsers) == 0 {
+		cap, err := utils.GetChaincodeActionPayload(act.Payload)
+		if err != nil {
+			t.Fatalf("Cannot get the chaincode action payload: %s", err)
+		}
+		cap.Action.Endorsements = nil
+		if act.Payload, err = utils.GetBytesChaincodeActionPayload(cap); err != nil {
+			t.Fatalf("Cannot marshal the chaincode action payload: %s", err)
+		}
+	}
+	return act
+}
+
+// nameChaincodeAction returns the proposal response payload prp with a ChaincodeAction naming ccName
+func nameChaincodeAction(t *testing.T, prp []byte, ccName string) []byte {
+	payload, err := utils.GetProposalResponsePayload(prp)
+	if err != nil {
+		t.Fatalf("Cannot get the proposal response payload: %s", err)
+	}
+	ccAction, err := utils.GetChaincodeAction(payload.Extension)
+	if err != nil {
+		t.Fatalf("Cannot get the chaincode action: %s", err)
+	}
+	ccAction.ChaincodeID = &pb.ChaincodeID{Name: ccName}
+	if payload.Extension, err = proto.Marshal(ccAction); err != nil {
+		t.Fatalf("Cannot marshal the chaincode action: %s", err)
+	}
+	if prp, err = proto.Marshal(payload); err != nil {
+		t.Fatalf("Cannot marshal the proposal response payload: %s", err)
+	}
+	return prp
+}
+
+// writes returns a read-write set writing the keys into namespace ns
+func writes(ns string, keys ...string) *txmgmt.TxReadWriteSet {
+	nsRWSet := &txmgmt.NsReadWriteSet{NameSpace: ns}
+	for _, key := range keys {
+		nsRWSet.Writes = append(nsRWSet.Writes, txmgmt.NewKVWrite(key, []byte("value")))
+	}
+	return &txmgmt.TxReadWriteSet{NsRWs: []*txmgmt.NsReadWriteSet{nsRWSet}}
+}
+
+func TestValidateEndorsements(t *testing.T) {
+	policies := &mockPolicies{policies: map[string]*common.SignaturePolicyEnvelope{
+		"chain/both": cauthdsl.Envelope(cauthdsl.And(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)), [][]byte{[]byte("a"), []byte("b")}),
+	}}
+	v := &MSPTxValidator{policies: policies, ch: mockCryptoHelper{}}
+	both, none := []string{"both"}, []string{"none"}
+
+	tests := []struct {
+		name   string
+		chain  string
+		action *pb.TransactionAction
+		valid  bool
+	}{
+		{"policy satisfied", "chain", endorsedAction(t, "both", both, false, "a", "b"), true},
+		{"policy not satisfied", "chain", endorsedAction(t, "both", both, false, "a"), false},
+		{"policy satisfied twice by one endorser", "chain", endorsedAction(t, "both", both, false, "a", "a"), false},
+		{"bad signature of a required endorser", "chain", endorsedAction(t, "both", both, true, "a", "b"), false},
+		{"policy of another chain", "other", endorsedAction(t, "both", both, false, "a"), true},
+		{"no policy", "chain", endorsedAction(t, "none", none, false, "c"), true},
+		{"no policy and a bad signature", "chain", endorsedAction(t, "none", none, true, "c", "d"), false},
+		{"no endorsements", "chain", endorsedAction(t, "none", none, false), false},
+		{"no results", "chain", endorsedAction(t, "both", nil, false, "a"), true},
+		{"no results and a bad signature", "chain", endorsedAction(t, "both", nil, true, "a"), false},
+		{"policy of a called chaincode not satisfied", "chain", endorsedAction(t, "none", []string{"none", "both"}, false, "a"), false},
+		{"policies of all chaincodes satisfied", "chain", endorsedAction(t, "none", []string{"none", "both"}, false, "a", "b"), true},
+		{"named chaincode without policy writing another", "chain", endorsedResults(t, "none", true, writes("both", "key"), false, "a"), false},
+		{"policies of named and written chaincodes satisfied", "chain", endorsedResults(t, "both", true, writes("none", "key"), false, "a", "b"), true},
+		{"policy of named chaincode not satisfied", "chain", endorsedResults(t, "both", true, writes("none", "key"), false, "a"), false},
+		{"named chaincode writing into lccc", "chain", endorsedResults(t, "none", true, writes("lccc", "key"), false, "a"), false},
+		{"unnamed action writing into lccc", "chain", endorsedResults(t, "none", false, writes("lccc", "key"), false, "a"), false},
+		{"lccc action", "chain", endorsedResults(t, "lccc", true, writes("lccc", "key"), false, "c"), true},
+		{"lccc deploy", "chain", endorsedResults(t, "lccc", true, writes("lccc", "endorsementpolicy:new:chain"), false, "c"), true},
+		{"lccc replacing a policy not satisfied", "chain", endorsedResults(t, "lccc", true, writes("lccc", "endorsementpolicy:both:chain"), false, "a"), false},
+		{"lccc replacing a policy satisfied", "chain", endorsedResults(t, "lccc", true, writes("lccc", "endorsementpolicy:both:chain"), false, "a", "b"), true},
+		{"lccc setting a policy of another chain", "chain", endorsedResults(t, "lccc", true, writes("lccc", "endorsementpolicy:new:other"), false, "c"), false},
+	}
+	for _, test := range tests {
+		valid, err := v.validateEndorsements(test.chain, test.action)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", test.name, err)
+		}
+		if valid != test.valid {
+			t.Errorf("%s: expected valid to be %t", test.name, test.valid)
+		}
+	}
+}
+
+func TestValidateEndorsementsPolicyError(t *testing.T) {
+	v := &MSPTxValidator{policies: &mockPolicies{err: fmt.Errorf("unreadable state")}, ch: mockCryptoHelper{}}
+	if _, err := v.validateEndorsements("chain", endorsedAction(t, "cc", []string{"cc"}, false, "a")); err == nil {
+		t.Fatalf("Expected an error when the endorsement policy cannot be read")
+	}
+}
+
+func TestEndorsedChaincodesOfNamedAction(t *testing.T) {
+	rwSet := &txmgmt.TxReadWriteSet{NsRWs: []*txmgmt.NsReadWriteSet{
+		{NameSpace: "read", Reads: []*txmgmt.KVRead{txmgmt.NewKVRead("key", 1)}},
+		{NameSpace: "other", Writes: []*txmgmt.KVWrite{txmgmt.NewKVWrite("key", []byte("value"))}},
+	}}
+	results, err := rwSet.Marshal()
+	if err != nil {
+		t.Fatalf("Cannot marshal the read-write set: %s", err)
+	}
+	ccAction, err := proto.Marshal(&pb.ChaincodeAction{Results: results, ChaincodeID: &pb.ChaincodeID{Name: "cc"}})
+	if err != nil {
+		t.Fatalf("Cannot marshal the chaincode action: %s", err)
+	}
+	prp, err := proto.Marshal(&pb.ProposalResponsePayload{Extension: ccAction})
+	if err != nil {
+		t.Fatalf("Cannot marshal the proposal response payload: %s", err)
+	}
+
+	ccNames, err := endorsedChaincodes("chain", &pb.ChaincodeEndorsedAction{ProposalResponsePayload: prp})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(ccNames) != 2 || ccNames[0] != "cc" || ccNames[1] != "other" {
+		t.Fatalf("Expected the chaincode of the action and the written chaincode, got %v", ccNames)
+	}
+}
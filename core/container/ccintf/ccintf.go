@@ -21,6 +21,10 @@ package ccintf
 //Currently inproccontroller uses it. dockercontroller does not.
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"golang.org/x/net/context"
 )
@@ -47,4 +51,24 @@ type CCID struct {
 	ChaincodeSpec *pb.ChaincodeSpec
 	NetworkID     string
 	PeerID        string
+	ChainID       string
+}
+
+//GetName returns the name of the chaincode qualified with the chain and the version, so that
+//the chaincode runs in a container of its own on each chain and for each version. Chain names
+//and versions may contain any character, so the pair is qualified by its hash, which is both
+//unambiguous and valid in docker names. A chaincode of no chain and no version keeps its name
+func (ccid *CCID) GetName() string {
+	name := ccid.ChaincodeSpec.ChaincodeID.Name
+	version := ccid.ChaincodeSpec.ChaincodeID.Version
+	if ccid.ChainID == "" && version == "" {
+		return name
+	}
+	hash := sha256.New()
+	//each field is prefixed with its length, no two pairs are hashed from the same bytes
+	for _, field := range []string{ccid.ChainID, version} {
+		binary.Write(hash, binary.BigEndian, uint64(len(field)))
+		hash.Write([]byte(field))
+	}
+	return name + "-" + hex.EncodeToString(hash.Sum(nil))
 }
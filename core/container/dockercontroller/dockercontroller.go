@@ -265,13 +265,14 @@ func (vm *DockerVM) Destroy(ctxt context.Context, ccid ccintf.CCID, force bool,
 }
 
 //GetVMName generates the docker image from peer information given the hashcode. This is needed to
-//keep image name's unique in a single host, multi-peer environment (such as a development environment)
+//keep image name's unique in a single host, multi-peer environment (such as a development environment).
+//The chain and the version of the chaincode are part of the name, see ccintf.CCID.GetName
 func (vm *DockerVM) GetVMName(ccid ccintf.CCID) (string, error) {
 	if ccid.NetworkID != "" {
-		return fmt.Sprintf("%s-%s-%s", ccid.NetworkID, ccid.PeerID, ccid.ChaincodeSpec.ChaincodeID.Name), nil
+		return fmt.Sprintf("%s-%s-%s", ccid.NetworkID, ccid.PeerID, ccid.GetName()), nil
 	} else if ccid.PeerID != "" {
-		return fmt.Sprintf("%s-%s", ccid.PeerID, ccid.ChaincodeSpec.ChaincodeID.Name), nil
+		return fmt.Sprintf("%s-%s", ccid.PeerID, ccid.GetName()), nil
 	} else {
-		return ccid.ChaincodeSpec.ChaincodeID.Name, nil
+		return ccid.GetName(), nil
 	}
 }
@@ -19,13 +19,16 @@ package dockercontroller
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/fsouza/go-dockerclient"
 	"github.com/spf13/viper"
 
 	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger/testutil"
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 func TestHostConfig(t *testing.T) {
@@ -54,3 +57,29 @@ func TestGetDockerHostConfig(t *testing.T) {
 	testutil.AssertEquals(t, hostConfig.Memory, int64(1024*1024*1024*2))
 	testutil.AssertEquals(t, hostConfig.CPUShares, int64(1024*1024*1024*2))
 }
+
+func TestGetVMName(t *testing.T) {
+	vm := &DockerVM{}
+	spec := &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: "mycc", Version: "1.0"}}
+
+	name1, err := vm.GetVMName(ccintf.CCID{ChaincodeSpec: spec, NetworkID: "dev", PeerID: "vp0", ChainID: "chain1"})
+	testutil.AssertNoError(t, err, "Error getting the VM name")
+	if !regexp.MustCompile("^dev-vp0-mycc-[0-9a-f]{64}$").MatchString(name1) {
+		t.Fatalf("Unexpected VM name %s", name1)
+	}
+
+	name2, _ := vm.GetVMName(ccintf.CCID{ChaincodeSpec: spec, NetworkID: "dev", PeerID: "vp0", ChainID: "chain2"})
+	if name1 == name2 {
+		t.Fatalf("Same VM name %s on two chains", name1)
+	}
+
+	// the chain and the version are not told apart by a separator they may contain
+	name1, _ = vm.GetVMName(ccintf.CCID{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: "mycc", Version: "b-1.0"}}, ChainID: "a"})
+	name2, _ = vm.GetVMName(ccintf.CCID{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: "mycc", Version: "1.0"}}, ChainID: "a-b"})
+	if name1 == name2 {
+		t.Fatalf("Same VM name %s for two chains and versions", name1)
+	}
+
+	name, _ := vm.GetVMName(ccintf.CCID{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: "mycc"}}})
+	testutil.AssertEquals(t, name, "mycc")
+}
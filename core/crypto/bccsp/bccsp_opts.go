@@ -16,6 +16,11 @@ limitations under the License.
 
 package bccsp
 
+import (
+	"crypto/x509"
+	"time"
+)
+
 const (
 	// ECDSA Elliptic Curve Digital Signature Algorithm (key gen, import, sign, verify),
 	// at default security level (see primitives package).
@@ -40,6 +45,9 @@ const (
 	// X509Certificate Label for X509 certificate realted operation
 	X509Certificate = "X509Certificate"
 
+	// X509CertificateChain Label for X509 certificate chain related operation
+	X509CertificateChain = "X509CertificateChain"
+
 	// DefaultHash is the identifier for the default hash function (see primitives package)
 	DefaultHash = "DEFAULT_HASH"
 )
@@ -298,3 +306,36 @@ func (opts *X509PublicKeyImportOpts) Algorithm() string {
 func (opts *X509PublicKeyImportOpts) Ephemeral() bool {
 	return opts.Temporary
 }
+
+// X509ChainPublicKeyImportOpts contains options for importing public keys
+// from an x509 certificate chain. The raw material is either a sequence of
+// PEM certificates or a []*x509.Certificate, with the leaf certificate first
+// followed by the intermediate CAs. The chain is verified against Roots before
+// the public key of the leaf is imported, hence the SKI of the imported key
+// is the same as the one obtained with X509PublicKeyImportOpts.
+type X509ChainPublicKeyImportOpts struct {
+	Temporary bool
+
+	// Roots are the trusted root CAs. They must not be empty.
+	Roots []*x509.Certificate
+
+	// KeyUsages are the extended key usages the leaf certificate must
+	// allow one of. If empty any usage is accepted.
+	KeyUsages []x509.ExtKeyUsage
+
+	// CurrentTime is the time at which the chain is verified.
+	// If zero the current time is used.
+	CurrentTime time.Time
+}
+
+// Algorithm returns an identifier for the algorithm to be used
+// to generate a key.
+func (opts *X509ChainPublicKeyImportOpts) Algorithm() string {
+	return X509CertificateChain
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *X509ChainPublicKeyImportOpts) Ephemeral() bool {
+	return opts.Temporary
+}
@@ -38,6 +38,13 @@ var (
 
 	// Factories' Initialization Error
 	factoriesInitError error
+
+	// Options of the KeyStore of the default BCCSP
+	defaultKeyStoreOpts KeyStoreOpts
+
+	// Factories which are only built in with a build tag, such as the PKCS#11-based one
+	// whose provider requires cgo, they register themselves from an init function
+	optionalFactories []func() BCCSPFactory
 )
 
 // BCCSPFactory is used to get instances of the BCCSP interface.
@@ -61,6 +68,27 @@ type Opts interface {
 	Ephemeral() bool
 }
 
+// KeyStoreOpts configures the file-based KeyStore of the default BCCSP
+type KeyStoreOpts struct {
+	// Path of the folder storing the keys, the system temporary folder when empty
+	Path string
+
+	// Passphrase encrypting the secret keys, which are stored unencrypted when empty
+	Passphrase []byte
+}
+
+// SetDefaultKeyStore configures the KeyStore of the default BCCSP. It has no
+// effect once the default BCCSP has been created by GetDefault or GetBCCSP
+func SetDefaultKeyStore(opts KeyStoreOpts) {
+	defaultKeyStoreOpts = opts
+}
+
+// IsDefaultKeyStoreProtected returns true if the KeyStore of the default BCCSP is in a
+// configured folder and encrypted, so that secret keys may be kept in it
+func IsDefaultKeyStoreProtected() bool {
+	return defaultKeyStoreOpts.Path != "" && len(defaultKeyStoreOpts.Passphrase) != 0
+}
+
 // GetDefault returns a non-ephemeral (long-term) BCCSP
 func GetDefault() (bccsp.BCCSP, error) {
 	if err := initFactories(); err != nil {
@@ -102,11 +130,26 @@ func initFactoriesMap() error {
 	f := &SWFactory{}
 	factories[f.Name()] = f
 
+	for _, newFactory := range optionalFactories {
+		f := newFactory()
+		factories[f.Name()] = f
+	}
+
 	return nil
 }
 
 func createDefaultBCCSP() (bccsp.BCCSP, error) {
-	return sw.NewDefaultSecurityLevel(os.TempDir())
+	path := defaultKeyStoreOpts.Path
+	if path == "" {
+		path = os.TempDir()
+	}
+
+	ks := &sw.FileBasedKeyStore{}
+	if err := ks.Init(defaultKeyStoreOpts.Passphrase, path, false); err != nil {
+		return nil, fmt.Errorf("Failed initializing key store [%s]", err)
+	}
+
+	return sw.New(256, "SHA2", ks)
 }
 
 func getBCCSPInternal(opts Opts) (bccsp.BCCSP, error) {
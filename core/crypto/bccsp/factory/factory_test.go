@@ -32,6 +32,26 @@ func TestGetDefault(t *testing.T) {
 	}
 }
 
+func TestIsDefaultKeyStoreProtected(t *testing.T) {
+	defer SetDefaultKeyStore(defaultKeyStoreOpts)
+
+	tests := []struct {
+		opts      KeyStoreOpts
+		protected bool
+	}{
+		{KeyStoreOpts{}, false},
+		{KeyStoreOpts{Path: os.TempDir()}, false},
+		{KeyStoreOpts{Passphrase: []byte("passphrase")}, false},
+		{KeyStoreOpts{Path: os.TempDir(), Passphrase: []byte("passphrase")}, true},
+	}
+	for _, test := range tests {
+		SetDefaultKeyStore(test.opts)
+		if IsDefaultKeyStoreProtected() != test.protected {
+			t.Errorf("Expected the KeyStore with path %q and passphrase %q to be protected: %t", test.opts.Path, test.opts.Passphrase, test.protected)
+		}
+	}
+}
+
 func TestGetBCCPEphemeral(t *testing.T) {
 	ks := &sw.FileBasedKeyStore{}
 	if err := ks.Init(nil, os.TempDir(), false); err != nil {
@@ -0,0 +1,103 @@
+// +build pkcs11
+
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp"
+	"github.com/hyperledger/fabric/core/crypto/bccsp/pkcs11"
+)
+
+// The PKCS#11-based BCCSP is only available in binaries built with the pkcs11 tag,
+// as its provider requires cgo and the dynamic loader
+func init() {
+	optionalFactories = append(optionalFactories, func() BCCSPFactory { return &PKCS11Factory{} })
+}
+
+const (
+	// PKCS11BasedFactoryName is the name of the factory of the PKCS#11-based BCCSP implementation
+	PKCS11BasedFactoryName = "PKCS11"
+)
+
+// PKCS11Factory is the factory of the PKCS#11-based BCCSP.
+type PKCS11Factory struct {
+	initOnce sync.Once
+	bccsp    bccsp.BCCSP
+	err      error
+}
+
+// Name returns the name of this factory
+func (f *PKCS11Factory) Name() string {
+	return PKCS11BasedFactoryName
+}
+
+// Get returns an instance of BCCSP using Opts.
+func (f *PKCS11Factory) Get(opts Opts) (bccsp.BCCSP, error) {
+	// Validate arguments
+	if opts == nil {
+		return nil, errors.New("Invalid opts. It must not be nil.")
+	}
+
+	if opts.FactoryName() != f.Name() {
+		return nil, fmt.Errorf("Invalid Provider Name [%s]. Opts must refer to [%s].", opts.FactoryName(), f.Name())
+	}
+
+	pkcs11Opts, ok := opts.(*PKCS11Opts)
+	if !ok {
+		return nil, errors.New("Invalid opts. They must be of type PKCS11Opts.")
+	}
+
+	if !opts.Ephemeral() {
+		f.initOnce.Do(func() {
+			f.bccsp, f.err = pkcs11.New(pkcs11Opts.SecLevel, pkcs11Opts.HashFamily, pkcs11Opts.KeyStore, pkcs11Opts.Library, pkcs11Opts.Label, pkcs11Opts.Pin)
+			return
+		})
+		return f.bccsp, f.err
+	}
+
+	return pkcs11.New(pkcs11Opts.SecLevel, pkcs11Opts.HashFamily, pkcs11Opts.KeyStore, pkcs11Opts.Library, pkcs11Opts.Label, pkcs11Opts.Pin)
+}
+
+// PKCS11Opts contains options for the PKCS11Factory
+type PKCS11Opts struct {
+	Ephemeral_ bool
+	SecLevel   int
+	HashFamily string
+
+	// KeyStore holds the keys the software fallback generates or imports
+	KeyStore bccsp.KeyStore
+
+	// Library is the path of the PKCS#11 library, Label the label of the
+	// token holding the keys and Pin the user PIN of the token
+	Library string
+	Label   string
+	Pin     string
+}
+
+// FactoryName returns the name of the provider
+func (o *PKCS11Opts) FactoryName() string {
+	return PKCS11BasedFactoryName
+}
+
+// Ephemeral returns true if the CSP has to be ephemeral, false otherwise
+func (o *PKCS11Opts) Ephemeral() bool {
+	return o.Ephemeral_
+}
@@ -0,0 +1,42 @@
+// +build pkcs11
+
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package factory
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp/sw"
+)
+
+func TestGetPKCS11BCCSPInvalidOpts(t *testing.T) {
+	ks := &sw.FileBasedKeyStore{}
+	if err := ks.Init(nil, os.TempDir(), false); err != nil {
+		t.Fatalf("Failed initializing key store [%s]", err)
+	}
+
+	_, err := GetBCCSP(&PKCS11Opts{Ephemeral_: true, SecLevel: 256, HashFamily: "SHA2", KeyStore: ks, Library: "/nonexistent/libpkcs11.so", Label: "ForFabric", Pin: "98765432"})
+	if err == nil {
+		t.Fatal("Getting a PKCS#11-based BCCSP with a missing library should fail")
+	}
+
+	f := &PKCS11Factory{}
+	if _, err = f.Get(&SwOpts{Ephemeral_: true, SecLevel: 256, HashFamily: "SHA2", KeyStore: ks}); err == nil {
+		t.Fatal("Getting a PKCS#11-based BCCSP with software-based options should fail")
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pkcs11
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
+)
+
+var (
+	oidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+)
+
+// ecdsaPrivateKey is a handle to an ECDSA private key held by the token,
+// which is looked up by its SKI when signing.
+type ecdsaPrivateKey struct {
+	ski []byte
+	pub ecdsaPublicKey
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *ecdsaPrivateKey) Bytes() (raw []byte, err error) {
+	return nil, errors.New("Not supported.")
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *ecdsaPrivateKey) SKI() (ski []byte) {
+	return k.ski
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *ecdsaPrivateKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *ecdsaPrivateKey) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *ecdsaPrivateKey) PublicKey() (bccsp.Key, error) {
+	return &k.pub, nil
+}
+
+// ecdsaPublicKey is the public part of an ECDSA key pair held by the token.
+type ecdsaPublicKey struct {
+	ski    []byte
+	pubKey *ecdsa.PublicKey
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *ecdsaPublicKey) Bytes() (raw []byte, err error) {
+	raw, err = x509.MarshalPKIXPublicKey(k.pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("Failed marshalling key [%s]", err)
+	}
+	return
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *ecdsaPublicKey) SKI() (ski []byte) {
+	return k.ski
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *ecdsaPublicKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *ecdsaPublicKey) Private() bool {
+	return false
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *ecdsaPublicKey) PublicKey() (bccsp.Key, error) {
+	return k, nil
+}
+
+// namedCurveFromOID returns the curve identified by the DER encoded
+// CKA_EC_PARAMS of a key.
+func namedCurveFromOID(ecParams []byte) (elliptic.Curve, error) {
+	oid := asn1.ObjectIdentifier{}
+	if _, err := asn1.Unmarshal(ecParams, &oid); err != nil {
+		return nil, fmt.Errorf("Failed unmarshalling EC parameters [%s]", err)
+	}
+
+	switch {
+	case oid.Equal(oidNamedCurveP256):
+		return elliptic.P256(), nil
+	case oid.Equal(oidNamedCurveP384):
+		return elliptic.P384(), nil
+	default:
+		return nil, fmt.Errorf("Curve not supported [%s]", oid)
+	}
+}
+
+// oidFromNamedCurve returns the DER encoded CKA_EC_PARAMS of curve.
+func oidFromNamedCurve(curve elliptic.Curve) ([]byte, error) {
+	switch curve {
+	case elliptic.P256():
+		return asn1.Marshal(oidNamedCurveP256)
+	case elliptic.P384():
+		return asn1.Marshal(oidNamedCurveP384)
+	default:
+		return nil, fmt.Errorf("Curve not supported [%s]", curve.Params().Name)
+	}
+}
+
+// unmarshalECPoint parses the CKA_EC_POINT of a key, which tokens return
+// either as a DER octet string wrapping the uncompressed point or as the
+// uncompressed point itself.
+func unmarshalECPoint(curve elliptic.Curve, ecPoint []byte) (*ecdsa.PublicKey, error) {
+	point := ecPoint
+	var wrapped []byte
+	if rest, err := asn1.Unmarshal(ecPoint, &wrapped); err == nil && len(rest) == 0 {
+		point = wrapped
+	}
+
+	x, y := elliptic.Unmarshal(curve, point)
+	if x == nil {
+		// Not an octet string after all, try the point as is
+		x, y = elliptic.Unmarshal(curve, ecPoint)
+		if x == nil {
+			return nil, errors.New("Failed unmarshalling EC point")
+		}
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// ski returns the subject key identifier of a public key, under which the key
+// pair is stored in the token as CKA_ID. It is the hash of the PEM encoding of
+// the key, as computed by the software-based BCCSP, so that a key has the same
+// SKI whichever BCCSP holds it.
+func ski(pubKey *ecdsa.PublicKey) ([]byte, error) {
+	raw, err := primitives.PublicKeyToPEM(pubKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed marshalling key [%s]", err)
+	}
+
+	hash := sha256.New()
+	hash.Write(raw)
+	return hash.Sum(nil), nil
+}
+
+// toLowS normalises s to the lower half of the order of curve, since both
+// s and n - s verify and the lower one is the canonical signature.
+func toLowS(curve elliptic.Curve, s *big.Int) *big.Int {
+	n := curve.Params().N
+	halfOrder := new(big.Int).Rsh(n, 1)
+	if s.Cmp(halfOrder) > 0 {
+		return new(big.Int).Sub(n, s)
+	}
+	return s
+}
+
+// marshalECDSASignature converts the raw r || s signature returned by the
+// token into a DER encoded signature with a low s.
+func marshalECDSASignature(curve elliptic.Curve, raw []byte) ([]byte, error) {
+	if len(raw) == 0 || len(raw)%2 != 0 {
+		return nil, fmt.Errorf("Invalid raw signature length [%d]", len(raw))
+	}
+
+	r := new(big.Int).SetBytes(raw[:len(raw)/2])
+	s := new(big.Int).SetBytes(raw[len(raw)/2:])
+	return asn1.Marshal(primitives.ECDSASignature{R: r, S: toLowS(curve, s)})
+}
@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pkcs11
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp"
+	"github.com/hyperledger/fabric/core/crypto/bccsp/sw"
+	"github.com/op/go-logging"
+)
+
+var (
+	logger = logging.MustGetLogger("PKCS11_BCCSP")
+)
+
+// New returns a new instance of the PKCS#11-based BCCSP, which holds its ECDSA
+// keys in the token labelled label of the PKCS#11 library lib, logging in with pin.
+// Hashing, verification and the other algorithms are delegated to a software-based
+// BCCSP set at the passed security level, hash family and keystore.
+func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore, lib, label, pin string) (bccsp.BCCSP, error) {
+	var curve elliptic.Curve
+	switch securityLevel {
+	case 256:
+		curve = elliptic.P256()
+	case 384:
+		curve = elliptic.P384()
+	default:
+		return nil, fmt.Errorf("Security level not supported [%d]", securityLevel)
+	}
+
+	swCSP, err := sw.New(securityLevel, hashFamily, keyStore)
+	if err != nil {
+		return nil, fmt.Errorf("Failed initializing fallback SW BCCSP [%s]", err)
+	}
+
+	token, err := openToken(lib, label, pin)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Using PKCS#11 token [%s] of library [%s]", label, lib)
+
+	return &impl{curve: curve, token: token, sw: swCSP}, nil
+}
+
+// impl is the PKCS#11-based implementation of the BCCSP.
+type impl struct {
+	curve elliptic.Curve
+	token *token
+	sw    bccsp.BCCSP
+}
+
+// KeyGen generates a key using opts.
+// ECDSA keys are generated in the token, the other keys in software.
+func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
+	// Validate arguments
+	if opts == nil {
+		return nil, errors.New("Invalid Opts parameter. It must not be nil.")
+	}
+
+	if opts.Algorithm() == bccsp.ECDSA {
+		k, err = csp.token.generateECKey(csp.curve, opts.Ephemeral())
+		if err != nil {
+			return nil, fmt.Errorf("Failed generating ECDSA key [%s]", err)
+		}
+		return k, nil
+	}
+
+	return csp.sw.KeyGen(opts)
+}
+
+// KeyDeriv derives a key from k using opts.
+// The opts argument should be appropriate for the primitive used.
+func (csp *impl) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, err error) {
+	switch k.(type) {
+	case *ecdsaPrivateKey, *ecdsaPublicKey:
+		return nil, errors.New("Key derivation is not supported for keys held by the token.")
+	}
+
+	return csp.sw.KeyDeriv(k, opts)
+}
+
+// KeyImport imports a key from its raw representation using opts.
+// The opts argument should be appropriate for the primitive used.
+// Imported keys are held in software.
+func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.Key, err error) {
+	return csp.sw.KeyImport(raw, opts)
+}
+
+// GetKey returns the key this CSP associates to
+// the Subject Key Identifier ski, looking it up in the token first.
+func (csp *impl) GetKey(ski []byte) (k bccsp.Key, err error) {
+	key, err := csp.token.findECKey(ski)
+	if err != nil {
+		return nil, fmt.Errorf("Failed looking up key in token [%s]", err)
+	}
+	if key != nil {
+		return key, nil
+	}
+
+	return csp.sw.GetKey(ski)
+}
+
+// Hash hashes messages msg using options opts.
+func (csp *impl) Hash(msg []byte, opts bccsp.HashOpts) (hash []byte, err error) {
+	return csp.sw.Hash(msg, opts)
+}
+
+// GetHash returns and instance of hash.Hash using options opts.
+// If opts is nil then the default hash function is returned.
+func (csp *impl) GetHash(opts bccsp.HashOpts) (h hash.Hash, err error) {
+	return csp.sw.GetHash(opts)
+}
+
+// Sign signs digest using key k.
+// The opts argument should be appropriate for the primitive used.
+//
+// Note that when a signature of a hash of a larger message is needed,
+// the caller is responsible for hashing the larger message and passing
+// the hash (as digest).
+func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil.")
+	}
+	if len(digest) == 0 {
+		return nil, errors.New("Invalid digest. Cannot be empty.")
+	}
+
+	if ecdsaK, ok := k.(*ecdsaPrivateKey); ok {
+		return csp.token.signECDSA(ecdsaK, digest)
+	}
+
+	return csp.sw.Sign(k, digest, opts)
+}
+
+// Verify verifies signature against key k and digest
+// Signatures of keys held by the token are verified in software.
+func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	// Validate arguments
+	if k == nil {
+		return false, errors.New("Invalid Key. It must not be nil.")
+	}
+
+	if ecdsaK, ok := k.(*ecdsaPrivateKey); ok {
+		k = &ecdsaK.pub
+	}
+	if ecdsaK, ok := k.(*ecdsaPublicKey); ok {
+		k, err = csp.sw.KeyImport(ecdsaK.pubKey, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+		if err != nil {
+			return false, fmt.Errorf("Failed importing public key [%s]", err)
+		}
+	}
+
+	return csp.sw.Verify(k, signature, digest, opts)
+}
+
+// Encrypt encrypts plaintext using key k.
+// The opts argument should be appropriate for the primitive used.
+func (csp *impl) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) (ciphertext []byte, err error) {
+	return csp.sw.Encrypt(k, plaintext, opts)
+}
+
+// Decrypt decrypts ciphertext using key k.
+// The opts argument should be appropriate for the primitive used.
+func (csp *impl) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) (plaintext []byte, err error) {
+	return csp.sw.Decrypt(k, ciphertext, opts)
+}
@@ -0,0 +1,332 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pkcs11
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp"
+	"github.com/hyperledger/fabric/core/crypto/bccsp/sw"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
+)
+
+// The tests of the token run against SoftHSM, whose library, token label and
+// user PIN may be set with PKCS11_LIB, PKCS11_LABEL and PKCS11_PIN. The token
+// can be created with
+//
+//	softhsm2-util --init-token --slot 0 --label ForFabric --so-pin 1234 --pin 98765432
+var (
+	softHSMLibs = []string{
+		"/usr/lib/softhsm/libsofthsm2.so",
+		"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+		"/usr/local/lib/softhsm/libsofthsm2.so",
+	}
+
+	currentBCCSP bccsp.BCCSP
+	tokenErr     error
+)
+
+func TestMain(m *testing.M) {
+	lib := os.Getenv("PKCS11_LIB")
+	if lib == "" {
+		for _, path := range softHSMLibs {
+			if _, err := os.Stat(path); err == nil {
+				lib = path
+				break
+			}
+		}
+	}
+	label := os.Getenv("PKCS11_LABEL")
+	if label == "" {
+		label = "ForFabric"
+	}
+	pin := os.Getenv("PKCS11_PIN")
+	if pin == "" {
+		pin = "98765432"
+	}
+
+	if lib == "" {
+		tokenErr = fmt.Errorf("SoftHSM not found, set PKCS11_LIB")
+	} else {
+		ks := &sw.FileBasedKeyStore{}
+		if tokenErr = ks.Init(nil, os.TempDir(), false); tokenErr == nil {
+			currentBCCSP, tokenErr = New(256, "SHA2", ks, lib, label, pin)
+		}
+	}
+
+	os.Exit(m.Run())
+}
+
+func getBCCSP(t *testing.T) bccsp.BCCSP {
+	if tokenErr != nil {
+		t.Skipf("Skipping test of the token [%s]", tokenErr)
+	}
+	return currentBCCSP
+}
+
+func TestInvalidNew(t *testing.T) {
+	ks := &sw.FileBasedKeyStore{}
+	if err := ks.Init(nil, os.TempDir(), false); err != nil {
+		t.Fatalf("Failed initializing key store [%s]", err)
+	}
+
+	if _, err := New(256, "SHA2", ks, "", "ForFabric", "98765432"); err == nil {
+		t.Fatal("Error should be different from nil in this case")
+	}
+	if _, err := New(256, "SHA2", ks, "/nonexistent/libpkcs11.so", "ForFabric", "98765432"); err == nil {
+		t.Fatal("Error should be different from nil in this case")
+	}
+	if _, err := New(128, "SHA2", ks, "/nonexistent/libpkcs11.so", "ForFabric", "98765432"); err == nil {
+		t.Fatal("Error should be different from nil in this case")
+	}
+}
+
+func TestToLowS(t *testing.T) {
+	curve := elliptic.P256()
+	n := curve.Params().N
+	halfOrder := new(big.Int).Rsh(n, 1)
+
+	low := new(big.Int).Sub(halfOrder, big.NewInt(1))
+	if toLowS(curve, low).Cmp(low) != 0 {
+		t.Fatal("A low s should be left unchanged")
+	}
+
+	high := new(big.Int).Add(halfOrder, big.NewInt(1))
+	if toLowS(curve, high).Cmp(new(big.Int).Sub(n, high)) != 0 {
+		t.Fatal("A high s should be replaced by n - s")
+	}
+}
+
+func TestMarshalECDSASignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed generating ECDSA key [%s]", err)
+	}
+	digest := make([]byte, 32)
+
+	// Sign until getting a high s, as a token would return
+	var r, s *big.Int
+	for {
+		r, s, err = ecdsa.Sign(rand.Reader, key, digest)
+		if err != nil {
+			t.Fatalf("Failed signing [%s]", err)
+		}
+		if s.Cmp(new(big.Int).Rsh(key.Params().N, 1)) > 0 {
+			break
+		}
+	}
+	raw := make([]byte, 64)
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	copy(raw[32-len(rBytes):32], rBytes)
+	copy(raw[64-len(sBytes):], sBytes)
+
+	signature, err := marshalECDSASignature(key.Curve, raw)
+	if err != nil {
+		t.Fatalf("Failed marshalling signature [%s]", err)
+	}
+	ecdsaSignature := new(primitives.ECDSASignature)
+	if _, err = asn1.Unmarshal(signature, ecdsaSignature); err != nil {
+		t.Fatalf("Failed unmarshalling signature [%s]", err)
+	}
+	if ecdsaSignature.S.Cmp(s) == 0 {
+		t.Fatal("The signature should have a low s")
+	}
+	if !ecdsa.Verify(&key.PublicKey, digest, ecdsaSignature.R, ecdsaSignature.S) {
+		t.Fatal("The low-S signature should be valid")
+	}
+
+	if _, err = marshalECDSASignature(key.Curve, raw[:63]); err == nil {
+		t.Fatal("Error should be different from nil in this case")
+	}
+}
+
+func TestECParametersAndPoint(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		ecParams, err := oidFromNamedCurve(curve)
+		if err != nil {
+			t.Fatalf("Failed marshalling curve [%s]", err)
+		}
+		parsed, err := namedCurveFromOID(ecParams)
+		if err != nil || parsed != curve {
+			t.Fatalf("Failed parsing curve [%s]", err)
+		}
+
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatalf("Failed generating ECDSA key [%s]", err)
+		}
+		point := elliptic.Marshal(curve, key.X, key.Y)
+		wrapped, err := asn1.Marshal(point)
+		if err != nil {
+			t.Fatalf("Failed marshalling point [%s]", err)
+		}
+		for _, ecPoint := range [][]byte{point, wrapped} {
+			pubKey, err := unmarshalECPoint(curve, ecPoint)
+			if err != nil || pubKey.X.Cmp(key.X) != 0 || pubKey.Y.Cmp(key.Y) != 0 {
+				t.Fatalf("Failed parsing point [%s]", err)
+			}
+		}
+	}
+
+	if _, err := oidFromNamedCurve(elliptic.P224()); err == nil {
+		t.Fatal("Error should be different from nil in this case")
+	}
+}
+
+func TestSKIMatchesSoftware(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed generating ECDSA key [%s]", err)
+	}
+
+	ks := &sw.FileBasedKeyStore{}
+	if err := ks.Init(nil, os.TempDir(), false); err != nil {
+		t.Fatalf("Failed initializing key store [%s]", err)
+	}
+	csp, err := sw.New(256, "SHA2", ks)
+	if err != nil {
+		t.Fatalf("Failed initializing software-based BCCSP [%s]", err)
+	}
+	swKey, err := csp.KeyImport(&key.PublicKey, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		t.Fatalf("Failed importing ECDSA public key [%s]", err)
+	}
+
+	p11SKI, err := ski(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed computing SKI [%s]", err)
+	}
+	if !bytes.Equal(p11SKI, swKey.SKI()) {
+		t.Fatal("The SKI of a key should be the same in the PKCS#11-based and the software-based BCCSP")
+	}
+}
+
+func TestECDSAKeyGenSignVerify(t *testing.T) {
+	csp := getBCCSP(t)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: false})
+	if err != nil {
+		t.Fatalf("Failed generating ECDSA key [%s]", err)
+	}
+	if !k.Private() || k.Symmetric() {
+		t.Fatal("Failed generating ECDSA key. Key should be private and asymmetric")
+	}
+	if _, err = k.Bytes(); err == nil {
+		t.Fatal("The private key should not be exportable")
+	}
+
+	digest, err := csp.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+	if err != nil {
+		t.Fatalf("Failed computing HASH [%s]", err)
+	}
+
+	signature, err := csp.Sign(k, digest, nil)
+	if err != nil {
+		t.Fatalf("Failed generating ECDSA signature [%s]", err)
+	}
+	ecdsaSignature := new(primitives.ECDSASignature)
+	if _, err = asn1.Unmarshal(signature, ecdsaSignature); err != nil {
+		t.Fatalf("Failed unmarshalling signature [%s]", err)
+	}
+	if ecdsaSignature.S.Cmp(new(big.Int).Rsh(elliptic.P256().Params().N, 1)) > 0 {
+		t.Fatal("The signature should have a low s")
+	}
+
+	pk, err := k.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed getting public key [%s]", err)
+	}
+	for _, key := range []bccsp.Key{k, pk} {
+		valid, err := csp.Verify(key, signature, digest, nil)
+		if err != nil || !valid {
+			t.Fatalf("Failed verifying ECDSA signature [%s]", err)
+		}
+	}
+
+	digest[0]++
+	if valid, _ := csp.Verify(pk, signature, digest, nil); valid {
+		t.Fatal("The signature of another digest should not be valid")
+	}
+}
+
+func TestECDSAGetKey(t *testing.T) {
+	csp := getBCCSP(t)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: false})
+	if err != nil {
+		t.Fatalf("Failed generating ECDSA key [%s]", err)
+	}
+
+	k2, err := csp.GetKey(k.SKI())
+	if err != nil {
+		t.Fatalf("Failed getting ECDSA key [%s]", err)
+	}
+	if !k2.Private() || !bytes.Equal(k.SKI(), k2.SKI()) {
+		t.Fatal("The key should be the private key with the same SKI")
+	}
+
+	pk, _ := k.PublicKey()
+	pk2, _ := k2.PublicKey()
+	raw, _ := pk.Bytes()
+	raw2, _ := pk2.Bytes()
+	if !bytes.Equal(raw, raw2) {
+		t.Fatal("The public keys should be the same")
+	}
+
+	digest, _ := csp.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+	signature, err := csp.Sign(k2, digest, nil)
+	if err != nil {
+		t.Fatalf("Failed generating ECDSA signature [%s]", err)
+	}
+	if valid, err := csp.Verify(pk, signature, digest, nil); err != nil || !valid {
+		t.Fatalf("Failed verifying ECDSA signature [%s]", err)
+	}
+
+	if _, err = csp.GetKey([]byte("not a known SKI")); err == nil {
+		t.Fatal("Error should be different from nil in this case")
+	}
+}
+
+func TestSoftwareFallback(t *testing.T) {
+	csp := getBCCSP(t)
+
+	k, err := csp.KeyGen(&bccsp.AESKeyGenOpts{Temporary: false})
+	if err != nil {
+		t.Fatalf("Failed generating AES key [%s]", err)
+	}
+
+	k2, err := csp.GetKey(k.SKI())
+	if err != nil || !bytes.Equal(k.SKI(), k2.SKI()) {
+		t.Fatalf("Failed getting AES key from the software keystore [%s]", err)
+	}
+
+	ciphertext, err := csp.Encrypt(k, []byte("Hello World"), &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		t.Fatalf("Failed encrypting [%s]", err)
+	}
+	plaintext, err := csp.Decrypt(k, ciphertext, &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil || string(plaintext) != "Hello World" {
+		t.Fatalf("Failed decrypting [%s]", err)
+	}
+}
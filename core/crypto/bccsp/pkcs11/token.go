@@ -0,0 +1,276 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pkcs11
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/pkcs11"
+)
+
+// sessionCacheSize is the number of idle sessions kept open with the token
+const sessionCacheSize = 10
+
+// token is a PKCS#11 token holding ECDSA key pairs, identified by their SKI
+// which is stored as the CKA_ID of both the public and the private key.
+type token struct {
+	ctx      *pkcs11.Ctx
+	slot     uint
+	sessions chan pkcs11.SessionHandle
+}
+
+// openToken loads the PKCS#11 library lib and logs in with pin to the token labelled label.
+func openToken(lib, label, pin string) (*token, error) {
+	if lib == "" {
+		return nil, errors.New("Invalid PKCS#11 library. It must not be empty.")
+	}
+
+	ctx := pkcs11.New(lib)
+	if ctx == nil {
+		return nil, fmt.Errorf("Failed loading PKCS#11 library [%s]", lib)
+	}
+	if err := ctx.Initialize(); err != nil && err != pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		return nil, fmt.Errorf("Failed initializing PKCS#11 library [%s]", err)
+	}
+
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing PKCS#11 slots [%s]", err)
+	}
+
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil || strings.TrimSpace(info.Label) != label {
+			continue
+		}
+
+		t := &token{ctx: ctx, slot: slot, sessions: make(chan pkcs11.SessionHandle, sessionCacheSize)}
+		session, err := t.getSession()
+		if err != nil {
+			return nil, err
+		}
+		defer t.returnSession(session)
+
+		// Logging in a session logs in all the sessions of the application
+		err = ctx.Login(session, pkcs11.CKU_USER, pin)
+		if err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+			return nil, fmt.Errorf("Failed logging in to token [%s] [%s]", label, err)
+		}
+
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("Could not find token with label [%s]", label)
+}
+
+func (t *token) getSession() (pkcs11.SessionHandle, error) {
+	select {
+	case session := <-t.sessions:
+		return session, nil
+	default:
+		session, err := t.ctx.OpenSession(t.slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+		if err != nil {
+			return 0, fmt.Errorf("Failed opening PKCS#11 session [%s]", err)
+		}
+		return session, nil
+	}
+}
+
+func (t *token) returnSession(session pkcs11.SessionHandle) {
+	select {
+	case t.sessions <- session:
+	default:
+		t.ctx.CloseSession(session)
+	}
+}
+
+// generateECKey generates an ECDSA key pair on curve in the token. Ephemeral
+// key pairs are session objects, which the token destroys with the session.
+func (t *token) generateECKey(curve elliptic.Curve, ephemeral bool) (*ecdsaPrivateKey, error) {
+	ecParams, err := oidFromNamedCurve(curve)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := t.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer t.returnSession(session)
+
+	// The key pair is identified by a random ID until its SKI is known
+	tempID := make([]byte, 16)
+	if _, err = rand.Read(tempID); err != nil {
+		return nil, fmt.Errorf("Failed generating key ID [%s]", err)
+	}
+
+	publicTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, ecParams),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, tempID),
+	}
+	privateTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, tempID),
+	}
+
+	pub, priv, err := t.ctx.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		publicTemplate, privateTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating ECDSA key in token [%s]", err)
+	}
+
+	pubKey, err := t.publicKey(session, pub)
+	if err != nil {
+		return nil, err
+	}
+
+	// Identify the key pair by its SKI
+	ski, err := ski(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	id := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, hex.EncodeToString(ski)),
+	}
+	for _, object := range []pkcs11.ObjectHandle{pub, priv} {
+		if err = t.ctx.SetAttributeValue(session, object, id); err != nil {
+			return nil, fmt.Errorf("Failed setting the SKI of the key [%s]", err)
+		}
+	}
+
+	return &ecdsaPrivateKey{ski: ski, pub: ecdsaPublicKey{ski: ski, pubKey: pubKey}}, nil
+}
+
+// findECKey returns the ECDSA key pair of the token with the given SKI, or nil if there is none.
+func (t *token) findECKey(ski []byte) (*ecdsaPrivateKey, error) {
+	session, err := t.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer t.returnSession(session)
+
+	if _, found, err := t.findObject(session, pkcs11.CKO_PRIVATE_KEY, ski); err != nil || !found {
+		return nil, err
+	}
+	pub, found, err := t.findObject(session, pkcs11.CKO_PUBLIC_KEY, ski)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("Public key of private key [%x] not found in token", ski)
+	}
+
+	pubKey, err := t.publicKey(session, pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsaPrivateKey{ski: ski, pub: ecdsaPublicKey{ski: ski, pubKey: pubKey}}, nil
+}
+
+// signECDSA signs digest with the private key k, returning a DER encoded low-S signature.
+func (t *token) signECDSA(k *ecdsaPrivateKey, digest []byte) ([]byte, error) {
+	session, err := t.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer t.returnSession(session)
+
+	priv, found, err := t.findObject(session, pkcs11.CKO_PRIVATE_KEY, k.ski)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("Private key [%x] not found in token", k.ski)
+	}
+
+	err = t.ctx.SignInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, priv)
+	if err != nil {
+		return nil, fmt.Errorf("Failed initializing signature [%s]", err)
+	}
+	raw, err := t.ctx.Sign(session, digest)
+	if err != nil {
+		return nil, fmt.Errorf("Failed signing [%s]", err)
+	}
+
+	return marshalECDSASignature(k.pub.pubKey.Curve, raw)
+}
+
+func (t *token) findObject(session pkcs11.SessionHandle, class uint, ski []byte) (pkcs11.ObjectHandle, bool, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
+	}
+	if err := t.ctx.FindObjectsInit(session, template); err != nil {
+		return 0, false, fmt.Errorf("Failed looking up key [%s]", err)
+	}
+	objects, _, err := t.ctx.FindObjects(session, 1)
+	if finalErr := t.ctx.FindObjectsFinal(session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("Failed looking up key [%s]", err)
+	}
+	if len(objects) == 0 {
+		return 0, false, nil
+	}
+	return objects[0], true, nil
+}
+
+// publicKey reads the ECDSA public key pub from the token
+func (t *token) publicKey(session pkcs11.SessionHandle, pub pkcs11.ObjectHandle) (*ecdsa.PublicKey, error) {
+	attributes, err := t.ctx.GetAttributeValue(session, pub, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading public key [%s]", err)
+	}
+
+	var ecParams, ecPoint []byte
+	for _, attribute := range attributes {
+		switch attribute.Type {
+		case pkcs11.CKA_EC_PARAMS:
+			ecParams = attribute.Value
+		case pkcs11.CKA_EC_POINT:
+			ecPoint = attribute.Value
+		}
+	}
+
+	curve, err := namedCurveFromOID(ecParams)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalECPoint(curve, ecPoint)
+}
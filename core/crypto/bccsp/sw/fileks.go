@@ -27,7 +27,9 @@ import (
 
 	"crypto/ecdsa"
 	"crypto/rsa"
+	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"path/filepath"
 
@@ -40,7 +42,11 @@ import (
 // and flags to identity the key's type. All the keys are stored in
 // a folder whose path is provided at initialization time.
 // The KeyStore can be initialized with a password, this password
-// is used to encrypt and decrypt the files storing the keys.
+// is used to encrypt and decrypt the files storing the secret keys.
+// Secret keys are encrypted with AES-256-GCM under a key derived from the
+// password with scrypt; public keys are stored unencrypted.
+// Keys stored unencrypted, or encrypted with the legacy PEM encryption,
+// by previous versions can still be loaded.
 // A KeyStore can be read only to avoid the overwriting of keys.
 type FileBasedKeyStore struct {
 	path string
@@ -214,6 +220,162 @@ func (ks *FileBasedKeyStore) StoreKey(k bccsp.Key) (err error) {
 	return
 }
 
+// KeyInfo describes a key stored in a FileBasedKeyStore
+type KeyInfo struct {
+	// SKI is the subject key identifier of the key
+	SKI []byte
+	// Type is the suffix of the file storing the key:
+	// "sk" for private keys, "pk" for public keys and "key" for symmetric keys
+	Type string
+	// Encrypted is true if the file storing the key is encrypted
+	Encrypted bool
+}
+
+// ListKeys returns the keys stored in this KeyStore, sorted by SKI.
+func (ks *FileBasedKeyStore) ListKeys() ([]KeyInfo, error) {
+	ks.m.Lock()
+	defer ks.m.Unlock()
+
+	files, err := ioutil.ReadDir(ks.path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading KeyStore [%s]", err)
+	}
+
+	var infos []KeyInfo
+	for _, f := range files {
+		alias, suffix, ok := parseKeyFileName(f.Name())
+		if !ok || f.IsDir() {
+			continue
+		}
+
+		raw, err := ioutil.ReadFile(filepath.Join(ks.path, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading key [%s] [%s]", alias, err)
+		}
+
+		ski, _ := hex.DecodeString(alias)
+		infos = append(infos, KeyInfo{SKI: ski, Type: suffix, Encrypted: isEncryptedFile(raw)})
+	}
+
+	return infos, nil
+}
+
+// ChangePassword re-encrypts all the keys in this KeyStore with newPwd.
+// All the keys are first decrypted with the current password and written,
+// re-encrypted, to temporary files, so that nothing is modified if any of
+// them cannot be loaded or written. The temporary files then replace the
+// key files; should one of these renames fail, the keys already replaced
+// are restored, so that all the keys keep the same password.
+// Keys stored unencrypted or with the legacy encryption are migrated to
+// the current format.
+// If newPwd is empty the keys are stored unencrypted.
+func (ks *FileBasedKeyStore) ChangePassword(newPwd []byte) error {
+	if ks.readOnly {
+		return errors.New("Read only KeyStore.")
+	}
+
+	ks.m.Lock()
+	defer ks.m.Unlock()
+
+	files, err := ioutil.ReadDir(ks.path)
+	if err != nil {
+		return fmt.Errorf("Failed reading KeyStore [%s]", err)
+	}
+
+	// Decrypt everything before writing anything
+	type keyFile struct {
+		path     string
+		original []byte
+		plain    []byte
+	}
+	var keys []keyFile
+	for _, f := range files {
+		alias, suffix, ok := parseKeyFileName(f.Name())
+		if !ok || f.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(ks.path, f.Name())
+		original, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Failed reading key [%s] [%s]", alias, err)
+		}
+
+		var raw []byte
+		switch suffix {
+		case "sk":
+			key, err := ks.loadPrivateKey(alias)
+			if err != nil {
+				return fmt.Errorf("Failed loading secret key [%s] [%s]", alias, err)
+			}
+			raw, err = primitives.PrivateKeyToPEM(key, nil)
+			if err != nil {
+				return fmt.Errorf("Failed converting secret key [%s] [%s]", alias, err)
+			}
+		case "pk":
+			key, err := ks.loadPublicKey(alias)
+			if err != nil {
+				return fmt.Errorf("Failed loading public key [%s] [%s]", alias, err)
+			}
+			raw, err = primitives.PublicKeyToPEM(key, nil)
+			if err != nil {
+				return fmt.Errorf("Failed converting public key [%s] [%s]", alias, err)
+			}
+		case "key":
+			key, err := ks.loadKey(alias)
+			if err != nil {
+				return fmt.Errorf("Failed loading key [%s] [%s]", alias, err)
+			}
+			raw = primitives.AEStoPEM(key)
+		}
+		keys = append(keys, keyFile{path: path, original: original, plain: raw})
+	}
+
+	// Write the re-encrypted keys to temporary files
+	written := 0
+	removeTemporary := func() {
+		for _, key := range keys[:written] {
+			os.Remove(key.path + ".tmp")
+		}
+	}
+	for _, key := range keys {
+		raw := key.plain
+		if !strings.HasSuffix(key.path, "_pk") && len(newPwd) != 0 {
+			raw, err = encryptKeyPEM(raw, newPwd, defaultScryptParams)
+			if err != nil {
+				removeTemporary()
+				return fmt.Errorf("Failed encrypting key [%s] [%s]", filepath.Base(key.path), err)
+			}
+		}
+
+		if err = ioutil.WriteFile(key.path+".tmp", raw, 0700); err != nil {
+			removeTemporary()
+			return fmt.Errorf("Failed storing key [%s] [%s]", filepath.Base(key.path), err)
+		}
+		written++
+	}
+
+	// Replace the keys, restoring the ones already replaced if a rename fails
+	for i, key := range keys {
+		if err = os.Rename(key.path+".tmp", key.path); err != nil {
+			for _, replaced := range keys[:i] {
+				if rerr := writeFileAtomic(replaced.path, replaced.original, 0700); rerr != nil {
+					logger.Errorf("Failed restoring key [%s] [%s]", filepath.Base(replaced.path), rerr)
+				}
+			}
+			for _, pending := range keys[i:] {
+				os.Remove(pending.path + ".tmp")
+			}
+			return fmt.Errorf("Failed replacing key [%s] [%s]", filepath.Base(key.path), err)
+		}
+	}
+
+	ks.pwd = utils.Clone(newPwd)
+	logger.Infof("Re-encrypted [%d] keys in KeyStore at [%s]", len(keys), ks.path)
+
+	return nil
+}
+
 func (ks *FileBasedKeyStore) getSuffix(alias string) string {
 	files, _ := ioutil.ReadDir(ks.path)
 	for _, f := range files {
@@ -234,12 +396,18 @@ func (ks *FileBasedKeyStore) getSuffix(alias string) string {
 }
 
 func (ks *FileBasedKeyStore) storePrivateKey(alias string, privateKey interface{}) error {
-	rawKey, err := primitives.PrivateKeyToPEM(privateKey, ks.pwd)
+	rawKey, err := primitives.PrivateKeyToPEM(privateKey, nil)
 	if err != nil {
 		logger.Errorf("Failed converting private key to PEM [%s]: [%s]", alias, err)
 		return err
 	}
 
+	rawKey, err = ks.encrypt(rawKey)
+	if err != nil {
+		logger.Errorf("Failed encrypting private key [%s]: [%s]", alias, err)
+		return err
+	}
+
 	err = ioutil.WriteFile(ks.getPathForAlias(alias, "sk"), rawKey, 0700)
 	if err != nil {
 		logger.Errorf("Failed storing private key [%s]: [%s]", alias, err)
@@ -250,7 +418,7 @@ func (ks *FileBasedKeyStore) storePrivateKey(alias string, privateKey interface{
 }
 
 func (ks *FileBasedKeyStore) storePublicKey(alias string, publicKey interface{}) error {
-	rawKey, err := primitives.PublicKeyToPEM(publicKey, ks.pwd)
+	rawKey, err := primitives.PublicKeyToPEM(publicKey, nil)
 	if err != nil {
 		logger.Errorf("Failed converting public key to PEM [%s]: [%s]", alias, err)
 		return err
@@ -266,12 +434,18 @@ func (ks *FileBasedKeyStore) storePublicKey(alias string, publicKey interface{})
 }
 
 func (ks *FileBasedKeyStore) storeKey(alias string, key []byte) error {
-	pem, err := primitives.AEStoEncryptedPEM(key, ks.pwd)
+	pem, err := primitives.AEStoEncryptedPEM(key, nil)
 	if err != nil {
 		logger.Errorf("Failed converting key to PEM [%s]: [%s]", alias, err)
 		return err
 	}
 
+	pem, err = ks.encrypt(pem)
+	if err != nil {
+		logger.Errorf("Failed encrypting key [%s]: [%s]", alias, err)
+		return err
+	}
+
 	err = ioutil.WriteFile(ks.getPathForAlias(alias, "key"), pem, 0700)
 	if err != nil {
 		logger.Errorf("Failed storing key [%s]: [%s]", alias, err)
@@ -292,7 +466,14 @@ func (ks *FileBasedKeyStore) loadPrivateKey(alias string) (interface{}, error) {
 		return nil, err
 	}
 
-	privateKey, err := primitives.PEMtoPrivateKey(raw, ks.pwd)
+	raw, pwd, err := ks.decrypt(raw)
+	if err != nil {
+		logger.Errorf("Failed decrypting private key [%s]: [%s].", alias, err.Error())
+
+		return nil, err
+	}
+
+	privateKey, err := primitives.PEMtoPrivateKey(raw, pwd)
 	if err != nil {
 		logger.Errorf("Failed parsing private key [%s]: [%s].", alias, err.Error())
 
@@ -334,7 +515,14 @@ func (ks *FileBasedKeyStore) loadKey(alias string) ([]byte, error) {
 		return nil, err
 	}
 
-	key, err := primitives.PEMtoAES(pem, ks.pwd)
+	pem, pwd, err := ks.decrypt(pem)
+	if err != nil {
+		logger.Errorf("Failed decrypting key [%s]: [%s]", alias, err)
+
+		return nil, err
+	}
+
+	key, err := primitives.PEMtoAES(pem, pwd)
 	if err != nil {
 		logger.Errorf("Failed parsing key [%s]: [%s]", alias, err)
 
@@ -344,6 +532,32 @@ func (ks *FileBasedKeyStore) loadKey(alias string) ([]byte, error) {
 	return key, nil
 }
 
+// encrypt encrypts the unencrypted PEM encoding of a secret key
+// with the password of this KeyStore, if any.
+func (ks *FileBasedKeyStore) encrypt(raw []byte) ([]byte, error) {
+	if len(ks.pwd) == 0 {
+		return raw, nil
+	}
+
+	return encryptKeyPEM(raw, ks.pwd, defaultScryptParams)
+}
+
+// decrypt returns the PEM encoding of a stored key together with
+// the password that must be used to parse it. Keys in the current format
+// are decrypted here; anything else is passed through, with the password
+// of this KeyStore, for the legacy PEM decryption.
+func (ks *FileBasedKeyStore) decrypt(raw []byte) ([]byte, []byte, error) {
+	if !isEncryptedKeyPEM(raw) {
+		return raw, ks.pwd, nil
+	}
+
+	plain, err := decryptKeyPEM(raw, ks.pwd)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plain, nil, nil
+}
+
 func (ks *FileBasedKeyStore) close() error {
 	ks.isOpen = false
 	logger.Debug("Closing keystore...done!")
@@ -397,3 +611,47 @@ func (ks *FileBasedKeyStore) openKeyStore() error {
 func (ks *FileBasedKeyStore) getPathForAlias(alias, suffix string) string {
 	return filepath.Join(ks.path, alias+"_"+suffix)
 }
+
+// parseKeyFileName splits the name of a file storing a key
+// into the key's alias and the suffix identifying its type.
+func parseKeyFileName(name string) (alias, suffix string, ok bool) {
+	i := strings.LastIndex(name, "_")
+	if i <= 0 {
+		return "", "", false
+	}
+
+	alias, suffix = name[:i], name[i+1:]
+	if _, err := hex.DecodeString(alias); err != nil {
+		return "", "", false
+	}
+	switch suffix {
+	case "sk", "pk", "key":
+		return alias, suffix, true
+	}
+	return "", "", false
+}
+
+// isEncryptedFile returns true if raw is a key encrypted
+// either in the current format or with the legacy PEM encryption.
+func isEncryptedFile(raw []byte) bool {
+	if isEncryptedKeyPEM(raw) {
+		return true
+	}
+	block, _ := pem.Decode(raw)
+	return block != nil && x509.IsEncryptedPEMBlock(block)
+}
+
+// writeFileAtomic writes data to a temporary file
+// and renames it to filename.
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	tmp := filename + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, filename); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -16,10 +16,19 @@ limitations under the License.
 package sw
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
 )
 
 func TestInvalidStoreKey(t *testing.T) {
@@ -64,3 +73,229 @@ func TestInvalidStoreKey(t *testing.T) {
 		t.Fatal("Error should be different from nil in this case")
 	}
 }
+
+func newTestKeyStore(t *testing.T, pwd []byte) (*FileBasedKeyStore, string) {
+	path, err := ioutil.TempDir("", "bccspks")
+	if err != nil {
+		t.Fatalf("Failed creating temp dir [%s]", err)
+	}
+
+	ks := &FileBasedKeyStore{}
+	if err := ks.Init(pwd, path, false); err != nil {
+		t.Fatalf("Failed initiliazing KeyStore [%s]", err)
+	}
+	return ks, path
+}
+
+func storeTestKeys(t *testing.T, ks *FileBasedKeyStore) (*ecdsaPrivateKey, *aesPrivateKey) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed generating ECDSA key [%s]", err)
+	}
+	sk := &ecdsaPrivateKey{ecKey}
+
+	aesKey := &aesPrivateKey{make([]byte, 32), false}
+	rand.Read(aesKey.privKey)
+
+	for _, k := range []bccsp.Key{sk, &ecdsaPublicKey{&ecKey.PublicKey}, aesKey} {
+		if err := ks.StoreKey(k); err != nil {
+			t.Fatalf("Failed storing key [%s]", err)
+		}
+	}
+	return sk, aesKey
+}
+
+func checkTestKeys(t *testing.T, ks *FileBasedKeyStore, sk *ecdsaPrivateKey, aesKey *aesPrivateKey) {
+	k, err := ks.GetKey(sk.SKI())
+	if err != nil {
+		t.Fatalf("Failed loading ECDSA key [%s]", err)
+	}
+	if k.(*ecdsaPrivateKey).privKey.D.Cmp(sk.privKey.D) != 0 {
+		t.Fatal("Loaded ECDSA key differs from the stored one")
+	}
+
+	k, err = ks.GetKey(aesKey.SKI())
+	if err != nil {
+		t.Fatalf("Failed loading AES key [%s]", err)
+	}
+	if !bytes.Equal(k.(*aesPrivateKey).privKey, aesKey.privKey) {
+		t.Fatal("Loaded AES key differs from the stored one")
+	}
+}
+
+func TestEncryptedKeyStore(t *testing.T) {
+	ks, path := newTestKeyStore(t, []byte("passphrase"))
+	defer os.RemoveAll(path)
+
+	sk, aesKey := storeTestKeys(t, ks)
+	checkTestKeys(t, ks, sk, aesKey)
+
+	// Secret keys are encrypted, public keys are not
+	infos, err := ks.ListKeys()
+	if err != nil {
+		t.Fatalf("Failed listing keys [%s]", err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("Expected 3 keys, got %d", len(infos))
+	}
+	for _, info := range infos {
+		if info.Encrypted != (info.Type != "pk") {
+			t.Fatalf("Unexpected encryption for key [%x] of type [%s]", info.SKI, info.Type)
+		}
+	}
+
+	// A KeyStore opened with the wrong password cannot load secret keys
+	wrong := &FileBasedKeyStore{}
+	if err := wrong.Init([]byte("wrong"), path, true); err != nil {
+		t.Fatalf("Failed initiliazing KeyStore [%s]", err)
+	}
+	if _, err := wrong.GetKey(sk.SKI()); err == nil {
+		t.Fatal("Loading a key with the wrong password should fail")
+	}
+	if _, err := wrong.GetKey(aesKey.SKI()); err == nil {
+		t.Fatal("Loading a key with the wrong password should fail")
+	}
+
+	// Nor can one opened without password
+	none := &FileBasedKeyStore{}
+	if err := none.Init(nil, path, true); err != nil {
+		t.Fatalf("Failed initiliazing KeyStore [%s]", err)
+	}
+	if _, err := none.GetKey(sk.SKI()); err == nil {
+		t.Fatal("Loading an encrypted key without password should fail")
+	}
+}
+
+func TestLegacyKeys(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed generating ECDSA key [%s]", err)
+	}
+	sk := &ecdsaPrivateKey{ecKey}
+	aesKey := &aesPrivateKey{make([]byte, 32), false}
+	rand.Read(aesKey.privKey)
+
+	for _, pwd := range [][]byte{nil, []byte("passphrase")} {
+		ks, path := newTestKeyStore(t, pwd)
+
+		// Store the keys as previous versions did
+		raw, err := primitives.PrivateKeyToPEM(ecKey, pwd)
+		if err != nil {
+			t.Fatalf("Failed converting private key to PEM [%s]", err)
+		}
+		ioutil.WriteFile(ks.getPathForAlias(fmt.Sprintf("%x", sk.SKI()), "sk"), raw, 0700)
+		raw, err = primitives.AEStoEncryptedPEM(aesKey.privKey, pwd)
+		if err != nil {
+			t.Fatalf("Failed converting AES key to PEM [%s]", err)
+		}
+		ioutil.WriteFile(ks.getPathForAlias(fmt.Sprintf("%x", aesKey.SKI()), "key"), raw, 0700)
+
+		checkTestKeys(t, ks, sk, aesKey)
+
+		// Migrate them to the current format
+		if err := ks.ChangePassword([]byte("new passphrase")); err != nil {
+			t.Fatalf("Failed changing password [%s]", err)
+		}
+		checkTestKeys(t, ks, sk, aesKey)
+		raw, _ = ioutil.ReadFile(ks.getPathForAlias(fmt.Sprintf("%x", sk.SKI()), "sk"))
+		if !isEncryptedKeyPEM(raw) {
+			t.Fatal("Key should have been migrated to the current format")
+		}
+
+		os.RemoveAll(path)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	ks, path := newTestKeyStore(t, []byte("passphrase"))
+	defer os.RemoveAll(path)
+
+	sk, aesKey := storeTestKeys(t, ks)
+	if err := ks.ChangePassword([]byte("new passphrase")); err != nil {
+		t.Fatalf("Failed changing password [%s]", err)
+	}
+	checkTestKeys(t, ks, sk, aesKey)
+
+	// The old password no longer works, the new one does
+	old := &FileBasedKeyStore{}
+	old.Init([]byte("passphrase"), path, true)
+	if _, err := old.GetKey(sk.SKI()); err == nil {
+		t.Fatal("Loading a key with the old password should fail")
+	}
+	reopened := &FileBasedKeyStore{}
+	reopened.Init([]byte("new passphrase"), path, true)
+	checkTestKeys(t, reopened, sk, aesKey)
+
+	// A read only KeyStore cannot be re-encrypted
+	if err := reopened.ChangePassword([]byte("other")); err == nil {
+		t.Fatal("Changing the password of a read only KeyStore should fail")
+	}
+
+	// Nothing is modified if the current password is wrong
+	wrong := &FileBasedKeyStore{}
+	wrong.Init([]byte("wrong"), path, false)
+	if err := wrong.ChangePassword([]byte("other")); err == nil {
+		t.Fatal("Changing the password with the wrong current password should fail")
+	}
+	checkTestKeys(t, reopened, sk, aesKey)
+
+	// Removing the password stores the keys unencrypted
+	if err := ks.ChangePassword(nil); err != nil {
+		t.Fatalf("Failed removing password [%s]", err)
+	}
+	infos, _ := ks.ListKeys()
+	for _, info := range infos {
+		if info.Encrypted {
+			t.Fatalf("Key [%x] should be unencrypted", info.SKI)
+		}
+	}
+	plain := &FileBasedKeyStore{}
+	plain.Init(nil, path, true)
+	checkTestKeys(t, plain, sk, aesKey)
+}
+
+func TestChangePasswordWriteFailure(t *testing.T) {
+	ks, path := newTestKeyStore(t, []byte("passphrase"))
+	defer os.RemoveAll(path)
+
+	sk, aesKey := storeTestKeys(t, ks)
+
+	// The re-encrypted AES key cannot be written, its temporary file being a directory
+	blocked := ks.getPathForAlias(fmt.Sprintf("%x", aesKey.SKI()), "key") + ".tmp"
+	if err := os.Mkdir(blocked, 0700); err != nil {
+		t.Fatalf("Failed creating directory [%s]", err)
+	}
+	if err := ks.ChangePassword([]byte("new passphrase")); err == nil {
+		t.Fatal("Changing the password should fail if a key cannot be written")
+	}
+	os.Remove(blocked)
+
+	// No key was re-encrypted and no temporary file is left
+	reopened := &FileBasedKeyStore{}
+	reopened.Init([]byte("passphrase"), path, true)
+	checkTestKeys(t, reopened, sk, aesKey)
+	files, _ := ioutil.ReadDir(path)
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), ".tmp") {
+			t.Fatalf("Temporary file [%s] should have been removed", f.Name())
+		}
+	}
+}
+
+func TestDecryptKeyPEMTampered(t *testing.T) {
+	raw, err := encryptKeyPEM([]byte("key"), []byte("passphrase"), scryptParams{N: 1 << 10, R: 8, P: 1})
+	if err != nil {
+		t.Fatalf("Failed encrypting [%s]", err)
+	}
+
+	plain, err := decryptKeyPEM(raw, []byte("passphrase"))
+	if err != nil || string(plain) != "key" {
+		t.Fatalf("Failed decrypting [%s]", err)
+	}
+
+	// The KDF parameters are authenticated
+	tampered := bytes.Replace(raw, []byte("Scrypt-N: 1024"), []byte("Scrypt-N: 2048"), 1)
+	if _, err := decryptKeyPEM(tampered, []byte("passphrase")); err == nil {
+		t.Fatal("Decrypting with tampered parameters should fail")
+	}
+}
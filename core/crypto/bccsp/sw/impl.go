@@ -26,6 +26,7 @@ import (
 	"crypto/rsa"
 
 	"hash"
+	"time"
 
 	"crypto/x509"
 
@@ -458,6 +459,46 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 			return nil, errors.New("Certificate public key type not recognized. Supported keys: [ECDSA, RSA]")
 		}
 
+	case *bccsp.X509ChainPublicKeyImportOpts:
+		var chain []*x509.Certificate
+		switch r := raw.(type) {
+		case []byte:
+			chain, err = primitives.PEMtoCertificateChain(r)
+			if err != nil {
+				return nil, fmt.Errorf("[X509ChainPublicKeyImportOpts] Failed parsing certificate chain [%s]", err)
+			}
+		case []*x509.Certificate:
+			chain = r
+		default:
+			return nil, errors.New("[X509ChainPublicKeyImportOpts] Invalid raw material. Expected PEM byte array or []*x509.Certificate.")
+		}
+
+		if len(chain) == 0 {
+			return nil, errors.New("[X509ChainPublicKeyImportOpts] Invalid raw. It must not be empty.")
+		}
+
+		x509Opts := opts.(*bccsp.X509ChainPublicKeyImportOpts)
+		if len(x509Opts.Roots) == 0 {
+			return nil, errors.New("[X509ChainPublicKeyImportOpts] Invalid roots. They must not be empty.")
+		}
+
+		roots := x509.NewCertPool()
+		for _, root := range x509Opts.Roots {
+			roots.AddCert(root)
+		}
+
+		now := x509Opts.CurrentTime
+		if now.IsZero() {
+			now = time.Now()
+		}
+
+		_, err = primitives.CheckCertificateChain(chain, roots, x509Opts.KeyUsages, now)
+		if err != nil {
+			return nil, fmt.Errorf("Failed verifying certificate chain [%s]", err)
+		}
+
+		return csp.KeyImport(chain[0], &bccsp.X509PublicKeyImportOpts{Temporary: opts.Ephemeral()})
+
 	default:
 		return nil, errors.New("Import Key Options not recognized")
 	}
@@ -43,6 +43,7 @@ import (
 	"github.com/hyperledger/fabric/core/crypto/bccsp"
 	"github.com/hyperledger/fabric/core/crypto/bccsp/signer"
 	"github.com/hyperledger/fabric/core/crypto/primitives"
+	"github.com/hyperledger/fabric/core/crypto/primitives/testutil"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -1510,3 +1511,60 @@ func getCryptoHashIndex(t *testing.T) crypto.Hash {
 
 	return crypto.SHA3_256
 }
+
+func TestX509ChainPublicKeyImportOpts(t *testing.T) {
+	root, rootKey := testutil.NewCert(t, testutil.CATemplate("root"), nil, nil)
+	ica, icaKey := testutil.NewCert(t, testutil.CATemplate("intermediate"), root, rootKey)
+	leafTemplate := testutil.Template("leaf")
+	leafTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	leaf, leafKey := testutil.NewCert(t, leafTemplate, ica, icaKey)
+	chainPEM := primitives.CertificateChainToPEM([]*x509.Certificate{leaf, ica})
+
+	opts := &bccsp.X509ChainPublicKeyImportOpts{Temporary: true, Roots: []*x509.Certificate{root}}
+	pk, err := currentBCCSP.KeyImport(chainPEM, opts)
+	if err != nil {
+		t.Fatalf("Failed importing certificate chain [%s]", err)
+	}
+
+	// The SKI is the one of the leaf's public key, whatever the import path
+	single, err := currentBCCSP.KeyImport(leaf, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		t.Fatalf("Failed importing certificate [%s]", err)
+	}
+	if !bytes.Equal(pk.SKI(), single.SKI()) {
+		t.Fatal("SKIs of the keys imported from the chain and from the certificate differ")
+	}
+	if !bytes.Equal(pk.SKI(), (&ecdsaPublicKey{&leafKey.PublicKey}).SKI()) {
+		t.Fatal("SKI of the key imported from the chain differs from the one of the leaf key")
+	}
+
+	// Parsed certificates are accepted too
+	if _, err := currentBCCSP.KeyImport([]*x509.Certificate{leaf, ica}, opts); err != nil {
+		t.Fatalf("Failed importing parsed certificate chain [%s]", err)
+	}
+
+	// Key usages
+	opts.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	if _, err := currentBCCSP.KeyImport(chainPEM, opts); err != nil {
+		t.Fatalf("Failed importing certificate chain for client authentication [%s]", err)
+	}
+	opts.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	if _, err := currentBCCSP.KeyImport(chainPEM, opts); err == nil {
+		t.Fatal("Importing should fail for a usage the leaf does not allow")
+	}
+	opts.KeyUsages = nil
+
+	// Invalid chains
+	if _, err := currentBCCSP.KeyImport(primitives.DERCertToPEM(leaf.Raw), opts); err == nil {
+		t.Fatal("Importing should fail without the intermediate CA")
+	}
+	if _, err := currentBCCSP.KeyImport(chainPEM, &bccsp.X509ChainPublicKeyImportOpts{Temporary: true}); err == nil {
+		t.Fatal("Importing should fail without roots")
+	}
+	if _, err := currentBCCSP.KeyImport(chainPEM, &bccsp.X509ChainPublicKeyImportOpts{Temporary: true, Roots: []*x509.Certificate{ica}, CurrentTime: time.Now().Add(48 * time.Hour)}); err == nil {
+		t.Fatal("Importing should fail once expired")
+	}
+	if _, err := currentBCCSP.KeyImport(leaf, opts); err == nil {
+		t.Fatal("Importing should fail on invalid raw material")
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sw
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Keys are encrypted at rest by wrapping their unencrypted PEM encoding into
+// a PEM block of type encryptedKeyPEMType, whose content is the AES-256-GCM
+// encryption of the unencrypted PEM under a key derived from the password with
+// scrypt. The KDF parameters, salt and nonce are stored in the headers of the
+// block, and the parameters are authenticated as additional data.
+const (
+	encryptedKeyPEMType = "BCCSP ENCRYPTED KEY"
+
+	kdfHeader     = "KDF"
+	kdfScrypt     = "scrypt"
+	scryptNHeader = "Scrypt-N"
+	scryptRHeader = "Scrypt-R"
+	scryptPHeader = "Scrypt-P"
+	saltHeader    = "Salt"
+	nonceHeader   = "Nonce"
+
+	saltLength = 32
+	keyLength  = 32
+)
+
+// scryptParams are the cost parameters of scrypt
+type scryptParams struct {
+	N, R, P int
+}
+
+// defaultScryptParams are the recommended parameters for interactive logins
+var defaultScryptParams = scryptParams{N: 1 << 15, R: 8, P: 1}
+
+func (p scryptParams) additionalData() []byte {
+	return []byte(fmt.Sprintf("%s:%d:%d:%d", kdfScrypt, p.N, p.R, p.P))
+}
+
+// isEncryptedKeyPEM returns true if raw is a key encrypted by encryptKeyPEM
+func isEncryptedKeyPEM(raw []byte) bool {
+	block, _ := pem.Decode(raw)
+	return block != nil && block.Type == encryptedKeyPEMType
+}
+
+// encryptKeyPEM encrypts the unencrypted PEM encoding of a key with pwd
+func encryptKeyPEM(plain []byte, pwd []byte, params scryptParams) ([]byte, error) {
+	if len(pwd) == 0 {
+		return nil, errors.New("Invalid password. It must not be empty.")
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("Failed generating salt [%s]", err)
+	}
+
+	aead, err := newKeyAEAD(pwd, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("Failed generating nonce [%s]", err)
+	}
+
+	block := &pem.Block{
+		Type: encryptedKeyPEMType,
+		Headers: map[string]string{
+			kdfHeader:     kdfScrypt,
+			scryptNHeader: strconv.Itoa(params.N),
+			scryptRHeader: strconv.Itoa(params.R),
+			scryptPHeader: strconv.Itoa(params.P),
+			saltHeader:    hex.EncodeToString(salt),
+			nonceHeader:   hex.EncodeToString(nonce),
+		},
+		Bytes: aead.Seal(nil, nonce, plain, params.additionalData()),
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+// decryptKeyPEM decrypts a key encrypted by encryptKeyPEM, returning its unencrypted PEM encoding
+func decryptKeyPEM(raw []byte, pwd []byte) ([]byte, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != encryptedKeyPEMType {
+		return nil, errors.New("Invalid encrypted key. PEM block not found.")
+	}
+	if len(pwd) == 0 {
+		return nil, errors.New("The key is encrypted and no password was provided.")
+	}
+	if block.Headers[kdfHeader] != kdfScrypt {
+		return nil, fmt.Errorf("KDF not supported [%s]", block.Headers[kdfHeader])
+	}
+
+	var params scryptParams
+	var err error
+	for header, value := range map[string]*int{scryptNHeader: &params.N, scryptRHeader: &params.R, scryptPHeader: &params.P} {
+		if *value, err = strconv.Atoi(block.Headers[header]); err != nil {
+			return nil, fmt.Errorf("Invalid %s header [%s]", header, err)
+		}
+	}
+	salt, err := hex.DecodeString(block.Headers[saltHeader])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s header [%s]", saltHeader, err)
+	}
+	nonce, err := hex.DecodeString(block.Headers[nonceHeader])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s header [%s]", nonceHeader, err)
+	}
+
+	aead, err := newKeyAEAD(pwd, salt, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("Invalid nonce length [%d]", len(nonce))
+	}
+
+	plain, err := aead.Open(nil, nonce, block.Bytes, params.additionalData())
+	if err != nil {
+		return nil, errors.New("Failed decrypting key. Wrong password or corrupted key.")
+	}
+	return plain, nil
+}
+
+func newKeyAEAD(pwd, salt []byte, params scryptParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key(pwd, salt, params.N, params.R, params.P, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("Failed deriving key from password [%s]", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating AES cipher [%s]", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sw
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// ReadPassphrase returns the passphrase protecting a KeyStore.
+// The passphrase is taken, in order of preference, from the environment
+// variable named env, from the file at path file, or, if prompt is true,
+// by prompting the user on the terminal. Empty sources are skipped.
+// A nil passphrase is returned if none of the sources provides one.
+func ReadPassphrase(env, file string, prompt bool) ([]byte, error) {
+	if env != "" {
+		if pwd := os.Getenv(env); pwd != "" {
+			return []byte(pwd), nil
+		}
+	}
+
+	if file != "" {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading passphrase file [%s]", err)
+		}
+		if pwd := bytes.TrimRight(raw, "\r\n"); len(pwd) != 0 {
+			return pwd, nil
+		}
+	}
+
+	if prompt {
+		return PromptPassphrase("KeyStore passphrase: ")
+	}
+
+	return nil, nil
+}
+
+// PromptPassphrase prints prompt on stderr and reads
+// a passphrase from the terminal without echoing it.
+func PromptPassphrase(prompt string) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return nil, errors.New("Cannot prompt for a passphrase. Standard input is not a terminal.")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	pwd, err := terminal.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading passphrase [%s]", err)
+	}
+	return pwd, nil
+}
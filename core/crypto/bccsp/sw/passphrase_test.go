@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sw
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestReadPassphrase(t *testing.T) {
+	f, err := ioutil.TempFile("", "passphrase")
+	if err != nil {
+		t.Fatalf("Failed creating temp file [%s]", err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("from file\n")
+	f.Close()
+
+	os.Setenv("BCCSP_TEST_PASSPHRASE", "from env")
+	defer os.Unsetenv("BCCSP_TEST_PASSPHRASE")
+
+	pwd, err := ReadPassphrase("BCCSP_TEST_PASSPHRASE", f.Name(), false)
+	if err != nil || string(pwd) != "from env" {
+		t.Fatalf("Expected passphrase from env, got [%s] [%v]", pwd, err)
+	}
+
+	pwd, err = ReadPassphrase("BCCSP_TEST_UNSET", f.Name(), false)
+	if err != nil || string(pwd) != "from file" {
+		t.Fatalf("Expected passphrase from file, got [%s] [%v]", pwd, err)
+	}
+
+	pwd, err = ReadPassphrase("BCCSP_TEST_UNSET", "", false)
+	if err != nil || pwd != nil {
+		t.Fatalf("Expected no passphrase, got [%s] [%v]", pwd, err)
+	}
+
+	if _, err = ReadPassphrase("", f.Name()+".missing", false); err == nil {
+		t.Fatal("Reading a missing passphrase file should fail")
+	}
+}
@@ -3,12 +3,16 @@ package primitives
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
+	"crypto/x509"
 	"encoding/asn1"
 	"fmt"
 	"math/big"
 	"os"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/crypto/primitives/testutil"
 )
 
 type TestParameters struct {
@@ -419,3 +423,64 @@ func TestX509(t *testing.T) {
 		t.Fatalf("Checking cert vk against sk shoud failed. Invalid VK [%s]", err)
 	}
 }
+
+func TestCertificateChain(t *testing.T) {
+	root, rootKey := testutil.NewCert(t, testutil.CATemplate("root"), nil, nil)
+	ica, icaKey := testutil.NewCert(t, testutil.CATemplate("intermediate"), root, rootKey)
+	leafTemplate := testutil.Template("leaf")
+	leafTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	leaf, _ := testutil.NewCert(t, leafTemplate, ica, icaKey)
+
+	// PEM round trip
+	chain, err := PEMtoCertificateChain(CertificateChainToPEM([]*x509.Certificate{leaf, ica}))
+	if err != nil {
+		t.Fatalf("Failed converting PEM to chain [%s]", err)
+	}
+	if len(chain) != 2 || !chain[0].Equal(leaf) || !chain[1].Equal(ica) {
+		t.Fatal("Invalid chain from PEM")
+	}
+	if _, err := PEMtoCertificateChain(leaf.Raw); err == nil {
+		t.Fatal("Converting PEM to chain should fail on DER")
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	paths, err := CheckCertificateChain(chain, roots, nil, time.Now())
+	if err != nil {
+		t.Fatalf("Failed checking chain [%s]", err)
+	}
+	if len(paths) != 1 || len(paths[0]) != 3 {
+		t.Fatalf("Invalid verified chains [%v]", paths)
+	}
+
+	if _, err := CheckCertificateChain(chain, roots, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, time.Now()); err != nil {
+		t.Fatalf("Failed checking chain for client authentication [%s]", err)
+	}
+	if _, err := CheckCertificateChain(chain, roots, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, time.Now()); err == nil {
+		t.Fatal("Checking chain should fail for a usage the leaf does not allow")
+	}
+	if _, err := CheckCertificateChain(chain[:1], roots, nil, time.Now()); err == nil {
+		t.Fatal("Checking chain should fail without the intermediate CA")
+	}
+	if _, err := CheckCertificateChain(chain, roots, nil, time.Now().Add(48*time.Hour)); err == nil {
+		t.Fatal("Checking chain should fail once expired")
+	}
+
+	// An intermediate CA not allowed to sign certificates
+	badICATemplate := testutil.CATemplate("bad intermediate")
+	badICATemplate.KeyUsage = x509.KeyUsageDigitalSignature
+	badICA, badICAKey := testutil.NewCert(t, badICATemplate, root, rootKey)
+	badLeaf, _ := testutil.NewCert(t, testutil.Template("leaf"), badICA, badICAKey)
+	if _, err := CheckCertificateChain([]*x509.Certificate{badLeaf, badICA}, roots, nil, time.Now()); err == nil {
+		t.Fatal("Checking chain should fail when a CA is not allowed to sign certificates")
+	}
+
+	// A leaf not allowed to sign
+	encLeafTemplate := testutil.Template("leaf")
+	encLeafTemplate.KeyUsage = x509.KeyUsageKeyEncipherment
+	encLeaf, _ := testutil.NewCert(t, encLeafTemplate, ica, icaKey)
+	if _, err := CheckCertificateChain([]*x509.Certificate{encLeaf, ica}, roots, nil, time.Now()); err == nil {
+		t.Fatal("Checking chain should fail when the leaf is not allowed to sign")
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"encoding/asn1"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"math/big"
 	"net"
 	"time"
@@ -115,6 +116,102 @@ func DERCertToPEM(der []byte) []byte {
 	)
 }
 
+// PEMtoCertificateChain converts a sequence of PEM certificates to x509.
+// The chain is returned in the order found, that is leaf first by convention.
+func PEMtoCertificateChain(raw []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, raw = pem.Decode(raw)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" || len(block.Headers) != 0 {
+			return nil, errors.New("Not a valid CERTIFICATE PEM block")
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("No PEM block available")
+	}
+
+	return chain, nil
+}
+
+// CertificateChainToPEM converts a chain of x509 certificates to a sequence of PEM certificates
+func CertificateChainToPEM(chain []*x509.Certificate) []byte {
+	var raw []byte
+	for _, cert := range chain {
+		raw = append(raw, DERCertToPEM(cert.Raw)...)
+	}
+	return raw
+}
+
+// CheckCertificateChain checks the validity of the leaf certificate chain[0]
+// against the roots in certPool, building the path through the intermediate
+// certificates chain[1:]. Key usages are checked along the whole path:
+// the extended key usages of the leaf must allow one of keyUsages (any if empty),
+// the leaf must be allowed to sign and every CA to sign certificates.
+func CheckCertificateChain(chain []*x509.Certificate, certPool *x509.CertPool, keyUsages []x509.ExtKeyUsage, now time.Time) ([][]*x509.Certificate, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("Invalid chain. It must contain at least one certificate.")
+	}
+	if certPool == nil {
+		return nil, errors.New("Invalid roots. They must be different from nil.")
+	}
+	if len(keyUsages) == 0 {
+		keyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         certPool,
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   now,
+		KeyUsages:     keyUsages,
+	}
+	for _, cert := range chain[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	paths, err := chain[0].Verify(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The key usage extension is optional, check it only when present
+	if chain[0].KeyUsage != 0 && chain[0].KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return nil, errors.New("The certificate is not allowed to sign")
+	}
+	var valid [][]*x509.Certificate
+	err = nil
+	for _, path := range paths {
+		if err = checkCAKeyUsages(path[1:]); err == nil {
+			valid = append(valid, path)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, err
+	}
+
+	return valid, nil
+}
+
+func checkCAKeyUsages(cas []*x509.Certificate) error {
+	for _, ca := range cas {
+		if ca.KeyUsage != 0 && ca.KeyUsage&x509.KeyUsageCertSign == 0 {
+			return fmt.Errorf("The CA certificate [%s] is not allowed to sign certificates", ca.Subject.CommonName)
+		}
+	}
+	return nil
+}
+
 // GetCriticalExtension returns a requested critical extension. It also remove it from the list
 // of unhandled critical extensions
 func GetCriticalExtension(cert *x509.Certificate, oid asn1.ObjectIdentifier) ([]byte, error) {
@@ -17,6 +17,7 @@ limitations under the License.
 package endorser
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -26,8 +27,10 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
 )
@@ -47,9 +50,34 @@ func NewEndorserServer() pb.EndorserServer {
 	return e
 }
 
-//TODO - what would Endorser's ACL be ?
-func (*Endorser) checkACL(prop *pb.Proposal) error {
-	return nil
+//checkACL checks the creator of the proposal against the ACL of the invoked chaincode. System
+//chaincodes have no ACL, but an update of the ACL of a chaincode through LCCC is restricted to
+//the admins of the chaincode
+func (e *Endorser) checkACL(ctx context.Context, txid string, chainName string, signedProp *pb.SignedProposal, prop *pb.Proposal, creator []byte, cis *pb.ChaincodeInvocationSpec, txsim ledger.TxSimulator) error {
+	ccname := cis.ChaincodeSpec.ChaincodeID.Name
+	args := cis.ChaincodeSpec.CtorMsg.Args
+
+	if ccname == "lccc" && len(args) == 4 && string(args[0]) == chaincode.SETACL {
+		acl, err := e.getACLFromLCCC(ctx, txid, prop, string(args[1]), string(args[2]), txsim)
+		if err != nil {
+			return err
+		}
+		return chaincode.CheckACLAdmins(acl, signedProp, creator)
+	}
+
+	if chaincode.IsSysCC(ccname) {
+		return nil
+	}
+
+	var function string
+	if len(args) > 0 {
+		function = string(args[0])
+	}
+	acl, err := e.getACLFromLCCC(ctx, txid, prop, chainName, ccname, txsim)
+	if err != nil {
+		return err
+	}
+	return chaincode.CheckACL(acl, function, signedProp, creator)
 }
 
 //TODO - check for escc and vscc
@@ -57,6 +85,18 @@ func (*Endorser) checkEsccAndVscc(prop *pb.Proposal) error {
 	return nil
 }
 
+//the chain is resolved from the ChainID of the proposal, the default chain if it is not set
+func (*Endorser) getChainName(hdr *common.Header) (string, error) {
+	chainName := string(hdr.ChainHeader.ChainID)
+	if chainName == "" {
+		chainName = string(chaincode.DefaultChain)
+	}
+	if chaincode.GetChain(chaincode.ChainName(chainName)) == nil {
+		return "", fmt.Errorf("chain %s has not been joined by this peer", chainName)
+	}
+	return chainName, nil
+}
+
 func (*Endorser) getTxSimulator(ledgername string) (ledger.TxSimulator, error) {
 	lgr := kvledger.GetLedger(ledgername)
 	return lgr.NewTxSimulator()
@@ -64,7 +104,6 @@ func (*Endorser) getTxSimulator(ledgername string) (ledger.TxSimulator, error) {
 
 //deploy the chaincode after call to the system chaincode is successful
 func (e *Endorser) deploy(ctxt context.Context, txid string, proposal *pb.Proposal, chainname string, cds *pb.ChaincodeDeploymentSpec, cid *pb.ChaincodeID) error {
-	//TODO - create chaincode support for chainname, for now use DefaultChain
 	chaincodeSupport := chaincode.GetChain(chaincode.ChainName(chainname))
 
 	_, err := chaincodeSupport.Deploy(ctxt, cds)
@@ -85,14 +124,11 @@ func (e *Endorser) deploy(ctxt context.Context, txid string, proposal *pb.Propos
 }
 
 //call specified chaincode (system or user)
-func (e *Endorser) callChaincode(ctxt context.Context, txid string, prop *pb.Proposal, cis *pb.ChaincodeInvocationSpec, cid *pb.ChaincodeID, txsim ledger.TxSimulator) ([]byte, *pb.ChaincodeEvent, error) {
+func (e *Endorser) callChaincode(ctxt context.Context, txid string, chainName string, prop *pb.Proposal, cis *pb.ChaincodeInvocationSpec, cid *pb.ChaincodeID, txsim ledger.TxSimulator) ([]byte, *pb.ChaincodeEvent, error) {
 	var err error
 	var b []byte
 	var ccevent *pb.ChaincodeEvent
 
-	//TODO - get chainname from cis when defined
-	chainName := string(chaincode.DefaultChain)
-
 	ctxt = context.WithValue(ctxt, chaincode.TXSimulatorKey, txsim)
 	b, ccevent, err = chaincode.ExecuteChaincode(ctxt, txid, prop, chainName, cid.Name, cis.ChaincodeSpec.CtorMsg.Args)
 
@@ -108,7 +144,12 @@ func (e *Endorser) callChaincode(ctxt context.Context, txid string, prop *pb.Pro
 	//
 	//NOTE that if there's an error all simulation, including the chaincode
 	//table changes in lccc will be thrown away
-	if cid.Name == "lccc" && len(cis.ChaincodeSpec.CtorMsg.Args) == 3 && string(cis.ChaincodeSpec.CtorMsg.Args[0]) == "deploy" {
+	if cid.Name == "lccc" && len(cis.ChaincodeSpec.CtorMsg.Args) >= 3 && string(cis.ChaincodeSpec.CtorMsg.Args[0]) == "deploy" {
+		//the chaincode is deployed on the chain of the proposal, it can't be deployed on another
+		//chain with the simulator of this one
+		if string(cis.ChaincodeSpec.CtorMsg.Args[1]) != chainName {
+			return nil, nil, fmt.Errorf("cannot deploy on chain %s with a proposal for chain %s", cis.ChaincodeSpec.CtorMsg.Args[1], chainName)
+		}
 		var cds *pb.ChaincodeDeploymentSpec
 		cds, err = putils.GetChaincodeDeploymentSpec(cis.ChaincodeSpec.CtorMsg.Args[2])
 		if err != nil {
@@ -121,11 +162,18 @@ func (e *Endorser) callChaincode(ctxt context.Context, txid string, prop *pb.Pro
 	}
 	//----- END -------
 
+	//likewise the ACL of a chaincode is only replaced on the chain of the proposal
+	if cid.Name == "lccc" && len(cis.ChaincodeSpec.CtorMsg.Args) >= 2 && string(cis.ChaincodeSpec.CtorMsg.Args[0]) == "setacl" {
+		if string(cis.ChaincodeSpec.CtorMsg.Args[1]) != chainName {
+			return nil, nil, fmt.Errorf("cannot set an ACL on chain %s with a proposal for chain %s", cis.ChaincodeSpec.CtorMsg.Args[1], chainName)
+		}
+	}
+
 	return b, ccevent, err
 }
 
 //simulate the proposal by calling the chaincode
-func (e *Endorser) simulateProposal(ctx context.Context, txid string, prop *pb.Proposal, cid *pb.ChaincodeID, txsim ledger.TxSimulator) ([]byte, []byte, *pb.ChaincodeEvent, error) {
+func (e *Endorser) simulateProposal(ctx context.Context, txid string, chainName string, prop *pb.Proposal, cid *pb.ChaincodeID, txsim ledger.TxSimulator) ([]byte, []byte, *pb.ChaincodeEvent, error) {
 	//we do expect the payload to be a ChaincodeInvocationSpec
 	//if we are supporting other payloads in future, this be glaringly point
 	//as something that should change
@@ -133,21 +181,16 @@ func (e *Endorser) simulateProposal(ctx context.Context, txid string, prop *pb.P
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	//---1. check ACL
-	if err = e.checkACL(prop); err != nil {
-		return nil, nil, nil, err
-	}
-
-	//---2. check ESCC and VSCC for the chaincode
+	//---1. check ESCC and VSCC for the chaincode
 	if err = e.checkEsccAndVscc(prop); err != nil {
 		return nil, nil, nil, err
 	}
 
-	//---3. execute the proposal and get simulation results
+	//---2. execute the proposal and get simulation results
 	var simResult []byte
 	var resp []byte
 	var ccevent *pb.ChaincodeEvent
-	resp, ccevent, err = e.callChaincode(ctx, txid, prop, cis, cid, txsim)
+	resp, ccevent, err = e.callChaincode(ctx, txid, chainName, prop, cis, cid, txsim)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -159,19 +202,139 @@ func (e *Endorser) simulateProposal(ctx context.Context, txid string, prop *pb.P
 	return resp, simResult, ccevent, nil
 }
 
-func (e *Endorser) getCDSFromLCCC(ctx context.Context, txid string, prop *pb.Proposal, chaincodeID string, txsim ledger.TxSimulator) ([]byte, error) {
+//verify the endorsement of the peer that simulated the proposal, carried in its simulationResponse,
+//of the ChaincodeAction carried in its extension, and return that action: the endorsement must be of
+//this very proposal and action, by a valid identity other than the creator of the proposal, which
+//could otherwise endorse results of its own
+func (e *Endorser) verifySimulatingEndorsement(prop *pb.Proposal, hdrExt *pb.ChaincodeHeaderExtension, creator []byte, resp *pb.ProposalResponse) (*pb.ChaincodeAction, error) {
+	if resp.Endorsement == nil || len(resp.Endorsement.Endorser) == 0 || len(resp.Endorsement.Signature) == 0 {
+		return nil, fmt.Errorf("chaincode action is not endorsed by the peer that simulated it")
+	}
+	//the identities are compared by their certificate, whichever intermediate CAs they are serialized with
+	endorserCert, err := msp.SignerCertificate(resp.Endorsement.Endorser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the endorser of the chaincode action - %s", err)
+	}
+	if creatorCert, err := msp.SignerCertificate(creator); bytes.Equal(resp.Endorsement.Endorser, creator) || (err == nil && bytes.Equal(endorserCert, creatorCert)) {
+		return nil, fmt.Errorf("chaincode action is endorsed by the creator of the proposal")
+	}
+
+	endorser, err := msp.GetManager().DeserializeIdentity(resp.Endorsement.Endorser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize the endorser of the chaincode action - %s", err)
+	}
+	if valid, err := endorser.Validate(); err != nil || !valid {
+		return nil, fmt.Errorf("the endorser of the chaincode action is not valid - %v", err)
+	}
+	//the endorsement signs the payload of the response and the identity of the endorser, see ESCC
+	verified, err := endorser.Verify(append(append([]byte{}, resp.Payload...), resp.Endorsement.Endorser...), resp.Endorsement.Signature)
+	if err != nil || !verified {
+		return nil, fmt.Errorf("invalid endorsement of the chaincode action - %v", err)
+	}
+
+	prp, err := putils.GetProposalResponsePayload(resp.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the endorsed payload - %s", err)
+	}
+	pHash, err := putils.GetProposalHash1(prop.Header, prop.Payload, hdrExt.PayloadVisibility)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(prp.ProposalHash, pHash) {
+		return nil, fmt.Errorf("chaincode action is endorsed for another proposal")
+	}
+	if !bytes.Equal(prp.Extension, prop.Extension) {
+		return nil, fmt.Errorf("the endorsed chaincode action is not the one of the proposal")
+	}
+
+	return putils.GetChaincodeAction(prop.Extension)
+}
+
+//verify a ChaincodeAction endorsed by the peer that simulated the proposal instead of simulating
+//it: the action must come from the version of the chaincode deployed on the chain and the versions
+//of the keys it read must be current. Returns the event of the action
+func (e *Endorser) verifyChaincodeAction(ctx context.Context, txid string, prop *pb.Proposal, chainName string, cid *pb.ChaincodeID, action *pb.ChaincodeAction, txsim ledger.TxSimulator) (*pb.ChaincodeEvent, error) {
+	if action.ChaincodeID != nil && action.ChaincodeID.Name != cid.Name {
+		return nil, fmt.Errorf("chaincode action is not from chaincode %s", cid.Name)
+	}
+
+	ctxt := context.WithValue(ctx, chaincode.TXSimulatorKey, txsim)
+	version, err := chaincode.GetVersionFromLCCC(ctxt, txid, prop, chainName, cid.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain the version of %s - %s", cid.Name, err)
+	}
+	//both the action and the proposal header are checked against the version recorded
+	//by LCCC, neither version declared by the client is trusted. The actions endorsed by
+	//ESCC carry no ID: the version of the proposal, which the endorsement covers, is
+	//then the one the action comes from and must be given
+	if action.ChaincodeID != nil && action.ChaincodeID.Version != version {
+		return nil, fmt.Errorf("chaincode action is from version %s of %s, version %s is deployed", action.ChaincodeID.Version, cid.Name, version)
+	}
+	if action.ChaincodeID == nil && cid.Version == "" {
+		return nil, fmt.Errorf("proposal does not give the version of %s its chaincode action is from", cid.Name)
+	}
+	if cid.Version != "" && cid.Version != version {
+		return nil, fmt.Errorf("proposal is for version %s of %s, version %s is deployed", cid.Version, cid.Name, version)
+	}
+
+	if err = checkActionNamespace(cid, action.Results); err != nil {
+		return nil, err
+	}
+
+	//the reads are checked with a fresh simulator so that they are not mixed with the
+	//LCCC calls of the endorsement
+	checker, err := e.getTxSimulator(chainName)
+	if err != nil {
+		return nil, err
+	}
+	defer checker.Done()
+	if err = txmgmt.CheckReadVersions(action.Results, checker); err != nil {
+		return nil, err
+	}
+
+	if len(action.Events) == 0 {
+		return nil, nil
+	}
+	ccevent := &pb.ChaincodeEvent{}
+	if err = proto.Unmarshal(action.Events, ccevent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the event of the chaincode action - %s", err)
+	}
+	return ccevent, nil
+}
+
+//the action may only touch the namespace of its chaincode: the peer that simulated it could
+//otherwise have writes into LCCC or into other chaincodes endorsed
+func checkActionNamespace(cid *pb.ChaincodeID, results []byte) error {
+	rwSet := &txmgmt.TxReadWriteSet{}
+	if err := rwSet.Unmarshal(results); err != nil {
+		return fmt.Errorf("failed to unmarshal the results of the chaincode action - %s", err)
+	}
+	for _, nsRWSet := range rwSet.NsRWs {
+		if nsRWSet.NameSpace != cid.Name {
+			return fmt.Errorf("chaincode action of %s has results in namespace %s", cid.Name, nsRWSet.NameSpace)
+		}
+	}
+	return nil
+}
+
+func (e *Endorser) getCDSFromLCCC(ctx context.Context, txid string, prop *pb.Proposal, chainID string, chaincodeID string, txsim ledger.TxSimulator) ([]byte, error) {
+	ctxt := context.WithValue(ctx, chaincode.TXSimulatorKey, txsim)
+	return chaincode.GetCDSFromLCCC(ctxt, txid, prop, chainID, chaincodeID)
+}
+
+func (e *Endorser) getACLFromLCCC(ctx context.Context, txid string, prop *pb.Proposal, chainID string, chaincodeID string, txsim ledger.TxSimulator) (*pb.ChaincodeACL, error) {
 	ctxt := context.WithValue(ctx, chaincode.TXSimulatorKey, txsim)
-	return chaincode.GetCDSFromLCCC(ctxt, txid, prop, string(chaincode.DefaultChain), chaincodeID)
+	return chaincode.GetACLFromLCCC(ctxt, txid, prop, chainID, chaincodeID)
 }
 
 //endorse the proposal by calling the ESCC
-func (e *Endorser) endorseProposal(ctx context.Context, txid string, proposal *pb.Proposal, simRes []byte, event *pb.ChaincodeEvent, visibility []byte, ccid *pb.ChaincodeID, txsim ledger.TxSimulator) ([]byte, error) {
+func (e *Endorser) endorseProposal(ctx context.Context, txid string, chainName string, proposal *pb.Proposal, simRes []byte, event *pb.ChaincodeEvent, visibility []byte, ccid *pb.ChaincodeID, txsim ledger.TxSimulator) ([]byte, error) {
 	endorserLogger.Infof("endorseProposal starts for proposal %p, simRes %p event %p, visibility %p, ccid %s", proposal, simRes, event, visibility, ccid)
 
 	// 1) extract the chaincodeDeploymentSpec for the chaincode we are invoking; we need it to get the escc
 	var escc string
 	if ccid.Name != "lccc" {
-		depPayload, err := e.getCDSFromLCCC(ctx, txid, proposal, ccid.Name, txsim)
+		depPayload, err := e.getCDSFromLCCC(ctx, txid, proposal, chainName, ccid.Name, txsim)
 		if err != nil {
 			return nil, fmt.Errorf("failed to obtain cds for %s - %s", ccid, err)
 		}
@@ -210,7 +373,7 @@ func (e *Endorser) endorseProposal(ctx context.Context, txid string, proposal *p
 	// args[5] - payloadVisibility
 	args := [][]byte{[]byte(""), proposal.Header, proposal.Payload, simRes, eventBytes, visibility}
 	ecccis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_GOLANG, ChaincodeID: &pb.ChaincodeID{Name: escc}, CtorMsg: &pb.ChaincodeInput{Args: args}}}
-	prBytes, _, err := e.callChaincode(ctx, txid, proposal, ecccis, &pb.ChaincodeID{Name: escc}, txsim)
+	prBytes, _, err := e.callChaincode(ctx, txid, chainName, proposal, ecccis, &pb.ChaincodeID{Name: escc}, txsim)
 	if err != nil {
 		return nil, err
 	}
@@ -248,31 +411,73 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
 
+	// the proposal is simulated on the ledger of its chain, which the peer must have joined
+	chainName, err := e.getChainName(hdr)
+	if err != nil {
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+
 	// obtaining once the tx simulator for this proposal
 	var txsim ledger.TxSimulator
-	//TODO - get chainname from the proposal when defined
-	chainName := string(chaincode.DefaultChain)
 	if txsim, err = e.getTxSimulator(chainName); err != nil {
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
 	defer txsim.Done()
 
-	// TODO: if the proposal has an extension, it will be of type ChaincodeAction;
-	//       if it's present it means that no simulation is to be performed because
-	//       we're trying to emulate a submitting peer. On the other hand, we need
-	//       to validate the supplied action before endorsing it
+	// the chaincode gets the signed proposal to check the creator of the proposal
+	ctx = context.WithValue(ctx, chaincode.SignedProposalKey, signedProp)
 
-	//1 -- simulate
-	//TODO what do we do with response ? We need it for Invoke responses for sure
-	//Which field in PayloadResponse will carry return value ?
-	result, simulationResult, ccevent, err := e.simulateProposal(ctx, txid, prop, hdrExt.ChaincodeID, txsim)
+	//0 -- check the creator against the ACL of the chaincode
+	cis, err := putils.GetChaincodeInvocationSpec(prop)
 	if err != nil {
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
+	if err = e.checkACL(ctx, txid, chainName, signedProp, prop, hdr.SignatureHeader.Creator, cis, txsim); err != nil {
+		// the denial is reported in the response alone: gRPC does not deliver
+		// a response returned together with an error
+		if _, denied := err.(chaincode.ACLDeniedErr); denied {
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 403, Message: err.Error()}}, nil
+		}
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+
+	//1 -- simulate, unless the proposal carries in its extension a ChaincodeAction endorsed by
+	//the peer that simulated it; no simulation is performed then, the action is verified and
+	//the result is the one of the simulating peer
+	//TODO what do we do with response ? We need it for Invoke responses for sure
+	//Which field in PayloadResponse will carry return value ?
+	var result, simulationResult []byte
+	var ccevent *pb.ChaincodeEvent
+	if len(prop.Extension) > 0 {
+		if len(prop.SimulationResponse) == 0 {
+			err = fmt.Errorf("chaincode action is not endorsed by the peer that simulated it")
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+		}
+		simResp, err := putils.GetProposalResponse(prop.SimulationResponse)
+		if err != nil {
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+		}
+		action, err := e.verifySimulatingEndorsement(prop, hdrExt, hdr.SignatureHeader.Creator, simResp)
+		if err != nil {
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+		}
+		if ccevent, err = e.verifyChaincodeAction(ctx, txid, prop, chainName, hdrExt.ChaincodeID, action, txsim); err != nil {
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+		}
+		if simResp.Response != nil {
+			result = simResp.Response.Payload
+		}
+		simulationResult = action.Results
+	} else {
+		result, simulationResult, ccevent, err = e.simulateProposal(ctx, txid, chainName, prop, hdrExt.ChaincodeID, txsim)
+		if err != nil {
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+		}
+	}
 
 	//2 -- endorse and get a marshalled ProposalResponse message
 	//TODO what do we do with response ? We need it for Invoke responses for sure
-	prBytes, err := e.endorseProposal(ctx, txid, prop, simulationResult, ccevent, hdrExt.PayloadVisibility, hdrExt.ChaincodeID, txsim)
+	prBytes, err := e.endorseProposal(ctx, txid, chainName, prop, simulationResult, ccevent, hdrExt.PayloadVisibility, hdrExt.ChaincodeID, txsim)
 	if err != nil {
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
@@ -31,6 +31,7 @@ import (
 	"github.com/hyperledger/fabric/core/crypto/primitives"
 	"github.com/hyperledger/fabric/core/db"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/util"
 	"github.com/hyperledger/fabric/msp"
@@ -349,6 +350,109 @@ func TestDeployAndInvoke(t *testing.T) {
 	chaincode.GetChain(chaincode.DefaultChain).Stop(ctxt, &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: chaincodeID}})
 }
 
+// TestVerifySimulatingEndorsement checks that the action carried by a proposal is only
+// accepted with the endorsement of this proposal and action by a peer other than its creator
+func TestVerifySimulatingEndorsement(t *testing.T) {
+	e := endorserServer.(*Endorser)
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: 1, ChaincodeID: &pb.ChaincodeID{Name: "ex01", Version: "0"}, CtorMsg: &pb.ChaincodeInput{Args: util.ToChaincodeArgs("invoke", "10")}}}
+	hdrExt := &pb.ChaincodeHeaderExtension{ChaincodeID: cis.ChaincodeSpec.ChaincodeID}
+	creator := []byte("client")
+	prop, err := getProposal(cis, creator)
+	if err != nil {
+		t.Fatalf("Error creating proposal: %s", err)
+	}
+	results := []byte("results")
+
+	resp, err := pbutils.CreateProposalResponse(prop.Header, prop.Payload, results, nil, nil, signer)
+	if err != nil {
+		t.Fatalf("Error endorsing proposal: %s", err)
+	}
+	endorsed, err := pbutils.CreateProposalWithEndorsement(prop, resp)
+	if err != nil {
+		t.Fatalf("Error creating proposal with endorsement: %s", err)
+	}
+	action, err := e.verifySimulatingEndorsement(endorsed, hdrExt, creator, resp)
+	if err != nil {
+		t.Fatalf("Endorsed action rejected: %s", err)
+	}
+	if string(action.Results) != string(results) {
+		t.Fatalf("Unexpected results %s", action.Results)
+	}
+
+	unsigned, err := pbutils.ConstructUnsignedProposalResponse(prop.Header, prop.Payload, results, nil, nil)
+	if err != nil {
+		t.Fatalf("Error creating response: %s", err)
+	}
+	if _, err = e.verifySimulatingEndorsement(endorsed, hdrExt, creator, unsigned); err == nil {
+		t.Fatalf("Unendorsed action accepted")
+	}
+
+	// the endorser signs with the identity of the creator, who endorses its own results
+	endorser, err := signer.Serialize()
+	if err != nil {
+		t.Fatalf("Error serializing signer: %s", err)
+	}
+	if _, err = e.verifySimulatingEndorsement(endorsed, hdrExt, endorser, resp); err == nil {
+		t.Fatalf("Action endorsed by the creator of the proposal accepted")
+	}
+	// nor may the creator endorse them with its certificate serialized differently
+	reserialized := append(append([]byte{}, endorser...), endorser...)
+	if _, err = e.verifySimulatingEndorsement(endorsed, hdrExt, reserialized, resp); err == nil {
+		t.Fatalf("Action endorsed by the creator of the proposal, serialized differently, accepted")
+	}
+
+	forged := proto.Clone(resp).(*pb.ProposalResponse)
+	forged.Payload, err = pbutils.GetBytesProposalResponsePayload(nil, []byte("forged"), nil)
+	if err != nil {
+		t.Fatalf("Error creating payload: %s", err)
+	}
+	if _, err = e.verifySimulatingEndorsement(endorsed, hdrExt, creator, forged); err == nil {
+		t.Fatalf("Action with an invalid endorsement accepted")
+	}
+
+	// the action of the proposal must be the endorsed one
+	other := proto.Clone(endorsed).(*pb.Proposal)
+	other.Extension, err = proto.Marshal(&pb.ChaincodeAction{Results: []byte("forged")})
+	if err != nil {
+		t.Fatalf("Error marshaling action: %s", err)
+	}
+	if _, err = e.verifySimulatingEndorsement(other, hdrExt, creator, resp); err == nil {
+		t.Fatalf("Action other than the endorsed one accepted")
+	}
+
+	if other, err = getProposal(cis, creator); err != nil {
+		t.Fatalf("Error creating proposal: %s", err)
+	}
+	if other, err = pbutils.CreateProposalWithEndorsement(other, resp); err != nil {
+		t.Fatalf("Error creating proposal with endorsement: %s", err)
+	}
+	if _, err = e.verifySimulatingEndorsement(other, hdrExt, creator, resp); err == nil {
+		t.Fatalf("Action endorsed for another proposal accepted")
+	}
+}
+
+// TestCheckActionNamespace checks that an action may only have results in the namespace of its chaincode
+func TestCheckActionNamespace(t *testing.T) {
+	cid := &pb.ChaincodeID{Name: "ex01"}
+	rwSet := &txmgmt.TxReadWriteSet{NsRWs: []*txmgmt.NsReadWriteSet{
+		{NameSpace: "ex01", Writes: []*txmgmt.KVWrite{txmgmt.NewKVWrite("a", []byte("90"))}}}}
+	results, err := rwSet.Marshal()
+	if err != nil {
+		t.Fatalf("Error marshaling results: %s", err)
+	}
+	if err = checkActionNamespace(cid, results); err != nil {
+		t.Fatalf("Results of the chaincode rejected: %s", err)
+	}
+
+	rwSet.NsRWs = append(rwSet.NsRWs, &txmgmt.NsReadWriteSet{NameSpace: "lccc", Writes: []*txmgmt.KVWrite{txmgmt.NewKVWrite("ex01", []byte("cds"))}})
+	if results, err = rwSet.Marshal(); err != nil {
+		t.Fatalf("Error marshaling results: %s", err)
+	}
+	if err = checkActionNamespace(cid, results); err == nil {
+		t.Fatalf("Results in the namespace of lccc accepted")
+	}
+}
+
 func TestMain(m *testing.M) {
 	SetupTestConfig()
 	testDBWrapper.CleanDB(nil)
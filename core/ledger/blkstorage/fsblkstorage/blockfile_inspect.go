@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/blkstorage"
+	"github.com/syndtr/goleveldb/leveldb"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// The functions in this file operate directly on the block files and the index db of a block store
+// that is not in use (e.g., the ledger of a stopped peer). They are meant for offline inspection and repair tools
+
+// BlockfileEntry describes a block as it is found in the block files
+type BlockfileEntry struct {
+	// Number is the position of the block in the files, starting from 1 as in the `BlockchainInfo`
+	Number uint64
+	// FileNum is the suffix of the block file that contains the block
+	FileNum int
+	// Offset is the offset of the block (including its length prefix) in the file
+	Offset int64
+	Hash   []byte
+	Block  *pb.Block2
+
+	blockBytesOffset int64
+	txOffsets        []int
+}
+
+// BlockfilesSummary describes the content of the block files as found by `ScanBlockfiles`
+type BlockfilesSummary struct {
+	NumBlocks uint64
+	// LastFileNum is the suffix of the last block file
+	LastFileNum int
+	// LastFileSize is the offset at which the last complete block of the last file ends
+	LastFileSize int64
+	// PartialBlock is true if the last file ends with a partially written block,
+	// which happens if the peer crashed while appending a block
+	PartialBlock bool
+}
+
+// ScanBlockfiles reads, in order, all the blocks stored in the block files of the given configuration and
+// invokes `handle` for each of them. The index and the checkpoint info are not used. Scanning stops at the
+// first error returned by `handle`. A partially written block at the end of the last file is reported in the
+// summary; anywhere else it is an error
+func ScanBlockfiles(conf *Conf, handle func(entry *BlockfileEntry) error) (*BlockfilesSummary, error) {
+	lastFileNum, err := findLastBlockfile(conf.blockfilesDir)
+	if err != nil {
+		return nil, err
+	}
+	summary := &BlockfilesSummary{LastFileNum: lastFileNum}
+	if lastFileNum < 0 {
+		summary.LastFileNum = 0
+		return summary, nil
+	}
+	stream, err := newBlockStream(conf.blockfilesDir, 0, 0, lastFileNum)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.close()
+
+	for {
+		blockBytes, placement, err := stream.nextBlockBytesAndPlacementInfo()
+		if err == ErrUnexpectedEndOfBlockfile && stream.currentFileNum == lastFileNum {
+			summary.PartialBlock = true
+			break
+		}
+		if err != nil {
+			return summary, fmt.Errorf("Error while reading block file [%d] after block [%d]: %s",
+				stream.currentFileNum, summary.NumBlocks, err)
+		}
+		if blockBytes == nil {
+			break
+		}
+		serBlock := pb.NewSerBlock2(blockBytes)
+		entry := &BlockfileEntry{
+			Number:           summary.NumBlocks + 1,
+			FileNum:          placement.fileNum,
+			Offset:           placement.blockStartOffset,
+			Hash:             serBlock.ComputeHash(),
+			blockBytesOffset: placement.blockBytesOffset,
+		}
+		if entry.Block, err = serBlock.ToBlock2(); err != nil {
+			return summary, fmt.Errorf("Error while decoding block [%d] in block file [%d] at offset [%d]: %s",
+				entry.Number, entry.FileNum, entry.Offset, err)
+		}
+		if entry.txOffsets, err = serBlock.GetTxOffsets(); err != nil {
+			return summary, fmt.Errorf("Error while decoding block [%d] in block file [%d] at offset [%d]: %s",
+				entry.Number, entry.FileNum, entry.Offset, err)
+		}
+		summary.NumBlocks++
+		if err = handle(entry); err != nil {
+			return summary, err
+		}
+	}
+	summary.LastFileSize = stream.currentFileStream.currentOffset
+	return summary, nil
+}
+
+// findLastBlockfile returns the highest block file suffix present in the directory (-1 if there is none)
+// after checking that there is no gap in the numbering of the files
+func findLastBlockfile(rootDir string) (int, error) {
+	files, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return -1, err
+	}
+	present := make(map[int]bool)
+	lastFileNum := -1
+	for _, f := range files {
+		if f.IsDir() || !strings.HasPrefix(f.Name(), blockfilePrefix) {
+			continue
+		}
+		num, err := strconv.Atoi(strings.TrimPrefix(f.Name(), blockfilePrefix))
+		if err != nil {
+			continue
+		}
+		present[num] = true
+		if num > lastFileNum {
+			lastFileNum = num
+		}
+	}
+	for i := 0; i < lastFileNum; i++ {
+		if !present[i] {
+			return -1, fmt.Errorf("Block file [%s] is missing", deriveBlockfilePath(rootDir, i))
+		}
+	}
+	return lastFileNum, nil
+}
+
+// HashChainReport is the outcome of `VerifyHashChain`
+type HashChainReport struct {
+	Summary *BlockfilesSummary
+	// Broken lists the blocks whose previous block hash does not match the hash of the preceding block
+	Broken []uint64
+	// Unlinked lists the blocks (other than the first one) which do not record the hash of the preceding block
+	Unlinked []uint64
+}
+
+// VerifyHashChain checks that every block in the block files records the hash of the block which precedes it
+func VerifyHashChain(conf *Conf) (*HashChainReport, error) {
+	report := &HashChainReport{}
+	var previousHash []byte
+	summary, err := ScanBlockfiles(conf, func(entry *BlockfileEntry) error {
+		if entry.Number > 1 {
+			switch {
+			case len(entry.Block.PreviousBlockHash) == 0:
+				report.Unlinked = append(report.Unlinked, entry.Number)
+			case !bytes.Equal(entry.Block.PreviousBlockHash, previousHash):
+				report.Broken = append(report.Broken, entry.Number)
+			}
+		}
+		previousHash = entry.Hash
+		return nil
+	})
+	report.Summary = summary
+	return report, err
+}
+
+// RebuildIndex discards the block index and the checkpoint info stored in the index db and rebuilds them by
+// scanning the block files. A partially written block at the end of the last file is left out of the
+// checkpoint info, so that it is truncated when the block store is next opened
+func RebuildIndex(conf *Conf, indexConfig *blkstorage.IndexConfig) (*BlockfilesSummary, error) {
+	if _, err := os.Stat(conf.blockfilesDir); err != nil {
+		return nil, err
+	}
+	db := initDB(conf)
+	defer db.Close()
+
+	// the db only holds the index and the checkpoint info
+	batch := &leveldb.Batch{}
+	itr := db.GetIterator(nil, nil)
+	for itr.Next() {
+		batch.Delete(append([]byte{}, itr.Key()...))
+	}
+	itr.Release()
+	if err := db.WriteBatch(batch, true); err != nil {
+		return nil, err
+	}
+
+	index := newBlockIndex(indexConfig, db)
+	summary, err := ScanBlockfiles(conf, func(entry *BlockfileEntry) error {
+		txOffsets := entry.txOffsets
+		for i := range txOffsets {
+			txOffsets[i] += int(entry.blockBytesOffset)
+		}
+		return index.indexBlock(&blockIdxInfo{
+			blockNum:  entry.Number,
+			blockHash: entry.Hash,
+			flp:       &fileLocPointer{fileSuffixNum: entry.FileNum, locPointer: locPointer{offset: int(entry.Offset)}},
+			txOffsets: txOffsets})
+	})
+	if err != nil {
+		return summary, err
+	}
+
+	mgr := &blockfileMgr{rootDir: conf.blockfilesDir, conf: conf, db: db}
+	cpInfo := &checkpointInfo{
+		latestFileChunkSuffixNum: summary.LastFileNum,
+		latestFileChunksize:      int(summary.LastFileSize),
+		lastBlockNumber:          summary.NumBlocks}
+	logger.Debugf("Saving rebuilt checkpointInfo: %s", cpInfo)
+	if err = mgr.saveCurrentInfo(cpInfo, true); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
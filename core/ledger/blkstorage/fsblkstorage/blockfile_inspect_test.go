@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/testutil"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+func constructChainedTestBlocks(t *testing.T, numBlocks int) []*pb.Block2 {
+	blocks := testutil.ConstructTestBlocks(t, numBlocks)
+	for i := 1; i < len(blocks); i++ {
+		blocks[i].PreviousBlockHash = testutil.ComputeBlockHash(t, blocks[i-1])
+	}
+	return blocks
+}
+
+func TestScanBlockfilesAcrossFiles(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.Cleanup()
+	// small enough for the blocks to span several files
+	env.conf.maxBlockfileSize = 5000
+	blkfileMgrWrapper := newTestBlockfileWrapper(t, env)
+	blocks := constructChainedTestBlocks(t, 10)
+	blkfileMgrWrapper.addBlocks(blocks)
+	cpInfo := blkfileMgrWrapper.blockfileMgr.cpInfo
+	blkfileMgrWrapper.close()
+
+	scanned := []*pb.Block2{}
+	summary, err := ScanBlockfiles(env.conf, func(entry *BlockfileEntry) error {
+		testutil.AssertEquals(t, entry.Number, uint64(len(scanned)+1))
+		testutil.AssertEquals(t, entry.Hash, testutil.ComputeBlockHash(t, entry.Block))
+		scanned = append(scanned, entry.Block)
+		return nil
+	})
+	testutil.AssertNoError(t, err, "Error while scanning block files")
+	testutil.AssertEquals(t, scanned, blocks)
+	testutil.AssertEquals(t, summary.NumBlocks, uint64(10))
+	testutil.AssertEquals(t, summary.LastFileNum, cpInfo.latestFileChunkSuffixNum)
+	testutil.AssertEquals(t, summary.LastFileSize, int64(cpInfo.latestFileChunksize))
+	testutil.AssertEquals(t, summary.PartialBlock, false)
+	if summary.LastFileNum == 0 {
+		t.Fatalf("Expected the blocks to be stored in more than one file")
+	}
+}
+
+func TestVerifyHashChain(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(t, env)
+	blocks := constructChainedTestBlocks(t, 5)
+	// block 4 points to a wrong predecessor and block 5 to none
+	blocks[3].PreviousBlockHash = []byte("wrong hash")
+	blocks[4].PreviousBlockHash = nil
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.close()
+
+	report, err := VerifyHashChain(env.conf)
+	testutil.AssertNoError(t, err, "Error while verifying the hash chain")
+	testutil.AssertEquals(t, report.Summary.NumBlocks, uint64(5))
+	testutil.AssertEquals(t, report.Broken, []uint64{4})
+	testutil.AssertEquals(t, report.Unlinked, []uint64{5})
+}
+
+func TestRebuildIndex(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.Cleanup()
+	env.conf.maxBlockfileSize = 5000
+	blkfileMgrWrapper := newTestBlockfileWrapper(t, env)
+	blocks := constructChainedTestBlocks(t, 10)
+	blkfileMgrWrapper.addBlocks(blocks)
+	cpInfo := blkfileMgrWrapper.blockfileMgr.cpInfo
+
+	// simulate a crash in the middle of an append
+	blkfileMgrWrapper.blockfileMgr.currentFileWriter.append([]byte{0x90, 0x4e, 0x01, 0x02}, true)
+	blkfileMgrWrapper.close()
+
+	// lose the index and the checkpoint info
+	os.RemoveAll(env.conf.dbPath)
+	summary, err := RebuildIndex(env.conf, env.indexConfig)
+	testutil.AssertNoError(t, err, "Error while rebuilding the index")
+	testutil.AssertEquals(t, summary.NumBlocks, uint64(10))
+	testutil.AssertEquals(t, summary.PartialBlock, true)
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(t, env)
+	defer blkfileMgrWrapper.close()
+	testutil.AssertEquals(t, blkfileMgrWrapper.blockfileMgr.cpInfo, cpInfo)
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 1)
+	tx, err := blkfileMgrWrapper.blockfileMgr.retrieveTransactionByID(constructTxID(3, 2))
+	testutil.AssertNoError(t, err, "Error while retrieving a transaction after rebuilding the index")
+	testutil.AssertNotNil(t, tx)
+
+	// the store can be appended to after the repair
+	moreBlocks := testutil.ConstructTestBlocks(t, 2)
+	blkfileMgrWrapper.addBlocks(moreBlocks)
+	blkfileMgrWrapper.testGetBlockByNumber(moreBlocks, 11)
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/blkstorage"
@@ -28,6 +29,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/couchdbtxmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/lockbasedtxmgmt"
+	"github.com/hyperledger/fabric/metrics"
 
 	logging "github.com/op/go-logging"
 
@@ -36,11 +38,15 @@ import (
 
 var logger = logging.MustGetLogger("kvledger")
 
+var commitDuration = metrics.NewHistogram("ledger_commit_duration_seconds",
+	"Time taken to commit a block to the block storage and the state database", nil, "chain")
+
 // Conf captures `KVLedger` configurations
 type Conf struct {
-	blockStorageDir  string
-	maxBlockfileSize int
-	txMgrDBPath      string
+	blockStorageDir   string
+	maxBlockfileSize  int
+	txMgrDBPath       string
+	validationWorkers int
 }
 
 // NewConf constructs new `Conf`.
@@ -51,12 +57,23 @@ func NewConf(filesystemPath string, maxBlockfileSize int) *Conf {
 	}
 	blocksStorageDir := filesystemPath + "blocks"
 	txMgrDBPath := filesystemPath + "txMgmgt/db"
-	return &Conf{blocksStorageDir, maxBlockfileSize, txMgrDBPath}
+	return &Conf{blocksStorageDir, maxBlockfileSize, txMgrDBPath, kvledgerconfig.GetValidationWorkers()}
+}
+
+// BlockStorageDir returns the directory under which the block storage of the ledger keeps its data
+func (conf *Conf) BlockStorageDir() string {
+	return conf.blockStorageDir
+}
+
+// TxMgrDBPath returns the path of the state database of the ledger
+func (conf *Conf) TxMgrDBPath() string {
+	return conf.txMgrDBPath
 }
 
 // KVLedger provides an implementation of `ledger.ValidatedLedger`.
 // This implementation provides a key-value based data model
 type KVLedger struct {
+	name                 string
 	blockStore           blkstorage.BlockStore
 	txtmgmt              txmgmt.TxMgr
 	pendingBlockToCommit *pb.Block2
@@ -83,17 +100,17 @@ func NewKVLedger(conf *Conf) (*KVLedger, error) {
 		couchDBDef := kvledgerconfig.GetCouchDBDefinition()
 
 		//create new transaction manager based on couchDB
-		txmgmt := couchdbtxmgmt.NewCouchDBTxMgr(&couchdbtxmgmt.Conf{DBPath: conf.txMgrDBPath},
+		txmgmt := couchdbtxmgmt.NewCouchDBTxMgr(&couchdbtxmgmt.Conf{DBPath: conf.txMgrDBPath, ValidationWorkers: conf.validationWorkers},
 			couchDBDef.URL,      //couchDB connection URL
 			"system",            //couchDB db name matches ledger name, TODO for now use system ledger, eventually allow passing in subledger name
 			couchDBDef.Username, //enter couchDB id here
 			couchDBDef.Password) //enter couchDB pw here
-		return &KVLedger{blockStore, txmgmt, nil}, nil
+		return &KVLedger{blockStore: blockStore, txtmgmt: txmgmt}, nil
 	}
 
 	// Fall back to using RocksDB lockbased transaction manager
-	txmgmt := lockbasedtxmgmt.NewLockBasedTxMgr(&lockbasedtxmgmt.Conf{DBPath: conf.txMgrDBPath})
-	return &KVLedger{blockStore, txmgmt, nil}, nil
+	txmgmt := lockbasedtxmgmt.NewLockBasedTxMgr(&lockbasedtxmgmt.Conf{DBPath: conf.txMgrDBPath, ValidationWorkers: conf.validationWorkers})
+	return &KVLedger{blockStore: blockStore, txtmgmt: txmgmt}, nil
 
 }
 
@@ -156,12 +173,19 @@ func (l *KVLedger) RemoveInvalidTransactionsAndPrepare(block *pb.Block2) (*pb.Bl
 	return validBlock, invalidTxs, err
 }
 
+// SetTxValidator sets the validator that checks the signatures and endorsements of the transactions
+// in `RemoveInvalidTransactionsAndPrepare`
+func (l *KVLedger) SetTxValidator(validator txmgmt.TxValidator) {
+	l.txtmgmt.SetTxValidator(validator)
+}
+
 // Commit commits the valid block (returned in the method RemoveInvalidTransactionsAndPrepare) and related state changes
 func (l *KVLedger) Commit() error {
 	if l.pendingBlockToCommit == nil {
 		panic(fmt.Errorf(`Nothing to commit. RemoveInvalidTransactionsAndPrepare() method should have been called and should not have thrown error`))
 	}
 
+	start := time.Now()
 	logger.Debugf("Committing block to storage")
 	if err := l.blockStore.AddBlock(l.pendingBlockToCommit); err != nil {
 		return err
@@ -172,6 +196,7 @@ func (l *KVLedger) Commit() error {
 		panic(fmt.Errorf(`Error during commit to txmgr:%s`, err))
 	}
 	l.pendingBlockToCommit = nil
+	commitDuration.Observe(time.Since(start).Seconds(), l.name)
 	return nil
 }
 
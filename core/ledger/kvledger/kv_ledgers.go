@@ -101,6 +101,7 @@ func (lMgr *ledgerManager) create(name string) (*KVLedger, error) {
 	if lgr, err = NewKVLedger(ledgerConf); err != nil || lgr == nil {
 		return nil, LedgerCreateErr(name)
 	}
+	lgr.name = name
 
 	lMgr.ledgers[lPath] = lgr
 
@@ -48,3 +48,8 @@ func GetCouchDBDefinition() *CouchDBDef {
 
 	return &CouchDBDef{couchDBAddress, username, password}
 }
+
+//GetValidationWorkers returns the number of transactions of a block that are validated concurrently
+func GetValidationWorkers() int {
+	return viper.GetInt("ledger.validation.workers")
+}
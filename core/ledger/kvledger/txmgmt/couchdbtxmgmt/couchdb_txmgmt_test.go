@@ -41,7 +41,7 @@ func newTestEnv(t testing.TB) *testEnv {
 
 	couchDBDef := kvledgerconfig.GetCouchDBDefinition()
 
-	conf := &Conf{"/tmp/tests/ledger/kvledger/txmgmt/couchdbtxmgmt"}
+	conf := &Conf{DBPath: "/tmp/tests/ledger/kvledger/txmgmt/couchdbtxmgmt"}
 	os.RemoveAll(conf.DBPath)
 	return &testEnv{
 		conf:              conf,
@@ -27,7 +27,6 @@ import (
 	"github.com/op/go-logging"
 
 	pb "github.com/hyperledger/fabric/protos/peer"
-	putils "github.com/hyperledger/fabric/protos/utils"
 )
 
 var logger = logging.MustGetLogger("couchdbtxmgmt")
@@ -35,6 +34,9 @@ var logger = logging.MustGetLogger("couchdbtxmgmt")
 // Conf - configuration for `CouchDBTxMgr`
 type Conf struct {
 	DBPath string
+	// ValidationWorkers is the number of transactions that are decoded and checked concurrently
+	// during `ValidateAndPrepare`. A value <= 0 defaults to the number of CPUs
+	ValidationWorkers int
 }
 
 type versionedValue struct {
@@ -66,10 +68,12 @@ func (u *updateSet) get(compositeKey []byte) *versionedValue {
 // CouchDBTxMgr a simple implementation of interface `txmgmt.TxMgr`.
 // This implementation uses a read-write lock to prevent conflicts between transaction simulation and committing
 type CouchDBTxMgr struct {
-	db           *db.DB
-	updateSet    *updateSet
-	commitRWLock sync.RWMutex
-	couchDB      *couchdb.CouchDBConnectionDef // COUCHDB new properties for CouchDB
+	db                *db.DB
+	updateSet         *updateSet
+	commitRWLock      sync.RWMutex
+	couchDB           *couchdb.CouchDBConnectionDef // COUCHDB new properties for CouchDB
+	txValidator       txmgmt.TxValidator
+	validationWorkers int
 }
 
 // CouchConnection provides connection info for CouchDB
@@ -103,7 +107,7 @@ func NewCouchDBTxMgr(conf *Conf, couchDBConnectURL string, dbName string, id str
 	}
 
 	// db and stateIndexCF will not be used for CouchDB. TODO to cleanup
-	return &CouchDBTxMgr{db: db, couchDB: couchDB}
+	return &CouchDBTxMgr{db: db, couchDB: couchDB, validationWorkers: conf.ValidationWorkers}
 }
 
 // NewQueryExecutor implements method in interface `txmgmt.TxMgr`
@@ -124,25 +128,23 @@ func (txmgr *CouchDBTxMgr) ValidateAndPrepare(block *pb.Block2) (*pb.Block2, []*
 	validatedBlock := &pb.Block2{}
 	//TODO pull PreviousBlockHash from db
 	validatedBlock.PreviousBlockHash = block.PreviousBlockHash
+	validatedBlock.OrdererBlock = block.OrdererBlock
 	invalidTxs := []*pb.InvalidTransaction{}
 	var valid bool
+	var err error
 	txmgr.updateSet = newUpdateSet()
 	logger.Debugf("Validating a block with [%d] transactions", len(block.Transactions))
-	for _, envBytes := range block.Transactions {
-		// extract actions from the envelope message
-		respPayload, err := putils.GetActionFromEnvelope(envBytes)
-		if err != nil {
-			return nil, nil, err
+	preprocessedTxs := txmgmt.PreprocessBlock(block, txmgr.txValidator, txmgr.validationWorkers)
+	for _, tx := range preprocessedTxs {
+		if tx.Err != nil {
+			return nil, nil, tx.Err
 		}
-
-		//preparation for extracting RWSet from transaction
-		txRWSet := &txmgmt.TxReadWriteSet{}
-
-		// Get the Result from the Action
-		// and then Unmarshal it into a TxReadWriteSet using custom unmarshalling
-		if err = txRWSet.Unmarshal(respPayload.Results); err != nil {
-			return nil, nil, err
+		if !tx.Valid {
+			invalidTxs = append(invalidTxs, &pb.InvalidTransaction{
+				Transaction: &pb.Transaction{ /* FIXME */ }, Cause: tx.Cause})
+			continue
 		}
+		txRWSet := tx.RWSet
 
 		// trace the first 2000 characters of RWSet only, in case it is huge
 		if logger.IsEnabledFor(logging.DEBUG) {
@@ -162,7 +164,7 @@ func (txmgr *CouchDBTxMgr) ValidateAndPrepare(block *pb.Block2) (*pb.Block2, []*
 			if err := txmgr.addWriteSetToBatch(txRWSet); err != nil {
 				return nil, nil, err
 			}
-			validatedBlock.Transactions = append(validatedBlock.Transactions, envBytes)
+			validatedBlock.Transactions = append(validatedBlock.Transactions, tx.EnvBytes)
 		} else {
 			invalidTxs = append(invalidTxs, &pb.InvalidTransaction{
 				Transaction: &pb.Transaction{ /* FIXME */ }, Cause: pb.InvalidTransaction_RWConflictDuringCommit})
@@ -172,6 +174,11 @@ func (txmgr *CouchDBTxMgr) ValidateAndPrepare(block *pb.Block2) (*pb.Block2, []*
 	return validatedBlock, invalidTxs, nil
 }
 
+// SetTxValidator implements method in interface `txmgmt.TxMgr`
+func (txmgr *CouchDBTxMgr) SetTxValidator(validator txmgmt.TxValidator) {
+	txmgr.txValidator = validator
+}
+
 // Shutdown implements method in interface `txmgmt.TxMgr`
 func (txmgr *CouchDBTxMgr) Shutdown() {
 	txmgr.db.Close()
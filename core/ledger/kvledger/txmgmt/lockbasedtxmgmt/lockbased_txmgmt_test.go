@@ -311,6 +311,44 @@ func TestIteratorWithDeletes(t *testing.T) {
 	testutil.AssertEquals(t, kv.(*ledger.KV).Key, createTestKey(5))
 }
 
+func TestScanCommittedState(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.Cleanup()
+	txMgr := NewLockBasedTxMgr(env.conf)
+	defer txMgr.Shutdown()
+
+	s, _ := txMgr.NewTxSimulator()
+	s.SetState("ns1", "key1", []byte("value1"))
+	s.SetState("ns1", "key2", []byte("value2"))
+	s.SetState("ns10", "key3", []byte("value3"))
+	s.Done()
+	txRWSet := s.(*LockBasedTxSimulator).getTxReadWriteSet()
+	txMgr.addWriteSetToBatch(txRWSet)
+	testutil.AssertNoError(t, txMgr.Commit(), "")
+
+	s, _ = txMgr.NewTxSimulator()
+	s.SetState("ns1", "key1", []byte("value1_1"))
+	s.DeleteState("ns1", "key2")
+	s.Done()
+	txRWSet = s.(*LockBasedTxSimulator).getTxReadWriteSet()
+	txMgr.addWriteSetToBatch(txRWSet)
+	testutil.AssertNoError(t, txMgr.Commit(), "")
+
+	keys := []string{}
+	values := [][]byte{}
+	versions := []uint64{}
+	err := txMgr.ScanCommittedState("ns1", func(key string, value []byte, version uint64) error {
+		keys = append(keys, key)
+		values = append(values, value)
+		versions = append(versions, version)
+		return nil
+	})
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, keys, []string{"key1", "key2"})
+	testutil.AssertEquals(t, values, [][]byte{[]byte("value1_1"), nil})
+	testutil.AssertEquals(t, versions, []uint64{2, 2})
+}
+
 func TestTxValidationWithItr(t *testing.T) {
 	cID := "cID"
 	env := newTestEnv(t)
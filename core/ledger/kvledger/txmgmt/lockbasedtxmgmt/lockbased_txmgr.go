@@ -27,7 +27,6 @@ import (
 	"github.com/op/go-logging"
 
 	pb "github.com/hyperledger/fabric/protos/peer"
-	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
@@ -39,6 +38,9 @@ var compositeKeySep = []byte{0x00}
 // Conf - configuration for `LockBasedTxMgr`
 type Conf struct {
 	DBPath string
+	// ValidationWorkers is the number of transactions that are decoded and checked concurrently
+	// during `ValidateAndPrepare`. A value <= 0 defaults to the number of CPUs
+	ValidationWorkers int
 }
 
 type versionedValue struct {
@@ -70,16 +72,18 @@ func (u *updateSet) get(compositeKey []byte) *versionedValue {
 // LockBasedTxMgr a simple implementation of interface `txmgmt.TxMgr`.
 // This implementation uses a read-write lock to prevent conflicts between transaction simulation and committing
 type LockBasedTxMgr struct {
-	db           *db.DB
-	updateSet    *updateSet
-	commitRWLock sync.RWMutex
+	db                *db.DB
+	updateSet         *updateSet
+	commitRWLock      sync.RWMutex
+	txValidator       txmgmt.TxValidator
+	validationWorkers int
 }
 
 // NewLockBasedTxMgr constructs a `LockBasedTxMgr`
 func NewLockBasedTxMgr(conf *Conf) *LockBasedTxMgr {
 	db := db.CreateDB(&db.Conf{DBPath: conf.DBPath})
 	db.Open()
-	return &LockBasedTxMgr{db: db}
+	return &LockBasedTxMgr{db: db, validationWorkers: conf.ValidationWorkers}
 }
 
 // NewQueryExecutor implements method in interface `txmgmt.TxMgr`
@@ -96,30 +100,31 @@ func (txmgr *LockBasedTxMgr) NewTxSimulator() (ledger.TxSimulator, error) {
 	return s, nil
 }
 
-// ValidateAndPrepare implements method in interface `txmgmt.TxMgr`
+// ValidateAndPrepare implements method in interface `txmgmt.TxMgr`.
+// The validation is performed in two stages. In the first stage, the transactions are decoded and checked
+// by the `txmgmt.TxValidator` concurrently. In the second stage, the MVCC checks are performed serially in block order
 func (txmgr *LockBasedTxMgr) ValidateAndPrepare(block *pb.Block2) (*pb.Block2, []*pb.InvalidTransaction, error) {
 	validatedBlock := &pb.Block2{}
 	//TODO pull PreviousBlockHash from db
 	validatedBlock.PreviousBlockHash = block.PreviousBlockHash
+	validatedBlock.OrdererBlock = block.OrdererBlock
 	invalidTxs := []*pb.InvalidTransaction{}
 	var valid bool
+	var err error
 	txmgr.updateSet = newUpdateSet()
 	logger.Debugf("Validating a block with [%d] transactions", len(block.Transactions))
-	for _, envBytes := range block.Transactions {
-		// extract actions from the envelope message
-		respPayload, err := putils.GetActionFromEnvelope(envBytes)
-		if err != nil {
-			return nil, nil, err
+	preprocessedTxs := txmgmt.PreprocessBlock(block, txmgr.txValidator, txmgr.validationWorkers)
+	for _, tx := range preprocessedTxs {
+		if tx.Err != nil {
+			return nil, nil, tx.Err
 		}
-
-		//preparation for extracting RWSet from transaction
-		txRWSet := &txmgmt.TxReadWriteSet{}
-
-		// Get the Result from the Action
-		// and then Unmarshal it into a TxReadWriteSet using custom unmarshalling
-		if err = txRWSet.Unmarshal(respPayload.Results); err != nil {
-			return nil, nil, err
+		if !tx.Valid {
+			logger.Debugf("Transaction failed validation with cause [%s]", tx.Cause)
+			invalidTxs = append(invalidTxs, &pb.InvalidTransaction{
+				Transaction: &pb.Transaction{ /* FIXME */ }, Cause: tx.Cause})
+			continue
 		}
+		txRWSet := tx.RWSet
 
 		// trace the first 2000 characters of RWSet only, in case it is huge
 		if logger.IsEnabledFor(logging.DEBUG) {
@@ -139,7 +144,7 @@ func (txmgr *LockBasedTxMgr) ValidateAndPrepare(block *pb.Block2) (*pb.Block2, [
 			if err := txmgr.addWriteSetToBatch(txRWSet); err != nil {
 				return nil, nil, err
 			}
-			validatedBlock.Transactions = append(validatedBlock.Transactions, envBytes)
+			validatedBlock.Transactions = append(validatedBlock.Transactions, tx.EnvBytes)
 		} else {
 			invalidTxs = append(invalidTxs, &pb.InvalidTransaction{
 				Transaction: &pb.Transaction{ /* FIXME */ }, Cause: pb.InvalidTransaction_RWConflictDuringCommit})
@@ -148,6 +153,11 @@ func (txmgr *LockBasedTxMgr) ValidateAndPrepare(block *pb.Block2) (*pb.Block2, [
 	return validatedBlock, invalidTxs, nil
 }
 
+// SetTxValidator implements method in interface `txmgmt.TxMgr`
+func (txmgr *LockBasedTxMgr) SetTxValidator(validator txmgmt.TxValidator) {
+	txmgr.txValidator = validator
+}
+
 // Shutdown implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Shutdown() {
 	txmgr.db.Close()
@@ -264,6 +274,29 @@ func (txmgr *LockBasedTxMgr) getCommittedRangeScanner(namespace string, startKey
 	return newKVScanner(namespace, dbItr), nil
 }
 
+// ScanCommittedState invokes `fn`, in key order, for every key of the namespace in the committed state
+// along with its value (nil if the key has been deleted) and its version.
+// It stops at the first error returned by `fn`
+func (txmgr *LockBasedTxMgr) ScanCommittedState(namespace string, fn func(key string, value []byte, version uint64) error) error {
+	compositeStartKey := constructCompositeKey(namespace, "")
+	// the smallest key that sorts after all the composite keys of the namespace
+	compositeEndKey := append([]byte(namespace), compositeKeySep[0]+1)
+	scanner := newKVScanner(namespace, txmgr.db.GetIterator(compositeStartKey, compositeEndKey))
+	defer scanner.close()
+	for {
+		committedKV, err := scanner.next()
+		if err != nil {
+			return err
+		}
+		if committedKV == nil {
+			return nil
+		}
+		if err = fn(committedKV.key, committedKV.value, committedKV.version); err != nil {
+			return err
+		}
+	}
+}
+
 func encodeValue(value []byte, version uint64) []byte {
 	versionBytes := proto.EncodeVarint(version)
 	deleteMarker := 0
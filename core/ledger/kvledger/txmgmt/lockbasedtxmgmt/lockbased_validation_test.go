@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lockbasedtxmgmt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt"
+	"github.com/hyperledger/fabric/core/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// sigTxValidator verifies an ECDSA signature over the envelope payload,
+// standing in for the creator and endorsement signature checks of the committer
+type sigTxValidator struct {
+	pubKey *ecdsa.PublicKey
+}
+
+func (v *sigTxValidator) ValidateTx(env *common.Envelope) (bool, pb.InvalidTransaction_Cause, error) {
+	sig := &ecdsaSignature{}
+	if _, err := asn1.Unmarshal(env.Signature, sig); err != nil {
+		return false, pb.InvalidTransaction_BadCreatorSignature, nil
+	}
+	digest := sha256.Sum256(env.Payload)
+	if !ecdsa.Verify(v.pubKey, digest[:], sig.R, sig.S) {
+		return false, pb.InvalidTransaction_BadCreatorSignature, nil
+	}
+	return true, 0, nil
+}
+
+// constructBlockWithConflicts constructs a block of `numTx` transactions. Transaction i reads and writes
+// key i%numKeys (so only the first transaction for each key is valid), and every third transaction carries a bad signature
+func constructBlockWithConflicts(tb testing.TB, txMgr *LockBasedTxMgr, key *ecdsa.PrivateKey, numTx int, numKeys int) *pb.Block2 {
+	block := &pb.Block2{PreviousBlockHash: []byte{}}
+	for i := 0; i < numTx; i++ {
+		s, _ := txMgr.NewTxSimulator()
+		k := fmt.Sprintf("key%d", i%numKeys)
+		s.GetState("ns", k)
+		s.SetState("ns", k, []byte(fmt.Sprintf("value%d", i)))
+		s.Done()
+		simRes, err := s.GetTxSimulationResults()
+		testutil.AssertNoError(tb, err, "Error while getting simulation results")
+
+		env, err := testutil.ConstructTestTransaction(tb, simRes, false)
+		testutil.AssertNoError(tb, err, "Error while constructing transaction")
+		payload := env.Payload
+		if i%3 == 2 {
+			payload = []byte("tampered")
+		}
+		digest := sha256.Sum256(payload)
+		r, ss, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		testutil.AssertNoError(tb, err, "Error while signing transaction")
+		env.Signature, _ = asn1.Marshal(ecdsaSignature{r, ss})
+
+		envBytes, _ := proto.Marshal(env)
+		block.Transactions = append(block.Transactions, envBytes)
+	}
+	return block
+}
+
+func TestParallelValidationMatchesSerial(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.Cleanup()
+	txMgr := NewLockBasedTxMgr(env.conf)
+	defer txMgr.Shutdown()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	txMgr.SetTxValidator(&sigTxValidator{&key.PublicKey})
+	block := constructBlockWithConflicts(t, txMgr, key, 30, 7)
+
+	txMgr.validationWorkers = 1
+	serialBlock, serialInvalidTxs, err := txMgr.ValidateAndPrepare(block)
+	testutil.AssertNoError(t, err, "Error in serial ValidateAndPrepare()")
+	txMgr.Rollback()
+
+	// 20 txs have a good signature; among them, only the first one for each of the 7 keys is valid
+	testutil.AssertEquals(t, len(serialBlock.Transactions), 7)
+	testutil.AssertEquals(t, len(serialInvalidTxs), 23)
+
+	for _, workers := range []int{2, 4, 16, 64} {
+		txMgr.validationWorkers = workers
+		validBlock, invalidTxs, err := txMgr.ValidateAndPrepare(block)
+		testutil.AssertNoError(t, err, fmt.Sprintf("Error in ValidateAndPrepare() with %d workers", workers))
+		testutil.AssertEquals(t, validBlock, serialBlock)
+		testutil.AssertEquals(t, invalidTxs, serialInvalidTxs)
+		txMgr.Rollback()
+	}
+}
+
+func TestParallelValidationReturnsFirstError(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.Cleanup()
+	txMgr := NewLockBasedTxMgr(&Conf{DBPath: env.conf.DBPath, ValidationWorkers: 8})
+	defer txMgr.Shutdown()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	block := constructBlockWithConflicts(t, txMgr, key, 10, 10)
+	block.Transactions[4] = []byte("not an envelope")
+	_, _, err := txMgr.ValidateAndPrepare(block)
+	testutil.AssertError(t, err, "Expected an error for a malformed transaction")
+}
+
+func TestCheckReadVersions(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.Cleanup()
+	txMgr := NewLockBasedTxMgr(env.conf)
+	defer txMgr.Shutdown()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	block := constructBlockWithConflicts(t, txMgr, key, 1, 1)
+	_, _, err := txMgr.ValidateAndPrepare(block)
+	testutil.AssertNoError(t, err, "Error in ValidateAndPrepare()")
+	testutil.AssertNoError(t, txMgr.Commit(), "Error in Commit()")
+
+	s, _ := txMgr.NewTxSimulator()
+	s.GetState("ns", "key0")
+	s.GetState("ns", "key1")
+	s.Done()
+	simRes, err := s.GetTxSimulationResults()
+	testutil.AssertNoError(t, err, "Error while getting simulation results")
+
+	checker, _ := txMgr.NewTxSimulator()
+	err = txmgmt.CheckReadVersions(simRes, checker)
+	checker.Done()
+	testutil.AssertNoError(t, err, "Expected the read versions to be current")
+
+	// key0 is updated after it was read
+	block = constructBlockWithConflicts(t, txMgr, key, 1, 1)
+	_, _, err = txMgr.ValidateAndPrepare(block)
+	testutil.AssertNoError(t, err, "Error in ValidateAndPrepare()")
+	testutil.AssertNoError(t, txMgr.Commit(), "Error in Commit()")
+
+	checker, _ = txMgr.NewTxSimulator()
+	err = txmgmt.CheckReadVersions(simRes, checker)
+	checker.Done()
+	testutil.AssertError(t, err, "Expected the read version of key0 to be stale")
+}
+
+// The benchmarks validate a block of 500 transactions per op, so the throughput
+// in transactions per second is 500 * 1e9 / (ns/op)
+func benchmarkValidateAndPrepare(b *testing.B, workers int) {
+	env := newTestEnv(b)
+	defer env.Cleanup()
+	txMgr := NewLockBasedTxMgr(&Conf{DBPath: env.conf.DBPath, ValidationWorkers: workers})
+	defer txMgr.Shutdown()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	txMgr.SetTxValidator(&sigTxValidator{&key.PublicKey})
+	block := constructBlockWithConflicts(b, txMgr, key, 500, 500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := txMgr.ValidateAndPrepare(block); err != nil {
+			b.Fatalf("Error in ValidateAndPrepare(): %s", err)
+		}
+		txMgr.Rollback()
+	}
+}
+
+func BenchmarkValidateAndPrepare1Worker(b *testing.B) {
+	benchmarkValidateAndPrepare(b, 1)
+}
+
+func BenchmarkValidateAndPrepare4Workers(b *testing.B) {
+	benchmarkValidateAndPrepare(b, 4)
+}
+
+func BenchmarkValidateAndPrepare16Workers(b *testing.B) {
+	benchmarkValidateAndPrepare(b, 16)
+}
@@ -26,6 +26,7 @@ type TxMgr interface {
 	NewQueryExecutor() (ledger.QueryExecutor, error)
 	NewTxSimulator() (ledger.TxSimulator, error)
 	ValidateAndPrepare(block *pb.Block2) (*pb.Block2, []*pb.InvalidTransaction, error)
+	SetTxValidator(validator TxValidator)
 	Commit() error
 	Rollback()
 	Shutdown()
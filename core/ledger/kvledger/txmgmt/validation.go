@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txmgmt
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+)
+
+// TxValidator performs the checks on a transaction that do not depend on the state,
+// such as the verification of the creator's signature and of the endorsements.
+// Since the transactions of a block are checked concurrently, implementations must be safe for concurrent use
+type TxValidator interface {
+	// ValidateTx returns false and the cause if the transaction is invalid.
+	// A non-nil error aborts the validation of the whole block
+	ValidateTx(env *common.Envelope) (bool, pb.InvalidTransaction_Cause, error)
+}
+
+// PreprocessedTx holds the outcome of the first (concurrent) validation stage for a transaction
+type PreprocessedTx struct {
+	EnvBytes []byte
+	RWSet    *TxReadWriteSet
+	// Valid is false if the transaction failed the checks of the `TxValidator`
+	Valid bool
+	Cause pb.InvalidTransaction_Cause
+	// Err is set if the transaction could not be decoded or validated
+	Err error
+}
+
+// PreprocessBlock runs the first validation stage on all the transactions of a block using at most `workers`
+// goroutines (runtime.NumCPU() if workers <= 0). Each transaction is unmarshalled, its RWSet is extracted and it
+// is checked with `validator` (if not nil). The results are returned in block order so that the
+// second stage (MVCC checks) can be performed serially
+func PreprocessBlock(block *pb.Block2, validator TxValidator, workers int) []*PreprocessedTx {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(block.Transactions) {
+		workers = len(block.Transactions)
+	}
+	results := make([]*PreprocessedTx, len(block.Transactions))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = preprocessTx(block.Transactions[i], validator)
+			}
+		}()
+	}
+	for i := range block.Transactions {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+func preprocessTx(envBytes []byte, validator TxValidator) *PreprocessedTx {
+	tx := &PreprocessedTx{EnvBytes: envBytes}
+	env, err := putils.GetEnvelope(envBytes)
+	if err != nil {
+		tx.Err = err
+		return tx
+	}
+	// extract actions from the envelope message
+	respPayload, err := putils.GetActionFromEnvelope(envBytes)
+	if err != nil {
+		tx.Err = err
+		return tx
+	}
+
+	// Get the Result from the Action
+	// and then Unmarshal it into a TxReadWriteSet using custom unmarshalling
+	txRWSet := &TxReadWriteSet{}
+	if err = txRWSet.Unmarshal(respPayload.Results); err != nil {
+		tx.Err = err
+		return tx
+	}
+	tx.RWSet = txRWSet
+
+	if validator == nil {
+		tx.Valid = true
+		return tx
+	}
+	tx.Valid, tx.Cause, tx.Err = validator.ValidateTx(env)
+	return tx
+}
+
+// CheckReadVersions checks that the versions of the keys read in the simulation `results`
+// of a transaction are the versions in the current state. The keys are read again with `sim`,
+// which must be a fresh simulator, and the versions it records are compared to the ones in `results`
+func CheckReadVersions(results []byte, sim ledger.TxSimulator) error {
+	txRWSet := &TxReadWriteSet{}
+	if err := txRWSet.Unmarshal(results); err != nil {
+		return err
+	}
+	for _, nsRWSet := range txRWSet.NsRWs {
+		for _, kvRead := range nsRWSet.Reads {
+			if _, err := sim.GetState(nsRWSet.NameSpace, kvRead.Key); err != nil {
+				return err
+			}
+		}
+	}
+	currentResults, err := sim.GetTxSimulationResults()
+	if err != nil {
+		return err
+	}
+	current := &TxReadWriteSet{}
+	if err = current.Unmarshal(currentResults); err != nil {
+		return err
+	}
+	currentVersions := make(map[string]map[string]uint64)
+	for _, nsRWSet := range current.NsRWs {
+		versions := make(map[string]uint64)
+		for _, kvRead := range nsRWSet.Reads {
+			versions[kvRead.Key] = kvRead.Version
+		}
+		currentVersions[nsRWSet.NameSpace] = versions
+	}
+	for _, nsRWSet := range txRWSet.NsRWs {
+		for _, kvRead := range nsRWSet.Reads {
+			if version := currentVersions[nsRWSet.NameSpace][kvRead.Key]; version != kvRead.Version {
+				return fmt.Errorf("version of key [%s:%s] is %d in the state, %d was read", nsRWSet.NameSpace, kvRead.Key, version, kvRead.Version)
+			}
+		}
+	}
+	return nil
+}
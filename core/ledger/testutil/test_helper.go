@@ -27,7 +27,7 @@ import (
 )
 
 // ConstructBlockForSimulationResults constructs a block that includes a number of transactions - one per simulationResults
-func ConstructBlockForSimulationResults(t *testing.T, simulationResults [][]byte, sign bool) *pb.Block2 {
+func ConstructBlockForSimulationResults(t testing.TB, simulationResults [][]byte, sign bool) *pb.Block2 {
 	envs := []*common.Envelope{}
 	for i := 0; i < len(simulationResults); i++ {
 		env, err := ConstructTestTransaction(t, simulationResults[i], sign)
@@ -59,7 +59,7 @@ func ConstructTestBlock(t *testing.T, numTx int, txSize int, startingTxID int) *
 }
 
 // ConstructTestTransaction constructs a transaction for testing
-func ConstructTestTransaction(t *testing.T, simulationResults []byte, sign bool) (*common.Envelope, error) {
+func ConstructTestTransaction(t testing.TB, simulationResults []byte, sign bool) (*common.Envelope, error) {
 	ccName := "foo"
 	txID := util.GenerateUUID()
 	if sign {
@@ -22,10 +22,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 
 	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/util"
+	"github.com/hyperledger/fabric/msp"
 	ehpb "github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -36,10 +39,12 @@ type EventsClient struct {
 	regTimeout  time.Duration
 	stream      ehpb.Events_ChatClient
 	adapter     EventAdapter
+	signer      msp.SigningIdentity
 }
 
 //NewEventsClient Returns a new grpc.ClientConn to the configured local PEER.
-func NewEventsClient(peerAddress string, regTimeout time.Duration, adapter EventAdapter) (*EventsClient, error) {
+//The events sent to the PEER are signed with the given signing identity
+func NewEventsClient(peerAddress string, regTimeout time.Duration, adapter EventAdapter, signer msp.SigningIdentity) (*EventsClient, error) {
 	var err error
 	if regTimeout < 100*time.Millisecond {
 		regTimeout = 100 * time.Millisecond
@@ -48,7 +53,7 @@ func NewEventsClient(peerAddress string, regTimeout time.Duration, adapter Event
 		regTimeout = 60 * time.Second
 		err = fmt.Errorf("regTimeout > 60, setting to 60 sec")
 	}
-	return &EventsClient{sync.RWMutex{}, peerAddress, regTimeout, nil, adapter}, err
+	return &EventsClient{sync.RWMutex{}, peerAddress, regTimeout, nil, adapter, signer}, err
 }
 
 //newEventsClientConnectionWithAddress Returns a new grpc.ClientConn to the configured local PEER.
@@ -59,10 +64,33 @@ func newEventsClientConnectionWithAddress(peerAddress string) (*grpc.ClientConn,
 	return comm.NewClientConnectionWithAddress(peerAddress, true, false, nil)
 }
 
+//signEvent sets the creator and the timestamp of the event and signs it
+func (ec *EventsClient) signEvent(emsg *ehpb.Event) (*ehpb.SignedEvent, error) {
+	creator, err := ec.signer.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing the signing identity: %s", err)
+	}
+	emsg.Creator = creator
+	emsg.Timestamp = util.CreateUtcTimestamp()
+	eventBytes, err := proto.Marshal(emsg)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling the event: %s", err)
+	}
+	signature, err := ec.signer.Sign(eventBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error signing the event: %s", err)
+	}
+	return &ehpb.SignedEvent{Signature: signature, EventBytes: eventBytes}, nil
+}
+
 func (ec *EventsClient) send(emsg *ehpb.Event) error {
+	signedEvt, err := ec.signEvent(emsg)
+	if err != nil {
+		return err
+	}
 	ec.Lock()
 	defer ec.Unlock()
-	return ec.stream.Send(emsg)
+	return ec.stream.Send(signedEvt)
 }
 
 // RegisterAsync - registers interest in a event and doesn't wait for a response
@@ -24,10 +24,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
 	"github.com/hyperledger/fabric/events/consumer"
 	"github.com/hyperledger/fabric/events/producer"
+	"github.com/hyperledger/fabric/msp"
 	ehpb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
@@ -42,6 +47,7 @@ type Adapter struct {
 var peerAddress string
 var adapter *Adapter
 var obcEHClient *consumer.EventsClient
+var signer msp.SigningIdentity
 
 func (a *Adapter) GetInterestedEvents() ([]*ehpb.Interest, error) {
 	return []*ehpb.Interest{
@@ -245,6 +251,85 @@ func TestUnregister(t *testing.T) {
 
 }
 
+type rejectionAdapter struct {
+	Adapter
+}
+
+func (a *rejectionAdapter) GetInterestedEvents() ([]*ehpb.Interest, error) {
+	return []*ehpb.Interest{&ehpb.Interest{EventType: ehpb.EventType_REJECTION}}, nil
+}
+
+func TestRegistrationNotAuthorized(t *testing.T) {
+	// the registration policy does not allow any MSP to register for rejections
+	client, _ := consumer.NewEventsClient(peerAddress, 2*time.Second, &rejectionAdapter{}, signer)
+	defer client.Stop()
+	if err := client.Start(); err == nil {
+		t.Fatalf("Registration for rejection events should have been rejected")
+	}
+}
+
+// sendRawEvent sends a signed event on a new stream and returns the error
+// with which the producer ends the stream, if any
+func sendRawEvent(t *testing.T, signedEvt *ehpb.SignedEvent) error {
+	conn, err := grpc.Dial(peerAddress, grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(2*time.Second))
+	if err != nil {
+		t.Fatalf("Could not connect to the events server: %s", err)
+	}
+	defer conn.Close()
+	stream, err := ehpb.NewEventsClient(conn).Chat(context.Background())
+	if err != nil {
+		t.Fatalf("Could not create the chat stream: %s", err)
+	}
+	if err = stream.Send(signedEvt); err != nil {
+		t.Fatalf("Could not send the event: %s", err)
+	}
+	_, err = stream.Recv()
+	return err
+}
+
+func createSignedRegistration(t *testing.T, timestamp time.Time) (*ehpb.Event, *ehpb.SignedEvent) {
+	creator, _ := signer.Serialize()
+	ts, _ := ptypes.TimestampProto(timestamp)
+	evt := &ehpb.Event{
+		Event:     &ehpb.Event_Register{Register: &ehpb.Register{Events: []*ehpb.Interest{&ehpb.Interest{EventType: ehpb.EventType_BLOCK}}}},
+		Creator:   creator,
+		Timestamp: ts,
+	}
+	evtBytes, _ := proto.Marshal(evt)
+	sig, err := signer.Sign(evtBytes)
+	if err != nil {
+		t.Fatalf("Could not sign the event: %s", err)
+	}
+	return evt, &ehpb.SignedEvent{Signature: sig, EventBytes: evtBytes}
+}
+
+func TestSignedRegistration(t *testing.T) {
+	_, signedEvt := createSignedRegistration(t, time.Now())
+	if err := sendRawEvent(t, signedEvt); err != nil {
+		t.Fatalf("Valid registration failed: %s", err)
+	}
+}
+
+func TestInvalidSignature(t *testing.T) {
+	_, signedEvt := createSignedRegistration(t, time.Now())
+	signedEvt.Signature[len(signedEvt.Signature)-1] ^= 0xff
+	if err := sendRawEvent(t, signedEvt); err == nil {
+		t.Fatalf("Registration with an invalid signature should have been rejected")
+	}
+
+	signedEvt.Signature = nil
+	if err := sendRawEvent(t, signedEvt); err == nil {
+		t.Fatalf("Unsigned registration should have been rejected")
+	}
+}
+
+func TestStaleTimestamp(t *testing.T) {
+	_, signedEvt := createSignedRegistration(t, time.Now().Add(-2*time.Minute))
+	if err := sendRawEvent(t, signedEvt); err == nil {
+		t.Fatalf("Registration with a stale timestamp should have been rejected")
+	}
+}
+
 func BenchmarkMessages(b *testing.B) {
 	numMessages := 10000
 
@@ -294,9 +379,27 @@ func TestMain(m *testing.M) {
 		return
 	}
 
+	// setup the MSP manager so that we can sign/verify
+	primitives.SetSecurityLevel("SHA2", 256)
+	if err = msp.GetManager().Setup("../msp/peer-config.json"); err != nil {
+		fmt.Printf("Could not setup the MSP manager %s....not doing tests", err)
+		return
+	}
+	signer, err = msp.GetManager().GetSigningIdentity(&msp.IdentityIdentifier{Mspid: msp.ProviderIdentifier{Value: "DEFAULT"}, Value: "PEER"})
+	if err != nil {
+		fmt.Printf("Could not get the signing identity %s....not doing tests", err)
+		return
+	}
+
 	// Register EventHub server
 	// use a buffer of 100 and blocking timeout
-	ehServer := producer.NewEventsServer(100, 0)
+	ehServer := producer.NewEventsServer(100, 0, &producer.RegistrationPolicy{
+		TimeWindow: time.Minute,
+		ACL: map[ehpb.EventType][]string{
+			ehpb.EventType_BLOCK:     []string{"DEFAULT"},
+			ehpb.EventType_CHAINCODE: []string{"DEFAULT"},
+		},
+	})
 	ehpb.RegisterEventsServer(grpcServer, ehServer)
 
 	fmt.Printf("Starting events server\n")
@@ -305,7 +408,7 @@ func TestMain(m *testing.M) {
 	var regTimeout = 5 * time.Second
 	done := make(chan struct{})
 	adapter = &Adapter{notfy: done}
-	obcEHClient, _ = consumer.NewEventsClient(peerAddress, regTimeout, adapter)
+	obcEHClient, _ = consumer.NewEventsClient(peerAddress, regTimeout, adapter, signer)
 	if err = obcEHClient.Start(); err != nil {
 		fmt.Printf("could not start chat %s\n", err)
 		obcEHClient.Stop()
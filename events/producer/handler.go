@@ -20,17 +20,20 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/hyperledger/fabric/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 type handler struct {
 	ChatStream       pb.Events_ChatServer
 	interestedEvents map[string]*pb.Interest
+	policy           *RegistrationPolicy
 }
 
-func newEventHandler(stream pb.Events_ChatServer) (*handler, error) {
+func newEventHandler(stream pb.Events_ChatServer, policy *RegistrationPolicy) (*handler, error) {
 	d := &handler{
 		ChatStream: stream,
+		policy:     policy,
 	}
 	d.interestedEvents = make(map[string]*pb.Interest)
 	return d, nil
@@ -58,6 +61,17 @@ func getInterestKey(interest pb.Interest) string {
 	return key
 }
 
+// authorize checks that the creator of a registration can register for all the interests
+func (d *handler) authorize(iMsg []*pb.Interest, creator msp.Identity) error {
+	mspID := creator.GetMSPIdentifier()
+	for _, v := range iMsg {
+		if !d.policy.allowed(v.EventType, mspID) {
+			return fmt.Errorf("members of MSP %s are not authorized to register for %s events", mspID, v.EventType)
+		}
+	}
+	return nil
+}
+
 func (d *handler) register(iMsg []*pb.Interest) error {
 	// Could consider passing interest array to registerHandler
 	// and only lock once for entire array here
@@ -93,12 +107,19 @@ func (d *handler) deregisterAll() {
 	}
 }
 
-// HandleMessage handles the Openchain messages for the Peer.
-func (d *handler) HandleMessage(msg *pb.Event) error {
-	//producerLogger.Debug("Handling Event")
+// HandleMessage handles the messages sent by a consumer. The message must be
+// signed by its creator and registrations must be allowed by the registration policy
+func (d *handler) HandleMessage(signedEvt *pb.SignedEvent) error {
+	msg, creator, err := validateEventMessage(signedEvt, d.policy.TimeWindow)
+	if err != nil {
+		return fmt.Errorf("Invalid event from client: %s", err)
+	}
 	switch msg.Event.(type) {
 	case *pb.Event_Register:
 		eventsObj := msg.GetRegister()
+		if err := d.authorize(eventsObj.Events, creator); err != nil {
+			return fmt.Errorf("Registration rejected: %s", err)
+		}
 		if err := d.register(eventsObj.Events); err != nil {
 			return fmt.Errorf("Could not register events %s", err)
 		}
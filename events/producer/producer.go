@@ -31,17 +31,19 @@ var producerLogger = logging.MustGetLogger("eventhub_producer")
 
 // EventsServer implementation of the Peer service
 type EventsServer struct {
+	policy *RegistrationPolicy
 }
 
 //singleton - if we want to create multiple servers, we need to subsume events.gEventConsumers into EventsServer
 var globalEventsServer *EventsServer
 
-// NewEventsServer returns a EventsServer
-func NewEventsServer(bufferSize uint, timeout int) *EventsServer {
+// NewEventsServer returns a EventsServer. Consumers can register for events
+// only as allowed by the registration policy
+func NewEventsServer(bufferSize uint, timeout int, policy *RegistrationPolicy) *EventsServer {
 	if globalEventsServer != nil {
 		panic("Cannot create multiple event hub servers")
 	}
-	globalEventsServer = new(EventsServer)
+	globalEventsServer = &EventsServer{policy: policy}
 	initializeEvents(bufferSize, timeout)
 	//initializeCCEventProcessor(bufferSize, timeout)
 	return globalEventsServer
@@ -49,7 +51,7 @@ func NewEventsServer(bufferSize uint, timeout int) *EventsServer {
 
 // Chat implementation of the the Chat bidi streaming RPC function
 func (p *EventsServer) Chat(stream pb.Events_ChatServer) error {
-	handler, err := newEventHandler(stream)
+	handler, err := newEventHandler(stream, p.policy)
 	if err != nil {
 		return fmt.Errorf("Error creating handler during handleChat initiation: %s", err)
 	}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// RegistrationPolicy controls which consumers can register for events
+type RegistrationPolicy struct {
+	// TimeWindow is the maximum difference allowed between the timestamp
+	// of a consumer event and the local time
+	TimeWindow time.Duration
+
+	// ACL maps each event type to the identifiers of the MSPs whose members
+	// can register for it. Registrations for event types not in the map are rejected
+	ACL map[pb.EventType][]string
+}
+
+// allowed returns true if members of the given MSP can register for the event type
+func (p *RegistrationPolicy) allowed(eventType pb.EventType, mspID string) bool {
+	for _, id := range p.ACL[eventType] {
+		if id == mspID {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEventMessage checks the signature and the timestamp of an event sent
+// by a consumer, and returns the event along with the identity of its creator
+func validateEventMessage(signedEvt *pb.SignedEvent, timeWindow time.Duration) (*pb.Event, msp.Identity, error) {
+	evt := &pb.Event{}
+	if err := proto.Unmarshal(signedEvt.EventBytes, evt); err != nil {
+		return nil, nil, fmt.Errorf("Error unmarshalling the event bytes: %s", err)
+	}
+
+	if evt.Timestamp == nil {
+		return nil, nil, fmt.Errorf("Event has no timestamp")
+	}
+	ts, err := ptypes.Timestamp(evt.Timestamp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Invalid event timestamp: %s", err)
+	}
+	if delta := time.Since(ts); delta > timeWindow || delta < -timeWindow {
+		return nil, nil, fmt.Errorf("Event timestamp %s is more than %s apart from the local time", ts, timeWindow)
+	}
+
+	if len(evt.Creator) == 0 || len(signedEvt.Signature) == 0 {
+		return nil, nil, fmt.Errorf("Event is not signed")
+	}
+	creator, err := msp.GetManager().DeserializeIdentity(evt.Creator)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to deserialize the event creator: %s", err)
+	}
+	valid, err := creator.Validate()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not determine whether the event creator is valid: %s", err)
+	} else if !valid {
+		return nil, nil, fmt.Errorf("The event creator is not a valid identity")
+	}
+	verified, err := creator.Verify(signedEvt.EventBytes, signedEvt.Signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not determine whether the event signature is valid: %s", err)
+	} else if !verified {
+		return nil, nil, fmt.Errorf("The signature of the event is not valid")
+	}
+
+	return evt, creator, nil
+}
@@ -24,7 +24,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/hyperledger/fabric/core/crypto/primitives"
 	"github.com/hyperledger/fabric/events/consumer"
+	"github.com/hyperledger/fabric/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -76,13 +78,23 @@ func (a *adapter) Disconnected(err error) {
 	os.Exit(1)
 }
 
-func createEventClient(eventAddress string, listenToRejections bool, cid string) *adapter {
+//getSigner returns the identity with which the registration with the event hub is signed
+func getSigner(mspConfigFile string, mspID string, id string) (msp.SigningIdentity, error) {
+	primitives.SetSecurityLevel("SHA2", 256)
+	if err := msp.GetManager().Setup(mspConfigFile); err != nil {
+		return nil, fmt.Errorf("Error reading MSP config file %s: %s", mspConfigFile, err)
+	}
+	signingIdentity := &msp.IdentityIdentifier{Mspid: msp.ProviderIdentifier{Value: mspID}, Value: id}
+	return msp.GetManager().GetSigningIdentity(signingIdentity)
+}
+
+func createEventClient(eventAddress string, listenToRejections bool, cid string, signer msp.SigningIdentity) *adapter {
 	var obcEHClient *consumer.EventsClient
 
 	done := make(chan *pb.Event_Block)
 	reject := make(chan *pb.Event_Rejection)
 	adapter := &adapter{notfy: done, rejected: reject, listenToRejections: listenToRejections, chaincodeID: cid, cEvent: make(chan *pb.Event_ChaincodeEvent)}
-	obcEHClient, _ = consumer.NewEventsClient(eventAddress, 5, adapter)
+	obcEHClient, _ = consumer.NewEventsClient(eventAddress, 5, adapter, signer)
 	if err := obcEHClient.Start(); err != nil {
 		fmt.Printf("could not start chat %s\n", err)
 		obcEHClient.Stop()
@@ -96,14 +108,26 @@ func main() {
 	var eventAddress string
 	var listenToRejections bool
 	var chaincodeID string
+	var mspConfigFile string
+	var mspID string
+	var identity string
 	flag.StringVar(&eventAddress, "events-address", "0.0.0.0:7053", "address of events server")
 	flag.BoolVar(&listenToRejections, "listen-to-rejections", false, "whether to listen to rejection events")
 	flag.StringVar(&chaincodeID, "events-from-chaincode", "", "listen to events from given chaincode")
+	flag.StringVar(&mspConfigFile, "msp-config", os.Getenv("GOPATH")+"/src/github.com/hyperledger/fabric/msp/peer-config.json", "MSP configuration file")
+	flag.StringVar(&mspID, "msp-id", "DEFAULT", "identifier of the MSP of the signing identity")
+	flag.StringVar(&identity, "identity", "PEER", "identifier of the signing identity")
 	flag.Parse()
 
 	fmt.Printf("Event Address: %s\n", eventAddress)
 
-	a := createEventClient(eventAddress, listenToRejections, chaincodeID)
+	signer, err := getSigner(mspConfigFile, mspID, identity)
+	if err != nil {
+		fmt.Printf("Error getting the signing identity: %s\n", err)
+		return
+	}
+
+	a := createEventClient(eventAddress, listenToRejections, chaincodeID, signer)
 	if a == nil {
 		fmt.Printf("Error creating event client\n")
 		return
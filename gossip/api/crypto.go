@@ -39,12 +39,20 @@ type MessageCryptoService interface {
 	// If the verification succeeded, Verify returns nil meaning no error occurred.
 	// If peerCert is nil, then the signature is verified against this peer's verification key.
 	Verify(peerIdentity PeerIdentityType, signature, message []byte) error
+
+	// ValidateIdentity returns nil if the peer's identity is valid according to the MSP,
+	// else returns an error, for instance if it is expired or revoked
+	ValidateIdentity(peerIdentity PeerIdentityType) error
 }
 
 // PeerIdentityType is the peer's certificate
 type PeerIdentityType []byte
 
 // SignedBlock represents a fabric block that is signed according
-// to the latest block verification policy known to the peer
+// to the latest block verification policy known to the peer.
+// Blocks of the ordering service are *common.Block, whose signatures
+// implementations of VerifyBlock can check with a blocksig.Verifier.
+// Gossip state transfer verifies the blocks of the ordering service
+// carried by the payloads it receives
 type SignedBlock interface {
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package comm
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 
 	"github.com/hyperledger/fabric/gossip/common"
@@ -81,6 +83,19 @@ type SecurityProvider interface {
 	Verify(vkID, signature, message []byte) error
 }
 
+// TLSConfig defines the TLS certificate a comm instance presents to remote peers,
+// and the roots the certificate chains of remote peers are verified against
+type TLSConfig struct {
+
+	// Certificate is the TLS certificate and private key of this peer,
+	// which is also the identity its PKI-ID is derived from
+	Certificate tls.Certificate
+
+	// RootCAs returns the MSP root certificates of the organizations of the channels this peer is in.
+	// It is invoked for each new connection, so that the roots follow the channels joined by the peer
+	RootCAs func() *x509.CertPool
+}
+
 // ReceivedMessage is a GossipMessage wrapper that
 // enables the user to send a message to the origin from which
 // the ReceivedMessage was sent from
@@ -26,9 +26,10 @@ import (
 	"time"
 
 	"crypto/tls"
-	"os"
 
+	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/proto"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/op/go-logging"
@@ -64,6 +65,26 @@ func (c *commImpl) SetDialOpts(opts ...grpc.DialOption) {
 
 // NewCommInstanceWithServer creates a comm instance that creates an underlying gRPC server
 func NewCommInstanceWithServer(port int, sec SecurityProvider, pkID common.PKIidType, dialOpts ...grpc.DialOption) (Comm, error) {
+	return newCommInstanceWithServer(port, nil, nil, nil, sec, pkID, nil, dialOpts...)
+}
+
+// NewSecureCommInstanceWithServer creates a comm instance that creates an underlying gRPC server secured by mutual TLS.
+// The PKI-ID of the instance is the one mcs derives from the TLS certificate of tlsConf, and the connections of remote
+// peers whose TLS certificate chain isn't rooted in tlsConf.RootCAs, or doesn't belong to the PKI-ID they claim, are refused.
+// The identities of the peers, sent during the handshakes, are put in idMapper
+func NewSecureCommInstanceWithServer(port int, tlsConf *TLSConfig, idMapper identity.Mapper, mcs api.MessageCryptoService, sec SecurityProvider, dialOpts ...grpc.DialOption) (Comm, error) {
+	if len(tlsConf.Certificate.Certificate) == 0 {
+		return nil, fmt.Errorf("No TLS certificate given")
+	}
+	selfIdentity := certToIdentity(tlsConf.Certificate.Certificate[0])
+	pkID := mcs.GetPKIidOfCert(selfIdentity)
+	if err := idMapper.Put(pkID, selfIdentity); err != nil {
+		return nil, err
+	}
+	return newCommInstanceWithServer(port, tlsConf, idMapper, mcs, sec, pkID, selfIdentity, dialOpts...)
+}
+
+func newCommInstanceWithServer(port int, tlsConf *TLSConfig, idMapper identity.Mapper, mcs api.MessageCryptoService, sec SecurityProvider, pkID common.PKIidType, selfIdentity api.PeerIdentityType, dialOpts ...grpc.DialOption) (Comm, error) {
 	var ll net.Listener
 	var s *grpc.Server
 	var secOpt grpc.DialOption
@@ -73,7 +94,7 @@ func NewCommInstanceWithServer(port int, sec SecurityProvider, pkID common.PKIid
 	}
 
 	if port > 0 {
-		s, ll, secOpt = createGRPCLayer(port)
+		s, ll, secOpt = createGRPCLayer(port, tlsConf)
 		dialOpts = append(dialOpts, secOpt)
 	}
 
@@ -82,6 +103,10 @@ func NewCommInstanceWithServer(port int, sec SecurityProvider, pkID common.PKIid
 		PKIID:             pkID,
 		opts:              dialOpts,
 		sec:               sec,
+		tlsConf:           tlsConf,
+		mcs:               mcs,
+		identity:          selfIdentity,
+		idMapper:          idMapper,
 		port:              port,
 		lsnr:              ll,
 		gSrv:              s,
@@ -123,6 +148,10 @@ func NewCommInstance(s *grpc.Server, sec SecurityProvider, PKIID common.PKIidTyp
 type commImpl struct {
 	logger            *util.Logger
 	sec               SecurityProvider
+	tlsConf           *TLSConfig
+	mcs               api.MessageCryptoService
+	identity          api.PeerIdentityType
+	idMapper          identity.Mapper
 	opts              []grpc.DialOption
 	connStore         *connectionStore
 	PKIID             []byte
@@ -259,7 +288,11 @@ func (c *commImpl) Probe(peer *RemotePeer) error {
 
 	opts := c.opts
 	if opts == nil {
-		opts = []grpc.DialOption{grpc.WithInsecure(), grpc.WithTimeout(dialTimeout)}
+		ta := credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: true,
+			MaxVersion:         tls.VersionTLS12,
+		})
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(&authCreds{tlsCreds: ta}), grpc.WithTimeout(dialTimeout)}
 	}
 	cc, err := grpc.Dial(peer.Endpoint, append(opts, grpc.WithBlock())...)
 	if err != nil {
@@ -379,7 +412,7 @@ func (c *commImpl) authenticateRemotePeer(stream stream) (common.PKIidType, erro
 		}
 	}
 
-	cMsg := createConnectionMsg(c.PKIID, sig)
+	cMsg := createConnectionMsg(c.PKIID, c.identity, sig)
 	stream.Send(cMsg)
 	m := readWithTimeout(stream, defConnTimeout)
 	if m == nil {
@@ -404,10 +437,26 @@ func (c *commImpl) authenticateRemotePeer(stream stream) (common.PKIidType, erro
 		}
 	}
 
+	if c.tlsConf != nil {
+		err = c.verifyRemoteCertificate(ctx, connMsg.PkiID)
+		if err != nil {
+			c.logger.Warning("Failed verifying the TLS certificate of", remoteAddress, ":", err)
+			return nil, err
+		}
+	}
+
 	if connMsg.PkiID == nil {
 		return nil, fmt.Errorf("%s didn't send a pkiID", "Didn't send a pkiID")
 	}
 
+	if c.idMapper != nil {
+		err = c.idMapper.Put(connMsg.PkiID, connMsg.Cert)
+		if err != nil {
+			c.logger.Warning("Failed learning the identity of", remoteAddress, ":", err)
+			return nil, err
+		}
+	}
+
 	c.logger.Debug("Authenticated", remoteAddress)
 	return connMsg.PkiID, nil
 
@@ -484,13 +533,14 @@ func readWithTimeout(stream interface{}, timeout time.Duration) *proto.GossipMes
 	}
 }
 
-func createConnectionMsg(pkiID common.PKIidType, sig []byte) *proto.GossipMessage {
+func createConnectionMsg(pkiID common.PKIidType, cert api.PeerIdentityType, sig []byte) *proto.GossipMessage {
 	return &proto.GossipMessage{
 		Tag:   proto.GossipMessage_EMPTY,
 		Nonce: 0,
 		Content: &proto.GossipMessage_Conn{
 			Conn: &proto.ConnEstablish{
 				PkiID: pkiID,
+				Cert:  cert,
 				Sig:   sig,
 			},
 		},
@@ -503,30 +553,44 @@ type stream interface {
 	grpc.Stream
 }
 
-func createGRPCLayer(port int) (*grpc.Server, net.Listener, grpc.DialOption) {
+func createGRPCLayer(port int, tlsConf *TLSConfig) (*grpc.Server, net.Listener, grpc.DialOption) {
 	var s *grpc.Server
 	var ll net.Listener
 	var err error
 	var serverOpts []grpc.ServerOption
 	var dialOpts grpc.DialOption
 
-	keyFileName := fmt.Sprintf("key.%d.pem", time.Now().UnixNano())
-	certFileName := fmt.Sprintf("cert.%d.pem", time.Now().UnixNano())
-
-	defer os.Remove(keyFileName)
-	defer os.Remove(certFileName)
-
-	err = generateCertificates(keyFileName, certFileName)
-	if err == nil {
-		var creds credentials.TransportCredentials
-		creds, err = credentials.NewServerTLSFromFile(certFileName, keyFileName)
-		serverOpts = append(serverOpts, grpc.Creds(creds))
+	if tlsConf != nil {
+		// The certificate chains are verified against the roots of the channels known when
+		// the remote peer authenticates, in verifyRemoteCertificate, and not during the handshake.
+		// TLS 1.3 has no TLS-Unique for the authentication to sign, hence the maximal version
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{tlsConf.Certificate},
+			ClientAuth:   tls.RequireAnyClientCert,
+			MaxVersion:   tls.VersionTLS12,
+		})))
 		ta := credentials.NewTLS(&tls.Config{
+			Certificates:       []tls.Certificate{tlsConf.Certificate},
 			InsecureSkipVerify: true,
+			MaxVersion:         tls.VersionTLS12,
 		})
 		dialOpts = grpc.WithTransportCredentials(&authCreds{tlsCreds: ta})
 	} else {
-		dialOpts = grpc.WithInsecure()
+		// Without a TLS configuration the connections are still encrypted with a self-signed
+		// certificate, and the peers authenticate by signing the TLS-Unique
+		cert, err := generateSelfSignedCertificate()
+		if err != nil {
+			panic(err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MaxVersion:   tls.VersionTLS12,
+		})))
+		ta := credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: true,
+			MaxVersion:         tls.VersionTLS12,
+		})
+		dialOpts = grpc.WithTransportCredentials(&authCreds{tlsCreds: ta})
 	}
 
 	listenAddress := fmt.Sprintf("%s:%d", "", port)
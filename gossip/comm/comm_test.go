@@ -18,6 +18,8 @@ package comm
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -26,7 +28,9 @@ import (
 
 	"crypto/tls"
 
+	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/proto"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
@@ -63,6 +67,54 @@ func (*naiveSecProvider) Verify(vkID, signature, message []byte) error {
 	return fmt.Errorf("Failed verifying")
 }
 
+// naiveMCSImpl derives the PKI-ID of a peer from the hash of its identity
+type naiveMCSImpl struct {
+	naiveSecProvider
+}
+
+var naiveMCS = &naiveMCSImpl{}
+
+func (*naiveMCSImpl) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
+	digest := sha256.Sum256(peerIdentity)
+	return digest[:]
+}
+
+func (*naiveMCSImpl) VerifyBlock(signedBlock api.SignedBlock) error {
+	return nil
+}
+
+func (*naiveMCSImpl) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
+	return nil
+}
+
+func (*naiveMCSImpl) Verify(peerIdentity api.PeerIdentityType, signature, message []byte) error {
+	return naiveSec.Verify(peerIdentity, signature, message)
+}
+
+// impostorMCS claims the PKI-ID of another peer whatever its certificate
+type impostorMCS struct {
+	naiveMCSImpl
+	pkiID common.PKIidType
+}
+
+func (mcs *impostorMCS) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
+	return mcs.pkiID
+}
+
+func newSecureCommInstance(port int, ca *testCA, mcs api.MessageCryptoService) (Comm, error) {
+	return newSecureCommInstanceWithRoots(port, ca, ca.roots, mcs)
+}
+
+// newSecureCommInstanceWithRoots creates an instance whose certificate is issued by ca, trusting the CAs of roots
+func newSecureCommInstanceWithRoots(port int, ca *testCA, roots func() *x509.CertPool, mcs api.MessageCryptoService) (Comm, error) {
+	cert, err := ca.newCertificate()
+	if err != nil {
+		return nil, err
+	}
+	idMapper := identity.NewIdentityMapper(mcs)
+	return NewSecureCommInstanceWithServer(port, &TLSConfig{Certificate: cert, RootCAs: roots}, idMapper, mcs, naiveSec)
+}
+
 func newCommInstance(port int, sec SecurityProvider) (Comm, error) {
 	endpoint := fmt.Sprintf("localhost:%d", port)
 	inst, err := NewCommInstanceWithServer(port, sec, []byte(endpoint))
@@ -70,11 +122,19 @@ func newCommInstance(port int, sec SecurityProvider) (Comm, error) {
 }
 
 func TestHandshake(t *testing.T) {
-	comm1, _ := newCommInstance(9611, naiveSec)
+	ca, err := newTestCA()
+	assert.NoError(t, err, "%v", err)
+	comm1, err := newSecureCommInstance(9611, ca, naiveMCS)
+	assert.NoError(t, err, "%v", err)
 	defer comm1.Stop()
 
+	clientCert, err := ca.newCertificate()
+	assert.NoError(t, err, "%v", err)
+	clientPKIid := naiveMCS.GetPKIidOfCert(certToIdentity(clientCert.Certificate[0]))
 	ta := credentials.NewTLS(&tls.Config{
+		Certificates:       []tls.Certificate{clientCert},
 		InsecureSkipVerify: true,
+		MaxVersion:         tls.VersionTLS12,
 	})
 	conn, err := grpc.Dial("localhost:9611", grpc.WithTransportCredentials(&authCreds{tlsCreds: ta}), grpc.WithBlock(), grpc.WithTimeout(time.Second))
 	assert.NoError(t, err, "%v", err)
@@ -92,12 +152,12 @@ func TestHandshake(t *testing.T) {
 	clientTLSUnique := ExtractTLSUnique(stream.Context())
 	sig, err := naiveSec.Sign(clientTLSUnique)
 	assert.NoError(t, err, "%v", err)
-	msg := createConnectionMsg(common.PKIidType("localhost:9610"), sig)
+	msg := createConnectionMsg(clientPKIid, certToIdentity(clientCert.Certificate[0]), sig)
 	stream.Send(msg)
 	msg, err = stream.Recv()
 	assert.NoError(t, err, "%v", err)
 	assert.Equal(t, clientTLSUnique, msg.GetConn().Sig)
-	assert.Equal(t, []byte("localhost:9611"), msg.GetConn().PkiID)
+	assert.Equal(t, []byte(comm1.GetPKIid()), msg.GetConn().PkiID)
 	time.Sleep(time.Second)
 	msg2Send := createGossipMsg()
 	nonce := uint64(rand.Int())
@@ -151,10 +211,10 @@ func TestHandshake(t *testing.T) {
 	} else {
 		sig[0] = 0
 	}
-	msg = createConnectionMsg(common.PKIidType("localhost:9612"), sig)
+	msg = createConnectionMsg(clientPKIid, certToIdentity(clientCert.Certificate[0]), sig)
 	stream.Send(msg)
 	msg, err = stream.Recv()
-	assert.Equal(t, []byte("localhost:9611"), msg.GetConn().PkiID)
+	assert.Equal(t, []byte(comm1.GetPKIid()), msg.GetConn().PkiID)
 	assert.NoError(t, err, "%v", err)
 	msg2Send = createGossipMsg()
 	nonce = uint64(rand.Int())
@@ -183,6 +243,104 @@ func TestBasic(t *testing.T) {
 	waitForMessages(t, out, 2, "Didn't receive 2 messages")
 }
 
+func TestSecureConnections(t *testing.T) {
+	t.Parallel()
+	ca, err := newTestCA()
+	assert.NoError(t, err, "%v", err)
+	foreignCA, err := newTestCA()
+	assert.NoError(t, err, "%v", err)
+
+	comm1, err := newSecureCommInstance(10611, ca, naiveMCS)
+	assert.NoError(t, err, "%v", err)
+	comm2, err := newSecureCommInstance(10612, ca, naiveMCS)
+	assert.NoError(t, err, "%v", err)
+	foreign, err := newSecureCommInstance(10613, foreignCA, naiveMCS)
+	assert.NoError(t, err, "%v", err)
+	impostor, err := newSecureCommInstance(10614, ca, &impostorMCS{pkiID: comm2.GetPKIid()})
+	assert.NoError(t, err, "%v", err)
+	defer comm1.Stop()
+	defer comm2.Stop()
+	defer foreign.Stop()
+	defer impostor.Stop()
+
+	reader := func(out chan uint64, in <-chan ReceivedMessage) {
+		for {
+			msg := <-in
+			if msg == nil {
+				return
+			}
+			out <- msg.GetGossipMessage().Nonce
+		}
+	}
+	out1 := make(chan uint64, 4)
+	out2 := make(chan uint64, 4)
+	outForeign := make(chan uint64, 4)
+	go reader(out1, comm1.Accept(acceptAll))
+	go reader(out2, comm2.Accept(acceptAll))
+	go reader(outForeign, foreign.Accept(acceptAll))
+
+	peerOf := func(port int, c Comm) *RemotePeer {
+		return &RemotePeer{Endpoint: fmt.Sprintf("localhost:%d", port), PKIID: c.GetPKIid()}
+	}
+
+	comm1.Send(createGossipMsg(), peerOf(10612, comm2))
+	waitForMessages(t, out2, 1, "comm2 should have received 1 message")
+
+	// the certificate of foreign isn't issued by the CA of comm1, and vice versa
+	foreign.Send(createGossipMsg(), peerOf(10611, comm1))
+	comm1.Send(createGossipMsg(), peerOf(10613, foreign))
+	// the certificate of impostor doesn't belong to the PKI-ID of comm2 it claims
+	impostor.Send(createGossipMsg(), peerOf(10611, comm1))
+
+	time.Sleep(time.Second * 2)
+	assert.Equal(t, 0, len(out1), "comm1 shouldn't have received messages")
+	assert.Equal(t, 0, len(outForeign), "foreign shouldn't have received messages")
+}
+
+func TestClientRejectsUntrustedServer(t *testing.T) {
+	t.Parallel()
+	ca, err := newTestCA()
+	assert.NoError(t, err, "%v", err)
+	foreignCA, err := newTestCA()
+	assert.NoError(t, err, "%v", err)
+	bothRoots := func() *x509.CertPool {
+		pool := ca.roots()
+		pool.AddCert(foreignCA.cert)
+		return pool
+	}
+
+	// server trusts the CAs of both clients, so it never refuses their connections
+	server, err := newSecureCommInstanceWithRoots(10621, foreignCA, bothRoots, naiveMCS)
+	assert.NoError(t, err, "%v", err)
+	trustingClient, err := newSecureCommInstanceWithRoots(10622, ca, bothRoots, naiveMCS)
+	assert.NoError(t, err, "%v", err)
+	client, err := newSecureCommInstance(10623, ca, naiveMCS)
+	assert.NoError(t, err, "%v", err)
+	defer server.Stop()
+	defer trustingClient.Stop()
+	defer client.Stop()
+
+	out := make(chan uint64, 2)
+	go func(in <-chan ReceivedMessage) {
+		for {
+			msg := <-in
+			if msg == nil {
+				return
+			}
+			out <- msg.GetGossipMessage().Nonce
+		}
+	}(server.Accept(acceptAll))
+	serverPeer := &RemotePeer{Endpoint: "localhost:10621", PKIID: server.GetPKIid()}
+
+	trustingClient.Send(createGossipMsg(), serverPeer)
+	waitForMessages(t, out, 1, "server should have received the message of the client trusting its CA")
+
+	// the certificate of server isn't issued by the CA client trusts
+	client.Send(createGossipMsg(), serverPeer)
+	time.Sleep(time.Second * 2)
+	assert.Equal(t, 0, len(out), "server shouldn't have received the message of the client not trusting its CA")
+}
+
 func TestBlackListPKIid(t *testing.T) {
 	t.Parallel()
 	comm1, _ := newCommInstance(1611, naiveSec)
@@ -441,6 +599,28 @@ func TestProbe(t *testing.T) {
 	assert.NoError(t, comm1.Probe(remotePeer(6612)))
 }
 
+func TestSelfSignedTLS(t *testing.T) {
+	t.Parallel()
+	comm1, _ := newCommInstance(6613, naiveSec)
+	defer comm1.Stop()
+	time.Sleep(time.Duration(500) * time.Millisecond)
+
+	// An instance without a TLS configuration still only accepts TLS connections
+	cc, err := grpc.Dial("localhost:6613", grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second))
+	if err == nil {
+		defer cc.Close()
+		_, err = proto.NewGossipClient(cc).Ping(context.Background(), &proto.Empty{})
+	}
+	assert.Error(t, err, "A plaintext connection should be refused")
+
+	ta := credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})
+	cc, err = grpc.Dial("localhost:6613", grpc.WithTransportCredentials(ta), grpc.WithBlock(), grpc.WithTimeout(time.Second))
+	assert.NoError(t, err)
+	defer cc.Close()
+	_, err = proto.NewGossipClient(cc).Ping(context.Background(), &proto.Empty{})
+	assert.NoError(t, err)
+}
+
 func TestPresumedDead(t *testing.T) {
 	t.Parallel()
 	comm1, _ := newCommInstance(4611, naiveSec)
@@ -500,3 +680,18 @@ func waitForMessages(t *testing.T, msgChan chan uint64, count int, errMsg string
 	}
 	assert.Equal(t, count, c, errMsg)
 }
+
+func TestChainLabelBounded(t *testing.T) {
+	chainLabels.Lock()
+	chainLabels.values = make(map[string]bool)
+	chainLabels.Unlock()
+
+	assert.Equal(t, "A", chainLabel([]byte("A")))
+	for i := 0; i < 2*maxChainLabels; i++ {
+		chainLabel([]byte(fmt.Sprintf("chain%d", i)))
+	}
+	assert.Len(t, chainLabels.values, maxChainLabels)
+	assert.Equal(t, "A", chainLabel([]byte("A")), "A chain labelled before the bound was reached keeps its label")
+	assert.Equal(t, otherChains, chainLabel([]byte("B")))
+	assert.Equal(t, otherChains, chainLabel(bytes.Repeat([]byte("A"), maxChainLabelLength+1)))
+}
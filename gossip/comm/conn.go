@@ -18,15 +18,65 @@ package comm
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/proto"
 	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/metrics"
 	"google.golang.org/grpc"
 )
 
+var (
+	messagesSent = metrics.NewCounter("gossip_messages_sent_total",
+		"Number of gossip messages sent to remote peers", "chain", "type")
+	messagesReceived = metrics.NewCounter("gossip_messages_received_total",
+		"Number of gossip messages received from remote peers", "chain", "type")
+)
+
+// otherChains labels the messages of the chains beyond maxChainLabels, and of
+// chain names longer than maxChainLabelLength
+const otherChains = "other"
+
+// maxChainLabels bounds the number of chains with a chain label of their own.
+// The chain of a message is chosen by the remote peer, so it must not be able
+// to create label values at will
+const maxChainLabels = 100
+
+// maxChainLabelLength bounds the length of a chain label
+const maxChainLabelLength = 64
+
+// chainLabels holds the chain label values given so far
+var chainLabels = struct {
+	sync.Mutex
+	values map[string]bool
+}{values: make(map[string]bool)}
+
+// chainLabel returns the value of the chain label of the messages of chain
+func chainLabel(chain []byte) string {
+	if len(chain) > maxChainLabelLength {
+		return otherChains
+	}
+
+	label := string(chain)
+	chainLabels.Lock()
+	defer chainLabels.Unlock()
+	if !chainLabels.values[label] {
+		if len(chainLabels.values) >= maxChainLabels {
+			return otherChains
+		}
+		chainLabels.values[label] = true
+	}
+	return label
+}
+
+// messageType returns the name of the content of a gossip message, such as "AliveMsg"
+func messageType(msg *proto.GossipMessage) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", msg.Content), "*proto.GossipMessage_")
+}
+
 type handler func(*proto.GossipMessage)
 
 type connFactory interface {
@@ -280,6 +330,7 @@ func (conn *connection) serviceConnection() error {
 		case err := <-errChan:
 			return err
 		case msg := <-msgChan:
+			messagesReceived.Inc(chainLabel(msg.Channel), messageType(msg))
 			conn.handler(msg)
 		}
 	}
@@ -300,6 +351,7 @@ func (conn *connection) writeToStream() {
 				go m.onErr(err)
 				return
 			}
+			messagesSent.Inc(chainLabel(m.msg.Channel), messageType(m.msg))
 			break
 		case stop := <-conn.stopChan:
 			conn.logger.Warning("Closing writing to stream")
@@ -17,39 +17,38 @@ limitations under the License.
 package comm
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"math/big"
-	"os"
 
 	"crypto/tls"
 	"net"
 	"time"
 
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 )
 
-func writeFile(filename string, keyType string, data []byte) error {
-	f, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	return pem.Encode(f, &pem.Block{Type: keyType, Bytes: data})
-}
-
-func generateCertificates(privKeyFile string, certKeyFile string) error {
+// generateSelfSignedCertificate creates the TLS certificate of an instance which was not given one,
+// so that its connections are still encrypted and carry a TLS-Unique for the peers to sign
+func generateSelfSignedCertificate() (tls.Certificate, error) {
 	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
-		return err
+		return tls.Certificate{}, err
 	}
 
 	sn, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
 	template := x509.Certificate{
 		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
 		SerialNumber: sn,
@@ -57,22 +56,30 @@ func generateCertificates(privKeyFile string, certKeyFile string) error {
 	}
 	rawBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
 	if err != nil {
-		return err
-	}
-	err = writeFile(certKeyFile, "CERTIFICATE", rawBytes)
-	if err != nil {
-		return err
+		return tls.Certificate{}, err
 	}
-	privBytes, err := x509.MarshalECPrivateKey(privateKey)
-	if err != nil {
-		return err
-	}
-	err = writeFile(privKeyFile, "EC PRIVATE KEY", privBytes)
-	return err
+	return tls.Certificate{Certificate: [][]byte{rawBytes}, PrivateKey: privateKey}, nil
 }
 
 // ExtractTLSUnique extracts the TLS-Unique from the stream
 func ExtractTLSUnique(ctx context.Context) []byte {
+	state := extractTLSState(ctx)
+	if state == nil {
+		return nil
+	}
+	return state.TLSUnique
+}
+
+// ExtractPeerCertificates extracts the TLS certificate chain presented by the remote peer of the stream
+func ExtractPeerCertificates(ctx context.Context) []*x509.Certificate {
+	state := extractTLSState(ctx)
+	if state == nil {
+		return nil
+	}
+	return state.PeerCertificates
+}
+
+func extractTLSState(ctx context.Context) *tls.ConnectionState {
 	pr, extracted := peer.FromContext(ctx)
 	if !extracted {
 		return nil
@@ -87,7 +94,39 @@ func ExtractTLSUnique(ctx context.Context) []byte {
 	if !isTLSConn {
 		return nil
 	}
-	return tlsInfo.State.TLSUnique
+	return &tlsInfo.State
+}
+
+// certToIdentity returns the identity of a peer, which is its PEM encoded TLS certificate
+func certToIdentity(der []byte) api.PeerIdentityType {
+	return api.PeerIdentityType(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// verifyRemoteCertificate checks that the TLS certificate chain of the remote peer is rooted in
+// the MSP roots of this peer's channels, and that its certificate belongs to the PKI-ID it claims
+func (c *commImpl) verifyRemoteCertificate(ctx context.Context, pkiID common.PKIidType) error {
+	certs := ExtractPeerCertificates(ctx)
+	if len(certs) == 0 {
+		return fmt.Errorf("No TLS certificate presented")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         c.tlsConf.RootCAs(),
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	if _, err := certs[0].Verify(opts); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(c.mcs.GetPKIidOfCert(certToIdentity(certs[0].Raw)), pkiID) {
+		return fmt.Errorf("TLS certificate doesn't belong to %v", pkiID)
+	}
+	return nil
 }
 
 type authCreds struct {
@@ -18,10 +18,15 @@ package comm
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
+	"math/big"
 	"net"
-	"os"
 	"sync"
 	"testing"
 	"time"
@@ -63,20 +68,81 @@ func (s *gossipTestServer) Ping(context.Context, *proto.Empty) (*proto.Empty, er
 	return &proto.Empty{}, nil
 }
 
-func TestCertificateGeneration(t *testing.T) {
-	err := generateCertificates("key.pem", "cert.pem")
+// testCA issues the TLS certificates of the peers of the tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA() (*testCA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gossip test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &testCA{cert: cert, key: key}, nil
+}
+
+// newCertificate issues a TLS certificate usable both by clients and servers
+func (ca *testCA) newCertificate() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	sn, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: sn,
+		Subject:      pkix.Name{CommonName: "gossip test peer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{raw}, PrivateKey: key}, nil
+}
+
+func (ca *testCA) roots() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func TestTLSConnection(t *testing.T) {
+	ca, err := newTestCA()
 	assert.NoError(t, err, "%v", err)
 	if err != nil {
 		return
 	}
-	defer os.Remove("cert.pem")
-	defer os.Remove("key.pem")
-	var ll net.Listener
-	creds, err := credentials.NewServerTLSFromFile("cert.pem", "key.pem")
+	cert, err := ca.newCertificate()
 	assert.NoError(t, err, "%v", err)
 	if err != nil {
 		return
 	}
+	var ll net.Listener
+	creds := credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}})
 	s := grpc.NewServer(grpc.Creds(creds))
 	ll, err = net.Listen("tcp", fmt.Sprintf("%s:%d", "", 5611))
 	assert.NoError(t, err, "%v", err)
@@ -93,6 +159,7 @@ func TestCertificateGeneration(t *testing.T) {
 	time.Sleep(time.Second * time.Duration(2))
 	ta := credentials.NewTLS(&tls.Config{
 		InsecureSkipVerify: true,
+		MaxVersion:         tls.VersionTLS12,
 	})
 	assert.NoError(t, err, "%v", err)
 	if err != nil {
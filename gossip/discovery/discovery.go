@@ -80,4 +80,8 @@ type Discovery interface {
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
+
+	// Purge removes a member from the view, alive or dead,
+	// for instance because its identity is no longer valid
+	Purge(pkiID common.PKIidType)
 }
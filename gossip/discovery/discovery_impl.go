@@ -539,6 +539,33 @@ func (d *gossipDiscoveryImpl) expireDeadMembers(dead []common.PKIidType) {
 	}
 }
 
+func (d *gossipDiscoveryImpl) Purge(pkiID common.PKIidType) {
+	d.logger.Info("Purging", pkiID)
+
+	d.lock.Lock()
+
+	member, isKnown := d.id2Member[string(pkiID)]
+	delete(d.id2Member, string(pkiID))
+	delete(d.aliveLastTS, string(pkiID))
+	delete(d.deadLastTS, string(pkiID))
+
+	aliveMsgWithPKIid := &proto.AliveMessage{
+		Membership: &proto.Member{PkiID: pkiID},
+	}
+	if i := util.IndexInSlice(d.cachedMembership.Alive, aliveMsgWithPKIid, samePKIidAliveMessage); i != -1 {
+		d.cachedMembership.Alive = append(d.cachedMembership.Alive[:i], d.cachedMembership.Alive[i+1:]...)
+	}
+	if i := util.IndexInSlice(d.cachedMembership.Dead, aliveMsgWithPKIid, samePKIidAliveMessage); i != -1 {
+		d.cachedMembership.Dead = append(d.cachedMembership.Dead[:i], d.cachedMembership.Dead[i+1:]...)
+	}
+
+	d.lock.Unlock()
+
+	if isKnown {
+		d.comm.CloseConn(member)
+	}
+}
+
 func (d *gossipDiscoveryImpl) getDeadMembers() []common.PKIidType {
 	d.lock.RLock()
 	defer d.lock.RUnlock()
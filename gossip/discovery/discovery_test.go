@@ -372,6 +372,26 @@ func TestExpiration(t *testing.T) {
 	waitUntilOrFailBlocking(t, stopAction.Wait)
 }
 
+func TestPurge(t *testing.T) {
+	bootPeers := []string{bootPeer(7611)}
+	instances := []*gossipInstance{}
+	for i := 1; i <= 3; i++ {
+		instances = append(instances, createDiscoveryInstance(7610+i, fmt.Sprintf("d%d", i), bootPeers))
+	}
+
+	assertMembership(t, instances, 2)
+
+	// stop d3 so that it doesn't come back, and purge it before it expires
+	waitUntilOrFailBlocking(t, instances[2].Stop)
+	instances[0].Purge(common.PKIidType(bootPeer(7613)))
+
+	membership := instances[0].GetMembership()
+	assert.Len(t, membership, 1)
+	assert.Equal(t, bootPeer(7612), membership[0].Endpoint)
+
+	stopInstances(t, instances[:2])
+}
+
 func TestGetFullMembership(t *testing.T) {
 	nodeNum := 15
 	bootPeers := []string{bootPeer(5611), bootPeer(5612)}
@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gossip
+
+import (
+	pb "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/proto"
+	"github.com/hyperledger/fabric/gossip/util"
+)
+
+// aliveMsgCryptoService signs the alive messages of the peer with its MessageCryptoService,
+// and verifies those of the other peers against the identities held by the identity mapper,
+// whether they were learned in a connection handshake or through identity pull
+type aliveMsgCryptoService struct {
+	mcs      api.MessageCryptoService
+	idMapper identity.Mapper
+	logger   *util.Logger
+}
+
+// NewAliveMsgCryptoService creates a discovery.CryptoService signing alive messages with mcs,
+// and verifying them with the identities of idMapper
+func NewAliveMsgCryptoService(id string, mcs api.MessageCryptoService, idMapper identity.Mapper) discovery.CryptoService {
+	return &aliveMsgCryptoService{
+		mcs:      mcs,
+		idMapper: idMapper,
+		logger:   util.GetLogger(util.LOGGING_GOSSIP_MODULE, id),
+	}
+}
+
+// ValidateAliveMsg checks that the alive message is signed by the peer of its PKI-ID
+func (cs *aliveMsgCryptoService) ValidateAliveMsg(am *proto.AliveMessage) bool {
+	if am.Membership == nil {
+		return false
+	}
+	msg, err := signedAliveMsgBytes(am)
+	if err != nil {
+		cs.logger.Warning("Failed marshalling alive message:", err)
+		return false
+	}
+	if err := cs.idMapper.Verify(am.Membership.PkiID, am.Signature, msg); err != nil {
+		cs.logger.Debug("Failed verifying alive message of", am.Membership.Endpoint, ":", err)
+		return false
+	}
+	return true
+}
+
+// SignMessage signs an AliveMessage and updates its signature field
+func (cs *aliveMsgCryptoService) SignMessage(am *proto.AliveMessage) *proto.AliveMessage {
+	msg, err := signedAliveMsgBytes(am)
+	if err != nil {
+		cs.logger.Warning("Failed marshalling alive message:", err)
+		return am
+	}
+	signature, err := cs.mcs.Sign(msg)
+	if err != nil {
+		cs.logger.Warning("Failed signing alive message:", err)
+		return am
+	}
+	am.Signature = signature
+	return am
+}
+
+// signedAliveMsgBytes returns the bytes of an alive message covered by its signature
+func signedAliveMsgBytes(am *proto.AliveMessage) ([]byte, error) {
+	return pb.Marshal(&proto.AliveMessage{
+		Membership: am.Membership,
+		Timestamp:  am.Timestamp,
+	})
+}
@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gossip
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/gossip/algo"
+	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/proto"
+	"github.com/hyperledger/fabric/gossip/util"
+)
+
+var identityExpirationCheckInterval = time.Minute
+
+// SetIdentityExpirationCheckInterval sets the interval between checks of the identities' validity
+func SetIdentityExpirationCheckInterval(interval time.Duration) {
+	identityExpirationCheckInterval = interval
+}
+
+// certStore disseminates the identities of the peers with pull-based gossip.
+// Identities are items of its PullEngine, identified by a digest of their PKI-ID,
+// and the identities no longer valid are purged, along with their peers in the discovery view
+type certStore struct {
+	g        *gossipServiceImpl
+	idMapper identity.Mapper
+	pull     *algo.PullEngine
+	lock     sync.RWMutex
+	items    map[uint64]common.PKIidType
+	logger   *util.Logger
+	stopFlag int32
+}
+
+func newCertStore(g *gossipServiceImpl, idMapper identity.Mapper) *certStore {
+	cs := &certStore{
+		g:        g,
+		idMapper: idMapper,
+		items:    make(map[uint64]common.PKIidType),
+		logger:   g.logger,
+	}
+	cs.pull = algo.NewPullEngine(cs, g.conf.PullInterval)
+	cs.learnIdentities()
+
+	go cs.periodicalPurge()
+
+	return cs
+}
+
+// pkiIDToItem returns the item of the PullEngine that identifies the identity of pkiID
+func pkiIDToItem(pkiID common.PKIidType) uint64 {
+	digest := sha256.Sum256(pkiID)
+	return binary.BigEndian.Uint64(digest[:8])
+}
+
+// learnIdentities adds to the PullEngine the identities the identity mapper learned otherwise,
+// for instance during connection handshakes
+func (cs *certStore) learnIdentities() {
+	for _, pkiID := range cs.idMapper.PKIids() {
+		cs.add(pkiID)
+	}
+}
+
+func (cs *certStore) add(pkiID common.PKIidType) {
+	item := pkiIDToItem(pkiID)
+	cs.lock.Lock()
+	_, exists := cs.items[item]
+	cs.items[item] = pkiID
+	cs.lock.Unlock()
+	if !exists {
+		cs.pull.Add(item)
+	}
+}
+
+func (cs *certStore) toDie() bool {
+	return atomic.LoadInt32(&cs.stopFlag) == int32(1)
+}
+
+func (cs *certStore) periodicalPurge() {
+	for !cs.toDie() {
+		time.Sleep(identityExpirationCheckInterval)
+		if cs.toDie() {
+			return
+		}
+		for _, pkiID := range cs.idMapper.Purge() {
+			cs.logger.Warning("Identity of", pkiID, "is no longer valid, purging it")
+			item := pkiIDToItem(pkiID)
+			cs.lock.Lock()
+			delete(cs.items, item)
+			cs.lock.Unlock()
+			cs.pull.Remove(item)
+			cs.g.disc.Purge(pkiID)
+		}
+	}
+}
+
+func (cs *certStore) SelectPeers() []string {
+	cs.learnIdentities()
+	return cs.g.SelectPeers()
+}
+
+func (cs *certStore) Hello(dest string, nonce uint64) {
+	helloMsg := &proto.GossipMessage{
+		Tag:   proto.GossipMessage_EMPTY,
+		Nonce: 0,
+		Content: &proto.GossipMessage_Hello{
+			Hello: &proto.GossipHello{
+				Nonce:   nonce,
+				MsgType: proto.PullMsgType_IdentityMsg,
+			},
+		},
+	}
+	cs.g.comm.Send(helloMsg, cs.g.peersWithEndpoints(dest)...)
+}
+
+func (cs *certStore) SendDigest(digest []uint64, nonce uint64, context interface{}) {
+	digMsg := &proto.GossipMessage{
+		Tag:   proto.GossipMessage_EMPTY,
+		Nonce: 0,
+		Content: &proto.GossipMessage_DataDig{
+			DataDig: &proto.DataDigest{
+				Nonce:   nonce,
+				SeqMap:  digest,
+				MsgType: proto.PullMsgType_IdentityMsg,
+			},
+		},
+	}
+	context.(comm.ReceivedMessage).Respond(digMsg)
+}
+
+func (cs *certStore) SendReq(dest string, items []uint64, nonce uint64) {
+	req := &proto.GossipMessage{
+		Tag:   proto.GossipMessage_EMPTY,
+		Nonce: 0,
+		Content: &proto.GossipMessage_DataReq{
+			DataReq: &proto.DataRequest{
+				Nonce:   nonce,
+				SeqMap:  items,
+				MsgType: proto.PullMsgType_IdentityMsg,
+			},
+		},
+	}
+	cs.g.comm.Send(req, cs.g.peersWithEndpoints(dest)...)
+}
+
+func (cs *certStore) SendRes(requestedItems []uint64, context interface{}, nonce uint64) {
+	identities := []*proto.PeerIdentity{}
+	for _, item := range requestedItems {
+		cs.lock.RLock()
+		pkiID, exists := cs.items[item]
+		cs.lock.RUnlock()
+		if !exists {
+			continue
+		}
+		cert, err := cs.idMapper.Get(pkiID)
+		if err != nil {
+			continue
+		}
+		identities = append(identities, &proto.PeerIdentity{PkiID: pkiID, Cert: cert})
+	}
+
+	returnedUpdate := &proto.GossipMessage{
+		Tag:   proto.GossipMessage_EMPTY,
+		Nonce: 0,
+		Content: &proto.GossipMessage_DataUpdate{
+			DataUpdate: &proto.DataUpdate{
+				Nonce:      nonce,
+				MsgType:    proto.PullMsgType_IdentityMsg,
+				Identities: identities,
+			},
+		},
+	}
+	context.(comm.ReceivedMessage).Respond(returnedUpdate)
+}
+
+func (cs *certStore) handleMessage(msg comm.ReceivedMessage) {
+	if helloMsg := msg.GetGossipMessage().GetHello(); helloMsg != nil {
+		cs.pull.OnHello(helloMsg.Nonce, msg)
+	}
+	if digest := msg.GetGossipMessage().GetDataDig(); digest != nil {
+		cs.pull.OnDigest(digest.SeqMap, digest.Nonce, msg)
+	}
+	if req := msg.GetGossipMessage().GetDataReq(); req != nil {
+		cs.pull.OnReq(req.SeqMap, req.Nonce, msg)
+	}
+	if res := msg.GetGossipMessage().GetDataUpdate(); res != nil {
+		items := []uint64{}
+		for _, peerIdentity := range res.Identities {
+			// identities which aren't valid, or don't match their PKI-ID, aren't learned
+			if err := cs.idMapper.Put(peerIdentity.PkiID, api.PeerIdentityType(peerIdentity.Cert)); err != nil {
+				cs.logger.Warning("Ignoring identity of", peerIdentity.PkiID, ":", err)
+				continue
+			}
+			item := pkiIDToItem(peerIdentity.PkiID)
+			cs.lock.Lock()
+			cs.items[item] = peerIdentity.PkiID
+			cs.lock.Unlock()
+			items = append(items, item)
+		}
+		cs.pull.OnRes(items, res.Nonce)
+	}
+}
+
+func (cs *certStore) stop() {
+	atomic.StoreInt32(&cs.stopFlag, int32(1))
+	cs.pull.Stop()
+}
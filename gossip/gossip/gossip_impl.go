@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/gossip/algo"
+	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/proto"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/op/go-logging"
@@ -51,10 +52,12 @@ type gossipServiceImpl struct {
 	pushPull    *algo.PullEngine
 	goRoutines  []uint64
 	discAdapter *discoveryAdapter
+	idMapper    identity.Mapper
+	certStore   *certStore
 }
 
 // NewGossipService creates a new gossip instance
-func NewGossipService(conf *Config, c comm.Comm, crypto discovery.CryptoService) Gossip {
+func NewGossipService(conf *Config, c comm.Comm, crypto discovery.CryptoService, idMapper identity.Mapper) Gossip {
 	g := &gossipServiceImpl{
 		presumedDead:         make(chan common.PKIidType, presumedDeadChanSize),
 		disc:                 nil,
@@ -66,6 +69,7 @@ func NewGossipService(conf *Config, c comm.Comm, crypto discovery.CryptoService)
 		stopFlag:             int32(0),
 		stopSignal:           &sync.WaitGroup{},
 		goRoutines:           make([]uint64, 0),
+		idMapper:             idMapper,
 	}
 
 	g.emitter = newBatchingEmitter(conf.PropagateIterations,
@@ -80,6 +84,8 @@ func NewGossipService(conf *Config, c comm.Comm, crypto discovery.CryptoService)
 
 	g.pushPull = algo.NewPullEngine(g, conf.PullInterval)
 
+	g.certStore = newCertStore(g, idMapper)
+
 	g.msgStore = newMessageStore(proto.NewGossipMessageComparator(g.conf.MaxMessageCountToStore), func(m interface{}) {
 		if dataMsg, isDataMsg := m.(*proto.DataMessage); isDataMsg {
 			g.pushPull.Remove(dataMsg.Payload.SeqNum)
@@ -295,6 +301,10 @@ func (g *gossipServiceImpl) forwardDiscoveryMsg(msg comm.ReceivedMessage) {
 
 func (g *gossipServiceImpl) handlePushPullMsg(msg comm.ReceivedMessage) {
 	g.logger.Debug(msg)
+	if isIdentityPullMsg(msg.GetGossipMessage()) {
+		g.certStore.handleMessage(msg)
+		return
+	}
 	if helloMsg := msg.GetGossipMessage().GetHello(); helloMsg != nil {
 		g.pushPull.OnHello(helloMsg.Nonce, msg)
 	}
@@ -391,6 +401,7 @@ func (g *gossipServiceImpl) Stop() {
 	g.discAdapter.close()
 	g.disc.Stop()
 	g.pushPull.Stop()
+	g.certStore.stop()
 	g.toDieChan <- struct{}{}
 	g.emitter.Stop()
 	g.ChannelDeMultiplexer.Close()
@@ -510,3 +521,20 @@ func (g *gossipServiceImpl) peersWithEndpoints(endpoints ...string) []*comm.Remo
 	}
 	return peers
 }
+
+// isIdentityPullMsg returns whether the message is part of the pull-based dissemination of identities
+func isIdentityPullMsg(m *proto.GossipMessage) bool {
+	if hello := m.GetHello(); hello != nil {
+		return hello.MsgType == proto.PullMsgType_IdentityMsg
+	}
+	if digest := m.GetDataDig(); digest != nil {
+		return digest.MsgType == proto.PullMsgType_IdentityMsg
+	}
+	if req := m.GetDataReq(); req != nil {
+		return req.MsgType == proto.PullMsgType_IdentityMsg
+	}
+	if res := m.GetDataUpdate(); res != nil {
+		return res.MsgType == proto.PullMsgType_IdentityMsg
+	}
+	return false
+}
@@ -28,9 +28,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/gossip/algo"
+	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/proto"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/stretchr/testify/assert"
@@ -45,6 +48,7 @@ func init() {
 	discovery.SetAliveExpirationCheckInterval(aliveTimeInterval)
 	discovery.SetExpirationTimeout(aliveTimeInterval * 10)
 	discovery.SetReconnectInterval(aliveTimeInterval * 5)
+	SetIdentityExpirationCheckInterval(aliveTimeInterval)
 }
 
 var portPrefix = 5610
@@ -83,6 +87,51 @@ func (*naiveCryptoService) Verify(vkID, signature, message []byte) error {
 	return fmt.Errorf("Failed verifying")
 }
 
+// naiveMCS considers the PKI-ID of an identity to be the identity itself,
+// and all the identities to be valid until they are revoked
+type naiveMCS struct {
+	lock    sync.RWMutex
+	revoked map[string]bool
+}
+
+func newNaiveMCS() *naiveMCS {
+	return &naiveMCS{revoked: make(map[string]bool)}
+}
+
+func (cs *naiveMCS) revoke(peerIdentity api.PeerIdentityType) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	cs.revoked[string(peerIdentity)] = true
+}
+
+func (*naiveMCS) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
+	return common.PKIidType(peerIdentity)
+}
+
+func (*naiveMCS) VerifyBlock(signedBlock api.SignedBlock) error {
+	return nil
+}
+
+func (*naiveMCS) Sign(msg []byte) ([]byte, error) {
+	return msg, nil
+}
+
+func (*naiveMCS) Verify(peerIdentity api.PeerIdentityType, signature, message []byte) error {
+	if bytes.Equal(signature, message) {
+		return nil
+	}
+	return fmt.Errorf("Failed verifying")
+}
+
+func (cs *naiveMCS) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
+	cs.lock.RLock()
+	defer cs.lock.RUnlock()
+	if cs.revoked[string(peerIdentity)] {
+		return fmt.Errorf("Identity %s was revoked", string(peerIdentity))
+	}
+	return nil
+}
+
 func bootPeers(ids ...int) []string {
 	peers := []string{}
 	for _, id := range ids {
@@ -106,11 +155,17 @@ func newGossipInstance(id int, maxMsgCount int, boot ...int) Gossip {
 		PullPeerNum:                5,
 		SelfEndpoint:               fmt.Sprintf("localhost:%d", port),
 	}
-	comm, err := comm.NewCommInstanceWithServer(port, &naiveCryptoService{}, []byte(conf.SelfEndpoint))
+	return newGossipInstanceWithCryptoService(conf, newNaiveMCS())
+}
+
+func newGossipInstanceWithCryptoService(conf *Config, mcs api.MessageCryptoService) Gossip {
+	comm, err := comm.NewCommInstanceWithServer(conf.BindPort, &naiveCryptoService{}, []byte(conf.SelfEndpoint))
 	if err != nil {
 		panic(err)
 	}
-	return NewGossipService(conf, comm, &naiveCryptoService{})
+	idMapper := identity.NewIdentityMapper(mcs)
+	idMapper.Put(comm.GetPKIid(), api.PeerIdentityType(conf.SelfEndpoint))
+	return NewGossipService(conf, comm, &naiveCryptoService{}, idMapper)
 }
 
 func newGossipInstanceWithOnlyPull(id int, maxMsgCount int, boot ...int) Gossip {
@@ -128,11 +183,7 @@ func newGossipInstanceWithOnlyPull(id int, maxMsgCount int, boot ...int) Gossip
 		PullPeerNum:                20,
 		SelfEndpoint:               fmt.Sprintf("localhost:%d", port),
 	}
-	comm, err := comm.NewCommInstanceWithServer(port, &naiveCryptoService{}, []byte(conf.SelfEndpoint))
-	if err != nil {
-		panic(err)
-	}
-	return NewGossipService(conf, comm, &naiveCryptoService{})
+	return newGossipInstanceWithCryptoService(conf, newNaiveMCS())
 }
 
 func TestPull(t *testing.T) {
@@ -228,6 +279,217 @@ func TestPull(t *testing.T) {
 	ensureGoroutineExit(t)
 }
 
+func TestIdentityDissemination(t *testing.T) {
+	t1 := time.Now()
+	// Scenario: each peer knows only its own identity at first.
+	// First phase: Ensure all peers learn the identities of all peers via pull
+	// Second phase: Revoke the identity of the last peer and ensure it is purged from all peers
+
+	testLock.Lock()
+	defer testLock.Unlock()
+
+	shortenedWaitTime := time.Duration(500) * time.Millisecond
+	algo.SetDigestWaitTime(shortenedWaitTime / 5)
+	algo.SetRequestWaitTime(shortenedWaitTime)
+	algo.SetResponseWaitTime(shortenedWaitTime)
+
+	defer func() {
+		algo.SetDigestWaitTime(time.Duration(1) * time.Second)
+		algo.SetRequestWaitTime(time.Duration(1) * time.Second)
+		algo.SetResponseWaitTime(time.Duration(2) * time.Second)
+	}()
+
+	stopped := int32(0)
+	go waitForTestCompletion(&stopped, t)
+
+	n := 4
+	mcs := newNaiveMCS()
+	peers := make([]Gossip, n+1)
+	for i := 0; i <= n; i++ {
+		port := i + portPrefix
+		conf := &Config{
+			BindPort:       port,
+			BootstrapPeers: bootPeers(0),
+			ID:             fmt.Sprintf("p%d", i),
+			MaxMessageCountToStore:     100,
+			MaxPropagationBurstLatency: time.Duration(10) * time.Millisecond,
+			MaxPropagationBurstSize:    10,
+			PropagateIterations:        1,
+			PropagatePeerNum:           3,
+			PullInterval:               time.Duration(1000) * time.Millisecond,
+			PullPeerNum:                20,
+			SelfEndpoint:               fmt.Sprintf("localhost:%d", port),
+		}
+		peers[i] = newGossipInstanceWithCryptoService(conf, mcs)
+	}
+
+	knownIdentities := func(p Gossip) int {
+		return len(p.(*gossipServiceImpl).idMapper.PKIids())
+	}
+
+	knowAll := func() bool {
+		for _, p := range peers {
+			if knownIdentities(p) != n+1 {
+				return false
+			}
+		}
+		return true
+	}
+	waitUntilOrFail(t, knowAll)
+
+	revokedPeer := peers[n]
+	mcs.revoke(api.PeerIdentityType(fmt.Sprintf("localhost:%d", n+portPrefix)))
+
+	purged := func() bool {
+		for _, p := range peers[:n] {
+			if knownIdentities(p) != n {
+				return false
+			}
+		}
+		return true
+	}
+	waitUntilOrFail(t, purged)
+
+	for _, p := range peers[:n] {
+		_, err := p.(*gossipServiceImpl).idMapper.Get(revokedPeer.(*gossipServiceImpl).comm.GetPKIid())
+		assert.Error(t, err)
+	}
+
+	stop := func() {
+		stopPeers(peers)
+	}
+
+	waitUntilOrFailBlocking(t, stop)
+
+	fmt.Println("Took", time.Since(t1))
+	atomic.StoreInt32(&stopped, int32(1))
+	ensureGoroutineExit(t)
+}
+
+// signingMCS signs messages with the identity of its peer, so that a signature
+// can only be verified with the identity of the peer that produced it
+type signingMCS struct {
+	*naiveMCS
+	identity api.PeerIdentityType
+}
+
+func (cs *signingMCS) Sign(msg []byte) ([]byte, error) {
+	return append(append([]byte{}, cs.identity...), msg...), nil
+}
+
+func (cs *signingMCS) Verify(peerIdentity api.PeerIdentityType, signature, message []byte) error {
+	if bytes.Equal(signature, append(append([]byte{}, peerIdentity...), message...)) {
+		return nil
+	}
+	return fmt.Errorf("Failed verifying")
+}
+
+// acceptingAliveMsgCrypto signs alive messages, but considers all the alive messages it receives authentic
+type acceptingAliveMsgCrypto struct {
+	discovery.CryptoService
+}
+
+func (*acceptingAliveMsgCrypto) ValidateAliveMsg(am *proto.AliveMessage) bool {
+	return true
+}
+
+func TestAliveMsgOfPeerKnownThroughIdentityPull(t *testing.T) {
+	t1 := time.Now()
+	// Scenario: p1 and p2 boot from p0, and verify the alive messages they receive with the identities of
+	// their identity mappers. p0 accepts all alive messages, and is the only peer whose identity p1 and p2
+	// know in advance, as if they had connected to it in a handshake. The comm layer doesn't put any
+	// identity in the mappers, so p1 can only learn p2's identity, and then p2's membership, through identity pull
+	testLock.Lock()
+	defer testLock.Unlock()
+
+	shortenedWaitTime := time.Duration(500) * time.Millisecond
+	algo.SetDigestWaitTime(shortenedWaitTime / 5)
+	algo.SetRequestWaitTime(shortenedWaitTime)
+	algo.SetResponseWaitTime(shortenedWaitTime)
+
+	defer func() {
+		algo.SetDigestWaitTime(time.Duration(1) * time.Second)
+		algo.SetRequestWaitTime(time.Duration(1) * time.Second)
+		algo.SetResponseWaitTime(time.Duration(2) * time.Second)
+	}()
+
+	stopped := int32(0)
+	go waitForTestCompletion(&stopped, t)
+
+	revocations := newNaiveMCS()
+	bootIdentity := api.PeerIdentityType(bootPeers(0)[0])
+	aliveCrypto := make([]discovery.CryptoService, 3)
+	peers := make([]Gossip, 3)
+	for i := 0; i < 3; i++ {
+		port := i + portPrefix
+		conf := &Config{
+			BindPort:       port,
+			ID:             fmt.Sprintf("p%d", i),
+			MaxMessageCountToStore:     100,
+			MaxPropagationBurstLatency: time.Duration(10) * time.Millisecond,
+			MaxPropagationBurstSize:    10,
+			PropagateIterations:        1,
+			PropagatePeerNum:           3,
+			PullInterval:               time.Duration(1000) * time.Millisecond,
+			PullPeerNum:                5,
+			SelfEndpoint:               fmt.Sprintf("localhost:%d", port),
+		}
+		if i > 0 {
+			conf.BootstrapPeers = bootPeers(0)
+		}
+		comm, err := comm.NewCommInstanceWithServer(conf.BindPort, &naiveCryptoService{}, []byte(conf.SelfEndpoint))
+		if err != nil {
+			panic(err)
+		}
+		mcs := &signingMCS{naiveMCS: revocations, identity: api.PeerIdentityType(conf.SelfEndpoint)}
+		idMapper := identity.NewIdentityMapper(mcs)
+		idMapper.Put(comm.GetPKIid(), mcs.identity)
+		aliveCrypto[i] = NewAliveMsgCryptoService(conf.ID, mcs, idMapper)
+		if i == 0 {
+			aliveCrypto[i] = &acceptingAliveMsgCrypto{aliveCrypto[i]}
+		} else {
+			idMapper.Put(common.PKIidType(bootIdentity), bootIdentity)
+		}
+		peers[i] = NewGossipService(conf, comm, aliveCrypto[i], idMapper)
+	}
+
+	knowsPeer := func(p Gossip, endpoint string) bool {
+		for _, member := range p.GetPeers() {
+			if member.Endpoint == endpoint {
+				return true
+			}
+		}
+		return false
+	}
+	knowEachOther := func() bool {
+		return knowsPeer(peers[1], bootPeers(2)[0]) && knowsPeer(peers[2], bootPeers(1)[0])
+	}
+	waitUntilOrFail(t, knowEachOther)
+
+	_, err := peers[1].(*gossipServiceImpl).idMapper.Get(common.PKIidType(bootPeers(2)[0]))
+	assert.NoError(t, err)
+
+	// An alive message of p2 is authentic to p1 only if it is signed by p2
+	aliveMsg := func(signer int) *proto.AliveMessage {
+		return aliveCrypto[signer].SignMessage(&proto.AliveMessage{
+			Membership: &proto.Member{Endpoint: bootPeers(2)[0], PkiID: common.PKIidType(bootPeers(2)[0])},
+			Timestamp:  &proto.PeerTime{IncNumber: uint64(time.Now().UnixNano()), SeqNum: 1},
+		})
+	}
+	assert.True(t, aliveCrypto[1].ValidateAliveMsg(aliveMsg(2)))
+	assert.False(t, aliveCrypto[1].ValidateAliveMsg(aliveMsg(0)))
+
+	stop := func() {
+		stopPeers(peers)
+	}
+
+	waitUntilOrFailBlocking(t, stop)
+
+	fmt.Println("Took", time.Since(t1))
+	atomic.StoreInt32(&stopped, int32(1))
+	ensureGoroutineExit(t)
+}
+
 func TestMembership(t *testing.T) {
 	t1 := time.Now()
 	// Scenario: spawn 20 nodes and a single bootstrap node and then:
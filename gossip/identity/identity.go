@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+)
+
+// Mapper holds the identities of the peers, which are the certificates their PKI-IDs are derived from,
+// so that messages signed by peers that aren't directly connected can be verified as well
+type Mapper interface {
+
+	// Put associates an identity with its PKI-ID, and returns an error if the identity
+	// isn't valid according to the MSP, or if the PKI-ID isn't derived from it
+	Put(pkiID common.PKIidType, identity api.PeerIdentityType) error
+
+	// Get returns the identity associated with a PKI-ID, or an error if it is unknown
+	Get(pkiID common.PKIidType) (api.PeerIdentityType, error)
+
+	// Verify checks that signature is a valid signature of message by the peer of the given PKI-ID
+	Verify(pkiID common.PKIidType, signature, message []byte) error
+
+	// PKIids returns the PKI-IDs of the identities held
+	PKIids() []common.PKIidType
+
+	// Purge validates again the identities held, removes those that are no longer
+	// valid, for instance because they are expired or revoked, and returns their PKI-IDs
+	Purge() []common.PKIidType
+}
+
+type identityMapperImpl struct {
+	mcs        api.MessageCryptoService
+	identities map[string]api.PeerIdentityType
+	lock       sync.RWMutex
+}
+
+// NewIdentityMapper creates a Mapper validating the identities with the given MessageCryptoService
+func NewIdentityMapper(mcs api.MessageCryptoService) Mapper {
+	return &identityMapperImpl{
+		mcs:        mcs,
+		identities: make(map[string]api.PeerIdentityType),
+	}
+}
+
+func (is *identityMapperImpl) Put(pkiID common.PKIidType, identity api.PeerIdentityType) error {
+	if pkiID == nil {
+		return fmt.Errorf("PKI-ID is nil")
+	}
+	if identity == nil {
+		return fmt.Errorf("Identity is nil")
+	}
+	if err := is.mcs.ValidateIdentity(identity); err != nil {
+		return fmt.Errorf("Invalid identity of %v: %s", pkiID, err)
+	}
+	if !bytes.Equal(is.mcs.GetPKIidOfCert(identity), pkiID) {
+		return fmt.Errorf("Identity doesn't match the PKI-ID %v", pkiID)
+	}
+
+	is.lock.Lock()
+	defer is.lock.Unlock()
+	is.identities[string(pkiID)] = identity
+	return nil
+}
+
+func (is *identityMapperImpl) Get(pkiID common.PKIidType) (api.PeerIdentityType, error) {
+	is.lock.RLock()
+	defer is.lock.RUnlock()
+	identity, exists := is.identities[string(pkiID)]
+	if !exists {
+		return nil, fmt.Errorf("No identity known for %v", pkiID)
+	}
+	return identity, nil
+}
+
+func (is *identityMapperImpl) Verify(pkiID common.PKIidType, signature, message []byte) error {
+	identity, err := is.Get(pkiID)
+	if err != nil {
+		return err
+	}
+	return is.mcs.Verify(identity, signature, message)
+}
+
+func (is *identityMapperImpl) PKIids() []common.PKIidType {
+	is.lock.RLock()
+	defer is.lock.RUnlock()
+	pkiIDs := make([]common.PKIidType, 0, len(is.identities))
+	for pkiID := range is.identities {
+		pkiIDs = append(pkiIDs, common.PKIidType(pkiID))
+	}
+	return pkiIDs
+}
+
+func (is *identityMapperImpl) Purge() []common.PKIidType {
+	is.lock.Lock()
+	defer is.lock.Unlock()
+	purged := []common.PKIidType{}
+	for pkiID, identity := range is.identities {
+		if is.mcs.ValidateIdentity(identity) != nil {
+			delete(is.identities, pkiID)
+			purged = append(purged, common.PKIidType(pkiID))
+		}
+	}
+	return purged
+}
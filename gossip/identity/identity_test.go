@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// naiveCryptoService considers the PKI-ID of an identity to be the identity itself,
+// and all the identities to be valid until they are revoked
+type naiveCryptoService struct {
+	lock    sync.Mutex
+	revoked map[string]bool
+}
+
+func newNaiveCryptoService() *naiveCryptoService {
+	return &naiveCryptoService{revoked: make(map[string]bool)}
+}
+
+func (cs *naiveCryptoService) revoke(identity api.PeerIdentityType) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	cs.revoked[string(identity)] = true
+}
+
+func (cs *naiveCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
+	return common.PKIidType(peerIdentity)
+}
+
+func (cs *naiveCryptoService) VerifyBlock(signedBlock api.SignedBlock) error {
+	return nil
+}
+
+func (cs *naiveCryptoService) Sign(msg []byte) ([]byte, error) {
+	return msg, nil
+}
+
+// Verify considers a signature valid if it is the message prefixed by the identity of its signer
+func (cs *naiveCryptoService) Verify(peerIdentity api.PeerIdentityType, signature, message []byte) error {
+	if !bytes.Equal(signature, append([]byte(peerIdentity), message...)) {
+		return fmt.Errorf("Invalid signature")
+	}
+	return nil
+}
+
+func (cs *naiveCryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	if cs.revoked[string(peerIdentity)] {
+		return fmt.Errorf("Revoked identity")
+	}
+	return nil
+}
+
+func TestPut(t *testing.T) {
+	cs := newNaiveCryptoService()
+	idMapper := NewIdentityMapper(cs)
+
+	assert.NoError(t, idMapper.Put(common.PKIidType("p1"), api.PeerIdentityType("p1")))
+	assert.Error(t, idMapper.Put(common.PKIidType("p2"), api.PeerIdentityType("p1")), "Identity put under another PKI-ID")
+	assert.Error(t, idMapper.Put(nil, api.PeerIdentityType("p1")))
+	assert.Error(t, idMapper.Put(common.PKIidType("p1"), nil))
+
+	cs.revoke(api.PeerIdentityType("p3"))
+	assert.Error(t, idMapper.Put(common.PKIidType("p3"), api.PeerIdentityType("p3")), "Revoked identity put")
+
+	identity, err := idMapper.Get(common.PKIidType("p1"))
+	assert.NoError(t, err)
+	assert.Equal(t, api.PeerIdentityType("p1"), identity)
+	_, err = idMapper.Get(common.PKIidType("p2"))
+	assert.Error(t, err)
+	_, err = idMapper.Get(common.PKIidType("p3"))
+	assert.Error(t, err)
+	assert.Equal(t, []common.PKIidType{common.PKIidType("p1")}, idMapper.PKIids())
+}
+
+func TestVerify(t *testing.T) {
+	idMapper := NewIdentityMapper(newNaiveCryptoService())
+	assert.NoError(t, idMapper.Put(common.PKIidType("p1"), api.PeerIdentityType("p1")))
+
+	assert.NoError(t, idMapper.Verify(common.PKIidType("p1"), []byte("p1msg"), []byte("msg")))
+	assert.Error(t, idMapper.Verify(common.PKIidType("p1"), []byte("p2msg"), []byte("msg")))
+	assert.Error(t, idMapper.Verify(common.PKIidType("p2"), []byte("p2msg"), []byte("msg")), "Verified a signature of an unknown peer")
+}
+
+func TestPurge(t *testing.T) {
+	cs := newNaiveCryptoService()
+	idMapper := NewIdentityMapper(cs)
+	assert.NoError(t, idMapper.Put(common.PKIidType("p1"), api.PeerIdentityType("p1")))
+	assert.NoError(t, idMapper.Put(common.PKIidType("p2"), api.PeerIdentityType("p2")))
+
+	assert.Empty(t, idMapper.Purge())
+
+	cs.revoke(api.PeerIdentityType("p2"))
+	assert.Equal(t, []common.PKIidType{common.PKIidType("p2")}, idMapper.Purge())
+	assert.Equal(t, []common.PKIidType{common.PKIidType("p1")}, idMapper.PKIids())
+	_, err := idMapper.Get(common.PKIidType("p2"))
+	assert.Error(t, err)
+}
@@ -18,14 +18,20 @@ package integration
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/proto"
+	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 )
 
@@ -63,7 +69,28 @@ func newComm(selfEndpoint string, s *grpc.Server, dialOpts ...grpc.DialOption) c
 func NewGossipComponent(endpoint string, s *grpc.Server, bootPeers ...string) (gossip.Gossip, comm.Comm) {
 	conf := newConfig(endpoint, bootPeers...)
 	comm := newComm(endpoint, s, grpc.WithInsecure())
-	return gossip.NewGossipService(conf, comm, NewGossipCryptoService()), comm
+	return gossip.NewGossipService(conf, comm, NewGossipCryptoService(), identity.NewIdentityMapper(&naiveMessageCryptoService{})), comm
+}
+
+// NewSecureGossipComponent creates a gossip component listening on the port of endpoint with mutual TLS, using the
+// TLS certificate and key of the peer configured in core.yaml. The PKI-ID of the component is derived from its TLS
+// certificate by mcs, the TLS certificates of remote peers are verified against the roots returned by rootCAs,
+// and the identities of the peers are validated by mcs. Alive messages are signed by mcs, and verified against the
+// identities of the peers, learned in handshakes or through identity pull
+func NewSecureGossipComponent(endpoint string, mcs api.MessageCryptoService, rootCAs func() *x509.CertPool, bootPeers ...string) (gossip.Gossip, comm.Comm, error) {
+	cert, err := tls.LoadX509KeyPair(viper.GetString("peer.tls.cert.file"), viper.GetString("peer.tls.key.file"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed loading the TLS certificate of the peer: %s", err)
+	}
+	conf := newConfig(endpoint, bootPeers...)
+	tlsConf := &comm.TLSConfig{Certificate: cert, RootCAs: rootCAs}
+	idMapper := identity.NewIdentityMapper(mcs)
+	comm, err := comm.NewSecureCommInstanceWithServer(conf.BindPort, tlsConf, idMapper, mcs, NewGossipCryptoService())
+	if err != nil {
+		return nil, nil, err
+	}
+	aliveCrypto := gossip.NewAliveMsgCryptoService(conf.ID, mcs, idMapper)
+	return gossip.NewGossipService(conf, comm, aliveCrypto, idMapper), comm, nil
 }
 
 // GossipCryptoService is an interface that conforms to both
@@ -124,3 +151,25 @@ func (cs *naiveCryptoServiceImpl) Verify(vkID, signature, message []byte) error
 	}
 	return nil
 }
+
+// naiveMessageCryptoService considers all identities valid,
+// and the PKI-ID of a peer to be its identity
+type naiveMessageCryptoService struct {
+	naiveCryptoServiceImpl
+}
+
+func (cs *naiveMessageCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
+	return common.PKIidType(peerIdentity)
+}
+
+func (cs *naiveMessageCryptoService) VerifyBlock(signedBlock api.SignedBlock) error {
+	return nil
+}
+
+func (cs *naiveMessageCryptoService) Verify(peerIdentity api.PeerIdentityType, signature, message []byte) error {
+	return cs.naiveCryptoServiceImpl.Verify(peerIdentity, signature, message)
+}
+
+func (cs *naiveMessageCryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
+	return nil
+}
@@ -13,6 +13,7 @@ It has these top-level messages:
 	StateInfo
 	ChannelCommand
 	ConnEstablish
+	PeerIdentity
 	DataRequest
 	GossipHello
 	DataUpdate
@@ -50,6 +51,29 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// PullMsgType defines the kind of items
+// a pull-based gossip round is about
+type PullMsgType int32
+
+const (
+	PullMsgType_BlockMessage PullMsgType = 0
+	PullMsgType_IdentityMsg  PullMsgType = 1
+)
+
+var PullMsgType_name = map[int32]string{
+	0: "BlockMessage",
+	1: "IdentityMsg",
+}
+var PullMsgType_value = map[string]int32{
+	"BlockMessage": 0,
+	"IdentityMsg":  1,
+}
+
+func (x PullMsgType) String() string {
+	return proto1.EnumName(PullMsgType_name, int32(x))
+}
+func (PullMsgType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 type GossipMessage_Tag int32
 
 const (
@@ -622,6 +646,7 @@ func (*ChannelCommand) Descriptor() ([]byte, []int) { return fileDescriptor0, []
 type ConnEstablish struct {
 	Sig   []byte `protobuf:"bytes,1,opt,name=sig,proto3" json:"sig,omitempty"`
 	PkiID []byte `protobuf:"bytes,2,opt,name=pkiID,proto3" json:"pkiID,omitempty"`
+	Cert  []byte `protobuf:"bytes,3,opt,name=cert,proto3" json:"cert,omitempty"`
 }
 
 func (m *ConnEstablish) Reset()                    { *m = ConnEstablish{} }
@@ -629,40 +654,56 @@ func (m *ConnEstablish) String() string            { return proto1.CompactTextSt
 func (*ConnEstablish) ProtoMessage()               {}
 func (*ConnEstablish) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
+// PeerIdentity defines the identity of a peer,
+// which is the certificate its PKI-ID is derived from
+type PeerIdentity struct {
+	PkiID []byte `protobuf:"bytes,1,opt,name=pkiID,proto3" json:"pkiID,omitempty"`
+	Cert  []byte `protobuf:"bytes,2,opt,name=cert,proto3" json:"cert,omitempty"`
+}
+
+func (m *PeerIdentity) Reset()                    { *m = PeerIdentity{} }
+func (m *PeerIdentity) String() string            { return proto1.CompactTextString(m) }
+func (*PeerIdentity) ProtoMessage()               {}
+func (*PeerIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
 // DataRequest is a message used for a peer to request
 // certain data blocks from a remote peer
 type DataRequest struct {
-	Nonce  uint64   `protobuf:"varint,1,opt,name=nonce" json:"nonce,omitempty"`
-	SeqMap []uint64 `protobuf:"varint,2,rep,packed,name=seqMap" json:"seqMap,omitempty"`
+	Nonce   uint64      `protobuf:"varint,1,opt,name=nonce" json:"nonce,omitempty"`
+	SeqMap  []uint64    `protobuf:"varint,2,rep,packed,name=seqMap" json:"seqMap,omitempty"`
+	MsgType PullMsgType `protobuf:"varint,3,opt,name=msgType,enum=proto.PullMsgType" json:"msgType,omitempty"`
 }
 
 func (m *DataRequest) Reset()                    { *m = DataRequest{} }
 func (m *DataRequest) String() string            { return proto1.CompactTextString(m) }
 func (*DataRequest) ProtoMessage()               {}
-func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 // GossipHello is the message that is used for the peer to initiate
 // a pull round with another peer
 type GossipHello struct {
-	Nonce uint64 `protobuf:"varint,1,opt,name=nonce" json:"nonce,omitempty"`
+	Nonce   uint64      `protobuf:"varint,1,opt,name=nonce" json:"nonce,omitempty"`
+	MsgType PullMsgType `protobuf:"varint,2,opt,name=msgType,enum=proto.PullMsgType" json:"msgType,omitempty"`
 }
 
 func (m *GossipHello) Reset()                    { *m = GossipHello{} }
 func (m *GossipHello) String() string            { return proto1.CompactTextString(m) }
 func (*GossipHello) ProtoMessage()               {}
-func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 // DataUpdate is the the final message in the pull phase
 // sent from the receiver to the initiator
 type DataUpdate struct {
-	Nonce uint64         `protobuf:"varint,1,opt,name=nonce" json:"nonce,omitempty"`
-	Data  []*DataMessage `protobuf:"bytes,2,rep,name=data" json:"data,omitempty"`
+	Nonce      uint64          `protobuf:"varint,1,opt,name=nonce" json:"nonce,omitempty"`
+	Data       []*DataMessage  `protobuf:"bytes,2,rep,name=data" json:"data,omitempty"`
+	MsgType    PullMsgType     `protobuf:"varint,3,opt,name=msgType,enum=proto.PullMsgType" json:"msgType,omitempty"`
+	Identities []*PeerIdentity `protobuf:"bytes,4,rep,name=identities" json:"identities,omitempty"`
 }
 
 func (m *DataUpdate) Reset()                    { *m = DataUpdate{} }
 func (m *DataUpdate) String() string            { return proto1.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()               {}
-func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *DataUpdate) GetData() []*DataMessage {
 	if m != nil {
@@ -671,17 +712,25 @@ func (m *DataUpdate) GetData() []*DataMessage {
 	return nil
 }
 
+func (m *DataUpdate) GetIdentities() []*PeerIdentity {
+	if m != nil {
+		return m.Identities
+	}
+	return nil
+}
+
 // DataDigest is the message sent from the receiver peer
 // to the initator peer and contains the data items it has
 type DataDigest struct {
-	Nonce  uint64   `protobuf:"varint,1,opt,name=nonce" json:"nonce,omitempty"`
-	SeqMap []uint64 `protobuf:"varint,2,rep,packed,name=seqMap" json:"seqMap,omitempty"`
+	Nonce   uint64      `protobuf:"varint,1,opt,name=nonce" json:"nonce,omitempty"`
+	SeqMap  []uint64    `protobuf:"varint,2,rep,packed,name=seqMap" json:"seqMap,omitempty"`
+	MsgType PullMsgType `protobuf:"varint,3,opt,name=msgType,enum=proto.PullMsgType" json:"msgType,omitempty"`
 }
 
 func (m *DataDigest) Reset()                    { *m = DataDigest{} }
 func (m *DataDigest) String() string            { return proto1.CompactTextString(m) }
 func (*DataDigest) ProtoMessage()               {}
-func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 // DataMessage is the message that contains a block
 type DataMessage struct {
@@ -691,7 +740,7 @@ type DataMessage struct {
 func (m *DataMessage) Reset()                    { *m = DataMessage{} }
 func (m *DataMessage) String() string            { return proto1.CompactTextString(m) }
 func (*DataMessage) ProtoMessage()               {}
-func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *DataMessage) GetPayload() *Payload {
 	if m != nil {
@@ -700,7 +749,7 @@ func (m *DataMessage) GetPayload() *Payload {
 	return nil
 }
 
-// Payload contains a block
+// Payload contains a block of the ordering service, with its signatures
 type Payload struct {
 	SeqNum uint64 `protobuf:"varint,1,opt,name=seqNum" json:"seqNum,omitempty"`
 	Hash   string `protobuf:"bytes,2,opt,name=hash" json:"hash,omitempty"`
@@ -710,7 +759,7 @@ type Payload struct {
 func (m *Payload) Reset()                    { *m = Payload{} }
 func (m *Payload) String() string            { return proto1.CompactTextString(m) }
 func (*Payload) ProtoMessage()               {}
-func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 // AliveMessage is sent to inform remote peers
 // of a peer's existence and activity
@@ -723,7 +772,7 @@ type AliveMessage struct {
 func (m *AliveMessage) Reset()                    { *m = AliveMessage{} }
 func (m *AliveMessage) String() string            { return proto1.CompactTextString(m) }
 func (*AliveMessage) ProtoMessage()               {}
-func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *AliveMessage) GetMembership() *Member {
 	if m != nil {
@@ -748,7 +797,7 @@ type PeerTime struct {
 func (m *PeerTime) Reset()                    { *m = PeerTime{} }
 func (m *PeerTime) String() string            { return proto1.CompactTextString(m) }
 func (*PeerTime) ProtoMessage()               {}
-func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 // MembershipRequest is used to ask membership information
 // from a remote peer
@@ -760,7 +809,7 @@ type MembershipRequest struct {
 func (m *MembershipRequest) Reset()                    { *m = MembershipRequest{} }
 func (m *MembershipRequest) String() string            { return proto1.CompactTextString(m) }
 func (*MembershipRequest) ProtoMessage()               {}
-func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *MembershipRequest) GetSelfInformation() *AliveMessage {
 	if m != nil {
@@ -778,7 +827,7 @@ type MembershipResponse struct {
 func (m *MembershipResponse) Reset()                    { *m = MembershipResponse{} }
 func (m *MembershipResponse) String() string            { return proto1.CompactTextString(m) }
 func (*MembershipResponse) ProtoMessage()               {}
-func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *MembershipResponse) GetAlive() []*AliveMessage {
 	if m != nil {
@@ -805,7 +854,7 @@ type Member struct {
 func (m *Member) Reset()                    { *m = Member{} }
 func (m *Member) String() string            { return proto1.CompactTextString(m) }
 func (*Member) ProtoMessage()               {}
-func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 // Empty is used for pinging and in tests
 type Empty struct {
@@ -814,7 +863,7 @@ type Empty struct {
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto1.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 // RemoteStateRequest is used to ask a set of blocks
 // from a remote peer
@@ -825,7 +874,7 @@ type RemoteStateRequest struct {
 func (m *RemoteStateRequest) Reset()                    { *m = RemoteStateRequest{} }
 func (m *RemoteStateRequest) String() string            { return proto1.CompactTextString(m) }
 func (*RemoteStateRequest) ProtoMessage()               {}
-func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 // RemoteStateResponse is used to send a set of blocks
 // to a remote peer
@@ -836,7 +885,7 @@ type RemoteStateResponse struct {
 func (m *RemoteStateResponse) Reset()                    { *m = RemoteStateResponse{} }
 func (m *RemoteStateResponse) String() string            { return proto1.CompactTextString(m) }
 func (*RemoteStateResponse) ProtoMessage()               {}
-func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *RemoteStateResponse) GetPayloads() []*Payload {
 	if m != nil {
@@ -850,6 +899,7 @@ func init() {
 	proto1.RegisterType((*StateInfo)(nil), "proto.StateInfo")
 	proto1.RegisterType((*ChannelCommand)(nil), "proto.ChannelCommand")
 	proto1.RegisterType((*ConnEstablish)(nil), "proto.ConnEstablish")
+	proto1.RegisterType((*PeerIdentity)(nil), "proto.PeerIdentity")
 	proto1.RegisterType((*DataRequest)(nil), "proto.DataRequest")
 	proto1.RegisterType((*GossipHello)(nil), "proto.GossipHello")
 	proto1.RegisterType((*DataUpdate)(nil), "proto.DataUpdate")
@@ -864,6 +914,7 @@ func init() {
 	proto1.RegisterType((*Empty)(nil), "proto.Empty")
 	proto1.RegisterType((*RemoteStateRequest)(nil), "proto.RemoteStateRequest")
 	proto1.RegisterType((*RemoteStateResponse)(nil), "proto.RemoteStateResponse")
+	proto1.RegisterEnum("proto.PullMsgType", PullMsgType_name, PullMsgType_value)
 	proto1.RegisterEnum("proto.GossipMessage_Tag", GossipMessage_Tag_name, GossipMessage_Tag_value)
 }
 
@@ -1012,68 +1063,74 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 func init() { proto1.RegisterFile("message.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 994 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x56, 0x5b, 0x4f, 0xe3, 0x46,
-	0x14, 0x8e, 0xc9, 0x0d, 0x9f, 0x38, 0x10, 0xce, 0xd2, 0xca, 0x45, 0xad, 0x84, 0xdc, 0x55, 0x9b,
-	0x46, 0x25, 0xec, 0x86, 0x87, 0x95, 0x5a, 0x55, 0x2d, 0x10, 0x4a, 0xa8, 0x4a, 0x40, 0x03, 0xdb,
-	0x6a, 0xfb, 0x82, 0x86, 0x64, 0x70, 0xac, 0x8d, 0xc7, 0x26, 0x33, 0xb4, 0xe2, 0x2f, 0xec, 0x43,
-	0x7f, 0xf3, 0x6a, 0x2e, 0x4e, 0x6c, 0xf0, 0x3e, 0xec, 0x53, 0x7c, 0xce, 0x7c, 0xdf, 0xb9, 0xcc,
-	0xb9, 0x4c, 0xa0, 0x1d, 0x33, 0x21, 0x68, 0xc8, 0xfa, 0xe9, 0x22, 0x91, 0x09, 0xd6, 0xf5, 0x4f,
-	0xf0, 0x7f, 0x13, 0xda, 0xa7, 0x89, 0x10, 0x51, 0x7a, 0x6e, 0x8e, 0x71, 0x1b, 0xea, 0x3c, 0xe1,
-	0x13, 0xe6, 0x3b, 0xbb, 0x4e, 0xb7, 0x46, 0x8c, 0x80, 0x3e, 0x34, 0x27, 0x33, 0xca, 0x39, 0x9b,
-	0xfb, 0x6b, 0xbb, 0x4e, 0xd7, 0x23, 0x99, 0x88, 0x3d, 0xa8, 0x4a, 0x1a, 0xfa, 0xd5, 0x5d, 0xa7,
-	0xbb, 0x31, 0xf0, 0x8d, 0xf5, 0x7e, 0xc1, 0x64, 0xff, 0x9a, 0x86, 0x44, 0x81, 0xf0, 0x35, 0xac,
-	0xd3, 0x79, 0xf4, 0x2f, 0x3b, 0x17, 0xa1, 0x5f, 0xdb, 0x75, 0xba, 0xad, 0xc1, 0x0b, 0x4b, 0x38,
-	0xd4, 0x6a, 0x83, 0x1f, 0x55, 0xc8, 0x12, 0x86, 0x03, 0x68, 0xc4, 0x2c, 0x26, 0xec, 0xde, 0xaf,
-	0x6b, 0x42, 0xe6, 0xe1, 0x9c, 0xc5, 0xb7, 0x6c, 0x21, 0x66, 0x51, 0x4a, 0xd8, 0xfd, 0x03, 0x13,
-	0x72, 0x54, 0x21, 0x16, 0x89, 0x07, 0x96, 0x23, 0xfc, 0x86, 0xe6, 0x7c, 0x55, 0xc2, 0x11, 0x69,
-	0xc2, 0x05, 0x5b, 0x92, 0x04, 0xf6, 0xa1, 0x39, 0xa5, 0x92, 0xaa, 0xd0, 0x9a, 0x9a, 0x85, 0x96,
-	0x35, 0x54, 0xda, 0x65, 0x64, 0x19, 0x08, 0x7b, 0x50, 0x9f, 0xb1, 0xf9, 0x3c, 0xf1, 0xff, 0x2e,
-	0xa0, 0x4d, 0xe6, 0x23, 0x75, 0x32, 0xaa, 0x10, 0x03, 0xc1, 0x3d, 0x63, 0x7b, 0x18, 0x85, 0xbe,
-	0xab, 0xd1, 0x5b, 0x39, 0xdb, 0xc3, 0x28, 0x34, 0xe1, 0x67, 0x98, 0x2c, 0x14, 0x95, 0x34, 0x3c,
-	0x0b, 0x65, 0x95, 0x6e, 0x06, 0xc2, 0x03, 0x00, 0xf5, 0xf9, 0x36, 0x9d, 0x52, 0xc9, 0xfc, 0xd6,
-	0x33, 0x0f, 0xe6, 0x60, 0x54, 0x21, 0x39, 0x18, 0xbe, 0x36, 0x15, 0x3d, 0x8e, 0xa7, 0xbe, 0xa7,
-	0x19, 0x5f, 0x58, 0xc6, 0xb1, 0x29, 0xec, 0x71, 0x12, 0xc7, 0x94, 0x4f, 0x95, 0x1f, 0x8b, 0xc3,
-	0x97, 0x50, 0x67, 0x71, 0x2a, 0x1f, 0xfd, 0xb6, 0x26, 0x78, 0x96, 0x70, 0xa2, 0x74, 0x2a, 0x59,
-	0x7d, 0x88, 0x3d, 0xa8, 0x4d, 0x12, 0xce, 0xfd, 0x0d, 0x0d, 0xda, 0xce, 0xac, 0x26, 0x9c, 0x9f,
-	0x08, 0x49, 0x6f, 0xe7, 0x91, 0x98, 0x8d, 0x2a, 0x44, 0x63, 0xf0, 0x15, 0xb8, 0x42, 0x52, 0xc9,
-	0xce, 0xf8, 0x5d, 0xe2, 0x6f, 0x6a, 0x42, 0xc7, 0x12, 0xae, 0x32, 0xfd, 0xa8, 0x42, 0x56, 0x20,
-	0xfc, 0x15, 0x3c, 0x2d, 0xd8, 0x6b, 0xf0, 0x3b, 0x85, 0x0a, 0x13, 0x16, 0x27, 0x92, 0x5d, 0xe5,
-	0x00, 0xa3, 0x0a, 0x29, 0x10, 0xf0, 0x08, 0xda, 0x56, 0x36, 0x2d, 0xe0, 0x6f, 0x69, 0x0b, 0x3b,
-	0x65, 0x16, 0x96, 0x4d, 0x52, 0xa4, 0x04, 0x63, 0xa8, 0x5e, 0xd3, 0x10, 0xdb, 0xe0, 0xbe, 0x1d,
-	0x0f, 0x4f, 0x7e, 0x3f, 0x1b, 0x9f, 0x0c, 0x3b, 0x15, 0x74, 0xa1, 0x7e, 0x72, 0x7e, 0x79, 0xfd,
-	0xae, 0xe3, 0xa0, 0x07, 0xeb, 0x17, 0xe4, 0xf4, 0xe6, 0x62, 0xfc, 0xe7, 0xbb, 0xce, 0x9a, 0xc2,
-	0x1d, 0x8f, 0x0e, 0xc7, 0x46, 0xac, 0x62, 0x07, 0x3c, 0x2d, 0x1e, 0x8e, 0x87, 0x37, 0x17, 0xe4,
-	0xb4, 0x53, 0x3b, 0x72, 0xa1, 0x39, 0x49, 0xb8, 0x64, 0x5c, 0x06, 0x1f, 0x1c, 0x70, 0x97, 0xa9,
-	0xe3, 0x0e, 0xac, 0xc7, 0x4c, 0x52, 0x55, 0x36, 0x3d, 0x8f, 0x1e, 0x59, 0xca, 0xb8, 0x07, 0xae,
-	0x8c, 0x62, 0x26, 0x24, 0x8d, 0x53, 0x3d, 0x94, 0xad, 0xc1, 0xa6, 0x4d, 0xe2, 0x92, 0xb1, 0xc5,
-	0x75, 0x14, 0x33, 0xb2, 0x42, 0xa8, 0xb9, 0x4e, 0xdf, 0x47, 0x67, 0x43, 0x3d, 0xa9, 0x1e, 0x31,
-	0x02, 0x7e, 0x0d, 0xae, 0x88, 0x42, 0x4e, 0xe5, 0xc3, 0x82, 0xe9, 0x91, 0xf4, 0xc8, 0x4a, 0x11,
-	0xf4, 0x60, 0xa3, 0xd8, 0x0d, 0x6a, 0x0f, 0xa4, 0xf4, 0x71, 0x9e, 0xd0, 0xa9, 0x8d, 0x27, 0x13,
-	0x83, 0x37, 0xd0, 0x2e, 0xd4, 0x18, 0x3b, 0x50, 0x15, 0x51, 0x68, 0x61, 0xea, 0x73, 0x15, 0xc2,
-	0x5a, 0x2e, 0x84, 0xe0, 0x67, 0x68, 0xe5, 0xfa, 0xfa, 0x13, 0xfb, 0xe7, 0x4b, 0x68, 0x08, 0x76,
-	0x7f, 0x4e, 0x55, 0xa6, 0xd5, 0x6e, 0x8d, 0x58, 0x29, 0xf8, 0x16, 0x5a, 0xb9, 0x89, 0x2b, 0x27,
-	0x07, 0x7f, 0x00, 0xac, 0xc6, 0xe0, 0x13, 0x0e, 0xbe, 0x83, 0x9a, 0xbe, 0x65, 0x65, 0xbe, 0x74,
-	0xf6, 0x89, 0x3e, 0x0f, 0x7e, 0x32, 0xb6, 0xcc, 0xd0, 0x7e, 0x66, 0xb0, 0x6f, 0x4c, 0xa6, 0xd9,
-	0xa6, 0xed, 0x16, 0xef, 0xb2, 0x35, 0xd8, 0xc8, 0xca, 0x67, 0xb4, 0xab, 0xbb, 0x3d, 0x83, 0xa6,
-	0xd5, 0x59, 0xdb, 0xe3, 0x87, 0xd8, 0xba, 0xb4, 0x12, 0x22, 0xd4, 0x66, 0x54, 0xcc, 0xf4, 0xd5,
-	0xba, 0x44, 0x7f, 0x2b, 0x9d, 0xce, 0xc9, 0x54, 0xdc, 0xc4, 0xff, 0xc1, 0x01, 0x2f, 0xbf, 0x6c,
-	0x71, 0x0f, 0x20, 0x5e, 0xee, 0x45, 0x1b, 0x48, 0xbb, 0xb0, 0x30, 0x49, 0x0e, 0xf0, 0xb9, 0x5d,
-	0x57, 0xe8, 0xaf, 0xea, 0xd3, 0xfe, 0x3a, 0x84, 0xf5, 0x8c, 0x84, 0xdf, 0x00, 0x44, 0x7c, 0x72,
-	0xc3, 0x1f, 0x94, 0x2b, 0x9b, 0x9c, 0x1b, 0xf1, 0xc9, 0x58, 0x2b, 0x72, 0x79, 0xaf, 0xe5, 0xf3,
-	0x0e, 0x66, 0xb0, 0xf5, 0xec, 0x29, 0xc0, 0x5f, 0x60, 0x53, 0xb0, 0xf9, 0x9d, 0x1a, 0xa1, 0x45,
-	0x4c, 0x65, 0x94, 0x70, 0x9b, 0x58, 0xd9, 0x73, 0x43, 0x9e, 0x62, 0x55, 0x55, 0xdf, 0xf3, 0xe4,
-	0x3f, 0xae, 0xcb, 0xe7, 0x11, 0x23, 0x04, 0x33, 0xc0, 0xe7, 0x0f, 0x08, 0xfe, 0x00, 0x75, 0xfd,
-	0x56, 0xf9, 0x8e, 0x6e, 0x9c, 0x52, 0x07, 0x06, 0x81, 0xdf, 0x43, 0x6d, 0xca, 0xe8, 0xd4, 0xb6,
-	0x58, 0x29, 0x52, 0x03, 0x82, 0xbf, 0xa0, 0x61, 0x3c, 0xa9, 0xf9, 0x67, 0x7c, 0x9a, 0x26, 0x11,
-	0x97, 0x3a, 0x03, 0x97, 0x2c, 0xe5, 0xc2, 0x6e, 0x58, 0x7b, 0xb2, 0x1b, 0x4a, 0x87, 0x3d, 0x68,
-	0x42, 0x5d, 0xef, 0xea, 0xa0, 0x0f, 0xf8, 0x7c, 0x53, 0xaa, 0xd9, 0x36, 0x97, 0x2a, 0x74, 0x32,
-	0x35, 0x92, 0x89, 0xc1, 0x21, 0xbc, 0x28, 0xd9, 0x8b, 0xd8, 0x83, 0x75, 0xdb, 0xa1, 0xc2, 0xa6,
-	0xff, 0xb4, 0x83, 0x97, 0xe7, 0x83, 0x14, 0x1a, 0x66, 0x50, 0xf1, 0x37, 0xf0, 0xcc, 0xd7, 0x95,
-	0x5c, 0x30, 0x1a, 0xe3, 0x76, 0xd9, 0x7f, 0x86, 0x9d, 0x52, 0x6d, 0x50, 0xe9, 0x3a, 0xaf, 0x1c,
-	0x7c, 0x09, 0xb5, 0xcb, 0x88, 0x87, 0x58, 0x78, 0x80, 0x76, 0x0a, 0x52, 0x50, 0x39, 0xfa, 0xf1,
-	0x9f, 0x5e, 0x18, 0xc9, 0xd9, 0xc3, 0x6d, 0x7f, 0x92, 0xc4, 0xfb, 0xb3, 0xc7, 0x94, 0x2d, 0xe6,
-	0x6c, 0x1a, 0xb2, 0xc5, 0xfe, 0x1d, 0xbd, 0x5d, 0x44, 0x93, 0xfd, 0x50, 0x9b, 0xde, 0xd7, 0xac,
-	0xdb, 0x86, 0xfe, 0x39, 0xf8, 0x18, 0x00, 0x00, 0xff, 0xff, 0x54, 0x9e, 0xcc, 0xd3, 0x27, 0x09,
-	0x00, 0x00,
+	// 1093 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x56, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0x8e, 0xf3, 0xdb, 0x9c, 0x38, 0x6d, 0x76, 0x76, 0x41, 0xa6, 0x02, 0x29, 0xb2, 0x56, 0x10,
+	0xa2, 0xdd, 0xb4, 0x9b, 0x5e, 0xc0, 0x0d, 0x82, 0xb6, 0x29, 0x4d, 0x05, 0x49, 0xcb, 0xb4, 0x0b,
+	0x5a, 0x6e, 0xaa, 0x69, 0x32, 0xb5, 0x47, 0xb5, 0xc7, 0x6e, 0x66, 0x02, 0xea, 0x2b, 0x70, 0xc1,
+	0x9b, 0xf0, 0x8e, 0x68, 0x7e, 0x9c, 0xd8, 0x6d, 0x2a, 0xb4, 0x17, 0x5c, 0x79, 0xce, 0x99, 0xef,
+	0x3b, 0x73, 0xce, 0xcc, 0xf9, 0x31, 0xb4, 0x63, 0x2a, 0x04, 0x09, 0xe8, 0x20, 0x5d, 0x24, 0x32,
+	0x41, 0x35, 0xfd, 0xf1, 0xff, 0x6e, 0x40, 0xfb, 0x34, 0x11, 0x82, 0xa5, 0x13, 0xb3, 0x8d, 0x5e,
+	0x41, 0x8d, 0x27, 0x7c, 0x46, 0x3d, 0xa7, 0xeb, 0xf4, 0xaa, 0xd8, 0x08, 0xc8, 0x83, 0xc6, 0x2c,
+	0x24, 0x9c, 0xd3, 0xc8, 0x2b, 0x77, 0x9d, 0x9e, 0x8b, 0x33, 0x11, 0xf5, 0xa1, 0x22, 0x49, 0xe0,
+	0x55, 0xba, 0x4e, 0x6f, 0x7b, 0xe8, 0x19, 0xeb, 0x83, 0x82, 0xc9, 0xc1, 0x15, 0x09, 0xb0, 0x02,
+	0xa1, 0x77, 0xb0, 0x45, 0x22, 0xf6, 0x07, 0x9d, 0x88, 0xc0, 0xab, 0x76, 0x9d, 0x5e, 0x6b, 0xf8,
+	0xd2, 0x12, 0x0e, 0xb5, 0xda, 0xe0, 0xc7, 0x25, 0xbc, 0x82, 0xa1, 0x21, 0xd4, 0x63, 0x1a, 0x63,
+	0x7a, 0xef, 0xd5, 0x34, 0x21, 0x3b, 0x61, 0x42, 0xe3, 0x1b, 0xba, 0x10, 0x21, 0x4b, 0x31, 0xbd,
+	0x5f, 0x52, 0x21, 0xc7, 0x25, 0x6c, 0x91, 0xe8, 0xc0, 0x72, 0x84, 0x57, 0xd7, 0x9c, 0xcf, 0x36,
+	0x70, 0x44, 0x9a, 0x70, 0x41, 0x57, 0x24, 0x81, 0x06, 0xd0, 0x98, 0x13, 0x49, 0x94, 0x6b, 0x0d,
+	0xcd, 0x42, 0x96, 0x35, 0x52, 0xda, 0x95, 0x67, 0x19, 0x08, 0xf5, 0xa1, 0x16, 0xd2, 0x28, 0x4a,
+	0xbc, 0xdf, 0x0a, 0x68, 0x13, 0xf9, 0x58, 0xed, 0x8c, 0x4b, 0xd8, 0x40, 0xd0, 0x5b, 0x63, 0x7b,
+	0xc4, 0x02, 0xaf, 0xa9, 0xd1, 0x2f, 0x72, 0xb6, 0x47, 0x2c, 0x30, 0xee, 0x67, 0x98, 0xcc, 0x15,
+	0x15, 0x34, 0x3c, 0x71, 0x65, 0x1d, 0x6e, 0x06, 0x42, 0x07, 0x00, 0x6a, 0xf9, 0x3e, 0x9d, 0x13,
+	0x49, 0xbd, 0xd6, 0x93, 0x13, 0xcc, 0xc6, 0xb8, 0x84, 0x73, 0x30, 0xf4, 0xce, 0xbc, 0xe8, 0x71,
+	0x3c, 0xf7, 0x5c, 0xcd, 0xf8, 0xc4, 0x32, 0x8e, 0xcd, 0xc3, 0x1e, 0x27, 0x71, 0x4c, 0xf8, 0x5c,
+	0x9d, 0x63, 0x71, 0xe8, 0x35, 0xd4, 0x68, 0x9c, 0xca, 0x07, 0xaf, 0xad, 0x09, 0xae, 0x25, 0x9c,
+	0x28, 0x9d, 0x0a, 0x56, 0x6f, 0xa2, 0x3e, 0x54, 0x67, 0x09, 0xe7, 0xde, 0xb6, 0x06, 0xbd, 0xca,
+	0xac, 0x26, 0x9c, 0x9f, 0x08, 0x49, 0x6e, 0x22, 0x26, 0xc2, 0x71, 0x09, 0x6b, 0x0c, 0xda, 0x87,
+	0xa6, 0x90, 0x44, 0xd2, 0x33, 0x7e, 0x9b, 0x78, 0x3b, 0x9a, 0xd0, 0xb1, 0x84, 0xcb, 0x4c, 0x3f,
+	0x2e, 0xe1, 0x35, 0x08, 0x7d, 0x0f, 0xae, 0x16, 0xec, 0x35, 0x78, 0x9d, 0xc2, 0x0b, 0x63, 0x1a,
+	0x27, 0x92, 0x5e, 0xe6, 0x00, 0xe3, 0x12, 0x2e, 0x10, 0xd0, 0x11, 0xb4, 0xad, 0x6c, 0x52, 0xc0,
+	0x7b, 0xa1, 0x2d, 0xec, 0x6e, 0xb2, 0xb0, 0x4a, 0x92, 0x22, 0xc5, 0x9f, 0x42, 0xe5, 0x8a, 0x04,
+	0xa8, 0x0d, 0xcd, 0xf7, 0xd3, 0xd1, 0xc9, 0x8f, 0x67, 0xd3, 0x93, 0x51, 0xa7, 0x84, 0x9a, 0x50,
+	0x3b, 0x99, 0x5c, 0x5c, 0x7d, 0xe8, 0x38, 0xc8, 0x85, 0xad, 0x73, 0x7c, 0x7a, 0x7d, 0x3e, 0xfd,
+	0xf9, 0x43, 0xa7, 0xac, 0x70, 0xc7, 0xe3, 0xc3, 0xa9, 0x11, 0x2b, 0xa8, 0x03, 0xae, 0x16, 0x0f,
+	0xa7, 0xa3, 0xeb, 0x73, 0x7c, 0xda, 0xa9, 0x1e, 0x35, 0xa1, 0x31, 0x4b, 0xb8, 0xa4, 0x5c, 0xfa,
+	0x7f, 0x39, 0xd0, 0x5c, 0x85, 0x8e, 0x76, 0x61, 0x2b, 0xa6, 0x92, 0xa8, 0x67, 0xd3, 0xf5, 0xe8,
+	0xe2, 0x95, 0x8c, 0xde, 0x42, 0x53, 0xb2, 0x98, 0x0a, 0x49, 0xe2, 0x54, 0x17, 0x65, 0x6b, 0xb8,
+	0x63, 0x83, 0xb8, 0xa0, 0x74, 0x71, 0xc5, 0x62, 0x8a, 0xd7, 0x08, 0x55, 0xd7, 0xe9, 0x1d, 0x3b,
+	0x1b, 0xe9, 0x4a, 0x75, 0xb1, 0x11, 0xd0, 0xe7, 0xd0, 0x14, 0x2c, 0xe0, 0x44, 0x2e, 0x17, 0x54,
+	0x97, 0xa4, 0x8b, 0xd7, 0x0a, 0xbf, 0x0f, 0xdb, 0xc5, 0x6c, 0x50, 0x7d, 0x20, 0x25, 0x0f, 0x51,
+	0x42, 0xe6, 0xd6, 0x9f, 0x4c, 0xf4, 0x7f, 0x82, 0x76, 0xe1, 0x8d, 0x51, 0x07, 0x2a, 0x82, 0x05,
+	0x16, 0xa6, 0x96, 0x6b, 0x17, 0xca, 0x79, 0x17, 0x10, 0x54, 0x67, 0x74, 0x21, 0xad, 0x5f, 0x7a,
+	0xed, 0x7f, 0x0b, 0xae, 0x8a, 0xe1, 0x6c, 0x4e, 0xb9, 0x64, 0xf2, 0x61, 0xcd, 0x74, 0x36, 0x31,
+	0xcb, 0x39, 0x26, 0x83, 0x56, 0xae, 0x4a, 0x9e, 0xe9, 0x66, 0x9f, 0x42, 0x5d, 0xd0, 0xfb, 0x09,
+	0x51, 0xf7, 0x56, 0xe9, 0x55, 0xb1, 0x95, 0xd0, 0x1b, 0x68, 0xc4, 0x22, 0xb8, 0x7a, 0x48, 0xa9,
+	0xed, 0x67, 0x59, 0xe1, 0x5d, 0x2c, 0xa3, 0x68, 0x62, 0x76, 0x70, 0x06, 0xf1, 0x7f, 0x81, 0x56,
+	0xae, 0xda, 0x9f, 0x39, 0x2a, 0x67, 0xb2, 0xfc, 0xdf, 0x26, 0xff, 0x71, 0x00, 0xd6, 0x15, 0xfb,
+	0x8c, 0xc9, 0x2f, 0xa1, 0xaa, 0x13, 0x42, 0xf9, 0xbe, 0xb1, 0x4d, 0x61, 0xbd, 0xff, 0x71, 0xd1,
+	0xa8, 0x26, 0xc2, 0xcc, 0x75, 0x33, 0x2a, 0xbc, 0x6a, 0xb7, 0x92, 0xeb, 0xce, 0xf9, 0xb7, 0xc0,
+	0x39, 0x98, 0x1f, 0x02, 0xac, 0x5b, 0xd8, 0xff, 0x7a, 0xd9, 0xdf, 0x40, 0x2b, 0x17, 0x21, 0xea,
+	0x15, 0xf3, 0xb0, 0x35, 0xdc, 0xce, 0xc8, 0x46, 0xbb, 0xce, 0xcb, 0x33, 0x68, 0x58, 0x9d, 0xf5,
+	0x64, 0xba, 0x8c, 0xad, 0x83, 0x56, 0x52, 0x79, 0x14, 0x12, 0x11, 0xea, 0x07, 0x6a, 0x62, 0xbd,
+	0x56, 0x3a, 0x7d, 0xc9, 0x36, 0x2b, 0xd5, 0x5a, 0xd5, 0xa6, 0x9b, 0x1f, 0x54, 0xe8, 0x2d, 0x40,
+	0xbc, 0x9a, 0x29, 0xd6, 0x91, 0x76, 0x61, 0xd8, 0xe0, 0x1c, 0xe0, 0x63, 0x2b, 0xb6, 0x50, 0x9b,
+	0x95, 0xc7, 0xb5, 0x79, 0x08, 0x5b, 0x19, 0x09, 0x7d, 0x01, 0xc0, 0xf8, 0xec, 0x9a, 0x2f, 0xd5,
+	0x51, 0x36, 0xb8, 0x26, 0xe3, 0xb3, 0xa9, 0x56, 0xe4, 0xe2, 0x2e, 0xe7, 0xe3, 0xf6, 0x43, 0x78,
+	0xf1, 0x64, 0x8c, 0xa2, 0xef, 0x60, 0x47, 0xd0, 0xe8, 0x56, 0xb5, 0x9f, 0x45, 0x4c, 0x24, 0x4b,
+	0xb8, 0x0d, 0x6c, 0xd3, 0xa8, 0xc6, 0x8f, 0xb1, 0x2a, 0x07, 0xee, 0x78, 0xf2, 0x27, 0xd7, 0x8f,
+	0xed, 0x62, 0x23, 0xf8, 0x21, 0xa0, 0xa7, 0xc3, 0x17, 0x7d, 0x0d, 0x35, 0x3d, 0xe7, 0x3d, 0xa7,
+	0x5b, 0x79, 0xee, 0x00, 0x83, 0x40, 0x5f, 0x41, 0x75, 0x4e, 0xc9, 0xdc, 0x2b, 0x3f, 0x8f, 0xd4,
+	0x00, 0xff, 0x57, 0xa8, 0x9b, 0x93, 0x54, 0xef, 0xa4, 0x7c, 0x9e, 0x26, 0x8c, 0x4b, 0x1d, 0x41,
+	0x13, 0xaf, 0xe4, 0x42, 0x5f, 0x2d, 0x3f, 0xea, 0xab, 0x1b, 0x1b, 0xa5, 0xdf, 0x80, 0x9a, 0x9e,
+	0x73, 0xfe, 0x00, 0xd0, 0xd3, 0x29, 0xa3, 0xfa, 0xa2, 0xb9, 0x54, 0xa1, 0x83, 0xa9, 0xe2, 0x4c,
+	0xf4, 0x0f, 0xe1, 0xe5, 0x86, 0x99, 0x82, 0xfa, 0xb0, 0x65, 0x33, 0x54, 0xd8, 0xf0, 0x1f, 0x67,
+	0xf0, 0x6a, 0xbf, 0xbf, 0x0f, 0xad, 0x5c, 0x4d, 0xa8, 0xf9, 0x71, 0x14, 0x25, 0xb3, 0x3b, 0x1b,
+	0x78, 0xa7, 0x84, 0x76, 0xa0, 0x95, 0x95, 0xe7, 0x44, 0x04, 0x1d, 0x67, 0x98, 0x42, 0xdd, 0xb4,
+	0x26, 0xf4, 0x03, 0xb8, 0x66, 0x75, 0x29, 0x17, 0x94, 0xc4, 0xe8, 0xd5, 0xa6, 0x3f, 0xb4, 0xdd,
+	0x8d, 0x5a, 0xbf, 0xd4, 0x73, 0xf6, 0x1d, 0xf4, 0x1a, 0xaa, 0x17, 0x8c, 0x07, 0xa8, 0x30, 0xee,
+	0x77, 0x0b, 0x92, 0x5f, 0x3a, 0x7a, 0xf3, 0x7b, 0x3f, 0x60, 0x32, 0x5c, 0xde, 0x0c, 0x66, 0x49,
+	0xbc, 0x17, 0x3e, 0xa4, 0x74, 0x11, 0xd1, 0x79, 0x40, 0x17, 0x7b, 0xb7, 0xe4, 0x66, 0xc1, 0x66,
+	0x7b, 0x81, 0x36, 0xbd, 0xa7, 0x59, 0x37, 0x75, 0xfd, 0x39, 0xf8, 0x77, 0x00, 0xdd, 0x92, 0xa6,
+	0x60, 0x95, 0x0a, 0x00, 0x00,
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package state
 
 import (
+	"fmt"
+	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/proto"
 	pb "github.com/golang/protobuf/proto"
@@ -26,6 +28,7 @@ import (
 	"sync/atomic"
 	"time"
 	"math/rand"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/core/committer"
 )
@@ -77,13 +80,17 @@ type GossipStateProviderImpl struct {
 
 	committer  committer.Committer;
 
+	// Verifies the blocks received from other peers
+	mcs        api.MessageCryptoService;
+
 	logger     *logging.Logger;
 
 	done       sync.WaitGroup;
 }
 
-// NewGossipStateProvider creates initialized instance of gossip state provider
-func NewGossipStateProvider(g gossip.Gossip, c comm.Comm, committer committer.Committer) GossipStateProvider {
+// NewGossipStateProvider creates initialized instance of gossip state provider,
+// the blocks received from other peers are only committed if mcs verifies them
+func NewGossipStateProvider(g gossip.Gossip, c comm.Comm, committer committer.Committer, mcs api.MessageCryptoService) GossipStateProvider {
 	logger, _ := logging.GetLogger("GossipStateProvider")
 
 	gossipChan := g.Accept(func(message interface{}) bool {
@@ -124,6 +131,8 @@ func NewGossipStateProvider(g gossip.Gossip, c comm.Comm, committer committer.Co
 
 		committer: committer,
 
+		mcs: mcs,
+
 		logger: logger,
 	}
 
@@ -198,29 +207,12 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg comm.ReceivedMessage) {
 	request := msg.GetGossipMessage().GetStateRequest()
 	response := &proto.RemoteStateResponse{Payloads:make([]*proto.Payload, 0)}
 	for _, seqNum := range request.SeqNums {
-		s.logger.Debug("Reading block ", seqNum, " from the committer service")
-		blocks := s.committer.GetBlocks([]uint64{seqNum})
-
-		if blocks == nil || len(blocks) < 1 {
-			s.logger.Errorf("Wasn't able to read block with sequence number %d from ledger, skipping....", seqNum)
+		payload := s.committedPayload(seqNum)
+		if payload == nil {
+			s.logger.Debugf("No signed block with sequence number %d to send, skipping....", seqNum)
 			continue
 		}
-
-		blockBytes, err := pb.Marshal(blocks[0])
-		if err != nil {
-			s.logger.Errorf("Could not marshal block: %s", err)
-		}
-
-		if err != nil {
-			s.logger.Errorf("Could not calculate hash of block: %s", err)
-		}
-
-		response.Payloads = append(response.Payloads, &proto.Payload{
-			SeqNum: seqNum,
-			Data: blockBytes,
-			// TODO: Check hash generation for given block from the ledger
-			Hash: "",
-		})
+		response.Payloads = append(response.Payloads, payload)
 	}
 	// Sending back response with missing blocks
 	msg.Respond(&proto.GossipMessage{
@@ -232,6 +224,10 @@ func (s *GossipStateProviderImpl) handleStateResponse(msg comm.ReceivedMessage)
 	response := msg.GetGossipMessage().GetStateResponse()
 	for _, payload := range response.GetPayloads() {
 		s.logger.Debugf("Received payload with sequence number %d.", payload.SeqNum)
+		if err := s.verifyPayload(payload); err != nil {
+			s.logger.Warningf("Dropping payload with sequence number %d, %s", payload.SeqNum, err)
+			continue
+		}
 		err := s.payloads.Push(payload)
 		if err != nil {
 			s.logger.Warningf("Payload with sequence number %d was received earlier", payload.SeqNum)
@@ -257,12 +253,38 @@ func (s *GossipStateProviderImpl) queueNewMessage(msg *proto.GossipMessage) {
 	if (dataMsg != nil) {
 		// Add new payload to ordered set
 		s.logger.Debugf("Received new payload with sequence number = [%d]", dataMsg.Payload.SeqNum)
+		if err := s.verifyPayload(dataMsg.GetPayload()); err != nil {
+			s.logger.Warningf("Dropping payload with sequence number %d, %s", dataMsg.Payload.SeqNum, err)
+			return
+		}
 		s.payloads.Push(dataMsg.GetPayload())
 	} else {
 		s.logger.Debug("Gossip message received is not of data message type, usually this should not happen.")
 	}
 }
 
+// verifyPayload checks the block of a payload received from another peer before
+// it is buffered, so that a forged block cannot take the place of the real one.
+// The payload holds a block of the ordering service, whose signatures mcs verifies.
+// The ledger numbers its blocks from 1 and the ordering service from 0, its
+// genesis block being the first block of the ledger
+func (s *GossipStateProviderImpl) verifyPayload(payload *proto.Payload) error {
+	if payload == nil {
+		return fmt.Errorf("empty payload")
+	}
+	block := &common.Block{}
+	if err := pb.Unmarshal(payload.Data, block); err != nil {
+		return fmt.Errorf("invalid block: %s", err)
+	}
+	if block.Header == nil || block.Header.Number+1 != payload.SeqNum {
+		return fmt.Errorf("block is not the one of sequence number %d", payload.SeqNum)
+	}
+	if err := s.mcs.VerifyBlock(block); err != nil {
+		return fmt.Errorf("block verification failed: %s", err)
+	}
+	return nil
+}
+
 func (s *GossipStateProviderImpl) deliverPayloads() {
 	for !s.isDone() {
 		next:
@@ -273,14 +295,14 @@ func (s *GossipStateProviderImpl) deliverPayloads() {
 					s.logger.Debugf("Ready to transfer payloads to the ledger, next sequence number is = [%d]", s.payloads.Next())
 					// Collect all subsequent payloads
 					for payload := s.payloads.Pop(); payload != nil; payload = s.payloads.Pop() {
-						rawblock := &peer.Block2{}
+						rawblock := &common.Block{}
 						if err := pb.Unmarshal(payload.Data, rawblock); err != nil {
 							s.logger.Errorf("Error getting block with seqNum = %d due to (%s)...dropping block\n", payload.SeqNum, err)
 							continue
 						}
 						s.logger.Debug("New block with sequence number ", payload.SeqNum, " is ", rawblock)
 
-						s.commitBlock(rawblock, payload.SeqNum)
+						s.commitBlock(committer.ToBlock2(rawblock), payload.SeqNum)
 					}
 				}
 			case <-time.After(defPollingPeriod):
@@ -375,6 +397,20 @@ func (s *GossipStateProviderImpl) GetBlock(index uint64) *peer.Block2 {
 	return nil
 }
 
+// committedPayload returns the payload of the committed block with the sequence
+// number, which holds the block of the ordering service kept by the ledger with
+// its signatures, so that the requester can verify it. It returns nil if the
+// block is not committed or was not received from the ordering service
+func (s *GossipStateProviderImpl) committedPayload(seqNum uint64) *proto.Payload {
+	block := s.GetBlock(seqNum)
+	if block == nil || len(block.OrdererBlock) == 0 {
+		return nil
+	}
+	return &proto.Payload{SeqNum: seqNum, Data: block.OrdererBlock}
+}
+
+// AddPayload adds a payload received from the ordering service, its data is a
+// block of the ordering service with its signatures
 func (s *GossipStateProviderImpl) AddPayload(payload *proto.Payload) error {
 	return s.payloads.Push(payload)
 }
@@ -27,10 +27,13 @@ import (
 	pb "github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/committer"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/proto"
-	"github.com/hyperledger/fabric/protos/peer"
+	pcomm "github.com/hyperledger/fabric/protos/common"
 	"github.com/op/go-logging"
 )
 
@@ -65,6 +68,105 @@ func (*naiveCryptoService) Verify(vkID, signature, message []byte) error {
 	return fmt.Errorf("Failed verifying")
 }
 
+// naiveMCS considers the PKI-ID of an identity to be the identity itself
+type naiveMCS struct {
+}
+
+func (*naiveMCS) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
+	return common.PKIidType(peerIdentity)
+}
+
+func (*naiveMCS) VerifyBlock(signedBlock api.SignedBlock) error {
+	return nil
+}
+
+func (*naiveMCS) Sign(msg []byte) ([]byte, error) {
+	return msg, nil
+}
+
+func (*naiveMCS) Verify(peerIdentity api.PeerIdentityType, signature, message []byte) error {
+	if bytes.Equal(signature, message) {
+		return nil
+	}
+	return fmt.Errorf("Failed verifying")
+}
+
+func (*naiveMCS) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
+	return nil
+}
+
+// rejectingMCS fails the verification of every block
+type rejectingMCS struct {
+	naiveMCS
+}
+
+func (*rejectingMCS) VerifyBlock(signedBlock api.SignedBlock) error {
+	return fmt.Errorf("Unverified block")
+}
+
+func TestVerifyPayload(t *testing.T) {
+	blockBytes, _ := pb.Marshal(&pcomm.Block{Header: &pcomm.BlockHeader{Number: 0}, Data: &pcomm.BlockData{Data: [][]byte{[]byte("tx")}}})
+	payload := &proto.Payload{SeqNum: 1, Data: blockBytes}
+
+	s := &GossipStateProviderImpl{mcs: &naiveMCS{}}
+	if err := s.verifyPayload(payload); err != nil {
+		t.Fatalf("Expected the payload to be verified, got %s", err)
+	}
+	if s.verifyPayload(&proto.Payload{SeqNum: 1, Data: []byte("not a block")}) == nil {
+		t.Fatal("Expected a payload which is not a block to be rejected")
+	}
+	if s.verifyPayload(nil) == nil {
+		t.Fatal("Expected an empty payload to be rejected")
+	}
+	if s.verifyPayload(&proto.Payload{SeqNum: 2, Data: blockBytes}) == nil {
+		t.Fatal("Expected a block with another sequence number to be rejected")
+	}
+
+	s = &GossipStateProviderImpl{mcs: &rejectingMCS{}}
+	if s.verifyPayload(payload) == nil {
+		t.Fatal("Expected a block failing verification to be rejected")
+	}
+}
+
+func TestCommittedPayloadFromLedger(t *testing.T) {
+	ledgerPath := "/tmp/tests/ledger/"
+	defer os.RemoveAll(ledgerPath)
+
+	c := newCommitter(0, ledgerPath+"committed/")
+	defer c.Close()
+	s := &GossipStateProviderImpl{committer: c}
+
+	blocks := []*pcomm.Block{}
+	for i := 0; i < 3; i++ {
+		block := &pcomm.Block{
+			Header:   &pcomm.BlockHeader{Number: uint64(i)},
+			Data:     &pcomm.BlockData{},
+			Metadata: &pcomm.BlockMetadata{Metadata: [][]byte{[]byte(fmt.Sprintf("signatures of block %d", i))}},
+		}
+		if err := c.CommitBlock(committer.ToBlock2(block)); err != nil {
+			t.Fatalf("Failed committing block %d: %s", i, err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	for i, block := range blocks {
+		payload := s.committedPayload(uint64(i + 1))
+		if payload == nil {
+			t.Fatalf("Expected the payload of block %d to be read from the ledger", i)
+		}
+		served := &pcomm.Block{}
+		if err := pb.Unmarshal(payload.Data, served); err != nil {
+			t.Fatalf("Expected the payload to hold a block: %s", err)
+		}
+		if !pb.Equal(served, block) {
+			t.Fatalf("Expected block %v with its signatures, got %v", block, served)
+		}
+	}
+	if s.committedPayload(uint64(len(blocks) + 1)) != nil {
+		t.Fatal("Expected no payload for a block which is not committed")
+	}
+}
+
 func bootPeers(ids ...int) []string {
 	peers := []string{}
 	for _, id := range ids {
@@ -110,7 +212,9 @@ func newGossipConfig(id int, maxMsgCount int, boot ...int) *gossip.Config {
 
 // Create gossip instance
 func newGossipInstance(config *gossip.Config, comm comm.Comm) gossip.Gossip {
-	return gossip.NewGossipService(config, comm, &naiveCryptoService{})
+	idMapper := identity.NewIdentityMapper(&naiveMCS{})
+	idMapper.Put(comm.GetPKIid(), api.PeerIdentityType(config.SelfEndpoint))
+	return gossip.NewGossipService(config, comm, &naiveCryptoService{}, idMapper)
 }
 
 // Setup and create basic communication module
@@ -145,7 +249,7 @@ func newPeerNode(config *gossip.Config, committer committer.Committer) *peerNode
 	return &peerNode{
 		c: comm,
 		g: gossip,
-		s: NewGossipStateProvider(gossip, comm, committer),
+		s: NewGossipStateProvider(gossip, comm, committer, &naiveMCS{}),
 
 		commit: committer,
 	}
@@ -266,11 +370,7 @@ func TestNewGossipStateProvider_SendingManyMessages(t *testing.T) {
 	msgCount := 10
 
 	for i := 1; i <= msgCount; i++ {
-		rawblock := &peer.Block2{}
-		if err := pb.Unmarshal([]byte{}, rawblock); err != nil {
-			t.Fail()
-		}
-
+		rawblock := &pcomm.Block{Header: &pcomm.BlockHeader{Number: uint64(i - 1)}, Data: &pcomm.BlockData{}}
 		if bytes, err := pb.Marshal(rawblock); err == nil {
 			payload := &proto.Payload{uint64(i), "", bytes}
 			bootstrapSet[0].s.AddPayload(payload)
@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds (in seconds) of the histogram buckets used when none are specified.
+// They are meant for latencies ranging from a millisecond to ten seconds
+var DefaultBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// metric is the common part of all the metric types: the description and the
+// series, one for each combination of label values seen so far
+type metric struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+	// the upper bounds of the buckets, only used by histograms
+	buckets []float64
+
+	lock   sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	// only used by histograms
+	bucketCounts []uint64
+	count        uint64
+}
+
+func newMetric(name, help, metricType string, labelNames []string) *metric {
+	return &metric{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		labelNames: labelNames,
+		series:     make(map[string]*series),
+	}
+}
+
+// getSeries returns the series for the label values, creating it if needed.
+// It must be called with the lock held
+func (m *metric) getSeries(labelValues []string) *series {
+	if len(labelValues) != len(m.labelNames) {
+		panic(fmt.Errorf("Metric %s expects %d label values, got %d", m.name, len(m.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	s, ok := m.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string{}, labelValues...)}
+		m.series[key] = s
+	}
+	return s
+}
+
+func (m *metric) add(delta float64, labelValues []string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.getSeries(labelValues).value += delta
+}
+
+func (m *metric) set(value float64, labelValues []string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.getSeries(labelValues).value = value
+}
+
+func (m *metric) get(labelValues []string) float64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.getSeries(labelValues).value
+}
+
+// sortedSeries returns a snapshot of the series ordered by label values
+func (m *metric) sortedSeries() []series {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	keys := make([]string, 0, len(m.series))
+	for k := range m.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	snapshot := make([]series, len(keys))
+	for i, k := range keys {
+		s := m.series[k]
+		snapshot[i] = *s
+		snapshot[i].bucketCounts = append([]uint64{}, s.bucketCounts...)
+	}
+	return snapshot
+}
+
+// Counter is a metric whose value can only go up, such as the number of messages received
+type Counter struct {
+	*metric
+}
+
+// Inc increments by one the counter for the given label values
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds `delta` (which must not be negative) to the counter for the given label values
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Errorf("Counter %s cannot be decreased", c.name))
+	}
+	c.add(delta, labelValues)
+}
+
+// Value returns the current value of the counter for the given label values
+func (c *Counter) Value(labelValues ...string) float64 {
+	return c.get(labelValues)
+}
+
+// Gauge is a metric whose value can go up and down, such as the length of a queue
+type Gauge struct {
+	*metric
+}
+
+// Set sets the gauge for the given label values
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.set(value, labelValues)
+}
+
+// Add adds `delta` (which may be negative) to the gauge for the given label values
+func (g *Gauge) Add(delta float64, labelValues ...string) {
+	g.add(delta, labelValues)
+}
+
+// Inc increments by one the gauge for the given label values
+func (g *Gauge) Inc(labelValues ...string) {
+	g.add(1, labelValues)
+}
+
+// Dec decrements by one the gauge for the given label values
+func (g *Gauge) Dec(labelValues ...string) {
+	g.add(-1, labelValues)
+}
+
+// Value returns the current value of the gauge for the given label values
+func (g *Gauge) Value(labelValues ...string) float64 {
+	return g.get(labelValues)
+}
+
+// Histogram samples observations (such as latencies) and counts them in configurable buckets
+type Histogram struct {
+	*metric
+}
+
+// Observe adds an observation to the histogram for the given label values
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	s := h.getSeries(labelValues)
+	if s.bucketCounts == nil {
+		s.bucketCounts = make([]uint64, len(h.buckets))
+	}
+	// the buckets are counted cumulatively when written
+	i := sort.SearchFloat64s(h.buckets, value)
+	if i < len(h.buckets) {
+		s.bucketCounts[i]++
+	}
+	s.count++
+	s.value += value
+}
+
+// Count returns the number of observations and their sum for the given label values
+func (h *Histogram) Count(labelValues ...string) (uint64, float64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	s := h.getSeries(labelValues)
+	return s.count, s.value
+}
+
+func checkBuckets(buckets []float64) []float64 {
+	if len(buckets) == 0 {
+		return DefaultBuckets
+	}
+	for i := range buckets {
+		if math.IsInf(buckets[i], 1) {
+			panic(fmt.Errorf("The +Inf bucket is implicit and must not be specified"))
+		}
+		if i > 0 && buckets[i] <= buckets[i-1] {
+			panic(fmt.Errorf("Histogram buckets must be in increasing order"))
+		}
+	}
+	return buckets
+}
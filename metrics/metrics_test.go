@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("test_total", "A test counter", "chain")
+	c.Inc("a")
+	c.Add(2.5, "a")
+	c.Inc("b")
+	assert.Equal(t, 3.5, c.Value("a"))
+	assert.Equal(t, float64(1), c.Value("b"))
+	assert.Panics(t, func() { c.Add(-1, "a") }, "Counters cannot be decreased")
+	assert.Panics(t, func() { c.Inc() }, "The number of label values must match the label names")
+}
+
+func TestGauge(t *testing.T) {
+	r := NewRegistry()
+	g := r.NewGauge("test_depth", "A test gauge")
+	g.Inc()
+	g.Inc()
+	g.Dec()
+	assert.Equal(t, float64(1), g.Value())
+	g.Set(42)
+	assert.Equal(t, float64(42), g.Value())
+}
+
+func TestHistogram(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogram("test_seconds", "A test histogram", []float64{1, 2}, "chain")
+	h.Observe(0.5, "a")
+	h.Observe(1, "a")
+	h.Observe(1.5, "a")
+	h.Observe(3, "a")
+	count, sum := h.Count("a")
+	assert.Equal(t, uint64(4), count)
+	assert.Equal(t, float64(6), sum)
+
+	assert.Panics(t, func() { r.NewHistogram("unordered", "", []float64{2, 1}) })
+}
+
+func TestDuplicateRegistration(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("test_total", "")
+	assert.Panics(t, func() { r.NewGauge("test_total", "") })
+}
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("test_total", "A test\ncounter", "chain", "reason")
+	c.Inc("b", "timeout")
+	c.Add(2, "a", "batch \"size\"")
+	g := r.NewGauge("test_depth", "A test gauge")
+	g.Set(3)
+	h := r.NewHistogram("test_seconds", "A test histogram", []float64{0.5, 1}, "chain")
+	h.Observe(0.25, "a")
+	h.Observe(0.75, "a")
+	h.Observe(2, "a")
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, r.WriteText(buf))
+	expected := `# HELP test_depth A test gauge
+# TYPE test_depth gauge
+test_depth 3
+# HELP test_seconds A test histogram
+# TYPE test_seconds histogram
+test_seconds_bucket{chain="a",le="0.5"} 1
+test_seconds_bucket{chain="a",le="1"} 2
+test_seconds_bucket{chain="a",le="+Inf"} 3
+test_seconds_sum{chain="a"} 3
+test_seconds_count{chain="a"} 3
+# HELP test_total A test\ncounter
+# TYPE test_total counter
+test_total{chain="a",reason="batch \"size\""} 2
+test_total{chain="b",reason="timeout"} 1
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("test_total", "", "chain")
+	h := r.NewHistogram("test_seconds", "", nil, "chain")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Inc("a")
+				h.Observe(0.01, "a")
+				r.WriteText(ioutil.Discard)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, float64(1000), c.Value("a"))
+	count, _ := h.Count("a")
+	assert.Equal(t, uint64(1000), count)
+}
+
+func TestHandler(t *testing.T) {
+	NewCounter("metrics_test_handler_total", "Served by the handler").Inc()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "metrics_test_handler_total 1\n")
+}
@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("metrics")
+
+// DefaultRegistry is the registry used by the package level constructors and served by `Handler`
+var DefaultRegistry = NewRegistry()
+
+// Registry holds a set of metrics, each identified by its name
+type Registry struct {
+	lock    sync.RWMutex
+	metrics map[string]*metric
+}
+
+// NewRegistry constructs an empty `Registry`
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]*metric)}
+}
+
+func (r *Registry) register(m *metric) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.metrics[m.name]; ok {
+		panic(fmt.Errorf("Metric %s registered twice", m.name))
+	}
+	r.metrics[m.name] = m
+}
+
+// NewCounter creates a counter with the given label names and registers it
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *Counter {
+	c := &Counter{newMetric(name, help, "counter", labelNames)}
+	r.register(c.metric)
+	return c
+}
+
+// NewGauge creates a gauge with the given label names and registers it
+func (r *Registry) NewGauge(name, help string, labelNames ...string) *Gauge {
+	g := &Gauge{newMetric(name, help, "gauge", labelNames)}
+	r.register(g.metric)
+	return g
+}
+
+// NewHistogram creates a histogram with the given bucket upper bounds (`DefaultBuckets` if nil)
+// and label names and registers it
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	h := &Histogram{newMetric(name, help, "histogram", labelNames)}
+	h.buckets = checkBuckets(buckets)
+	r.register(h.metric)
+	return h
+}
+
+// NewCounter creates a counter in the `DefaultRegistry`
+func NewCounter(name, help string, labelNames ...string) *Counter {
+	return DefaultRegistry.NewCounter(name, help, labelNames...)
+}
+
+// NewGauge creates a gauge in the `DefaultRegistry`
+func NewGauge(name, help string, labelNames ...string) *Gauge {
+	return DefaultRegistry.NewGauge(name, help, labelNames...)
+}
+
+// NewHistogram creates a histogram in the `DefaultRegistry`
+func NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	return DefaultRegistry.NewHistogram(name, help, buckets, labelNames...)
+}
+
+// WriteText writes all the metrics of the registry in the Prometheus text exposition format
+func (r *Registry) WriteText(w io.Writer) error {
+	r.lock.RLock()
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	r.lock.RUnlock()
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, name := range names {
+		r.lock.RLock()
+		m := r.metrics[name]
+		r.lock.RUnlock()
+
+		fmt.Fprintf(bw, "# HELP %s %s\n", m.name, escapeHelp(m.help))
+		fmt.Fprintf(bw, "# TYPE %s %s\n", m.name, m.metricType)
+		for _, s := range m.sortedSeries() {
+			if m.metricType != "histogram" {
+				fmt.Fprintf(bw, "%s%s %s\n", m.name, formatLabels(m.labelNames, s.labelValues, "", ""), formatValue(s.value))
+				continue
+			}
+			var cumulative uint64
+			for i, upperBound := range m.buckets {
+				if s.bucketCounts != nil {
+					cumulative += s.bucketCounts[i]
+				}
+				fmt.Fprintf(bw, "%s_bucket%s %d\n", m.name, formatLabels(m.labelNames, s.labelValues, "le", formatValue(upperBound)), cumulative)
+			}
+			fmt.Fprintf(bw, "%s_bucket%s %d\n", m.name, formatLabels(m.labelNames, s.labelValues, "le", "+Inf"), s.count)
+			fmt.Fprintf(bw, "%s_sum%s %s\n", m.name, formatLabels(m.labelNames, s.labelValues, "", ""), formatValue(s.value))
+			fmt.Fprintf(bw, "%s_count%s %d\n", m.name, formatLabels(m.labelNames, s.labelValues, "", ""), s.count)
+		}
+	}
+	return bw.Flush()
+}
+
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", name, escapeLabelValue(values[i])))
+	}
+	if extraName != "" {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extraName, extraValue))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
+
+// Handler returns an http.Handler that serves the metrics of the `DefaultRegistry`
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := DefaultRegistry.WriteText(w); err != nil {
+			logger.Warningf("Error writing metrics: %s", err)
+		}
+	})
+}
+
+// ListenAndServe serves the metrics on the `/metrics` path of the given address.
+// It does not return unless an error occurs
+func ListenAndServe(address string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	logger.Infof("Serving metrics on %s/metrics", address)
+	return http.ListenAndServe(address, mux)
+}
@@ -17,18 +17,23 @@ limitations under the License.
 package msp
 
 import (
+	"bytes"
 	"crypto/x509"
 	"fmt"
 	"time"
 
+	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 
 	"encoding/pem"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/crypto/bccsp"
 	"github.com/hyperledger/fabric/core/crypto/bccsp/factory"
 	"github.com/hyperledger/fabric/core/crypto/bccsp/signer"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
+	"github.com/hyperledger/fabric/protos/common"
 )
 
 // This is an instantiation of an MSP that
@@ -40,6 +45,9 @@ type bccspmsp struct {
 	// list of signing identities
 	signers map[string]SigningIdentity
 
+	// list of certs of the administrators of this MSP
+	admins []*x509.Certificate
+
 	// the crypto provider
 	bccsp bccsp.BCCSP
 
@@ -66,6 +74,55 @@ func newBccspMsp() (PeerMSP, error) {
 	return theMsp, nil
 }
 
+// NewMSPFromConfig returns the MSP of an organisation as defined in the configuration
+// of a chain: it validates identities against the root CA certificates of the
+// configuration and recognizes its admin certificates. It has no signing identity
+func NewMSPFromConfig(config *common.MSPConfig) (PeerMSP, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("The MSP configuration has no name")
+	}
+	if len(config.RootCerts) == 0 {
+		return nil, fmt.Errorf("The configuration of MSP %s has no root CA certificate", config.Name)
+	}
+
+	m, err := newBccspMsp()
+	if err != nil {
+		return nil, err
+	}
+	theMsp := m.(*bccspmsp)
+	theMsp.id.Value = config.Name
+
+	for i, pemRoot := range config.RootCerts {
+		block, _ := pem.Decode(pemRoot)
+		if block == nil {
+			return nil, fmt.Errorf("Failed to decode root CA cert %d of MSP %s", i, config.Name)
+		}
+		rootCert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse root CA cert %d of MSP %s, err %s", i, config.Name, err)
+		}
+		rootPub, err := theMsp.bccsp.KeyImport(rootCert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to import the public key of root CA cert %d of MSP %s, err %s", i, config.Name, err)
+		}
+		theMsp.trustedCerts[fmt.Sprintf("ROOT%d", i)] = newIdentity(&IdentityIdentifier{Mspid: theMsp.id, Value: "ROOTCA"}, rootCert, nil, rootPub, theMsp)
+	}
+
+	for i, pemAdmin := range config.AdminCerts {
+		block, _ := pem.Decode(pemAdmin)
+		if block == nil {
+			return nil, fmt.Errorf("Failed to decode admin cert %d of MSP %s", i, config.Name)
+		}
+		adminCert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse admin cert %d of MSP %s, err %s", i, config.Name, err)
+		}
+		theMsp.admins = append(theMsp.admins, adminCert)
+	}
+
+	return theMsp, nil
+}
+
 // FIXME: these structs are used for now to parse
 // the json config file - we need to consolidate
 // them with the COP team and put their definition
@@ -96,6 +153,18 @@ type Verifier struct {
 /******************END OF CODE TAKEN FROM THE COP TREE******************/
 /***********************************************************************/
 
+// mspAdmins lists the PEM certificates of the administrators of the MSP,
+// which may optionally be found alongside the identity in the config file
+type mspAdmins struct {
+	Admins [][]byte `json:"admins"`
+}
+
+// mspSigningKey gives the SKI of the signing key of the identity in the KeyStore
+// of the BCCSP, where it is kept encrypted, in place of the key in the config file
+type mspSigningKey struct {
+	SKI string `json:"signingKeySKI"`
+}
+
 func (msp *bccspmsp) Setup(configFile string) error {
 	mspLogger.Infof("Setting up MSP instance from file %s", configFile)
 
@@ -117,13 +186,32 @@ func (msp *bccspmsp) Setup(configFile string) error {
 		return fmt.Errorf("Unmarshalling error: %s", err)
 	}
 
-	// Extract the certificate of the identity
-	var cert *x509.Certificate
-	pemCert, _ := pem.Decode(id.PublicSigner.Cert)
-	cert, err = x509.ParseCertificate(pemCert.Bytes)
+	// Parse the certificates of the administrators, if any
+	var admins mspAdmins
+	err = json.Unmarshal(file, &admins)
+	if err != nil {
+		return fmt.Errorf("Unmarshalling error: %s", err)
+	}
+	msp.admins = nil
+	for _, pemAdmin := range admins.Admins {
+		block, _ := pem.Decode(pemAdmin)
+		if block == nil {
+			return fmt.Errorf("Failed to decode admin cert")
+		}
+		adminCert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("Failed to parse admin x509 cert, err %s", err)
+		}
+		msp.admins = append(msp.admins, adminCert)
+	}
+
+	// Extract the certificate of the identity, which may be
+	// followed by the intermediate CAs that issued it
+	chain, err := primitives.PEMtoCertificateChain(id.PublicSigner.Cert)
 	if err != nil {
 		return fmt.Errorf("Failed to parse x509 cert, err %s", err)
 	}
+	cert := chain[0]
 
 	// Get public key
 	pub, err := msp.bccsp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
@@ -132,10 +220,21 @@ func (msp *bccspmsp) Setup(configFile string) error {
 	}
 
 	// Get secret key
-	pemKey, _ := pem.Decode(id.PublicSigner.Key)
-	key, err := msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+	var signingKey mspSigningKey
+	err = json.Unmarshal(file, &signingKey)
+	if err != nil {
+		return fmt.Errorf("Unmarshalling error: %s", err)
+	}
+	key, err := msp.getSigningKey(configFile, id.PublicSigner.Key, signingKey.SKI)
+	if err != nil {
+		return err
+	}
+	keyPub, err := key.PublicKey()
 	if err != nil {
-		return fmt.Errorf("Failed to import EC private key, err %s", err)
+		return fmt.Errorf("Failed getting the public key of the signing key, err %s", err)
+	}
+	if !bytes.Equal(keyPub.SKI(), pub.SKI()) {
+		return fmt.Errorf("The signing key does not match the certificate of the identity")
 	}
 
 	// get the peer signer
@@ -165,16 +264,52 @@ func (msp *bccspmsp) Setup(configFile string) error {
 	}
 
 	// Set the trusted identity related to the ROOT CA
-	rootCaIdentity := newIdentity(&IdentityIdentifier{Mspid: MSPID, Value: "ROOTCA"}, CACert, CAPub)
+	rootCaIdentity := newIdentity(&IdentityIdentifier{Mspid: MSPID, Value: "ROOTCA"}, CACert, nil, CAPub, msp)
 	msp.trustedCerts["ROOT"] = rootCaIdentity
 
-	// Set the signing identity related to the peer
-	peerSigningIdentity := newSigningIdentity(&IdentityIdentifier{Mspid: MSPID, Value: id.Name}, cert, pub, peerSigner)
-	msp.signers["PEER"] = peerSigningIdentity
+	// Set the signing identity related to the peer, under the name given by the config
+	peerSigningIdentity := newSigningIdentity(&IdentityIdentifier{Mspid: MSPID, Value: id.Name}, cert, chain[1:], pub, peerSigner, msp)
+	msp.signers[id.Name] = peerSigningIdentity
 
 	return nil
 }
 
+// getSigningKey returns the signing key of the identity from the KeyStore of the BCCSP, where it
+// is encrypted with the passphrase of the KeyStore. A key still given in PEM in the config file is
+// imported into the KeyStore if the KeyStore is in a configured folder and encrypted, so that the
+// file may refer to it by its SKI instead, else it is only kept in memory
+func (msp *bccspmsp) getSigningKey(configFile string, pemKey []byte, hexSKI string) (bccsp.Key, error) {
+	if hexSKI != "" {
+		ski, err := hex.DecodeString(hexSKI)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid SKI of the signing key, err %s", err)
+		}
+		key, err := msp.bccsp.GetKey(ski)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load the signing key from the KeyStore, err %s", err)
+		}
+		if !key.Private() {
+			return nil, fmt.Errorf("The key %s of the KeyStore is not a private key", hexSKI)
+		}
+		return key, nil
+	}
+
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, fmt.Errorf("Failed to decode the signing key")
+	}
+	protected := factory.IsDefaultKeyStoreProtected()
+	key, err := msp.bccsp.KeyImport(block.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: !protected})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to import EC private key, err %s", err)
+	}
+	if protected {
+		mspLogger.Warningf("The signing key is stored unencrypted in %s, it has been imported into the KeyStore: "+
+			"replace it in the file with \"signingKeySKI\":\"%x\"", configFile, key.SKI())
+	}
+	return key, nil
+}
+
 func (msp *bccspmsp) Reconfig(reconfigMessage string) error {
 	// TODO
 	return nil
@@ -225,17 +360,18 @@ func (msp *bccspmsp) IsValid(id Identity) (bool, error) {
 	// this is how I can validate it given the
 	// root of trust this MSP has
 	case *identity:
-		opts := x509.VerifyOptions{
-			Roots:       x509.NewCertPool(),
-			CurrentTime: time.Now(),
-		}
-
+		roots := x509.NewCertPool()
 		for _, v := range msp.trustedCerts {
-			opts.Roots.AddCert(v.(*identity).cert)
+			roots.AddCert(v.(*identity).cert)
 		}
 
-		_, err := id.(*identity).cert.Verify(opts)
+		// The path to the roots is built through the intermediate CAs carried by the identity
+		paths, err := primitives.CheckCertificateChain(id.(*identity).certificateChain(), roots, nil, time.Now())
 		mspLogger.Infof("Verify returned %s", err)
+		if err == nil && !carriesPath(id.(*identity).chain, paths) {
+			// Any other certificate would give the identity another serialization
+			err = fmt.Errorf("The identity carries certificates which are not the intermediate CAs of its path to a root CA")
+		}
 		if err == nil {
 			mspLogger.Infof("Identity is valid")
 			return true, nil
@@ -248,14 +384,135 @@ func (msp *bccspmsp) IsValid(id Identity) (bool, error) {
 	}
 }
 
+// carriesPath returns whether the intermediate CAs are exactly, in order, those between the leaf
+// and the root CA of one of the verified paths
+func carriesPath(intermediates []*x509.Certificate, paths [][]*x509.Certificate) bool {
+	for _, path := range paths {
+		if len(path) != len(intermediates)+2 {
+			continue
+		}
+		matches := true
+		for i, ca := range intermediates {
+			if !ca.Equal(path[i+1]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// SatisfiesPrincipal checks whether the identity is the identity, a member, an
+// admin or a member of an organizational unit of this MSP, as the principal requires
+func (msp *bccspmsp) SatisfiesPrincipal(id Identity, principal *common.MSPPrincipal) error {
+	switch principal.PrincipalClassification {
+	case common.MSPPrincipal_ByIdentity:
+		// The identities are compared by their certificate, the intermediate CAs serialized
+		// with them may differ
+		x509id, ok := id.(*identity)
+		if !ok {
+			return fmt.Errorf("Identity type not recognized")
+		}
+		principalCert, err := SignerCertificate(principal.Principal)
+		if err != nil {
+			return fmt.Errorf("Could not parse the principal identity, err %s", err)
+		}
+		if !bytes.Equal(x509id.cert.Raw, principalCert) {
+			return fmt.Errorf("The identity is not the principal identity")
+		}
+		return nil
+	case common.MSPPrincipal_ByMSPRole:
+		role := &common.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return fmt.Errorf("Could not unmarshal MSPRole from principal, err %s", err)
+		}
+		if role.MSPIdentifier != msp.id.Value {
+			return fmt.Errorf("The identity is a member of a different MSP (expected %s, got %s)", role.MSPIdentifier, msp.id.Value)
+		}
+		if err := msp.validate(id); err != nil {
+			return err
+		}
+		switch role.Role {
+		case common.MSPRole_Member:
+			return nil
+		case common.MSPRole_Admin:
+			if !msp.isAdmin(id) {
+				return fmt.Errorf("The identity is not an admin of MSP %s", msp.id.Value)
+			}
+			return nil
+		default:
+			return fmt.Errorf("Invalid MSP role type %d", role.Role)
+		}
+	case common.MSPPrincipal_ByOrganizationUnit:
+		ou := &common.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return fmt.Errorf("Could not unmarshal OrganizationUnit from principal, err %s", err)
+		}
+		if ou.MSPIdentifier != msp.id.Value {
+			return fmt.Errorf("The identity is a member of a different MSP (expected %s, got %s)", ou.MSPIdentifier, msp.id.Value)
+		}
+		if err := msp.validate(id); err != nil {
+			return err
+		}
+		for _, unit := range id.GetOrganizationalUnits() {
+			if unit == ou.OrganizationalUnitIdentifier {
+				return nil
+			}
+		}
+		return fmt.Errorf("The identity is not part of the organizational unit %s", ou.OrganizationalUnitIdentifier)
+	default:
+		return fmt.Errorf("Invalid principal classification %d", principal.PrincipalClassification)
+	}
+}
+
+func (msp *bccspmsp) GetRootCerts() []Identity {
+	roots := make([]Identity, 0, len(msp.trustedCerts))
+	for _, root := range msp.trustedCerts {
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+func (msp *bccspmsp) validate(id Identity) error {
+	valid, err := msp.IsValid(id)
+	if err != nil {
+		return fmt.Errorf("The identity is not valid under MSP %s, err %s", msp.id.Value, err)
+	}
+	if !valid {
+		return fmt.Errorf("The identity is not valid under MSP %s", msp.id.Value)
+	}
+	return nil
+}
+
+func (msp *bccspmsp) isAdmin(id Identity) bool {
+	x509id, ok := id.(*identity)
+	if !ok {
+		return false
+	}
+	for _, admin := range msp.admins {
+		if x509id.cert.Equal(admin) {
+			return true
+		}
+	}
+	return false
+}
+
 func (msp *bccspmsp) DeserializeIdentity(serializedID []byte) (Identity, error) {
 	mspLogger.Infof("Obtaining identity")
 
-	// This MSP will always deserialize certs this way
-	cert, err := x509.ParseCertificate(serializedID)
+	// This MSP will always deserialize certs this way: the
+	// certificate of the identity followed by its intermediate CAs
+	certs, err := x509.ParseCertificates(serializedID)
 	if err != nil {
 		return nil, fmt.Errorf("ParseCertificate failed %s", err)
 	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("ParseCertificate failed, no certificate found")
+	}
+	cert := certs[0]
 
 	id := &IdentityIdentifier{Mspid: ProviderIdentifier{Value: msp.id.Value},
 		Value: "PEER"} // TODO: where should this identifier be obtained from?
@@ -265,7 +522,21 @@ func (msp *bccspmsp) DeserializeIdentity(serializedID []byte) (Identity, error)
 		return nil, fmt.Errorf("Failed to import certitifacateś public key [%s]", err)
 	}
 
-	return newIdentity(id, cert, pub), nil
+	// the identity is validated by this MSP, which may not be one of the local manager
+	return newIdentity(id, cert, certs[1:], pub, msp), nil
+}
+
+// SignerCertificate returns the certificate of a serialized identity, without the intermediate CAs
+// following it, which is the same for all the serializations of the identity
+func SignerCertificate(serializedID []byte) ([]byte, error) {
+	certs, err := x509.ParseCertificates(serializedID)
+	if err != nil {
+		return nil, fmt.Errorf("ParseCertificate failed %s", err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("ParseCertificate failed, no certificate found")
+	}
+	return certs[0].Raw, nil
 }
 
 func (msp *bccspmsp) DeleteSigningIdentity(identifier string) (bool, error) {
@@ -30,12 +30,18 @@ import (
 type identity struct {
 	id   *IdentityIdentifier
 	cert *x509.Certificate
-	pk   bccsp.Key
+	// the intermediate CAs certifying cert, if any, from the
+	// issuer of cert up to the last one before a root CA
+	chain []*x509.Certificate
+	pk    bccsp.Key
+	// the MSP which validates the identity, the MSP of the
+	// local manager with the identifier of the identity if nil
+	msp PeerMSP
 }
 
-func newIdentity(id *IdentityIdentifier, cert *x509.Certificate, pk bccsp.Key) Identity {
+func newIdentity(id *IdentityIdentifier, cert *x509.Certificate, chain []*x509.Certificate, pk bccsp.Key, msp PeerMSP) Identity {
 	mspLogger.Infof("Creating identity instance for ID %s", id)
-	return &identity{id: id, cert: cert, pk: pk}
+	return &identity{id: id, cert: cert, chain: chain, pk: pk, msp: msp}
 }
 
 func (id *identity) Identifier() *IdentityIdentifier {
@@ -47,6 +53,9 @@ func (id *identity) GetMSPIdentifier() string {
 }
 
 func (id *identity) Validate() (bool, error) {
+	if id.msp != nil {
+		return id.msp.IsValid(id)
+	}
 	return GetManager().IsValid(id, &id.id.Mspid)
 }
 
@@ -55,6 +64,10 @@ func (id *identity) ParticipantID() string {
 	return "dunno"
 }
 
+func (id *identity) GetOrganizationalUnits() []string {
+	return id.cert.Subject.OrganizationalUnit
+}
+
 func (id *identity) Verify(msg []byte, sig []byte) (bool, error) {
 	mspLogger.Infof("Verifying signature")
 	bccsp, err := factory.GetDefault()
@@ -90,7 +103,18 @@ func (id *identity) Serialize() ([]byte, error) {
 
 	return idBytes, nil
 	*/
-	return id.cert.Raw, nil
+	// The certificate is followed by its intermediate CAs, if any, so that
+	// verifiers can build the path to a root CA without knowing them beforehand
+	idBytes := append([]byte{}, id.cert.Raw...)
+	for _, ca := range id.chain {
+		idBytes = append(idBytes, ca.Raw...)
+	}
+	return idBytes, nil
+}
+
+// certificateChain returns the certificate of the identity followed by its intermediate CAs
+func (id *identity) certificateChain() []*x509.Certificate {
+	return append([]*x509.Certificate{id.cert}, id.chain...)
 }
 
 type signingidentity struct {
@@ -98,9 +122,9 @@ type signingidentity struct {
 	signer *signer.CryptoSigner
 }
 
-func newSigningIdentity(id *IdentityIdentifier, cert *x509.Certificate, pk bccsp.Key, signer *signer.CryptoSigner) SigningIdentity {
+func newSigningIdentity(id *IdentityIdentifier, cert *x509.Certificate, chain []*x509.Certificate, pk bccsp.Key, signer *signer.CryptoSigner, msp PeerMSP) SigningIdentity {
 	mspLogger.Infof("Creating signing identity instance for ID %s", id)
-	return &signingidentity{identity{id: id, cert: cert, pk: pk}, signer}
+	return &signingidentity{identity{id: id, cert: cert, chain: chain, pk: pk, msp: msp}, signer}
 }
 
 func (id *signingidentity) Identity() {
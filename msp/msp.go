@@ -71,7 +71,9 @@ type Identity interface {
 	// TODO: check if we need a dedicated type for participantID properly namespaced by the associated provider identifier.
 	ParticipantID() string
 
-	// TODO: Discuss GetOU() further.
+	// GetOrganizationalUnits returns the organizational units this identity
+	// is related to, as certified by its MSP
+	GetOrganizationalUnits() []string
 
 	// Verify a signature over some message using this identity as reference
 	Verify(msg []byte, sig []byte) (bool, error)
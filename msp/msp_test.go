@@ -1,11 +1,22 @@
 package msp
 
 import (
+	"bytes"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
 	"os"
 	"reflect"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/crypto/bccsp"
+	"github.com/hyperledger/fabric/core/crypto/bccsp/factory"
 	"github.com/hyperledger/fabric/core/crypto/primitives"
+	"github.com/hyperledger/fabric/core/crypto/primitives/testutil"
+	"github.com/hyperledger/fabric/protos/common"
 )
 
 var mgr PeerMSPManager
@@ -26,6 +37,60 @@ func TestMSPSetupGood(t *testing.T) {
 	}
 }
 
+func TestMSPSetupSigningKeyFromKeyStore(t *testing.T) {
+	file, err := ioutil.ReadFile("peer-config.json")
+	if err != nil {
+		t.Fatalf("Could not read the config file, err %s", err)
+	}
+	var config map[string]interface{}
+	var id Identity1
+	if err := json.Unmarshal(file, &config); err != nil || json.Unmarshal(file, &id) != nil {
+		t.Fatalf("Could not parse the config file")
+	}
+
+	csp, err := factory.GetDefault()
+	if err != nil {
+		t.Fatalf("Could not get the default BCCSP, err %s", err)
+	}
+	block, _ := pem.Decode(id.PublicSigner.Key)
+	key, err := csp.KeyImport(block.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: false})
+	if err != nil {
+		t.Fatalf("Could not store the signing key in the KeyStore, err %s", err)
+	}
+	otherKey, err := csp.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: false})
+	if err != nil {
+		t.Fatalf("Could not generate a key in the KeyStore, err %s", err)
+	}
+
+	// The config file refers to the signing key in the KeyStore instead of holding it
+	publicSigner := config["publicSigner"].(map[string]interface{})
+	delete(publicSigner, "key")
+	setup := func(ski string) error {
+		config["signingKeySKI"] = ski
+		raw, _ := json.Marshal(config)
+		configFile, err := ioutil.TempFile("", "msp-config")
+		if err != nil {
+			t.Fatalf("Could not create the config file, err %s", err)
+		}
+		defer os.Remove(configFile.Name())
+		configFile.Write(raw)
+		configFile.Close()
+
+		theMsp, _ := newBccspMsp()
+		return theMsp.Setup(configFile.Name())
+	}
+
+	if err := setup(hex.EncodeToString(key.SKI())); err != nil {
+		t.Fatalf("Setup should have succeeded with the signing key in the KeyStore, got err %s", err)
+	}
+	if err := setup(hex.EncodeToString([]byte("barf"))); err == nil {
+		t.Fatalf("Setup should have failed with a signing key missing from the KeyStore")
+	}
+	if err := setup(hex.EncodeToString(otherKey.SKI())); err == nil {
+		t.Fatalf("Setup should have failed with a signing key which does not match the certificate")
+	}
+}
+
 func TestGetBadIdentities(t *testing.T) {
 	idBad := IdentityIdentifier{Mspid: ProviderIdentifier{Value: "BARF"}, Value: "PEER"}
 	id, err := mgr.GetSigningIdentity(&idBad)
@@ -114,6 +179,194 @@ func TestSignAndVerify(t *testing.T) {
 	}
 }
 
+func TestSatisfiesPrincipal(t *testing.T) {
+	idId := IdentityIdentifier{Mspid: ProviderIdentifier{Value: "DEFAULT"}, Value: "PEER"}
+	id, err := mgr.GetSigningIdentity(&idId)
+	if err != nil {
+		t.Fatalf("GetSigningIdentity should have succeeded")
+		return
+	}
+
+	serializedID, err := id.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize should have succeeded")
+		return
+	}
+
+	idBack, err := mgr.DeserializeIdentity(serializedID)
+	if err != nil {
+		t.Fatalf("DeserializeIdentity should have succeeded")
+		return
+	}
+
+	principal := func(classification common.MSPPrincipal_Classification, msg proto.Message) *common.MSPPrincipal {
+		bytes, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatalf("Marshal should have succeeded")
+		}
+		return &common.MSPPrincipal{PrincipalClassification: classification, Principal: bytes}
+	}
+
+	satisfied := []*common.MSPPrincipal{
+		{PrincipalClassification: common.MSPPrincipal_ByIdentity, Principal: serializedID},
+		principal(common.MSPPrincipal_ByMSPRole, &common.MSPRole{MSPIdentifier: "DEFAULT", Role: common.MSPRole_Member}),
+		principal(common.MSPPrincipal_ByOrganizationUnit, &common.OrganizationUnit{MSPIdentifier: "DEFAULT", OrganizationalUnitIdentifier: "COP"}),
+	}
+	for _, p := range satisfied {
+		if err := mgr.SatisfiesPrincipal(idBack, p); err != nil {
+			t.Fatalf("The identity should satisfy principal %v, got err %s instead", p, err)
+		}
+	}
+
+	unsatisfied := []*common.MSPPrincipal{
+		{PrincipalClassification: common.MSPPrincipal_ByIdentity, Principal: []byte("other")},
+		principal(common.MSPPrincipal_ByMSPRole, &common.MSPRole{MSPIdentifier: "DEFAULT", Role: common.MSPRole_Admin}),
+		principal(common.MSPPrincipal_ByMSPRole, &common.MSPRole{MSPIdentifier: "BARF", Role: common.MSPRole_Member}),
+		principal(common.MSPPrincipal_ByOrganizationUnit, &common.OrganizationUnit{MSPIdentifier: "DEFAULT", OrganizationalUnitIdentifier: "BARF"}),
+	}
+	for _, p := range unsatisfied {
+		if err := mgr.SatisfiesPrincipal(idBack, p); err == nil {
+			t.Fatalf("The identity should not satisfy principal %v", p)
+		}
+	}
+}
+
+func TestIntermediateCAs(t *testing.T) {
+	root, rootKey := testutil.NewCert(t, testutil.CATemplate("root"), nil, nil)
+	ica, icaKey := testutil.NewCert(t, testutil.CATemplate("intermediate"), root, rootKey)
+	leaf, _ := testutil.NewCert(t, testutil.Template("leaf"), ica, icaKey)
+	otherCA, _ := testutil.NewCert(t, testutil.CATemplate("other"), nil, nil)
+
+	peerMSP, err := newBccspMsp()
+	if err != nil {
+		t.Fatalf("Failed creating MSP, err %s", err)
+	}
+	theMsp := peerMSP.(*bccspmsp)
+	theMsp.trustedCerts["ROOT"] = newIdentity(&IdentityIdentifier{Mspid: theMsp.id, Value: "ROOTCA"}, root, nil, nil, theMsp)
+
+	// The serialized identity carries the intermediate CA
+	id := newIdentity(&IdentityIdentifier{Mspid: theMsp.id, Value: "PEER"}, leaf, []*x509.Certificate{ica}, nil, theMsp)
+	serializedID, err := id.Serialize()
+	if err != nil {
+		t.Fatalf("Serialize should have succeeded, got err %s", err)
+	}
+	if !bytes.Equal(serializedID, append(append([]byte{}, leaf.Raw...), ica.Raw...)) {
+		t.Fatalf("The serialized identity should be the certificate followed by its intermediate CA")
+	}
+
+	idBack, err := theMsp.DeserializeIdentity(serializedID)
+	if err != nil {
+		t.Fatalf("DeserializeIdentity should have succeeded, got err %s", err)
+	}
+	if reserialized, _ := idBack.Serialize(); !bytes.Equal(reserialized, serializedID) {
+		t.Fatalf("The deserialized identity should serialize back to the same bytes")
+	}
+	if valid, err := theMsp.IsValid(idBack); !valid || err != nil {
+		t.Fatalf("The identity should be valid, got err %s", err)
+	}
+
+	// The identity is its certificate, whichever intermediate CAs it is serialized with
+	principal := &common.MSPPrincipal{PrincipalClassification: common.MSPPrincipal_ByIdentity, Principal: leaf.Raw}
+	if err := theMsp.SatisfiesPrincipal(idBack, principal); err != nil {
+		t.Fatalf("The identity should be the principal identity, got err %s", err)
+	}
+	principal.Principal = ica.Raw
+	if err := theMsp.SatisfiesPrincipal(idBack, principal); err == nil {
+		t.Fatalf("The identity should not be its intermediate CA")
+	}
+
+	// Without the intermediate CA the path to the root cannot be built
+	idBack, err = theMsp.DeserializeIdentity(leaf.Raw)
+	if err != nil {
+		t.Fatalf("DeserializeIdentity should have succeeded, got err %s", err)
+	}
+	if valid, _ := theMsp.IsValid(idBack); valid {
+		t.Fatalf("The identity should not be valid without its intermediate CA")
+	}
+
+	// Nor is it valid with certificates besides the intermediate CAs of its path, which would give
+	// it other serializations
+	for name, extra := range map[string][]*x509.Certificate{
+		"the root CA":            {ica, root},
+		"another CA":             {ica, otherCA},
+		"its intermediate twice": {ica, ica},
+		"reordered CAs":          {otherCA, ica},
+	} {
+		var serialized []byte
+		for _, cert := range append([]*x509.Certificate{leaf}, extra...) {
+			serialized = append(serialized, cert.Raw...)
+		}
+		idBack, err = theMsp.DeserializeIdentity(serialized)
+		if err != nil {
+			t.Fatalf("DeserializeIdentity should have succeeded, got err %s", err)
+		}
+		if valid, _ := theMsp.IsValid(idBack); valid {
+			t.Fatalf("The identity should not be valid when serialized with %s", name)
+		}
+	}
+}
+
+func TestMSPFromConfig(t *testing.T) {
+	root, rootKey := testutil.NewCert(t, testutil.CATemplate("root"), nil, nil)
+	admin, _ := testutil.NewCert(t, testutil.Template("admin"), root, rootKey)
+	member, _ := testutil.NewCert(t, testutil.Template("member"), root, rootKey)
+	otherRoot, otherRootKey := testutil.NewCert(t, testutil.CATemplate("other root"), nil, nil)
+	other, _ := testutil.NewCert(t, testutil.Template("other"), otherRoot, otherRootKey)
+	toPEM := func(cert *x509.Certificate) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+
+	orgMSP, err := NewMSPFromConfig(&common.MSPConfig{Name: "Org1MSP", RootCerts: [][]byte{toPEM(root)}, AdminCerts: [][]byte{toPEM(admin)}})
+	if err != nil {
+		t.Fatalf("NewMSPFromConfig should have succeeded, got err %s", err)
+	}
+
+	memberID, err := orgMSP.DeserializeIdentity(member.Raw)
+	if err != nil {
+		t.Fatalf("DeserializeIdentity should have succeeded, got err %s", err)
+	}
+	if memberID.GetMSPIdentifier() != "Org1MSP" {
+		t.Fatalf("The identity should be of MSP Org1MSP, got %s", memberID.GetMSPIdentifier())
+	}
+	// The identity is validated by the MSP of the configuration, not by the local manager
+	if valid, err := memberID.Validate(); !valid || err != nil {
+		t.Fatalf("The identity should be valid, got err %s", err)
+	}
+	adminID, _ := orgMSP.DeserializeIdentity(admin.Raw)
+
+	memberRole, _ := proto.Marshal(&common.MSPRole{MSPIdentifier: "Org1MSP", Role: common.MSPRole_Member})
+	adminRole, _ := proto.Marshal(&common.MSPRole{MSPIdentifier: "Org1MSP", Role: common.MSPRole_Admin})
+	if err := orgMSP.SatisfiesPrincipal(memberID, &common.MSPPrincipal{PrincipalClassification: common.MSPPrincipal_ByMSPRole, Principal: memberRole}); err != nil {
+		t.Fatalf("The identity should be a member of Org1MSP, got err %s", err)
+	}
+	if err := orgMSP.SatisfiesPrincipal(memberID, &common.MSPPrincipal{PrincipalClassification: common.MSPPrincipal_ByMSPRole, Principal: adminRole}); err == nil {
+		t.Fatalf("The identity should not be an admin of Org1MSP")
+	}
+	if err := orgMSP.SatisfiesPrincipal(adminID, &common.MSPPrincipal{PrincipalClassification: common.MSPPrincipal_ByMSPRole, Principal: adminRole}); err != nil {
+		t.Fatalf("The admin certificate should be an admin of Org1MSP, got err %s", err)
+	}
+
+	otherID, err := orgMSP.DeserializeIdentity(other.Raw)
+	if err != nil {
+		t.Fatalf("DeserializeIdentity should have succeeded, got err %s", err)
+	}
+	if valid, _ := otherID.Validate(); valid {
+		t.Fatalf("An identity issued by another root CA should not be valid")
+	}
+
+	for name, config := range map[string]*common.MSPConfig{
+		"no name":         {RootCerts: [][]byte{toPEM(root)}},
+		"no root cert":    {Name: "Org1MSP"},
+		"invalid root":    {Name: "Org1MSP", RootCerts: [][]byte{[]byte("barf")}},
+		"invalid admin":   {Name: "Org1MSP", RootCerts: [][]byte{toPEM(root)}, AdminCerts: [][]byte{[]byte("barf")}},
+		"root not a cert": {Name: "Org1MSP", RootCerts: [][]byte{pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("barf")})}},
+	} {
+		if _, err := NewMSPFromConfig(config); err == nil {
+			t.Fatalf("NewMSPFromConfig should have failed with %s", name)
+		}
+	}
+}
+
 func TestMain(m *testing.M) {
 	primitives.SetSecurityLevel("SHA2", 256)
 	mgr = GetManager()
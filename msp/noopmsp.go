@@ -16,6 +16,8 @@ limitations under the License.
 
 package msp
 
+import "github.com/hyperledger/fabric/protos/common"
+
 type noopmsp struct {
 }
 
@@ -68,6 +70,14 @@ func (msp *noopmsp) IsValid(id Identity) (bool, error) {
 	return true, nil
 }
 
+func (msp *noopmsp) SatisfiesPrincipal(id Identity, principal *common.MSPPrincipal) error {
+	return nil
+}
+
+func (msp *noopmsp) GetRootCerts() []Identity {
+	return nil
+}
+
 type noopidentity struct {
 }
 
@@ -93,6 +103,10 @@ func (id *noopidentity) ParticipantID() string {
 	return "dunno"
 }
 
+func (id *noopidentity) GetOrganizationalUnits() []string {
+	return nil
+}
+
 func (id *noopidentity) Verify(msg []byte, sig []byte) (bool, error) {
 	mspLogger.Infof("Signature is valid")
 	return true, nil
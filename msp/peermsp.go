@@ -16,6 +16,8 @@ limitations under the License.
 
 package msp
 
+import "github.com/hyperledger/fabric/protos/common"
+
 // Membership service provider APIs for Hyperledger Fabric:
 //
 // By "membership service provider" we refer to an abstract component of the
@@ -90,6 +92,10 @@ type PeerMSPManager interface {
 
 	// isValid checks whether the supplied identity is valid
 	IsValid(Identity, *ProviderIdentifier) (bool, error)
+
+	// SatisfiesPrincipal checks whether the supplied identity matches the principal,
+	// routing the check to the MSP of the identity
+	SatisfiesPrincipal(id Identity, principal *common.MSPPrincipal) error
 }
 
 // PeerMSP is the minimal Membership Service Provider Interface to be implemented
@@ -129,4 +135,10 @@ type PeerMSP interface {
 
 	// isValid checks whether the supplied identity is valid
 	IsValid(Identity) (bool, error)
+
+	// SatisfiesPrincipal checks whether the supplied identity matches the principal,
+	// returning nil if it does or an error explaining why it does not
+	SatisfiesPrincipal(id Identity, principal *common.MSPPrincipal) error
+	// GetRootCerts returns the root certificates of the identities this MSP validates
+	GetRootCerts() []Identity
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/op/go-logging"
 )
 
@@ -172,3 +173,14 @@ func (mgr *peerMspManagerImpl) IsValid(id Identity, idId *ProviderIdentifier) (b
 
 	return msp.IsValid(id)
 }
+
+// SatisfiesPrincipal checks whether the supplied identity matches the principal
+func (mgr *peerMspManagerImpl) SatisfiesPrincipal(id Identity, principal *common.MSPPrincipal) error {
+	mspLogger.Infof("Looking up MSP with ID %s", id.GetMSPIdentifier())
+	msp := mgr.mspsMap[id.GetMSPIdentifier()]
+	if msp == nil {
+		return fmt.Errorf("No MSP registered for MSP ID %s", id.GetMSPIdentifier())
+	}
+
+	return msp.SatisfiesPrincipal(id, principal)
+}
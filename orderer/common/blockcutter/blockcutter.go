@@ -17,8 +17,10 @@ limitations under the License.
 package blockcutter
 
 import (
+	"github.com/hyperledger/fabric/metrics"
 	"github.com/hyperledger/fabric/orderer/common/broadcastfilter"
 	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
 
 	"github.com/golang/protobuf/proto"
@@ -27,6 +29,18 @@ import (
 
 var logger = logging.MustGetLogger("orderer/common/blockcutter")
 
+// Reasons for which a batch is cut, used as the `reason` label of `BatchesCut`
+const (
+	CutReasonBatchSize     = "batch_size"
+	CutReasonConfiguration = "configuration"
+	CutReasonTimeout       = "timeout"
+)
+
+// BatchesCut counts the times the pending batch is cut, by chain and by reason. Consenters which cut batches
+// on a timer are expected to count them with the CutReasonTimeout reason
+var BatchesCut = metrics.NewCounter("orderer_batch_cuts_total",
+	"Number of times the pending batch was cut, by reason", "chain", "reason")
+
 func init() {
 	logging.SetLevel(logging.DEBUG, "")
 }
@@ -46,25 +60,40 @@ type Receiver interface {
 }
 
 type receiver struct {
-	batchSize     int
-	filters       *broadcastfilter.RuleSet
-	configManager configtx.Manager
-	curBatch      []*cb.Envelope
+	batchSize           int
+	chainID             string
+	filters             *broadcastfilter.RuleSet
+	configManager       configtx.Manager
+	sharedConfigManager sharedconfig.Manager
+	curBatch            []*cb.Envelope
 }
 
-func NewReceiverImpl(batchSize int, filters *broadcastfilter.RuleSet, configManager configtx.Manager) Receiver {
+// NewReceiverImpl creates a Receiver cutting batches of the size configured for the chain in
+// sharedConfigManager, or of batchSize messages if the chain does not configure it
+func NewReceiverImpl(batchSize int, filters *broadcastfilter.RuleSet, configManager configtx.Manager, sharedConfigManager sharedconfig.Manager) Receiver {
 	return &receiver{
-		batchSize:     batchSize,
-		filters:       filters,
-		configManager: configManager,
+		batchSize:           batchSize,
+		chainID:             string(configManager.ChainID()),
+		filters:             filters,
+		configManager:       configManager,
+		sharedConfigManager: sharedConfigManager,
+	}
+}
+
+// currentBatchSize returns the batch size of the current configuration of the chain
+func (r *receiver) currentBatchSize() int {
+	if batchSize := r.sharedConfigManager.BatchSize(); batchSize > 0 {
+		return batchSize
 	}
+	return r.batchSize
 }
 
 // Ordered should be invoked sequentially as messages are ordered
 // If the message is a valid normal message and does not fill the batch, nil, true is returned
 // If the message is a valid normal message and fills a batch, the batch, true is returned
 // If the message is a valid special message (like a config message) it terminates the current batch
-// and returns the current batch (if it is not empty), plus a second batch containing the special transaction and true
+// and returns the current batch (if it is not empty), plus a second batch containing the special transaction and true,
+// a config message is applied to the chain
 // If the ordered message is determined to be invalid, then nil, false is returned
 func (r *receiver) Ordered(msg *cb.Envelope) ([][]*cb.Envelope, bool) {
 	// The messages must be filtered a second time in case configuration has changed since the message was received
@@ -74,11 +103,12 @@ func (r *receiver) Ordered(msg *cb.Envelope) ([][]*cb.Envelope, bool) {
 		logger.Debugf("Enqueuing message into batch")
 		r.curBatch = append(r.curBatch, msg)
 
-		if len(r.curBatch) < r.batchSize {
+		if len(r.curBatch) < r.currentBatchSize() {
 			return nil, true
 		}
 
 		logger.Debugf("Batch size met, creating block")
+		BatchesCut.Inc(r.chainID, CutReasonBatchSize)
 		newBatch := r.curBatch
 		r.curBatch = nil
 		return [][]*cb.Envelope{newBatch}, true
@@ -94,13 +124,15 @@ func (r *receiver) Ordered(msg *cb.Envelope) ([][]*cb.Envelope, bool) {
 			logger.Errorf("A change was flagged as configuration, but could not be unmarshaled: %v", err)
 			return nil, false
 		}
-		err := r.configManager.Validate(newConfig)
+		// The configuration is applied at the block boundary, the following messages are batched with its values
+		err := r.configManager.Apply(newConfig)
 		if err != nil {
 			logger.Warningf("A configuration change made it through the ingress filter but could not be included in a batch: %v", err)
 			return nil, false
 		}
 
 		logger.Debugf("Configuration change applied successfully, committing previous block and configuration block")
+		BatchesCut.Inc(r.chainID, CutReasonConfiguration)
 		firstBatch := r.curBatch
 		r.curBatch = nil
 		secondBatch := []*cb.Envelope{msg}
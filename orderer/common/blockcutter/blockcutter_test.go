@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/orderer/common/broadcastfilter"
 	"github.com/hyperledger/fabric/orderer/common/configtx"
@@ -30,7 +31,9 @@ import (
 
 type mockConfigManager struct {
 	validated   bool
+	applied     bool
 	validateErr error
+	applyErr    error
 }
 
 func (mcm *mockConfigManager) Validate(configtx *cb.ConfigurationEnvelope) error {
@@ -39,11 +42,36 @@ func (mcm *mockConfigManager) Validate(configtx *cb.ConfigurationEnvelope) error
 }
 
 func (mcm *mockConfigManager) Apply(message *cb.ConfigurationEnvelope) error {
-	panic("Unimplemented")
+	mcm.applied = true
+	return mcm.applyErr
 }
 
 func (mcm *mockConfigManager) ChainID() []byte {
-	panic("Unimplemented")
+	return []byte("mockChainID")
+}
+
+type mockSharedConfigManager struct {
+	batchSize int
+}
+
+func (mscm *mockSharedConfigManager) ConsensusType() string {
+	return ""
+}
+
+func (mscm *mockSharedConfigManager) BatchSize() int {
+	return mscm.batchSize
+}
+
+func (mscm *mockSharedConfigManager) BatchTimeout() time.Duration {
+	return 0
+}
+
+func (mscm *mockSharedConfigManager) KafkaBrokers() []string {
+	return nil
+}
+
+func (mscm *mockSharedConfigManager) BlockDataHashingWidth() uint32 {
+	return cb.DefaultBlockDataHashingWidth
 }
 
 type mockConfigFilter struct {
@@ -105,7 +133,8 @@ func init() {
 func TestNormalBatch(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	batchSize := 2
-	r := NewReceiverImpl(batchSize, filters, cm)
+	r := NewReceiverImpl(batchSize, filters, cm, &mockSharedConfigManager{})
+	cuts := BatchesCut.Value(string(cm.ChainID()), CutReasonBatchSize)
 
 	batches, ok := r.Ordered(goodTx)
 
@@ -127,12 +156,15 @@ func TestNormalBatch(t *testing.T) {
 		t.Fatalf("Should have enqueued second message into batch")
 	}
 
+	if BatchesCut.Value(string(cm.ChainID()), CutReasonBatchSize) != cuts+1 {
+		t.Fatalf("Should have counted the batch cut because of its size")
+	}
 }
 
 func TestBadMessageInBatch(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	batchSize := 2
-	r := NewReceiverImpl(batchSize, filters, cm)
+	r := NewReceiverImpl(batchSize, filters, cm, &mockSharedConfigManager{})
 
 	batches, ok := r.Ordered(badTx)
 
@@ -168,7 +200,7 @@ func TestBadMessageInBatch(t *testing.T) {
 func TestUnmatchedMessageInBatch(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	batchSize := 2
-	r := NewReceiverImpl(batchSize, filters, cm)
+	r := NewReceiverImpl(batchSize, filters, cm, &mockSharedConfigManager{})
 
 	batches, ok := r.Ordered(unmatchedTx)
 
@@ -204,7 +236,7 @@ func TestUnmatchedMessageInBatch(t *testing.T) {
 func TestReconfigureEmptyBatch(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	batchSize := 2
-	r := NewReceiverImpl(batchSize, filters, cm)
+	r := NewReceiverImpl(batchSize, filters, cm, &mockSharedConfigManager{})
 
 	batches, ok := r.Ordered(configTx)
 
@@ -212,8 +244,8 @@ func TestReconfigureEmptyBatch(t *testing.T) {
 		t.Fatalf("Should have enqueued config message")
 	}
 
-	if !cm.validated {
-		t.Errorf("ConfigTx should have been validated before processing")
+	if !cm.applied {
+		t.Errorf("ConfigTx should have been applied before processing")
 	}
 
 	if len(batches) != 1 {
@@ -232,7 +264,7 @@ func TestReconfigureEmptyBatch(t *testing.T) {
 func TestReconfigurePartialBatch(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	batchSize := 2
-	r := NewReceiverImpl(batchSize, filters, cm)
+	r := NewReceiverImpl(batchSize, filters, cm, &mockSharedConfigManager{})
 
 	batches, ok := r.Ordered(goodTx)
 
@@ -250,8 +282,8 @@ func TestReconfigurePartialBatch(t *testing.T) {
 		t.Fatalf("Should have enqueued config message")
 	}
 
-	if !cm.validated {
-		t.Errorf("ConfigTx should have been validated before processing")
+	if !cm.applied {
+		t.Errorf("ConfigTx should have been applied before processing")
 	}
 
 	if len(batches) != 2 {
@@ -279,7 +311,7 @@ func TestReconfigureFailToVerify(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	cm.validateErr = fmt.Errorf("Fail to apply")
 	batchSize := 2
-	r := NewReceiverImpl(batchSize, filters, cm)
+	r := NewReceiverImpl(batchSize, filters, cm, &mockSharedConfigManager{})
 
 	batches, ok := r.Ordered(goodTx)
 
@@ -323,3 +355,63 @@ func TestReconfigureFailToVerify(t *testing.T) {
 		t.Fatalf("Should have enqueued good message into batch")
 	}
 }
+
+func TestReconfigureFailToApply(t *testing.T) {
+	filters, cm := getFiltersAndConfig()
+	cm.applyErr = fmt.Errorf("Fail to apply")
+	batchSize := 2
+	r := NewReceiverImpl(batchSize, filters, cm, &mockSharedConfigManager{})
+
+	batches, ok := r.Ordered(goodTx)
+
+	if batches != nil {
+		t.Fatalf("Should not have created batch")
+	}
+
+	if !ok {
+		t.Fatalf("Should have enqueued good message into batch")
+	}
+
+	batches, ok = r.Ordered(configTx)
+
+	if !cm.applied {
+		t.Errorf("ConfigTx should have been applied before processing")
+	}
+
+	if batches != nil {
+		t.Fatalf("Should not have created batch")
+	}
+
+	if ok {
+		t.Fatalf("Should not have enqueued config message which failed to apply into batch")
+	}
+}
+
+func TestSharedConfigBatchSize(t *testing.T) {
+	filters, cm := getFiltersAndConfig()
+	batchSize := 3
+	scm := &mockSharedConfigManager{batchSize: 1}
+	r := NewReceiverImpl(batchSize, filters, cm, scm)
+
+	batches, ok := r.Ordered(goodTx)
+
+	if !ok {
+		t.Fatalf("Should have enqueued message into batch")
+	}
+
+	if len(batches) != 1 {
+		t.Fatalf("Should have cut a batch at the batch size of the shared config, got %d batches", len(batches))
+	}
+
+	scm.batchSize = 0
+
+	batches, ok = r.Ordered(goodTx)
+
+	if !ok {
+		t.Fatalf("Should have enqueued message into batch")
+	}
+
+	if batches != nil {
+		t.Fatalf("Should have fallen back to the configured batch size when the shared config does not set one")
+	}
+}
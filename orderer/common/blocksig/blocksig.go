@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package blocksig signs the blocks created by the ordering service and
+// verifies these signatures against the policy of the chain.
+package blocksig
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/orderer/rawledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// OrdererPolicyID is the ID of the policy the block signatures must satisfy
+const OrdererPolicyID = "OrdererBlockSigners"
+
+// Signer is the identity signing the blocks, an msp.SigningIdentity is one
+type Signer interface {
+	// Serialize returns the serialized identity of the signer
+	Serialize() ([]byte, error)
+
+	// Sign signs the message
+	Sign(msg []byte) ([]byte, error)
+}
+
+type blockSigner struct {
+	signer Signer
+}
+
+// NewBlockSigner returns a rawledger.BlockSigner which signs blocks with signer
+func NewBlockSigner(signer Signer) rawledger.BlockSigner {
+	return &blockSigner{signer: signer}
+}
+
+// SignBlock implements the rawledger.BlockSigner interface
+func (bs *blockSigner) SignBlock(block *cb.Block) error {
+	return SignBlock(block, bs.signer)
+}
+
+// SignBlock adds the signature of signer over the header of the block and the
+// value of its signatures metadata to this metadata
+func SignBlock(block *cb.Block, signer Signer) error {
+	if block.Header == nil {
+		return fmt.Errorf("Block has no header")
+	}
+	metadata, err := GetSignaturesMetadata(block)
+	if err != nil {
+		return err
+	}
+
+	creator, err := signer.Serialize()
+	if err != nil {
+		return fmt.Errorf("Could not serialize the identity of the signer: %s", err)
+	}
+	signatureHeader, err := proto.Marshal(&cb.SignatureHeader{Creator: creator})
+	if err != nil {
+		return err
+	}
+	signature, err := signer.Sign(signedBytes(metadata.Value, block.Header))
+	if err != nil {
+		return fmt.Errorf("Could not sign block %d: %s", block.Header.Number, err)
+	}
+	metadata.Signatures = append(metadata.Signatures, &cb.MetadataSignature{
+		SignatureHeader: signatureHeader,
+		Signature:       signature,
+	})
+
+	metadataBytes, err := proto.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if block.Metadata == nil {
+		block.Metadata = &cb.BlockMetadata{}
+	}
+	for len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_SIGNATURES) {
+		block.Metadata.Metadata = append(block.Metadata.Metadata, nil)
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = metadataBytes
+	return nil
+}
+
+// GetSignaturesMetadata returns the signatures metadata of the block, which is
+// empty if the block is not signed
+func GetSignaturesMetadata(block *cb.Block) (*cb.Metadata, error) {
+	metadata := &cb.Metadata{}
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_SIGNATURES) {
+		return metadata, nil
+	}
+	if err := proto.Unmarshal(block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES], metadata); err != nil {
+		return nil, fmt.Errorf("Could not decode the signatures metadata: %s", err)
+	}
+	return metadata, nil
+}
+
+// signedBytes returns the bytes signed by the orderers, that is the value of
+// the signatures metadata followed by the block header
+func signedBytes(value []byte, header *cb.BlockHeader) []byte {
+	headerBytes := header.Bytes()
+	msg := make([]byte, 0, len(value)+len(headerBytes))
+	msg = append(msg, value...)
+	return append(msg, headerBytes...)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package blocksig_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	. "github.com/hyperledger/fabric/orderer/common/blocksig"
+	"github.com/hyperledger/fabric/orderer/common/bootstrap/static"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/policies"
+	"github.com/hyperledger/fabric/orderer/common/util"
+	"github.com/hyperledger/fabric/orderer/rawledger/ramledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var orderer = []byte("orderer")
+
+// mockSigner "signs" by hashing its identity with the message
+type mockSigner struct {
+	id []byte
+}
+
+func (ms *mockSigner) Serialize() ([]byte, error) {
+	return ms.id, nil
+}
+
+func (ms *mockSigner) Sign(msg []byte) ([]byte, error) {
+	return mockSignature(ms.id, msg), nil
+}
+
+func mockSignature(id []byte, msg []byte) []byte {
+	h := sha256.Sum256(append(append([]byte{}, id...), msg...))
+	return h[:]
+}
+
+type mockCryptoHelper struct{}
+
+func (mch *mockCryptoHelper) VerifySignature(msg []byte, id []byte, signature []byte) bool {
+	return bytes.Equal(signature, mockSignature(id, msg))
+}
+
+// SatisfiesPrincipal considers the orderer to be the only member of the default orderer MSP
+func (mch *mockCryptoHelper) SatisfiesPrincipal(id []byte, principal *cb.MSPPrincipal) bool {
+	role := &cb.MSPRole{}
+	if principal.PrincipalClassification != cb.MSPPrincipal_ByMSPRole || proto.Unmarshal(principal.Principal, role) != nil {
+		return false
+	}
+	return role.MSPIdentifier == static.DefaultOrdererMSPID && role.Role == cb.MSPRole_Member && bytes.Equal(id, orderer)
+}
+
+func newTestVerifier(t *testing.T) *Verifier {
+	policyManager := policies.NewManagerImpl(&mockCryptoHelper{})
+	policyManager.BeginConfig()
+	err := policyManager.ProposeConfig(&cb.ConfigurationItem{
+		Type:  cb.ConfigurationItem_Policy,
+		Key:   OrdererPolicyID,
+		Value: util.MarshalOrPanic(util.MakePolicyOrPanic(cauthdsl.Envelope(cauthdsl.SignedBy(0), [][]byte{orderer}))),
+	})
+	if err != nil {
+		t.Fatalf("Error adding the orderer policy: %s", err)
+	}
+	policyManager.CommitConfig()
+	return NewVerifier(policyManager)
+}
+
+func newTestBlock() *cb.Block {
+	data := &cb.BlockData{Data: [][]byte{[]byte("tx")}}
+	return &cb.Block{
+		Header: &cb.BlockHeader{Number: 1, PreviousHash: []byte("previous"), DataHash: data.Hash()},
+		Data:   data,
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	verifier := newTestVerifier(t)
+	block := newTestBlock()
+	if err := verifier.Verify(block); err == nil {
+		t.Fatal("Verification of an unsigned block should fail")
+	}
+
+	if err := SignBlock(block, &mockSigner{id: orderer}); err != nil {
+		t.Fatalf("Error signing the block: %s", err)
+	}
+	if err := verifier.Verify(block); err != nil {
+		t.Fatalf("Verification of the signed block failed: %s", err)
+	}
+
+	block.Header.Number = 2
+	if err := verifier.Verify(block); err == nil {
+		t.Fatal("Verification of a block with a modified header should fail")
+	}
+}
+
+func TestVerifyWrongSigner(t *testing.T) {
+	block := newTestBlock()
+	if err := SignBlock(block, &mockSigner{id: []byte("someone else")}); err != nil {
+		t.Fatalf("Error signing the block: %s", err)
+	}
+	if err := newTestVerifier(t).Verify(block); err == nil {
+		t.Fatal("Verification of a block signed by an identity outside of the policy should fail")
+	}
+}
+
+func TestLedgerSignsBlocks(t *testing.T) {
+	genesisBlock, _ := static.New().GenesisBlock()
+	_, rl := ramledger.New(10, genesisBlock, NewBlockSigner(&mockSigner{id: orderer}))
+	block := rl.Append([]*cb.Envelope{{Payload: []byte("payload")}}, nil)
+	if err := newTestVerifier(t).Verify(block); err != nil {
+		t.Fatalf("Verification of the block appended to the ledger failed: %s", err)
+	}
+}
+
+func TestVerifierFromGenesisBlock(t *testing.T) {
+	genesisBlock, _ := static.New().GenesisBlock()
+	verifier, err := NewVerifierFromGenesisBlock(genesisBlock, &mockCryptoHelper{})
+	if err != nil {
+		t.Fatalf("Error creating a verifier from the genesis block: %s", err)
+	}
+	// the static genesis block requires the signature of a member of the orderer MSP
+	block := newTestBlock()
+	if err = verifier.Verify(block); err == nil {
+		t.Fatal("Verification of an unsigned block should fail")
+	}
+	if err = SignBlock(block, &mockSigner{id: []byte("someone else")}); err != nil {
+		t.Fatalf("Error signing the block: %s", err)
+	}
+	if err = verifier.Verify(block); err == nil {
+		t.Fatal("Verification of a block signed outside of the orderer MSP should fail")
+	}
+	if err = SignBlock(block, &mockSigner{id: orderer}); err != nil {
+		t.Fatalf("Error signing the block: %s", err)
+	}
+	if err = verifier.Verify(block); err != nil {
+		t.Fatalf("Verification failed: %s", err)
+	}
+
+	if _, err = NewVerifierFromGenesisBlock(newTestBlock(), &mockCryptoHelper{}); err == nil {
+		t.Fatal("Creating a verifier from a block without configuration should fail")
+	}
+}
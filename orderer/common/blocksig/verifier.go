@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package blocksig
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Verifier checks that blocks are signed according to the orderer policy of a chain
+type Verifier struct {
+	policyManager policies.Manager
+}
+
+// NewVerifier returns a Verifier checking block signatures against the
+// OrdererPolicyID policy of policyManager
+func NewVerifier(policyManager policies.Manager) *Verifier {
+	return &Verifier{policyManager: policyManager}
+}
+
+// NewVerifierFromGenesisBlock returns a Verifier checking block signatures
+// against the policies configured by the genesis block of a chain. It is meant
+// for the clients of the ordering service, which do not otherwise track the
+// configuration of the chain
+func NewVerifierFromGenesisBlock(genesisBlock *cb.Block, ch cauthdsl.CryptoHelper) (*Verifier, error) {
+	configEnvelope, err := configtx.GenesisConfiguration(genesisBlock)
+	if err != nil {
+		return nil, err
+	}
+	_, policyManager, _, err := configtx.Bootstrap(configEnvelope, ch)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifier(policyManager), nil
+}
+
+// Verify returns nil if the signatures of the block satisfy the orderer
+// policy, or an error indicating why they do not
+func (v *Verifier) Verify(block *cb.Block) error {
+	if block.Header == nil {
+		return fmt.Errorf("Block has no header")
+	}
+	policy, ok := v.policyManager.GetPolicy(OrdererPolicyID)
+	if !ok {
+		return fmt.Errorf("Chain has no %s policy", OrdererPolicyID)
+	}
+	metadata, err := GetSignaturesMetadata(block)
+	if err != nil {
+		return err
+	}
+
+	headers := make([][]byte, len(metadata.Signatures))
+	identities := make([][]byte, len(metadata.Signatures))
+	signatures := make([][]byte, len(metadata.Signatures))
+	for i, metadataSignature := range metadata.Signatures {
+		signatureHeader := &cb.SignatureHeader{}
+		if err = proto.Unmarshal(metadataSignature.SignatureHeader, signatureHeader); err != nil {
+			return fmt.Errorf("Could not decode the header of signature %d: %s", i, err)
+		}
+		headers[i] = metadataSignature.SignatureHeader
+		identities[i] = signatureHeader.Creator
+		signatures[i] = metadataSignature.Signature
+	}
+
+	if err = policy.Evaluate(headers, signedBytes(metadata.Value, block.Header), identities, signatures); err != nil {
+		return fmt.Errorf("Signatures of block %d do not satisfy the %s policy: %s", block.Header.Number, OrdererPolicyID, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric/orderer/common/bootstrap"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+)
+
+type fileBootstrapper struct {
+	path string
+}
+
+// New returns a new bootstrap helper reading the genesis block from a file, as written
+// by the genesis generation tool
+func New(path string) bootstrap.Helper {
+	return &fileBootstrapper{path: path}
+}
+
+// GenesisBlock returns the genesis block read from the file
+func (b *fileBootstrapper) GenesisBlock() (*cb.Block, error) {
+	data, err := ioutil.ReadFile(b.path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading genesis block file %s: %s", b.path, err)
+	}
+	block := &cb.Block{}
+	if err = proto.Unmarshal(data, block); err != nil {
+		return nil, fmt.Errorf("Error unmarshaling genesis block from %s: %s", b.path, err)
+	}
+	if block.Header == nil || block.Data == nil {
+		return nil, fmt.Errorf("Genesis block in %s has no header or no data", b.path)
+	}
+	if block.Header.Number != 0 {
+		return nil, fmt.Errorf("Block in %s is block %d, not a genesis block", b.path, block.Header.Number)
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.MerkleHash(sharedconfig.BlockDataHashingWidth(block.Data))) {
+		return nil, fmt.Errorf("Genesis block in %s does not match its data hash", b.path)
+	}
+	return block, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/bootstrap/static"
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+func writeBlock(t *testing.T, dir string, block *cb.Block) string {
+	path := filepath.Join(dir, "genesis.block")
+	data, err := proto.Marshal(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGenesisBlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	expected, _ := static.New().GenesisBlock()
+	block, err := New(writeBlock(t, dir, expected)).GenesisBlock()
+	if err != nil {
+		t.Fatalf("Error reading the genesis block: %s", err)
+	}
+	if !bytes.Equal(block.Header.Hash(), expected.Header.Hash()) {
+		t.Fatal("Expected the genesis block written to the file")
+	}
+
+	if _, err = New(filepath.Join(dir, "missing.block")).GenesisBlock(); err == nil {
+		t.Fatal("Expected reading a missing file to fail")
+	}
+
+	expected.Header.Number = 1
+	if _, err = New(writeBlock(t, dir, expected)).GenesisBlock(); err == nil {
+		t.Fatal("Expected reading a block which is not a genesis block to fail")
+	}
+
+	expected.Header.Number = 0
+	expected.Data.Data = append(expected.Data.Data, []byte("tampered"))
+	if _, err = New(writeBlock(t, dir, expected)).GenesisBlock(); err == nil {
+		t.Fatal("Expected reading a block whose data does not match its header to fail")
+	}
+}
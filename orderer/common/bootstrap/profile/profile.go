@@ -0,0 +1,361 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/blocksig"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/deliver"
+	"github.com/hyperledger/fabric/orderer/common/mspconfig"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
+	"github.com/hyperledger/fabric/orderer/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v2"
+)
+
+const msgVersion = int32(1)
+
+// The keys of the configuration items of type Chain, the MSP of each organisation is
+// stored under MSPKeyPrefix followed by the name of the organisation
+const (
+	OrdererAddressesKey = "OrdererAddresses"
+	MSPKeyPrefix        = mspconfig.KeyPrefix
+)
+
+// The rules a policy of the profile may be made of
+const (
+	AcceptAllRule  = "AcceptAll"
+	RejectAllRule  = "RejectAll"
+	NOutOfRule     = "NOutOf"
+	ExpressionRule = "Expression"
+)
+
+// Profile describes the chain configured by a genesis block
+type Profile struct {
+	ChainID       string            `yaml:"ChainID"`
+	Orderer       Orderer           `yaml:"Orderer"`
+	Organizations []Organization    `yaml:"Organizations"`
+	Policies      map[string]Policy `yaml:"Policies"`
+
+	// the directory the certificate files are relative to
+	dir string
+}
+
+// Orderer contains the configuration of the ordering service of the chain
+type Orderer struct {
+	OrdererType           string        `yaml:"OrdererType"`
+	MSPID                 string        `yaml:"MSPID"`
+	Addresses             []string      `yaml:"Addresses"`
+	BatchSize             uint32        `yaml:"BatchSize"`
+	BatchTimeout          time.Duration `yaml:"BatchTimeout"`
+	BlockDataHashingWidth uint32        `yaml:"BlockDataHashingWidth"`
+	Kafka                 Kafka         `yaml:"Kafka"`
+}
+
+// Kafka contains the configuration of the Kafka-backed ordering service
+type Kafka struct {
+	Brokers []string `yaml:"Brokers"`
+}
+
+// Organization is a member of the chain, identified by its MSP
+type Organization struct {
+	Name       string   `yaml:"Name"`
+	RootCerts  []string `yaml:"RootCerts"`
+	AdminCerts []string `yaml:"AdminCerts"`
+}
+
+// Policy is a policy of the chain. A NOutOf policy requires the signatures of N of the
+// signers, which are the certificates listed in Signers and the administrators of the
+// organisations listed in Admins. An Expression policy is written in the policy language
+// of cauthdsl, such as OutOf(2, 'Org1.member', 'Org2.admin'), over the organisations
+type Policy struct {
+	Rule       string   `yaml:"Rule"`
+	N          int32    `yaml:"N"`
+	Admins     []string `yaml:"Admins"`
+	Signers    []string `yaml:"Signers"`
+	Expression string   `yaml:"Expression"`
+}
+
+// The policies of the chain when the profile does not define them, they are those of the
+// static bootstrapper. The default OrdererPolicyID policy requires the signature of a
+// member of the MSP of the orderers, Orderer.MSPID
+var defaultPolicies = map[string]Policy{
+	configtx.DefaultModificationPolicyID: {Rule: RejectAllRule},
+	deliver.ReadersPolicyID:              {Rule: AcceptAllRule},
+}
+
+// Load reads a profile from a YAML file, the certificate files it refers to are relative
+// to the directory of the profile
+func Load(path string) (*Profile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading profile %s: %s", path, err)
+	}
+	profile := &Profile{}
+	if err = yaml.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("Error parsing profile %s: %s", path, err)
+	}
+	profile.dir = filepath.Dir(path)
+	return profile, nil
+}
+
+// GenesisBlock returns the genesis block of the chain described by the profile
+func (p *Profile) GenesisBlock() (*cb.Block, error) {
+	items, err := p.configurationItems()
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := []byte(p.ChainID)
+	epoch := uint64(0)
+	configEnvelope := util.MakeConfigurationEnvelope(items...)
+	payloadChainHeader := util.MakeChainHeader(cb.HeaderType_CONFIGURATION_TRANSACTION, msgVersion, chainID, epoch)
+	payloadSignatureHeader := util.MakeSignatureHeader(nil, util.CreateNonceOrPanic())
+	payloadHeader := util.MakePayloadHeader(payloadChainHeader, payloadSignatureHeader)
+	payload := &cb.Payload{Header: payloadHeader, Data: util.MarshalOrPanic(configEnvelope)}
+	envelope := &cb.Envelope{Payload: util.MarshalOrPanic(payload), Signature: nil}
+
+	blockData := &cb.BlockData{Data: [][]byte{util.MarshalOrPanic(envelope)}}
+
+	return &cb.Block{
+		Header: &cb.BlockHeader{
+			Number:       0,
+			PreviousHash: nil,
+			DataHash:     blockData.MerkleHash(sharedconfig.BlockDataHashingWidth(blockData)),
+		},
+		Data:     blockData,
+		Metadata: nil,
+	}, nil
+}
+
+// configurationItems validates the profile and returns the configuration items of the chain
+func (p *Profile) configurationItems() ([]*cb.SignedConfigurationItem, error) {
+	if p.ChainID == "" {
+		return nil, fmt.Errorf("ChainID must be set")
+	}
+
+	var items []*cb.SignedConfigurationItem
+	chainHeader := util.MakeChainHeader(cb.HeaderType_CONFIGURATION_ITEM, msgVersion, []byte(p.ChainID), 0)
+	addItem := func(itemType cb.ConfigurationItem_ConfigurationType, key string, value proto.Message) {
+		item := util.MakeConfigurationItem(chainHeader, itemType, 0, configtx.DefaultModificationPolicyID, key, util.MarshalOrPanic(value))
+		items = append(items, &cb.SignedConfigurationItem{ConfigurationItem: util.MarshalOrPanic(item), Signatures: nil})
+	}
+
+	// Orderer
+	switch p.Orderer.OrdererType {
+	case "solo":
+	case "kafka":
+		if len(p.Orderer.Kafka.Brokers) == 0 {
+			return nil, fmt.Errorf("Orderer.Kafka.Brokers must be set for the kafka orderer type")
+		}
+		addItem(cb.ConfigurationItem_Orderer, sharedconfig.KafkaBrokersKey, &ab.KafkaBrokers{Brokers: p.Orderer.Kafka.Brokers})
+	default:
+		return nil, fmt.Errorf("Unknown orderer type %q", p.Orderer.OrdererType)
+	}
+	if p.Orderer.BatchSize == 0 {
+		return nil, fmt.Errorf("Orderer.BatchSize must be set")
+	}
+	if p.Orderer.BatchTimeout <= 0 {
+		return nil, fmt.Errorf("Orderer.BatchTimeout must be set")
+	}
+	if len(p.Orderer.Addresses) == 0 {
+		return nil, fmt.Errorf("Orderer.Addresses must be set")
+	}
+	hashingWidth := p.Orderer.BlockDataHashingWidth
+	if hashingWidth == 0 {
+		hashingWidth = cb.DefaultBlockDataHashingWidth
+	}
+	if hashingWidth < 2 {
+		return nil, fmt.Errorf("Orderer.BlockDataHashingWidth must be at least 2")
+	}
+	addItem(cb.ConfigurationItem_Orderer, sharedconfig.ConsensusTypeKey, &ab.ConsensusType{Type: p.Orderer.OrdererType})
+	addItem(cb.ConfigurationItem_Orderer, sharedconfig.BatchSizeKey, &ab.BatchSize{Messages: p.Orderer.BatchSize})
+	addItem(cb.ConfigurationItem_Orderer, sharedconfig.BatchTimeoutKey, &ab.BatchTimeout{Timeout: p.Orderer.BatchTimeout.String()})
+	addItem(cb.ConfigurationItem_Orderer, sharedconfig.BlockDataHashingStructureKey, &ab.BlockDataHashingStructure{Width: hashingWidth})
+	addItem(cb.ConfigurationItem_Chain, OrdererAddressesKey, &cb.OrdererAddresses{Addresses: p.Orderer.Addresses})
+
+	// Organizations
+	orgs := make(map[string]*cb.MSPConfig)
+	for _, org := range p.Organizations {
+		if org.Name == "" {
+			return nil, fmt.Errorf("Organizations must have a Name")
+		}
+		if _, ok := orgs[org.Name]; ok {
+			return nil, fmt.Errorf("Organization %s is defined twice", org.Name)
+		}
+		if len(org.RootCerts) == 0 {
+			return nil, fmt.Errorf("Organization %s has no RootCerts", org.Name)
+		}
+		mspConfig := &cb.MSPConfig{Name: org.Name}
+		for _, file := range org.RootCerts {
+			cert, err := p.readCert(file)
+			if err != nil {
+				return nil, err
+			}
+			mspConfig.RootCerts = append(mspConfig.RootCerts, cert)
+		}
+		for _, file := range org.AdminCerts {
+			cert, err := p.readCert(file)
+			if err != nil {
+				return nil, err
+			}
+			mspConfig.AdminCerts = append(mspConfig.AdminCerts, cert)
+		}
+		orgs[org.Name] = mspConfig
+		addItem(cb.ConfigurationItem_Chain, MSPKeyPrefix+org.Name, mspConfig)
+	}
+
+	if _, ok := orgs[p.Orderer.MSPID]; p.Orderer.MSPID != "" && !ok {
+		return nil, fmt.Errorf("Orderer.MSPID %s is not one of the Organizations", p.Orderer.MSPID)
+	}
+
+	// Policies
+	policies := make(map[string]Policy)
+	for id, policy := range defaultPolicies {
+		policies[id] = policy
+	}
+	for id, policy := range p.Policies {
+		policies[id] = policy
+	}
+	for _, id := range sortedKeys(policies) {
+		envelope, err := policies[id].envelope(orgs, p.readCert)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid policy %s: %s", id, err)
+		}
+		addItem(cb.ConfigurationItem_Policy, id, util.MakePolicyOrPanic(envelope))
+	}
+	if _, ok := policies[blocksig.OrdererPolicyID]; !ok {
+		if p.Orderer.MSPID == "" {
+			return nil, fmt.Errorf("Orderer.MSPID must be set unless Policies.%s is", blocksig.OrdererPolicyID)
+		}
+		addItem(cb.ConfigurationItem_Policy, blocksig.OrdererPolicyID, util.MakePolicyOrPanic(cauthdsl.SignedByMSPMember(p.Orderer.MSPID)))
+	}
+
+	return items, nil
+}
+
+// envelope builds the signature policy, the signers are identified by their DER encoded certificate
+func (p Policy) envelope(orgs map[string]*cb.MSPConfig, readCert func(string) ([]byte, error)) (*cb.SignaturePolicyEnvelope, error) {
+	switch p.Rule {
+	case AcceptAllRule:
+		return cauthdsl.AcceptAllPolicy, nil
+	case RejectAllRule:
+		return cauthdsl.RejectAllPolicy, nil
+	case ExpressionRule:
+		return expressionEnvelope(p.Expression, orgs)
+	case NOutOfRule:
+	default:
+		return nil, fmt.Errorf("unknown rule %q", p.Rule)
+	}
+
+	var certs [][]byte
+	for _, name := range p.Admins {
+		org, ok := orgs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown organization %s", name)
+		}
+		if len(org.AdminCerts) == 0 {
+			return nil, fmt.Errorf("organization %s has no AdminCerts", name)
+		}
+		certs = append(certs, org.AdminCerts...)
+	}
+	for _, file := range p.Signers {
+		cert, err := readCert(file)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if p.N <= 0 || int(p.N) > len(certs) {
+		return nil, fmt.Errorf("N must be between 1 and the number of signers (%d), got %d", len(certs), p.N)
+	}
+	identities := make([][]byte, len(certs))
+	signedBy := make([]*cb.SignaturePolicy, len(certs))
+	for i, cert := range certs {
+		block, _ := pem.Decode(cert)
+		identities[i] = block.Bytes
+		signedBy[i] = cauthdsl.SignedBy(int32(i))
+	}
+	return cauthdsl.Envelope(cauthdsl.NOutOf(p.N, signedBy), identities), nil
+}
+
+// expressionEnvelope parses the expression of a policy, whose principals must be organizations of the profile
+func expressionEnvelope(expression string, orgs map[string]*cb.MSPConfig) (*cb.SignaturePolicyEnvelope, error) {
+	envelope, err := cauthdsl.FromString(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, principal := range envelope.Principals {
+		var name string
+		switch principal.PrincipalClassification {
+		case cb.MSPPrincipal_ByMSPRole:
+			role := &cb.MSPRole{}
+			if err := proto.Unmarshal(principal.Principal, role); err != nil {
+				return nil, err
+			}
+			name = role.MSPIdentifier
+		case cb.MSPPrincipal_ByOrganizationUnit:
+			ou := &cb.OrganizationUnit{}
+			if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+				return nil, err
+			}
+			name = ou.MSPIdentifier
+		}
+		if _, ok := orgs[name]; !ok {
+			return nil, fmt.Errorf("unknown organization %s", name)
+		}
+	}
+	return envelope, nil
+}
+
+// readCert reads a PEM encoded certificate
+func (p *Profile) readCert(file string) ([]byte, error) {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(p.dir, file)
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading certificate: %s", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%s is not a PEM encoded certificate", file)
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+func sortedKeys(policies map[string]Policy) []string {
+	keys := make([]string, 0, len(policies))
+	for key := range policies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
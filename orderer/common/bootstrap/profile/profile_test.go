@@ -0,0 +1,174 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"bytes"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/blocksig"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/deliver"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+)
+
+func loadItems(t *testing.T, p *Profile) map[string]*cb.ConfigurationItem {
+	block, err := p.GenesisBlock()
+	if err != nil {
+		t.Fatalf("Error creating the genesis block: %s", err)
+	}
+	configEnvelope, err := configtx.GenesisConfiguration(block)
+	if err != nil {
+		t.Fatalf("Error reading the configuration of the genesis block: %s", err)
+	}
+	items := make(map[string]*cb.ConfigurationItem)
+	for _, signedItem := range configEnvelope.Items {
+		item := &cb.ConfigurationItem{}
+		if err = proto.Unmarshal(signedItem.ConfigurationItem, item); err != nil {
+			t.Fatalf("Error unmarshaling configuration item: %s", err)
+		}
+		if string(item.Header.ChainID) != p.ChainID {
+			t.Fatalf("Expected item %s to be for chain %s, got %s", item.Key, p.ChainID, item.Header.ChainID)
+		}
+		items[item.Key] = item
+	}
+	return items
+}
+
+func TestGenesisBlock(t *testing.T) {
+	p, err := Load("testdata/genesis.yaml")
+	if err != nil {
+		t.Fatalf("Error loading the profile: %s", err)
+	}
+	items := loadItems(t, p)
+
+	batchSize := &ab.BatchSize{}
+	if err = proto.Unmarshal(items[sharedconfig.BatchSizeKey].Value, batchSize); err != nil || batchSize.Messages != 20 {
+		t.Fatalf("Expected a batch size of 20, got %v (%v)", batchSize, err)
+	}
+	batchTimeout := &ab.BatchTimeout{}
+	if err = proto.Unmarshal(items[sharedconfig.BatchTimeoutKey].Value, batchTimeout); err != nil || batchTimeout.Timeout != "2s" {
+		t.Fatalf("Expected a batch timeout of 2s, got %v (%v)", batchTimeout, err)
+	}
+	hashingStructure := &ab.BlockDataHashingStructure{}
+	if err = proto.Unmarshal(items[sharedconfig.BlockDataHashingStructureKey].Value, hashingStructure); err != nil || hashingStructure.Width != 4 {
+		t.Fatalf("Expected a block data hashing width of 4, got %v (%v)", hashingStructure, err)
+	}
+	brokers := &ab.KafkaBrokers{}
+	if err = proto.Unmarshal(items[sharedconfig.KafkaBrokersKey].Value, brokers); err != nil || len(brokers.Brokers) != 1 {
+		t.Fatalf("Expected one kafka broker, got %v (%v)", brokers, err)
+	}
+	if items[OrdererAddressesKey].Type != cb.ConfigurationItem_Chain {
+		t.Fatalf("Expected the orderer addresses to be a chain item, got %s", items[OrdererAddressesKey].Type)
+	}
+
+	mspConfig := &cb.MSPConfig{}
+	if err = proto.Unmarshal(items[MSPKeyPrefix+"Org1MSP"].Value, mspConfig); err != nil || len(mspConfig.RootCerts) != 1 || len(mspConfig.AdminCerts) != 1 {
+		t.Fatalf("Expected the MSP of Org1MSP with a root and an admin certificate, got %v (%v)", mspConfig, err)
+	}
+
+	policy := &cb.Policy{}
+	if err = proto.Unmarshal(items[configtx.DefaultModificationPolicyID].Value, policy); err != nil {
+		t.Fatalf("Error unmarshaling the modification policy: %s", err)
+	}
+	envelope := policy.GetSignaturePolicy()
+	block, _ := pem.Decode(mspConfig.AdminCerts[0])
+	if len(envelope.Identities) != 1 || !bytes.Equal(envelope.Identities[0], block.Bytes) {
+		t.Fatalf("Expected the modification policy to require the signature of the admin of Org1MSP")
+	}
+
+	writers := &cb.Policy{}
+	if err = proto.Unmarshal(items["Writers"].Value, writers); err != nil {
+		t.Fatalf("Error unmarshaling the writers policy: %s", err)
+	}
+	if expression, err := cauthdsl.ToString(writers.GetSignaturePolicy()); err != nil || expression != "OR('Org1MSP.member', 'Org1MSP.ou:Auditors')" {
+		t.Fatalf("Unexpected writers policy %q (%v)", expression, err)
+	}
+
+	for id, expected := range map[string]*cb.SignaturePolicyEnvelope{blocksig.OrdererPolicyID: cauthdsl.SignedByMSPMember("Org1MSP"), deliver.ReadersPolicyID: cauthdsl.RejectAllPolicy} {
+		policy := &cb.Policy{}
+		if err = proto.Unmarshal(items[id].Value, policy); err != nil || !proto.Equal(policy.GetSignaturePolicy(), expected) {
+			t.Fatalf("Unexpected %s policy %v (%v)", id, policy, err)
+		}
+	}
+}
+
+func TestInvalidProfiles(t *testing.T) {
+	valid := func() *Profile {
+		return &Profile{
+			ChainID:       "testchain",
+			Orderer:       Orderer{OrdererType: "solo", MSPID: "OrdererMSP", Addresses: []string{"127.0.0.1:7050"}, BatchSize: 10, BatchTimeout: 1},
+			Organizations: []Organization{{Name: "OrdererMSP", RootCerts: []string{"cert.pem"}}},
+			dir:           "testdata",
+		}
+	}
+	if _, err := valid().GenesisBlock(); err != nil {
+		t.Fatalf("Expected a valid profile, got %s", err)
+	}
+
+	for name, modify := range map[string]func(*Profile){
+		"no chain ID":           func(p *Profile) { p.ChainID = "" },
+		"unknown orderer type":  func(p *Profile) { p.Orderer.OrdererType = "pbft" },
+		"kafka without brokers": func(p *Profile) { p.Orderer.OrdererType = "kafka" },
+		"no batch size":         func(p *Profile) { p.Orderer.BatchSize = 0 },
+		"no orderer addresses":  func(p *Profile) { p.Orderer.Addresses = nil },
+		"no orderer MSP":        func(p *Profile) { p.Orderer.MSPID = "" },
+		"unknown orderer MSP":   func(p *Profile) { p.Orderer.MSPID = "Org2MSP" },
+		"hashing width of 1":    func(p *Profile) { p.Orderer.BlockDataHashingWidth = 1 },
+		"org without root cert": func(p *Profile) { p.Organizations = []Organization{{Name: "OrdererMSP"}} },
+		"missing cert file": func(p *Profile) {
+			p.Organizations = []Organization{{Name: "OrdererMSP", RootCerts: []string{"missing.pem"}}}
+		},
+		"not a cert": func(p *Profile) {
+			p.Organizations = []Organization{{Name: "OrdererMSP", RootCerts: []string{"genesis.yaml"}}}
+		},
+		"unknown rule": func(p *Profile) { p.Policies = map[string]Policy{"Readers": {Rule: "Maybe"}} },
+		"unknown admin org": func(p *Profile) {
+			p.Policies = map[string]Policy{"Readers": {Rule: NOutOfRule, N: 1, Admins: []string{"Org2MSP"}}}
+		},
+		"invalid expression": func(p *Profile) {
+			p.Policies = map[string]Policy{"Readers": {Rule: ExpressionRule, Expression: "OR('Org1MSP.member'"}}
+		},
+		"unknown expression org": func(p *Profile) {
+			p.Policies = map[string]Policy{"Readers": {Rule: ExpressionRule, Expression: "'Org2MSP.admin'"}}
+		},
+		"N above signers": func(p *Profile) {
+			p.Policies = map[string]Policy{"Readers": {Rule: NOutOfRule, N: 2, Signers: []string{"cert.pem"}}}
+		},
+	} {
+		p := valid()
+		modify(p)
+		if _, err := p.GenesisBlock(); err == nil {
+			t.Fatalf("Expected the genesis block of a profile with %s to fail", name)
+		}
+	}
+}
+
+func TestSampleProfile(t *testing.T) {
+	p, err := Load("../../../tools/genesisgen/genesis.yaml")
+	if err != nil {
+		t.Fatalf("Error loading the sample profile: %s", err)
+	}
+	if _, err = p.GenesisBlock(); err != nil {
+		t.Fatalf("Expected the sample profile to be valid: %s", err)
+	}
+}
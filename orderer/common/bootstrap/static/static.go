@@ -17,24 +17,36 @@ limitations under the License.
 package static
 
 import (
+	"github.com/hyperledger/fabric/orderer/common/blocksig"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap"
 	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
 	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/deliver"
 	"github.com/hyperledger/fabric/orderer/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
 
 var TestChainID = []byte("**TEST_CHAINID**")
 
+// DefaultOrdererMSPID is the MSP whose members may sign the blocks of the chains of New
+const DefaultOrdererMSPID = "DEFAULT"
+
 const msgVersion = int32(1)
 
 type bootstrapper struct {
-	chainID []byte
+	chainID      []byte
+	ordererMSPID string
 }
 
 // New returns a new static bootstrap helper.
 func New() bootstrap.Helper {
-	return &bootstrapper{chainID: TestChainID}
+	return NewWithOrdererMSP(DefaultOrdererMSPID)
+}
+
+// NewWithOrdererMSP returns a new static bootstrap helper whose chain accepts
+// the blocks signed by a member of the MSP identified by ordererMSPID
+func NewWithOrdererMSP(ordererMSPID string) bootstrap.Helper {
+	return &bootstrapper{chainID: TestChainID, ordererMSPID: ordererMSPID}
 }
 
 // GenesisBlock returns the genesis block to be used for bootstrapping
@@ -50,7 +62,17 @@ func (b *bootstrapper) GenesisBlock() (*cb.Block, error) {
 	configItem := util.MakeConfigurationItem(configItemChainHeader, cb.ConfigurationItem_Policy, lastModified, modPolicy, configItemKey, configItemValue)
 	signedConfigItem := &cb.SignedConfigurationItem{ConfigurationItem: util.MarshalOrPanic(configItem), Signatures: nil}
 
-	configEnvelope := util.MakeConfigurationEnvelope(signedConfigItem)
+	// Require the blocks to be signed by a member of the MSP of the orderer
+	ordererPolicyValue := util.MarshalOrPanic(util.MakePolicyOrPanic(cauthdsl.SignedByMSPMember(b.ordererMSPID)))
+	ordererPolicyItem := util.MakeConfigurationItem(configItemChainHeader, cb.ConfigurationItem_Policy, lastModified, modPolicy, blocksig.OrdererPolicyID, ordererPolicyValue)
+	signedOrdererPolicyItem := &cb.SignedConfigurationItem{ConfigurationItem: util.MarshalOrPanic(ordererPolicyItem), Signatures: nil}
+
+	// Allow anyone to read the chain, including the clients sending unsigned seek requests
+	readersPolicyValue := util.MarshalOrPanic(util.MakePolicyOrPanic(cauthdsl.AcceptAllPolicy))
+	readersPolicyItem := util.MakeConfigurationItem(configItemChainHeader, cb.ConfigurationItem_Policy, lastModified, modPolicy, deliver.ReadersPolicyID, readersPolicyValue)
+	signedReadersPolicyItem := &cb.SignedConfigurationItem{ConfigurationItem: util.MarshalOrPanic(readersPolicyItem), Signatures: nil}
+
+	configEnvelope := util.MakeConfigurationEnvelope(signedConfigItem, signedOrdererPolicyItem, signedReadersPolicyItem)
 	payloadChainHeader := util.MakeChainHeader(cb.HeaderType_CONFIGURATION_TRANSACTION, configItemChainHeader.Version, b.chainID, epoch)
 	payloadSignatureHeader := util.MakeSignatureHeader(nil, util.CreateNonceOrPanic())
 	payloadHeader := util.MakePayloadHeader(payloadChainHeader, payloadSignatureHeader)
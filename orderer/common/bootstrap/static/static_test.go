@@ -21,8 +21,10 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/blocksig"
 	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
 	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/deliver"
 	"github.com/hyperledger/fabric/orderer/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
@@ -59,7 +61,7 @@ func TestGenesisBlockData(t *testing.T) {
 	expectedPayloadChainHeaderType := int32(cb.HeaderType_CONFIGURATION_TRANSACTION)
 	expectedChainHeaderVersion := msgVersion
 	expectedChainHeaderEpoch := uint64(0)
-	expectedConfigEnvelopeItemsLength := 1
+	expectedConfigEnvelopeItemsLength := 3
 	expectedConfigurationItemChainHeaderType := int32(cb.HeaderType_CONFIGURATION_ITEM)
 	expectedConfigurationItemChainHeaderVersion := msgVersion
 	expectedConfigurationItemType := cb.ConfigurationItem_Policy
@@ -158,6 +160,22 @@ func TestGenesisBlockData(t *testing.T) {
 	if !proto.Equal(signaturePolicyEnvelope, expectedConfigurationItemValueSignaturePolicyEnvelope) {
 		t.Fatalf("Expected configuration item value signature policy envelope %s, got %s", expectedConfigurationItemValueSignaturePolicyEnvelope.String(), signaturePolicyEnvelope.String())
 	}
+
+	ordererPolicyItem := &cb.ConfigurationItem{}
+	if err := proto.Unmarshal(configurationEnvelope.Items[1].ConfigurationItem, ordererPolicyItem); err != nil {
+		t.Fatalf("Expected genesis block to carry a second ConfigurationItem")
+	}
+	if ordererPolicyItem.Type != cb.ConfigurationItem_Policy || ordererPolicyItem.Key != blocksig.OrdererPolicyID {
+		t.Fatalf("Expected the second configuration item to be the %s policy, got %s %s", blocksig.OrdererPolicyID, ordererPolicyItem.Type, ordererPolicyItem.Key)
+	}
+
+	readersPolicyItem := &cb.ConfigurationItem{}
+	if err := proto.Unmarshal(configurationEnvelope.Items[2].ConfigurationItem, readersPolicyItem); err != nil {
+		t.Fatalf("Expected genesis block to carry a third ConfigurationItem")
+	}
+	if readersPolicyItem.Type != cb.ConfigurationItem_Policy || readersPolicyItem.Key != deliver.ReadersPolicyID {
+		t.Fatalf("Expected the third configuration item to be the %s policy, got %s %s", deliver.ReadersPolicyID, readersPolicyItem.Type, readersPolicyItem.Key)
+	}
 }
 
 func TestGenesisMetadata(t *testing.T) {
@@ -17,6 +17,7 @@ limitations under the License.
 package broadcast
 
 import (
+	"github.com/hyperledger/fabric/metrics"
 	"github.com/hyperledger/fabric/orderer/common/broadcastfilter"
 	"github.com/hyperledger/fabric/orderer/common/configtx"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -27,6 +28,9 @@ import (
 
 var logger = logging.MustGetLogger("orderer/common/broadcast")
 
+var queueDepth = metrics.NewGauge("orderer_broadcast_queue_depth",
+	"Number of accepted broadcast messages waiting to be enqueued for ordering", "chain")
+
 func init() {
 	logging.SetLevel(logging.DEBUG, "")
 }
@@ -45,16 +49,21 @@ type Handler interface {
 
 type handlerImpl struct {
 	queueSize     int
+	quota         *Quota
+	chainID       string
 	target        Target
 	filters       *broadcastfilter.RuleSet
 	configManager configtx.Manager
 	exitChan      chan struct{}
 }
 
-// NewHandlerImpl constructs a new implementation of the Handler interface
-func NewHandlerImpl(queueSize int, target Target, filters *broadcastfilter.RuleSet, configManager configtx.Manager) Handler {
+// NewHandlerImpl constructs a new implementation of the Handler interface, queueing up to
+// queueSize messages of each client and enforcing quota on them, unless quota is nil
+func NewHandlerImpl(queueSize int, quota *Quota, target Target, filters *broadcastfilter.RuleSet, configManager configtx.Manager) Handler {
 	return &handlerImpl{
 		queueSize:     queueSize,
+		quota:         quota,
+		chainID:       string(configManager.ChainID()),
 		filters:       filters,
 		configManager: configManager,
 		target:        target,
@@ -88,6 +97,7 @@ func (b *broadcaster) drainQueue() {
 		select {
 		case msg, ok := <-b.queue:
 			if ok {
+				queueDepth.Dec(b.bs.chainID)
 				if !b.bs.target.Enqueue(msg) {
 					return
 				}
@@ -101,6 +111,7 @@ func (b *broadcaster) drainQueue() {
 }
 
 func (b *broadcaster) queueEnvelopes(srv ab.AtomicBroadcast_BroadcastServer) error {
+	client := StreamClient(srv.Context())
 
 	for {
 		msg, err := srv.Recv()
@@ -108,21 +119,34 @@ func (b *broadcaster) queueEnvelopes(srv ab.AtomicBroadcast_BroadcastServer) err
 			return err
 		}
 
+		if reason := b.bs.quota.Check(client, msg); reason != "" {
+			RecordRejection(b.bs.chainID, client, reason)
+			if err = srv.Send(&ab.BroadcastResponse{Status: RejectionStatus(reason)}); err != nil {
+				return err
+			}
+			continue
+		}
+
 		action, _ := b.bs.filters.Apply(msg)
 
 		switch action {
 		case broadcastfilter.Reconfigure:
 			fallthrough
 		case broadcastfilter.Accept:
+			// count the message before it can be drained so that the depth never goes negative
+			queueDepth.Inc(b.bs.chainID)
 			select {
 			case b.queue <- msg:
 				err = srv.Send(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})
 			default:
+				queueDepth.Dec(b.bs.chainID)
+				RecordRejection(b.bs.chainID, client, RejectedQueueFull)
 				err = srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE})
 			}
 		case broadcastfilter.Forward:
 			fallthrough
 		case broadcastfilter.Reject:
+			RecordRejection(b.bs.chainID, client, RejectedBadRequest)
 			err = srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
 		default:
 			logger.Fatalf("Unknown filter action :%v", action)
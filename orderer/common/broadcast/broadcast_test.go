@@ -19,9 +19,12 @@ package broadcast
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"testing"
 
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/orderer/common/broadcastfilter"
@@ -58,7 +61,7 @@ func (mcm *mockConfigManager) Apply(message *cb.ConfigurationEnvelope) error {
 }
 
 func (mcm *mockConfigManager) ChainID() []byte {
-	panic("Unimplemented")
+	return []byte("mockChainID")
 }
 
 type mockConfigFilter struct {
@@ -97,15 +100,28 @@ type mockB struct {
 	grpc.ServerStream
 	recvChan chan *cb.Envelope
 	sendChan chan *ab.BroadcastResponse
+	ctx      context.Context
 }
 
 func newMockB() *mockB {
 	return &mockB{
 		recvChan: make(chan *cb.Envelope),
 		sendChan: make(chan *ab.BroadcastResponse),
+		ctx:      context.Background(),
 	}
 }
 
+// newMockBFrom returns a stream of a client connected from host
+func newMockBFrom(host string) *mockB {
+	m := newMockB()
+	m.ctx = peer.NewContext(m.ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(host), Port: 1234}})
+	return m
+}
+
+func (m *mockB) Context() context.Context {
+	return m.ctx
+}
+
 func (m *mockB) Send(br *ab.BroadcastResponse) error {
 	m.sendChan <- br
 	return nil
@@ -134,7 +150,7 @@ func getFiltersConfigMockTarget() (*broadcastfilter.RuleSet, *mockConfigManager,
 func TestQueueOverflow(t *testing.T) {
 	filters, cm, mt := getFiltersConfigMockTarget()
 	defer mt.halt()
-	bh := NewHandlerImpl(2, mt, filters, cm)
+	bh := NewHandlerImpl(2, nil, mt, filters, cm)
 	m := newMockB()
 	defer close(m.recvChan)
 	b := newBroadcaster(bh.(*handlerImpl))
@@ -159,7 +175,7 @@ func TestQueueOverflow(t *testing.T) {
 func TestMultiQueueOverflow(t *testing.T) {
 	filters, cm, mt := getFiltersConfigMockTarget()
 	defer mt.halt()
-	bh := NewHandlerImpl(2, mt, filters, cm)
+	bh := NewHandlerImpl(2, nil, mt, filters, cm)
 	ms := []*mockB{newMockB(), newMockB(), newMockB()}
 
 	for _, m := range ms {
@@ -190,7 +206,7 @@ func TestMultiQueueOverflow(t *testing.T) {
 func TestEmptyEnvelope(t *testing.T) {
 	filters, cm, mt := getFiltersConfigMockTarget()
 	defer mt.halt()
-	bh := NewHandlerImpl(2, mt, filters, cm)
+	bh := NewHandlerImpl(2, nil, mt, filters, cm)
 	m := newMockB()
 	defer close(m.recvChan)
 	go bh.Handle(m)
@@ -206,7 +222,7 @@ func TestEmptyEnvelope(t *testing.T) {
 func TestReconfigureAccept(t *testing.T) {
 	filters, cm, mt := getFiltersConfigMockTarget()
 	defer mt.halt()
-	bh := NewHandlerImpl(2, mt, filters, cm)
+	bh := NewHandlerImpl(2, nil, mt, filters, cm)
 	m := newMockB()
 	defer close(m.recvChan)
 	go bh.Handle(m)
@@ -227,7 +243,7 @@ func TestReconfigureReject(t *testing.T) {
 	filters, cm, mt := getFiltersConfigMockTarget()
 	cm.validateErr = fmt.Errorf("Fail to validate")
 	defer mt.halt()
-	bh := NewHandlerImpl(2, mt, filters, cm)
+	bh := NewHandlerImpl(2, nil, mt, filters, cm)
 	m := newMockB()
 	defer close(m.recvChan)
 	go bh.Handle(m)
@@ -239,3 +255,56 @@ func TestReconfigureReject(t *testing.T) {
 		t.Fatalf("Should have failed to queue the message because it was invalid config")
 	}
 }
+
+func envelopeFrom(creator string, data []byte) *cb.Envelope {
+	payload, _ := proto.Marshal(&cb.Payload{
+		Header: &cb.Header{SignatureHeader: &cb.SignatureHeader{Creator: []byte(creator)}},
+		Data:   data,
+	})
+	return &cb.Envelope{Payload: payload}
+}
+
+func TestQuota(t *testing.T) {
+	filters, cm, mt := getFiltersConfigMockTarget()
+	defer mt.halt()
+	quota := NewQuota(Limits{MaxMessageBytes: 100, MessagesPerSecond: 1})
+	bh := NewHandlerImpl(10, quota, mt, filters, cm)
+	m := newMockBFrom("10.0.0.1")
+	defer close(m.recvChan)
+	go newBroadcaster(bh.(*handlerImpl)).queueEnvelopes(m)
+
+	chainID := string(cm.ChainID())
+	rateLimited := Rejections.Value(chainID, unauthenticated, RejectedRateLimited)
+	tooLarge := Rejections.Value(chainID, unauthenticated, RejectedTooLarge)
+
+	m.recvChan <- envelopeFrom("alice", []byte("Some bytes"))
+	if reply := <-m.sendChan; reply.Status != cb.Status_SUCCESS {
+		t.Fatalf("Should have successfully queued the message")
+	}
+
+	// The quota of the client is spent whatever the creator of its messages
+	m.recvChan <- envelopeFrom("bob", []byte("Some bytes"))
+	if reply := <-m.sendChan; reply.Status != cb.Status_SERVICE_UNAVAILABLE {
+		t.Fatalf("Should have rate limited the message, got %v", reply.Status)
+	}
+	if Rejections.Value(chainID, unauthenticated, RejectedRateLimited) != rateLimited+1 {
+		t.Fatalf("Should have counted the rate limited message")
+	}
+
+	// Another client has its own quota
+	other := newMockBFrom("10.0.0.2")
+	defer close(other.recvChan)
+	go newBroadcaster(bh.(*handlerImpl)).queueEnvelopes(other)
+	other.recvChan <- envelopeFrom("alice", []byte("Some bytes"))
+	if reply := <-other.sendChan; reply.Status != cb.Status_SUCCESS {
+		t.Fatalf("Should have successfully queued the message of another client")
+	}
+
+	m.recvChan <- envelopeFrom("alice", make([]byte, 200))
+	if reply := <-m.sendChan; reply.Status != cb.Status_BAD_REQUEST {
+		t.Fatalf("Should have rejected the oversized message, got %v", reply.Status)
+	}
+	if Rejections.Value(chainID, unauthenticated, RejectedTooLarge) != tooLarge+1 {
+		t.Fatalf("Should have counted the oversized message")
+	}
+}
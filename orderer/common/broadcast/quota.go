@@ -0,0 +1,265 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broadcast
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/metrics"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Reasons for which broadcast messages are rejected, as reported by the rejection counter
+const (
+	RejectedTooLarge    = "too_large"
+	RejectedRateLimited = "rate_limited"
+	RejectedQueueFull   = "queue_full"
+	RejectedBadRequest  = "bad_request"
+)
+
+// Rejections counts the rejected broadcast messages by chain, client and reason.
+// It is exposed, as all the metrics, by the metrics service of the orderer
+var Rejections = metrics.NewCounter("orderer_broadcast_rejected_total",
+	"Number of broadcast messages rejected", "chain", "client", "reason")
+
+// Values of the client label of the rejection counter which are not an authenticated client
+const (
+	// unauthenticated labels the clients without a verified TLS certificate
+	unauthenticated = "unauthenticated"
+	// otherClients labels the authenticated clients beyond maxClientLabels
+	otherClients = "other"
+)
+
+// maxClientLabels bounds the number of authenticated clients with a client label of their own
+const maxClientLabels = 100
+
+// clientLabels holds the client label values given to authenticated clients so far
+var clientLabels = struct {
+	sync.Mutex
+	values map[string]bool
+}{values: make(map[string]bool)}
+
+// idleBucketsThreshold is the number of identities tracked above which
+// the buckets left untouched for idleBucketsPeriod are discarded
+const idleBucketsThreshold = 1000
+
+// idleBucketsPeriod is long enough for the buckets of an identity to refill
+const idleBucketsPeriod = time.Minute
+
+// Limits bound the messages broadcast by a single client. Zero means unlimited
+type Limits struct {
+	// MaxMessageBytes is the maximum size of a message
+	MaxMessageBytes int
+	// MessagesPerSecond is the sustained rate of messages allowed to each identity
+	MessagesPerSecond float64
+	// BytesPerSecond is the sustained rate of bytes allowed to each identity
+	BytesPerSecond float64
+}
+
+// Client is the identity the messages of a broadcast stream are charged to. It is the
+// certificate the client authenticated with over TLS, or else its network address: the
+// creator of the messages is not used, as their signature is not verified at that point
+type Client struct {
+	// key identifies the client in the quota
+	key string
+	// label is the value of the client label of the rejection counter
+	label string
+}
+
+// StreamClient returns the Client of the broadcast stream whose context is ctx
+func StreamClient(ctx context.Context) Client {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Client{key: unauthenticated, label: unauthenticated}
+	}
+
+	// The chains are only verified when the orderer requests client certificates
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+		cert := tlsInfo.State.VerifiedChains[0][0]
+		digest := sha256.Sum256(cert.Raw)
+		return Client{key: fmt.Sprintf("certificate:%x", digest), label: fmt.Sprintf("%s/%x", cert.Subject.CommonName, digest[:4])}
+	}
+
+	host := p.Addr.String()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return Client{key: "address:" + host, label: unauthenticated}
+}
+
+// Quota enforces Limits on the messages of each Client. Each client may burst up
+// to one second worth of its rate
+type Quota struct {
+	limits Limits
+
+	lock    sync.Mutex
+	buckets map[string]*quotaBuckets
+	now     func() time.Time
+}
+
+type quotaBuckets struct {
+	messages tokenBucket
+	bytes    tokenBucket
+	last     time.Time
+}
+
+// NewQuota returns a Quota enforcing limits
+func NewQuota(limits Limits) *Quota {
+	return &Quota{
+		limits:  limits,
+		buckets: make(map[string]*quotaBuckets),
+		now:     time.Now,
+	}
+}
+
+// Check returns the empty string if msg is within the quota of client, which is charged
+// for the message, or the reason for which msg is rejected otherwise. A nil Quota accepts
+// all the messages
+func (q *Quota) Check(client Client, msg *cb.Envelope) (reason string) {
+	if q == nil {
+		return ""
+	}
+	size := proto.Size(msg)
+
+	if q.limits.MaxMessageBytes > 0 && size > q.limits.MaxMessageBytes {
+		return RejectedTooLarge
+	}
+	if q.limits.MessagesPerSecond <= 0 && q.limits.BytesPerSecond <= 0 {
+		return ""
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	now := q.now()
+	b := q.bucketsFor(client.key, now)
+	b.messages.refill(now)
+	b.bytes.refill(now)
+	if !b.messages.has(1) || !b.bytes.has(float64(size)) {
+		return RejectedRateLimited
+	}
+	b.messages.take(1)
+	b.bytes.take(float64(size))
+	b.last = now
+	return ""
+}
+
+// bucketsFor returns the buckets of identity, creating them full if needed.
+// It must be called with the lock held
+func (q *Quota) bucketsFor(identity string, now time.Time) *quotaBuckets {
+	b, ok := q.buckets[identity]
+	if ok {
+		return b
+	}
+
+	if len(q.buckets) >= idleBucketsThreshold {
+		// Full buckets carry no state, forget the identities idle for long enough to refill
+		for id, other := range q.buckets {
+			if now.Sub(other.last) > idleBucketsPeriod {
+				delete(q.buckets, id)
+			}
+		}
+	}
+
+	b = &quotaBuckets{
+		messages: newTokenBucket(q.limits.MessagesPerSecond, now),
+		bytes:    newTokenBucket(q.limits.BytesPerSecond, now),
+		last:     now,
+	}
+	q.buckets[identity] = b
+	return b
+}
+
+// tokenBucket holds up to rate tokens, refilled at rate tokens per second.
+// A zero rate means unlimited
+type tokenBucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) tokenBucket {
+	return tokenBucket{rate: rate, tokens: rate, last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if b.rate <= 0 {
+		return
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+	b.last = now
+}
+
+// has returns true if n tokens can be taken. A full bucket allows
+// to take more tokens than it holds, to let through messages larger
+// than the rate, and takes correspondingly longer to refill
+func (b *tokenBucket) has(n float64) bool {
+	return b.rate <= 0 || b.tokens >= n || b.tokens >= b.rate
+}
+
+func (b *tokenBucket) take(n float64) {
+	if b.rate > 0 {
+		b.tokens -= n
+	}
+}
+
+// RecordRejection counts the rejection of a message of client on chainID for reason
+func RecordRejection(chainID string, client Client, reason string) {
+	label := client.Label()
+	logger.Debugf("Rejecting broadcast message from %s on chain %s: %s", label, chainID, reason)
+	Rejections.Inc(chainID, label, reason)
+}
+
+// Label returns the value of the client label of the rejection counter for the client.
+// Only the first maxClientLabels authenticated clients have a label of their own, so
+// that the clients cannot grow the counter without bound
+func (c Client) Label() string {
+	if c.label == unauthenticated || c.label == "" {
+		return unauthenticated
+	}
+
+	clientLabels.Lock()
+	defer clientLabels.Unlock()
+	if !clientLabels.values[c.label] {
+		if len(clientLabels.values) >= maxClientLabels {
+			return otherClients
+		}
+		clientLabels.values[c.label] = true
+	}
+	return c.label
+}
+
+// RejectionStatus returns the status answered to a message rejected for reason
+func RejectionStatus(reason string) cb.Status {
+	switch reason {
+	case RejectedTooLarge, RejectedBadRequest:
+		return cb.Status_BAD_REQUEST
+	default:
+		return cb.Status_SERVICE_UNAVAILABLE
+	}
+}
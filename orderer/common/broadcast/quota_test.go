@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broadcast
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+type mockClock struct {
+	now time.Time
+}
+
+func (c *mockClock) Now() time.Time {
+	return c.now
+}
+
+func (c *mockClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestQuota(limits Limits) (*Quota, *mockClock) {
+	clock := &mockClock{now: time.Unix(0, 0)}
+	q := NewQuota(limits)
+	q.now = clock.Now
+	return q, clock
+}
+
+func testClient(name string) Client {
+	return Client{key: name, label: name}
+}
+
+func checkQuota(t *testing.T, q *Quota, client Client, msg *cb.Envelope, expected string) {
+	if reason := q.Check(client, msg); reason != expected {
+		t.Fatalf("Expected rejection reason %q, got %q", expected, reason)
+	}
+}
+
+func TestQuotaMessagesPerSecond(t *testing.T) {
+	q, clock := newTestQuota(Limits{MessagesPerSecond: 2})
+	alice := testClient("alice")
+	msg := envelopeFrom("alice", []byte("Some bytes"))
+
+	// Burst of one second worth of messages
+	checkQuota(t, q, alice, msg, "")
+	checkQuota(t, q, alice, msg, "")
+	checkQuota(t, q, alice, msg, RejectedRateLimited)
+
+	// Refilled at the rate
+	clock.advance(500 * time.Millisecond)
+	checkQuota(t, q, alice, msg, "")
+	checkQuota(t, q, alice, msg, RejectedRateLimited)
+
+	// Never above the burst
+	clock.advance(time.Hour)
+	checkQuota(t, q, alice, msg, "")
+	checkQuota(t, q, alice, msg, "")
+	checkQuota(t, q, alice, msg, RejectedRateLimited)
+
+	// The messages are charged to the client whatever their creator
+	bob := testClient("bob")
+	checkQuota(t, q, alice, envelopeFrom("bob", []byte("Some bytes")), RejectedRateLimited)
+	checkQuota(t, q, bob, msg, "")
+	checkQuota(t, q, bob, msg, "")
+	checkQuota(t, q, bob, msg, RejectedRateLimited)
+}
+
+func TestQuotaBytesPerSecond(t *testing.T) {
+	alice := testClient("alice")
+	small := envelopeFrom("alice", make([]byte, 40))
+	large := envelopeFrom("alice", make([]byte, 300))
+	// Room for two and a half small messages per second
+	rate := 2.5 * float64(proto.Size(small))
+	q, clock := newTestQuota(Limits{BytesPerSecond: rate})
+	seconds := func(bytes float64) time.Duration {
+		return time.Duration(bytes / rate * float64(time.Second))
+	}
+
+	checkQuota(t, q, alice, small, "")
+	checkQuota(t, q, alice, small, "")
+	checkQuota(t, q, alice, small, RejectedRateLimited)
+
+	// A full bucket lets through a message larger than the rate, and then takes longer to refill
+	clock.advance(time.Second)
+	checkQuota(t, q, alice, large, "")
+	clock.advance(seconds(float64(proto.Size(large)) - rate))
+	checkQuota(t, q, alice, small, RejectedRateLimited)
+	clock.advance(seconds(float64(proto.Size(small))) + time.Millisecond)
+	checkQuota(t, q, alice, small, "")
+}
+
+func TestQuotaMaxMessageBytes(t *testing.T) {
+	q, _ := newTestQuota(Limits{MaxMessageBytes: 100})
+	alice := testClient("alice")
+	checkQuota(t, q, alice, envelopeFrom("alice", make([]byte, 50)), "")
+	checkQuota(t, q, alice, envelopeFrom("alice", make([]byte, 200)), RejectedTooLarge)
+
+	// Without rate limits, any number of messages is accepted
+	for i := 0; i < 100; i++ {
+		checkQuota(t, q, alice, envelopeFrom("alice", nil), "")
+	}
+}
+
+func TestNilQuota(t *testing.T) {
+	var q *Quota
+	if reason := q.Check(testClient("alice"), envelopeFrom("alice", make([]byte, 1<<20))); reason != "" {
+		t.Fatalf("A nil quota should accept all messages, got %q", reason)
+	}
+}
+
+func TestQuotaForgetsIdleClients(t *testing.T) {
+	q, clock := newTestQuota(Limits{MessagesPerSecond: 1})
+	for i := 0; i < idleBucketsThreshold; i++ {
+		checkQuota(t, q, testClient(strings.Repeat("x", i+1)), envelopeFrom("alice", nil), "")
+	}
+
+	clock.advance(2 * idleBucketsPeriod)
+	checkQuota(t, q, testClient("alice"), envelopeFrom("alice", nil), "")
+	if len(q.buckets) != 1 {
+		t.Fatalf("Expected the idle clients to be forgotten, %d are tracked", len(q.buckets))
+	}
+}
+
+func TestRejectionStatus(t *testing.T) {
+	for reason, status := range map[string]cb.Status{
+		RejectedTooLarge:    cb.Status_BAD_REQUEST,
+		RejectedBadRequest:  cb.Status_BAD_REQUEST,
+		RejectedRateLimited: cb.Status_SERVICE_UNAVAILABLE,
+		RejectedQueueFull:   cb.Status_SERVICE_UNAVAILABLE,
+	} {
+		if RejectionStatus(reason) != status {
+			t.Errorf("Expected status %v for %s, got %v", status, reason, RejectionStatus(reason))
+		}
+	}
+}
+
+func TestStreamClient(t *testing.T) {
+	alice := &x509.Certificate{Raw: []byte("alice's certificate"), Subject: pkix.Name{CommonName: "alice"}}
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}
+	otherPort := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5678}
+	verified := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{alice}, VerifiedChains: [][]*x509.Certificate{{alice}}}}
+	unverified := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{alice}}}
+
+	authenticated := StreamClient(peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: verified}))
+	if !strings.HasPrefix(authenticated.Label(), "alice/") {
+		t.Fatalf("Expected the client to be labelled by its certificate, got %s", authenticated.Label())
+	}
+
+	// A certificate which was not verified does not authenticate the client, which is identified by its address
+	byAddress := StreamClient(peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: unverified}))
+	if byAddress.key == authenticated.key || byAddress.Label() != unauthenticated {
+		t.Fatalf("Expected the client with an unverified certificate to be unauthenticated, got %s", byAddress.Label())
+	}
+	if StreamClient(peer.NewContext(context.Background(), &peer.Peer{Addr: otherPort})) != byAddress {
+		t.Fatalf("Expected the streams from the same host to be the same client")
+	}
+	if StreamClient(context.Background()).Label() != unauthenticated {
+		t.Fatalf("Expected a stream without peer to be unauthenticated")
+	}
+}
+
+func TestClientLabelsBounded(t *testing.T) {
+	clientLabels.Lock()
+	clientLabels.values = make(map[string]bool)
+	clientLabels.Unlock()
+
+	for i := 0; i < maxClientLabels; i++ {
+		client := testClient(fmt.Sprintf("client%d", i))
+		if client.Label() != client.label {
+			t.Fatalf("Expected client %d to have its own label, got %s", i, client.Label())
+		}
+	}
+	if label := testClient("one too many").Label(); label != otherClients {
+		t.Fatalf("Expected the clients beyond %d to share a label, got %s", maxClientLabels, label)
+	}
+	if label := testClient("client0").Label(); label != "client0" {
+		t.Fatalf("Expected a client to keep its label, got %s", label)
+	}
+	if label := (Client{key: "address:10.0.0.1", label: unauthenticated}).Label(); label != unauthenticated {
+		t.Fatalf("Expected the unauthenticated clients to share a label, got %s", label)
+	}
+}
@@ -28,9 +28,220 @@ type CryptoHelper interface {
 	VerifySignature(msg []byte, id []byte, signature []byte) bool
 }
 
+// PrincipalHelper may be implemented by a CryptoHelper to match ids against principals which are not
+// an exact identity, such as the members or admins of an MSP, policies with such principals may only be
+// evaluated by a CryptoHelper which is also a PrincipalHelper
+type PrincipalHelper interface {
+	SatisfiesPrincipal(id []byte, principal *cb.MSPPrincipal) bool
+}
+
+// SignerHelper may be implemented by a CryptoHelper whose ids have several serializations, such as msp
+// identities serialized with or without their intermediate CAs, it returns the same signer for all the
+// serializations of an id, so that they count as one signer and match the same identity principals
+type SignerHelper interface {
+	Signer(id []byte) []byte
+}
+
+// signer returns the signer of id according to ch, id itself unless ch is a SignerHelper
+func signer(ch CryptoHelper, id []byte) []byte {
+	if sh, ok := ch.(SignerHelper); ok {
+		return sh.Signer(id)
+	}
+	return id
+}
+
 // SignaturePolicyEvaluator is useful for a chain Reader to stream blocks as they are created
 type SignaturePolicyEvaluator struct {
-	compiledAuthenticator func([]byte, [][]byte, [][]byte) bool
+	policy     *node
+	principals []func([]byte) bool
+	ch         CryptoHelper
+}
+
+// node is a compiled policy, a leaf satisfied by a signer of its principal, given by its index, or else
+// satisfied when n of its children are, no signer satisfying more than one leaf
+type node struct {
+	principal int
+	n         int
+	children  []*node
+}
+
+// isLeaf returns whether the node is satisfied by a signer of its principal
+func (n *node) isLeaf() bool {
+	return n.principal >= 0
+}
+
+// evaluation is the state of the evaluation of a policy against a set of signatures, the match of each
+// principal against each signer and the verification of each signature are computed at most once
+type evaluation struct {
+	msg        []byte
+	ids        [][]byte
+	signatures [][]byte
+	principals []func([]byte) bool
+	ch         CryptoHelper
+
+	// signers holds the index of the first occurrence of each distinct signer, so that an id present twice,
+	// or serialized twice differently, counts as one signer, the signatures of all its occurrences being tried
+	signers    []int
+	occurrence [][]int
+
+	// verified caches the verification of the signature of each signer, 0 if not verified yet, 1 if valid, -1 if invalid
+	verified []int8
+	// matched caches whether each signer matches each principal, with the same values as verified
+	matched [][]int8
+
+	// slots holds the principal of each leaf satisfied so far, and assigned the slot each signer
+	// is assigned to, -1 if none
+	slots    []int
+	assigned []int
+}
+
+func newEvaluation(msg []byte, ids [][]byte, signatures [][]byte, principals []func([]byte) bool, ch CryptoHelper) *evaluation {
+	e := &evaluation{
+		msg:        msg,
+		ids:        ids,
+		signatures: signatures,
+		principals: principals,
+		ch:         ch,
+		matched:    make([][]int8, len(principals)),
+	}
+	signerIDs := make([][]byte, 0, len(ids))
+	for i, id := range ids {
+		signerID := signer(ch, id)
+		s := 0
+		for s < len(signerIDs) && !bytes.Equal(signerID, signerIDs[s]) {
+			s++
+		}
+		if s == len(signerIDs) {
+			signerIDs = append(signerIDs, signerID)
+			e.signers = append(e.signers, i)
+			e.occurrence = append(e.occurrence, nil)
+		}
+		e.occurrence[s] = append(e.occurrence[s], i)
+	}
+	e.verified = make([]int8, len(e.signers))
+	for p := range e.matched {
+		e.matched[p] = make([]int8, len(e.signers))
+	}
+	e.assigned = make([]int, len(e.signers))
+	for s := range e.assigned {
+		e.assigned[s] = -1
+	}
+	return e
+}
+
+func (e *evaluation) verify(signer int) bool {
+	if e.verified[signer] == 0 {
+		e.verified[signer] = -1
+		for _, i := range e.occurrence[signer] {
+			if i < len(e.signatures) && e.ch.VerifySignature(e.msg, e.ids[i], e.signatures[i]) {
+				e.verified[signer] = 1
+				break
+			}
+		}
+	}
+	return e.verified[signer] == 1
+}
+
+// satisfies returns whether the signer matches the principal and its signature is valid
+func (e *evaluation) satisfies(principal int, signer int) bool {
+	if e.matched[principal][signer] == 0 {
+		e.matched[principal][signer] = -1
+		if e.principals[principal](e.ids[e.signers[signer]]) {
+			e.matched[principal][signer] = 1
+		}
+	}
+	return e.matched[principal][signer] == 1 && e.verify(signer)
+}
+
+// augment assigns a signer to a new slot of the principal, reassigning the signers of the other slots along
+// an augmenting path of the bipartite matching between the slots and the signers. The assignment is left
+// unchanged if there is no such path
+func (e *evaluation) augment(principal int) bool {
+	slot := len(e.slots)
+	e.slots = append(e.slots, principal)
+
+	var path func(slot int, visited []bool) bool
+	path = func(slot int, visited []bool) bool {
+		for s := range e.signers {
+			if visited[s] || !e.satisfies(e.slots[slot], s) {
+				continue
+			}
+			visited[s] = true
+			if e.assigned[s] < 0 || path(e.assigned[s], visited) {
+				e.assigned[s] = slot
+				return true
+			}
+		}
+		return false
+	}
+
+	if path(slot, make([]bool, len(e.signers))) {
+		return true
+	}
+	e.slots = e.slots[:slot]
+	return false
+}
+
+// save returns a function restoring the current assignment
+func (e *evaluation) save() func() {
+	slots := len(e.slots)
+	assigned := append([]int(nil), e.assigned...)
+	return func() {
+		e.slots = e.slots[:slots]
+		copy(e.assigned, assigned)
+	}
+}
+
+// satisfy returns whether the node is satisfied by the signers along with the leaves already satisfied,
+// and then cont returns true, the assignment is restored otherwise. Whether leaves can all be assigned
+// distinct signers only depends on the leaves, not on the order they are assigned in, so the evaluation
+// only backtracks over the children chosen to satisfy each node
+func (e *evaluation) satisfy(n *node, cont func() bool) bool {
+	restore := e.save()
+	if n.isLeaf() {
+		if e.augment(n.principal) && cont() {
+			return true
+		}
+		restore()
+		return false
+	}
+
+	var choose func(i int, remaining int) bool
+	choose = func(i int, remaining int) bool {
+		if remaining <= 0 {
+			return cont()
+		}
+		if e.bound(n.children[i:]) < remaining {
+			return false
+		}
+		return e.satisfy(n.children[i], func() bool { return choose(i+1, remaining-1) }) || choose(i+1, remaining)
+	}
+	if choose(0, n.n) {
+		return true
+	}
+	restore()
+	return false
+}
+
+// bound returns an upper bound of the number of the children which can be satisfied together along with the
+// leaves already satisfied, the number of leaves which can be assigned signers at once plus the number of
+// other children which can each be satisfied
+func (e *evaluation) bound(children []*node) int {
+	count := 0
+	restore := e.save()
+	for _, child := range children {
+		if child.isLeaf() && e.augment(child.principal) {
+			count++
+		}
+	}
+	restore()
+	for _, child := range children {
+		if !child.isLeaf() && e.satisfy(child, func() bool { return true }) {
+			restore()
+			count++
+		}
+	}
+	return count
 }
 
 // NewSignaturePolicyEvaluator evaluates a protbuf SignaturePolicy to produce a 'compiled' version which can be invoked in code
@@ -39,58 +250,84 @@ func NewSignaturePolicyEvaluator(policy *cb.SignaturePolicyEnvelope, ch CryptoHe
 		return nil, fmt.Errorf("This evaluator only understands messages of version 0, but version was %d", policy.Version)
 	}
 
-	compiled, err := compile(policy.Policy, policy.Identities, ch)
+	principals, err := compilePrincipals(policy, ch)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled, err := compile(policy.Policy, len(principals))
 	if err != nil {
 		return nil, err
 	}
 
 	return &SignaturePolicyEvaluator{
-		compiledAuthenticator: compiled,
+		policy:     compiled,
+		principals: principals,
+		ch:         ch,
 	}, nil
 }
 
-// compile recursively builds a go evaluatable function corresponding to the policy specified
-func compile(policy *cb.SignaturePolicy, identities [][]byte, ch CryptoHelper) (func([]byte, [][]byte, [][]byte) bool, error) {
+// compilePrincipals builds, for each principal of the policy, a function matching the ids of that principal
+// when the policy has no principals, each of its identities is the principal of exactly that identity
+func compilePrincipals(policy *cb.SignaturePolicyEnvelope, ch CryptoHelper) ([]func([]byte) bool, error) {
+	principals := policy.Principals
+	if len(principals) == 0 {
+		principals = make([]*cb.MSPPrincipal, len(policy.Identities))
+		for i, identity := range policy.Identities {
+			principals[i] = IdentityPrincipal(identity)
+		}
+	}
+
+	matchers := make([]func([]byte) bool, len(principals))
+	for i, principal := range principals {
+		principal := principal
+		if principal.PrincipalClassification == cb.MSPPrincipal_ByIdentity {
+			matchers[i] = func(id []byte) bool {
+				return bytes.Equal(signer(ch, id), signer(ch, principal.Principal))
+			}
+			continue
+		}
+
+		ph, ok := ch.(PrincipalHelper)
+		if !ok {
+			return nil, fmt.Errorf("Principal %d of classification %v cannot be evaluated by a %T", i, principal.PrincipalClassification, ch)
+		}
+		matchers[i] = func(id []byte) bool {
+			return ph.SatisfiesPrincipal(id, principal)
+		}
+	}
+	return matchers, nil
+}
+
+// compile recursively builds the tree of the policy specified
+func compile(policy *cb.SignaturePolicy, principals int) (*node, error) {
+	if policy == nil {
+		return nil, fmt.Errorf("Empty policy element")
+	}
+
 	switch t := policy.Type.(type) {
 	case *cb.SignaturePolicy_From:
-		policies := make([]func([]byte, [][]byte, [][]byte) bool, len(t.From.Policies))
-		for i, policy := range t.From.Policies {
-			compiledPolicy, err := compile(policy, identities, ch)
+		compiled := &node{principal: -1, n: int(t.From.N)}
+		for _, policy := range t.From.Policies {
+			child, err := compile(policy, principals)
 			if err != nil {
 				return nil, err
 			}
-			policies[i] = compiledPolicy
-
+			compiled.children = append(compiled.children, child)
 		}
-		return func(msg []byte, ids [][]byte, signatures [][]byte) bool {
-			verified := int32(0)
-			for _, policy := range policies {
-				if policy(msg, ids, signatures) {
-					verified++
-				}
-			}
-			return verified >= t.From.N
-		}, nil
+		return compiled, nil
 	case *cb.SignaturePolicy_SignedBy:
-		if t.SignedBy < 0 || t.SignedBy >= int32(len(identities)) {
-			return nil, fmt.Errorf("Identity index out of range, requested %d, but identies length is %d", t.SignedBy, len(identities))
-		}
-		signedByID := identities[t.SignedBy]
-		return func(msg []byte, ids [][]byte, signatures [][]byte) bool {
-			for i, id := range ids {
-				if bytes.Equal(id, signedByID) {
-					return ch.VerifySignature(msg, id, signatures[i])
-				}
-			}
-			return false
-		}, nil
+		if t.SignedBy < 0 || t.SignedBy >= int32(principals) {
+			return nil, fmt.Errorf("Principal index out of range, requested %d, but principals length is %d", t.SignedBy, principals)
+		}
+		return &node{principal: int(t.SignedBy)}, nil
 	default:
 		return nil, fmt.Errorf("Unknown type: %T:%v", t, t)
 	}
-
 }
 
-// Authenticate returns nil if the authentication policy is satisfied, or an error indicating why the authentication failed
+// Authenticate returns true if the signatures satisfy the policy, no signer satisfying more than one of its principals
 func (ape *SignaturePolicyEvaluator) Authenticate(msg []byte, ids [][]byte, signatures [][]byte) bool {
-	return ape.compiledAuthenticator(msg, ids, signatures)
+	e := newEvaluation(msg, ids, signatures, ape.principals, ape.ch)
+	return e.satisfy(ape.policy, func() bool { return true })
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package cauthdsl
 
 import (
+	"github.com/hyperledger/fabric/orderer/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 
 	"github.com/golang/protobuf/proto"
@@ -59,7 +60,40 @@ func Envelope(policy *cb.SignaturePolicy, identities [][]byte) *cb.SignaturePoli
 	}
 }
 
-// SignedBy creates a SignaturePolicy requiring a given signer's signature
+// EnvelopeWithPrincipals builds an envelope message embedding a SignaturePolicy whose signers are principals
+func EnvelopeWithPrincipals(policy *cb.SignaturePolicy, principals []*cb.MSPPrincipal) *cb.SignaturePolicyEnvelope {
+	return &cb.SignaturePolicyEnvelope{
+		Version:    0,
+		Policy:     policy,
+		Principals: principals,
+	}
+}
+
+// IdentityPrincipal creates a principal matching exactly the given serialized identity
+func IdentityPrincipal(identity []byte) *cb.MSPPrincipal {
+	return &cb.MSPPrincipal{
+		PrincipalClassification: cb.MSPPrincipal_ByIdentity,
+		Principal:               identity,
+	}
+}
+
+// MSPRolePrincipal creates a principal matching the identities of the given MSP having the given role
+func MSPRolePrincipal(mspID string, role cb.MSPRole_MSPRoleType) *cb.MSPPrincipal {
+	return &cb.MSPPrincipal{
+		PrincipalClassification: cb.MSPPrincipal_ByMSPRole,
+		Principal:               util.MarshalOrPanic(&cb.MSPRole{MSPIdentifier: mspID, Role: role}),
+	}
+}
+
+// OrganizationUnitPrincipal creates a principal matching the identities of the given MSP in the given organizational unit
+func OrganizationUnitPrincipal(mspID string, ou string) *cb.MSPPrincipal {
+	return &cb.MSPPrincipal{
+		PrincipalClassification: cb.MSPPrincipal_ByOrganizationUnit,
+		Principal:               util.MarshalOrPanic(&cb.OrganizationUnit{MSPIdentifier: mspID, OrganizationalUnitIdentifier: ou}),
+	}
+}
+
+// SignedBy creates a SignaturePolicy requiring the signature of a given principal, or identity if the envelope has no principals
 func SignedBy(index int32) *cb.SignaturePolicy {
 	return &cb.SignaturePolicy{
 		Type: &cb.SignaturePolicy_SignedBy{
@@ -89,3 +123,8 @@ func NOutOf(n int32, policies []*cb.SignaturePolicy) *cb.SignaturePolicy {
 		},
 	}
 }
+
+// SignedByMSPMember creates a policy envelope which requires the signature of a member of the given MSP
+func SignedByMSPMember(mspID string) *cb.SignaturePolicyEnvelope {
+	return EnvelopeWithPrincipals(SignedBy(0), []*cb.MSPPrincipal{MSPRolePrincipal(mspID, cb.MSPRole_Member)})
+}
@@ -18,7 +18,10 @@ package cauthdsl
 
 import (
 	"bytes"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -35,6 +38,36 @@ func (mch *mockCryptoHelper) VerifySignature(msg []byte, id []byte, signature []
 	return bytes.Equal(signature, validSignature)
 }
 
+// SatisfiesPrincipal treats ids as "MSP:role:OU"
+func (mch *mockCryptoHelper) SatisfiesPrincipal(id []byte, principal *cb.MSPPrincipal) bool {
+	fields := strings.Split(string(id), ":")
+	switch principal.PrincipalClassification {
+	case cb.MSPPrincipal_ByIdentity:
+		return bytes.Equal(id, principal.Principal)
+	case cb.MSPPrincipal_ByMSPRole:
+		role := &cb.MSPRole{}
+		if len(fields) != 3 || proto.Unmarshal(principal.Principal, role) != nil {
+			return false
+		}
+		return fields[0] == role.MSPIdentifier && (role.Role == cb.MSPRole_Member || fields[1] == "admin")
+	case cb.MSPPrincipal_ByOrganizationUnit:
+		ou := &cb.OrganizationUnit{}
+		if len(fields) != 3 || proto.Unmarshal(principal.Principal, ou) != nil {
+			return false
+		}
+		return fields[0] == ou.MSPIdentifier && fields[2] == ou.OrganizationalUnitIdentifier
+	default:
+		return false
+	}
+}
+
+type identityCryptoHelper struct {
+}
+
+func (ich *identityCryptoHelper) VerifySignature(msg []byte, id []byte, signature []byte) bool {
+	return bytes.Equal(signature, validSignature)
+}
+
 func TestSimpleSignature(t *testing.T) {
 	mch := &mockCryptoHelper{}
 	policy := Envelope(SignedBy(0), signers)
@@ -77,7 +110,7 @@ func TestMultipleSignature(t *testing.T) {
 
 func TestComplexNestedSignature(t *testing.T) {
 	mch := &mockCryptoHelper{}
-	policy := Envelope(And(Or(And(SignedBy(0), SignedBy(1)), And(SignedBy(0), SignedBy(0))), SignedBy(0)), signers)
+	policy := Envelope(And(Or(And(SignedBy(0), SignedBy(1)), SignedBy(1)), Or(SignedBy(0), SignedBy(2))), append(signers, []byte("signer2")))
 
 	spe, err := NewSignaturePolicyEvaluator(policy, mch)
 	if err != nil {
@@ -90,8 +123,164 @@ func TestComplexNestedSignature(t *testing.T) {
 	if spe.Authenticate(nil, signers, [][]byte{invalidSignature, validSignature}) {
 		t.Errorf("Expected authentication failure as only the signature of signer[1] was valid")
 	}
-	if !spe.Authenticate(nil, [][]byte{signers[0], signers[0]}, [][]byte{validSignature, validSignature}) {
-		t.Errorf("Expected authentication to succeed because the rule allows duplicated signatures for signer[0]")
+	if spe.Authenticate(nil, [][]byte{signers[0], signers[0]}, [][]byte{validSignature, validSignature}) {
+		t.Errorf("Expected authentication to fail because signer[0] may not satisfy the policy twice")
+	}
+}
+
+func TestSignerNotReused(t *testing.T) {
+	mch := &mockCryptoHelper{}
+	policy := Envelope(And(Or(SignedBy(0), SignedBy(1)), SignedBy(0)), signers)
+
+	spe, err := NewSignaturePolicyEvaluator(policy, mch)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+
+	if !spe.Authenticate(nil, signers, [][]byte{validSignature, validSignature}) {
+		t.Errorf("Expected authentication to succeed by using signer[1] for the first clause and signer[0] for the second")
+	}
+	if spe.Authenticate(nil, [][]byte{signers[0]}, [][]byte{validSignature}) {
+		t.Errorf("Expected authentication to fail because signer[0] may not satisfy both clauses")
+	}
+}
+
+func TestPrincipals(t *testing.T) {
+	mch := &mockCryptoHelper{}
+	policy := EnvelopeWithPrincipals(And(SignedBy(0), Or(SignedBy(1), SignedBy(2))), []*cb.MSPPrincipal{
+		MSPRolePrincipal("Org1", cb.MSPRole_Admin),
+		MSPRolePrincipal("Org1", cb.MSPRole_Member),
+		OrganizationUnitPrincipal("Org2", "Auditors"),
+	})
+
+	spe, err := NewSignaturePolicyEvaluator(policy, mch)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+
+	admin := []byte("Org1:admin:Sales")
+	member := []byte("Org1:member:Sales")
+	auditor := []byte("Org2:member:Auditors")
+
+	if !spe.Authenticate(nil, [][]byte{admin, member}, [][]byte{validSignature, validSignature}) {
+		t.Errorf("Expected authentication to succeed with an admin and a member of Org1")
+	}
+	if !spe.Authenticate(nil, [][]byte{auditor, admin}, [][]byte{validSignature, validSignature}) {
+		t.Errorf("Expected authentication to succeed with an admin of Org1 and an auditor of Org2")
+	}
+	if spe.Authenticate(nil, [][]byte{admin}, [][]byte{validSignature}) {
+		t.Errorf("Expected authentication to fail because the admin may not also satisfy the member principal")
+	}
+	if spe.Authenticate(nil, [][]byte{member, auditor}, [][]byte{validSignature, validSignature}) {
+		t.Errorf("Expected authentication to fail without an admin of Org1")
+	}
+	if spe.Authenticate(nil, [][]byte{admin, member}, [][]byte{validSignature, invalidSignature}) {
+		t.Errorf("Expected authentication to fail given the invalid signature of the member")
+	}
+}
+
+// countingCryptoHelper counts the calls made to the mockCryptoHelper it wraps
+type countingCryptoHelper struct {
+	mockCryptoHelper
+	verifications int
+	matches       int
+}
+
+func (cch *countingCryptoHelper) VerifySignature(msg []byte, id []byte, signature []byte) bool {
+	cch.verifications++
+	return cch.mockCryptoHelper.VerifySignature(msg, id, signature)
+}
+
+func (cch *countingCryptoHelper) SatisfiesPrincipal(id []byte, principal *cb.MSPPrincipal) bool {
+	cch.matches++
+	return cch.mockCryptoHelper.SatisfiesPrincipal(id, principal)
+}
+
+func TestManySignersEvaluatedOnce(t *testing.T) {
+	cch := &countingCryptoHelper{}
+	principals := []*cb.MSPPrincipal{MSPRolePrincipal("Org1", cb.MSPRole_Member), MSPRolePrincipal("Org2", cb.MSPRole_Member)}
+	policy := EnvelopeWithPrincipals(NOutOf(4, []*cb.SignaturePolicy{SignedBy(0), SignedBy(0), SignedBy(0), SignedBy(0), SignedBy(1)}), principals)
+
+	spe, err := NewSignaturePolicyEvaluator(policy, cch)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+
+	// Only three members of Org1 sign, which would have the policy tried with every ordering of the signers
+	var ids, signatures [][]byte
+	for i := 0; i < 64; i++ {
+		org := "Org3"
+		if i < 3 {
+			org = "Org1"
+		}
+		ids = append(ids, []byte(fmt.Sprintf("%s:member:signer%d", org, i)))
+		signatures = append(signatures, validSignature)
+	}
+	if spe.Authenticate(nil, ids, signatures) {
+		t.Fatalf("Expected authentication to fail with three members of Org1 and none of Org2")
+	}
+	if cch.matches > len(principals)*len(ids) {
+		t.Errorf("Expected each principal to be matched at most once against each signer, got %d matches", cch.matches)
+	}
+	if cch.verifications > len(ids) {
+		t.Errorf("Expected each signature to be verified at most once, got %d verifications", cch.verifications)
+	}
+
+	ids[63] = []byte("Org2:member:signer63")
+	if !spe.Authenticate(nil, ids, signatures) {
+		t.Errorf("Expected authentication to succeed with three members of Org1 and one of Org2")
+	}
+}
+
+func TestPrincipalsRequirePrincipalHelper(t *testing.T) {
+	policy := EnvelopeWithPrincipals(SignedBy(0), []*cb.MSPPrincipal{MSPRolePrincipal("Org1", cb.MSPRole_Member)})
+	_, err := NewSignaturePolicyEvaluator(policy, &identityCryptoHelper{})
+	if err == nil {
+		t.Fatalf("Should have errored compiling because the crypto-helper cannot match MSP roles")
+	}
+
+	policy = EnvelopeWithPrincipals(SignedBy(0), []*cb.MSPPrincipal{IdentityPrincipal(signers[0])})
+	spe, err := NewSignaturePolicyEvaluator(policy, &identityCryptoHelper{})
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+	if !spe.Authenticate(nil, [][]byte{signers[0]}, [][]byte{validSignature}) {
+		t.Errorf("Expected authentication to succeed with the identity of the principal")
+	}
+}
+
+// serializingCryptoHelper treats ids as "signer/serialization", a signer having several serializations
+type serializingCryptoHelper struct {
+	identityCryptoHelper
+}
+
+func (sch *serializingCryptoHelper) Signer(id []byte) []byte {
+	return bytes.SplitN(id, []byte("/"), 2)[0]
+}
+
+func TestSignerSerializations(t *testing.T) {
+	sch := &serializingCryptoHelper{}
+	policy := Envelope(And(SignedBy(0), SignedBy(0)), [][]byte{[]byte("signer0/a")})
+
+	spe, err := NewSignaturePolicyEvaluator(policy, sch)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+	ids := [][]byte{[]byte("signer0/a"), []byte("signer0/b")}
+	if spe.Authenticate(nil, ids, [][]byte{validSignature, validSignature}) {
+		t.Errorf("Expected authentication to fail because both serializations are of the same signer")
+	}
+
+	policy = Envelope(SignedBy(0), [][]byte{[]byte("signer0/a")})
+	spe, err = NewSignaturePolicyEvaluator(policy, sch)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+	if !spe.Authenticate(nil, [][]byte{[]byte("signer0/b")}, [][]byte{validSignature}) {
+		t.Errorf("Expected authentication to succeed with another serialization of the signer of the principal")
+	}
+	if spe.Authenticate(nil, [][]byte{[]byte("signer1/a")}, [][]byte{validSignature}) {
+		t.Errorf("Expected authentication to fail with another signer")
 	}
 }
 
@@ -107,3 +296,104 @@ func TestNegatively(t *testing.T) {
 		t.Fatalf("Should have errored compiling because the Type field was nil")
 	}
 }
+
+func TestLargeThresholds(t *testing.T) {
+	mch := &mockCryptoHelper{}
+	var ids [][]byte
+	var policies []*cb.SignaturePolicy
+	for i := 0; i < 24; i++ {
+		ids = append(ids, []byte(fmt.Sprintf("signer%d", i)))
+		policies = append(policies, SignedBy(int32(i)))
+	}
+
+	// 12 out of 24 has C(24, 12) combinations of signers, which are not enumerated
+	spe, err := NewSignaturePolicyEvaluator(Envelope(NOutOf(12, policies), ids), mch)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+	start := time.Now()
+	signatures := make([][]byte, len(ids))
+	for i := 0; i < 11; i++ {
+		signatures[2*i] = validSignature
+	}
+	if spe.Authenticate(nil, ids, signatures) {
+		t.Errorf("Expected authentication to fail with 11 signers")
+	}
+	signatures[23] = validSignature
+	if !spe.Authenticate(nil, ids, signatures) {
+		t.Errorf("Expected authentication to succeed with 12 signers")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Evaluating the policy took %s", elapsed)
+	}
+
+	// sub-policies which cannot be satisfied do not count, nor slow the evaluation down
+	unsatisfiable := NOutOf(2, []*cb.SignaturePolicy{SignedBy(0)})
+	for i := 0; i < 20; i++ {
+		policies = append(policies, unsatisfiable)
+	}
+	spe, err = NewSignaturePolicyEvaluator(Envelope(NOutOf(25, policies), ids), mch)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+	start = time.Now()
+	if spe.Authenticate(nil, ids, make([][]byte, len(ids))) {
+		t.Errorf("Expected authentication of an unsatisfiable policy to fail")
+	}
+	for i := range signatures {
+		signatures[i] = validSignature
+	}
+	if spe.Authenticate(nil, ids, signatures) {
+		t.Errorf("Expected authentication of an unsatisfiable policy to fail with all the signers")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Evaluating the policy took %s", elapsed)
+	}
+}
+
+func TestMajorityOfOrganizations(t *testing.T) {
+	mch := &mockCryptoHelper{}
+	var principals []*cb.MSPPrincipal
+	var policies []*cb.SignaturePolicy
+	for i := 0; i < 15; i++ {
+		principals = append(principals, MSPRolePrincipal(fmt.Sprintf("Org%d", i), cb.MSPRole_Member))
+		policies = append(policies, SignedBy(int32(i)))
+	}
+	spe, err := NewSignaturePolicyEvaluator(EnvelopeWithPrincipals(NOutOf(10, policies), principals), mch)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+
+	var ids, signatures [][]byte
+	for i := 0; i < 9; i++ {
+		ids = append(ids, []byte(fmt.Sprintf("Org%d:member:signer", i)))
+		signatures = append(signatures, validSignature)
+	}
+	if spe.Authenticate(nil, ids, signatures) {
+		t.Errorf("Expected authentication to fail with 9 organizations out of 15")
+	}
+	ids = append(ids, []byte("Org14:member:signer"))
+	signatures = append(signatures, validSignature)
+	if !spe.Authenticate(nil, ids, signatures) {
+		t.Errorf("Expected authentication to succeed with 10 organizations out of 15")
+	}
+}
+
+func TestChoiceOfSubPolicies(t *testing.T) {
+	mch := &mockCryptoHelper{}
+	principals := []*cb.MSPPrincipal{MSPRolePrincipal("Org1", cb.MSPRole_Member), MSPRolePrincipal("Org2", cb.MSPRole_Member), MSPRolePrincipal("Org1", cb.MSPRole_Admin)}
+	// the admin of Org1 satisfies both the first and the last principals, the member of Org2 must
+	// satisfy the first sub-policy for the admin to satisfy the last one
+	policy := EnvelopeWithPrincipals(And(Or(SignedBy(0), SignedBy(1)), SignedBy(2)), principals)
+	spe, err := NewSignaturePolicyEvaluator(policy, mch)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+	ids := [][]byte{[]byte("Org1:admin:signer0"), []byte("Org2:member:signer1")}
+	if !spe.Authenticate(nil, ids, [][]byte{validSignature, validSignature}) {
+		t.Errorf("Expected authentication to succeed with the member of Org2 satisfying the first sub-policy")
+	}
+	if spe.Authenticate(nil, ids[:1], [][]byte{validSignature}) {
+		t.Errorf("Expected authentication to fail with the admin of Org1 alone")
+	}
+}
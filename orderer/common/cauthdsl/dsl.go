@@ -0,0 +1,278 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cauthdsl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// The policy language expresses a SignaturePolicyEnvelope whose principals are MSP roles or organizational units:
+//
+//   policy    := principal | OutOf(n, policy, ...) | AND(policy, ...) | OR(policy, ...)
+//   principal := 'MSP.member' | 'MSP.admin' | 'MSP.ou:OU'
+//
+// where AND requires all of its policies and OR one of them, for instance
+//
+//   OutOf(2, 'Org1.member', 'Org2.admin', AND('Org3.member', 'Org3.ou:Auditors'))
+//
+// Principals may be quoted with single or double quotes and keywords are case insensitive.
+
+const (
+	memberSuffix = ".member"
+	adminSuffix  = ".admin"
+	ouSeparator  = ".ou:"
+)
+
+type parser struct {
+	input      string
+	pos        int
+	principals []*cb.MSPPrincipal
+}
+
+// FromString parses a policy expressed in the policy language into a SignaturePolicyEnvelope
+func FromString(policy string) (*cb.SignaturePolicyEnvelope, error) {
+	p := &parser{input: policy}
+	sp, err := p.parsePolicy()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return nil, p.errorf("unexpected trailing input %q", p.input[p.pos:])
+	}
+	return EnvelopeWithPrincipals(sp, p.principals), nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("Invalid policy at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// next returns the next non space character, or 0 at the end of the input
+func (p *parser) next() byte {
+	p.skipSpaces()
+	if p.pos == len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) expect(c byte) error {
+	if p.next() != c {
+		return p.errorf("expected '%c'", c)
+	}
+	p.pos++
+	return nil
+}
+
+// token reads a keyword or a number
+func (p *parser) token() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) parsePolicy() (*cb.SignaturePolicy, error) {
+	if c := p.next(); c == '\'' || c == '"' {
+		return p.parsePrincipal()
+	}
+
+	keyword := p.token()
+	if keyword == "" {
+		return nil, p.errorf("expected a principal, OutOf, AND or OR")
+	}
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	var n int32
+	all := false
+	switch strings.ToLower(keyword) {
+	case "outof":
+		number := p.token()
+		value, err := strconv.ParseInt(number, 10, 32)
+		if err != nil {
+			return nil, p.errorf("expected a number, got %q", number)
+		}
+		n = int32(value)
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+	case "and":
+		all = true
+	case "or":
+		n = 1
+	default:
+		return nil, p.errorf("unknown operator %q", keyword)
+	}
+
+	var policies []*cb.SignaturePolicy
+	for {
+		policy, err := p.parsePolicy()
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+		if p.next() != ',' {
+			break
+		}
+		p.pos++
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	if all {
+		n = int32(len(policies))
+	}
+	if n < 0 || n > int32(len(policies)) {
+		return nil, fmt.Errorf("Invalid policy: %s requires %d out of %d policies", keyword, n, len(policies))
+	}
+	return NOutOf(n, policies), nil
+}
+
+func (p *parser) parsePrincipal() (*cb.SignaturePolicy, error) {
+	quote := p.input[p.pos]
+	p.pos++
+	end := strings.IndexByte(p.input[p.pos:], quote)
+	if end < 0 {
+		return nil, p.errorf("unterminated principal")
+	}
+	value := p.input[p.pos : p.pos+end]
+
+	var principal *cb.MSPPrincipal
+	switch {
+	case strings.Contains(value, ouSeparator):
+		i := strings.Index(value, ouSeparator)
+		if i == 0 || i+len(ouSeparator) == len(value) {
+			return nil, p.errorf("invalid principal %q", value)
+		}
+		principal = OrganizationUnitPrincipal(value[:i], value[i+len(ouSeparator):])
+	case strings.HasSuffix(value, memberSuffix) && len(value) > len(memberSuffix):
+		principal = MSPRolePrincipal(strings.TrimSuffix(value, memberSuffix), cb.MSPRole_Member)
+	case strings.HasSuffix(value, adminSuffix) && len(value) > len(adminSuffix):
+		principal = MSPRolePrincipal(strings.TrimSuffix(value, adminSuffix), cb.MSPRole_Admin)
+	default:
+		return nil, p.errorf("invalid principal %q, expected 'MSP.member', 'MSP.admin' or 'MSP.ou:OU'", value)
+	}
+	p.pos += end + 1
+
+	for i, existing := range p.principals {
+		if proto.Equal(existing, principal) {
+			return SignedBy(int32(i)), nil
+		}
+	}
+	p.principals = append(p.principals, principal)
+	return SignedBy(int32(len(p.principals) - 1)), nil
+}
+
+// ToString expresses a SignaturePolicyEnvelope in the policy language, which is only possible
+// if its principals are MSP roles or organizational units
+func ToString(envelope *cb.SignaturePolicyEnvelope) (string, error) {
+	if len(envelope.Principals) == 0 && len(envelope.Identities) > 0 {
+		return "", fmt.Errorf("Policies of identities cannot be expressed in the policy language")
+	}
+
+	principals := make([]string, len(envelope.Principals))
+	for i, principal := range envelope.Principals {
+		var err error
+		if principals[i], err = principalToString(principal); err != nil {
+			return "", err
+		}
+	}
+
+	return policyToString(envelope.Policy, principals)
+}
+
+func principalToString(principal *cb.MSPPrincipal) (string, error) {
+	switch principal.PrincipalClassification {
+	case cb.MSPPrincipal_ByMSPRole:
+		role := &cb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return "", fmt.Errorf("Error unmarshaling MSPRole: %s", err)
+		}
+		switch role.Role {
+		case cb.MSPRole_Member:
+			return "'" + role.MSPIdentifier + memberSuffix + "'", nil
+		case cb.MSPRole_Admin:
+			return "'" + role.MSPIdentifier + adminSuffix + "'", nil
+		default:
+			return "", fmt.Errorf("Unknown MSP role %v", role.Role)
+		}
+	case cb.MSPPrincipal_ByOrganizationUnit:
+		ou := &cb.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return "", fmt.Errorf("Error unmarshaling OrganizationUnit: %s", err)
+		}
+		return "'" + ou.MSPIdentifier + ouSeparator + ou.OrganizationalUnitIdentifier + "'", nil
+	default:
+		return "", fmt.Errorf("Principals of classification %v cannot be expressed in the policy language", principal.PrincipalClassification)
+	}
+}
+
+func policyToString(policy *cb.SignaturePolicy, principals []string) (string, error) {
+	if policy == nil {
+		return "", fmt.Errorf("Empty policy element")
+	}
+
+	switch t := policy.Type.(type) {
+	case *cb.SignaturePolicy_From:
+		policies := make([]string, len(t.From.Policies))
+		for i, policy := range t.From.Policies {
+			var err error
+			if policies[i], err = policyToString(policy, principals); err != nil {
+				return "", err
+			}
+		}
+
+		switch {
+		case len(policies) > 1 && t.From.N == int32(len(policies)):
+			return "AND(" + strings.Join(policies, ", ") + ")", nil
+		case len(policies) > 1 && t.From.N == 1:
+			return "OR(" + strings.Join(policies, ", ") + ")", nil
+		case len(policies) == 0:
+			return "", fmt.Errorf("Policies requiring %d out of no policies cannot be expressed in the policy language", t.From.N)
+		default:
+			return fmt.Sprintf("OutOf(%d, %s)", t.From.N, strings.Join(policies, ", ")), nil
+		}
+	case *cb.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || t.SignedBy >= int32(len(principals)) {
+			return "", fmt.Errorf("Principal index out of range, requested %d, but principals length is %d", t.SignedBy, len(principals))
+		}
+		return principals[t.SignedBy], nil
+	default:
+		return "", fmt.Errorf("Unknown type: %T:%v", t, t)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cauthdsl
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func TestFromString(t *testing.T) {
+	envelope, err := FromString("OutOf(2, 'Org1.member', 'Org2.admin', AND('Org1.member', \"Org3.ou:Auditors\"))")
+	if err != nil {
+		t.Fatalf("Should have parsed the policy: %s", err)
+	}
+
+	expected := EnvelopeWithPrincipals(
+		NOutOf(2, []*cb.SignaturePolicy{SignedBy(0), SignedBy(1), NOutOf(2, []*cb.SignaturePolicy{SignedBy(0), SignedBy(2)})}),
+		[]*cb.MSPPrincipal{
+			MSPRolePrincipal("Org1", cb.MSPRole_Member),
+			MSPRolePrincipal("Org2", cb.MSPRole_Admin),
+			OrganizationUnitPrincipal("Org3", "Auditors"),
+		},
+	)
+	if !proto.Equal(envelope, expected) {
+		t.Fatalf("Parsed policy %v, expected %v", envelope, expected)
+	}
+}
+
+func TestFromStringErrors(t *testing.T) {
+	for _, policy := range []string{
+		"",
+		"'Org1'",
+		"'.member'",
+		"'Org1.ou:'",
+		"'Org1.member",
+		"Org1.member",
+		"NOT('Org1.member')",
+		"AND()",
+		"AND('Org1.member' 'Org2.member')",
+		"OutOf('Org1.member')",
+		"OutOf(3, 'Org1.member', 'Org2.member')",
+		"OutOf(-1, 'Org1.member')",
+		"OR('Org1.member', 'Org2.member'))",
+	} {
+		if _, err := FromString(policy); err == nil {
+			t.Errorf("Should have failed to parse %q", policy)
+		}
+	}
+}
+
+func TestToString(t *testing.T) {
+	for _, policy := range []string{
+		"'Org1.member'",
+		"AND('Org1.member', 'Org2.admin')",
+		"OR('Org1.member', AND('Org2.admin', 'Org3.ou:Auditors'))",
+		"OutOf(2, 'Org1.member', 'Org2.member', 'Org3.member')",
+	} {
+		envelope, err := FromString(policy)
+		if err != nil {
+			t.Fatalf("Should have parsed %q: %s", policy, err)
+		}
+		printed, err := ToString(envelope)
+		if err != nil {
+			t.Fatalf("Should have printed %q: %s", policy, err)
+		}
+		if printed != policy {
+			t.Errorf("Printed %q, expected %q", printed, policy)
+		}
+	}
+
+	if printed, err := ToString(&cb.SignaturePolicyEnvelope{Policy: SignedBy(0), Identities: signers}); err == nil {
+		t.Errorf("Should have failed to print a policy of identities, got %q", printed)
+	}
+}
+
+func TestFromStringEvaluation(t *testing.T) {
+	envelope, err := FromString("OutOf(2, 'Org1.member', 'Org2.admin', 'Org3.member')")
+	if err != nil {
+		t.Fatalf("Should have parsed the policy: %s", err)
+	}
+
+	spe, err := NewSignaturePolicyEvaluator(envelope, &mockCryptoHelper{})
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+
+	if !spe.Authenticate(nil, [][]byte{[]byte("Org1:member:"), []byte("Org3:member:")}, [][]byte{validSignature, validSignature}) {
+		t.Errorf("Expected authentication to succeed with members of Org1 and Org3")
+	}
+	if spe.Authenticate(nil, [][]byte{[]byte("Org1:member:"), []byte("Org2:member:")}, [][]byte{validSignature, validSignature}) {
+		t.Errorf("Expected authentication to fail because the member of Org2 is not an admin")
+	}
+}
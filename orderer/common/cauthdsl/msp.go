@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cauthdsl
+
+import (
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+// IdentityDeserializer converts serialized identities into msp identities and matches them against
+// principals, the msp manager is one
+type IdentityDeserializer interface {
+	DeserializeIdentity(serializedID []byte) (msp.Identity, error)
+	SatisfiesPrincipal(id msp.Identity, principal *cb.MSPPrincipal) error
+}
+
+type mspCryptoHelper struct {
+	deserializer IdentityDeserializer
+}
+
+// NewMSPCryptoHelper returns a CryptoHelper which treats ids as serialized msp identities, and accepts
+// a signature only if the identity is valid and the signature of the message verifies against it,
+// it is also a PrincipalHelper matching ids against principals according to their msp, and a SignerHelper
+// identifying the signer of an id by its certificate
+func NewMSPCryptoHelper(deserializer IdentityDeserializer) CryptoHelper {
+	return &mspCryptoHelper{deserializer: deserializer}
+}
+
+// VerifySignature returns true if id is a valid msp identity and signature is its signature of msg
+func (mch *mspCryptoHelper) VerifySignature(msg []byte, id []byte, signature []byte) bool {
+	identity, err := mch.deserializer.DeserializeIdentity(id)
+	if err != nil {
+		return false
+	}
+	if valid, err := identity.Validate(); err != nil || !valid {
+		return false
+	}
+	verified, err := identity.Verify(msg, signature)
+	return err == nil && verified
+}
+
+// SatisfiesPrincipal returns true if id is an msp identity which the msp considers to match the principal
+func (mch *mspCryptoHelper) SatisfiesPrincipal(id []byte, principal *cb.MSPPrincipal) bool {
+	identity, err := mch.deserializer.DeserializeIdentity(id)
+	if err != nil {
+		return false
+	}
+
+	return mch.deserializer.SatisfiesPrincipal(identity, principal) == nil
+}
+
+// Signer returns the certificate of id, without the intermediate CAs it may be serialized with, or id
+// itself if it is not a serialized msp identity
+func (mch *mspCryptoHelper) Signer(id []byte) []byte {
+	cert, err := msp.SignerCertificate(id)
+	if err != nil {
+		return id
+	}
+	return cert
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package configtx
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/mspconfig"
+	"github.com/hyperledger/fabric/orderer/common/policies"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
+	"github.com/hyperledger/fabric/orderer/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Bootstrap creates the configuration Manager of a chain, the policy manager holding the policies of its
+// configuration and the shared configuration manager holding the configuration of its orderers. The
+// signatures are checked with the MSPs of the organisations defined by the items of type Chain, or with
+// ch if the configuration defines none. Other configuration items are kept as bytes
+func Bootstrap(configtx *cb.ConfigurationEnvelope, ch cauthdsl.CryptoHelper) (Manager, *policies.ManagerImpl, *sharedconfig.ManagerImpl, error) {
+	// the policies are evaluated with the MSPs defined by the configuration of the chain, if any
+	mspManager := mspconfig.NewManagerImpl()
+	policyManager := policies.NewManagerImpl(mspManager.CryptoHelper(ch))
+	sharedConfigManager := sharedconfig.NewManagerImpl()
+	configHandlerMap := make(map[cb.ConfigurationItem_ConfigurationType]Handler)
+	for ctype := range cb.ConfigurationItem_ConfigurationType_name {
+		rtype := cb.ConfigurationItem_ConfigurationType(ctype)
+		switch rtype {
+		case cb.ConfigurationItem_Policy:
+			configHandlerMap[rtype] = policyManager
+		case cb.ConfigurationItem_Orderer:
+			configHandlerMap[rtype] = sharedConfigManager
+		case cb.ConfigurationItem_Chain:
+			configHandlerMap[rtype] = mspManager
+		default:
+			configHandlerMap[rtype] = NewBytesHandler()
+		}
+	}
+
+	configManager, err := NewConfigurationManager(configtx, policyManager, configHandlerMap)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return configManager, policyManager, sharedConfigManager, nil
+}
+
+// GenesisConfiguration returns the configuration transaction held by the genesis block of a chain
+func GenesisConfiguration(genesisBlock *cb.Block) (*cb.ConfigurationEnvelope, error) {
+	if genesisBlock.Data == nil || len(genesisBlock.Data.Data) != 1 {
+		return nil, fmt.Errorf("Genesis block must contain exactly one transaction")
+	}
+	envelope, err := util.ExtractEnvelope(genesisBlock, 0)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := util.ExtractPayload(envelope)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil || payload.Header.ChainHeader == nil ||
+		payload.Header.ChainHeader.Type != int32(cb.HeaderType_CONFIGURATION_TRANSACTION) {
+		return nil, fmt.Errorf("Genesis block does not contain a configuration transaction")
+	}
+	configEnvelope := &cb.ConfigurationEnvelope{}
+	if err = proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+		return nil, fmt.Errorf("Could not decode the configuration of the genesis block: %s", err)
+	}
+	return configEnvelope, nil
+}
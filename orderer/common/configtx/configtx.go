@@ -64,6 +64,7 @@ func (ap *acceptAllPolicy) Evaluate(headers [][]byte, payload []byte, identities
 
 type configurationManager struct {
 	sequence      uint64
+	bootstrapping bool
 	chainID       []byte
 	pm            policies.Manager
 	configuration map[cb.ConfigurationItem_ConfigurationType]map[string]*cb.ConfigurationItem
@@ -126,6 +127,7 @@ func NewConfigurationManager(configtx *cb.ConfigurationEnvelope, pm policies.Man
 
 	cm := &configurationManager{
 		sequence:      seq - 1,
+		bootstrapping: true,
 		chainID:       chainID,
 		pm:            pm,
 		handlers:      handlers,
@@ -138,6 +140,7 @@ func NewConfigurationManager(configtx *cb.ConfigurationEnvelope, pm policies.Man
 		return nil, err
 	}
 
+	cm.bootstrapping = false
 	return cm, nil
 }
 
@@ -211,44 +214,30 @@ func (cm *configurationManager) processConfig(configtx *cb.ConfigurationEnvelope
 			policy = defaultModificationPolicy
 		}
 
-		headers := make([][]byte, len(entry.Signatures))
-		signatures := make([][]byte, len(entry.Signatures))
-		identities := make([][]byte, len(entry.Signatures))
-
-		for i, configSig := range entry.Signatures {
-			headers[i] = configSig.Signature
-			signatures[i] = configSig.SignatureHeader
-			sigHeader := &cb.SignatureHeader{}
-			err := proto.Unmarshal(configSig.SignatureHeader, sigHeader)
-			if err != nil {
-				return nil, err
-			}
-			identities[i] = sigHeader.Creator
-		}
-
-		// Ensure the policy is satisfied
-		if err = policy.Evaluate(headers, entry.ConfigurationItem, identities, signatures); err != nil {
-			return nil, err
-		}
-
 		// Ensure the config sequence numbers are correct to prevent replay attacks
 		isModified := false
 
-		if val, ok := cm.configuration[config.Type][config.Key]; ok {
-			// Config was modified if the LastModified or the Data contents changed
-			isModified = (val.LastModified != config.LastModified) || !bytes.Equal(config.Value, val.Value)
+		if ok {
+			// Config was modified if any of its fields changed, including the LastModified
+			isModified = !proto.Equal(oldItem, config)
 		} else {
-			if config.LastModified != seq {
+			// The configuration of a running chain holds items last modified by earlier configtxs
+			if config.LastModified != seq && !(cm.bootstrapping && config.LastModified < seq) {
 				return nil, fmt.Errorf("Key %v for type %v was new, but had an older Sequence %d set", config.Key, config.Type, config.LastModified)
 			}
 			isModified = true
 		}
 
-		// If a config item was modified, its LastModified must be set correctly
 		if isModified {
-			if config.LastModified != seq {
+			// If a config item was modified, its LastModified must be set correctly
+			if config.LastModified != seq && !cm.bootstrapping {
 				return nil, fmt.Errorf("Key %v for type %v was modified, but its LastModified %d does not equal current configtx Sequence %d", config.Key, config.Type, config.LastModified, seq)
 			}
+
+			// Ensure the policy is satisfied, the items which are carried over unmodified need no new signatures
+			if err = evaluateSignatures(policy, entry); err != nil {
+				return nil, err
+			}
 		}
 
 		// Ensure the type handler agrees the config is well formed
@@ -277,6 +266,27 @@ func (cm *configurationManager) processConfig(configtx *cb.ConfigurationEnvelope
 
 }
 
+// evaluateSignatures checks that the signatures of a config item satisfy policy, each signature
+// being over the bytes of the config item
+func evaluateSignatures(policy policies.Policy, entry *cb.SignedConfigurationItem) error {
+	headers := make([][]byte, len(entry.Signatures))
+	signatures := make([][]byte, len(entry.Signatures))
+	identities := make([][]byte, len(entry.Signatures))
+
+	for i, configSig := range entry.Signatures {
+		headers[i] = configSig.SignatureHeader
+		signatures[i] = configSig.Signature
+		sigHeader := &cb.SignatureHeader{}
+		err := proto.Unmarshal(configSig.SignatureHeader, sigHeader)
+		if err != nil {
+			return err
+		}
+		identities[i] = sigHeader.Creator
+	}
+
+	return policy.Evaluate(headers, entry.ConfigurationItem, identities, signatures)
+}
+
 // Validate attempts to validate a new configtx against the current config state
 func (cm *configurationManager) Validate(configtx *cb.ConfigurationEnvelope) error {
 	cm.beginHandlers()
@@ -443,3 +443,69 @@ func TestMismatchedChainID(t *testing.T) {
 		t.Errorf("Should have errored creating the configuration manager because of the missing header")
 	}
 }
+
+// TestUnmodifiedConfigSkipsPolicy checks that the policy of a config item which is carried over unmodified
+// is not evaluated, so that an update only needs the signatures of the items it modifies
+func TestUnmodifiedConfigSkipsPolicy(t *testing.T) {
+	mpm := &mockPolicyManager{}
+	cm, err := NewConfigurationManager(&cb.ConfigurationEnvelope{
+		Items: []*cb.SignedConfigurationItem{
+			makeSignedConfigurationItem("foo", "foo", 0, []byte("foo"), defaultChain),
+			makeSignedConfigurationItem("bar", "bar", 0, []byte("bar"), defaultChain),
+		},
+	}, mpm, defaultHandlers())
+
+	if err != nil {
+		t.Fatalf("Error constructing configuration manager: %s", err)
+	}
+	// Set the mock policy to error
+	mpm.policy = &mockPolicy{fmt.Errorf("err")}
+
+	newConfig := &cb.ConfigurationEnvelope{
+		Items: []*cb.SignedConfigurationItem{
+			makeSignedConfigurationItem("foo", "foo", 0, []byte("foo"), defaultChain),
+			makeSignedConfigurationItem("bar", "bar", 1, []byte("changed"), defaultChain),
+		},
+	}
+
+	if err = cm.Validate(newConfig); err == nil {
+		t.Errorf("Should have errored validating config because policy rejected the modification of bar")
+	}
+
+	mpm.policy = &mockPolicy{}
+	if err = cm.Apply(newConfig); err != nil {
+		t.Fatalf("Should have applied the config: %s", err)
+	}
+}
+
+// TestBootstrapFromUpdatedConfig checks that a configuration manager may be constructed from the configuration
+// of a running chain, whose items were last modified by different configtxs
+func TestBootstrapFromUpdatedConfig(t *testing.T) {
+	cm, err := NewConfigurationManager(&cb.ConfigurationEnvelope{
+		Items: []*cb.SignedConfigurationItem{
+			makeSignedConfigurationItem("foo", "foo", 0, []byte("foo"), defaultChain),
+			makeSignedConfigurationItem("bar", "bar", 2, []byte("bar"), defaultChain),
+		},
+	}, &mockPolicyManager{&mockPolicy{}}, defaultHandlers())
+
+	if err != nil {
+		t.Fatalf("Error constructing configuration manager: %s", err)
+	}
+
+	newConfig := &cb.ConfigurationEnvelope{
+		Items: []*cb.SignedConfigurationItem{
+			makeSignedConfigurationItem("foo", "foo", 3, []byte("changed"), defaultChain),
+			makeSignedConfigurationItem("bar", "bar", 2, []byte("bar"), defaultChain),
+			makeSignedConfigurationItem("baz", "baz", 1, []byte("baz"), defaultChain),
+		},
+	}
+
+	if err = cm.Validate(newConfig); err == nil {
+		t.Errorf("Should have errored validating config because baz was new but its sequence number was old once bootstrapped")
+	}
+
+	newConfig.Items[2] = makeSignedConfigurationItem("baz", "baz", 3, []byte("baz"), defaultChain)
+	if err = cm.Apply(newConfig); err != nil {
+		t.Fatalf("Should have applied the config: %s", err)
+	}
+}
@@ -0,0 +1,437 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package configupdate helps the administrators of a chain change its configuration. The current
+// configuration is turned into a Document, edited as JSON or YAML, and the edited Document is
+// compared to the current configuration to produce an update in which only the changed items
+// have their LastModified bumped. The update is signed by enough administrators to satisfy the
+// modification policies of the changed items, then submitted to the ordering service.
+package configupdate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/orderer/common/blocksig"
+	"github.com/hyperledger/fabric/orderer/common/bootstrap/profile"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
+	"github.com/hyperledger/fabric/orderer/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"gopkg.in/yaml.v2"
+)
+
+const msgVersion = int32(1)
+
+// The formats a Document may be edited in
+const (
+	JSON = "json"
+	YAML = "yaml"
+)
+
+// Document is the editable form of the configuration of a chain
+type Document struct {
+	ChainID  string  `json:"ChainID" yaml:"ChainID"`
+	Sequence uint64  `json:"Sequence" yaml:"Sequence"`
+	Items    []*Item `json:"Items" yaml:"Items"`
+}
+
+// Item is the editable form of a configuration item. Value holds the decoded value of the
+// item when its message type is known, RawValue its bytes otherwise. LastModified is
+// informational, it is computed when the update is created
+type Item struct {
+	Type               string      `json:"Type" yaml:"Type"`
+	Key                string      `json:"Key" yaml:"Key"`
+	ModificationPolicy string      `json:"ModificationPolicy" yaml:"ModificationPolicy"`
+	LastModified       uint64      `json:"LastModified" yaml:"LastModified"`
+	Value              interface{} `json:"Value,omitempty" yaml:"Value,omitempty"`
+	RawValue           []byte      `json:"RawValue,omitempty" yaml:"RawValue,omitempty"`
+}
+
+// valueMessage returns an empty message of the type of the value of a configuration item,
+// or nil if the type of the value is not known
+func valueMessage(itemType cb.ConfigurationItem_ConfigurationType, key string) proto.Message {
+	switch itemType {
+	case cb.ConfigurationItem_Policy:
+		return &cb.Policy{}
+	case cb.ConfigurationItem_Chain:
+		switch {
+		case key == profile.OrdererAddressesKey:
+			return &cb.OrdererAddresses{}
+		case strings.HasPrefix(key, profile.MSPKeyPrefix):
+			return &cb.MSPConfig{}
+		}
+	case cb.ConfigurationItem_Orderer:
+		switch key {
+		case sharedconfig.ConsensusTypeKey:
+			return &ab.ConsensusType{}
+		case sharedconfig.BatchSizeKey:
+			return &ab.BatchSize{}
+		case sharedconfig.BatchTimeoutKey:
+			return &ab.BatchTimeout{}
+		case sharedconfig.KafkaBrokersKey:
+			return &ab.KafkaBrokers{}
+		}
+	}
+	return nil
+}
+
+// Sequence returns the sequence number of a configuration, the highest LastModified of its items
+func Sequence(config *cb.ConfigurationEnvelope) (uint64, error) {
+	items, err := unmarshalItems(config)
+	if err != nil {
+		return 0, err
+	}
+	return sequence(items), nil
+}
+
+func sequence(items []*cb.ConfigurationItem) uint64 {
+	seq := uint64(0)
+	for _, item := range items {
+		if item.LastModified > seq {
+			seq = item.LastModified
+		}
+	}
+	return seq
+}
+
+func unmarshalItems(config *cb.ConfigurationEnvelope) ([]*cb.ConfigurationItem, error) {
+	if len(config.Items) == 0 {
+		return nil, fmt.Errorf("Configuration has no item")
+	}
+	items := make([]*cb.ConfigurationItem, len(config.Items))
+	for i, signedItem := range config.Items {
+		item := &cb.ConfigurationItem{}
+		if err := proto.Unmarshal(signedItem.ConfigurationItem, item); err != nil {
+			return nil, fmt.Errorf("Error unmarshaling configuration item %d: %s", i, err)
+		}
+		if item.Header == nil {
+			return nil, fmt.Errorf("Configuration item %d has no header", i)
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// NewDocument returns the editable form of a configuration
+func NewDocument(config *cb.ConfigurationEnvelope) (*Document, error) {
+	items, err := unmarshalItems(config)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		ChainID:  string(items[0].Header.ChainID),
+		Sequence: sequence(items),
+	}
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	for _, item := range items {
+		docItem := &Item{
+			Type:               item.Type.String(),
+			Key:                item.Key,
+			ModificationPolicy: item.ModificationPolicy,
+			LastModified:       item.LastModified,
+		}
+		value := valueMessage(item.Type, item.Key)
+		if value == nil {
+			docItem.RawValue = item.Value
+		} else {
+			if err = proto.Unmarshal(item.Value, value); err != nil {
+				return nil, fmt.Errorf("Error unmarshaling the value of %s %s: %s", item.Type, item.Key, err)
+			}
+			data, err := marshaler.MarshalToString(value)
+			if err != nil {
+				return nil, fmt.Errorf("Error encoding the value of %s %s: %s", item.Type, item.Key, err)
+			}
+			if err = json.Unmarshal([]byte(data), &docItem.Value); err != nil {
+				return nil, err
+			}
+		}
+		doc.Items = append(doc.Items, docItem)
+	}
+	return doc, nil
+}
+
+// FormatOf returns the format of a Document file from its extension, JSON unless it is .yaml or .yml
+func FormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return YAML
+	default:
+		return JSON
+	}
+}
+
+// Marshal encodes a Document in the given format
+func (doc *Document) Marshal(format string) ([]byte, error) {
+	switch format {
+	case JSON:
+		return json.MarshalIndent(doc, "", "    ")
+	case YAML:
+		return yaml.Marshal(doc)
+	default:
+		return nil, fmt.Errorf("Unknown format %q", format)
+	}
+}
+
+// UnmarshalDocument decodes a Document in the given format
+func UnmarshalDocument(data []byte, format string) (*Document, error) {
+	doc := &Document{}
+	switch format {
+	case JSON:
+		if err := json.Unmarshal(data, doc); err != nil {
+			return nil, err
+		}
+	case YAML:
+		if err := yaml.Unmarshal(data, doc); err != nil {
+			return nil, err
+		}
+		// YAML maps are decoded with interface{} keys which JSON cannot encode
+		for _, item := range doc.Items {
+			item.Value = stringKeys(item.Value)
+		}
+	default:
+		return nil, fmt.Errorf("Unknown format %q", format)
+	}
+	return doc, nil
+}
+
+func stringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			m[fmt.Sprint(key)] = stringKeys(elem)
+		}
+		return m
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = stringKeys(elem)
+		}
+	}
+	return value
+}
+
+// value returns the bytes of the value of an edited item
+func (item *Item) value(itemType cb.ConfigurationItem_ConfigurationType) ([]byte, error) {
+	message := valueMessage(itemType, item.Key)
+	if message == nil {
+		if item.Value != nil {
+			return nil, fmt.Errorf("The value of %s %s is of an unknown type, it must be set as RawValue", item.Type, item.Key)
+		}
+		return item.RawValue, nil
+	}
+	if item.Value == nil {
+		return nil, fmt.Errorf("%s %s has no Value", item.Type, item.Key)
+	}
+	data, err := json.Marshal(item.Value)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding the value of %s %s: %s", item.Type, item.Key, err)
+	}
+	if err = jsonpb.Unmarshal(bytes.NewReader(data), message); err != nil {
+		return nil, fmt.Errorf("Invalid value for %s %s: %s", item.Type, item.Key, err)
+	}
+	return proto.Marshal(message)
+}
+
+// sameValue reports whether two values of a configuration item are equivalent, the values of
+// a known message type being compared as messages rather than as bytes
+func sameValue(itemType cb.ConfigurationItem_ConfigurationType, key string, a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	ma, mb := valueMessage(itemType, key), valueMessage(itemType, key)
+	if ma == nil || proto.Unmarshal(a, ma) != nil || proto.Unmarshal(b, mb) != nil {
+		return false
+	}
+	return proto.Equal(ma, mb)
+}
+
+type itemID struct {
+	itemType cb.ConfigurationItem_ConfigurationType
+	key      string
+}
+
+// Compute returns the unsigned update turning config into the edited Document. The items which
+// did not change are carried over as they are, the changed and added items have their LastModified
+// set to the sequence number of the update. Items may not be removed from the configuration
+func Compute(config *cb.ConfigurationEnvelope, edited *Document) (*cb.ConfigurationEnvelope, error) {
+	items, err := unmarshalItems(config)
+	if err != nil {
+		return nil, err
+	}
+	chainHeader := items[0].Header
+	if edited.ChainID != string(chainHeader.ChainID) {
+		return nil, fmt.Errorf("The edited configuration is for chain %s, not %s", edited.ChainID, chainHeader.ChainID)
+	}
+	seq := sequence(items) + 1
+
+	editedItems := make(map[itemID]*Item)
+	var added []itemID
+	for _, item := range edited.Items {
+		itemType, ok := cb.ConfigurationItem_ConfigurationType_value[item.Type]
+		if !ok {
+			return nil, fmt.Errorf("Unknown configuration item type %q", item.Type)
+		}
+		id := itemID{cb.ConfigurationItem_ConfigurationType(itemType), item.Key}
+		if _, ok := editedItems[id]; ok {
+			return nil, fmt.Errorf("%s %s is defined twice", item.Type, item.Key)
+		}
+		editedItems[id] = item
+		added = append(added, id)
+	}
+
+	update := &cb.ConfigurationEnvelope{}
+	modified := false
+	current := make(map[itemID]bool)
+	for i, item := range items {
+		id := itemID{item.Type, item.Key}
+		current[id] = true
+		editedItem, ok := editedItems[id]
+		if !ok {
+			return nil, fmt.Errorf("%s %s was removed, configuration items may not be deleted", item.Type, item.Key)
+		}
+		value, err := editedItem.value(item.Type)
+		if err != nil {
+			return nil, err
+		}
+		if editedItem.ModificationPolicy == item.ModificationPolicy && sameValue(item.Type, item.Key, item.Value, value) {
+			update.Items = append(update.Items, &cb.SignedConfigurationItem{ConfigurationItem: config.Items[i].ConfigurationItem})
+			continue
+		}
+		newItem := util.MakeConfigurationItem(item.Header, item.Type, seq, editedItem.ModificationPolicy, item.Key, value)
+		update.Items = append(update.Items, &cb.SignedConfigurationItem{ConfigurationItem: util.MarshalOrPanic(newItem)})
+		modified = true
+	}
+
+	for _, id := range added {
+		if current[id] {
+			continue
+		}
+		editedItem := editedItems[id]
+		value, err := editedItem.value(id.itemType)
+		if err != nil {
+			return nil, err
+		}
+		modificationPolicy := editedItem.ModificationPolicy
+		if modificationPolicy == "" {
+			modificationPolicy = configtx.DefaultModificationPolicyID
+		}
+		newItem := util.MakeConfigurationItem(chainHeader, id.itemType, seq, modificationPolicy, id.key, value)
+		update.Items = append(update.Items, &cb.SignedConfigurationItem{ConfigurationItem: util.MarshalOrPanic(newItem)})
+		modified = true
+	}
+
+	if !modified {
+		return nil, fmt.Errorf("The edited configuration does not change any item")
+	}
+	return update, nil
+}
+
+// Sign adds the signature of signer to every item modified by an update, the signature is over
+// the bytes of the item. The items already signed by signer are left as they are
+func Sign(update *cb.ConfigurationEnvelope, signer blocksig.Signer) error {
+	items, err := unmarshalItems(update)
+	if err != nil {
+		return err
+	}
+	seq := sequence(items)
+
+	creator, err := signer.Serialize()
+	if err != nil {
+		return fmt.Errorf("Error serializing the identity of the signer: %s", err)
+	}
+	for i, item := range items {
+		if item.LastModified != seq {
+			continue
+		}
+		signedItem := update.Items[i]
+		if signedBy(signedItem, creator) {
+			continue
+		}
+		signatureHeader, err := proto.Marshal(util.MakeSignatureHeader(creator, util.CreateNonceOrPanic()))
+		if err != nil {
+			return err
+		}
+		signature, err := signer.Sign(signedItem.ConfigurationItem)
+		if err != nil {
+			return fmt.Errorf("Error signing %s %s: %s", item.Type, item.Key, err)
+		}
+		signedItem.Signatures = append(signedItem.Signatures, &cb.ConfigurationSignature{
+			SignatureHeader: signatureHeader,
+			Signature:       signature,
+		})
+	}
+	return nil
+}
+
+func signedBy(signedItem *cb.SignedConfigurationItem, creator []byte) bool {
+	for _, configSig := range signedItem.Signatures {
+		signatureHeader := &cb.SignatureHeader{}
+		if err := proto.Unmarshal(configSig.SignatureHeader, signatureHeader); err == nil && bytes.Equal(signatureHeader.Creator, creator) {
+			return true
+		}
+	}
+	return false
+}
+
+// Envelope wraps an update in the configuration transaction submitted to the Broadcast of the
+// ordering service. The transaction is signed by signer unless it is nil
+func Envelope(update *cb.ConfigurationEnvelope, signer blocksig.Signer) (*cb.Envelope, error) {
+	items, err := unmarshalItems(update)
+	if err != nil {
+		return nil, err
+	}
+
+	var creator []byte
+	if signer != nil {
+		if creator, err = signer.Serialize(); err != nil {
+			return nil, fmt.Errorf("Error serializing the identity of the signer: %s", err)
+		}
+	}
+	payload := &cb.Payload{
+		Header: util.MakePayloadHeader(
+			util.MakeChainHeader(cb.HeaderType_CONFIGURATION_TRANSACTION, msgVersion, items[0].Header.ChainID, 0),
+			util.MakeSignatureHeader(creator, util.CreateNonceOrPanic()),
+		),
+		Data: util.MarshalOrPanic(update),
+	}
+	envelope := &cb.Envelope{Payload: util.MarshalOrPanic(payload)}
+	if signer != nil {
+		if envelope.Signature, err = signer.Sign(envelope.Payload); err != nil {
+			return nil, fmt.Errorf("Error signing the configuration transaction: %s", err)
+		}
+	}
+	return envelope, nil
+}
+
+// Validate checks an update against the current configuration of the chain, with the signatures
+// checked by ch, returning an error when the policies of the changed items are not yet satisfied
+func Validate(config *cb.ConfigurationEnvelope, update *cb.ConfigurationEnvelope, ch cauthdsl.CryptoHelper) error {
+	configManager, _, _, err := configtx.Bootstrap(config, ch)
+	if err != nil {
+		return fmt.Errorf("Error loading the current configuration: %s", err)
+	}
+	return configManager.Validate(update)
+}
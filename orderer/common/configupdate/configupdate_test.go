@@ -0,0 +1,242 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configupdate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
+	"github.com/hyperledger/fabric/orderer/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var admin = []byte("admin")
+
+// mockSigner "signs" by hashing its identity with the message
+type mockSigner struct {
+	id []byte
+}
+
+func (ms *mockSigner) Serialize() ([]byte, error) {
+	return ms.id, nil
+}
+
+func (ms *mockSigner) Sign(msg []byte) ([]byte, error) {
+	return mockSignature(ms.id, msg), nil
+}
+
+func mockSignature(id []byte, msg []byte) []byte {
+	h := sha256.Sum256(append(append([]byte{}, id...), msg...))
+	return h[:]
+}
+
+type mockCryptoHelper struct{}
+
+func (mch *mockCryptoHelper) VerifySignature(msg []byte, id []byte, signature []byte) bool {
+	return bytes.Equal(signature, mockSignature(id, msg))
+}
+
+// newTestConfig returns the configuration of a chain whose items may only be modified by admin
+func newTestConfig() *cb.ConfigurationEnvelope {
+	chainHeader := util.MakeChainHeader(cb.HeaderType_CONFIGURATION_ITEM, msgVersion, []byte("testchain"), 0)
+	makeItem := func(itemType cb.ConfigurationItem_ConfigurationType, key string, value proto.Message) *cb.SignedConfigurationItem {
+		item := util.MakeConfigurationItem(chainHeader, itemType, 0, configtx.DefaultModificationPolicyID, key, util.MarshalOrPanic(value))
+		return &cb.SignedConfigurationItem{ConfigurationItem: util.MarshalOrPanic(item)}
+	}
+	return util.MakeConfigurationEnvelope(
+		makeItem(cb.ConfigurationItem_Policy, configtx.DefaultModificationPolicyID, util.MakePolicyOrPanic(cauthdsl.Envelope(cauthdsl.SignedBy(0), [][]byte{admin}))),
+		makeItem(cb.ConfigurationItem_Orderer, sharedconfig.ConsensusTypeKey, &ab.ConsensusType{Type: "solo"}),
+		makeItem(cb.ConfigurationItem_Orderer, sharedconfig.BatchSizeKey, &ab.BatchSize{Messages: 10}),
+		makeItem(cb.ConfigurationItem_Orderer, sharedconfig.BatchTimeoutKey, &ab.BatchTimeout{Timeout: "10s"}),
+		makeItem(cb.ConfigurationItem_Fabric, "Opaque", &ab.BatchSize{Messages: 1}),
+	)
+}
+
+func roundTrip(t *testing.T, config *cb.ConfigurationEnvelope, format string) *Document {
+	doc, err := NewDocument(config)
+	if err != nil {
+		t.Fatalf("Error creating the document: %s", err)
+	}
+	data, err := doc.Marshal(format)
+	if err != nil {
+		t.Fatalf("Error marshaling the document: %s", err)
+	}
+	doc, err = UnmarshalDocument(data, format)
+	if err != nil {
+		t.Fatalf("Error unmarshaling the document: %s", err)
+	}
+	return doc
+}
+
+func findItem(doc *Document, key string) *Item {
+	for _, item := range doc.Items {
+		if item.Key == key {
+			return item
+		}
+	}
+	return nil
+}
+
+func TestUnchangedDocument(t *testing.T) {
+	config := newTestConfig()
+	for _, format := range []string{JSON, YAML} {
+		doc := roundTrip(t, config, format)
+		if doc.ChainID != "testchain" || len(doc.Items) != len(config.Items) {
+			t.Fatalf("Unexpected %s document %+v", format, doc)
+		}
+		if _, err := Compute(config, doc); err == nil {
+			t.Errorf("Computing an update from an unchanged %s document should fail", format)
+		}
+	}
+}
+
+func TestCompute(t *testing.T) {
+	config := newTestConfig()
+	doc := roundTrip(t, config, YAML)
+	findItem(doc, sharedconfig.BatchSizeKey).Value = map[string]interface{}{"Messages": 20}
+	doc.Items = append(doc.Items, &Item{
+		Type:  cb.ConfigurationItem_Orderer.String(),
+		Key:   sharedconfig.KafkaBrokersKey,
+		Value: map[string]interface{}{"Brokers": []interface{}{"127.0.0.1:9092"}},
+	})
+
+	update, err := Compute(config, doc)
+	if err != nil {
+		t.Fatalf("Error computing the update: %s", err)
+	}
+	if len(update.Items) != len(config.Items)+1 {
+		t.Fatalf("Expected %d items in the update, got %d", len(config.Items)+1, len(update.Items))
+	}
+
+	for i, signedItem := range update.Items {
+		item := &cb.ConfigurationItem{}
+		if err = proto.Unmarshal(signedItem.ConfigurationItem, item); err != nil {
+			t.Fatalf("Error unmarshaling item %d: %s", i, err)
+		}
+		switch item.Key {
+		case sharedconfig.BatchSizeKey:
+			batchSize := &ab.BatchSize{}
+			if err = proto.Unmarshal(item.Value, batchSize); err != nil || batchSize.Messages != 20 {
+				t.Errorf("Expected a batch size of 20, got %v (%v)", batchSize, err)
+			}
+			fallthrough
+		case sharedconfig.KafkaBrokersKey:
+			if item.LastModified != 1 {
+				t.Errorf("Expected %s to be modified by sequence 1, got %d", item.Key, item.LastModified)
+			}
+			if item.ModificationPolicy != configtx.DefaultModificationPolicyID {
+				t.Errorf("Expected %s to be modified under the default policy, got %s", item.Key, item.ModificationPolicy)
+			}
+		default:
+			if !bytes.Equal(signedItem.ConfigurationItem, config.Items[i].ConfigurationItem) {
+				t.Errorf("Expected the unchanged item %s to be carried over as it was", item.Key)
+			}
+		}
+	}
+}
+
+func TestComputeRemovedItem(t *testing.T) {
+	config := newTestConfig()
+	doc := roundTrip(t, config, JSON)
+	doc.Items = doc.Items[1:]
+	if _, err := Compute(config, doc); err == nil {
+		t.Fatal("Computing an update removing an item should fail")
+	}
+}
+
+func TestComputeInvalidValue(t *testing.T) {
+	config := newTestConfig()
+	doc := roundTrip(t, config, JSON)
+	findItem(doc, sharedconfig.BatchSizeKey).Value = map[string]interface{}{"Unknown": 20}
+	if _, err := Compute(config, doc); err == nil {
+		t.Fatal("Computing an update with an invalid value should fail")
+	}
+}
+
+func TestSignAndValidate(t *testing.T) {
+	config := newTestConfig()
+	doc := roundTrip(t, config, JSON)
+	findItem(doc, sharedconfig.BatchTimeoutKey).Value = map[string]interface{}{"Timeout": "1s"}
+	update, err := Compute(config, doc)
+	if err != nil {
+		t.Fatalf("Error computing the update: %s", err)
+	}
+
+	if err = Validate(config, update, &mockCryptoHelper{}); err == nil {
+		t.Fatal("Validation of an unsigned update should fail")
+	}
+
+	if err = Sign(update, &mockSigner{id: []byte("someone else")}); err != nil {
+		t.Fatalf("Error signing the update: %s", err)
+	}
+	if err = Validate(config, update, &mockCryptoHelper{}); err == nil {
+		t.Fatal("Validation of an update signed by an identity outside of the policy should fail")
+	}
+
+	// Signing is offline, the update goes through its serialized form between signers
+	data := util.MarshalOrPanic(update)
+	update = &cb.ConfigurationEnvelope{}
+	if err = proto.Unmarshal(data, update); err != nil {
+		t.Fatalf("Error unmarshaling the update: %s", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = Sign(update, &mockSigner{id: admin}); err != nil {
+			t.Fatalf("Error signing the update: %s", err)
+		}
+	}
+	if err = Validate(config, update, &mockCryptoHelper{}); err != nil {
+		t.Fatalf("Validation of the update signed by the admin failed: %s", err)
+	}
+
+	for _, signedItem := range update.Items {
+		item := &cb.ConfigurationItem{}
+		proto.Unmarshal(signedItem.ConfigurationItem, item)
+		expected := 0
+		// signed by someone else, then once by the admin who signed twice
+		if item.Key == sharedconfig.BatchTimeoutKey {
+			expected = 2
+		}
+		if len(signedItem.Signatures) != expected {
+			t.Errorf("Expected %d signatures on %s, got %d", expected, item.Key, len(signedItem.Signatures))
+		}
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	config := newTestConfig()
+	envelope, err := Envelope(config, &mockSigner{id: admin})
+	if err != nil {
+		t.Fatalf("Error creating the envelope: %s", err)
+	}
+	payload := util.ExtractPayloadOrPanic(envelope)
+	if payload.Header.ChainHeader.Type != int32(cb.HeaderType_CONFIGURATION_TRANSACTION) {
+		t.Errorf("Expected a configuration transaction, got type %d", payload.Header.ChainHeader.Type)
+	}
+	if string(payload.Header.ChainHeader.ChainID) != "testchain" {
+		t.Errorf("Expected the envelope to be for testchain, got %s", payload.Header.ChainHeader.ChainID)
+	}
+	if !bytes.Equal(envelope.Signature, mockSignature(admin, envelope.Payload)) {
+		t.Error("Expected the envelope to be signed by the admin")
+	}
+}
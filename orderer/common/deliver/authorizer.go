@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deliver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// SeekAuthorizer checks the seek requests of the clients of a chain: they must be for
+// the chain and satisfy its ReadersPolicyID policy, and signed requests must be fresh
+// and not replayed. It is shared by the clients of the chain, so that a request cannot
+// be replayed on another stream
+type SeekAuthorizer struct {
+	chainID       []byte
+	policyManager policies.Manager
+	seen          *nonceCache
+}
+
+// NewSeekAuthorizer creates a SeekAuthorizer for the seek requests of chainID,
+// checked against the ReadersPolicyID policy of policyManager
+func NewSeekAuthorizer(chainID []byte, policyManager policies.Manager) *SeekAuthorizer {
+	return &SeekAuthorizer{
+		chainID:       chainID,
+		policyManager: policyManager,
+		seen:          newNonceCache(time.Now),
+	}
+}
+
+// Authorize returns the seek request of a Seek or SignedSeek update, and the status
+// to reply to the client, which is not SUCCESS if the request is not authorized
+func (sa *SeekAuthorizer) Authorize(update *ab.DeliverUpdate) (*ab.SeekInfo, cb.Status) {
+	switch t := update.Type.(type) {
+	case *ab.DeliverUpdate_Seek:
+		if t.Seek == nil {
+			return nil, cb.Status_BAD_REQUEST
+		}
+		if len(t.Seek.ChainID) != 0 && !bytes.Equal(t.Seek.ChainID, sa.chainID) {
+			logger.Debugf("Rejecting seek for chain %s", t.Seek.ChainID)
+			return nil, cb.Status_NOT_FOUND
+		}
+		// an unsigned seek is served only if the policy requires no signature
+		return t.Seek, sa.authorize(nil, nil, nil, nil)
+	case *ab.DeliverUpdate_SignedSeek:
+		return sa.authorizeSignedSeek(t.SignedSeek)
+	default:
+		return nil, cb.Status_BAD_REQUEST
+	}
+}
+
+// authorizeSignedSeek extracts the seek request from its envelope and checks its signature against the readers policy
+func (sa *SeekAuthorizer) authorizeSignedSeek(env *cb.Envelope) (*ab.SeekInfo, cb.Status) {
+	payload := &cb.Payload{}
+	if env == nil || proto.Unmarshal(env.Payload, payload) != nil || payload.Header == nil ||
+		payload.Header.ChainHeader == nil || payload.Header.SignatureHeader == nil {
+		logger.Debugf("Rejecting malformed signed seek")
+		return nil, cb.Status_BAD_REQUEST
+	}
+	if payload.Header.ChainHeader.Type != int32(cb.HeaderType_DELIVER_SEEK_INFO) {
+		logger.Debugf("Rejecting signed seek with header type %d", payload.Header.ChainHeader.Type)
+		return nil, cb.Status_BAD_REQUEST
+	}
+	seek := &ab.SeekInfo{}
+	if err := proto.Unmarshal(payload.Data, seek); err != nil {
+		logger.Debugf("Rejecting signed seek with malformed seek info: %s", err)
+		return nil, cb.Status_BAD_REQUEST
+	}
+	// the signature covers the chain of the header, which must be the one the seek is for
+	chainID := payload.Header.ChainHeader.ChainID
+	if !bytes.Equal(chainID, sa.chainID) || !bytes.Equal(seek.ChainID, chainID) {
+		logger.Debugf("Rejecting signed seek for chain %s bound to chain %s", seek.ChainID, chainID)
+		return nil, cb.Status_NOT_FOUND
+	}
+
+	signatureHeader, err := proto.Marshal(payload.Header.SignatureHeader)
+	if err != nil {
+		logger.Errorf("Error marshaling signature header: %s", err)
+		return nil, cb.Status_INTERNAL_SERVER_ERROR
+	}
+	status := sa.authorize([][]byte{signatureHeader}, env.Payload, [][]byte{payload.Header.SignatureHeader.Creator}, [][]byte{env.Signature})
+	if status != cb.Status_SUCCESS {
+		return nil, status
+	}
+
+	// only remember the nonces of authorized requests, once they can no longer be forged
+	if err := sa.seen.check(payload.Header.ChainHeader.Timestamp, payload.Header.SignatureHeader); err != nil {
+		logger.Debugf("Rejecting signed seek: %s", err)
+		return nil, cb.Status_BAD_REQUEST
+	}
+	return seek, cb.Status_SUCCESS
+}
+
+// authorize checks the signatures of a seek request against the readers policy, returning FORBIDDEN when it is not satisfied
+func (sa *SeekAuthorizer) authorize(headers [][]byte, payload []byte, identities [][]byte, signatures [][]byte) cb.Status {
+	policy, _ := sa.policyManager.GetPolicy(ReadersPolicyID)
+	if err := policy.Evaluate(headers, payload, identities, signatures); err != nil {
+		logger.Debugf("Rejecting seek which does not satisfy the %s policy: %s", ReadersPolicyID, err)
+		return cb.Status_FORBIDDEN
+	}
+	return cb.Status_SUCCESS
+}
+
+// nonceCache rejects the signed requests whose timestamp is not within MaxSeekClockSkew of
+// the current time, or whose creator and nonce were already seen in a recent request
+type nonceCache struct {
+	lock sync.Mutex
+	// seen maps the hash of the creator and nonce of a request to when it may be forgotten
+	seen      map[[sha256.Size]byte]time.Time
+	lastPurge time.Time
+	now       func() time.Time
+}
+
+// nonceCachePurgePeriod is how often the expired nonces are forgotten
+const nonceCachePurgePeriod = time.Minute
+
+func newNonceCache(now func() time.Time) *nonceCache {
+	return &nonceCache{seen: make(map[[sha256.Size]byte]time.Time), lastPurge: now(), now: now}
+}
+
+// check returns an error if the request with the given timestamp and signature header is not
+// fresh, otherwise it remembers its nonce
+func (nc *nonceCache) check(ts *timestamp.Timestamp, header *cb.SignatureHeader) error {
+	if ts == nil {
+		return fmt.Errorf("request has no timestamp")
+	}
+	if len(header.Nonce) == 0 {
+		return fmt.Errorf("request has no nonce")
+	}
+	now := nc.now()
+	created := time.Unix(ts.Seconds, int64(ts.Nanos))
+	if created.Before(now.Add(-MaxSeekClockSkew)) || created.After(now.Add(MaxSeekClockSkew)) {
+		return fmt.Errorf("request timestamp %s is not within %s of %s", created, MaxSeekClockSkew, now)
+	}
+
+	key := sha256.Sum256(append(append([]byte{}, header.Creator...), header.Nonce...))
+
+	nc.lock.Lock()
+	defer nc.lock.Unlock()
+	if now.Sub(nc.lastPurge) > nonceCachePurgePeriod {
+		for k, expiry := range nc.seen {
+			if now.After(expiry) {
+				delete(nc.seen, k)
+			}
+		}
+		nc.lastPurge = now
+	}
+	if _, ok := nc.seen[key]; ok {
+		return fmt.Errorf("request is a replay")
+	}
+	// a request stays valid until its timestamp is MaxSeekClockSkew in the past
+	nc.seen[key] = created.Add(MaxSeekClockSkew)
+	return nil
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package deliver
 
 import (
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/policies"
 	"github.com/hyperledger/fabric/orderer/rawledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -24,6 +27,14 @@ import (
 	"github.com/op/go-logging"
 )
 
+// ReadersPolicyID is the ID of the policy the seek requests must satisfy
+const ReadersPolicyID = "Readers"
+
+// MaxSeekClockSkew is how far the timestamp of a signed seek request may be from the
+// clock of the orderer, the nonces of the requests are remembered for this long in
+// both directions so that a request cannot be replayed
+const MaxSeekClockSkew = 15 * time.Minute
+
 var logger = logging.MustGetLogger("orderer/common/deliver")
 
 func init() {
@@ -35,14 +46,18 @@ type Handler interface {
 }
 
 type DeliverServer struct {
-	rl        rawledger.Reader
-	maxWindow int
+	rl         rawledger.Reader
+	authorizer *SeekAuthorizer
+	maxWindow  int
 }
 
-func NewHandlerImpl(rl rawledger.Reader, maxWindow int) Handler {
+// NewHandlerImpl creates a Handler delivering the blocks of rl, the ledger of chainID, to the
+// clients whose seek requests satisfy the ReadersPolicyID policy of policyManager
+func NewHandlerImpl(chainID []byte, rl rawledger.Reader, policyManager policies.Manager, maxWindow int) Handler {
 	return &DeliverServer{
-		rl:        rl,
-		maxWindow: maxWindow,
+		rl:         rl,
+		authorizer: NewSeekAuthorizer(chainID, policyManager),
+		maxWindow:  maxWindow,
 	}
 }
 
@@ -60,6 +75,8 @@ type deliverer struct {
 	nextBlockNumber uint64
 	windowSize      uint64
 	lastAck         uint64
+	stop            *uint64 // the last block to deliver, nil to deliver blocks as they are created
+	failIfNotReady  bool
 	recvChan        chan *ab.DeliverUpdate
 	exitChan        chan struct{}
 }
@@ -89,8 +106,13 @@ func (d *deliverer) main() {
 			case *ab.DeliverUpdate_Acknowledgement:
 				logger.Debugf("Received acknowledgement from client")
 				d.lastAck = t.Acknowledgement.Number
-			case *ab.DeliverUpdate_Seek:
-				if !d.processUpdate(t.Seek) {
+			case *ab.DeliverUpdate_Seek, *ab.DeliverUpdate_SignedSeek:
+				seek, status := d.ds.authorizer.Authorize(update)
+				if status != cb.Status_SUCCESS {
+					d.reject(status)
+					return
+				}
+				if !d.processUpdate(seek) {
 					return
 				}
 			case nil:
@@ -115,6 +137,13 @@ func (d *deliverer) main() {
 				if !d.sendBlockReply(block) {
 					return
 				}
+				if d.stop != nil && block.Header.Number >= *d.stop {
+					logger.Debugf("Delivered the last requested block %d", block.Header.Number)
+					if !d.sendErrorReply(cb.Status_SUCCESS) {
+						return
+					}
+					d.cursor = nil
+				}
 			}
 		case <-d.exitChan:
 			return
@@ -132,6 +161,19 @@ func (d *deliverer) main() {
 
 		logger.Debugf("Room for more blocks, activating channel")
 		signal = d.cursor.ReadyChan()
+
+		if d.failIfNotReady {
+			select {
+			case <-signal:
+			default:
+				logger.Debugf("Block %d is not ready, failing the seek", d.nextBlockNumber)
+				if !d.sendErrorReply(cb.Status_NOT_FOUND) {
+					return
+				}
+				d.cursor = nil
+				signal = nil
+			}
+		}
 	}
 }
 
@@ -165,6 +207,13 @@ func (d *deliverer) sendErrorReply(status cb.Status) bool {
 
 }
 
+// reject replies with status and disconnects the client
+func (d *deliverer) reject(status cb.Status) {
+	if d.sendErrorReply(status) {
+		close(d.exitChan)
+	}
+}
+
 func (d *deliverer) sendBlockReply(block *cb.Block) bool {
 	err := d.srv.Send(&ab.DeliverResponse{
 		Type: &ab.DeliverResponse_Block{Block: block},
@@ -189,8 +238,30 @@ func (d *deliverer) processUpdate(update *ab.SeekInfo) bool {
 		close(d.exitChan)
 		return d.sendErrorReply(cb.Status_BAD_REQUEST)
 	}
+	start := update.SpecifiedNumber
+	if update.Start != ab.SeekInfo_SPECIFIED {
+		start = d.ds.rl.Height() - 1
+		if update.Start == ab.SeekInfo_OLDEST {
+			start = 0
+		}
+	}
+
+	d.stop = nil
+	if update.Stop != nil {
+		stop := update.Stop.SpecifiedNumber
+		if update.Stop.Type == ab.SeekStop_NEWEST {
+			stop = d.ds.rl.Height() - 1
+		}
+		if stop < start {
+			logger.Debugf("Rejecting seek from block %d to block %d", start, stop)
+			d.reject(cb.Status_BAD_REQUEST)
+			return false
+		}
+		d.stop = &stop
+	}
 
 	d.windowSize = update.WindowSize
+	d.failIfNotReady = update.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY
 
 	d.cursor, d.nextBlockNumber = d.ds.rl.Iterator(update.Start, update.SpecifiedNumber)
 	d.lastAck = d.nextBlockNumber - 1
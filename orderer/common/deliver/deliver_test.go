@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package deliver
+package deliver_test
 
 import (
 	"fmt"
@@ -22,6 +22,9 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/static"
+	. "github.com/hyperledger/fabric/orderer/common/deliver"
+	"github.com/hyperledger/fabric/orderer/common/policies"
+	"github.com/hyperledger/fabric/orderer/common/util"
 	"github.com/hyperledger/fabric/orderer/rawledger/ramledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -43,6 +46,27 @@ func init() {
 // MagicLargestWindow is used as the default max window size for initializing the deliver service
 const MagicLargestWindow int = 1000
 
+// mockPolicy always returns the error set as policyResult
+type mockPolicy struct {
+	policyResult error
+}
+
+func (mp *mockPolicy) Evaluate(headers [][]byte, payload []byte, identities [][]byte, signatures [][]byte) error {
+	if mp == nil {
+		return fmt.Errorf("Invoked nil policy")
+	}
+	return mp.policyResult
+}
+
+// mockPolicyManager always returns the policy set as policy, note that if unset, the default policy always returns error when evaluated
+type mockPolicyManager struct {
+	policy *mockPolicy
+}
+
+func (mpm *mockPolicyManager) GetPolicy(id string) (policies.Policy, bool) {
+	return mpm.policy, (mpm.policy != nil)
+}
+
 type mockD struct {
 	grpc.ServerStream
 	recvChan chan *ab.DeliverUpdate
@@ -71,14 +95,14 @@ func (m *mockD) Recv() (*ab.DeliverUpdate, error) {
 
 func TestOldestSeek(t *testing.T) {
 	ledgerSize := 5
-	_, rl := ramledger.New(ledgerSize, genesisBlock)
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
 	for i := 1; i < ledgerSize; i++ {
 		rl.Append([]*cb.Envelope{&cb.Envelope{Payload: []byte(fmt.Sprintf("%d", i))}}, nil)
 	}
 
 	m := newMockD()
 	defer close(m.recvChan)
-	ds := NewHandlerImpl(rl, MagicLargestWindow)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
 
 	go ds.Handle(m)
 
@@ -103,14 +127,14 @@ func TestOldestSeek(t *testing.T) {
 
 func TestNewestSeek(t *testing.T) {
 	ledgerSize := 5
-	_, rl := ramledger.New(ledgerSize, genesisBlock)
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
 	for i := 1; i < ledgerSize; i++ {
 		rl.Append([]*cb.Envelope{&cb.Envelope{Payload: []byte(fmt.Sprintf("%d", i))}}, nil)
 	}
 
 	m := newMockD()
 	defer close(m.recvChan)
-	ds := NewHandlerImpl(rl, MagicLargestWindow)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
 
 	go ds.Handle(m)
 
@@ -132,13 +156,13 @@ func TestNewestSeek(t *testing.T) {
 
 func TestSpecificSeek(t *testing.T) {
 	ledgerSize := 5
-	_, rl := ramledger.New(ledgerSize, genesisBlock)
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
 	for i := 1; i < ledgerSize; i++ {
 		rl.Append([]*cb.Envelope{&cb.Envelope{Payload: []byte(fmt.Sprintf("%d", i))}}, nil)
 	}
 
 	m := newMockD()
-	ds := NewHandlerImpl(rl, MagicLargestWindow)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
 
 	go ds.Handle(m)
 
@@ -160,14 +184,14 @@ func TestSpecificSeek(t *testing.T) {
 
 func TestBadSeek(t *testing.T) {
 	ledgerSize := 5
-	_, rl := ramledger.New(ledgerSize, genesisBlock)
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
 	for i := 1; i < 2*ledgerSize; i++ {
 		rl.Append([]*cb.Envelope{&cb.Envelope{Payload: []byte(fmt.Sprintf("%d", i))}}, nil)
 	}
 
 	m := newMockD()
 	defer close(m.recvChan)
-	ds := NewHandlerImpl(rl, MagicLargestWindow)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
 
 	go ds.Handle(m)
 
@@ -196,11 +220,11 @@ func TestBadSeek(t *testing.T) {
 
 func TestBadWindow(t *testing.T) {
 	ledgerSize := 5
-	_, rl := ramledger.New(ledgerSize, genesisBlock)
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
 
 	m := newMockD()
 	defer close(m.recvChan)
-	ds := NewHandlerImpl(rl, MagicLargestWindow)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
 
 	go ds.Handle(m)
 
@@ -219,14 +243,14 @@ func TestBadWindow(t *testing.T) {
 func TestAck(t *testing.T) {
 	ledgerSize := 10
 	windowSize := uint64(2)
-	_, rl := ramledger.New(ledgerSize, genesisBlock)
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
 	for i := 1; i < ledgerSize; i++ {
 		rl.Append([]*cb.Envelope{&cb.Envelope{Payload: []byte(fmt.Sprintf("%d", i))}}, nil)
 	}
 
 	m := newMockD()
 	defer close(m.recvChan)
-	ds := NewHandlerImpl(rl, MagicLargestWindow)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
 
 	go ds.Handle(m)
 
@@ -260,3 +284,256 @@ func TestAck(t *testing.T) {
 		}
 	}
 }
+
+func makeSignedSeek(headerType cb.HeaderType, seek *ab.SeekInfo) *ab.DeliverUpdate {
+	seek.ChainID = static.TestChainID
+	return makeSignedSeekWithHeader(util.MakeChainHeader(headerType, 1, static.TestChainID, 0), util.MakeSignatureHeader([]byte("creator"), util.CreateNonceOrPanic()), seek)
+}
+
+func makeSignedSeekWithHeader(chainHeader *cb.ChainHeader, signatureHeader *cb.SignatureHeader, seek *ab.SeekInfo) *ab.DeliverUpdate {
+	payload := &cb.Payload{
+		Header: util.MakePayloadHeader(chainHeader, signatureHeader),
+		Data:   util.MarshalOrPanic(seek),
+	}
+	return &ab.DeliverUpdate{Type: &ab.DeliverUpdate_SignedSeek{SignedSeek: &cb.Envelope{Payload: util.MarshalOrPanic(payload), Signature: []byte("signature")}}}
+}
+
+func TestSeekRange(t *testing.T) {
+	ledgerSize := 10
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
+	for i := 1; i < ledgerSize; i++ {
+		rl.Append([]*cb.Envelope{&cb.Envelope{Payload: []byte(fmt.Sprintf("%d", i))}}, nil)
+	}
+
+	m := newMockD()
+	defer close(m.recvChan)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
+
+	go ds.Handle(m)
+
+	m.recvChan <- makeSignedSeek(cb.HeaderType_DELIVER_SEEK_INFO, &ab.SeekInfo{
+		WindowSize:      uint64(MagicLargestWindow),
+		Start:           ab.SeekInfo_SPECIFIED,
+		SpecifiedNumber: 3,
+		Stop:            &ab.SeekStop{Type: ab.SeekStop_SPECIFIED, SpecifiedNumber: 6},
+	})
+
+	for i := uint64(3); i <= 6; i++ {
+		select {
+		case blockReply := <-m.sendChan:
+			if blockReply.GetBlock() == nil {
+				t.Fatalf("Received an error on the reply channel")
+			}
+			if blockReply.GetBlock().Header.Number != i {
+				t.Fatalf("Expected block %d, got %d", i, blockReply.GetBlock().Header.Number)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting to get all blocks")
+		}
+	}
+
+	select {
+	case blockReply := <-m.sendChan:
+		if blockReply.GetError() != cb.Status_SUCCESS {
+			t.Fatalf("Expected SUCCESS after the last requested block, got %v", blockReply)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the end of the range")
+	}
+
+	select {
+	case blockReply := <-m.sendChan:
+		t.Fatalf("Received a reply after the end of the range: %v", blockReply)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestBadSeekRange(t *testing.T) {
+	ledgerSize := 5
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
+	for i := 1; i < ledgerSize; i++ {
+		rl.Append([]*cb.Envelope{&cb.Envelope{Payload: []byte(fmt.Sprintf("%d", i))}}, nil)
+	}
+
+	m := newMockD()
+	defer close(m.recvChan)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
+
+	go ds.Handle(m)
+
+	m.recvChan <- &ab.DeliverUpdate{Type: &ab.DeliverUpdate_Seek{Seek: &ab.SeekInfo{
+		WindowSize:      uint64(MagicLargestWindow),
+		Start:           ab.SeekInfo_SPECIFIED,
+		SpecifiedNumber: 3,
+		Stop:            &ab.SeekStop{Type: ab.SeekStop_SPECIFIED, SpecifiedNumber: 2},
+	}}}
+
+	select {
+	case blockReply := <-m.sendChan:
+		if blockReply.GetError() != cb.Status_BAD_REQUEST {
+			t.Fatalf("Received wrong error on the reply channel")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the error")
+	}
+}
+
+func TestFailIfNotReady(t *testing.T) {
+	ledgerSize := 5
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
+
+	m := newMockD()
+	defer close(m.recvChan)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
+
+	go ds.Handle(m)
+
+	m.recvChan <- &ab.DeliverUpdate{Type: &ab.DeliverUpdate_Seek{Seek: &ab.SeekInfo{
+		WindowSize: uint64(MagicLargestWindow),
+		Start:      ab.SeekInfo_OLDEST,
+		Behavior:   ab.SeekInfo_FAIL_IF_NOT_READY,
+	}}}
+
+	select {
+	case blockReply := <-m.sendChan:
+		if blockReply.GetBlock() == nil {
+			t.Fatalf("Expected the genesis block, got %v", blockReply)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the genesis block")
+	}
+
+	select {
+	case blockReply := <-m.sendChan:
+		if blockReply.GetError() != cb.Status_NOT_FOUND {
+			t.Fatalf("Expected NOT_FOUND for the block not yet created, got %v", blockReply)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the error")
+	}
+}
+
+func TestReadersPolicy(t *testing.T) {
+	ledgerSize := 5
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
+
+	for _, update := range []*ab.DeliverUpdate{
+		{Type: &ab.DeliverUpdate_Seek{Seek: &ab.SeekInfo{WindowSize: uint64(MagicLargestWindow), Start: ab.SeekInfo_OLDEST}}},
+		makeSignedSeek(cb.HeaderType_DELIVER_SEEK_INFO, &ab.SeekInfo{WindowSize: uint64(MagicLargestWindow), Start: ab.SeekInfo_OLDEST}),
+	} {
+		m := newMockD()
+		ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{policyResult: fmt.Errorf("Rejected")}}, MagicLargestWindow)
+
+		go ds.Handle(m)
+
+		m.recvChan <- update
+
+		select {
+		case blockReply := <-m.sendChan:
+			if blockReply.GetError() != cb.Status_FORBIDDEN {
+				t.Fatalf("Expected FORBIDDEN, got %v", blockReply)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for the error")
+		}
+		close(m.recvChan)
+	}
+}
+
+func TestSignedSeekBadHeaderType(t *testing.T) {
+	ledgerSize := 5
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
+
+	m := newMockD()
+	defer close(m.recvChan)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
+
+	go ds.Handle(m)
+
+	m.recvChan <- makeSignedSeek(cb.HeaderType_ENDORSER_TRANSACTION, &ab.SeekInfo{WindowSize: uint64(MagicLargestWindow), Start: ab.SeekInfo_OLDEST})
+
+	select {
+	case blockReply := <-m.sendChan:
+		if blockReply.GetError() != cb.Status_BAD_REQUEST {
+			t.Fatalf("Expected BAD_REQUEST, got %v", blockReply)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the error")
+	}
+}
+
+func TestSignedSeekChainID(t *testing.T) {
+	ledgerSize := 5
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
+	otherChainID := []byte("otherChain")
+
+	for name, update := range map[string]*ab.DeliverUpdate{
+		"header for another chain": makeSignedSeekWithHeader(
+			util.MakeChainHeader(cb.HeaderType_DELIVER_SEEK_INFO, 1, otherChainID, 0),
+			util.MakeSignatureHeader([]byte("creator"), util.CreateNonceOrPanic()),
+			&ab.SeekInfo{WindowSize: uint64(MagicLargestWindow), Start: ab.SeekInfo_OLDEST, ChainID: otherChainID}),
+		"seek for another chain": makeSignedSeekWithHeader(
+			util.MakeChainHeader(cb.HeaderType_DELIVER_SEEK_INFO, 1, static.TestChainID, 0),
+			util.MakeSignatureHeader([]byte("creator"), util.CreateNonceOrPanic()),
+			&ab.SeekInfo{WindowSize: uint64(MagicLargestWindow), Start: ab.SeekInfo_OLDEST, ChainID: otherChainID}),
+		"unsigned seek for another chain": {Type: &ab.DeliverUpdate_Seek{Seek: &ab.SeekInfo{WindowSize: uint64(MagicLargestWindow), Start: ab.SeekInfo_OLDEST, ChainID: otherChainID}}},
+	} {
+		m := newMockD()
+		ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
+
+		go ds.Handle(m)
+
+		m.recvChan <- update
+
+		select {
+		case blockReply := <-m.sendChan:
+			if blockReply.GetError() != cb.Status_NOT_FOUND {
+				t.Fatalf("Expected NOT_FOUND for a %s, got %v", name, blockReply)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for the error")
+		}
+		close(m.recvChan)
+	}
+}
+
+func TestSignedSeekFreshness(t *testing.T) {
+	ledgerSize := 5
+	_, rl := ramledger.New(ledgerSize, genesisBlock, nil)
+	ds := NewHandlerImpl(static.TestChainID, rl, &mockPolicyManager{policy: &mockPolicy{}}, MagicLargestWindow)
+
+	stale := util.MakeChainHeader(cb.HeaderType_DELIVER_SEEK_INFO, 1, static.TestChainID, 0)
+	stale.Timestamp.Seconds -= int64(2 * MaxSeekClockSkew / time.Second)
+	replayed := makeSignedSeek(cb.HeaderType_DELIVER_SEEK_INFO, &ab.SeekInfo{WindowSize: uint64(MagicLargestWindow), Start: ab.SeekInfo_NEWEST})
+
+	for _, test := range []struct {
+		name     string
+		update   *ab.DeliverUpdate
+		expected cb.Status
+	}{
+		{"stale seek", makeSignedSeekWithHeader(stale, util.MakeSignatureHeader([]byte("creator"), util.CreateNonceOrPanic()),
+			&ab.SeekInfo{WindowSize: uint64(MagicLargestWindow), Start: ab.SeekInfo_NEWEST, ChainID: static.TestChainID}), cb.Status_BAD_REQUEST},
+		{"seek without nonce", makeSignedSeekWithHeader(util.MakeChainHeader(cb.HeaderType_DELIVER_SEEK_INFO, 1, static.TestChainID, 0), util.MakeSignatureHeader([]byte("creator"), nil),
+			&ab.SeekInfo{WindowSize: uint64(MagicLargestWindow), Start: ab.SeekInfo_NEWEST, ChainID: static.TestChainID}), cb.Status_BAD_REQUEST},
+		{"fresh seek", replayed, cb.Status_SUCCESS},
+		{"replayed seek", replayed, cb.Status_BAD_REQUEST},
+	} {
+		m := newMockD()
+		go ds.Handle(m)
+
+		m.recvChan <- test.update
+
+		select {
+		case reply := <-m.sendChan:
+			if test.expected == cb.Status_SUCCESS && reply.GetBlock() == nil {
+				t.Fatalf("Expected a block for the %s, got %v", test.name, reply)
+			}
+			if test.expected != cb.Status_SUCCESS && reply.GetError() != test.expected {
+				t.Fatalf("Expected %v for the %s, got %v", test.expected, test.name, reply)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for the reply to the %s", test.name)
+		}
+		close(m.recvChan)
+	}
+}
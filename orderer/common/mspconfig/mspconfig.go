@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mspconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/common/mspconfig")
+
+// KeyPrefix is the prefix of the keys of the configuration items of type Chain holding the
+// MSPs of the organisations of the chain, it is followed by the name of the organisation
+const KeyPrefix = "MSP."
+
+// ManagerImpl is the configtx.Handler of the configuration items of type Chain. It builds the MSPs
+// of the organisations of the chain from the items whose key starts with KeyPrefix, the other items
+// of type Chain are accepted as they are. Once committed, identities are deserialized and matched
+// against principals with these MSPs
+type ManagerImpl struct {
+	mutex    sync.RWMutex
+	msps     map[string]msp.PeerMSP
+	proposed map[string]msp.PeerMSP
+}
+
+// NewManagerImpl creates a new ManagerImpl, with no MSP until a configuration is committed
+func NewManagerImpl() *ManagerImpl {
+	return &ManagerImpl{msps: make(map[string]msp.PeerMSP)}
+}
+
+// BeginConfig called when a config proposal is begun
+func (mi *ManagerImpl) BeginConfig() {
+	if mi.proposed != nil {
+		panic("Programming error, called BeginConfig while a proposal was in process")
+	}
+	mi.proposed = make(map[string]msp.PeerMSP)
+}
+
+// RollbackConfig called when a config proposal is abandoned
+func (mi *ManagerImpl) RollbackConfig() {
+	mi.proposed = nil
+}
+
+// CommitConfig called when a config proposal is committed
+func (mi *ManagerImpl) CommitConfig() {
+	if mi.proposed == nil {
+		panic("Programming error, called CommitConfig with no proposal in process")
+	}
+	mi.mutex.Lock()
+	defer mi.mutex.Unlock()
+	mi.msps = mi.proposed
+	mi.proposed = nil
+	logger.Debugf("Committed the MSPs of %d organisations", len(mi.msps))
+}
+
+// ProposeConfig called when config is added to a proposal
+func (mi *ManagerImpl) ProposeConfig(configItem *cb.ConfigurationItem) error {
+	if configItem.Type != cb.ConfigurationItem_Chain {
+		return fmt.Errorf("Expected type of ConfigurationItem_Chain, got %v", configItem.Type)
+	}
+	if !strings.HasPrefix(configItem.Key, KeyPrefix) {
+		return nil
+	}
+
+	mspConfig := &cb.MSPConfig{}
+	if err := proto.Unmarshal(configItem.Value, mspConfig); err != nil {
+		return fmt.Errorf("Unmarshaling error for %s: %s", configItem.Key, err)
+	}
+	if name := strings.TrimPrefix(configItem.Key, KeyPrefix); mspConfig.Name != name {
+		return fmt.Errorf("Configuration item %s holds the MSP %s", configItem.Key, mspConfig.Name)
+	}
+	orgMSP, err := msp.NewMSPFromConfig(mspConfig)
+	if err != nil {
+		return fmt.Errorf("Invalid MSP %s: %s", mspConfig.Name, err)
+	}
+	mi.proposed[mspConfig.Name] = orgMSP
+	return nil
+}
+
+// HasMSPs returns whether the configuration of the chain defines the MSPs of its organisations
+func (mi *ManagerImpl) HasMSPs() bool {
+	mi.mutex.RLock()
+	defer mi.mutex.RUnlock()
+	return len(mi.msps) > 0
+}
+
+// DeserializeIdentity deserializes the identity with the MSP of the chain whose root CAs it is valid
+// under, it fails if it is not valid under any of them
+func (mi *ManagerImpl) DeserializeIdentity(serializedID []byte) (msp.Identity, error) {
+	mi.mutex.RLock()
+	defer mi.mutex.RUnlock()
+
+	names := make([]string, 0, len(mi.msps))
+	for name := range mi.msps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		identity, err := mi.msps[name].DeserializeIdentity(serializedID)
+		if err != nil {
+			continue
+		}
+		if valid, err := mi.msps[name].IsValid(identity); err == nil && valid {
+			return identity, nil
+		}
+	}
+	return nil, fmt.Errorf("The identity is not valid under any MSP of the chain")
+}
+
+// SatisfiesPrincipal checks whether the identity matches the principal, with the MSP of the chain
+// which deserialized it
+func (mi *ManagerImpl) SatisfiesPrincipal(id msp.Identity, principal *cb.MSPPrincipal) error {
+	mi.mutex.RLock()
+	orgMSP := mi.msps[id.GetMSPIdentifier()]
+	mi.mutex.RUnlock()
+	if orgMSP == nil {
+		return fmt.Errorf("No MSP of the chain has the identifier %s", id.GetMSPIdentifier())
+	}
+	return orgMSP.SatisfiesPrincipal(id, principal)
+}
+
+type cryptoHelper struct {
+	msps     *ManagerImpl
+	chain    cauthdsl.CryptoHelper
+	fallback cauthdsl.CryptoHelper
+}
+
+// CryptoHelper returns the CryptoHelper checking signatures against the MSPs of the chain. The
+// fallback CryptoHelper is only used while the configuration of the chain defines no MSP
+func (mi *ManagerImpl) CryptoHelper(fallback cauthdsl.CryptoHelper) cauthdsl.CryptoHelper {
+	return &cryptoHelper{msps: mi, chain: cauthdsl.NewMSPCryptoHelper(mi), fallback: fallback}
+}
+
+func (ch *cryptoHelper) current() cauthdsl.CryptoHelper {
+	if ch.msps.HasMSPs() {
+		return ch.chain
+	}
+	return ch.fallback
+}
+
+// VerifySignature returns true if id is a valid identity and signature is its signature of msg
+func (ch *cryptoHelper) VerifySignature(msg []byte, id []byte, signature []byte) bool {
+	return ch.current().VerifySignature(msg, id, signature)
+}
+
+// SatisfiesPrincipal returns true if id is an identity which matches the principal, it is false
+// for any principal which the fallback CryptoHelper cannot evaluate
+func (ch *cryptoHelper) SatisfiesPrincipal(id []byte, principal *cb.MSPPrincipal) bool {
+	ph, ok := ch.current().(cauthdsl.PrincipalHelper)
+	return ok && ph.SatisfiesPrincipal(id, principal)
+}
+
+// Signer returns the signer of id according to the current CryptoHelper, id itself unless it is a
+// SignerHelper
+func (ch *cryptoHelper) Signer(id []byte) []byte {
+	if sh, ok := ch.current().(cauthdsl.SignerHelper); ok {
+		return sh.Signer(id)
+	}
+	return id
+}
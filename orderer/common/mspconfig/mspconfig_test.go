@@ -0,0 +1,170 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mspconfig
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/crypto/primitives/testutil"
+	"github.com/hyperledger/fabric/orderer/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+func toPEM(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+func makeMSPItem(key string, config *cb.MSPConfig) *cb.ConfigurationItem {
+	return &cb.ConfigurationItem{
+		Type:  cb.ConfigurationItem_Chain,
+		Key:   key,
+		Value: util.MarshalOrPanic(config),
+	}
+}
+
+func memberOf(name string) *cb.MSPPrincipal {
+	return &cb.MSPPrincipal{
+		PrincipalClassification: cb.MSPPrincipal_ByMSPRole,
+		Principal:               util.MarshalOrPanic(&cb.MSPRole{MSPIdentifier: name, Role: cb.MSPRole_Member}),
+	}
+}
+
+type mockCryptoHelper struct{}
+
+func (mch *mockCryptoHelper) VerifySignature(msg []byte, id []byte, signature []byte) bool {
+	return true
+}
+
+func (mch *mockCryptoHelper) SatisfiesPrincipal(id []byte, principal *cb.MSPPrincipal) bool {
+	return true
+}
+
+func TestInvalidItems(t *testing.T) {
+	ca, _ := testutil.NewCert(t, testutil.CATemplate("root"), nil, nil)
+	items := map[string]*cb.ConfigurationItem{
+		"wrong type":    {Type: cb.ConfigurationItem_Orderer, Key: KeyPrefix + "Org1MSP"},
+		"garbage value": {Type: cb.ConfigurationItem_Chain, Key: KeyPrefix + "Org1MSP", Value: []byte("garbage")},
+		"name mismatch": makeMSPItem(KeyPrefix+"Org1MSP", &cb.MSPConfig{Name: "Org2MSP", RootCerts: [][]byte{toPEM(ca)}}),
+		"no root cert":  makeMSPItem(KeyPrefix+"Org1MSP", &cb.MSPConfig{Name: "Org1MSP"}),
+	}
+
+	m := NewManagerImpl()
+	for name, item := range items {
+		m.BeginConfig()
+		if err := m.ProposeConfig(item); err == nil {
+			t.Errorf("Should have rejected the item with %s", name)
+		}
+		m.RollbackConfig()
+	}
+}
+
+func TestOtherChainItems(t *testing.T) {
+	m := NewManagerImpl()
+	m.BeginConfig()
+	if err := m.ProposeConfig(&cb.ConfigurationItem{Type: cb.ConfigurationItem_Chain, Key: "SomeOtherKey", Value: []byte("whatever")}); err != nil {
+		t.Fatalf("Should have accepted an item of type Chain which is not an MSP: %s", err)
+	}
+	m.CommitConfig()
+	if m.HasMSPs() {
+		t.Fatalf("Should not have any MSP")
+	}
+}
+
+func TestRollback(t *testing.T) {
+	ca, _ := testutil.NewCert(t, testutil.CATemplate("root"), nil, nil)
+	m := NewManagerImpl()
+	m.BeginConfig()
+	if err := m.ProposeConfig(makeMSPItem(KeyPrefix+"Org1MSP", &cb.MSPConfig{Name: "Org1MSP", RootCerts: [][]byte{toPEM(ca)}})); err != nil {
+		t.Fatalf("Should have accepted the MSP: %s", err)
+	}
+	m.RollbackConfig()
+	if m.HasMSPs() {
+		t.Fatalf("Should not have any MSP after a rollback")
+	}
+}
+
+func TestFallback(t *testing.T) {
+	m := NewManagerImpl()
+	ch := m.CryptoHelper(&mockCryptoHelper{})
+	if !ch.VerifySignature([]byte("msg"), []byte("id"), []byte("sig")) {
+		t.Fatalf("Should have verified the signature with the fallback while the chain has no MSP")
+	}
+	if !ch.(interface {
+		SatisfiesPrincipal([]byte, *cb.MSPPrincipal) bool
+	}).SatisfiesPrincipal([]byte("id"), memberOf("Org1MSP")) {
+		t.Fatalf("Should have matched the principal with the fallback while the chain has no MSP")
+	}
+}
+
+func TestChainMSPs(t *testing.T) {
+	org1, org1Key := testutil.NewCert(t, testutil.CATemplate("org1"), nil, nil)
+	org2, org2Key := testutil.NewCert(t, testutil.CATemplate("org2"), nil, nil)
+	other, otherKey := testutil.NewCert(t, testutil.CATemplate("other"), nil, nil)
+	member1, _ := testutil.NewCert(t, testutil.Template("member1"), org1, org1Key)
+	member2, _ := testutil.NewCert(t, testutil.Template("member2"), org2, org2Key)
+	outsider, _ := testutil.NewCert(t, testutil.Template("outsider"), other, otherKey)
+
+	m := NewManagerImpl()
+	m.BeginConfig()
+	for _, item := range []*cb.ConfigurationItem{
+		makeMSPItem(KeyPrefix+"Org1MSP", &cb.MSPConfig{Name: "Org1MSP", RootCerts: [][]byte{toPEM(org1)}}),
+		makeMSPItem(KeyPrefix+"Org2MSP", &cb.MSPConfig{Name: "Org2MSP", RootCerts: [][]byte{toPEM(org2)}}),
+	} {
+		if err := m.ProposeConfig(item); err != nil {
+			t.Fatalf("Should have accepted %s: %s", item.Key, err)
+		}
+	}
+	m.CommitConfig()
+	if !m.HasMSPs() {
+		t.Fatalf("Should have the MSPs of the chain")
+	}
+
+	for cert, name := range map[*x509.Certificate]string{member1: "Org1MSP", member2: "Org2MSP"} {
+		identity, err := m.DeserializeIdentity(cert.Raw)
+		if err != nil {
+			t.Fatalf("Should have deserialized %s: %s", cert.Subject.CommonName, err)
+		}
+		if identity.GetMSPIdentifier() != name {
+			t.Fatalf("%s should be of MSP %s, got %s", cert.Subject.CommonName, name, identity.GetMSPIdentifier())
+		}
+		if err := m.SatisfiesPrincipal(identity, memberOf(name)); err != nil {
+			t.Fatalf("%s should be a member of %s: %s", cert.Subject.CommonName, name, err)
+		}
+	}
+	if _, err := m.DeserializeIdentity(outsider.Raw); err == nil {
+		t.Fatalf("Should have rejected an identity issued by a CA which is not in the configuration of the chain")
+	}
+
+	ch := m.CryptoHelper(&mockCryptoHelper{})
+	ph := ch.(interface {
+		SatisfiesPrincipal([]byte, *cb.MSPPrincipal) bool
+	})
+	if !ph.SatisfiesPrincipal(member2.Raw, memberOf("Org2MSP")) {
+		t.Fatalf("member2 should be a member of Org2MSP")
+	}
+	if ph.SatisfiesPrincipal(member2.Raw, memberOf("Org1MSP")) {
+		t.Fatalf("member2 should not be a member of Org1MSP")
+	}
+	if ph.SatisfiesPrincipal(outsider.Raw, memberOf("Org1MSP")) {
+		t.Fatalf("The fallback should not be used once the chain has MSPs")
+	}
+	if ch.VerifySignature([]byte("msg"), outsider.Raw, []byte("sig")) {
+		t.Fatalf("Should not have verified the signature of an identity outside the MSPs of the chain")
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedconfig
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/op/go-logging"
+)
+
+var logger = logging.MustGetLogger("orderer/common/sharedconfig")
+
+func init() {
+	logging.SetLevel(logging.DEBUG, "")
+}
+
+// The keys of the configuration items of type Orderer
+const (
+	ConsensusTypeKey = "ConsensusType"
+	BatchSizeKey     = "BatchSize"
+	BatchTimeoutKey  = "BatchTimeout"
+	KafkaBrokersKey  = "KafkaBrokers"
+
+	BlockDataHashingStructureKey = "BlockDataHashingStructure"
+)
+
+// Manager stores the configuration shared by the orderers of a chain. The values which are not
+// part of the configuration of the chain are zero, the orderer then uses its local configuration
+type Manager interface {
+	// ConsensusType returns the orderer implementation of the chain
+	ConsensusType() string
+
+	// BatchSize returns the maximum number of messages in a block
+	BatchSize() int
+
+	// BatchTimeout returns the time to wait before cutting a block which is not full
+	BatchTimeout() time.Duration
+
+	// KafkaBrokers returns the Kafka brokers the orderers of the chain connect to
+	KafkaBrokers() []string
+
+	// BlockDataHashingWidth returns the width of the MerkleTree hashing the data of the blocks
+	BlockDataHashingWidth() uint32
+}
+
+type ordererConfig struct {
+	consensusType string
+	batchSize     int
+	batchTimeout  time.Duration
+	kafkaBrokers  []string
+	hashingWidth  uint32
+}
+
+// ManagerImpl is the configtx.Handler of the configuration items of type Orderer, it validates
+// them when they are proposed and makes them the values of the Manager when they are committed
+type ManagerImpl struct {
+	mutex    sync.RWMutex
+	config   *ordererConfig
+	proposed *ordererConfig
+	// whether a configuration, the one of the genesis block first, has been committed
+	committed bool
+}
+
+// NewManagerImpl creates a new ManagerImpl, with no value until a configuration is committed
+func NewManagerImpl() *ManagerImpl {
+	return &ManagerImpl{config: &ordererConfig{}}
+}
+
+// ConsensusType returns the orderer implementation of the chain
+func (smi *ManagerImpl) ConsensusType() string {
+	smi.mutex.RLock()
+	defer smi.mutex.RUnlock()
+	return smi.config.consensusType
+}
+
+// BatchSize returns the maximum number of messages in a block
+func (smi *ManagerImpl) BatchSize() int {
+	smi.mutex.RLock()
+	defer smi.mutex.RUnlock()
+	return smi.config.batchSize
+}
+
+// BatchTimeout returns the time to wait before cutting a block which is not full
+func (smi *ManagerImpl) BatchTimeout() time.Duration {
+	smi.mutex.RLock()
+	defer smi.mutex.RUnlock()
+	return smi.config.batchTimeout
+}
+
+// KafkaBrokers returns the Kafka brokers the orderers of the chain connect to
+func (smi *ManagerImpl) KafkaBrokers() []string {
+	smi.mutex.RLock()
+	defer smi.mutex.RUnlock()
+	return smi.config.kafkaBrokers
+}
+
+// BlockDataHashingWidth returns the width of the MerkleTree hashing the data of the blocks,
+// cb.DefaultBlockDataHashingWidth unless the configuration of the chain sets another one
+func (smi *ManagerImpl) BlockDataHashingWidth() uint32 {
+	smi.mutex.RLock()
+	defer smi.mutex.RUnlock()
+	if smi.config.hashingWidth == 0 {
+		return cb.DefaultBlockDataHashingWidth
+	}
+	return smi.config.hashingWidth
+}
+
+// BeginConfig called when a config proposal is begun
+func (smi *ManagerImpl) BeginConfig() {
+	if smi.proposed != nil {
+		panic("Programming error, called BeginConfig while a proposal was in process")
+	}
+	smi.proposed = &ordererConfig{}
+}
+
+// RollbackConfig called when a config proposal is abandoned
+func (smi *ManagerImpl) RollbackConfig() {
+	smi.proposed = nil
+}
+
+// CommitConfig called when a config proposal is committed
+func (smi *ManagerImpl) CommitConfig() {
+	if smi.proposed == nil {
+		panic("Programming error, called CommitConfig with no proposal in process")
+	}
+	smi.mutex.Lock()
+	defer smi.mutex.Unlock()
+	if smi.committed && smi.proposed.hashingWidth == 0 {
+		// a configuration which does not set the width keeps the one of the chain
+		smi.proposed.hashingWidth = smi.config.hashingWidth
+	}
+	smi.config = smi.proposed
+	smi.proposed = nil
+	smi.committed = true
+	logger.Debugf("Committed orderer configuration %+v", smi.config)
+}
+
+// ProposeConfig called when config is added to a proposal
+func (smi *ManagerImpl) ProposeConfig(configItem *cb.ConfigurationItem) error {
+	if configItem.Type != cb.ConfigurationItem_Orderer {
+		return fmt.Errorf("Expected type of ConfigurationItem_Orderer, got %v", configItem.Type)
+	}
+
+	switch configItem.Key {
+	case ConsensusTypeKey:
+		consensusType := &ab.ConsensusType{}
+		if err := proto.Unmarshal(configItem.Value, consensusType); err != nil {
+			return fmt.Errorf("Unmarshaling error for %s: %s", configItem.Key, err)
+		}
+		if consensusType.Type == "" {
+			return fmt.Errorf("%s must be set", configItem.Key)
+		}
+		if current := smi.ConsensusType(); current != "" && current != consensusType.Type {
+			return fmt.Errorf("Attempted to change the consensus type from %s to %s after init", current, consensusType.Type)
+		}
+		smi.proposed.consensusType = consensusType.Type
+	case BatchSizeKey:
+		batchSize := &ab.BatchSize{}
+		if err := proto.Unmarshal(configItem.Value, batchSize); err != nil {
+			return fmt.Errorf("Unmarshaling error for %s: %s", configItem.Key, err)
+		}
+		if batchSize.Messages == 0 {
+			return fmt.Errorf("%s must be greater than 0", configItem.Key)
+		}
+		smi.proposed.batchSize = int(batchSize.Messages)
+	case BatchTimeoutKey:
+		batchTimeout := &ab.BatchTimeout{}
+		if err := proto.Unmarshal(configItem.Value, batchTimeout); err != nil {
+			return fmt.Errorf("Unmarshaling error for %s: %s", configItem.Key, err)
+		}
+		timeout, err := time.ParseDuration(batchTimeout.Timeout)
+		if err != nil {
+			return fmt.Errorf("Invalid %s %q: %s", configItem.Key, batchTimeout.Timeout, err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("%s must be greater than 0, got %s", configItem.Key, timeout)
+		}
+		smi.proposed.batchTimeout = timeout
+	case KafkaBrokersKey:
+		kafkaBrokers := &ab.KafkaBrokers{}
+		if err := proto.Unmarshal(configItem.Value, kafkaBrokers); err != nil {
+			return fmt.Errorf("Unmarshaling error for %s: %s", configItem.Key, err)
+		}
+		if len(kafkaBrokers.Brokers) == 0 {
+			return fmt.Errorf("%s must not be empty", configItem.Key)
+		}
+		for _, broker := range kafkaBrokers.Brokers {
+			if _, _, err := net.SplitHostPort(broker); err != nil {
+				return fmt.Errorf("Invalid Kafka broker %q: %s", broker, err)
+			}
+		}
+		smi.proposed.kafkaBrokers = kafkaBrokers.Brokers
+	case BlockDataHashingStructureKey:
+		hashingStructure := &ab.BlockDataHashingStructure{}
+		if err := proto.Unmarshal(configItem.Value, hashingStructure); err != nil {
+			return fmt.Errorf("Unmarshaling error for %s: %s", configItem.Key, err)
+		}
+		if hashingStructure.Width < 2 {
+			return fmt.Errorf("%s must be at least 2, got %d", configItem.Key, hashingStructure.Width)
+		}
+		// the blocks already in the chain, and the proofs of their entries, were computed with the
+		// current width, which is the default one if the genesis block did not set it
+		smi.mutex.RLock()
+		committed := smi.committed
+		smi.mutex.RUnlock()
+		if current := smi.BlockDataHashingWidth(); committed && current != hashingStructure.Width {
+			return fmt.Errorf("Attempted to change the block data hashing width from %d to %d after init", current, hashingStructure.Width)
+		}
+		smi.proposed.hashingWidth = hashingStructure.Width
+	default:
+		return fmt.Errorf("Unknown orderer configuration item %s", configItem.Key)
+	}
+	return nil
+}
+
+// BlockDataHashingWidth returns the width of the MerkleTree hashing the data of the blocks of a chain,
+// as set by the configuration transaction in the data of the genesis block of the chain. It is
+// cb.DefaultBlockDataHashingWidth if that configuration does not set it
+func BlockDataHashingWidth(genesisData *cb.BlockData) uint32 {
+	for _, entry := range genesisData.Data {
+		env := &cb.Envelope{}
+		if err := proto.Unmarshal(entry, env); err != nil {
+			continue
+		}
+		payload := &cb.Payload{}
+		if err := proto.Unmarshal(env.Payload, payload); err != nil || payload.Header == nil || payload.Header.ChainHeader == nil {
+			continue
+		}
+		if payload.Header.ChainHeader.Type != int32(cb.HeaderType_CONFIGURATION_TRANSACTION) {
+			continue
+		}
+		config := &cb.ConfigurationEnvelope{}
+		if err := proto.Unmarshal(payload.Data, config); err != nil {
+			continue
+		}
+		for _, signedItem := range config.Items {
+			item := &cb.ConfigurationItem{}
+			if err := proto.Unmarshal(signedItem.ConfigurationItem, item); err != nil {
+				continue
+			}
+			if item.Type != cb.ConfigurationItem_Orderer || item.Key != BlockDataHashingStructureKey {
+				continue
+			}
+			hashingStructure := &ab.BlockDataHashingStructure{}
+			if err := proto.Unmarshal(item.Value, hashingStructure); err == nil && hashingStructure.Width >= 2 {
+				return hashingStructure.Width
+			}
+		}
+	}
+	return cb.DefaultBlockDataHashingWidth
+}
@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func makeConfigItem(key string, value proto.Message) *cb.ConfigurationItem {
+	data, err := proto.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return &cb.ConfigurationItem{
+		Type:  cb.ConfigurationItem_Orderer,
+		Key:   key,
+		Value: data,
+	}
+}
+
+func TestCommit(t *testing.T) {
+	m := NewManagerImpl()
+
+	if m.BatchSize() != 0 || m.BatchTimeout() != 0 || m.ConsensusType() != "" || m.KafkaBrokers() != nil {
+		t.Fatalf("Should have no value before a configuration is committed")
+	}
+
+	m.BeginConfig()
+	items := []*cb.ConfigurationItem{
+		makeConfigItem(ConsensusTypeKey, &ab.ConsensusType{Type: "kafka"}),
+		makeConfigItem(BatchSizeKey, &ab.BatchSize{Messages: 10}),
+		makeConfigItem(BatchTimeoutKey, &ab.BatchTimeout{Timeout: "2s"}),
+		makeConfigItem(KafkaBrokersKey, &ab.KafkaBrokers{Brokers: []string{"127.0.0.1:9092"}}),
+	}
+	for _, item := range items {
+		if err := m.ProposeConfig(item); err != nil {
+			t.Fatalf("Should have accepted %s: %s", item.Key, err)
+		}
+	}
+
+	if m.BatchSize() != 0 {
+		t.Fatalf("Should not have applied the proposed configuration before it was committed")
+	}
+
+	m.CommitConfig()
+
+	if m.ConsensusType() != "kafka" {
+		t.Errorf("Expected consensus type kafka, got %s", m.ConsensusType())
+	}
+	if m.BatchSize() != 10 {
+		t.Errorf("Expected batch size 10, got %d", m.BatchSize())
+	}
+	if m.BatchTimeout() != 2*time.Second {
+		t.Errorf("Expected batch timeout 2s, got %s", m.BatchTimeout())
+	}
+	if brokers := m.KafkaBrokers(); len(brokers) != 1 || brokers[0] != "127.0.0.1:9092" {
+		t.Errorf("Expected Kafka brokers [127.0.0.1:9092], got %v", brokers)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	m := NewManagerImpl()
+
+	m.BeginConfig()
+	if err := m.ProposeConfig(makeConfigItem(BatchSizeKey, &ab.BatchSize{Messages: 10})); err != nil {
+		t.Fatalf("Should have accepted the batch size: %s", err)
+	}
+	m.RollbackConfig()
+
+	if m.BatchSize() != 0 {
+		t.Fatalf("Should not have applied a rolled back configuration")
+	}
+}
+
+func TestInvalidItems(t *testing.T) {
+	items := []*cb.ConfigurationItem{
+		makeConfigItem(ConsensusTypeKey, &ab.ConsensusType{}),
+		makeConfigItem(BatchSizeKey, &ab.BatchSize{Messages: 0}),
+		makeConfigItem(BatchTimeoutKey, &ab.BatchTimeout{Timeout: "soon"}),
+		makeConfigItem(BatchTimeoutKey, &ab.BatchTimeout{Timeout: "-1s"}),
+		makeConfigItem(KafkaBrokersKey, &ab.KafkaBrokers{}),
+		makeConfigItem(KafkaBrokersKey, &ab.KafkaBrokers{Brokers: []string{"127.0.0.1"}}),
+		makeConfigItem(BlockDataHashingStructureKey, &ab.BlockDataHashingStructure{Width: 1}),
+		makeConfigItem("Unknown", &ab.BatchSize{Messages: 10}),
+		&cb.ConfigurationItem{Type: cb.ConfigurationItem_Policy, Key: BatchSizeKey},
+	}
+
+	m := NewManagerImpl()
+	m.BeginConfig()
+	defer m.RollbackConfig()
+	for i, item := range items {
+		if err := m.ProposeConfig(item); err == nil {
+			t.Errorf("Should have rejected item %d (%s)", i, item.Key)
+		}
+	}
+}
+
+func TestConsensusTypeChange(t *testing.T) {
+	m := NewManagerImpl()
+
+	m.BeginConfig()
+	if err := m.ProposeConfig(makeConfigItem(ConsensusTypeKey, &ab.ConsensusType{Type: "solo"})); err != nil {
+		t.Fatalf("Should have accepted the consensus type: %s", err)
+	}
+	m.CommitConfig()
+
+	m.BeginConfig()
+	defer m.RollbackConfig()
+	if err := m.ProposeConfig(makeConfigItem(ConsensusTypeKey, &ab.ConsensusType{Type: "solo"})); err != nil {
+		t.Fatalf("Should have accepted the same consensus type: %s", err)
+	}
+	if err := m.ProposeConfig(makeConfigItem(ConsensusTypeKey, &ab.ConsensusType{Type: "kafka"})); err == nil {
+		t.Fatalf("Should have rejected a change of the consensus type")
+	}
+}
+
+func TestBlockDataHashingWidthChange(t *testing.T) {
+	m := NewManagerImpl()
+	if m.BlockDataHashingWidth() != cb.DefaultBlockDataHashingWidth {
+		t.Fatalf("Expected the default block data hashing width, got %d", m.BlockDataHashingWidth())
+	}
+
+	m.BeginConfig()
+	if err := m.ProposeConfig(makeConfigItem(BlockDataHashingStructureKey, &ab.BlockDataHashingStructure{Width: 4})); err != nil {
+		t.Fatalf("Should have accepted the block data hashing width: %s", err)
+	}
+	m.CommitConfig()
+	if m.BlockDataHashingWidth() != 4 {
+		t.Fatalf("Expected a block data hashing width of 4, got %d", m.BlockDataHashingWidth())
+	}
+
+	m.BeginConfig()
+	defer m.RollbackConfig()
+	if err := m.ProposeConfig(makeConfigItem(BlockDataHashingStructureKey, &ab.BlockDataHashingStructure{Width: 2})); err == nil {
+		t.Fatalf("Should have rejected a change of the block data hashing width")
+	}
+}
+
+func TestDefaultBlockDataHashingWidthChange(t *testing.T) {
+	m := NewManagerImpl()
+	m.BeginConfig()
+	if err := m.ProposeConfig(makeConfigItem(BatchSizeKey, &ab.BatchSize{Messages: 10})); err != nil {
+		t.Fatalf("Should have accepted the batch size: %s", err)
+	}
+	m.CommitConfig()
+
+	m.BeginConfig()
+	if err := m.ProposeConfig(makeConfigItem(BlockDataHashingStructureKey, &ab.BlockDataHashingStructure{Width: 4})); err == nil {
+		t.Fatalf("Should have rejected a change of the default block data hashing width")
+	}
+	if err := m.ProposeConfig(makeConfigItem(BlockDataHashingStructureKey, &ab.BlockDataHashingStructure{Width: cb.DefaultBlockDataHashingWidth})); err != nil {
+		t.Fatalf("Should have accepted the default block data hashing width: %s", err)
+	}
+	m.CommitConfig()
+	if m.BlockDataHashingWidth() != cb.DefaultBlockDataHashingWidth {
+		t.Fatalf("Expected the default block data hashing width, got %d", m.BlockDataHashingWidth())
+	}
+}
+
+func TestBlockDataHashingWidthKeptWhenUnset(t *testing.T) {
+	m := NewManagerImpl()
+	m.BeginConfig()
+	if err := m.ProposeConfig(makeConfigItem(BlockDataHashingStructureKey, &ab.BlockDataHashingStructure{Width: 4})); err != nil {
+		t.Fatalf("Should have accepted the block data hashing width: %s", err)
+	}
+	m.CommitConfig()
+
+	m.BeginConfig()
+	if err := m.ProposeConfig(makeConfigItem(BatchSizeKey, &ab.BatchSize{Messages: 10})); err != nil {
+		t.Fatalf("Should have accepted the batch size: %s", err)
+	}
+	m.CommitConfig()
+	if m.BlockDataHashingWidth() != 4 {
+		t.Fatalf("Expected the block data hashing width of the chain to be kept, got %d", m.BlockDataHashingWidth())
+	}
+}
+
+func TestBlockDataHashingWidthOfGenesis(t *testing.T) {
+	makeGenesisData := func(items ...*cb.ConfigurationItem) *cb.BlockData {
+		var signedItems []*cb.SignedConfigurationItem
+		for _, item := range items {
+			signedItems = append(signedItems, &cb.SignedConfigurationItem{ConfigurationItem: util.MarshalOrPanic(item)})
+		}
+		chainHeader := util.MakeChainHeader(cb.HeaderType_CONFIGURATION_TRANSACTION, 1, []byte("testchain"), 0)
+		payload := &cb.Payload{
+			Header: util.MakePayloadHeader(chainHeader, util.MakeSignatureHeader(nil, nil)),
+			Data:   util.MarshalOrPanic(util.MakeConfigurationEnvelope(signedItems...)),
+		}
+		return &cb.BlockData{Data: [][]byte{util.MarshalOrPanic(&cb.Envelope{Payload: util.MarshalOrPanic(payload)})}}
+	}
+
+	if width := BlockDataHashingWidth(makeGenesisData(makeConfigItem(BatchSizeKey, &ab.BatchSize{Messages: 10}))); width != cb.DefaultBlockDataHashingWidth {
+		t.Fatalf("Expected the default width without a configuration item, got %d", width)
+	}
+	if width := BlockDataHashingWidth(makeGenesisData(makeConfigItem(BlockDataHashingStructureKey, &ab.BlockDataHashingStructure{Width: 8}))); width != 8 {
+		t.Fatalf("Expected the configured width 8, got %d", width)
+	}
+}
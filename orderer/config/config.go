@@ -48,7 +48,12 @@ type General struct {
 	ListenAddress string
 	ListenPort    uint16
 	GenesisMethod string
+	GenesisFile   string
 	Profile       Profile
+	Metrics       Metrics
+	LocalMSP      LocalMSP
+	TLS           TLS
+	Quota         Quota
 }
 
 // Profile contains configuration for Go pprof profiling
@@ -57,6 +62,35 @@ type Profile struct {
 	Address string
 }
 
+// Metrics contains configuration for the HTTP service exposing the metrics in Prometheus format
+type Metrics struct {
+	Enabled bool
+	Address string
+}
+
+// LocalMSP contains config for the MSP providing the identity which signs the blocks
+type LocalMSP struct {
+	ConfigFile string
+	ID         string
+	Identity   string
+}
+
+// TLS contains config for the TLS connections of the Broadcast and Deliver clients
+type TLS struct {
+	Enabled       bool
+	Certificate   string
+	PrivateKey    string
+	ClientAuth    string
+	ClientRootCAs []string
+}
+
+// Quota contains config for the limits on the messages broadcast by each client, zero means unlimited
+type Quota struct {
+	MaxMessageBytes   uint
+	MessagesPerSecond uint
+	BytesPerSecond    uint
+}
+
 // RAMLedger contains config for the RAM ledger
 type RAMLedger struct {
 	HistorySize uint
@@ -106,10 +140,24 @@ var defaults = TopLevel{
 		ListenAddress: "127.0.0.1",
 		ListenPort:    7050,
 		GenesisMethod: "static",
+		GenesisFile:   "genesis.block",
 		Profile: Profile{
 			Enabled: false,
 			Address: "0.0.0.0:6060",
 		},
+		Metrics: Metrics{
+			Enabled: false,
+			Address: "0.0.0.0:8443",
+		},
+		LocalMSP: LocalMSP{
+			ConfigFile: "msp/orderer-config.json",
+			ID:         "DEFAULT",
+			Identity:   "ORDERER",
+		},
+		TLS: TLS{
+			Enabled:    false,
+			ClientAuth: "none",
+		},
 	},
 	RAMLedger: RAMLedger{
 		HistorySize: 10000,
@@ -161,9 +209,27 @@ func (c *TopLevel) completeInitialization() {
 			c.General.ListenPort = defaults.General.ListenPort
 		case c.General.GenesisMethod == "":
 			c.General.GenesisMethod = defaults.General.GenesisMethod
+		case c.General.GenesisMethod == "file" && c.General.GenesisFile == "":
+			logger.Infof("General.GenesisFile unset, setting to %s", defaults.General.GenesisFile)
+			c.General.GenesisFile = defaults.General.GenesisFile
 		case c.General.Profile.Enabled && (c.General.Profile.Address == ""):
 			logger.Infof("Profiling enabled and General.Profile.Address unset, setting to %s", defaults.General.Profile.Address)
 			c.General.Profile.Address = defaults.General.Profile.Address
+		case c.General.Metrics.Enabled && (c.General.Metrics.Address == ""):
+			logger.Infof("Metrics enabled and General.Metrics.Address unset, setting to %s", defaults.General.Metrics.Address)
+			c.General.Metrics.Address = defaults.General.Metrics.Address
+		case c.General.LocalMSP.ConfigFile == "":
+			logger.Infof("General.LocalMSP.ConfigFile unset, setting to %s", defaults.General.LocalMSP.ConfigFile)
+			c.General.LocalMSP.ConfigFile = defaults.General.LocalMSP.ConfigFile
+		case c.General.LocalMSP.ID == "":
+			logger.Infof("General.LocalMSP.ID unset, setting to %s", defaults.General.LocalMSP.ID)
+			c.General.LocalMSP.ID = defaults.General.LocalMSP.ID
+		case c.General.LocalMSP.Identity == "":
+			logger.Infof("General.LocalMSP.Identity unset, setting to %s", defaults.General.LocalMSP.Identity)
+			c.General.LocalMSP.Identity = defaults.General.LocalMSP.Identity
+		case c.General.TLS.ClientAuth == "":
+			logger.Infof("General.TLS.ClientAuth unset, setting to %s", defaults.General.TLS.ClientAuth)
+			c.General.TLS.ClientAuth = defaults.General.TLS.ClientAuth
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", defaults.FileLedger.Prefix)
 			c.FileLedger.Prefix = defaults.FileLedger.Prefix
@@ -230,5 +296,10 @@ func Load() *TopLevel {
 
 	uconf.completeInitialization()
 
+	// The MSP configuration ships next to orderer.yaml
+	if !filepath.IsAbs(uconf.General.LocalMSP.ConfigFile) {
+		uconf.General.LocalMSP.ConfigFile = filepath.Join(filepath.Dir(config.ConfigFileUsed()), uconf.General.LocalMSP.ConfigFile)
+	}
+
 	return &uconf
 }
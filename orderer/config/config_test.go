@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -120,3 +121,14 @@ func TestEnvInnerVar(t *testing.T) {
 		t.Fatalf("Environmental override of inner config test 2 did not work")
 	}
 }
+
+func TestLocalMSPConfigFile(t *testing.T) {
+	config := Load()
+
+	if !filepath.IsAbs(config.General.LocalMSP.ConfigFile) {
+		t.Fatalf("Expected the MSP configuration file to be resolved against the directory of orderer.yaml, got %s", config.General.LocalMSP.ConfigFile)
+	}
+	if _, err := os.Stat(config.General.LocalMSP.ConfigFile); err != nil {
+		t.Fatalf("The default MSP configuration file should exist: %s", err)
+	}
+}
@@ -21,8 +21,11 @@ import (
 	"sync"
 	"time"
 
-	"github.com/hyperledger/fabric/orderer/common/bootstrap/static"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
 	"github.com/hyperledger/fabric/orderer/config"
+	"github.com/hyperledger/fabric/orderer/rawledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 
@@ -36,9 +39,14 @@ type Broadcaster interface {
 }
 
 type broadcasterImpl struct {
-	producer Producer
-	config   *config.TopLevel
-	once     sync.Once
+	producer            Producer
+	config              *config.TopLevel
+	signer              rawledger.BlockSigner
+	quota               *broadcast.Quota
+	chainID             string
+	configManager       configtx.Manager
+	sharedConfigManager sharedconfig.Manager
+	once                sync.Once
 
 	batchChan  chan *cb.Envelope
 	messages   [][]byte
@@ -50,14 +58,18 @@ type broadcastSessionResponder struct {
 	queue chan *ab.BroadcastResponse
 }
 
-func newBroadcaster(conf *config.TopLevel) Broadcaster {
-	genesisBlock, _ := static.New().GenesisBlock()
+func newBroadcaster(conf *config.TopLevel, genesisBlock *cb.Block, signer rawledger.BlockSigner, quota *broadcast.Quota, configManager configtx.Manager, sharedConfigManager sharedconfig.Manager) Broadcaster {
 	return &broadcasterImpl{
-		producer:   newProducer(conf),
-		config:     conf,
-		batchChan:  make(chan *cb.Envelope, conf.General.BatchSize),
-		messages:   genesisBlock.GetData().Data,
-		nextNumber: 0,
+		producer:            newProducer(withBrokers(conf, sharedConfigManager)),
+		config:              conf,
+		signer:              signer,
+		quota:               quota,
+		chainID:             string(configManager.ChainID()),
+		configManager:       configManager,
+		sharedConfigManager: sharedConfigManager,
+		batchChan:           make(chan *cb.Envelope, conf.General.BatchSize),
+		messages:            genesisBlock.GetData().Data,
+		nextNumber:          0,
 	}
 }
 
@@ -70,7 +82,7 @@ func (b *broadcasterImpl) Broadcast(stream ab.AtomicBroadcast_BroadcastServer) e
 		// otherwise consumers will throw an exception.
 		b.sendBlock()
 		// Spawn the goroutine that cuts blocks
-		go b.cutBlock(b.config.General.BatchTimeout, b.config.General.BatchSize)
+		go b.cutBlock()
 	})
 	return b.recvRequests(stream)
 }
@@ -87,14 +99,24 @@ func (b *broadcasterImpl) sendBlock() error {
 	data := &cb.BlockData{
 		Data: b.messages,
 	}
+	hashingWidth := cb.DefaultBlockDataHashingWidth
+	if b.sharedConfigManager != nil {
+		hashingWidth = b.sharedConfigManager.BlockDataHashingWidth()
+	}
 	block := &cb.Block{
 		Header: &cb.BlockHeader{
 			Number:       b.nextNumber,
 			PreviousHash: b.prevHash,
-			DataHash:     data.Hash(),
+			DataHash:     data.MerkleHash(hashingWidth),
 		},
 		Data: data,
 	}
+	// the genesis block is part of the configuration and is not signed
+	if b.signer != nil && block.Header.Number > 0 {
+		if err := b.signer.SignBlock(block); err != nil {
+			logger.Fatalf("Error signing block %d: %s", block.Header.Number, err)
+		}
+	}
 	logger.Debugf("Prepared block %d with %d messages (%+v)", block.Header.Number, len(block.Data.Data), block)
 
 	b.messages = [][]byte{}
@@ -109,7 +131,94 @@ func (b *broadcasterImpl) sendBlock() error {
 	return b.producer.Send(blockBytes)
 }
 
-func (b *broadcasterImpl) cutBlock(period time.Duration, maxSize uint) {
+// batchSize returns the batch size of the current configuration of the chain
+func (b *broadcasterImpl) batchSize() int {
+	if b.sharedConfigManager != nil {
+		if batchSize := b.sharedConfigManager.BatchSize(); batchSize > 0 {
+			return batchSize
+		}
+	}
+	return int(b.config.General.BatchSize)
+}
+
+// batchTimeout returns the batch timeout of the current configuration of the chain
+func (b *broadcasterImpl) batchTimeout() time.Duration {
+	if b.sharedConfigManager != nil {
+		if batchTimeout := b.sharedConfigManager.BatchTimeout(); batchTimeout > 0 {
+			return batchTimeout
+		}
+	}
+	return b.config.General.BatchTimeout
+}
+
+// configuration returns whether msg is a configuration transaction, and its
+// configuration envelope if it could be decoded
+func (b *broadcasterImpl) configuration(msg *cb.Envelope) (*cb.ConfigurationEnvelope, bool) {
+	if b.configManager == nil {
+		return nil, false
+	}
+
+	payload := &cb.Payload{}
+	if err := proto.Unmarshal(msg.Payload, payload); err != nil {
+		return nil, false
+	}
+
+	if payload.Header == nil || payload.Header.ChainHeader == nil || payload.Header.ChainHeader.Type != int32(cb.HeaderType_CONFIGURATION_TRANSACTION) {
+		return nil, false
+	}
+
+	configEnvelope := &cb.ConfigurationEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+		return nil, true
+	}
+	return configEnvelope, true
+}
+
+// reconfigure applies a configuration transaction at the block boundary: the pending
+// messages are sent in a block, followed by a block with only the configuration transaction
+func (b *broadcasterImpl) reconfigure(configEnvelope *cb.ConfigurationEnvelope, data []byte) error {
+	brokers := withBrokers(b.config, b.sharedConfigManager).Kafka.Brokers
+
+	if err := b.configManager.Apply(configEnvelope); err != nil {
+		logger.Warningf("A configuration change was accepted for ordering but could not be applied: %s", err)
+		return nil
+	}
+
+	if len(b.messages) > 0 {
+		if err := b.sendBlock(); err != nil {
+			return err
+		}
+	}
+	b.messages = append(b.messages, data)
+	if err := b.sendBlock(); err != nil {
+		return err
+	}
+
+	newConf := withBrokers(b.config, b.sharedConfigManager)
+	if !sameBrokers(brokers, newConf.Kafka.Brokers) {
+		logger.Infof("Kafka brokers changed from %v to %v, reconnecting", brokers, newConf.Kafka.Brokers)
+		if err := b.producer.Close(); err != nil {
+			logger.Warningf("Error closing the producer: %s", err)
+		}
+		b.producer = newProducer(newConf)
+	}
+	return nil
+}
+
+func sameBrokers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *broadcasterImpl) cutBlock() {
+	period := b.batchTimeout()
 	timer := time.NewTimer(period)
 
 	for {
@@ -119,8 +228,24 @@ func (b *broadcasterImpl) cutBlock(period time.Duration, maxSize uint) {
 			if err != nil {
 				panic(fmt.Errorf("Error marshaling what should be a valid proto message: %s", err))
 			}
+			if configEnvelope, ok := b.configuration(msg); ok {
+				if configEnvelope == nil {
+					logger.Warning("Dropping a malformed configuration transaction")
+					continue
+				}
+				if err := b.reconfigure(configEnvelope, data); err != nil {
+					panic(fmt.Errorf("Cannot communicate with Kafka broker: %s", err))
+				}
+				// The batch timeout may have changed with the configuration
+				if !timer.Stop() {
+					<-timer.C
+				}
+				period = b.batchTimeout()
+				timer.Reset(period)
+				continue
+			}
 			b.messages = append(b.messages, data)
-			if len(b.messages) >= int(maxSize) {
+			if len(b.messages) >= b.batchSize() {
 				if !timer.Stop() {
 					<-timer.C
 				}
@@ -141,6 +266,7 @@ func (b *broadcasterImpl) cutBlock(period time.Duration, maxSize uint) {
 }
 
 func (b *broadcasterImpl) recvRequests(stream ab.AtomicBroadcast_BroadcastServer) error {
+	client := broadcast.StreamClient(stream.Context())
 	reply := new(ab.BroadcastResponse)
 	for {
 		msg, err := stream.Recv()
@@ -149,8 +275,22 @@ func (b *broadcasterImpl) recvRequests(stream ab.AtomicBroadcast_BroadcastServer
 			return err
 		}
 
-		b.batchChan <- msg
-		reply.Status = cb.Status_SUCCESS // TODO This shouldn't always be a success
+		if reason := b.quota.Check(client, msg); reason != "" {
+			broadcast.RecordRejection(b.chainID, client, reason)
+			reply.Status = broadcast.RejectionStatus(reason)
+		} else if configEnvelope, ok := b.configuration(msg); ok && (configEnvelope == nil || b.configManager.Validate(configEnvelope) != nil) {
+			broadcast.RecordRejection(b.chainID, client, broadcast.RejectedBadRequest)
+			reply.Status = cb.Status_BAD_REQUEST
+		} else {
+			// Do not block the client while the batch is full, it may retry
+			select {
+			case b.batchChan <- msg:
+				reply.Status = cb.Status_SUCCESS // TODO This shouldn't always be a success
+			default:
+				broadcast.RecordRejection(b.chainID, client, broadcast.RejectedQueueFull)
+				reply.Status = cb.Status_SERVICE_UNAVAILABLE
+			}
+		}
 
 		if err := stream.Send(reply); err != nil {
 			logger.Info("Cannot send broadcast reply to client")
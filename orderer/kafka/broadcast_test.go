@@ -18,6 +18,7 @@ package kafka
 
 import (
 	"bytes"
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
@@ -363,3 +364,179 @@ func TestBroadcastClose(t *testing.T) {
 	}
 
 }
+
+type mockConfigManager struct {
+	validateErr error
+	applyFunc   func()
+}
+
+func (mcm *mockConfigManager) Validate(configtx *cb.ConfigurationEnvelope) error {
+	return mcm.validateErr
+}
+
+func (mcm *mockConfigManager) Apply(configtx *cb.ConfigurationEnvelope) error {
+	if mcm.applyFunc != nil {
+		mcm.applyFunc()
+	}
+	return nil
+}
+
+func (mcm *mockConfigManager) ChainID() []byte {
+	return []byte("mockChainID")
+}
+
+type mockSharedConfigManager struct {
+	batchSize int
+}
+
+func (mscm *mockSharedConfigManager) ConsensusType() string {
+	return "kafka"
+}
+
+func (mscm *mockSharedConfigManager) BatchSize() int {
+	return mscm.batchSize
+}
+
+func (mscm *mockSharedConfigManager) BatchTimeout() time.Duration {
+	return 0
+}
+
+func (mscm *mockSharedConfigManager) KafkaBrokers() []string {
+	return nil
+}
+
+func (mscm *mockSharedConfigManager) BlockDataHashingWidth() uint32 {
+	return cb.DefaultBlockDataHashingWidth
+}
+
+func newConfigEnvelope(t *testing.T) *cb.Envelope {
+	data, err := proto.Marshal(&cb.ConfigurationEnvelope{})
+	if err != nil {
+		t.Fatal("Error marshaling configuration envelope:", err)
+	}
+	payload, err := proto.Marshal(&cb.Payload{
+		Header: &cb.Header{ChainHeader: &cb.ChainHeader{Type: int32(cb.HeaderType_CONFIGURATION_TRANSACTION)}},
+		Data:   data,
+	})
+	if err != nil {
+		t.Fatal("Error marshaling payload:", err)
+	}
+	return &cb.Envelope{Payload: payload}
+}
+
+func readBlock(t *testing.T, disk chan []byte) *cb.Block {
+	select {
+	case in := <-disk:
+		block := new(cb.Block)
+		if err := proto.Unmarshal(in, block); err != nil {
+			t.Fatal("Expected a block on the broker's disk")
+		}
+		return block
+	case <-time.After(testConf.General.BatchTimeout + timePadding):
+		t.Fatal("Should have received a block by now")
+	}
+	return nil
+}
+
+// startBroadcast serves the stream in its own goroutine, a Broadcast error is sent on the
+// returned channel so that the test goroutine fails on it rather than the serving one
+func startBroadcast(mb Broadcaster, mbs *mockBroadcastStream) <-chan error {
+	broadcastErr := make(chan error, 1)
+	go func() {
+		if err := mb.Broadcast(mbs); err != nil {
+			broadcastErr <- err
+		}
+	}()
+	return broadcastErr
+}
+
+func TestBroadcastReconfigure(t *testing.T) {
+	disk := make(chan []byte)
+
+	mb := mockNewBroadcaster(t, testConf, oldestOffset, disk)
+	defer testClose(t, mb)
+
+	scm := &mockSharedConfigManager{}
+	mb.(*broadcasterImpl).sharedConfigManager = scm
+	mb.(*broadcasterImpl).configManager = &mockConfigManager{applyFunc: func() { scm.batchSize = 1 }}
+
+	mbs := newMockBroadcastStream(t)
+	broadcastErr := startBroadcast(mb, mbs)
+
+	<-disk // We tested the checkpoint block in a previous test, so we can ignore it now
+
+	configTx := newConfigEnvelope(t)
+	go func() {
+		mbs.incoming <- &cb.Envelope{Payload: []byte("message 0")}
+		mbs.incoming <- configTx
+		mbs.incoming <- &cb.Envelope{Payload: []byte("message 1")}
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case reply := <-mbs.outgoing:
+			if reply.Status != cb.Status_SUCCESS {
+				t.Fatal("Client should have received a SUCCESS reply")
+			}
+		case err := <-broadcastErr:
+			t.Fatal("Broadcast error:", err)
+		}
+	}
+
+	if block := readBlock(t, disk); len(block.Data.Data) != 1 {
+		t.Fatalf("Expected the pending message to be cut in a block before the configuration, got %d messages", len(block.Data.Data))
+	}
+
+	block := readBlock(t, disk)
+	if len(block.Data.Data) != 1 {
+		t.Fatalf("Expected the configuration transaction to be alone in its block, got %d messages", len(block.Data.Data))
+	}
+	if configBytes, _ := proto.Marshal(configTx); !bytes.Equal(block.Data.Data[0], configBytes) {
+		t.Fatal("Expected the block to hold the configuration transaction")
+	}
+
+	// The new batch size of 1 cuts the following message in its own block right away
+	select {
+	case in := <-disk:
+		block := new(cb.Block)
+		if err := proto.Unmarshal(in, block); err != nil {
+			t.Fatal("Expected a block on the broker's disk")
+		}
+		if len(block.Data.Data) != 1 {
+			t.Fatalf("Expected block to have 1 message instead of %d", len(block.Data.Data))
+		}
+	case err := <-broadcastErr:
+		t.Fatal("Broadcast error:", err)
+	case <-time.After(testConf.General.BatchTimeout / 2):
+		t.Fatal("Should have cut a block at the new batch size before the batch timeout")
+	}
+}
+
+func TestBroadcastInvalidConfiguration(t *testing.T) {
+	disk := make(chan []byte)
+
+	mb := mockNewBroadcaster(t, testConf, oldestOffset, disk)
+	defer testClose(t, mb)
+
+	mb.(*broadcasterImpl).configManager = &mockConfigManager{validateErr: fmt.Errorf("invalid")}
+
+	mbs := newMockBroadcastStream(t)
+	broadcastErr := startBroadcast(mb, mbs)
+
+	<-disk // We tested the checkpoint block in a previous test, so we can ignore it now
+
+	go func() {
+		mbs.incoming <- newConfigEnvelope(t)
+	}()
+
+	select {
+	case reply := <-mbs.outgoing:
+		if reply.Status != cb.Status_BAD_REQUEST {
+			t.Fatal("Client should have received a BAD_REQUEST reply for an invalid configuration")
+		}
+	case err := <-broadcastErr:
+		t.Fatal("Broadcast error:", err)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Should have received a broadcast reply by the orderer by now")
+	}
+}
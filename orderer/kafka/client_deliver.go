@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/deliver"
 	"github.com/hyperledger/fabric/orderer/config"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -30,18 +31,25 @@ type clientDelivererImpl struct {
 	brokerFunc   func(*config.TopLevel) Broker
 	consumerFunc func(*config.TopLevel, int64) (Consumer, error) // This resets the consumer.
 
-	consumer Consumer
-	config   *config.TopLevel
-	deadChan chan struct{}
+	consumer   Consumer
+	config     *config.TopLevel
+	deadChan   chan struct{}
+	authorizer *deliver.SeekAuthorizer
 
 	errChan   chan error
 	updChan   chan *ab.DeliverUpdate
 	tokenChan chan struct{}
 	lastACK   int64
 	window    int64
+
+	nextBlock      int64  // the number of the next block to send
+	newestBlock    int64  // the newest block known to be available
+	stop           *int64 // the last block to send, nil to send blocks as they are created
+	failIfNotReady bool
+	done           bool // whether the blocks of the last seek have all been sent
 }
 
-func newClientDeliverer(conf *config.TopLevel, deadChan chan struct{}) Deliverer {
+func newClientDeliverer(conf *config.TopLevel, deadChan chan struct{}, authorizer *deliver.SeekAuthorizer) Deliverer {
 	brokerFunc := func(conf *config.TopLevel) Broker {
 		return newBroker(conf)
 	}
@@ -53,10 +61,11 @@ func newClientDeliverer(conf *config.TopLevel, deadChan chan struct{}) Deliverer
 		brokerFunc:   brokerFunc,
 		consumerFunc: consumerFunc,
 
-		config:   conf,
-		deadChan: deadChan,
-		errChan:  make(chan error),
-		updChan:  make(chan *ab.DeliverUpdate), // TODO Size this properly
+		config:     conf,
+		deadChan:   deadChan,
+		authorizer: authorizer,
+		errChan:    make(chan error),
+		updChan:    make(chan *ab.DeliverUpdate), // TODO Size this properly
 	}
 }
 
@@ -99,8 +108,12 @@ func (cd *clientDelivererImpl) sendBlocks(stream ab.AtomicBroadcast_DeliverServe
 			return err
 		case upd = <-cd.updChan:
 			switch t := upd.GetType().(type) {
-			case *ab.DeliverUpdate_Seek:
-				err = cd.processSeek(t)
+			case *ab.DeliverUpdate_Seek, *ab.DeliverUpdate_SignedSeek:
+				seek, status := cd.authorizer.Authorize(upd)
+				if status != cb.Status_SUCCESS {
+					return cd.reject(stream, status)
+				}
+				err = cd.processSeek(seek)
 			case *ab.DeliverUpdate_Acknowledgement:
 				err = cd.processACK(t)
 			}
@@ -111,7 +124,7 @@ func (cd *clientDelivererImpl) sendBlocks(stream ab.AtomicBroadcast_DeliverServe
 				switch err.Error() {
 				case seekOutOfRangeError:
 					errorStatus = cb.Status_NOT_FOUND
-				case ackOutOfRangeError, windowOutOfRangeError:
+				case ackOutOfRangeError, windowOutOfRangeError, stopOutOfRangeError:
 					errorStatus = cb.Status_BAD_REQUEST
 				default:
 					errorStatus = cb.Status_SERVICE_UNAVAILABLE
@@ -124,6 +137,13 @@ func (cd *clientDelivererImpl) sendBlocks(stream ab.AtomicBroadcast_DeliverServe
 				return fmt.Errorf("Failed to process received update: %s", err)
 			}
 		case <-cd.tokenChan:
+			if cd.failIfNotReady && !cd.ready() {
+				logger.Debugf("Block %d is not ready, failing the seek", cd.nextBlock)
+				if err := cd.endSeek(stream, cb.Status_NOT_FOUND); err != nil {
+					return err
+				}
+				continue
+			}
 			select {
 			case data := <-cd.consumer.Recv():
 				err := proto.Unmarshal(data.Value, block)
@@ -138,6 +158,13 @@ func (cd *clientDelivererImpl) sendBlocks(stream ab.AtomicBroadcast_DeliverServe
 				}
 				logger.Debugf("Sent block %v to client (prevHash: %v, messages: %v)\n",
 					block.Header.Number, block.Header.PreviousHash, block.Data.Data)
+				cd.nextBlock = int64(block.Header.Number) + 1
+				if cd.stop != nil && int64(block.Header.Number) >= *cd.stop {
+					logger.Debugf("Sent the last requested block %d", block.Header.Number)
+					if err := cd.endSeek(stream, cb.Status_SUCCESS); err != nil {
+						return err
+					}
+				}
 			default:
 				// Return the push token if there are no messages
 				// available from the ordering service.
@@ -147,12 +174,48 @@ func (cd *clientDelivererImpl) sendBlocks(stream ab.AtomicBroadcast_DeliverServe
 	}
 }
 
-func (cd *clientDelivererImpl) processSeek(msg *ab.DeliverUpdate_Seek) error {
+// reject replies with status to a seek request which is not authorized, and ends the stream
+func (cd *clientDelivererImpl) reject(stream ab.AtomicBroadcast_DeliverServer, status cb.Status) error {
+	reply := &ab.DeliverResponse{Type: &ab.DeliverResponse_Error{Error: status}}
+	if err := stream.Send(reply); err != nil {
+		return fmt.Errorf("Failed to send error response to the client: %s", err)
+	}
+	return fmt.Errorf("Rejected seek request: %s", status)
+}
+
+// endSeek replies with status once the blocks of a seek have been sent, or the next
+// one is not ready, and stops sending blocks until the next seek
+func (cd *clientDelivererImpl) endSeek(stream ab.AtomicBroadcast_DeliverServer, status cb.Status) error {
+	cd.disablePush()
+	cd.done = true
+	reply := &ab.DeliverResponse{Type: &ab.DeliverResponse_Error{Error: status}}
+	if err := stream.Send(reply); err != nil {
+		return fmt.Errorf("Failed to send status to the client: %s", err)
+	}
+	return nil
+}
+
+// ready returns true if the next block has been created, asking the brokers
+// again only once the newest block known to be available has been sent
+func (cd *clientDelivererImpl) ready() bool {
+	if cd.nextBlock <= cd.newestBlock {
+		return true
+	}
+	newestAvailable, err := cd.getOffset(int64(-1))
+	if err != nil {
+		logger.Debug("Failed to get the newest offset:", err)
+		return false
+	}
+	cd.newestBlock = newestAvailable - 1
+	return cd.nextBlock <= cd.newestBlock
+}
+
+func (cd *clientDelivererImpl) processSeek(msg *ab.SeekInfo) error {
 	var err error
 	var seek, window int64
 	logger.Debug("Received SEEK message")
 
-	window = int64(msg.Seek.WindowSize)
+	window = int64(msg.WindowSize)
 	if window <= 0 || window > int64(cd.config.General.MaxWindowSize) {
 		return errors.New(windowOutOfRangeError)
 	}
@@ -169,13 +232,13 @@ func (cd *clientDelivererImpl) processSeek(msg *ab.DeliverUpdate_Seek) error {
 	}
 	newestAvailable-- // Cause in the case of newest, the library actually gives us the seqNo of the *next* new block
 
-	switch msg.Seek.Start {
+	switch msg.Start {
 	case ab.SeekInfo_OLDEST:
 		seek = oldestAvailable
 	case ab.SeekInfo_NEWEST:
 		seek = newestAvailable
 	case ab.SeekInfo_SPECIFIED:
-		seek = int64(msg.Seek.SpecifiedNumber)
+		seek = int64(msg.SpecifiedNumber)
 		if !(seek >= oldestAvailable && seek <= newestAvailable) {
 			return errors.New(seekOutOfRangeError)
 		}
@@ -183,6 +246,23 @@ func (cd *clientDelivererImpl) processSeek(msg *ab.DeliverUpdate_Seek) error {
 
 	logger.Debug("Requested seek number set to", seek)
 
+	cd.stop = nil
+	if msg.Stop != nil {
+		stop := int64(msg.Stop.SpecifiedNumber)
+		if msg.Stop.Type == ab.SeekStop_NEWEST {
+			stop = newestAvailable
+		}
+		if stop < seek {
+			return errors.New(stopOutOfRangeError)
+		}
+		cd.stop = &stop
+		logger.Debug("Requested stop number set to", stop)
+	}
+	cd.failIfNotReady = msg.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY
+	cd.nextBlock = seek
+	cd.newestBlock = newestAvailable
+	cd.done = false
+
 	cd.disablePush()
 	if err := cd.Close(); err != nil {
 		return err
@@ -231,6 +311,10 @@ func (cd *clientDelivererImpl) processACK(msg *ab.DeliverUpdate_Acknowledgement)
 	}
 	newTokenCount := newACK - cd.lastACK + remTokens
 	cd.lastACK = newACK
+	if cd.done {
+		// No more blocks are sent until the next seek
+		return nil
+	}
 	cd.enablePush(newTokenCount)
 	return nil
 }
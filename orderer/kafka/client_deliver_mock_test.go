@@ -41,10 +41,11 @@ func mockNewClientDeliverer(t *testing.T, conf *config.TopLevel, deadChan chan s
 			brokerFunc:   mockBrokerFunc,
 			consumerFunc: mockConsumerFunc,
 
-			config:   conf,
-			deadChan: deadChan,
-			errChan:  make(chan error),
-			updChan:  make(chan *ab.DeliverUpdate),
+			config:     conf,
+			deadChan:   deadChan,
+			authorizer: testNewSeekAuthorizer(nil),
+			errChan:    make(chan error),
+			updChan:    make(chan *ab.DeliverUpdate),
 		},
 		t: t,
 	}
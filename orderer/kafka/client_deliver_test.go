@@ -17,9 +17,11 @@ limitations under the License.
 package kafka
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
 
@@ -181,3 +183,80 @@ func testClientDeliverAckFunc(label string, seek, window uint64, threshold, expe
 		}
 	}
 }
+
+func TestClientDeliverSeekForbidden(t *testing.T) {
+	mds := newMockDeliverStream(t)
+
+	dc := make(chan struct{})
+	defer close(dc) // Kill the getBlocks goroutine
+
+	mcd := mockNewClientDeliverer(t, testConf, dc).(*mockClientDelivererImpl)
+	mcd.authorizer = testNewSeekAuthorizer(fmt.Errorf("Not a reader"))
+	defer testClose(t, mcd)
+	go func() {
+		if err := mcd.Deliver(mds); err == nil {
+			t.Fatal("Should have received an error response")
+		}
+	}()
+
+	mds.incoming <- testNewSeekMessage("oldest", 0, 10)
+	select {
+	case msg := <-mds.outgoing:
+		if msg.GetError() != cb.Status_FORBIDDEN {
+			t.Fatalf("Expected a FORBIDDEN response, got %v", msg)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Should have received an error response")
+	}
+}
+
+func TestClientDeliverSeekStop(t *testing.T) {
+	t.Run("specified", testClientDeliverSeekStopFunc(uint64(middleOffset), &ab.SeekStop{Type: ab.SeekStop_SPECIFIED, SpecifiedNumber: uint64(middleOffset) + 2}, ab.SeekInfo_BLOCK_UNTIL_READY, 3, cb.Status_SUCCESS))
+	t.Run("newest", testClientDeliverSeekStopFunc(uint64(newestOffset)-3, &ab.SeekStop{Type: ab.SeekStop_NEWEST}, ab.SeekInfo_BLOCK_UNTIL_READY, 3, cb.Status_SUCCESS))
+	t.Run("fail-if-not-ready", testClientDeliverSeekStopFunc(uint64(newestOffset)-3, nil, ab.SeekInfo_FAIL_IF_NOT_READY, 3, cb.Status_NOT_FOUND))
+	t.Run("stop-before-start", testClientDeliverSeekStopFunc(uint64(middleOffset), &ab.SeekStop{Type: ab.SeekStop_SPECIFIED, SpecifiedNumber: uint64(middleOffset) - 1}, ab.SeekInfo_BLOCK_UNTIL_READY, 0, cb.Status_BAD_REQUEST))
+}
+
+func testClientDeliverSeekStopFunc(seek uint64, stop *ab.SeekStop, behavior ab.SeekInfo_SeekBehavior, expected int, status cb.Status) func(t *testing.T) {
+	return func(t *testing.T) {
+		mds := newMockDeliverStream(t)
+
+		dc := make(chan struct{})
+		defer close(dc) // Kill the getBlocks goroutine
+
+		mcd := mockNewClientDeliverer(t, testConf, dc)
+		defer testClose(t, mcd)
+		go mcd.Deliver(mds)
+
+		mds.incoming <- &ab.DeliverUpdate{
+			Type: &ab.DeliverUpdate_Seek{
+				Seek: &ab.SeekInfo{
+					Start:           ab.SeekInfo_SPECIFIED,
+					SpecifiedNumber: seek,
+					Stop:            stop,
+					Behavior:        behavior,
+					WindowSize:      10,
+				},
+			},
+		}
+		count := 0
+		for {
+			select {
+			case msg := <-mds.outgoing:
+				if msg.GetBlock() != nil {
+					count++
+					continue
+				}
+				if msg.GetError() != status {
+					t.Fatalf("Expected status %v, got %v", status, msg.GetError())
+				}
+				if count != expected {
+					t.Fatalf("Delivered %d blocks to the client before status %v, expected %d", count, status, expected)
+				}
+				return
+			case <-time.After(500 * time.Millisecond):
+				t.Fatalf("Delivered %d blocks to the client but no status, expected %d blocks and status %v", count, expected, status)
+			}
+		}
+	}
+}
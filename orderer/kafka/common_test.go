@@ -17,11 +17,38 @@ limitations under the License.
 package kafka
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/hyperledger/fabric/orderer/common/deliver"
+	"github.com/hyperledger/fabric/orderer/common/policies"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
 
+type mockPolicy struct {
+	policyResult error
+}
+
+func (mp *mockPolicy) Evaluate(headers [][]byte, payload []byte, identities [][]byte, signatures [][]byte) error {
+	if mp == nil {
+		return fmt.Errorf("Invoked nil policy")
+	}
+	return mp.policyResult
+}
+
+type mockPolicyManager struct {
+	policy *mockPolicy
+}
+
+func (mpm *mockPolicyManager) GetPolicy(id string) (policies.Policy, bool) {
+	return mpm.policy, (mpm.policy != nil)
+}
+
+// testNewSeekAuthorizer returns a seek authorizer whose readers policy evaluates to policyResult
+func testNewSeekAuthorizer(policyResult error) *deliver.SeekAuthorizer {
+	return deliver.NewSeekAuthorizer(nil, &mockPolicyManager{policy: &mockPolicy{policyResult: policyResult}})
+}
+
 func testClose(t *testing.T, x Closeable) {
 	if err := x.Close(); err != nil {
 		t.Fatal("Cannot close mock resource:", err)
@@ -19,6 +19,8 @@ package kafka
 import (
 	"sync"
 
+	"github.com/hyperledger/fabric/orderer/common/deliver"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
 	"github.com/hyperledger/fabric/orderer/config"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
@@ -30,22 +32,26 @@ type Deliverer interface {
 }
 
 type delivererImpl struct {
-	config   *config.TopLevel
-	deadChan chan struct{}
-	wg       sync.WaitGroup
+	config              *config.TopLevel
+	sharedConfigManager sharedconfig.Manager
+	authorizer          *deliver.SeekAuthorizer
+	deadChan            chan struct{}
+	wg                  sync.WaitGroup
 }
 
-func newDeliverer(conf *config.TopLevel) Deliverer {
+func newDeliverer(conf *config.TopLevel, sharedConfigManager sharedconfig.Manager, authorizer *deliver.SeekAuthorizer) Deliverer {
 	return &delivererImpl{
-		config:   conf,
-		deadChan: make(chan struct{}),
+		config:              conf,
+		sharedConfigManager: sharedConfigManager,
+		authorizer:          authorizer,
+		deadChan:            make(chan struct{}),
 	}
 }
 
 // Deliver receives updates from connected clients and adjusts
 // the transmission of ordered messages to them accordingly
 func (d *delivererImpl) Deliver(stream ab.AtomicBroadcast_DeliverServer) error {
-	cd := newClientDeliverer(d.config, d.deadChan)
+	cd := newClientDeliverer(withBrokers(d.config, d.sharedConfigManager), d.deadChan, d.authorizer)
 
 	d.wg.Add(1)
 	defer d.wg.Done()
@@ -17,7 +17,14 @@ limitations under the License.
 package kafka
 
 import (
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/deliver"
+	"github.com/hyperledger/fabric/orderer/common/policies"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
 	"github.com/hyperledger/fabric/orderer/config"
+	"github.com/hyperledger/fabric/orderer/rawledger"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
 
@@ -38,11 +45,16 @@ type serverImpl struct {
 	deliverer   Deliverer
 }
 
-// New creates a new orderer
-func New(conf *config.TopLevel) Orderer {
+// New creates a new orderer, which starts the chain with genesisBlock and signs the
+// blocks with signer unless it is nil. The broadcast messages are subject to quota
+// unless it is nil. Configuration transactions are validated and
+// applied with configManager, the batch size, batch timeout and Kafka brokers
+// configured for the chain in sharedConfigManager take precedence over conf. The seek requests
+// of the clients must satisfy the readers policy of policyManager
+func New(conf *config.TopLevel, genesisBlock *cb.Block, signer rawledger.BlockSigner, quota *broadcast.Quota, configManager configtx.Manager, sharedConfigManager sharedconfig.Manager, policyManager policies.Manager) Orderer {
 	return &serverImpl{
-		broadcaster: newBroadcaster(conf),
-		deliverer:   newDeliverer(conf),
+		broadcaster: newBroadcaster(conf, genesisBlock, signer, quota, configManager, sharedConfigManager),
+		deliverer:   newDeliverer(conf, sharedConfigManager, deliver.NewSeekAuthorizer(configManager.ChainID(), policyManager)),
 	}
 }
 
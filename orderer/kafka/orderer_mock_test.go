@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/config"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 )
 
@@ -52,6 +53,10 @@ func (mbs *mockBroadcastStream) Recv() (*cb.Envelope, error) {
 	return <-mbs.incoming, nil
 }
 
+func (mbs *mockBroadcastStream) Context() context.Context {
+	return context.Background()
+}
+
 func (mbs *mockBroadcastStream) Send(reply *ab.BroadcastResponse) error {
 	if !mbs.closed {
 		mbs.outgoing <- reply
@@ -18,12 +18,14 @@ package kafka
 
 import (
 	"github.com/Shopify/sarama"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
 	"github.com/hyperledger/fabric/orderer/config"
 )
 
 const (
 	ackOutOfRangeError    = "ACK out of range"
 	seekOutOfRangeError   = "Seek out of range"
+	stopOutOfRangeError   = "Stop out of range"
 	windowOutOfRangeError = "Window out of range"
 )
 
@@ -33,6 +35,21 @@ func newBrokerConfig(conf *config.TopLevel) *sarama.Config {
 	return brokerConfig
 }
 
+// withBrokers returns a copy of conf pointing to the Kafka brokers configured
+// for the chain, or conf itself if the chain does not configure them
+func withBrokers(conf *config.TopLevel, sharedConfigManager sharedconfig.Manager) *config.TopLevel {
+	if sharedConfigManager == nil {
+		return conf
+	}
+	brokers := sharedConfigManager.KafkaBrokers()
+	if len(brokers) == 0 {
+		return conf
+	}
+	brokerConf := *conf
+	brokerConf.Kafka.Brokers = brokers
+	return &brokerConf
+}
+
 func newMsg(payload []byte, topic string) *sarama.ProducerMessage {
 	return &sarama.ProducerMessage{
 		Topic: topic,
@@ -27,12 +27,21 @@ import (
 	"os"
 	"os/signal"
 
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
+	"github.com/hyperledger/fabric/metrics"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/blocksig"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap"
+	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/static"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/broadcastfilter"
-	// "github.com/hyperledger/fabric/orderer/common/broadcastfilter/configfilter"
+	"github.com/hyperledger/fabric/orderer/common/broadcastfilter/configfilter"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
 	"github.com/hyperledger/fabric/orderer/common/configtx"
 	"github.com/hyperledger/fabric/orderer/common/policies"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
 	"github.com/hyperledger/fabric/orderer/common/util"
 	"github.com/hyperledger/fabric/orderer/config"
 	"github.com/hyperledger/fabric/orderer/kafka"
@@ -63,6 +72,13 @@ func main() {
 		}()
 	}
 
+	// Start the metrics service if enabled
+	if conf.General.Metrics.Enabled {
+		go func() {
+			panic(fmt.Errorf("Metrics service failed: %s", metrics.ListenAndServe(conf.General.Metrics.Address)))
+		}()
+	}
+
 	switch conf.General.OrdererType {
 	case "solo":
 		launchSolo(conf)
@@ -73,25 +89,48 @@ func main() {
 	}
 }
 
-// XXX This crypto helper is a stand in until we have a real crypto handler
-// it considers all signatures to be valid
-type xxxCryptoHelper struct{}
+func init() {
+	logging.SetLevel(logging.DEBUG, "")
+}
 
-func (xxx xxxCryptoHelper) VerifySignature(msg []byte, ids []byte, sigs []byte) bool {
-	return true
+// createBlockSigner returns the signer of the blocks created by the orderer. The
+// peers reject unsigned blocks, so the orderer does not start without a local MSP
+func createBlockSigner(conf *config.TopLevel) rawledger.BlockSigner {
+	primitives.SetSecurityLevel("SHA2", 256)
+	if err := msp.GetManager().Setup(conf.General.LocalMSP.ConfigFile); err != nil {
+		panic(fmt.Errorf("Error setting up the local MSP from %s, set General.LocalMSP.ConfigFile (ORDERER_GENERAL_LOCALMSP_CONFIGFILE) to the MSP configuration of the orderer: %s", conf.General.LocalMSP.ConfigFile, err))
+	}
+	signer, err := msp.GetManager().GetSigningIdentity(&msp.IdentityIdentifier{
+		Mspid: msp.ProviderIdentifier{Value: conf.General.LocalMSP.ID},
+		Value: conf.General.LocalMSP.Identity,
+	})
+	if err != nil {
+		panic(fmt.Errorf("Error retrieving the signing identity of the orderer, General.LocalMSP.ID and General.LocalMSP.Identity must name an identity of %s: %s", conf.General.LocalMSP.ConfigFile, err))
+	}
+	return blocksig.NewBlockSigner(signer)
 }
 
-func init() {
-	logging.SetLevel(logging.DEBUG, "")
+// extractConfiguration returns the configuration of a configuration block, or nil
+// if the block does not hold a configuration transaction
+func extractConfiguration(block *cb.Block) *cb.ConfigurationEnvelope {
+	if len(block.Data.Data) != 1 {
+		return nil
+	}
+	envelope := util.ExtractEnvelopeOrPanic(block, 0)
+	payload := util.ExtractPayloadOrPanic(envelope)
+	if payload.Header.ChainHeader.Type != int32(cb.HeaderType_CONFIGURATION_TRANSACTION) {
+		return nil
+	}
+	configurationEnvelope := new(cb.ConfigurationEnvelope)
+	if err := proto.Unmarshal(payload.Data, configurationEnvelope); err != nil {
+		return nil
+	}
+	return configurationEnvelope
 }
 
 func retrieveConfiguration(rl rawledger.Reader) *cb.ConfigurationEnvelope {
 	var lastConfigTx *cb.ConfigurationEnvelope
 
-	envelope := new(cb.Envelope)
-	payload := new(cb.Payload)
-	configurationEnvelope := new(cb.ConfigurationEnvelope)
-
 	it, _ := rl.Iterator(ab.SeekInfo_OLDEST, 0)
 	// Iterate over the blockchain, looking for config transactions, track the most recent one encountered
 	// This will be the transaction which is returned
@@ -102,15 +141,7 @@ func retrieveConfiguration(rl rawledger.Reader) *cb.ConfigurationEnvelope {
 			if status != cb.Status_SUCCESS {
 				panic(fmt.Errorf("Error parsing blockchain at startup: %v", status))
 			}
-			if len(block.Data.Data) != 1 {
-				continue
-			}
-			envelope = util.ExtractEnvelopeOrPanic(block, 0)
-			payload = util.ExtractPayloadOrPanic(envelope)
-			if payload.Header.ChainHeader.Type != int32(cb.HeaderType_CONFIGURATION_TRANSACTION) {
-				continue
-			}
-			if err := proto.Unmarshal(payload.Data, configurationEnvelope); err == nil {
+			if configurationEnvelope := extractConfiguration(block); configurationEnvelope != nil {
 				lastConfigTx = configurationEnvelope
 			}
 		default:
@@ -119,36 +150,81 @@ func retrieveConfiguration(rl rawledger.Reader) *cb.ConfigurationEnvelope {
 	}
 }
 
-func bootstrapConfigManager(lastConfigTx *cb.ConfigurationEnvelope) configtx.Manager {
-	policyManager := policies.NewManagerImpl(xxxCryptoHelper{})
-	configHandlerMap := make(map[cb.ConfigurationItem_ConfigurationType]configtx.Handler)
-	for ctype := range cb.ConfigurationItem_ConfigurationType_name {
-		rtype := cb.ConfigurationItem_ConfigurationType(ctype)
-		switch rtype {
-		case cb.ConfigurationItem_Policy:
-			configHandlerMap[rtype] = policyManager
-		default:
-			configHandlerMap[rtype] = configtx.NewBytesHandler()
-		}
+func bootstrapConfigManager(lastConfigTx *cb.ConfigurationEnvelope, ch cauthdsl.CryptoHelper) (configtx.Manager, policies.Manager, sharedconfig.Manager) {
+	configManager, policyManager, sharedConfigManager, err := configtx.Bootstrap(lastConfigTx, ch)
+	if err != nil {
+		panic(err)
 	}
+	return configManager, policyManager, sharedConfigManager
+}
 
-	configManager, err := configtx.NewConfigurationManager(lastConfigTx, policyManager, configHandlerMap)
+// createCryptoHelper returns the CryptoHelper checking the signatures against the policies,
+// which is backed by the local MSP set up by createBlockSigner
+func createCryptoHelper(conf *config.TopLevel) cauthdsl.CryptoHelper {
+	return cauthdsl.NewMSPCryptoHelper(msp.GetManager())
+}
+
+// createGenesisBlock returns the genesis block of the chain, retrieved or generated
+// according to the genesis method
+func createGenesisBlock(conf *config.TopLevel) *cb.Block {
+	var bootstrapper bootstrap.Helper
+
+	// Select the bootstrapping mechanism
+	switch conf.General.GenesisMethod {
+	case "static":
+		bootstrapper = static.NewWithOrdererMSP(conf.General.LocalMSP.ID)
+	case "file":
+		bootstrapper = file.New(conf.General.GenesisFile)
+	default:
+		panic(fmt.Errorf("Unknown genesis method %s", conf.General.GenesisMethod))
+	}
+
+	genesisBlock, err := bootstrapper.GenesisBlock()
 	if err != nil {
-		panic(err)
+		panic(fmt.Errorf("Error retrieving the genesis block %s", err))
 	}
-	return configManager
+	return genesisBlock
+}
+
+// createBroadcastQuota returns the limits on the messages broadcast by each client
+func createBroadcastQuota(conf *config.TopLevel) *broadcast.Quota {
+	return broadcast.NewQuota(broadcast.Limits{
+		MaxMessageBytes:   int(conf.General.Quota.MaxMessageBytes),
+		MessagesPerSecond: float64(conf.General.Quota.MessagesPerSecond),
+		BytesPerSecond:    float64(conf.General.Quota.BytesPerSecond),
+	})
 }
 
 func createBroadcastRuleset(configManager configtx.Manager) *broadcastfilter.RuleSet {
 	return broadcastfilter.NewRuleSet([]broadcastfilter.Rule{
 		broadcastfilter.EmptyRejectRule,
-		// configfilter.New(configManager),
+		configfilter.New(configManager),
 		broadcastfilter.AcceptRule,
 	})
 }
 
+// createGRPCServer returns the server of the Broadcast and Deliver streams, with TLS
+// when enabled, logging the identity of the client of each stream
+func createGRPCServer(conf *config.TopLevel) *grpc.Server {
+	opts := []grpc.ServerOption{grpc.StreamInterceptor(comm.LogClientIdentity)}
+
+	tlsConf := conf.General.TLS
+	if tlsConf.Enabled {
+		creds, err := comm.NewServerCredentials(tlsConf.Certificate, tlsConf.PrivateKey, tlsConf.ClientRootCAs, tlsConf.ClientAuth)
+		if err != nil {
+			panic(fmt.Errorf("Error setting up TLS: %s", err))
+		}
+		logger.Infof("TLS enabled, client authentication %s", tlsConf.ClientAuth)
+		opts = append(opts, grpc.Creds(creds))
+	} else {
+		logger.Warning("TLS disabled, the Broadcast and Deliver streams are not encrypted")
+	}
+
+	return grpc.NewServer(opts...)
+}
+
 func launchSolo(conf *config.TopLevel) {
-	grpcServer := grpc.NewServer()
+	grpcServer := createGRPCServer(conf)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", conf.General.ListenAddress, conf.General.ListenPort))
 	if err != nil {
@@ -156,21 +232,9 @@ func launchSolo(conf *config.TopLevel) {
 		return
 	}
 
-	var bootstrapper bootstrap.Helper
+	genesisBlock := createGenesisBlock(conf)
 
-	// Select the bootstrapping mechanism
-	switch conf.General.GenesisMethod {
-	case "static":
-		bootstrapper = static.New()
-	default:
-		panic(fmt.Errorf("Unknown genesis method %s", conf.General.GenesisMethod))
-	}
-
-	genesisBlock, err := bootstrapper.GenesisBlock()
-
-	if err != nil {
-		panic(fmt.Errorf("Error retrieving the genesis block %s", err))
-	}
+	signer := createBlockSigner(conf)
 
 	// Stand in until real config
 	ledgerType := os.Getenv("ORDERER_LEDGER_TYPE")
@@ -186,11 +250,11 @@ func launchSolo(conf *config.TopLevel) {
 			}
 		}
 
-		_, rawledger = fileledger.New(location, genesisBlock)
+		_, rawledger = fileledger.New(location, genesisBlock, signer)
 	case "ram":
 		fallthrough
 	default:
-		_, rawledger = ramledger.New(int(conf.RAMLedger.HistorySize), genesisBlock)
+		_, rawledger = ramledger.New(int(conf.RAMLedger.HistorySize), genesisBlock, signer)
 	}
 
 	lastConfigTx := retrieveConfiguration(rawledger)
@@ -198,7 +262,7 @@ func launchSolo(conf *config.TopLevel) {
 		panic("No chain configuration found")
 	}
 
-	configManager := bootstrapConfigManager(lastConfigTx)
+	configManager, policyManager, sharedConfigManager := bootstrapConfigManager(lastConfigTx, createCryptoHelper(conf))
 	filters := createBroadcastRuleset(configManager)
 
 	soloConsenter := solo.NewConsenter(
@@ -207,6 +271,7 @@ func launchSolo(conf *config.TopLevel) {
 		rawledger,
 		filters,
 		configManager,
+		sharedConfigManager,
 	)
 
 	server := NewServer(
@@ -214,8 +279,10 @@ func launchSolo(conf *config.TopLevel) {
 		rawledger,
 		int(conf.General.QueueSize),
 		int(conf.General.MaxWindowSize),
+		createBroadcastQuota(conf),
 		filters,
 		configManager,
+		policyManager,
 	)
 
 	ab.RegisterAtomicBroadcastServer(grpcServer, server)
@@ -240,14 +307,24 @@ func launchKafka(conf *config.TopLevel) {
 		sarama.Logger = log.New(os.Stdout, "[sarama] ", log.Lshortfile)
 	}
 
-	ordererSrv := kafka.New(conf)
+	genesisBlock := createGenesisBlock(conf)
+
+	signer := createBlockSigner(conf)
+
+	genesisConfigTx := extractConfiguration(genesisBlock)
+	if genesisConfigTx == nil {
+		panic("No chain configuration found in the genesis block")
+	}
+	configManager, policyManager, sharedConfigManager := bootstrapConfigManager(genesisConfigTx, createCryptoHelper(conf))
+
+	ordererSrv := kafka.New(conf, genesisBlock, signer, createBroadcastQuota(conf), configManager, sharedConfigManager, policyManager)
 	defer ordererSrv.Teardown()
 
 	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", conf.General.ListenAddress, conf.General.ListenPort))
 	if err != nil {
 		panic(err)
 	}
-	rpcSrv := grpc.NewServer() // TODO Add TLS support
+	rpcSrv := createGRPCServer(conf)
 	ab.RegisterAtomicBroadcastServer(rpcSrv, ordererSrv)
 	go rpcSrv.Serve(lis)
 
@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"sync"
 
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
 	"github.com/hyperledger/fabric/orderer/rawledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -55,15 +56,21 @@ type fileLedger struct {
 	signal         chan struct{}
 	lastHash       []byte
 	marshaler      *jsonpb.Marshaler
+	signer         rawledger.BlockSigner
+	// the width of the MerkleTree of the block data, set by the genesis block
+	hashingWidth uint32
 }
 
 type fileLedgerFactory struct {
 	directory string
+	signer    rawledger.BlockSigner
 	ledgers   map[string]rawledger.ReadWriter
 	mutex     sync.Mutex
 }
 
-func New(directory string, systemGenesis *cb.Block) (rawledger.Factory, rawledger.ReadWriter) {
+// New creates a new file ledger factory and the ledger of the system chain from its genesis block.
+// The blocks appended to the ledgers are signed with signer, unless it is nil
+func New(directory string, systemGenesis *cb.Block, signer rawledger.BlockSigner) (rawledger.Factory, rawledger.ReadWriter) {
 	env := &cb.Envelope{}
 	err := proto.Unmarshal(systemGenesis.Data.Data[0], env)
 	if err != nil {
@@ -84,6 +91,7 @@ func New(directory string, systemGenesis *cb.Block) (rawledger.Factory, rawledge
 	flf := &fileLedgerFactory{
 		directory: directory,
 		ledgers:   make(map[string]rawledger.ReadWriter),
+		signer:    signer,
 	}
 
 	flt, err := flf.GetOrCreate(payload.Header.ChainHeader.ChainID)
@@ -103,6 +111,7 @@ func New(directory string, systemGenesis *cb.Block) (rawledger.Factory, rawledge
 		}
 	} else {
 		fl.writeBlock(systemGenesis)
+		fl.hashingWidth = sharedconfig.BlockDataHashingWidth(systemGenesis.Data)
 		fl.height = 1
 		fl.lastHash = systemGenesis.Header.Hash()
 	}
@@ -144,18 +153,19 @@ func (flf *fileLedgerFactory) GetOrCreate(chainID []byte) (rawledger.ReadWriter,
 		return nil, err
 	}
 
-	ch := newChain(directory)
+	ch := newChain(directory, flf.signer)
 	flf.ledgers[key] = ch
 	return ch, nil
 }
 
 // newChain creates a new chain backed by a file ledger
-func newChain(directory string) rawledger.ReadWriter {
+func newChain(directory string, signer rawledger.BlockSigner) rawledger.ReadWriter {
 	fl := &fileLedger{
 		directory:      directory,
 		fqFormatString: directory + "/" + blockFileFormatString,
 		signal:         make(chan struct{}),
 		marshaler:      &jsonpb.Marshaler{Indent: "  "},
+		signer:         signer,
 	}
 	fl.initializeBlockHeight()
 	logger.Debugf("Initialized to block height %d with hash %x", fl.height-1, fl.lastHash)
@@ -195,6 +205,12 @@ func (fl *fileLedger) initializeBlockHeight() {
 		panic(fmt.Errorf("Error reading block %d", fl.height-1))
 	}
 	fl.lastHash = block.Header.Hash()
+
+	genesis, found := fl.readBlock(0)
+	if !found || genesis == nil {
+		panic(fmt.Errorf("Error reading genesis block"))
+	}
+	fl.hashingWidth = sharedconfig.BlockDataHashingWidth(genesis.Data)
 }
 
 // blockFilename returns the fully qualified path to where a block of a given number should be stored on disk
@@ -251,18 +267,27 @@ func (fl *fileLedger) Append(messages []*cb.Envelope, metadata [][]byte) *cb.Blo
 			logger.Fatalf("Error marshaling what should be a valid proto message: %s", err)
 		}
 	}
+	if fl.hashingWidth == 0 {
+		// this is the genesis block of the chain
+		fl.hashingWidth = sharedconfig.BlockDataHashingWidth(data)
+	}
 
 	block := &cb.Block{
 		Header: &cb.BlockHeader{
 			Number:       fl.height,
 			PreviousHash: fl.lastHash,
-			DataHash:     data.Hash(),
+			DataHash:     data.MerkleHash(fl.hashingWidth),
 		},
 		Data: data,
 		Metadata: &cb.BlockMetadata{
 			Metadata: metadata,
 		},
 	}
+	if fl.signer != nil {
+		if err = fl.signer.SignBlock(block); err != nil {
+			logger.Fatalf("Error signing block %d: %s", block.Header.Number, err)
+		}
+	}
 	fl.writeBlock(block)
 	fl.height++
 	close(fl.signal)
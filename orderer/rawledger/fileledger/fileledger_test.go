@@ -48,7 +48,7 @@ func initialize(t *testing.T) (*testEnv, *fileLedger) {
 	if err != nil {
 		t.Fatalf("Error creating temp dir: %s", err)
 	}
-	_, fl := New(name, genesisBlock)
+	_, fl := New(name, genesisBlock, nil)
 	return &testEnv{location: name, t: t}, fl.(*fileLedger)
 }
 
@@ -78,7 +78,7 @@ func TestReinitialization(t *testing.T) {
 	tev, ofl := initialize(t)
 	defer tev.tearDown()
 	ofl.Append([]*cb.Envelope{&cb.Envelope{Payload: []byte("My Data")}}, nil)
-	_, flt := New(tev.location, genesisBlock)
+	_, flt := New(tev.location, genesisBlock, nil)
 	fl := flt.(*fileLedger)
 	if fl.height != 2 {
 		t.Fatalf("Block height should be 2")
@@ -69,5 +69,5 @@ func (env *fileLedgerTestFactory) Persistent() bool {
 }
 
 func (env *fileLedgerTestFactory) New() (Factory, ReadWriter) {
-	return fileledger.New(env.location, genesisBlock)
+	return fileledger.New(env.location, genesisBlock, nil)
 }
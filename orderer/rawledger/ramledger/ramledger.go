@@ -19,6 +19,7 @@ package ramledger
 import (
 	"sync"
 
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
 	"github.com/hyperledger/fabric/orderer/rawledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -48,18 +49,25 @@ type ramLedger struct {
 	size    int
 	oldest  *simpleList
 	newest  *simpleList
+	signer  rawledger.BlockSigner
+	// the width of the MerkleTree of the block data, set by the genesis block
+	hashingWidth uint32
 }
 
 type ramLedgerFactory struct {
 	maxSize int
 	ledgers map[string]rawledger.ReadWriter
 	mutex   sync.Mutex
+	signer  rawledger.BlockSigner
 }
 
-func New(maxSize int, systemGenesis *cb.Block) (rawledger.Factory, rawledger.ReadWriter) {
+// New creates a new ram ledger factory and the ledger of the system chain from its genesis block.
+// The blocks appended to the ledgers are signed with signer, unless it is nil
+func New(maxSize int, systemGenesis *cb.Block, signer rawledger.BlockSigner) (rawledger.Factory, rawledger.ReadWriter) {
 	rlf := &ramLedgerFactory{
 		maxSize: maxSize,
 		ledgers: make(map[string]rawledger.ReadWriter),
+		signer:  signer,
 	}
 	env := &cb.Envelope{}
 	err := proto.Unmarshal(systemGenesis.Data.Data[0], env)
@@ -74,6 +82,7 @@ func New(maxSize int, systemGenesis *cb.Block) (rawledger.Factory, rawledger.Rea
 	}
 
 	rl, _ := rlf.GetOrCreate(payload.Header.ChainHeader.ChainID)
+	rl.(*ramLedger).hashingWidth = sharedconfig.BlockDataHashingWidth(systemGenesis.Data)
 	rl.(*ramLedger).appendBlock(systemGenesis)
 	return rlf, rl
 }
@@ -90,7 +99,7 @@ func (rlf *ramLedgerFactory) GetOrCreate(chainID []byte) (rawledger.ReadWriter,
 		return l, nil
 	}
 
-	ch := newChain(rlf.maxSize)
+	ch := newChain(rlf.maxSize, rlf.signer)
 	rlf.ledgers[key] = ch
 	return ch, nil
 }
@@ -110,7 +119,7 @@ func (rlf *ramLedgerFactory) ChainIDs() [][]byte {
 }
 
 // newChain creates a new instance of the ram ledger for a chain
-func newChain(maxSize int) rawledger.ReadWriter {
+func newChain(maxSize int, signer rawledger.BlockSigner) rawledger.ReadWriter {
 	preGenesis := &cb.Block{
 		Header: &cb.BlockHeader{
 			Number: ^uint64(0),
@@ -120,6 +129,7 @@ func newChain(maxSize int) rawledger.ReadWriter {
 	rl := &ramLedger{
 		maxSize: maxSize,
 		size:    1,
+		signer:  signer,
 		oldest: &simpleList{
 			signal: make(chan struct{}),
 			block:  preGenesis,
@@ -224,18 +234,27 @@ func (rl *ramLedger) Append(messages []*cb.Envelope, metadata [][]byte) *cb.Bloc
 			logger.Fatalf("Error marshaling data which should be a valid proto: %s", err)
 		}
 	}
+	if rl.hashingWidth == 0 {
+		// this is the genesis block of the chain
+		rl.hashingWidth = sharedconfig.BlockDataHashingWidth(data)
+	}
 
 	block := &cb.Block{
 		Header: &cb.BlockHeader{
 			Number:       rl.newest.block.Header.Number + 1,
 			PreviousHash: rl.newest.block.Header.Hash(),
-			DataHash:     data.Hash(),
+			DataHash:     data.MerkleHash(rl.hashingWidth),
 		},
 		Data: data,
 		Metadata: &cb.BlockMetadata{
 			Metadata: metadata,
 		},
 	}
+	if rl.signer != nil {
+		if err = rl.signer.SignBlock(block); err != nil {
+			logger.Fatalf("Error signing block %d: %s", block.Header.Number, err)
+		}
+	}
 	rl.appendBlock(block)
 	return block
 }
@@ -38,7 +38,7 @@ func init() {
 // Note that 'only' is applicable because the genesis block will be discarded
 func TestAppend(t *testing.T) {
 	maxSize := 3
-	_, rlt := New(maxSize, genesisBlock)
+	_, rlt := New(maxSize, genesisBlock, nil)
 	rl := rlt.(*ramLedger)
 	var blocks []*cb.Block
 	for i := 0; i < 3; i++ {
@@ -64,7 +64,7 @@ func TestAppend(t *testing.T) {
 // TestSignal checks if the signal channel closes when an item is appended
 func TestSignal(t *testing.T) {
 	maxSize := 3
-	_, rlt := New(maxSize, genesisBlock)
+	_, rlt := New(maxSize, genesisBlock, nil)
 	rl := rlt.(*ramLedger)
 	item := rl.newest
 	select {
@@ -86,7 +86,7 @@ func TestSignal(t *testing.T) {
 func TestTruncationSafety(t *testing.T) {
 	maxSize := 3
 	newBlocks := 10
-	_, rlt := New(maxSize, genesisBlock)
+	_, rlt := New(maxSize, genesisBlock, nil)
 	rl := rlt.(*ramLedger)
 	item := rl.oldest.next
 	for i := 0; i < newBlocks; i++ {
@@ -47,5 +47,5 @@ func (env *ramledgerTestFactory) Persistent() bool {
 
 func (env *ramledgerTestFactory) New() (Factory, ReadWriter) {
 	historySize := 10
-	return ramledger.New(historySize, genesisBlock)
+	return ramledger.New(historySize, genesisBlock, nil)
 }
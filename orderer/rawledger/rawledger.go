@@ -52,6 +52,12 @@ type Writer interface {
 	Append(blockContents []*cb.Envelope, metadata [][]byte) *cb.Block
 }
 
+// BlockSigner is used by the ledger to sign the blocks it creates
+type BlockSigner interface {
+	// SignBlock adds the signature of the block header and metadata to the metadata of the block
+	SignBlock(block *cb.Block) error
+}
+
 // ReadWriter encapsulated both the reading and writing functions of the rawledger
 type ReadWriter interface {
 	Reader
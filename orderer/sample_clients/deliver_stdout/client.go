@@ -17,9 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"flag"
 	"fmt"
+	"strconv"
 
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/static"
+	"github.com/hyperledger/fabric/orderer/common/util"
 	"github.com/hyperledger/fabric/orderer/config"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -37,39 +40,21 @@ func newDeliverClient(client ab.AtomicBroadcast_DeliverClient, windowSize uint64
 	return &deliverClient{client: client, windowSize: windowSize}
 }
 
-func (r *deliverClient) seekOldest() error {
-	return r.client.Send(&ab.DeliverUpdate{
-		Type: &ab.DeliverUpdate_Seek{
-			Seek: &ab.SeekInfo{
-				Start:      ab.SeekInfo_OLDEST,
-				WindowSize: r.windowSize,
-				ChainID:    static.TestChainID,
-			},
-		},
-	})
-}
-
-func (r *deliverClient) seekNewest() error {
-	return r.client.Send(&ab.DeliverUpdate{
-		Type: &ab.DeliverUpdate_Seek{
-			Seek: &ab.SeekInfo{
-				Start:      ab.SeekInfo_NEWEST,
-				WindowSize: r.windowSize,
-				ChainID:    static.TestChainID,
-			},
-		},
-	})
-}
-
-func (r *deliverClient) seek(blockNumber uint64) error {
+// seek sends the seek request wrapped in an unsigned envelope, as the readers policy of the
+// static bootstrap requires no signature
+func (r *deliverClient) seek(seekInfo *ab.SeekInfo) error {
+	seekInfo.WindowSize = r.windowSize
+	seekInfo.ChainID = static.TestChainID
+	payload := &cb.Payload{
+		Header: util.MakePayloadHeader(
+			util.MakeChainHeader(cb.HeaderType_DELIVER_SEEK_INFO, 1, static.TestChainID, 0),
+			util.MakeSignatureHeader(nil, util.CreateNonceOrPanic()),
+		),
+		Data: util.MarshalOrPanic(seekInfo),
+	}
 	return r.client.Send(&ab.DeliverUpdate{
-		Type: &ab.DeliverUpdate_Seek{
-			Seek: &ab.SeekInfo{
-				Start:           ab.SeekInfo_SPECIFIED,
-				SpecifiedNumber: blockNumber,
-				WindowSize:      r.windowSize,
-				ChainID:         static.TestChainID,
-			},
+		Type: &ab.DeliverUpdate_SignedSeek{
+			SignedSeek: &cb.Envelope{Payload: util.MarshalOrPanic(payload)},
 		},
 	})
 }
@@ -85,10 +70,11 @@ func (r *deliverClient) readUntilClose() {
 		switch t := msg.Type.(type) {
 		case *ab.DeliverResponse_Error:
 			if t.Error == cb.Status_SUCCESS {
-				fmt.Println("ERROR! Received success in error field")
+				fmt.Println("Received all the requested blocks")
 				return
 			}
 			fmt.Println("Got error ", t)
+			return
 		case *ab.DeliverResponse_Block:
 			fmt.Println("Received block: ", t.Block)
 			r.unAcknowledged++
@@ -107,7 +93,57 @@ func (r *deliverClient) readUntilClose() {
 	}
 }
 
+// parseSeekInfo builds the seek request from the start and stop positions, which are
+// either "oldest" (start only), "newest" or a block number, an empty stop meaning no stop
+func parseSeekInfo(start, stop string, failIfNotReady bool) (*ab.SeekInfo, error) {
+	seekInfo := &ab.SeekInfo{}
+	switch start {
+	case "oldest":
+		seekInfo.Start = ab.SeekInfo_OLDEST
+	case "newest":
+		seekInfo.Start = ab.SeekInfo_NEWEST
+	default:
+		number, err := strconv.ParseUint(start, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid start position %s", start)
+		}
+		seekInfo.Start = ab.SeekInfo_SPECIFIED
+		seekInfo.SpecifiedNumber = number
+	}
+
+	switch stop {
+	case "":
+	case "newest":
+		seekInfo.Stop = &ab.SeekStop{Type: ab.SeekStop_NEWEST}
+	default:
+		number, err := strconv.ParseUint(stop, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid stop position %s", stop)
+		}
+		seekInfo.Stop = &ab.SeekStop{Type: ab.SeekStop_SPECIFIED, SpecifiedNumber: number}
+	}
+
+	if failIfNotReady {
+		seekInfo.Behavior = ab.SeekInfo_FAIL_IF_NOT_READY
+	}
+	return seekInfo, nil
+}
+
 func main() {
+	var start, stop string
+	var failIfNotReady bool
+
+	flag.StringVar(&start, "start", "oldest", "The first block to deliver: oldest, newest or a block number")
+	flag.StringVar(&stop, "stop", "", "The last block to deliver: newest or a block number, blocks are delivered as they are created if unset")
+	flag.BoolVar(&failIfNotReady, "fail", false, "Fail instead of waiting when a requested block is not yet created")
+	flag.Parse()
+
+	seekInfo, err := parseSeekInfo(start, stop, failIfNotReady)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
 	config := config.Load()
 	serverAddr := fmt.Sprintf("%s:%d", config.General.ListenAddress, config.General.ListenPort)
 	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
@@ -122,7 +158,10 @@ func main() {
 	}
 
 	s := newDeliverClient(client, 10)
-	s.seekOldest()
+	if err = s.seek(seekInfo); err != nil {
+		fmt.Println("Error sending the seek request:", err)
+		return
+	}
 	s.readUntilClose()
 
 }
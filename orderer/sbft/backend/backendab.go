@@ -19,6 +19,7 @@ package backend
 import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/orderer/common/deliver"
+	"github.com/hyperledger/fabric/orderer/common/policies"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
@@ -28,10 +29,10 @@ type BackendAB struct {
 	deliverserver deliver.Handler
 }
 
-func NewBackendAB(backend *Backend) *BackendAB {
+func NewBackendAB(backend *Backend, chainID []byte, policyManager policies.Manager) *BackendAB {
 	bab := &BackendAB{
 		backend:       backend,
-		deliverserver: deliver.NewHandlerImpl(backend.ledger, 1000),
+		deliverserver: deliver.NewHandlerImpl(chainID, backend.ledger, policyManager, 1000),
 	}
 	return bab
 }
@@ -22,8 +22,13 @@ import (
 	"net"
 	_ "net/http/pprof"
 	"os"
+	"strings"
 
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/static"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/configtx"
 	"github.com/hyperledger/fabric/orderer/rawledger/fileledger"
 	"github.com/hyperledger/fabric/orderer/sbft/backend"
 	"github.com/hyperledger/fabric/orderer/sbft/connection"
@@ -34,6 +39,8 @@ import (
 	"google.golang.org/grpc"
 )
 
+var log = logging.MustGetLogger("sbft")
+
 type consensusStack struct {
 	persist *persist.Persist
 	backend *backend.Backend
@@ -48,6 +55,9 @@ type flags struct {
 	dataDir       string
 	verbose       string
 	init          string
+	tls           bool
+	clientAuth    string
+	clientRootCAs string
 }
 
 func main() {
@@ -60,6 +70,9 @@ func main() {
 	flag.StringVar(&c.certFile, "cert", "", "certificate `file`")
 	flag.StringVar(&c.keyFile, "key", "", "key `file`")
 	flag.StringVar(&c.dataDir, "data-dir", "", "data `dir`ectory")
+	flag.BoolVar(&c.tls, "tls", true, "serve the GRPC atomic broadcast server with TLS, authenticating with the certificate and key")
+	flag.StringVar(&c.clientAuth, "client-auth", comm.ClientAuthNone, "authentication `mode` of the GRPC atomic broadcast clients (none, optional, required)")
+	flag.StringVar(&c.clientRootCAs, "client-root-cas", "", "comma separated PEM `files` of the root CAs of the GRPC atomic broadcast clients")
 	flag.StringVar(&c.verbose, "verbose", "info", "set verbosity `level` (critical, error, warning, notice, info, debug)")
 
 	flag.Parse()
@@ -126,7 +139,15 @@ func serve(c flags) {
 	if err != nil {
 		panic(err)
 	}
-	_, ledger := fileledger.New(c.dataDir, genesisBlock)
+	genesisConfig, err := configtx.GenesisConfiguration(genesisBlock)
+	if err != nil {
+		panic(err)
+	}
+	configManager, policyManager, _, err := configtx.Bootstrap(genesisConfig, cauthdsl.NewMSPCryptoHelper(msp.GetManager()))
+	if err != nil {
+		panic(err)
+	}
+	_, ledger := fileledger.New(c.dataDir, genesisBlock, nil)
 	s.backend, err = backend.NewBackend(config.Peers, conn, ledger, persist)
 	if err != nil {
 		panic(err)
@@ -135,15 +156,38 @@ func serve(c flags) {
 	sbft, _ := pb.New(s.backend.GetMyId(), config.Consensus, s.backend)
 	s.backend.SetReceiver(sbft)
 
-	grpcServer := grpc.NewServer()
+	grpcServer := createGRPCServer(c)
 	lis, err := net.Listen("tcp", c.grpcAddr)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to listen: %s", err))
 	}
-	broadcastab := backend.NewBackendAB(s.backend)
+	broadcastab := backend.NewBackendAB(s.backend, configManager.ChainID(), policyManager)
 	ab.RegisterAtomicBroadcastServer(grpcServer, broadcastab)
 	grpcServer.Serve(lis)
 
 	// block forever
 	select {}
 }
+
+// createGRPCServer returns the server of the Broadcast and Deliver streams, with TLS
+// when enabled, logging the identity of the client of each stream
+func createGRPCServer(c flags) *grpc.Server {
+	opts := []grpc.ServerOption{grpc.StreamInterceptor(comm.LogClientIdentity)}
+
+	if c.tls {
+		var clientRootCAs []string
+		if c.clientRootCAs != "" {
+			clientRootCAs = strings.Split(c.clientRootCAs, ",")
+		}
+		creds, err := comm.NewServerCredentials(c.certFile, c.keyFile, clientRootCAs, c.clientAuth)
+		if err != nil {
+			panic(fmt.Errorf("Error setting up TLS: %s", err))
+		}
+		log.Infof("TLS enabled, client authentication %s", c.clientAuth)
+		opts = append(opts, grpc.Creds(creds))
+	} else {
+		log.Warning("TLS disabled, the Broadcast and Deliver streams are not encrypted")
+	}
+
+	return grpc.NewServer(opts...)
+}
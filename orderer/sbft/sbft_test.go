@@ -17,6 +17,7 @@ limitations under the License.
 package sbft
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -24,11 +25,13 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/comm"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/op/go-logging"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var logger = logging.MustGetLogger("sbft_test")
@@ -56,7 +59,9 @@ func TestSbftPeer(t *testing.T) {
 		grpcAddr:   ":7101",
 		certFile:   "testdata/cert1.pem",
 		keyFile:    "testdata/key.pem",
-		dataDir:    tempDir}
+		dataDir:    tempDir,
+		tls:        true,
+		clientAuth: comm.ClientAuthNone}
 
 	logger.Info("Initialization of instance.")
 	err = initInstance(c)
@@ -72,7 +77,9 @@ func TestSbftPeer(t *testing.T) {
 
 	logger.Info("Creating an Atomic Broadcast GRPC connection.")
 	timeout := 4 * time.Second
-	clientconn, err := grpc.Dial(":7101", grpc.WithBlock(), grpc.WithTimeout(timeout), grpc.WithInsecure())
+	clientconn, err := grpc.Dial(":7101", grpc.WithBlock(), grpc.WithTimeout(timeout),
+		// the certificate of the test instance is self-signed, it is not verified
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
 	if err != nil {
 		t.Errorf("Failed to connect to GRPC: %s", err)
 		return
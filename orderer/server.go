@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/broadcastfilter"
 	"github.com/hyperledger/fabric/orderer/common/configtx"
 	"github.com/hyperledger/fabric/orderer/common/deliver"
+	"github.com/hyperledger/fabric/orderer/common/policies"
 	"github.com/hyperledger/fabric/orderer/rawledger"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
@@ -30,13 +31,15 @@ type server struct {
 	dh deliver.Handler
 }
 
-// NewServer creates a ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(consenter broadcast.Target, rl rawledger.Reader, queueSize, maxWindowSize int, filters *broadcastfilter.RuleSet, configManager configtx.Manager) ab.AtomicBroadcastServer {
+// NewServer creates a ab.AtomicBroadcastServer based on the broadcast target and ledger Reader,
+// delivering blocks to the clients allowed by the readers policy of policyManager and enforcing
+// quota on the broadcast messages
+func NewServer(consenter broadcast.Target, rl rawledger.Reader, queueSize, maxWindowSize int, quota *broadcast.Quota, filters *broadcastfilter.RuleSet, configManager configtx.Manager, policyManager policies.Manager) ab.AtomicBroadcastServer {
 	logger.Infof("Starting orderer with consenter=%T, and ledger=%T", consenter, rl)
 
 	s := &server{
-		dh: deliver.NewHandlerImpl(rl, maxWindowSize),
-		bh: broadcast.NewHandlerImpl(queueSize, consenter, filters, configManager),
+		dh: deliver.NewHandlerImpl(configManager.ChainID(), rl, policyManager, maxWindowSize),
+		bh: broadcast.NewHandlerImpl(queueSize, quota, consenter, filters, configManager),
 	}
 	return s
 }
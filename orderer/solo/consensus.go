@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/broadcastfilter"
 	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/sharedconfig"
 	"github.com/hyperledger/fabric/orderer/rawledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/op/go-logging"
@@ -34,30 +35,45 @@ func init() {
 }
 
 type consenter struct {
-	batchTimeout time.Duration
-	cutter       blockcutter.Receiver
-	rl           rawledger.Writer
-	sendChan     chan *cb.Envelope
-	exitChan     chan struct{}
+	batchTimeout        time.Duration
+	chainID             string
+	cutter              blockcutter.Receiver
+	sharedConfigManager sharedconfig.Manager
+	rl                  rawledger.Writer
+	sendChan            chan *cb.Envelope
+	exitChan            chan struct{}
 }
 
-func NewConsenter(batchSize int, batchTimeout time.Duration, rl rawledger.Writer, filters *broadcastfilter.RuleSet, configManager configtx.Manager) *consenter {
-	bs := newPlainConsenter(batchSize, batchTimeout, rl, filters, configManager)
+// NewConsenter creates a solo consenter. The batch size and timeout configured for the chain in
+// sharedConfigManager take precedence over batchSize and batchTimeout, and changes to them are
+// picked up at the next block
+func NewConsenter(batchSize int, batchTimeout time.Duration, rl rawledger.Writer, filters *broadcastfilter.RuleSet, configManager configtx.Manager, sharedConfigManager sharedconfig.Manager) *consenter {
+	bs := newPlainConsenter(batchSize, batchTimeout, rl, filters, configManager, sharedConfigManager)
 	go bs.main()
 	return bs
 }
 
-func newPlainConsenter(batchSize int, batchTimeout time.Duration, rl rawledger.Writer, filters *broadcastfilter.RuleSet, configManager configtx.Manager) *consenter {
+func newPlainConsenter(batchSize int, batchTimeout time.Duration, rl rawledger.Writer, filters *broadcastfilter.RuleSet, configManager configtx.Manager, sharedConfigManager sharedconfig.Manager) *consenter {
 	bs := &consenter{
-		cutter:       blockcutter.NewReceiverImpl(batchSize, filters, configManager),
-		batchTimeout: batchTimeout,
-		rl:           rl,
-		sendChan:     make(chan *cb.Envelope),
-		exitChan:     make(chan struct{}),
+		cutter:              blockcutter.NewReceiverImpl(batchSize, filters, configManager, sharedConfigManager),
+		batchTimeout:        batchTimeout,
+		chainID:             string(configManager.ChainID()),
+		sharedConfigManager: sharedConfigManager,
+		rl:                  rl,
+		sendChan:            make(chan *cb.Envelope),
+		exitChan:            make(chan struct{}),
 	}
 	return bs
 }
 
+// currentBatchTimeout returns the batch timeout of the current configuration of the chain
+func (bs *consenter) currentBatchTimeout() time.Duration {
+	if batchTimeout := bs.sharedConfigManager.BatchTimeout(); batchTimeout > 0 {
+		return batchTimeout
+	}
+	return bs.batchTimeout
+}
+
 func (bs *consenter) halt() {
 	close(bs.exitChan)
 }
@@ -80,7 +96,7 @@ func (bs *consenter) main() {
 		case msg := <-bs.sendChan:
 			batches, ok := bs.cutter.Ordered(msg)
 			if ok && len(batches) == 0 && timer == nil {
-				timer = time.After(bs.batchTimeout)
+				timer = time.After(bs.currentBatchTimeout())
 				continue
 			}
 			for _, batch := range batches {
@@ -99,6 +115,7 @@ func (bs *consenter) main() {
 				continue
 			}
 			logger.Debugf("Batch timer expired, creating block")
+			blockcutter.BatchesCut.Inc(bs.chainID, blockcutter.CutReasonTimeout)
 			bs.rl.Append(batch, nil)
 		case <-bs.exitChan:
 			logger.Debugf("Exiting")
@@ -24,6 +24,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/static"
 	"github.com/hyperledger/fabric/orderer/common/broadcastfilter"
 	"github.com/hyperledger/fabric/orderer/common/configtx"
@@ -53,7 +54,31 @@ func (mcm *mockConfigManager) Apply(message *cb.ConfigurationEnvelope) error {
 }
 
 func (mcm *mockConfigManager) ChainID() []byte {
-	panic("Unimplemented")
+	return []byte("mockChainID")
+}
+
+type mockSharedConfigManager struct {
+	batchTimeout time.Duration
+}
+
+func (mscm *mockSharedConfigManager) ConsensusType() string {
+	return ""
+}
+
+func (mscm *mockSharedConfigManager) BatchSize() int {
+	return 0
+}
+
+func (mscm *mockSharedConfigManager) BatchTimeout() time.Duration {
+	return mscm.batchTimeout
+}
+
+func (mscm *mockSharedConfigManager) KafkaBrokers() []string {
+	return nil
+}
+
+func (mscm *mockSharedConfigManager) BlockDataHashingWidth() uint32 {
+	return cb.DefaultBlockDataHashingWidth
 }
 
 type mockConfigFilter struct {
@@ -127,8 +152,8 @@ func (m *mockB) Recv() (*cb.Envelope, error) {
 
 func TestEmptyBatch(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
-	_, rl := ramledger.New(10, genesisBlock)
-	bs := newPlainConsenter(1, time.Millisecond, rl, filters, cm)
+	_, rl := ramledger.New(10, genesisBlock, nil)
+	bs := newPlainConsenter(1, time.Millisecond, rl, filters, cm, &mockSharedConfigManager{})
 	if bs.rl.(rawledger.Reader).Height() != 1 {
 		t.Fatalf("Expected no new blocks created")
 	}
@@ -137,10 +162,11 @@ func TestEmptyBatch(t *testing.T) {
 func TestBatchTimer(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	batchSize := 2
-	_, rl := ramledger.New(10, genesisBlock)
-	bs := NewConsenter(batchSize, time.Millisecond, rl, filters, cm)
+	_, rl := ramledger.New(10, genesisBlock, nil)
+	bs := NewConsenter(batchSize, time.Millisecond, rl, filters, cm, &mockSharedConfigManager{})
 	defer bs.halt()
 	it, _ := rl.Iterator(ab.SeekInfo_SPECIFIED, 1)
+	cuts := blockcutter.BatchesCut.Value(string(cm.ChainID()), blockcutter.CutReasonTimeout)
 
 	bs.sendChan <- &cb.Envelope{Payload: []byte("Some bytes")}
 
@@ -151,6 +177,10 @@ func TestBatchTimer(t *testing.T) {
 		t.Fatalf("Expected a block to be cut because of batch timer expiration but did not")
 	}
 
+	if blockcutter.BatchesCut.Value(string(cm.ChainID()), blockcutter.CutReasonTimeout) != cuts+1 {
+		t.Fatalf("Expected the batch cut to be counted as a timeout")
+	}
+
 	bs.sendChan <- &cb.Envelope{Payload: []byte("Some bytes")}
 	select {
 	case <-it.ReadyChan():
@@ -162,8 +192,8 @@ func TestBatchTimer(t *testing.T) {
 func TestBatchTimerHaltOnFilledBatch(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	batchSize := 2
-	_, rl := ramledger.New(10, genesisBlock)
-	bs := NewConsenter(batchSize, time.Hour, rl, filters, cm)
+	_, rl := ramledger.New(10, genesisBlock, nil)
+	bs := NewConsenter(batchSize, time.Hour, rl, filters, cm, &mockSharedConfigManager{})
 	defer bs.halt()
 	it, _ := rl.Iterator(ab.SeekInfo_SPECIFIED, 1)
 
@@ -192,8 +222,8 @@ func TestFilledBatch(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	batchSize := 2
 	messages := 10
-	_, rl := ramledger.New(10, genesisBlock)
-	bs := newPlainConsenter(batchSize, time.Hour, rl, filters, cm)
+	_, rl := ramledger.New(10, genesisBlock, nil)
+	bs := newPlainConsenter(batchSize, time.Hour, rl, filters, cm, &mockSharedConfigManager{})
 	done := make(chan struct{})
 	go func() {
 		bs.main()
@@ -213,8 +243,8 @@ func TestFilledBatch(t *testing.T) {
 func TestReconfigureGoodPath(t *testing.T) {
 	filters, cm := getFiltersAndConfig()
 	batchSize := 2
-	_, rl := ramledger.New(10, genesisBlock)
-	bs := newPlainConsenter(batchSize, time.Hour, rl, filters, cm)
+	_, rl := ramledger.New(10, genesisBlock, nil)
+	bs := newPlainConsenter(batchSize, time.Hour, rl, filters, cm, &mockSharedConfigManager{})
 	done := make(chan struct{})
 	go func() {
 		bs.main()
@@ -233,7 +263,24 @@ func TestReconfigureGoodPath(t *testing.T) {
 		t.Fatalf("Expected %d blocks but got %d", expected, bs.rl.(rawledger.Reader).Height())
 	}
 
-	if !cm.validated {
-		t.Errorf("ConfigTx should have been validated before processing")
+	if !cm.applied {
+		t.Errorf("ConfigTx should have been applied before processing")
+	}
+}
+
+func TestSharedConfigBatchTimeout(t *testing.T) {
+	filters, cm := getFiltersAndConfig()
+	batchSize := 2
+	_, rl := ramledger.New(10, genesisBlock, nil)
+	bs := NewConsenter(batchSize, time.Hour, rl, filters, cm, &mockSharedConfigManager{batchTimeout: time.Millisecond})
+	defer bs.halt()
+	it, _ := rl.Iterator(ab.SeekInfo_SPECIFIED, 1)
+
+	bs.sendChan <- &cb.Envelope{Payload: []byte("Some bytes")}
+
+	select {
+	case <-it.ReadyChan():
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a block to be cut at the batch timeout of the shared config but did not")
 	}
 }
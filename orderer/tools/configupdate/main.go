@@ -0,0 +1,395 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/blocksig"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
+	"github.com/hyperledger/fabric/orderer/common/configtx"
+	"github.com/hyperledger/fabric/orderer/common/configupdate"
+	"github.com/hyperledger/fabric/orderer/common/util"
+	"github.com/hyperledger/fabric/orderer/config"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+const usage = `Usage: configupdate <command> [flags]
+
+Changes the configuration of a chain. The orderer to connect to and the local MSP
+signing the requests are those of the orderer configuration (orderer.yaml). The
+commands connecting to the orderer take the -tls flags of the connection.
+
+Commands:
+  fetch   Write the current configuration of the chain as an editable JSON or YAML document
+  create  Write the unsigned update turning the current configuration into an edited document
+  sign    Add the signature of the local MSP to an update
+  submit  Send an update to the ordering service once its policies are satisfied
+`
+
+// configupdate walks the administrators of a chain through a configuration change: the
+// configuration is fetched and edited, the update is created and passed around for the
+// administrators to sign offline, and it is submitted when signed by enough of them
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "fetch":
+		err = fetch(args)
+	case "create":
+		err = create(args)
+	case "sign":
+		err = sign(args)
+	case "submit":
+		err = submit(args)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func fetch(args []string) error {
+	var chainID, output string
+	var tls tlsFlags
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	flags.StringVar(&chainID, "chainID", "", "The chain whose configuration is fetched")
+	flags.StringVar(&output, "output", "config.yaml", "The file the configuration is written to, as JSON unless its extension is .yaml or .yml")
+	tls.register(flags)
+	flags.Parse(args)
+
+	conf := config.Load()
+	current, err := fetchConfiguration(conf, &tls, chainID, newSigner(conf))
+	if err != nil {
+		return err
+	}
+	doc, err := configupdate.NewDocument(current)
+	if err != nil {
+		return err
+	}
+	data, err := doc.Marshal(configupdate.FormatOf(output))
+	if err != nil {
+		return fmt.Errorf("Error encoding the configuration: %s", err)
+	}
+	if err = ioutil.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("Error writing the configuration: %s", err)
+	}
+	fmt.Printf("Wrote the configuration of sequence %d of chain %s to %s\n", doc.Sequence, doc.ChainID, output)
+	return nil
+}
+
+func create(args []string) error {
+	var edited, output string
+	var tls tlsFlags
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	flags.StringVar(&edited, "edited", "config.yaml", "The edited configuration, as JSON unless its extension is .yaml or .yml")
+	flags.StringVar(&output, "output", "update.pb", "The file the unsigned update is written to")
+	tls.register(flags)
+	flags.Parse(args)
+
+	data, err := ioutil.ReadFile(edited)
+	if err != nil {
+		return fmt.Errorf("Error reading the edited configuration: %s", err)
+	}
+	doc, err := configupdate.UnmarshalDocument(data, configupdate.FormatOf(edited))
+	if err != nil {
+		return fmt.Errorf("Error parsing the edited configuration %s: %s", edited, err)
+	}
+
+	conf := config.Load()
+	current, err := fetchConfiguration(conf, &tls, doc.ChainID, newSigner(conf))
+	if err != nil {
+		return err
+	}
+	if seq, err := configupdate.Sequence(current); err == nil && seq != doc.Sequence {
+		return fmt.Errorf("The configuration was changed since it was fetched, sequence %d is now %d", doc.Sequence, seq)
+	}
+	update, err := configupdate.Compute(current, doc)
+	if err != nil {
+		return err
+	}
+	if err = writeUpdate(output, update); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote the unsigned update of chain %s to %s\n", doc.ChainID, output)
+	return nil
+}
+
+func sign(args []string) error {
+	var updatePath string
+	flags := flag.NewFlagSet("sign", flag.ExitOnError)
+	flags.StringVar(&updatePath, "update", "update.pb", "The update to sign, the signature is added to the file")
+	flags.Parse(args)
+
+	update, err := readUpdate(updatePath)
+	if err != nil {
+		return err
+	}
+	signer := newSigner(config.Load())
+	if signer == nil {
+		return fmt.Errorf("A local MSP must be configured to sign updates")
+	}
+	if err = configupdate.Sign(update, signer); err != nil {
+		return err
+	}
+	if err = writeUpdate(updatePath, update); err != nil {
+		return err
+	}
+	fmt.Printf("Signed the update %s\n", updatePath)
+	return nil
+}
+
+func submit(args []string) error {
+	var updatePath string
+	var force bool
+	var tls tlsFlags
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	flags.StringVar(&updatePath, "update", "update.pb", "The signed update to submit")
+	flags.BoolVar(&force, "force", false, "Submit the update without checking its signatures against the current policies")
+	tls.register(flags)
+	flags.Parse(args)
+
+	update, err := readUpdate(updatePath)
+	if err != nil {
+		return err
+	}
+	conf := config.Load()
+	signer := newSigner(conf)
+	envelope, err := configupdate.Envelope(update, signer)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		payload, err := util.ExtractPayload(envelope)
+		if err != nil {
+			return err
+		}
+		current, err := fetchConfiguration(conf, &tls, string(payload.Header.ChainHeader.ChainID), signer)
+		if err != nil {
+			return err
+		}
+		if err = configupdate.Validate(current, update, cauthdsl.NewMSPCryptoHelper(msp.GetManager())); err != nil {
+			return fmt.Errorf("The update is not valid against the current configuration: %s", err)
+		}
+	}
+
+	conn, err := dial(conf, &tls)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client, err := ab.NewAtomicBroadcastClient(conn).Broadcast(context.TODO())
+	if err != nil {
+		return fmt.Errorf("Error connecting: %s", err)
+	}
+	if err = client.Send(envelope); err != nil {
+		return fmt.Errorf("Error sending the update: %s", err)
+	}
+	reply, err := client.Recv()
+	if err != nil {
+		return fmt.Errorf("Error receiving the reply: %s", err)
+	}
+	if reply.Status != cb.Status_SUCCESS {
+		return fmt.Errorf("The ordering service rejected the update: %v", reply.Status)
+	}
+	fmt.Printf("Submitted the update %s\n", updatePath)
+	return nil
+}
+
+func readUpdate(path string) (*cb.ConfigurationEnvelope, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the update: %s", err)
+	}
+	update := &cb.ConfigurationEnvelope{}
+	if err = proto.Unmarshal(data, update); err != nil {
+		return nil, fmt.Errorf("Error parsing the update %s: %s", path, err)
+	}
+	return update, nil
+}
+
+func writeUpdate(path string, update *cb.ConfigurationEnvelope) error {
+	data, err := proto.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("Error marshaling the update: %s", err)
+	}
+	if err = ioutil.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("Error writing the update: %s", err)
+	}
+	return nil
+}
+
+// newSigner returns the signing identity of the local MSP, or nil if none is configured
+func newSigner(conf *config.TopLevel) blocksig.Signer {
+	if conf.General.LocalMSP.ConfigFile == "" {
+		return nil
+	}
+
+	primitives.SetSecurityLevel("SHA2", 256)
+	if err := msp.GetManager().Setup(conf.General.LocalMSP.ConfigFile); err != nil {
+		panic(fmt.Errorf("Error setting up the MSP from %s: %s", conf.General.LocalMSP.ConfigFile, err))
+	}
+	signer, err := msp.GetManager().GetSigningIdentity(&msp.IdentityIdentifier{
+		Mspid: msp.ProviderIdentifier{Value: conf.General.LocalMSP.ID},
+		Value: conf.General.LocalMSP.Identity,
+	})
+	if err != nil {
+		panic(fmt.Errorf("Error retrieving the local signing identity: %s", err))
+	}
+	return signer
+}
+
+// tlsFlags are the TLS settings of the connection to the orderer
+type tlsFlags struct {
+	enabled            bool
+	rootCAs            string
+	certificate        string
+	privateKey         string
+	serverHostOverride string
+}
+
+func (t *tlsFlags) register(flags *flag.FlagSet) {
+	flags.BoolVar(&t.enabled, "tls", false, "Connect to the orderer with TLS")
+	flags.StringVar(&t.rootCAs, "tls.rootcas", "", "Comma separated PEM files of the root CAs of the orderer, the system roots if not set")
+	flags.StringVar(&t.certificate, "tls.cert", "", "The PEM certificate authenticating with the orderer, for mutual TLS")
+	flags.StringVar(&t.privateKey, "tls.key", "", "The PEM private key of the certificate")
+	flags.StringVar(&t.serverHostOverride, "tls.serverhostoverride", "", "The name expected in the certificate of the orderer, its address if not set")
+}
+
+// dialOption returns the transport security of the connection to the orderer
+func (t *tlsFlags) dialOption() (grpc.DialOption, error) {
+	if !t.enabled {
+		return grpc.WithInsecure(), nil
+	}
+	var rootCAs []string
+	if t.rootCAs != "" {
+		rootCAs = strings.Split(t.rootCAs, ",")
+	}
+	creds, err := comm.NewClientCredentials(rootCAs, t.certificate, t.privateKey, t.serverHostOverride)
+	if err != nil {
+		return nil, fmt.Errorf("Error setting up TLS: %s", err)
+	}
+	return grpc.WithTransportCredentials(creds), nil
+}
+
+func dial(conf *config.TopLevel, tls *tlsFlags) (*grpc.ClientConn, error) {
+	security, err := tls.dialOption()
+	if err != nil {
+		return nil, err
+	}
+	serverAddr := fmt.Sprintf("%s:%d", conf.General.ListenAddress, conf.General.ListenPort)
+	conn, err := grpc.Dial(serverAddr, security)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to %s: %s", serverAddr, err)
+	}
+	return conn, nil
+}
+
+// fetchConfiguration delivers the blocks of the chain up to the newest one and returns the
+// configuration held by the last configuration block
+func fetchConfiguration(conf *config.TopLevel, tls *tlsFlags, chainID string, signer blocksig.Signer) (*cb.ConfigurationEnvelope, error) {
+	conn, err := dial(conf, tls)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client, err := ab.NewAtomicBroadcastClient(conn).Deliver(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting: %s", err)
+	}
+
+	windowSize := uint64(10)
+	seekInfo := &ab.SeekInfo{
+		Start:      ab.SeekInfo_OLDEST,
+		Stop:       &ab.SeekStop{Type: ab.SeekStop_NEWEST},
+		WindowSize: windowSize,
+		ChainID:    []byte(chainID),
+	}
+	var creator []byte
+	if signer != nil {
+		if creator, err = signer.Serialize(); err != nil {
+			return nil, fmt.Errorf("Error serializing the local identity: %s", err)
+		}
+	}
+	payload := &cb.Payload{
+		Header: util.MakePayloadHeader(
+			util.MakeChainHeader(cb.HeaderType_DELIVER_SEEK_INFO, 1, []byte(chainID), 0),
+			util.MakeSignatureHeader(creator, util.CreateNonceOrPanic()),
+		),
+		Data: util.MarshalOrPanic(seekInfo),
+	}
+	seek := &cb.Envelope{Payload: util.MarshalOrPanic(payload)}
+	if signer != nil {
+		if seek.Signature, err = signer.Sign(seek.Payload); err != nil {
+			return nil, fmt.Errorf("Error signing the seek request: %s", err)
+		}
+	}
+	if err = client.Send(&ab.DeliverUpdate{Type: &ab.DeliverUpdate_SignedSeek{SignedSeek: seek}}); err != nil {
+		return nil, fmt.Errorf("Error sending the seek request: %s", err)
+	}
+
+	var current *cb.ConfigurationEnvelope
+	unAcknowledged := uint64(0)
+	for {
+		msg, err := client.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("Error receiving the blocks of chain %s: %s", chainID, err)
+		}
+		switch t := msg.Type.(type) {
+		case *ab.DeliverResponse_Error:
+			if t.Error != cb.Status_SUCCESS {
+				return nil, fmt.Errorf("Error delivering the blocks of chain %s: %v", chainID, t.Error)
+			}
+			if current == nil {
+				return nil, fmt.Errorf("Chain %s has no configuration block", chainID)
+			}
+			return current, nil
+		case *ab.DeliverResponse_Block:
+			if config, err := configtx.GenesisConfiguration(t.Block); err == nil {
+				current = config
+			}
+			unAcknowledged++
+			if unAcknowledged >= windowSize/2 {
+				ack := &ab.DeliverUpdate{Type: &ab.DeliverUpdate_Acknowledgement{Acknowledgement: &ab.Acknowledgement{Number: t.Block.Header.Number}}}
+				if err = client.Send(ack); err != nil {
+					return nil, fmt.Errorf("Error acknowledging block %d: %s", t.Block.Header.Number, err)
+				}
+				unAcknowledged = 0
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/bootstrap/profile"
+)
+
+// genesisgen writes the genesis block of the chain described by a profile, the orderer
+// boots from it with the file genesis method
+func main() {
+	var profilePath, output string
+
+	flag.StringVar(&profilePath, "profile", "genesis.yaml", "The YAML profile describing the chain")
+	flag.StringVar(&output, "output", "genesis.block", "The file the genesis block is written to")
+	flag.Parse()
+
+	if err := generate(profilePath, output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote the genesis block of the chain described by %s to %s\n", profilePath, output)
+}
+
+func generate(profilePath string, output string) error {
+	p, err := profile.Load(profilePath)
+	if err != nil {
+		return err
+	}
+	block, err := p.GenesisBlock()
+	if err != nil {
+		return fmt.Errorf("Error generating the genesis block: %s", err)
+	}
+	data, err := proto.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("Error marshaling the genesis block: %s", err)
+	}
+	if err = ioutil.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("Error writing the genesis block: %s", err)
+	}
+	return nil
+}
@@ -48,10 +48,17 @@ func Cmd() *cobra.Command {
 		fmt.Sprint("Username for chaincode operations when security is enabled"))
 	flags.StringVarP(&customIDGenAlg, "tid", "t", common.UndefinedParamValue,
 		fmt.Sprint("Name of a custom ID generation algorithm (hashing and decoding) e.g. sha256base64"))
+	flags.StringVarP(&chaincodeTransientJSON, "transient", "", "{}",
+		fmt.Sprintf("Transient data passed to the %s but not included in the transaction, as a JSON object of strings", chainFuncName))
+	flags.StringVarP(&chainID, "chainID", "C", "",
+		fmt.Sprint("Name of the chain of the chaincode, the default chain of the peer if not set"))
 
 	chaincodeCmd.AddCommand(deployCmd())
 	chaincodeCmd.AddCommand(invokeCmd())
 	chaincodeCmd.AddCommand(queryCmd())
+	chaincodeCmd.AddCommand(listCmd())
+	chaincodeCmd.AddCommand(stopCmd())
+	chaincodeCmd.AddCommand(startCmd())
 
 	return chaincodeCmd
 }
@@ -67,6 +74,9 @@ var (
 	chaincodeQueryHex       bool
 	chaincodeAttributesJSON string
 	customIDGenAlg          string
+	chaincodeTransientJSON  string
+	chaincodePolicy         string
+	chainID                 string
 )
 
 var chaincodeCmd = &cobra.Command{
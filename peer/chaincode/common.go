@@ -23,13 +23,16 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/hyperledger/fabric/core"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/peer"
 	cutil "github.com/hyperledger/fabric/core/util"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/cauthdsl"
 	"github.com/hyperledger/fabric/peer/common"
 	"github.com/hyperledger/fabric/peer/util"
 	protcommon "github.com/hyperledger/fabric/protos/common"
@@ -75,6 +78,35 @@ func getChaincodeBytes(spec *pb.ChaincodeSpec) (*pb.ChaincodeDeploymentSpec, err
 	return chaincodeDeploymentSpec, nil
 }
 
+// getTransientMap parses the --transient flag. Values are JSON strings
+func getTransientMap() (map[string][]byte, error) {
+	var values map[string]string
+	if err := json.Unmarshal([]byte(chaincodeTransientJSON), &values); err != nil {
+		return nil, fmt.Errorf("Transient data error: %s", err)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	transientMap := make(map[string][]byte, len(values))
+	for k, v := range values {
+		transientMap[k] = []byte(v)
+	}
+	return transientMap, nil
+}
+
+// getChaincodeACL parses the --policy flag, the policy the creators of the proposals
+// invoking the chaincode must satisfy. Without a policy, anyone may invoke the chaincode
+func getChaincodeACL() (*pb.ChaincodeACL, error) {
+	if chaincodePolicy == "" {
+		return nil, nil
+	}
+	policy, err := cauthdsl.FromString(chaincodePolicy)
+	if err != nil {
+		return nil, fmt.Errorf("Chaincode policy error: %s", err)
+	}
+	return &pb.ChaincodeACL{Default: policy}, nil
+}
+
 func getChaincodeSpecification(cmd *cobra.Command) (*pb.ChaincodeSpec, error) {
 	spec := &pb.ChaincodeSpec{}
 	if err := checkChaincodeCmdParams(cmd); err != nil {
@@ -191,7 +223,11 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, args []string, invoke bool) (err
 	uuid := cutil.GenerateUUID()
 
 	var prop *pb.Proposal
-	prop, err = putils.CreateProposalFromCIS(uuid, invocation, creator)
+	transientMap, err := getTransientMap()
+	if err != nil {
+		return err
+	}
+	prop, err = putils.CreateChaincodeProposalForChain(uuid, chainID, invocation, creator, transientMap)
 	if err != nil {
 		return fmt.Errorf("Error creating proposal  %s: %s\n", chainFuncName, err)
 	}
@@ -207,6 +243,9 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, args []string, invoke bool) (err
 	if err != nil {
 		return fmt.Errorf("Error endorsing %s: %s\n", chainFuncName, err)
 	}
+	if proposalResp != nil && proposalResp.Response != nil && proposalResp.Response.Status >= 400 {
+		return fmt.Errorf("Error endorsing %s: %d %s\n", chainFuncName, proposalResp.Response.Status, proposalResp.Response.Message)
+	}
 
 	if invoke {
 		if proposalResp != nil {
@@ -302,3 +341,21 @@ func sendTransaction(env *protcommon.Envelope) error {
 
 	return Send(orderer, env)
 }
+
+// getAdminClient connects to the admin service of the local peer
+func getAdminClient() (pb.AdminClient, error) {
+	clientConn, err := peer.NewPeerClientConnection()
+	if err != nil {
+		return nil, fmt.Errorf("Error trying to connect to local peer: %s", err)
+	}
+	return pb.NewAdminClient(clientConn), nil
+}
+
+// printChaincodeStatus prints the status of a chaincode as returned by the admin service
+func printChaincodeStatus(status *pb.ChaincodeStatus) {
+	lastActivity := "-"
+	if status.LastActivity > 0 {
+		lastActivity = time.Unix(status.LastActivity, 0).Format(time.RFC3339)
+	}
+	fmt.Printf("%-16s %-24s %-10s %-10s %8d  %s\n", status.ChainID, status.Name, status.Version, status.State, status.Restarts, lastActivity)
+}
@@ -76,3 +76,42 @@ func TestCheckChaincodeCmdParamsEmptyCtor(t *testing.T) {
 
 	require.Error(result)
 }
+
+func TestGetTransientMap(t *testing.T) {
+	require := require.New(t)
+
+	chaincodeTransientJSON = `{"key1":"value1","key2":""}`
+	transientMap, err := getTransientMap()
+	require.Nil(err)
+	require.Equal(map[string][]byte{"key1": []byte("value1"), "key2": []byte("")}, transientMap)
+
+	chaincodeTransientJSON = "{}"
+	transientMap, err = getTransientMap()
+	require.Nil(err)
+	require.Nil(transientMap)
+
+	chaincodeTransientJSON = `{"key1":1}`
+	_, err = getTransientMap()
+	require.NotNil(err)
+}
+
+func TestGetChaincodeACL(t *testing.T) {
+	require := require.New(t)
+
+	chaincodePolicy = ""
+	acl, err := getChaincodeACL()
+	require.Nil(err)
+	require.Nil(acl)
+
+	chaincodePolicy = "OR('Org1.member', 'Org2.admin')"
+	acl, err = getChaincodeACL()
+	require.Nil(err)
+	require.NotNil(acl.Default)
+	require.Len(acl.Default.Principals, 2)
+
+	chaincodePolicy = "OR('Org1.member'"
+	_, err = getChaincodeACL()
+	require.NotNil(err)
+
+	chaincodePolicy = ""
+}
@@ -32,6 +32,9 @@ import (
 
 // Cmd returns the cobra command for Chaincode Deploy
 func deployCmd() *cobra.Command {
+	chaincodeDeployCmd.Flags().StringVarP(&chaincodePolicy, "policy", "P", "",
+		fmt.Sprintf("Policy the creators invoking the %s must satisfy, e.g. OR('Org1.member', 'Org2.admin'), anyone if not set", chainFuncName))
+
 	return chaincodeDeployCmd
 }
 
@@ -52,6 +55,11 @@ func deploy(cmd *cobra.Command) (*protcommon.Envelope, error) {
 		return nil, err
 	}
 
+	acl, err := getChaincodeACL()
+	if err != nil {
+		return nil, err
+	}
+
 	cds, err := getChaincodeBytes(spec)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting chaincode code %s: %s", chainFuncName, err)
@@ -80,7 +88,7 @@ func deploy(cmd *cobra.Command) (*protcommon.Envelope, error) {
 
 	uuid := util.GenerateUUID()
 
-	prop, err := utils.CreateProposalFromCDS(uuid, cds, creator)
+	prop, err := utils.CreateDeployProposalForChain(uuid, chainID, cds, acl, creator)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating proposal  %s: %s\n", chainFuncName, err)
 	}
@@ -95,6 +103,9 @@ func deploy(cmd *cobra.Command) (*protcommon.Envelope, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Error endorsing %s: %s\n", chainFuncName, err)
 	}
+	if proposalResponse != nil && proposalResponse.Response != nil && proposalResponse.Response.Status >= 400 {
+		return nil, fmt.Errorf("Error endorsing %s: %d %s\n", chainFuncName, proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
 
 	if proposalResponse != nil {
 		// assemble a signed transaction (it's an Envelope message)
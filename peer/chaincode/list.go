@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func listCmd() *cobra.Command {
+	return chaincodeListCmd
+}
+
+var chaincodeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: fmt.Sprintf("List the %ss running on the local peer.", chainFuncName),
+	Long:  fmt.Sprintf(`List the %ss running, or being restarted, on the local peer.`, chainFuncName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return chaincodeList(cmd, args)
+	},
+}
+
+func chaincodeList(cmd *cobra.Command, args []string) error {
+	adminClient, err := getAdminClient()
+	if err != nil {
+		return err
+	}
+
+	statuses, err := adminClient.ListChaincodes(context.Background(), &empty.Empty{})
+	if err != nil {
+		return fmt.Errorf("Error listing chaincodes: %s", err)
+	}
+
+	fmt.Printf("%-16s %-24s %-10s %-10s %8s  %s\n", "CHAIN", "NAME", "VERSION", "STATE", "RESTARTS", "LAST ACTIVITY")
+	for _, status := range statuses.Chaincodes {
+		printChaincodeStatus(status)
+	}
+	return nil
+}
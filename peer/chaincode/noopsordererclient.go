@@ -24,12 +24,11 @@ package chaincode
 //-------------------------------------------------------------
 import (
 	"fmt"
-	"time"
 
+	"github.com/hyperledger/fabric/core/comm"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
 )
 
 type broadcastClient struct {
@@ -54,16 +53,11 @@ func (s *broadcastClient) getAck() error {
 
 //Send data to solo orderer
 func Send(serverAddr string, env *cb.Envelope) error {
-	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
-	opts = append(opts, grpc.WithTimeout(3*time.Second))
-	opts = append(opts, grpc.WithBlock())
-
-	conn, err := grpc.Dial(serverAddr, opts...)
-	defer conn.Close()
+	conn, err := comm.NewOrdererClientConnection(serverAddr)
 	if err != nil {
 		return fmt.Errorf("Error connecting: %s", err)
 	}
+	defer conn.Close()
 	client, err := ab.NewAtomicBroadcastClient(conn).Broadcast(context.TODO())
 	if err != nil {
 		return fmt.Errorf("Error connecting: %s", err)
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func stopCmd() *cobra.Command {
+	return chaincodeStopCmd
+}
+
+var chaincodeStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: fmt.Sprintf("Stop the specified %s running on the local peer.", chainFuncName),
+	Long:  fmt.Sprintf(`Stop the specified %s running on the local peer, it is launched again by its next invocation.`, chainFuncName),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return chaincodeStop(cmd, args)
+	},
+}
+
+func chaincodeStop(cmd *cobra.Command, args []string) error {
+	if chaincodeName == common.UndefinedParamValue {
+		return fmt.Errorf("Must supply value for %s name parameter.", chainFuncName)
+	}
+
+	adminClient, err := getAdminClient()
+	if err != nil {
+		return err
+	}
+
+	status, err := adminClient.StopChaincode(context.Background(), &pb.ChaincodeRequest{ChainID: chainID, Name: chaincodeName})
+	if err != nil {
+		return fmt.Errorf("Error stopping chaincode %s: %s", chaincodeName, err)
+	}
+
+	printChaincodeStatus(status)
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keystore
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp/factory"
+	"github.com/hyperledger/fabric/core/crypto/bccsp/sw"
+	"github.com/op/go-logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const keystoreFuncName = "keystore"
+
+var logger = logging.MustGetLogger("keystoreCmd")
+
+// Cmd returns the cobra command for KeyStore
+func Cmd() *cobra.Command {
+	keystoreCmd.AddCommand(listCmd())
+	keystoreCmd.AddCommand(rekeyCmd())
+
+	return keystoreCmd
+}
+
+var keystoreCmd = &cobra.Command{
+	Use:   keystoreFuncName,
+	Short: fmt.Sprintf("%s specific commands.", keystoreFuncName),
+	Long:  fmt.Sprintf("Manages the local %s of the peer, as configured by peer.BCCSP.keyStore.", keystoreFuncName),
+}
+
+// keyStorePath returns the path of the configured KeyStore
+func keyStorePath() string {
+	path := viper.GetString("peer.BCCSP.keyStore.path")
+	if path == "" {
+		path = os.TempDir()
+	}
+	return path
+}
+
+// passphrase returns the current passphrase of the configured KeyStore
+func passphrase() ([]byte, error) {
+	return sw.ReadPassphrase(
+		viper.GetString("peer.BCCSP.keyStore.passphraseEnv"),
+		viper.GetString("peer.BCCSP.keyStore.passphraseFile"),
+		viper.GetBool("peer.BCCSP.keyStore.passphrasePrompt"))
+}
+
+// DefaultKeyStoreOpts returns the options of the KeyStore of the default BCCSP,
+// as configured by peer.BCCSP.keyStore
+func DefaultKeyStoreOpts() (factory.KeyStoreOpts, error) {
+	pwd, err := passphrase()
+	if err != nil {
+		return factory.KeyStoreOpts{}, err
+	}
+	return factory.KeyStoreOpts{Path: viper.GetString("peer.BCCSP.keyStore.path"), Passphrase: pwd}, nil
+}
+
+// openKeyStore opens the configured KeyStore with pwd
+func openKeyStore(pwd []byte, readOnly bool) (*sw.FileBasedKeyStore, error) {
+	ks := &sw.FileBasedKeyStore{}
+	if err := ks.Init(pwd, keyStorePath(), readOnly); err != nil {
+		return nil, fmt.Errorf("Failed opening KeyStore [%s]", err)
+	}
+	return ks, nil
+}
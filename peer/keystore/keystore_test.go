@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keystore
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp"
+	"github.com/hyperledger/fabric/core/crypto/bccsp/sw"
+	"github.com/spf13/viper"
+)
+
+func TestListAndRekey(t *testing.T) {
+	path, err := ioutil.TempDir("", "keystorecmd")
+	if err != nil {
+		t.Fatalf("Failed creating temp dir [%s]", err)
+	}
+	defer os.RemoveAll(path)
+
+	viper.Set("peer.BCCSP.keyStore.path", path)
+	viper.Set("peer.BCCSP.keyStore.passphraseEnv", "KEYSTORE_TEST_PASSPHRASE")
+	os.Setenv("KEYSTORE_TEST_PASSPHRASE", "old")
+	os.Setenv("KEYSTORE_TEST_NEW_PASSPHRASE", "new")
+	defer os.Unsetenv("KEYSTORE_TEST_PASSPHRASE")
+	defer os.Unsetenv("KEYSTORE_TEST_NEW_PASSPHRASE")
+
+	ks, err := openKeyStore([]byte("old"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csp, err := sw.New(256, "SHA2", ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := csp.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: false})
+	if err != nil {
+		t.Fatalf("Failed generating key [%s]", err)
+	}
+
+	var out bytes.Buffer
+	if err := list(&out); err != nil {
+		t.Fatalf("Failed listing keys [%s]", err)
+	}
+	expected := fmt.Sprintf("%x\tprivate\tencrypted\n", k.SKI())
+	if !strings.Contains(out.String(), expected) {
+		t.Fatalf("Expected [%s] in [%s]", expected, out.String())
+	}
+
+	newPassphraseEnv = "KEYSTORE_TEST_NEW_PASSPHRASE"
+	defer func() { newPassphraseEnv = "" }()
+	if err := rekey(); err != nil {
+		t.Fatalf("Failed re-encrypting KeyStore [%s]", err)
+	}
+
+	reopened, _ := openKeyStore([]byte("new"), true)
+	if _, err := reopened.GetKey(k.SKI()); err != nil {
+		t.Fatalf("Failed loading key with the new passphrase [%s]", err)
+	}
+
+	// The configured passphrase is now stale
+	if err := rekey(); err == nil {
+		t.Fatal("Re-encrypting with the wrong current passphrase should fail")
+	}
+}
+
+func TestNewPassphraseEmpty(t *testing.T) {
+	newPassphraseEnv = "KEYSTORE_TEST_UNSET"
+	defer func() { newPassphraseEnv = "" }()
+
+	if _, err := newPassphrase(); err == nil {
+		t.Fatal("An empty new passphrase should be rejected")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keystore
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp/sw"
+	"github.com/spf13/cobra"
+)
+
+func listCmd() *cobra.Command {
+	return keystoreListCmd
+}
+
+var keystoreListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the keys in the KeyStore by SKI.",
+	Long:  `Lists the keys in the KeyStore by SKI, with their type and whether they are encrypted`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return list(os.Stdout)
+	},
+}
+
+func list(w io.Writer) error {
+	// Listing does not decrypt the keys, hence no passphrase is needed
+	ks, err := openKeyStore(nil, true)
+	if err != nil {
+		return err
+	}
+
+	infos, err := ks.ListKeys()
+	if err != nil {
+		return err
+	}
+
+	printKeys(w, infos)
+	return nil
+}
+
+func printKeys(w io.Writer, infos []sw.KeyInfo) {
+	for _, info := range infos {
+		encryption := "unencrypted"
+		if info.Encrypted {
+			encryption = "encrypted"
+		}
+		fmt.Fprintf(w, "%x\t%s\t%s\n", info.SKI, keyTypeName(info.Type), encryption)
+	}
+}
+
+func keyTypeName(suffix string) string {
+	switch suffix {
+	case "sk":
+		return "private"
+	case "pk":
+		return "public"
+	case "key":
+		return "symmetric"
+	}
+	return suffix
+}
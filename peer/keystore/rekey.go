@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/crypto/bccsp/sw"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newPassphraseEnv  string
+	newPassphraseFile string
+)
+
+func rekeyCmd() *cobra.Command {
+	flags := keystoreRekeyCmd.Flags()
+	flags.StringVar(&newPassphraseEnv, "new-passphrase-env", "", "Name of the environment variable holding the new passphrase")
+	flags.StringVar(&newPassphraseFile, "new-passphrase-file", "", "Path of the file holding the new passphrase")
+
+	return keystoreRekeyCmd
+}
+
+var keystoreRekeyCmd = &cobra.Command{
+	Use:   "rekey",
+	Short: "Re-encrypts the keys in the KeyStore under a new passphrase.",
+	Long: `Re-encrypts the keys in the KeyStore under a new passphrase. The current passphrase is read as configured by peer.BCCSP.keyStore.
+The new passphrase is read from --new-passphrase-env or --new-passphrase-file, otherwise it is prompted on the terminal`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rekey()
+	},
+}
+
+func rekey() error {
+	pwd, err := passphrase()
+	if err != nil {
+		return err
+	}
+
+	newPwd, err := newPassphrase()
+	if err != nil {
+		return err
+	}
+
+	ks, err := openKeyStore(pwd, false)
+	if err != nil {
+		return err
+	}
+
+	if err := ks.ChangePassword(newPwd); err != nil {
+		return fmt.Errorf("Failed re-encrypting KeyStore [%s]", err)
+	}
+
+	logger.Infof("KeyStore at [%s] re-encrypted", keyStorePath())
+	return nil
+}
+
+// newPassphrase reads the new passphrase from the flags,
+// or prompts for it twice on the terminal
+func newPassphrase() ([]byte, error) {
+	if newPassphraseEnv != "" || newPassphraseFile != "" {
+		pwd, err := sw.ReadPassphrase(newPassphraseEnv, newPassphraseFile, false)
+		if err != nil {
+			return nil, err
+		}
+		if len(pwd) == 0 {
+			return nil, errors.New("The new passphrase is empty")
+		}
+		return pwd, nil
+	}
+
+	pwd, err := sw.PromptPassphrase("New KeyStore passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(pwd) == 0 {
+		return nil, errors.New("The new passphrase is empty")
+	}
+
+	confirm, err := sw.PromptPassphrase("Confirm new KeyStore passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pwd, confirm) {
+		return nil, errors.New("The passphrases do not match")
+	}
+	return pwd, nil
+}
@@ -30,11 +30,13 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/hyperledger/fabric/core"
+	"github.com/hyperledger/fabric/core/crypto/bccsp/factory"
 	"github.com/hyperledger/fabric/core/crypto/primitives"
 	"github.com/hyperledger/fabric/flogging"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/peer/chaincode"
 	"github.com/hyperledger/fabric/peer/clilogging"
+	"github.com/hyperledger/fabric/peer/keystore"
 	"github.com/hyperledger/fabric/peer/node"
 	"github.com/hyperledger/fabric/peer/version"
 )
@@ -110,6 +112,7 @@ func main() {
 	mainCmd.AddCommand(node.Cmd())
 	mainCmd.AddCommand(chaincode.Cmd())
 	mainCmd.AddCommand(clilogging.Cmd())
+	mainCmd.AddCommand(keystore.Cmd())
 
 	runtime.GOMAXPROCS(viper.GetInt("peer.gomaxprocs"))
 
@@ -117,6 +120,13 @@ func main() {
 	//TODO: integrate new crypto / idp code
 	primitives.SetSecurityLevel("SHA2", 256)
 
+	// Init the KeyStore of the default BCCSP, which holds the signing key of the MSP
+	ksOpts, err := keystore.DefaultKeyStoreOpts()
+	if err != nil {
+		panic(fmt.Errorf("Fatal error when reading the KeyStore passphrase: %s\n", err))
+	}
+	factory.SetDefaultKeyStore(ksOpts)
+
 	// Init the MSP
 	// TODO: determine the location of this config file
 	var mspMgrConfigFile string
@@ -25,6 +25,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -39,6 +40,7 @@ import (
 	"github.com/hyperledger/fabric/core/endorser"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/events/producer"
+	"github.com/hyperledger/fabric/metrics"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -134,7 +136,11 @@ func serve(args []string) error {
 
 	grpcServer := grpc.NewServer(opts...)
 
-	registerChaincodeSupport(chaincode.DefaultChain, grpcServer)
+	// The chains joined in addition to the default chain need the committer of their
+	// blocks, or their ledgers would never be updated
+	chainDeliverServices := noopssinglechain.NewChainDeliverServices()
+
+	registerChaincodeSupport(chaincode.DefaultChain, chainDeliverServices, grpcServer)
 
 	logger.Debugf("Running peer")
 
@@ -149,13 +155,33 @@ func serve(args []string) error {
 	// interaction is closely tied to bootstrapping. This is to be viewed
 	// as temporary implementation to test the end-to-end flows in the
 	// system outside of multi-ledger, multi-channel work
-	if deliverService := noopssinglechain.NewDeliverService(); deliverService != nil {
+	deliverService, err := noopssinglechain.NewDeliverService()
+	if err != nil {
+		return fmt.Errorf("Failed to create the committer: %s", err)
+	}
+	if deliverService != nil {
+		if viper.GetBool("peer.gossip.enabled") {
+			var bootPeers []string
+			if bootstrap := viper.GetString("peer.gossip.bootstrap"); bootstrap != "" {
+				bootPeers = strings.Split(bootstrap, ",")
+			}
+			if err := deliverService.StartGossip(viper.GetString("peer.gossip.endpoint"), bootPeers...); err != nil {
+				return fmt.Errorf("Failed to start gossip: %s", err)
+			}
+		}
 		go func() {
 			if err := deliverService.Start(); err != nil {
 				fmt.Printf("Could not start solo committer(%s), continuing without committer\n", err)
 			}
 		}()
 	}
+	for chainID, deliverService := range chainDeliverServices {
+		go func(chainID string, deliverService *noopssinglechain.DeliverService) {
+			if err := deliverService.Start(); err != nil {
+				logger.Errorf("Could not start the committer of chain %s: %s", chainID, err)
+			}
+		}(chainID, deliverService)
+	}
 
 	logger.Infof("Starting peer with ID=%s, network ID=%s, address=%s, rootnodes=%v, validator=%v",
 		peerEndpoint.ID, viper.GetString("peer.networkId"), peerEndpoint.Address,
@@ -203,11 +229,21 @@ func serve(args []string) error {
 		}()
 	}
 
+	if viper.GetBool("peer.metrics.enabled") {
+		go func() {
+			if metricsErr := metrics.ListenAndServe(viper.GetString("peer.metrics.listenAddress")); metricsErr != nil {
+				logger.Errorf("Error starting metrics server: %s", metricsErr)
+			}
+		}()
+	}
+
 	// Block until grpc server exits
-	return <-serve
+	serveErr := <-serve
+	chaincode.Shutdown()
+	return serveErr
 }
 
-func registerChaincodeSupport(chainname chaincode.ChainName, grpcServer *grpc.Server) {
+func registerChaincodeSupport(chainname chaincode.ChainName, chainDeliverServices map[string]*noopssinglechain.DeliverService, grpcServer *grpc.Server) {
 	//get user mode
 	userRunsCC := false
 	if viper.GetString("chaincode.mode") == chaincode.DevModeUserRunsChaincode {
@@ -224,6 +260,20 @@ func registerChaincodeSupport(chainname chaincode.ChainName, grpcServer *grpc.Se
 
 	ccSrv := chaincode.NewChaincodeSupport(chainname, peer.GetPeerEndpoint, userRunsCC, ccStartupTimeout)
 
+	//the other chains joined by the peer get a chaincode support of their own. Their chaincodes
+	//register with the server of ccSrv, which routes them to the chaincode support of their chain.
+	//A chain is not joined without the committer of its blocks, so its proposals are rejected
+	for _, joined := range viper.GetStringSlice("peer.chains") {
+		if chaincode.ChainName(joined) == chainname {
+			continue
+		}
+		if _, committed := chainDeliverServices[joined]; !committed {
+			logger.Errorf("Not joining chain %s: its genesis block is not in peer.committer.ledger.chainGenesisBlocks", joined)
+			continue
+		}
+		chaincode.NewChaincodeSupport(chaincode.ChainName(joined), peer.GetPeerEndpoint, userRunsCC, ccStartupTimeout)
+	}
+
 	//Now that chaincode is initialized, register all system chaincodes.
 	chaincode.RegisterSysCCs()
 
@@ -256,13 +306,27 @@ func createEventHubServer() (net.Listener, *grpc.Server, error) {
 		grpcServer = grpc.NewServer(opts...)
 		ehServer := producer.NewEventsServer(
 			uint(viper.GetInt("peer.validator.events.buffersize")),
-			viper.GetInt("peer.validator.events.timeout"))
+			viper.GetInt("peer.validator.events.timeout"),
+			getEventHubRegistrationPolicy())
 
 		pb.RegisterEventsServer(grpcServer, ehServer)
 	}
 	return lis, grpcServer, err
 }
 
+// getEventHubRegistrationPolicy reads from the configuration which consumers
+// can register for each event type
+func getEventHubRegistrationPolicy() *producer.RegistrationPolicy {
+	acl := make(map[pb.EventType][]string)
+	for _, eventType := range []pb.EventType{pb.EventType_BLOCK, pb.EventType_CHAINCODE, pb.EventType_REJECTION} {
+		acl[eventType] = viper.GetStringSlice("peer.validator.events.acl." + strings.ToLower(eventType.String()))
+	}
+	return &producer.RegistrationPolicy{
+		TimeWindow: viper.GetDuration("peer.validator.events.timewindow"),
+		ACL:        acl,
+	}
+}
+
 func writePid(fileName string, pid int) error {
 	err := os.MkdirAll(filepath.Dir(fileName), 0755)
 	if err != nil {
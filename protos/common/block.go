@@ -17,24 +17,197 @@ limitations under the License.
 package common
 
 import (
-	"github.com/golang/protobuf/proto"
+	"bytes"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
 	"github.com/hyperledger/fabric/core/util"
 )
 
-func (b *BlockHeader) Hash() []byte {
-	data, err := proto.Marshal(b) // XXX this is wrong, protobuf is not the right mechanism to serialize for a hash
+// DefaultBlockDataHashingWidth is the width of the MerkleTree used to compute
+// the hash of the BlockData, unless the configuration of the chain sets another
+// one with its BlockDataHashingStructure item
+const DefaultBlockDataHashingWidth uint32 = 2
+
+// the leaves and the inner nodes of the MerkleTree are hashed with different
+// prefixes, so that an inner node cannot be passed off as an entry
+var (
+	merkleLeafPrefix = []byte{0}
+	merkleNodePrefix = []byte{1}
+)
+
+type asn1Header struct {
+	Number       *big.Int
+	PreviousHash []byte
+	DataHash     []byte
+}
+
+// Bytes returns the ASN.1 DER encoding of the block header, which is hashed
+// to chain the blocks and signed by the orderers
+func (b *BlockHeader) Bytes() []byte {
+	asn1Header := asn1Header{
+		Number:       new(big.Int).SetUint64(b.Number),
+		PreviousHash: b.PreviousHash,
+		DataHash:     b.DataHash,
+	}
+	result, err := asn1.Marshal(asn1Header)
 	if err != nil {
-		panic("This should never fail and is generally irrecoverable")
+		// Errors should only arise for types which cannot be encoded, since the
+		// BlockHeader type is known a-priori to contain only encodable types, an
+		// error here is fatal and should not be propogated
+		panic(err)
 	}
+	return result
+}
 
-	return util.ComputeCryptoHash(data)
+// Hash returns the hash of the block header
+func (b *BlockHeader) Hash() []byte {
+	return util.ComputeCryptoHash(b.Bytes())
 }
 
+// Hash returns the root of the MerkleTree of the entries of the BlockData, with
+// the default width
 func (b *BlockData) Hash() []byte {
-	data, err := proto.Marshal(b) // XXX this is wrong, protobuf is not the right mechanism to serialize for a hash, AND, it is not a MerkleTree hash
+	return b.MerkleHash(DefaultBlockDataHashingWidth)
+}
+
+// MerkleHash returns the root of the MerkleTree of the entries of the BlockData.
+// Each inner node of the tree is the hash of up to width children, a node left
+// alone at the end of a level is moved up unchanged
+func (b *BlockData) MerkleHash(width uint32) []byte {
+	levels := b.merkleTree(width)
+	return levels[len(levels)-1][0]
+}
+
+// merkleTree returns the levels of the MerkleTree of the entries, from the leaves
+// to the root
+func (b *BlockData) merkleTree(width uint32) [][][]byte {
+	if width < 2 {
+		panic(fmt.Sprintf("the width of a MerkleTree must be at least 2, got %d", width))
+	}
+	if len(b.Data) == 0 {
+		return [][][]byte{{util.ComputeCryptoHash(nil)}}
+	}
+
+	level := make([][]byte, len(b.Data))
+	for i, entry := range b.Data {
+		level[i] = merkleLeaf(entry)
+	}
+	levels := [][][]byte{level}
+	for len(level) > 1 {
+		var next [][]byte
+		for i := 0; i < len(level); i += int(width) {
+			end := i + int(width)
+			if end > len(level) {
+				end = len(level)
+			}
+			if end-i == 1 {
+				next = append(next, level[i])
+			} else {
+				next = append(next, merkleNode(level[i:end]))
+			}
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return levels
+}
+
+// Proof returns the proof that the entry at index is included in the BlockData,
+// whose hash was computed with a MerkleTree of the given width
+func (b *BlockData) Proof(index int, width uint32) (*MerkleProof, error) {
+	if index < 0 || index >= len(b.Data) {
+		return nil, fmt.Errorf("index %d out of range, the block data holds %d entries", index, len(b.Data))
+	}
+
+	levels := b.merkleTree(width)
+	proof := &MerkleProof{Index: uint32(index), Width: width}
+	for _, level := range levels[:len(levels)-1] {
+		start := index - index%int(width)
+		end := start + int(width)
+		if end > len(level) {
+			end = len(level)
+		}
+		var siblings [][]byte
+		siblings = append(siblings, level[start:index]...)
+		siblings = append(siblings, level[index+1:end]...)
+		proof.Levels = append(proof.Levels, &MerkleProofLevel{Siblings: siblings})
+		index /= int(width)
+	}
+	return proof, nil
+}
+
+// Proof returns the proof that the entry at index is included in the block, whose
+// data hash was computed with a MerkleTree of the given width
+func (b *Block) Proof(index int, width uint32) (*MerkleProof, error) {
+	if b.Header == nil || b.Data == nil {
+		return nil, fmt.Errorf("the block has no header or no data")
+	}
+	proof, err := b.Data.Proof(index, width)
 	if err != nil {
-		panic("This should never fail and is generally irrecoverable")
+		return nil, err
+	}
+	proof.BlockNumber = b.Header.Number
+	return proof, nil
+}
+
+// Verify checks that entry is included in the block of the given header, whose
+// data hash was computed with a MerkleTree of the given width, the width of the
+// chain. The width the proof claims is not trusted
+func (p *MerkleProof) Verify(header *BlockHeader, entry []byte, width uint32) error {
+	if header.Number != p.BlockNumber {
+		return fmt.Errorf("the proof is for block %d, not for block %d", p.BlockNumber, header.Number)
 	}
+	if width < 2 {
+		return fmt.Errorf("invalid MerkleTree width %d", width)
+	}
+	if p.Width != width {
+		return fmt.Errorf("the proof is for a MerkleTree of width %d, the chain uses width %d", p.Width, width)
+	}
+
+	node := merkleLeaf(entry)
+	index := p.Index
+	for i, level := range p.Levels {
+		position := int(index % p.Width)
+		switch {
+		case len(level.Siblings) == 0:
+			// the node is moved up unchanged, it must be the first of its group
+			if position != 0 {
+				return fmt.Errorf("level %d of the proof has no sibling for the node at position %d", i, position)
+			}
+		case len(level.Siblings) >= int(p.Width) || position > len(level.Siblings):
+			return fmt.Errorf("level %d of the proof has %d siblings for the node at position %d", i, len(level.Siblings), position)
+		default:
+			// the children are concatenated when hashed: a sibling that is not exactly one
+			// hash long could stand for several children, or a part of one
+			for _, sibling := range level.Siblings {
+				if len(sibling) != len(node) {
+					return fmt.Errorf("level %d of the proof has a sibling of %d bytes, not a hash", i, len(sibling))
+				}
+			}
+			children := make([][]byte, 0, len(level.Siblings)+1)
+			children = append(children, level.Siblings[:position]...)
+			children = append(children, node)
+			children = append(children, level.Siblings[position:]...)
+			node = merkleNode(children)
+		}
+		index /= p.Width
+	}
+	if index != 0 {
+		return fmt.Errorf("the proof is too short for index %d", p.Index)
+	}
+
+	if !bytes.Equal(node, header.DataHash) {
+		return fmt.Errorf("the entry %d is not included in block %d", p.Index, header.Number)
+	}
+	return nil
+}
+
+func merkleLeaf(entry []byte) []byte {
+	return util.ComputeCryptoHash(append(append([]byte{}, merkleLeafPrefix...), entry...))
+}
 
-	return util.ComputeCryptoHash(data)
+func merkleNode(children [][]byte) []byte {
+	return util.ComputeCryptoHash(bytes.Join(append([][]byte{merkleNodePrefix}, children...), nil))
 }
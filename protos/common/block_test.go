@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestBlockHeaderBytes(t *testing.T) {
+	header := &BlockHeader{Number: 1, PreviousHash: []byte("previous"), DataHash: []byte("data")}
+	// SEQUENCE { INTEGER 1, OCTET STRING "previous", OCTET STRING "data" }
+	expected := []byte{0x30, 0x13, 0x02, 0x01, 0x01, 0x04, 0x08}
+	expected = append(expected, []byte("previous")...)
+	expected = append(expected, 0x04, 0x04)
+	expected = append(expected, []byte("data")...)
+	if !bytes.Equal(header.Bytes(), expected) {
+		t.Fatalf("Expected the DER encoding %x of the header, got %x", expected, header.Bytes())
+	}
+
+	large := &BlockHeader{Number: 1 << 63}
+	if bytes.Equal(large.Hash(), (&BlockHeader{}).Hash()) {
+		t.Fatal("Expected headers of different numbers to have different hashes")
+	}
+}
+
+func TestMerkleHash(t *testing.T) {
+	a, b, c := merkleLeaf([]byte("a")), merkleLeaf([]byte("b")), merkleLeaf([]byte("c"))
+
+	data := &BlockData{Data: [][]byte{[]byte("a"), []byte("b"), []byte("c")}}
+	if expected := merkleNode([][]byte{merkleNode([][]byte{a, b}), c}); !bytes.Equal(data.Hash(), expected) {
+		t.Fatalf("Expected the root of the binary MerkleTree %x, got %x", expected, data.Hash())
+	}
+	if expected := merkleNode([][]byte{a, b, c}); !bytes.Equal(data.MerkleHash(3), expected) {
+		t.Fatalf("Expected the root of the MerkleTree of width 3 %x, got %x", expected, data.MerkleHash(3))
+	}
+	if single := (&BlockData{Data: [][]byte{[]byte("a")}}); !bytes.Equal(single.Hash(), a) {
+		t.Fatalf("Expected the root of a single entry to be its leaf, got %x", single.Hash())
+	}
+	if bytes.Equal((&BlockData{}).Hash(), a) {
+		t.Fatal("Expected the empty block data to have its own hash")
+	}
+}
+
+func TestMerkleProof(t *testing.T) {
+	for _, width := range []uint32{2, 3, 4, 16} {
+		for _, size := range []int{1, 2, 3, 5, 8, 17} {
+			data := &BlockData{}
+			for i := 0; i < size; i++ {
+				data.Data = append(data.Data, []byte(fmt.Sprintf("tx%d", i)))
+			}
+			block := &Block{Header: &BlockHeader{Number: 7, DataHash: data.MerkleHash(width)}, Data: data}
+
+			for i, entry := range data.Data {
+				proof, err := block.Proof(i, width)
+				if err != nil {
+					t.Fatalf("Error creating the proof of entry %d: %s", i, err)
+				}
+				if err = proof.Verify(block.Header, entry, width); err != nil {
+					t.Fatalf("Expected the proof of entry %d of %d with width %d to be valid: %s", i, size, width, err)
+				}
+				if err = proof.Verify(block.Header, []byte("other"), width); err == nil {
+					t.Fatalf("Expected the proof of entry %d not to be valid for another entry", i)
+				}
+				if err = proof.Verify(&BlockHeader{Number: 8, DataHash: block.Header.DataHash}, entry, width); err == nil {
+					t.Fatal("Expected the proof not to be valid for another block")
+				}
+				if err = proof.Verify(block.Header, entry, width+1); err == nil {
+					t.Fatal("Expected the proof not to be valid for another width")
+				}
+				if size > 1 {
+					proof.Index = uint32((i + 1) % size)
+					if err = proof.Verify(block.Header, entry, width); err == nil {
+						t.Fatalf("Expected the proof of entry %d not to be valid for index %d", i, proof.Index)
+					}
+				}
+			}
+		}
+	}
+
+	if _, err := (&Block{Header: &BlockHeader{}, Data: &BlockData{}}).Proof(0, 2); err == nil {
+		t.Fatal("Expected the proof of an entry out of range to fail")
+	}
+}
+
+func TestMerkleProofForgedSiblings(t *testing.T) {
+	data := &BlockData{Data: [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}}
+	header := &BlockHeader{Number: 7, DataHash: data.MerkleHash(4)}
+	a, b, d := merkleLeaf(data.Data[0]), merkleLeaf(data.Data[1]), merkleLeaf(data.Data[3])
+
+	// the root hashes A||B||C||D, which is also [A||B, C, D] concatenated: c would be
+	// included at index 1
+	forged := &MerkleProof{BlockNumber: 7, Index: 1, Width: 4, Levels: []*MerkleProofLevel{
+		{Siblings: [][]byte{append(append([]byte{}, a...), b...), d}}}}
+	if err := forged.Verify(header, data.Data[2], 4); err == nil {
+		t.Fatal("Expected a proof with a sibling of two hashes not to be valid")
+	}
+}
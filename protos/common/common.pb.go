@@ -19,13 +19,22 @@ It has these top-level messages:
 	BlockHeader
 	BlockData
 	BlockMetadata
+	MerkleProof
+	MerkleProofLevel
+	Metadata
+	MetadataSignature
 	ConfigurationEnvelope
 	SignedConfigurationItem
 	ConfigurationItem
 	ConfigurationSignature
 	Policy
 	SignaturePolicyEnvelope
+	MSPPrincipal
+	MSPRole
+	OrganizationUnit
 	SignaturePolicy
+	OrdererAddresses
+	MSPConfig
 */
 package common
 
@@ -89,6 +98,7 @@ const (
 	HeaderType_CONFIGURATION_TRANSACTION HeaderType = 1
 	HeaderType_CONFIGURATION_ITEM        HeaderType = 2
 	HeaderType_ENDORSER_TRANSACTION      HeaderType = 3
+	HeaderType_DELIVER_SEEK_INFO         HeaderType = 4
 )
 
 var HeaderType_name = map[int32]string{
@@ -96,12 +106,14 @@ var HeaderType_name = map[int32]string{
 	1: "CONFIGURATION_TRANSACTION",
 	2: "CONFIGURATION_ITEM",
 	3: "ENDORSER_TRANSACTION",
+	4: "DELIVER_SEEK_INFO",
 }
 var HeaderType_value = map[string]int32{
 	"MESSAGE":                   0,
 	"CONFIGURATION_TRANSACTION": 1,
 	"CONFIGURATION_ITEM":        2,
 	"ENDORSER_TRANSACTION":      3,
+	"DELIVER_SEEK_INFO":         4,
 }
 
 func (x HeaderType) String() string {
@@ -109,6 +121,25 @@ func (x HeaderType) String() string {
 }
 func (HeaderType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+// This enum enlists indexes of the block metadata array
+type BlockMetadataIndex int32
+
+const (
+	BlockMetadataIndex_SIGNATURES BlockMetadataIndex = 0
+)
+
+var BlockMetadataIndex_name = map[int32]string{
+	0: "SIGNATURES",
+}
+var BlockMetadataIndex_value = map[string]int32{
+	"SIGNATURES": 0,
+}
+
+func (x BlockMetadataIndex) String() string {
+	return proto.EnumName(BlockMetadataIndex_name, int32(x))
+}
+func (BlockMetadataIndex) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
 type Header struct {
 	ChainHeader     *ChainHeader     `protobuf:"bytes,1,opt,name=chainHeader" json:"chainHeader,omitempty"`
 	SignatureHeader *SignatureHeader `protobuf:"bytes,2,opt,name=signatureHeader" json:"signatureHeader,omitempty"`
@@ -255,6 +286,7 @@ func (m *Block) GetMetadata() *BlockMetadata {
 	return nil
 }
 
+// The hash of the BlockHeader is computed over its ASN.1 DER encoding, see BlockHeader.Bytes
 type BlockHeader struct {
 	Number       uint64 `protobuf:"varint,1,opt,name=Number" json:"Number,omitempty"`
 	PreviousHash []byte `protobuf:"bytes,2,opt,name=PreviousHash,proto3" json:"PreviousHash,omitempty"`
@@ -284,6 +316,68 @@ func (m *BlockMetadata) String() string            { return proto.CompactTextStr
 func (*BlockMetadata) ProtoMessage()               {}
 func (*BlockMetadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
+// MerkleProof proves that an entry of the BlockData of a block is included in the
+// DataHash of its header, see BlockData.Proof
+type MerkleProof struct {
+	BlockNumber uint64              `protobuf:"varint,1,opt,name=BlockNumber" json:"BlockNumber,omitempty"`
+	Index       uint32              `protobuf:"varint,2,opt,name=Index" json:"Index,omitempty"`
+	Width       uint32              `protobuf:"varint,3,opt,name=Width" json:"Width,omitempty"`
+	Levels      []*MerkleProofLevel `protobuf:"bytes,4,rep,name=Levels" json:"Levels,omitempty"`
+}
+
+func (m *MerkleProof) Reset()                    { *m = MerkleProof{} }
+func (m *MerkleProof) String() string            { return proto.CompactTextString(m) }
+func (*MerkleProof) ProtoMessage()               {}
+func (*MerkleProof) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *MerkleProof) GetLevels() []*MerkleProofLevel {
+	if m != nil {
+		return m.Levels
+	}
+	return nil
+}
+
+// MerkleProofLevel holds the siblings of the node on the path from the entry to the root, at one
+// level of the MerkleTree. It holds no sibling when the node is the only child of its parent
+type MerkleProofLevel struct {
+	Siblings [][]byte `protobuf:"bytes,1,rep,name=Siblings,proto3" json:"Siblings,omitempty"`
+}
+
+func (m *MerkleProofLevel) Reset()                    { *m = MerkleProofLevel{} }
+func (m *MerkleProofLevel) String() string            { return proto.CompactTextString(m) }
+func (*MerkleProofLevel) ProtoMessage()               {}
+func (*MerkleProofLevel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+// Metadata is a common structure to be used to encode block metadata
+type Metadata struct {
+	Value      []byte               `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Signatures []*MetadataSignature `protobuf:"bytes,2,rep,name=signatures" json:"signatures,omitempty"`
+}
+
+func (m *Metadata) Reset()                    { *m = Metadata{} }
+func (m *Metadata) String() string            { return proto.CompactTextString(m) }
+func (*Metadata) ProtoMessage()               {}
+func (*Metadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *Metadata) GetSignatures() []*MetadataSignature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+// MetadataSignature is the signature of a block by an orderer. The signature
+// is over the metadata value and the bytes of the block header
+type MetadataSignature struct {
+	SignatureHeader []byte `protobuf:"bytes,1,opt,name=signatureHeader,proto3" json:"signatureHeader,omitempty"`
+	Signature       []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *MetadataSignature) Reset()                    { *m = MetadataSignature{} }
+func (m *MetadataSignature) String() string            { return proto.CompactTextString(m) }
+func (*MetadataSignature) ProtoMessage()               {}
+func (*MetadataSignature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
 func init() {
 	proto.RegisterType((*Header)(nil), "common.Header")
 	proto.RegisterType((*ChainHeader)(nil), "common.ChainHeader")
@@ -294,56 +388,72 @@ func init() {
 	proto.RegisterType((*BlockHeader)(nil), "common.BlockHeader")
 	proto.RegisterType((*BlockData)(nil), "common.BlockData")
 	proto.RegisterType((*BlockMetadata)(nil), "common.BlockMetadata")
+	proto.RegisterType((*MerkleProof)(nil), "common.MerkleProof")
+	proto.RegisterType((*MerkleProofLevel)(nil), "common.MerkleProofLevel")
+	proto.RegisterType((*Metadata)(nil), "common.Metadata")
+	proto.RegisterType((*MetadataSignature)(nil), "common.MetadataSignature")
 	proto.RegisterEnum("common.Status", Status_name, Status_value)
 	proto.RegisterEnum("common.HeaderType", HeaderType_name, HeaderType_value)
+	proto.RegisterEnum("common.BlockMetadataIndex", BlockMetadataIndex_name, BlockMetadataIndex_value)
 }
 
 func init() { proto.RegisterFile("common/common.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 694 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x5c, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
-	0x14, 0xad, 0xeb, 0x3c, 0x9a, 0xeb, 0xd0, 0x9a, 0xe9, 0x03, 0x13, 0x81, 0x1a, 0x59, 0x02, 0x45,
-	0xad, 0x48, 0x44, 0x11, 0x12, 0x5b, 0x27, 0x9e, 0xb6, 0x16, 0xad, 0x5d, 0xc6, 0x4e, 0x91, 0xd8,
-	0x44, 0x4e, 0x32, 0x4d, 0x02, 0x89, 0x27, 0x72, 0x9c, 0xaa, 0xdd, 0xb2, 0x45, 0x42, 0x48, 0xf0,
-	0x51, 0xfc, 0x01, 0x3f, 0x82, 0xc4, 0x16, 0xcd, 0x8c, 0x9d, 0x47, 0x57, 0x99, 0x73, 0xcf, 0x7d,
-	0x9c, 0x73, 0xc7, 0x19, 0xd8, 0xed, 0xb1, 0xc9, 0x84, 0x45, 0x0d, 0xf9, 0x53, 0x9f, 0xc6, 0x2c,
-	0x61, 0xa8, 0x20, 0x51, 0xe5, 0x70, 0xc0, 0xd8, 0x60, 0x4c, 0x1b, 0x22, 0xda, 0x9d, 0xdf, 0x34,
-	0x92, 0xd1, 0x84, 0xce, 0x92, 0x70, 0x32, 0x95, 0x89, 0xe6, 0x57, 0x05, 0x0a, 0xe7, 0x34, 0xec,
-	0xd3, 0x18, 0xbd, 0x05, 0xad, 0x37, 0x0c, 0x47, 0x91, 0x84, 0x86, 0x52, 0x55, 0x6a, 0xda, 0xc9,
-	0x6e, 0x3d, 0xed, 0xdb, 0x5a, 0x52, 0x64, 0x35, 0x0f, 0x59, 0xb0, 0x33, 0x1b, 0x0d, 0xa2, 0x30,
-	0x99, 0xc7, 0x34, 0x2d, 0xdd, 0x14, 0xa5, 0x4f, 0xb2, 0x52, 0x7f, 0x9d, 0x26, 0x0f, 0xf3, 0xcd,
-	0x3f, 0x0a, 0x68, 0x2b, 0xfd, 0x11, 0x82, 0x5c, 0x72, 0x3f, 0xa5, 0x42, 0x42, 0x9e, 0x88, 0x33,
-	0x32, 0xa0, 0x78, 0x4b, 0xe3, 0xd9, 0x88, 0x45, 0xa2, 0x7d, 0x9e, 0x64, 0x10, 0xbd, 0x83, 0xd2,
-	0xc2, 0x95, 0xa1, 0x8a, 0xd1, 0x95, 0xba, 0xf4, 0x5d, 0xcf, 0x7c, 0xd7, 0x83, 0x2c, 0x83, 0x2c,
-	0x93, 0x79, 0x4f, 0xe1, 0xc4, 0xb1, 0x8d, 0x5c, 0x55, 0xa9, 0x95, 0x49, 0x06, 0x85, 0x82, 0x3b,
-	0xc7, 0x36, 0xf2, 0x55, 0xa5, 0x56, 0x22, 0xe2, 0x8c, 0xf6, 0x20, 0x4f, 0xa7, 0xac, 0x37, 0x34,
-	0x0a, 0x55, 0xa5, 0x96, 0x23, 0x12, 0xa0, 0x67, 0x50, 0xa2, 0x77, 0x09, 0x8d, 0x84, 0xb2, 0xa2,
-	0xe8, 0xb2, 0x0c, 0x98, 0x16, 0xec, 0x3c, 0x70, 0x2f, 0x86, 0xc6, 0x34, 0x4c, 0x98, 0x5c, 0x31,
-	0x1f, 0x2a, 0x21, 0x1f, 0x10, 0xb1, 0xa8, 0x47, 0x85, 0xc1, 0x32, 0x91, 0xc0, 0xc4, 0x50, 0xbc,
-	0x0a, 0xef, 0xc7, 0x2c, 0xec, 0xa3, 0x97, 0x50, 0x18, 0xae, 0x5e, 0xce, 0x76, 0xb6, 0xe1, 0x74,
-	0xb1, 0x29, 0xcb, 0xd5, 0xf7, 0xc3, 0x24, 0x4c, 0xfb, 0x88, 0xb3, 0xd9, 0x84, 0x2d, 0x1c, 0xdd,
-	0xd2, 0x31, 0x93, 0xbb, 0x9c, 0xca, 0x96, 0x99, 0x84, 0x14, 0x72, 0x37, 0x8b, 0xcb, 0x49, 0xcb,
-	0x97, 0x01, 0xf3, 0xbb, 0x02, 0xf9, 0xe6, 0x98, 0xf5, 0xbe, 0xa0, 0xe3, 0xec, 0xab, 0x79, 0xf8,
-	0x99, 0x08, 0x3a, 0x93, 0x93, 0x3a, 0x7e, 0x01, 0x39, 0x3b, 0x93, 0xa3, 0x9d, 0x3c, 0x5e, 0x4b,
-	0xe5, 0x04, 0x11, 0x34, 0x7a, 0x0d, 0x5b, 0x97, 0x34, 0x09, 0x85, 0x72, 0x79, 0x8d, 0xfb, 0x6b,
-	0xa9, 0x19, 0x49, 0x16, 0x69, 0x26, 0x05, 0x6d, 0x65, 0x20, 0x3a, 0x80, 0x82, 0x3b, 0x9f, 0x74,
-	0x53, 0x55, 0x39, 0x92, 0x22, 0x64, 0x42, 0xf9, 0x2a, 0xa6, 0xb7, 0x23, 0x36, 0x9f, 0x9d, 0x87,
-	0xb3, 0x61, 0x6a, 0x6c, 0x2d, 0x86, 0x2a, 0xb0, 0xc5, 0x55, 0x08, 0x5e, 0x15, 0xfc, 0x02, 0x9b,
-	0x87, 0x50, 0x5a, 0x88, 0xe5, 0xcb, 0x15, 0x6e, 0x94, 0xaa, 0xca, 0x97, 0xcb, 0xcf, 0xe6, 0x31,
-	0x3c, 0x5a, 0x93, 0xc8, 0xbb, 0x2d, 0xbc, 0xc8, 0xc4, 0x05, 0x3e, 0xfa, 0xa6, 0x40, 0xc1, 0x4f,
-	0xc2, 0x64, 0x3e, 0x43, 0x1a, 0x14, 0xdb, 0xee, 0x7b, 0xd7, 0xfb, 0xe8, 0xea, 0x1b, 0xa8, 0x0c,
-	0x45, 0xbf, 0xdd, 0x6a, 0x61, 0xdf, 0xd7, 0x7f, 0x2b, 0x48, 0x07, 0xad, 0x69, 0xd9, 0x1d, 0x82,
-	0x3f, 0xb4, 0xb1, 0x1f, 0xe8, 0x3f, 0x54, 0xb4, 0x0d, 0xa5, 0x53, 0x8f, 0x34, 0x1d, 0xdb, 0xc6,
-	0xae, 0xfe, 0x53, 0x60, 0xd7, 0x0b, 0x3a, 0xa7, 0x5e, 0xdb, 0xb5, 0xf5, 0x5f, 0x2a, 0xaa, 0xc0,
-	0xbe, 0xe3, 0x06, 0x98, 0xb8, 0xd6, 0x45, 0xc7, 0xc7, 0xe4, 0x1a, 0x93, 0x0e, 0x26, 0xc4, 0x23,
-	0xfa, 0x5f, 0x15, 0x19, 0xb0, 0xcb, 0x43, 0x4e, 0x0b, 0x77, 0xda, 0xae, 0x75, 0x6d, 0x39, 0x17,
-	0x56, 0xf3, 0x02, 0xeb, 0xff, 0xd4, 0xa3, 0xcf, 0x00, 0x72, 0x7b, 0x01, 0xff, 0x97, 0x69, 0x50,
-	0xbc, 0xc4, 0xbe, 0x6f, 0x9d, 0x61, 0x7d, 0x03, 0x3d, 0x87, 0xa7, 0x2d, 0xcf, 0x3d, 0x75, 0xce,
-	0xda, 0xc4, 0x0a, 0x1c, 0xcf, 0xed, 0x04, 0xc4, 0x72, 0x7d, 0xab, 0xc5, 0xcf, 0xba, 0x82, 0x0e,
-	0x00, 0xad, 0xd3, 0x4e, 0x80, 0x2f, 0xf5, 0x4d, 0x64, 0xc0, 0x1e, 0x76, 0x6d, 0x8f, 0xf8, 0x98,
-	0xac, 0x55, 0xa8, 0xcd, 0x57, 0x9f, 0x8e, 0x07, 0xa3, 0x64, 0x38, 0xef, 0xf2, 0x7b, 0x6d, 0x0c,
-	0xef, 0xa7, 0x34, 0x1e, 0xd3, 0xfe, 0x80, 0xc6, 0x8d, 0x9b, 0xb0, 0x1b, 0x8f, 0x7a, 0xf2, 0x99,
-	0x9a, 0xa5, 0x4f, 0x59, 0xb7, 0x20, 0xe0, 0x9b, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0e, 0x46,
-	0x37, 0x79, 0xe2, 0x04, 0x00, 0x00,
+	// 867 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x7c, 0x55, 0x5f, 0x6f, 0xe3, 0xc4,
+	0x17, 0xad, 0xeb, 0xfc, 0x69, 0xae, 0xd3, 0xae, 0x3b, 0xdd, 0xee, 0xcf, 0x5b, 0xfd, 0xd0, 0x46,
+	0x16, 0xa0, 0xa8, 0x15, 0x09, 0x14, 0x21, 0xc1, 0xa3, 0x13, 0x4f, 0xbb, 0xd6, 0xa6, 0x76, 0x19,
+	0x3b, 0xbb, 0x12, 0xfb, 0x10, 0x39, 0xc9, 0x34, 0xb1, 0xd6, 0xb1, 0x23, 0xdb, 0x89, 0xda, 0x47,
+	0x78, 0x45, 0x20, 0x24, 0xf8, 0x50, 0x7c, 0x03, 0xbe, 0x08, 0x12, 0xaf, 0x68, 0x66, 0xec, 0x24,
+	0xce, 0x4a, 0x3c, 0x75, 0xce, 0xbd, 0x67, 0xee, 0x3d, 0xe7, 0xce, 0x6d, 0x0c, 0x67, 0x93, 0x78,
+	0xb1, 0x88, 0xa3, 0xae, 0xf8, 0xd3, 0x59, 0x26, 0x71, 0x16, 0xa3, 0x9a, 0x40, 0x17, 0xaf, 0x66,
+	0x71, 0x3c, 0x0b, 0x69, 0x97, 0x47, 0xc7, 0xab, 0x87, 0x6e, 0x16, 0x2c, 0x68, 0x9a, 0xf9, 0x8b,
+	0xa5, 0x20, 0xea, 0x3f, 0x49, 0x50, 0x7b, 0x4d, 0xfd, 0x29, 0x4d, 0xd0, 0x37, 0xa0, 0x4c, 0xe6,
+	0x7e, 0x10, 0x09, 0xa8, 0x49, 0x2d, 0xa9, 0xad, 0x5c, 0x9f, 0x75, 0xf2, 0xba, 0xfd, 0x6d, 0x8a,
+	0xec, 0xf2, 0x90, 0x01, 0xcf, 0xd2, 0x60, 0x16, 0xf9, 0xd9, 0x2a, 0xa1, 0xf9, 0xd5, 0x43, 0x7e,
+	0xf5, 0x7f, 0xc5, 0x55, 0xb7, 0x9c, 0x26, 0xfb, 0x7c, 0xfd, 0x2f, 0x09, 0x94, 0x9d, 0xfa, 0x08,
+	0x41, 0x25, 0x7b, 0x5a, 0x52, 0x2e, 0xa1, 0x4a, 0xf8, 0x19, 0x69, 0x50, 0x5f, 0xd3, 0x24, 0x0d,
+	0xe2, 0x88, 0x97, 0xaf, 0x92, 0x02, 0xa2, 0x6f, 0xa1, 0xb1, 0x71, 0xa5, 0xc9, 0xbc, 0xf5, 0x45,
+	0x47, 0xf8, 0xee, 0x14, 0xbe, 0x3b, 0x5e, 0xc1, 0x20, 0x5b, 0x32, 0xab, 0xc9, 0x9d, 0x58, 0xa6,
+	0x56, 0x69, 0x49, 0xed, 0x26, 0x29, 0x20, 0x57, 0xf0, 0x68, 0x99, 0x5a, 0xb5, 0x25, 0xb5, 0x1b,
+	0x84, 0x9f, 0xd1, 0x73, 0xa8, 0xd2, 0x65, 0x3c, 0x99, 0x6b, 0xb5, 0x96, 0xd4, 0xae, 0x10, 0x01,
+	0xd0, 0xff, 0xa1, 0x41, 0x1f, 0x33, 0x1a, 0x71, 0x65, 0x75, 0x5e, 0x65, 0x1b, 0xd0, 0x0d, 0x78,
+	0xb6, 0xe7, 0x9e, 0x37, 0x4d, 0xa8, 0x9f, 0xc5, 0x62, 0xc4, 0x4d, 0x52, 0x40, 0xd6, 0x20, 0x8a,
+	0xa3, 0x09, 0xe5, 0x06, 0x9b, 0x44, 0x00, 0x1d, 0x43, 0xfd, 0xde, 0x7f, 0x0a, 0x63, 0x7f, 0x8a,
+	0x3e, 0x87, 0xda, 0x7c, 0xf7, 0x71, 0x4e, 0x8a, 0x09, 0xe7, 0x83, 0xcd, 0xb3, 0x4c, 0xfd, 0xd4,
+	0xcf, 0xfc, 0xbc, 0x0e, 0x3f, 0xeb, 0x3d, 0x38, 0xc2, 0xd1, 0x9a, 0x86, 0xb1, 0x98, 0xe5, 0x52,
+	0x94, 0x2c, 0x24, 0xe4, 0x90, 0xb9, 0xd9, 0x3c, 0x4e, 0x7e, 0x7d, 0x1b, 0xd0, 0x7f, 0x95, 0xa0,
+	0xda, 0x0b, 0xe3, 0xc9, 0x07, 0x74, 0x55, 0x6c, 0xcd, 0xfe, 0x9a, 0xf0, 0x74, 0x21, 0x27, 0x77,
+	0xfc, 0x19, 0x54, 0xcc, 0x42, 0x8e, 0x72, 0x7d, 0x5a, 0xa2, 0xb2, 0x04, 0xe1, 0x69, 0xf4, 0x15,
+	0x1c, 0xdd, 0xd1, 0xcc, 0xe7, 0xca, 0xc5, 0x33, 0x9e, 0x97, 0xa8, 0x45, 0x92, 0x6c, 0x68, 0x3a,
+	0x05, 0x65, 0xa7, 0x21, 0x7a, 0x01, 0x35, 0x7b, 0xb5, 0x18, 0xe7, 0xaa, 0x2a, 0x24, 0x47, 0x48,
+	0x87, 0xe6, 0x7d, 0x42, 0xd7, 0x41, 0xbc, 0x4a, 0x5f, 0xfb, 0xe9, 0x3c, 0x37, 0x56, 0x8a, 0xa1,
+	0x0b, 0x38, 0x62, 0x2a, 0x78, 0x5e, 0xe6, 0xf9, 0x0d, 0xd6, 0x5f, 0x41, 0x63, 0x23, 0x96, 0x0d,
+	0x97, 0xbb, 0x91, 0x5a, 0x32, 0x1b, 0x2e, 0x3b, 0xeb, 0x57, 0x70, 0x5c, 0x92, 0xc8, 0xaa, 0x6d,
+	0xbc, 0x08, 0xe2, 0x56, 0xf4, 0x2f, 0x12, 0x28, 0x77, 0x34, 0xf9, 0x10, 0xd2, 0xfb, 0x24, 0x8e,
+	0x1f, 0x50, 0x2b, 0x37, 0x51, 0x92, 0xbe, 0x1b, 0x62, 0x8b, 0x61, 0x45, 0x53, 0xfa, 0xc8, 0x85,
+	0x1f, 0x13, 0x01, 0x58, 0xf4, 0x5d, 0x30, 0xcd, 0x84, 0xdc, 0x63, 0x22, 0x00, 0xfa, 0x12, 0x6a,
+	0x03, 0xba, 0xa6, 0x61, 0xaa, 0x55, 0x5a, 0x72, 0x5b, 0xb9, 0xd6, 0x8a, 0x19, 0xee, 0xb4, 0xe4,
+	0x04, 0x92, 0xf3, 0xf4, 0x0e, 0xa8, 0xfb, 0x39, 0xa6, 0xdf, 0x0d, 0xc6, 0x61, 0x10, 0xcd, 0xd2,
+	0x42, 0x7f, 0x81, 0xf5, 0xf7, 0x5b, 0x6f, 0x4c, 0xc3, 0xda, 0x0f, 0x57, 0x34, 0xdf, 0x23, 0x01,
+	0xd0, 0x77, 0x00, 0x9b, 0xa5, 0x49, 0xb5, 0x43, 0xae, 0xe3, 0xe5, 0x56, 0x87, 0xb8, 0xbb, 0xf9,
+	0xbf, 0x20, 0x3b, 0x64, 0xfd, 0x3d, 0x9c, 0x7e, 0x44, 0x40, 0xed, 0x8f, 0x7f, 0x62, 0x44, 0xbf,
+	0xfd, 0xf0, 0x7f, 0xef, 0xef, 0xe5, 0xcf, 0x12, 0xd4, 0xdc, 0xcc, 0xcf, 0x56, 0x29, 0x52, 0xa0,
+	0x3e, 0xb4, 0xdf, 0xd8, 0xce, 0x3b, 0x5b, 0x3d, 0x40, 0x4d, 0xa8, 0xbb, 0xc3, 0x7e, 0x1f, 0xbb,
+	0xae, 0xfa, 0xa7, 0x84, 0x54, 0x50, 0x7a, 0x86, 0x39, 0x22, 0xf8, 0xfb, 0x21, 0x76, 0x3d, 0xf5,
+	0x37, 0x19, 0x9d, 0x40, 0xe3, 0xc6, 0x21, 0x3d, 0xcb, 0x34, 0xb1, 0xad, 0xfe, 0xce, 0xb1, 0xed,
+	0x78, 0xa3, 0x1b, 0x67, 0x68, 0x9b, 0xea, 0x1f, 0x32, 0xba, 0x80, 0x73, 0xcb, 0xf6, 0x30, 0xb1,
+	0x8d, 0xc1, 0xc8, 0xc5, 0xe4, 0x2d, 0x26, 0x23, 0x4c, 0x88, 0x43, 0xd4, 0xbf, 0x65, 0xa4, 0xc1,
+	0x19, 0x0b, 0x59, 0x7d, 0x3c, 0x1a, 0xda, 0xc6, 0x5b, 0xc3, 0x1a, 0x18, 0xbd, 0x01, 0x56, 0xff,
+	0x91, 0x2f, 0x7f, 0x94, 0x00, 0x84, 0x6c, 0x8f, 0xfd, 0xc0, 0x29, 0x50, 0xbf, 0xc3, 0xae, 0x6b,
+	0xdc, 0x62, 0xf5, 0x00, 0x7d, 0x02, 0x2f, 0xfb, 0x8e, 0x7d, 0x63, 0xdd, 0x0e, 0x89, 0xe1, 0x59,
+	0x8e, 0x3d, 0xf2, 0x88, 0x61, 0xbb, 0x46, 0x9f, 0x9d, 0x55, 0x09, 0xbd, 0x00, 0x54, 0x4e, 0x5b,
+	0x1e, 0xbe, 0x53, 0x0f, 0x91, 0x06, 0xcf, 0xb1, 0x6d, 0x3a, 0xc4, 0xc5, 0xa4, 0x74, 0x43, 0x46,
+	0xe7, 0x70, 0x6a, 0xe2, 0x81, 0xc5, 0xa4, 0xb9, 0x18, 0xbf, 0x19, 0x59, 0xf6, 0x8d, 0xa3, 0x56,
+	0x2e, 0x3f, 0x05, 0x54, 0x5a, 0x5c, 0xb1, 0x59, 0x27, 0x00, 0xae, 0x75, 0x6b, 0x1b, 0xde, 0x90,
+	0x60, 0x57, 0x3d, 0xe8, 0x7d, 0xf1, 0xc3, 0xd5, 0x2c, 0xc8, 0xe6, 0xab, 0x31, 0x7b, 0xc3, 0xee,
+	0xfc, 0x69, 0x49, 0x93, 0x90, 0x4e, 0x67, 0x34, 0xe9, 0x3e, 0xf8, 0xe3, 0x24, 0x98, 0x88, 0xcf,
+	0x4b, 0x9a, 0x7f, 0x82, 0xc6, 0x35, 0x0e, 0xbf, 0xfe, 0x77, 0x00, 0xee, 0xf4, 0xc2, 0xa2, 0x9a,
+	0x06, 0x00, 0x00,
 }
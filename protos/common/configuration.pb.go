@@ -42,6 +42,53 @@ func (ConfigurationItem_ConfigurationType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor1, []int{2, 0}
 }
 
+type MSPPrincipal_Classification int32
+
+const (
+	MSPPrincipal_ByIdentity         MSPPrincipal_Classification = 0
+	MSPPrincipal_ByMSPRole          MSPPrincipal_Classification = 1
+	MSPPrincipal_ByOrganizationUnit MSPPrincipal_Classification = 2
+)
+
+var MSPPrincipal_Classification_name = map[int32]string{
+	0: "ByIdentity",
+	1: "ByMSPRole",
+	2: "ByOrganizationUnit",
+}
+var MSPPrincipal_Classification_value = map[string]int32{
+	"ByIdentity":         0,
+	"ByMSPRole":          1,
+	"ByOrganizationUnit": 2,
+}
+
+func (x MSPPrincipal_Classification) String() string {
+	return proto.EnumName(MSPPrincipal_Classification_name, int32(x))
+}
+func (MSPPrincipal_Classification) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{6, 0}
+}
+
+type MSPRole_MSPRoleType int32
+
+const (
+	MSPRole_Member MSPRole_MSPRoleType = 0
+	MSPRole_Admin  MSPRole_MSPRoleType = 1
+)
+
+var MSPRole_MSPRoleType_name = map[int32]string{
+	0: "Member",
+	1: "Admin",
+}
+var MSPRole_MSPRoleType_value = map[string]int32{
+	"Member": 0,
+	"Admin":  1,
+}
+
+func (x MSPRole_MSPRoleType) String() string {
+	return proto.EnumName(MSPRole_MSPRoleType_name, int32(x))
+}
+func (MSPRole_MSPRoleType) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{7, 0} }
+
 // ConfigurationEnvelope is designed to contain _all_ configuration for a chain with no dependency
 // on previous configuration transactions.
 //
@@ -221,10 +268,13 @@ func _Policy_OneofSizer(msg proto.Message) (n int) {
 }
 
 // SignaturePolicyEnvelope wraps a SignaturePolicy and includes a version for future enhancements
+// The SignedBy of the policy refer to the Principals by index, or to the Identities when there are
+// no Principals, each identity then being the principal of exactly that identity
 type SignaturePolicyEnvelope struct {
 	Version    int32            `protobuf:"varint,1,opt,name=Version" json:"Version,omitempty"`
 	Policy     *SignaturePolicy `protobuf:"bytes,2,opt,name=Policy" json:"Policy,omitempty"`
 	Identities [][]byte         `protobuf:"bytes,3,rep,name=Identities,proto3" json:"Identities,omitempty"`
+	Principals []*MSPPrincipal  `protobuf:"bytes,4,rep,name=Principals" json:"Principals,omitempty"`
 }
 
 func (m *SignaturePolicyEnvelope) Reset()                    { *m = SignaturePolicyEnvelope{} }
@@ -239,6 +289,47 @@ func (m *SignaturePolicyEnvelope) GetPolicy() *SignaturePolicy {
 	return nil
 }
 
+func (m *SignaturePolicyEnvelope) GetPrincipals() []*MSPPrincipal {
+	if m != nil {
+		return m.Principals
+	}
+	return nil
+}
+
+// MSPPrincipal identifies a set of identities of an MSP, a signature policy requires signatures of
+// identities of its principals
+type MSPPrincipal struct {
+	PrincipalClassification MSPPrincipal_Classification `protobuf:"varint,1,opt,name=PrincipalClassification,enum=common.MSPPrincipal_Classification" json:"PrincipalClassification,omitempty"`
+	Principal               []byte                      `protobuf:"bytes,2,opt,name=Principal,proto3" json:"Principal,omitempty"`
+}
+
+func (m *MSPPrincipal) Reset()                    { *m = MSPPrincipal{} }
+func (m *MSPPrincipal) String() string            { return proto.CompactTextString(m) }
+func (*MSPPrincipal) ProtoMessage()               {}
+func (*MSPPrincipal) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+// MSPRole is a role of the identities of an MSP
+type MSPRole struct {
+	MSPIdentifier string              `protobuf:"bytes,1,opt,name=MSPIdentifier" json:"MSPIdentifier,omitempty"`
+	Role          MSPRole_MSPRoleType `protobuf:"varint,2,opt,name=Role,enum=common.MSPRole_MSPRoleType" json:"Role,omitempty"`
+}
+
+func (m *MSPRole) Reset()                    { *m = MSPRole{} }
+func (m *MSPRole) String() string            { return proto.CompactTextString(m) }
+func (*MSPRole) ProtoMessage()               {}
+func (*MSPRole) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+// OrganizationUnit is an organizational unit of the identities of an MSP, as found in their certificates
+type OrganizationUnit struct {
+	MSPIdentifier                string `protobuf:"bytes,1,opt,name=MSPIdentifier" json:"MSPIdentifier,omitempty"`
+	OrganizationalUnitIdentifier string `protobuf:"bytes,2,opt,name=OrganizationalUnitIdentifier" json:"OrganizationalUnitIdentifier,omitempty"`
+}
+
+func (m *OrganizationUnit) Reset()                    { *m = OrganizationUnit{} }
+func (m *OrganizationUnit) String() string            { return proto.CompactTextString(m) }
+func (*OrganizationUnit) ProtoMessage()               {}
+func (*OrganizationUnit) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
 // SignaturePolicy is a recursive message structure which defines a featherweight DSL for describing
 // policies which are more complicated than 'exactly this signature'.  The NOutOf operator is sufficent
 // to express AND as well as OR, as well as of course N out of the following M policies
@@ -255,7 +346,7 @@ type SignaturePolicy struct {
 func (m *SignaturePolicy) Reset()                    { *m = SignaturePolicy{} }
 func (m *SignaturePolicy) String() string            { return proto.CompactTextString(m) }
 func (*SignaturePolicy) ProtoMessage()               {}
-func (*SignaturePolicy) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+func (*SignaturePolicy) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
 
 type isSignaturePolicy_Type interface {
 	isSignaturePolicy_Type()
@@ -369,7 +460,7 @@ type SignaturePolicy_NOutOf struct {
 func (m *SignaturePolicy_NOutOf) Reset()                    { *m = SignaturePolicy_NOutOf{} }
 func (m *SignaturePolicy_NOutOf) String() string            { return proto.CompactTextString(m) }
 func (*SignaturePolicy_NOutOf) ProtoMessage()               {}
-func (*SignaturePolicy_NOutOf) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6, 0} }
+func (*SignaturePolicy_NOutOf) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9, 0} }
 
 func (m *SignaturePolicy_NOutOf) GetPolicies() []*SignaturePolicy {
 	if m != nil {
@@ -378,6 +469,29 @@ func (m *SignaturePolicy_NOutOf) GetPolicies() []*SignaturePolicy {
 	return nil
 }
 
+// OrdererAddresses are the addresses of the orderers of the chain, the value of a configuration item of type Chain
+type OrdererAddresses struct {
+	Addresses []string `protobuf:"bytes,1,rep,name=Addresses" json:"Addresses,omitempty"`
+}
+
+func (m *OrdererAddresses) Reset()                    { *m = OrdererAddresses{} }
+func (m *OrdererAddresses) String() string            { return proto.CompactTextString(m) }
+func (*OrdererAddresses) ProtoMessage()               {}
+func (*OrdererAddresses) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+// MSPConfig is the configuration of the membership service provider of an organisation of the chain, the value
+// of a configuration item of type Chain. The certificates are PEM encoded
+type MSPConfig struct {
+	Name       string   `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	RootCerts  [][]byte `protobuf:"bytes,2,rep,name=RootCerts,proto3" json:"RootCerts,omitempty"`
+	AdminCerts [][]byte `protobuf:"bytes,3,rep,name=AdminCerts,proto3" json:"AdminCerts,omitempty"`
+}
+
+func (m *MSPConfig) Reset()                    { *m = MSPConfig{} }
+func (m *MSPConfig) String() string            { return proto.CompactTextString(m) }
+func (*MSPConfig) ProtoMessage()               {}
+func (*MSPConfig) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+
 func init() {
 	proto.RegisterType((*ConfigurationEnvelope)(nil), "common.ConfigurationEnvelope")
 	proto.RegisterType((*SignedConfigurationItem)(nil), "common.SignedConfigurationItem")
@@ -385,47 +499,70 @@ func init() {
 	proto.RegisterType((*ConfigurationSignature)(nil), "common.ConfigurationSignature")
 	proto.RegisterType((*Policy)(nil), "common.Policy")
 	proto.RegisterType((*SignaturePolicyEnvelope)(nil), "common.SignaturePolicyEnvelope")
+	proto.RegisterType((*MSPPrincipal)(nil), "common.MSPPrincipal")
+	proto.RegisterType((*MSPRole)(nil), "common.MSPRole")
+	proto.RegisterType((*OrganizationUnit)(nil), "common.OrganizationUnit")
 	proto.RegisterType((*SignaturePolicy)(nil), "common.SignaturePolicy")
 	proto.RegisterType((*SignaturePolicy_NOutOf)(nil), "common.SignaturePolicy.NOutOf")
+	proto.RegisterType((*OrdererAddresses)(nil), "common.OrdererAddresses")
+	proto.RegisterType((*MSPConfig)(nil), "common.MSPConfig")
 	proto.RegisterEnum("common.ConfigurationItem_ConfigurationType", ConfigurationItem_ConfigurationType_name, ConfigurationItem_ConfigurationType_value)
+	proto.RegisterEnum("common.MSPPrincipal_Classification", MSPPrincipal_Classification_name, MSPPrincipal_Classification_value)
+	proto.RegisterEnum("common.MSPRole_MSPRoleType", MSPRole_MSPRoleType_name, MSPRole_MSPRoleType_value)
 }
 
 func init() { proto.RegisterFile("common/configuration.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 540 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x54, 0xdf, 0x8f, 0xd2, 0x40,
-	0x10, 0x66, 0x29, 0xed, 0xdd, 0x0d, 0x8d, 0x87, 0x73, 0xea, 0x35, 0xe4, 0x82, 0x4d, 0x9f, 0x9a,
-	0xa0, 0x90, 0x70, 0xfa, 0xaa, 0x09, 0x17, 0x2f, 0x5c, 0x50, 0x30, 0xab, 0xb9, 0x07, 0x13, 0x13,
-	0x0b, 0x5d, 0x60, 0x13, 0xe8, 0x92, 0x6d, 0x31, 0xe1, 0xdd, 0xc4, 0xff, 0xc9, 0x47, 0xff, 0x32,
-	0xd3, 0xdd, 0xb6, 0xf2, 0xa3, 0x3c, 0xb5, 0xfb, 0xcd, 0xf7, 0xcd, 0x7c, 0x33, 0x9d, 0x2e, 0x34,
-	0xa7, 0x62, 0xb5, 0x12, 0x51, 0x77, 0x2a, 0xa2, 0x19, 0x9f, 0x6f, 0x64, 0x90, 0x70, 0x11, 0x75,
-	0xd6, 0x52, 0x24, 0x02, 0x2d, 0x1d, 0x6b, 0x5e, 0x15, 0x9c, 0xf4, 0xa1, 0x83, 0xde, 0x08, 0x9e,
-	0xdf, 0xed, 0x6a, 0x3e, 0x44, 0x3f, 0xd9, 0x52, 0xac, 0x19, 0xbe, 0x05, 0xf3, 0x21, 0x61, 0xab,
-	0xd8, 0x21, 0xae, 0xe1, 0xd7, 0x7b, 0x2f, 0x3b, 0x99, 0xec, 0x0b, 0x9f, 0x47, 0x2c, 0xdc, 0xd3,
-	0xa4, 0x3c, 0xaa, 0xd9, 0xde, 0x6f, 0x02, 0xd7, 0x27, 0x28, 0xf8, 0x0a, 0x9e, 0x1e, 0x81, 0x0e,
-	0x71, 0x89, 0x6f, 0xd3, 0xe3, 0x00, 0xbe, 0x03, 0x48, 0x13, 0x05, 0xc9, 0x46, 0xb2, 0xd8, 0xa9,
-	0x2a, 0x17, 0xad, 0xdc, 0xc5, 0x1e, 0xbd, 0xa0, 0xd1, 0x1d, 0x85, 0xf7, 0xb7, 0x5a, 0x52, 0x0e,
-	0xdb, 0x60, 0x0d, 0x58, 0x10, 0x32, 0xa9, 0x0a, 0xd7, 0x7b, 0x57, 0x45, 0xc6, 0x45, 0xc0, 0x23,
-	0x1d, 0xa2, 0x19, 0x05, 0xdf, 0x43, 0xed, 0xeb, 0x76, 0xcd, 0x9c, 0xaa, 0x4b, 0xfc, 0x27, 0xbd,
-	0x76, 0x69, 0xf1, 0x34, 0xeb, 0x3e, 0x92, 0x4a, 0xa8, 0x12, 0xa2, 0x07, 0xf6, 0xc7, 0x20, 0x4e,
-	0x3e, 0x89, 0x90, 0xcf, 0x38, 0x0b, 0x1d, 0xc3, 0x25, 0x7e, 0x8d, 0xee, 0x61, 0xd8, 0x01, 0xd4,
-	0xef, 0x53, 0xa5, 0xfe, 0x2c, 0x96, 0x7c, 0xba, 0x75, 0x6a, 0x2e, 0xf1, 0x2f, 0x68, 0x49, 0x04,
-	0x1b, 0x60, 0x0c, 0xd9, 0xd6, 0x31, 0x15, 0x21, 0x7d, 0xc5, 0x67, 0x60, 0x3e, 0x06, 0xcb, 0x0d,
-	0x73, 0x2c, 0x35, 0x4b, 0x7d, 0xf0, 0xee, 0x0e, 0xda, 0x57, 0x86, 0x00, 0x2c, 0x9d, 0xa6, 0x51,
-	0xc1, 0x0b, 0x30, 0x55, 0xd3, 0x0d, 0x82, 0x75, 0x38, 0x1b, 0xcb, 0x90, 0x49, 0x26, 0x1b, 0xd5,
-	0x94, 0x73, 0x1f, 0x4c, 0x24, 0x9f, 0x36, 0x0c, 0xef, 0x07, 0xbc, 0x28, 0x1f, 0x35, 0xfa, 0x70,
-	0x19, 0xe7, 0x87, 0x9d, 0x89, 0xda, 0xf4, 0x10, 0xc6, 0x1b, 0xb8, 0x28, 0x20, 0x35, 0x4a, 0x9b,
-	0xfe, 0x07, 0xbc, 0xef, 0xb9, 0x23, 0x1c, 0xc2, 0x65, 0x91, 0x3e, 0x9b, 0x82, 0xfe, 0x46, 0x7b,
-	0xbb, 0xb7, 0x13, 0xce, 0x77, 0x75, 0x50, 0xa1, 0x87, 0xca, 0xbe, 0xa5, 0x3f, 0x9d, 0xf7, 0x2b,
-	0xdb, 0xc7, 0x12, 0x19, 0x3a, 0x70, 0xf6, 0xc8, 0x64, 0xcc, 0x45, 0xa4, 0x0a, 0x99, 0x34, 0x3f,
-	0x62, 0x37, 0x37, 0xa5, 0xfc, 0xd6, 0x7b, 0xd7, 0x27, 0x1c, 0xd0, 0xdc, 0x7b, 0x0b, 0xe0, 0x21,
-	0x64, 0x51, 0xc2, 0x13, 0xce, 0x62, 0xc7, 0x70, 0x0d, 0xdf, 0xa6, 0x3b, 0x88, 0xf7, 0x87, 0x1c,
-	0x35, 0x87, 0x37, 0x70, 0xae, 0xff, 0x94, 0xbe, 0x6e, 0xd4, 0x1c, 0x54, 0x68, 0x81, 0xe0, 0x1b,
-	0xa8, 0xdd, 0x4b, 0xb1, 0xca, 0x0c, 0xb4, 0x4e, 0x18, 0xe8, 0x8c, 0xc6, 0x9b, 0x64, 0x3c, 0x1b,
-	0x54, 0xa8, 0x62, 0x37, 0x87, 0x60, 0x69, 0x04, 0x6d, 0x20, 0xa3, 0xac, 0x2d, 0x32, 0xc2, 0x5b,
-	0x38, 0x57, 0x02, 0x5e, 0xfc, 0x4a, 0x27, 0x5b, 0x2a, 0x88, 0xf9, 0x0c, 0xfb, 0xaf, 0xbf, 0xb5,
-	0xe7, 0x3c, 0x59, 0x6c, 0x26, 0xa9, 0xa4, 0xbb, 0xd8, 0xae, 0x99, 0x5c, 0xb2, 0x70, 0xce, 0x64,
-	0x77, 0xa6, 0xf6, 0xa4, 0xab, 0xae, 0x92, 0x38, 0xbb, 0x58, 0x26, 0x96, 0x3a, 0xde, 0xfe, 0x0b,
-	0x00, 0x00, 0xff, 0xff, 0x71, 0x39, 0xd4, 0x0c, 0x94, 0x04, 0x00, 0x00,
+	// 789 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x55, 0x5d, 0x6f, 0xda, 0x3a,
+	0x18, 0x26, 0x7c, 0xb5, 0xbc, 0xa4, 0x34, 0xc7, 0xed, 0x69, 0xa3, 0x9e, 0xaa, 0x07, 0xe5, 0xf4,
+	0x02, 0xa9, 0x67, 0x30, 0xd1, 0xee, 0x76, 0x53, 0x41, 0xeb, 0xa8, 0x3a, 0x3e, 0x64, 0xb6, 0x5e,
+	0x4c, 0xaa, 0xb4, 0x40, 0x0c, 0xb5, 0x94, 0x0f, 0xe4, 0x84, 0x49, 0x4c, 0xbb, 0xde, 0xfe, 0xd3,
+	0x2e, 0xf7, 0x1b, 0xf6, 0x83, 0x26, 0xdb, 0x89, 0x09, 0x14, 0xa6, 0x5d, 0xc5, 0x7e, 0xde, 0xe7,
+	0x79, 0xfd, 0xfa, 0xf5, 0x63, 0x07, 0x4e, 0xc6, 0x81, 0xe7, 0x05, 0x7e, 0x63, 0x1c, 0xf8, 0x13,
+	0x3a, 0x9d, 0x33, 0x3b, 0xa2, 0x81, 0x5f, 0x9f, 0xb1, 0x20, 0x0a, 0x50, 0x51, 0xc6, 0x4e, 0x0e,
+	0x14, 0xc7, 0xf3, 0x92, 0xa0, 0xd5, 0x83, 0xbf, 0xdb, 0x69, 0xcd, 0x6b, 0xff, 0x13, 0x71, 0x83,
+	0x19, 0x41, 0x2f, 0xa0, 0x70, 0x1b, 0x11, 0x2f, 0x34, 0xb5, 0x6a, 0xae, 0x56, 0x6e, 0xfe, 0x5b,
+	0x8f, 0x65, 0x43, 0x3a, 0xf5, 0x89, 0xb3, 0xa2, 0xe1, 0x3c, 0x2c, 0xd9, 0xd6, 0x37, 0x0d, 0x8e,
+	0xb7, 0x50, 0xd0, 0xff, 0xf0, 0xd7, 0x13, 0xd0, 0xd4, 0xaa, 0x5a, 0x4d, 0xc7, 0x4f, 0x03, 0xe8,
+	0x25, 0x00, 0x4f, 0x64, 0x47, 0x73, 0x46, 0x42, 0x33, 0x2b, 0xaa, 0x38, 0x4b, 0xaa, 0x58, 0xa1,
+	0x2b, 0x1a, 0x4e, 0x29, 0xac, 0x1f, 0xd9, 0x0d, 0xcb, 0xa1, 0x0b, 0x28, 0x76, 0x88, 0xed, 0x10,
+	0x26, 0x16, 0x2e, 0x37, 0x0f, 0x54, 0xc6, 0x47, 0x9b, 0xfa, 0x32, 0x84, 0x63, 0x0a, 0x7a, 0x05,
+	0xf9, 0x77, 0x8b, 0x19, 0x31, 0xb3, 0x55, 0xad, 0x56, 0x69, 0x5e, 0x6c, 0x5c, 0x9c, 0x67, 0x5d,
+	0x45, 0xb8, 0x04, 0x0b, 0x21, 0xb2, 0x40, 0x7f, 0x6b, 0x87, 0x51, 0x37, 0x70, 0xe8, 0x84, 0x12,
+	0xc7, 0xcc, 0x55, 0xb5, 0x5a, 0x1e, 0xaf, 0x60, 0xa8, 0x0e, 0x48, 0x8e, 0xc7, 0x42, 0x3d, 0x08,
+	0x5c, 0x3a, 0x5e, 0x98, 0xf9, 0xaa, 0x56, 0x2b, 0xe1, 0x0d, 0x11, 0x64, 0x40, 0xee, 0x8e, 0x2c,
+	0xcc, 0x82, 0x20, 0xf0, 0x21, 0x3a, 0x84, 0xc2, 0xbd, 0xed, 0xce, 0x89, 0x59, 0x14, 0xbd, 0x94,
+	0x13, 0xab, 0xbd, 0xb6, 0x7d, 0x51, 0x10, 0x40, 0x51, 0xa6, 0x31, 0x32, 0xa8, 0x04, 0x05, 0xb1,
+	0x69, 0x43, 0x43, 0x65, 0xd8, 0xe9, 0x33, 0x87, 0x30, 0xc2, 0x8c, 0x2c, 0xe7, 0xdc, 0xd8, 0x23,
+	0x46, 0xc7, 0x46, 0xce, 0xfa, 0x08, 0x47, 0x9b, 0x5b, 0x8d, 0x6a, 0xb0, 0x1f, 0x26, 0x93, 0x54,
+	0x47, 0x75, 0xbc, 0x0e, 0xa3, 0x53, 0x28, 0x29, 0x48, 0xb4, 0x52, 0xc7, 0x4b, 0xc0, 0x7a, 0x48,
+	0x2a, 0x42, 0x77, 0xb0, 0xaf, 0xd2, 0xc7, 0x5d, 0x90, 0x67, 0xb4, 0xe2, 0xbd, 0x54, 0x38, 0xf1,
+	0x6a, 0x27, 0x83, 0xd7, 0x95, 0xad, 0xa2, 0x3c, 0x3a, 0xeb, 0x7b, 0xec, 0xc7, 0x0d, 0x32, 0x64,
+	0xc2, 0xce, 0x3d, 0x61, 0x21, 0x0d, 0x7c, 0xb1, 0x50, 0x01, 0x27, 0x53, 0xd4, 0x48, 0x8a, 0x12,
+	0xf5, 0x96, 0x9b, 0xc7, 0x5b, 0x2a, 0xc0, 0x49, 0xed, 0x67, 0x00, 0xb7, 0x0e, 0xf1, 0x23, 0x1a,
+	0x51, 0x12, 0x9a, 0xb9, 0x6a, 0xae, 0xa6, 0xe3, 0x14, 0x82, 0xae, 0x00, 0x06, 0x8c, 0xfa, 0x63,
+	0x3a, 0xb3, 0xdd, 0xd0, 0xcc, 0x0b, 0x33, 0x1f, 0x26, 0x49, 0xbb, 0xc3, 0x81, 0x0a, 0xe2, 0x14,
+	0xcf, 0xfa, 0xa9, 0x81, 0x9e, 0x0e, 0xa2, 0x07, 0x38, 0x56, 0x93, 0xb6, 0x6b, 0x87, 0xa1, 0x32,
+	0x87, 0xd8, 0x41, 0xa5, 0xf9, 0xdf, 0xa6, 0x9c, 0xf5, 0x55, 0x2a, 0xde, 0x96, 0x83, 0x9f, 0x94,
+	0x0a, 0x25, 0x27, 0xa5, 0x00, 0xeb, 0x0d, 0x54, 0xd6, 0xf8, 0x15, 0x80, 0xd6, 0x22, 0xde, 0x25,
+	0x77, 0xd4, 0x1e, 0x94, 0x5a, 0x8b, 0xee, 0x70, 0x80, 0x03, 0x97, 0x18, 0x1a, 0x3a, 0x02, 0xd4,
+	0x5a, 0xf4, 0xd9, 0xd4, 0xf6, 0xe9, 0x67, 0x21, 0x78, 0xef, 0xd3, 0xc8, 0xc8, 0x5a, 0x5f, 0x35,
+	0xd8, 0x89, 0x59, 0xe8, 0x1c, 0xf6, 0xba, 0xc3, 0x81, 0xcc, 0x31, 0xa1, 0xb1, 0x89, 0x4a, 0x78,
+	0x15, 0x44, 0x0d, 0xc8, 0x73, 0x76, 0x7c, 0x11, 0xff, 0x49, 0x6d, 0x92, 0xc3, 0xc9, 0x57, 0x5e,
+	0x3c, 0x3e, 0xb2, 0xce, 0xa1, 0x9c, 0x02, 0xb9, 0xa5, 0xbb, 0xc4, 0x1b, 0x11, 0x26, 0x6d, 0x7f,
+	0xed, 0x78, 0xdc, 0xf6, 0xd6, 0x17, 0x30, 0xd6, 0xcb, 0xfb, 0xc3, 0x82, 0x5a, 0x70, 0x9a, 0x56,
+	0xda, 0x2e, 0xd7, 0xa6, 0x44, 0x59, 0x21, 0xfa, 0x2d, 0x87, 0x5b, 0x73, 0xdd, 0xb6, 0xe8, 0x14,
+	0x76, 0xe5, 0xeb, 0xd9, 0x92, 0xe6, 0x2f, 0x74, 0x32, 0x58, 0x21, 0xe8, 0x0a, 0xf2, 0x37, 0x2c,
+	0xf0, 0x62, 0x53, 0x9e, 0x6d, 0x31, 0x65, 0xbd, 0xd7, 0x9f, 0x47, 0xfd, 0x49, 0x27, 0x83, 0x05,
+	0xfb, 0xe4, 0x0e, 0x8a, 0x12, 0x41, 0x3a, 0x68, 0xbd, 0xd8, 0xea, 0x5a, 0x0f, 0x5d, 0xc2, 0xae,
+	0x10, 0x50, 0xf5, 0xbc, 0x6e, 0xb5, 0xb9, 0x22, 0xaa, 0x7b, 0xf5, 0x1c, 0x8c, 0xf8, 0xc5, 0xb8,
+	0x76, 0x1c, 0x46, 0xc2, 0x90, 0x84, 0xdc, 0x3e, 0x6a, 0x22, 0x7e, 0x1b, 0x25, 0xbc, 0x04, 0xac,
+	0x07, 0x28, 0x75, 0x87, 0x03, 0xf9, 0x9a, 0x20, 0x04, 0xf9, 0x9e, 0xed, 0x91, 0xb8, 0xb9, 0x62,
+	0xcc, 0xe5, 0x38, 0x08, 0xa2, 0x36, 0x61, 0x91, 0x2c, 0x48, 0xc7, 0x4b, 0x80, 0xdf, 0x30, 0x71,
+	0x6c, 0x32, 0x1c, 0xdf, 0xb0, 0x25, 0xd2, 0x7a, 0xf6, 0xe1, 0x62, 0x4a, 0xa3, 0xc7, 0xf9, 0x88,
+	0xef, 0xa1, 0xf1, 0xb8, 0x98, 0x11, 0xe6, 0x12, 0x67, 0x4a, 0x58, 0x63, 0x22, 0x1e, 0xb3, 0x86,
+	0xf8, 0xdf, 0x85, 0xf1, 0xdf, 0x6f, 0x54, 0x14, 0xd3, 0xcb, 0x5f, 0x03, 0x00, 0x40, 0x87, 0x22,
+	0xa9, 0x39, 0x07, 0x00, 0x00,
 }
@@ -11,9 +11,15 @@ It is generated from these files:
 It has these top-level messages:
 	BroadcastResponse
 	SeekInfo
+	SeekStop
 	Acknowledgement
 	DeliverUpdate
 	DeliverResponse
+	ConsensusType
+	BatchSize
+	BatchTimeout
+	KafkaBrokers
+	BlockDataHashingStructure
 */
 package orderer
 
@@ -66,6 +72,49 @@ func (x SeekInfo_StartType) String() string {
 }
 func (SeekInfo_StartType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1, 0} }
 
+// Behavior specifies what happens when the next block to deliver has not been created yet
+type SeekInfo_SeekBehavior int32
+
+const (
+	SeekInfo_BLOCK_UNTIL_READY SeekInfo_SeekBehavior = 0
+	SeekInfo_FAIL_IF_NOT_READY SeekInfo_SeekBehavior = 1
+)
+
+var SeekInfo_SeekBehavior_name = map[int32]string{
+	0: "BLOCK_UNTIL_READY",
+	1: "FAIL_IF_NOT_READY",
+}
+var SeekInfo_SeekBehavior_value = map[string]int32{
+	"BLOCK_UNTIL_READY": 0,
+	"FAIL_IF_NOT_READY": 1,
+}
+
+func (x SeekInfo_SeekBehavior) String() string {
+	return proto.EnumName(SeekInfo_SeekBehavior_name, int32(x))
+}
+func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1, 1} }
+
+type SeekStop_StopType int32
+
+const (
+	SeekStop_NEWEST    SeekStop_StopType = 0
+	SeekStop_SPECIFIED SeekStop_StopType = 1
+)
+
+var SeekStop_StopType_name = map[int32]string{
+	0: "NEWEST",
+	1: "SPECIFIED",
+}
+var SeekStop_StopType_value = map[string]int32{
+	"NEWEST":    0,
+	"SPECIFIED": 1,
+}
+
+func (x SeekStop_StopType) String() string {
+	return proto.EnumName(SeekStop_StopType_name, int32(x))
+}
+func (SeekStop_StopType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 0} }
+
 type BroadcastResponse struct {
 	Status common.Status `protobuf:"varint,1,opt,name=Status,enum=common.Status" json:"Status,omitempty"`
 }
@@ -76,10 +125,12 @@ func (*BroadcastResponse) ProtoMessage()               {}
 func (*BroadcastResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
 type SeekInfo struct {
-	Start           SeekInfo_StartType `protobuf:"varint,1,opt,name=Start,enum=orderer.SeekInfo_StartType" json:"Start,omitempty"`
-	SpecifiedNumber uint64             `protobuf:"varint,2,opt,name=SpecifiedNumber" json:"SpecifiedNumber,omitempty"`
-	WindowSize      uint64             `protobuf:"varint,3,opt,name=WindowSize" json:"WindowSize,omitempty"`
-	ChainID         []byte             `protobuf:"bytes,4,opt,name=ChainID,proto3" json:"ChainID,omitempty"`
+	Start           SeekInfo_StartType    `protobuf:"varint,1,opt,name=Start,enum=orderer.SeekInfo_StartType" json:"Start,omitempty"`
+	SpecifiedNumber uint64                `protobuf:"varint,2,opt,name=SpecifiedNumber" json:"SpecifiedNumber,omitempty"`
+	WindowSize      uint64                `protobuf:"varint,3,opt,name=WindowSize" json:"WindowSize,omitempty"`
+	ChainID         []byte                `protobuf:"bytes,4,opt,name=ChainID,proto3" json:"ChainID,omitempty"`
+	Stop            *SeekStop             `protobuf:"bytes,5,opt,name=Stop" json:"Stop,omitempty"`
+	Behavior        SeekInfo_SeekBehavior `protobuf:"varint,6,opt,name=Behavior,enum=orderer.SeekInfo_SeekBehavior" json:"Behavior,omitempty"`
 }
 
 func (m *SeekInfo) Reset()                    { *m = SeekInfo{} }
@@ -87,6 +138,25 @@ func (m *SeekInfo) String() string            { return proto.CompactTextString(m
 func (*SeekInfo) ProtoMessage()               {}
 func (*SeekInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+func (m *SeekInfo) GetStop() *SeekStop {
+	if m != nil {
+		return m.Stop
+	}
+	return nil
+}
+
+// SeekStop is the last block to deliver. The stop location is inclusive, once the block is sent a SUCCESS status
+// ends the stream of blocks
+type SeekStop struct {
+	Type            SeekStop_StopType `protobuf:"varint,1,opt,name=Type,enum=orderer.SeekStop_StopType" json:"Type,omitempty"`
+	SpecifiedNumber uint64            `protobuf:"varint,2,opt,name=SpecifiedNumber" json:"SpecifiedNumber,omitempty"`
+}
+
+func (m *SeekStop) Reset()                    { *m = SeekStop{} }
+func (m *SeekStop) String() string            { return proto.CompactTextString(m) }
+func (*SeekStop) ProtoMessage()               {}
+func (*SeekStop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
 type Acknowledgement struct {
 	Number uint64 `protobuf:"varint,1,opt,name=Number" json:"Number,omitempty"`
 }
@@ -94,20 +164,21 @@ type Acknowledgement struct {
 func (m *Acknowledgement) Reset()                    { *m = Acknowledgement{} }
 func (m *Acknowledgement) String() string            { return proto.CompactTextString(m) }
 func (*Acknowledgement) ProtoMessage()               {}
-func (*Acknowledgement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*Acknowledgement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
 // The update message either causes a seek to a new stream start with a new window, or acknowledges a received block and advances the base of the window
 type DeliverUpdate struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverUpdate_Acknowledgement
 	//	*DeliverUpdate_Seek
+	//	*DeliverUpdate_SignedSeek
 	Type isDeliverUpdate_Type `protobuf_oneof:"Type"`
 }
 
 func (m *DeliverUpdate) Reset()                    { *m = DeliverUpdate{} }
 func (m *DeliverUpdate) String() string            { return proto.CompactTextString(m) }
 func (*DeliverUpdate) ProtoMessage()               {}
-func (*DeliverUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*DeliverUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 type isDeliverUpdate_Type interface {
 	isDeliverUpdate_Type()
@@ -119,9 +190,13 @@ type DeliverUpdate_Acknowledgement struct {
 type DeliverUpdate_Seek struct {
 	Seek *SeekInfo `protobuf:"bytes,2,opt,name=Seek,oneof"`
 }
+type DeliverUpdate_SignedSeek struct {
+	SignedSeek *common.Envelope `protobuf:"bytes,3,opt,name=SignedSeek,oneof"`
+}
 
 func (*DeliverUpdate_Acknowledgement) isDeliverUpdate_Type() {}
 func (*DeliverUpdate_Seek) isDeliverUpdate_Type()            {}
+func (*DeliverUpdate_SignedSeek) isDeliverUpdate_Type()      {}
 
 func (m *DeliverUpdate) GetType() isDeliverUpdate_Type {
 	if m != nil {
@@ -144,11 +219,19 @@ func (m *DeliverUpdate) GetSeek() *SeekInfo {
 	return nil
 }
 
+func (m *DeliverUpdate) GetSignedSeek() *common.Envelope {
+	if x, ok := m.GetType().(*DeliverUpdate_SignedSeek); ok {
+		return x.SignedSeek
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverUpdate) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverUpdate_OneofMarshaler, _DeliverUpdate_OneofUnmarshaler, _DeliverUpdate_OneofSizer, []interface{}{
 		(*DeliverUpdate_Acknowledgement)(nil),
 		(*DeliverUpdate_Seek)(nil),
+		(*DeliverUpdate_SignedSeek)(nil),
 	}
 }
 
@@ -166,6 +249,11 @@ func _DeliverUpdate_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Seek); err != nil {
 			return err
 		}
+	case *DeliverUpdate_SignedSeek:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SignedSeek); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DeliverUpdate.Type has unexpected type %T", x)
@@ -192,6 +280,14 @@ func _DeliverUpdate_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.
 		err := b.DecodeMessage(msg)
 		m.Type = &DeliverUpdate_Seek{msg}
 		return true, err
+	case 3: // Type.SignedSeek
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(common.Envelope)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverUpdate_SignedSeek{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -211,6 +307,11 @@ func _DeliverUpdate_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(2<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DeliverUpdate_SignedSeek:
+		s := proto.Size(x.SignedSeek)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -228,7 +329,7 @@ type DeliverResponse struct {
 func (m *DeliverResponse) Reset()                    { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()               {}
-func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*DeliverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 type isDeliverResponse_Type interface {
 	isDeliverResponse_Type()
@@ -334,13 +435,72 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// ConsensusType is the orderer implementation of the chain, "solo" or "kafka"
+type ConsensusType struct {
+	Type string `protobuf:"bytes,1,opt,name=Type" json:"Type,omitempty"`
+}
+
+func (m *ConsensusType) Reset()                    { *m = ConsensusType{} }
+func (m *ConsensusType) String() string            { return proto.CompactTextString(m) }
+func (*ConsensusType) ProtoMessage()               {}
+func (*ConsensusType) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+// BatchSize is the maximum number of messages in a block
+type BatchSize struct {
+	Messages uint32 `protobuf:"varint,1,opt,name=Messages" json:"Messages,omitempty"`
+}
+
+func (m *BatchSize) Reset()                    { *m = BatchSize{} }
+func (m *BatchSize) String() string            { return proto.CompactTextString(m) }
+func (*BatchSize) ProtoMessage()               {}
+func (*BatchSize) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+// BatchTimeout is the time to wait before cutting a block which is not full, as parsed by time.ParseDuration
+type BatchTimeout struct {
+	Timeout string `protobuf:"bytes,1,opt,name=Timeout" json:"Timeout,omitempty"`
+}
+
+func (m *BatchTimeout) Reset()                    { *m = BatchTimeout{} }
+func (m *BatchTimeout) String() string            { return proto.CompactTextString(m) }
+func (*BatchTimeout) ProtoMessage()               {}
+func (*BatchTimeout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+// KafkaBrokers are the Kafka brokers the orderers of the chain connect to, in IP:port notation
+type KafkaBrokers struct {
+	Brokers []string `protobuf:"bytes,1,rep,name=Brokers" json:"Brokers,omitempty"`
+}
+
+func (m *KafkaBrokers) Reset()                    { *m = KafkaBrokers{} }
+func (m *KafkaBrokers) String() string            { return proto.CompactTextString(m) }
+func (*KafkaBrokers) ProtoMessage()               {}
+func (*KafkaBrokers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+// BlockDataHashingStructure is the width of the MerkleTree hashing the data of the blocks of the chain,
+// it is set at the creation of the chain and cannot be changed afterwards
+type BlockDataHashingStructure struct {
+	Width uint32 `protobuf:"varint,1,opt,name=Width" json:"Width,omitempty"`
+}
+
+func (m *BlockDataHashingStructure) Reset()                    { *m = BlockDataHashingStructure{} }
+func (m *BlockDataHashingStructure) String() string            { return proto.CompactTextString(m) }
+func (*BlockDataHashingStructure) ProtoMessage()               {}
+func (*BlockDataHashingStructure) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
 func init() {
 	proto.RegisterType((*BroadcastResponse)(nil), "orderer.BroadcastResponse")
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
+	proto.RegisterType((*SeekStop)(nil), "orderer.SeekStop")
 	proto.RegisterType((*Acknowledgement)(nil), "orderer.Acknowledgement")
 	proto.RegisterType((*DeliverUpdate)(nil), "orderer.DeliverUpdate")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
+	proto.RegisterType((*ConsensusType)(nil), "orderer.ConsensusType")
+	proto.RegisterType((*BatchSize)(nil), "orderer.BatchSize")
+	proto.RegisterType((*BatchTimeout)(nil), "orderer.BatchTimeout")
+	proto.RegisterType((*KafkaBrokers)(nil), "orderer.KafkaBrokers")
+	proto.RegisterType((*BlockDataHashingStructure)(nil), "orderer.BlockDataHashingStructure")
 	proto.RegisterEnum("orderer.SeekInfo_StartType", SeekInfo_StartType_name, SeekInfo_StartType_value)
+	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
+	proto.RegisterEnum("orderer.SeekStop_StopType", SeekStop_StopType_name, SeekStop_StopType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -523,34 +683,50 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 463 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x52, 0xdd, 0x6e, 0xd3, 0x4c,
-	0x10, 0xf5, 0xf6, 0x4b, 0x9c, 0x2f, 0x53, 0xd2, 0xa4, 0x8b, 0x54, 0x59, 0x41, 0x42, 0x91, 0x25,
-	0xc0, 0x48, 0xc8, 0x06, 0x73, 0xc9, 0x05, 0x4a, 0x6a, 0xa3, 0x44, 0x42, 0x05, 0xd9, 0x45, 0x95,
-	0xb8, 0xf3, 0xcf, 0xa4, 0xb1, 0x1a, 0x7b, 0xad, 0xf5, 0xa6, 0x55, 0x79, 0x00, 0xde, 0x80, 0x47,
-	0xe3, 0x7d, 0x90, 0xd7, 0x6b, 0xd3, 0x26, 0x5c, 0x79, 0xe6, 0xcc, 0x99, 0x39, 0xe3, 0xb3, 0x03,
-	0x13, 0xc6, 0x53, 0xe4, 0xc8, 0x9d, 0x28, 0xb6, 0x4b, 0xce, 0x04, 0xa3, 0x03, 0x85, 0x4c, 0x9f,
-	0x26, 0x2c, 0xcf, 0x59, 0xe1, 0x34, 0x9f, 0xa6, 0x6a, 0x7e, 0x80, 0xd3, 0x05, 0x67, 0x51, 0x9a,
-	0x44, 0x95, 0x08, 0xb0, 0x2a, 0x59, 0x51, 0x21, 0x7d, 0x09, 0x7a, 0x28, 0x22, 0xb1, 0xab, 0x0c,
-	0x32, 0x23, 0xd6, 0x89, 0x7b, 0x62, 0xab, 0x9e, 0x06, 0x0d, 0x54, 0xd5, 0xfc, 0x4d, 0xe0, 0xff,
-	0x10, 0xf1, 0x66, 0x55, 0xac, 0x19, 0x7d, 0x07, 0xfd, 0x50, 0x44, 0x5c, 0xa8, 0x9e, 0x67, 0xb6,
-	0xd2, 0xb5, 0x5b, 0x86, 0x2d, 0xcb, 0x97, 0xf7, 0x25, 0x06, 0x0d, 0x93, 0x5a, 0x30, 0x0e, 0x4b,
-	0x4c, 0xb2, 0x75, 0x86, 0xe9, 0xc5, 0x2e, 0x8f, 0x91, 0x1b, 0x47, 0x33, 0x62, 0xf5, 0x82, 0x7d,
-	0x98, 0x3e, 0x07, 0xb8, 0xca, 0x8a, 0x94, 0xdd, 0x85, 0xd9, 0x0f, 0x34, 0xfe, 0x93, 0xa4, 0x07,
-	0x08, 0x35, 0x60, 0x70, 0xbe, 0x89, 0xb2, 0x62, 0xe5, 0x19, 0xbd, 0x19, 0xb1, 0x9e, 0x04, 0x6d,
-	0x6a, 0xba, 0x30, 0xec, 0x74, 0x29, 0x80, 0x7e, 0xe1, 0x5f, 0xf9, 0xe1, 0xe5, 0x44, 0xab, 0xe3,
-	0x2f, 0x9f, 0xbd, 0x3a, 0x26, 0x74, 0x04, 0xc3, 0xf0, 0xab, 0x7f, 0xbe, 0xfa, 0xb4, 0xf2, 0xbd,
-	0xc9, 0x91, 0xf9, 0x1a, 0xc6, 0xf3, 0xe4, 0xa6, 0x60, 0x77, 0x5b, 0x4c, 0xaf, 0x31, 0xc7, 0x42,
-	0xd0, 0x33, 0xd0, 0xd5, 0x86, 0x44, 0x8a, 0xab, 0xcc, 0xfc, 0x49, 0x60, 0xe4, 0xe1, 0x36, 0xbb,
-	0x45, 0xfe, 0xad, 0x4c, 0x23, 0x81, 0xd4, 0x3b, 0x68, 0x96, 0x2d, 0xc7, 0xae, 0xd1, 0x39, 0xb2,
-	0x57, 0x5f, 0x6a, 0xc1, 0x81, 0xde, 0x2b, 0xe8, 0xd5, 0xbe, 0x49, 0x3f, 0x8e, 0xdd, 0xd3, 0x03,
-	0x33, 0x97, 0x5a, 0x20, 0x09, 0x0b, 0x1d, 0x7a, 0xf5, 0xaf, 0x99, 0x1b, 0x18, 0xab, 0x3d, 0x1e,
-	0x3c, 0x63, 0xdf, 0xe7, 0x9c, 0xf1, 0x7f, 0xbf, 0xe2, 0x52, 0x0b, 0x9a, 0x32, 0x7d, 0x01, 0xfd,
-	0xc5, 0x96, 0x25, 0xad, 0xd8, 0xa8, 0xe5, 0x49, 0xb0, 0xa6, 0xc9, 0xa0, 0x55, 0x72, 0x7f, 0x11,
-	0x18, 0xcf, 0x05, 0xcb, 0xb3, 0xa4, 0xbb, 0x1c, 0xfa, 0x11, 0x86, 0x7f, 0x93, 0x49, 0x3b, 0xc0,
-	0x2f, 0x6e, 0x71, 0xcb, 0x4a, 0x9c, 0x4e, 0xbb, 0xfd, 0x0f, 0x8e, 0xcd, 0xd4, 0x2c, 0xf2, 0x96,
-	0xd0, 0x39, 0x0c, 0xd4, 0xfa, 0xf4, 0xac, 0x23, 0x3f, 0x32, 0x76, 0x6a, 0xec, 0xe3, 0x8f, 0x47,
-	0x2c, 0xec, 0xef, 0x6f, 0xae, 0x33, 0xb1, 0xd9, 0xc5, 0xb5, 0xbc, 0xb3, 0xb9, 0x2f, 0x91, 0x4b,
-	0x3f, 0xb9, 0xb3, 0x8e, 0x62, 0x9e, 0x25, 0x8e, 0xbc, 0xf8, 0xca, 0x51, 0x53, 0x62, 0x5d, 0xe6,
-	0xef, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x22, 0xc6, 0xf5, 0x3a, 0x33, 0x03, 0x00, 0x00,
+	// 706 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4f, 0x6f, 0xda, 0x48,
+	0x14, 0xc7, 0x04, 0x08, 0xbc, 0x84, 0x40, 0x66, 0x77, 0x23, 0x2f, 0x2b, 0x45, 0xc8, 0xab, 0x6c,
+	0x58, 0x69, 0x65, 0x36, 0xec, 0x6d, 0x5b, 0xa9, 0xc2, 0x40, 0x04, 0x0a, 0x25, 0x95, 0x4d, 0x14,
+	0xb5, 0x17, 0x34, 0xd8, 0x03, 0xb6, 0x00, 0x8f, 0x35, 0x1e, 0x12, 0xa5, 0x5f, 0xa1, 0xe7, 0x7e,
+	0x99, 0x7e, 0xb0, 0x9e, 0xab, 0x19, 0x8f, 0x09, 0x84, 0x1c, 0x7a, 0xb1, 0xdf, 0x9f, 0xdf, 0xfb,
+	0xff, 0xde, 0x40, 0x95, 0x32, 0x8f, 0x30, 0xc2, 0x9a, 0x78, 0x6a, 0x46, 0x8c, 0x72, 0x8a, 0x0e,
+	0x95, 0xa4, 0xf6, 0x8b, 0x4b, 0x57, 0x2b, 0x1a, 0x36, 0x93, 0x5f, 0xa2, 0x35, 0xde, 0xc0, 0xa9,
+	0xc5, 0x28, 0xf6, 0x5c, 0x1c, 0x73, 0x9b, 0xc4, 0x11, 0x0d, 0x63, 0x82, 0xfe, 0x82, 0x82, 0xc3,
+	0x31, 0x5f, 0xc7, 0xba, 0x56, 0xd7, 0x1a, 0x27, 0xad, 0x13, 0x53, 0xd9, 0x24, 0x52, 0x5b, 0x69,
+	0x8d, 0xef, 0x59, 0x28, 0x3a, 0x84, 0x2c, 0x06, 0xe1, 0x8c, 0xa2, 0x2b, 0xc8, 0x3b, 0x1c, 0x33,
+	0xae, 0x6c, 0xfe, 0x30, 0x55, 0x5c, 0x33, 0x45, 0x98, 0x52, 0x3d, 0x7e, 0x8a, 0x88, 0x9d, 0x20,
+	0x51, 0x03, 0x2a, 0x4e, 0x44, 0xdc, 0x60, 0x16, 0x10, 0x6f, 0xb4, 0x5e, 0x4d, 0x09, 0xd3, 0xb3,
+	0x75, 0xad, 0x91, 0xb3, 0x5f, 0x8a, 0xd1, 0x39, 0xc0, 0x7d, 0x10, 0x7a, 0xf4, 0xd1, 0x09, 0x3e,
+	0x13, 0xfd, 0x40, 0x82, 0xb6, 0x24, 0x48, 0x87, 0xc3, 0x8e, 0x8f, 0x83, 0x70, 0xd0, 0xd5, 0x73,
+	0x75, 0xad, 0x71, 0x6c, 0xa7, 0x2c, 0xba, 0x80, 0x9c, 0xc3, 0x69, 0xa4, 0xe7, 0xeb, 0x5a, 0xe3,
+	0xa8, 0x75, 0xba, 0x93, 0x95, 0x50, 0xd8, 0x52, 0x8d, 0xfe, 0x87, 0xa2, 0x45, 0x7c, 0xfc, 0x10,
+	0x50, 0xa6, 0x17, 0x64, 0x01, 0xe7, 0xaf, 0x14, 0x40, 0xc8, 0x22, 0x45, 0xd9, 0x1b, 0xbc, 0xd1,
+	0x82, 0xd2, 0xa6, 0x34, 0x04, 0x50, 0x18, 0xf5, 0xee, 0x7b, 0xce, 0xb8, 0x9a, 0x11, 0xf4, 0xed,
+	0xb0, 0x2b, 0x68, 0x0d, 0x95, 0xa1, 0xe4, 0x7c, 0xe8, 0x75, 0x06, 0xd7, 0x83, 0x5e, 0xb7, 0x9a,
+	0x35, 0xde, 0xc2, 0xf1, 0xb6, 0x37, 0xf4, 0x1b, 0x9c, 0x5a, 0xc3, 0xdb, 0xce, 0xcd, 0xe4, 0x6e,
+	0x34, 0x1e, 0x0c, 0x27, 0x76, 0xaf, 0xdd, 0xfd, 0x58, 0xcd, 0x08, 0xf1, 0x75, 0x7b, 0x30, 0x9c,
+	0x0c, 0xae, 0x27, 0xa3, 0xdb, 0xb1, 0x12, 0x6b, 0xc6, 0x17, 0x2d, 0x69, 0xbc, 0x4c, 0xdd, 0x84,
+	0x9c, 0x88, 0xac, 0xfa, 0x5e, 0xdb, 0xab, 0xd0, 0x14, 0x1f, 0xd9, 0x76, 0x89, 0xfb, 0xf9, 0xae,
+	0x1b, 0x17, 0x50, 0x4c, 0x6d, 0x77, 0xea, 0xda, 0xa9, 0x45, 0x33, 0xfe, 0x86, 0x4a, 0xdb, 0x5d,
+	0x84, 0xf4, 0x71, 0x49, 0xbc, 0x39, 0x59, 0x91, 0x90, 0xa3, 0x33, 0x28, 0x28, 0xd7, 0x9a, 0x74,
+	0xad, 0x38, 0xe3, 0x9b, 0x06, 0xe5, 0x2e, 0x59, 0x06, 0x0f, 0x84, 0xdd, 0x45, 0x1e, 0xe6, 0x04,
+	0x75, 0xf7, 0x8c, 0xa5, 0xc9, 0x51, 0x4b, 0xdf, 0x14, 0xf2, 0x42, 0xdf, 0xcf, 0xd8, 0x7b, 0xf1,
+	0x2e, 0x21, 0x27, 0xca, 0xd5, 0xb3, 0xaf, 0x4c, 0x59, 0x8c, 0xae, 0x9f, 0xb1, 0x25, 0x00, 0xb5,
+	0x00, 0x9c, 0x60, 0x1e, 0x12, 0x4f, 0xc2, 0x0f, 0x24, 0xbc, 0x9a, 0xae, 0x77, 0x2f, 0x7c, 0x20,
+	0x4b, 0x1a, 0x91, 0x7e, 0xc6, 0xde, 0x42, 0x59, 0x85, 0xa4, 0xc1, 0x86, 0x0f, 0x15, 0x95, 0xfb,
+	0xd6, 0xa5, 0xe4, 0x7b, 0x8c, 0x51, 0xf6, 0xfa, 0xa1, 0xf4, 0x33, 0x76, 0xa2, 0x46, 0x17, 0x90,
+	0xb7, 0x96, 0xd4, 0x4d, 0x13, 0x2c, 0xa7, 0x38, 0x29, 0x14, 0x30, 0x49, 0x6c, 0x22, 0xfd, 0x09,
+	0xe5, 0x8e, 0xf0, 0x1f, 0xc6, 0xeb, 0x58, 0x76, 0x1f, 0x6d, 0xcd, 0xb8, 0x94, 0xcc, 0xd1, 0xb8,
+	0x84, 0x92, 0x85, 0xb9, 0xeb, 0xcb, 0x03, 0xa8, 0x41, 0xf1, 0x3d, 0x89, 0x63, 0x3c, 0x27, 0xc9,
+	0xd1, 0x96, 0xed, 0x0d, 0x6f, 0x34, 0xe0, 0x58, 0x02, 0xc7, 0xc1, 0x8a, 0xd0, 0x35, 0x17, 0xc7,
+	0xa2, 0x48, 0xe5, 0x2f, 0x65, 0x05, 0xf2, 0x06, 0xcf, 0x16, 0xd8, 0x62, 0x74, 0x41, 0x58, 0x2c,
+	0x90, 0x8a, 0xd4, 0xb5, 0xfa, 0x81, 0x40, 0x2a, 0xd6, 0xb8, 0x82, 0xdf, 0x65, 0xca, 0x5d, 0xcc,
+	0x71, 0x1f, 0xc7, 0x7e, 0x10, 0xce, 0x1d, 0xce, 0xd6, 0x2e, 0x5f, 0x33, 0x82, 0x7e, 0x85, 0xfc,
+	0x7d, 0xe0, 0x71, 0x5f, 0x65, 0x92, 0x30, 0xad, 0xaf, 0x1a, 0x54, 0xda, 0x9c, 0xae, 0x02, 0x77,
+	0xf3, 0xe2, 0xa0, 0x77, 0x50, 0x7a, 0x66, 0xf6, 0xe6, 0x50, 0x7b, 0x5e, 0xe6, 0xbd, 0x47, 0xca,
+	0xc8, 0x34, 0xb4, 0x7f, 0x35, 0xd4, 0x86, 0x43, 0x35, 0x13, 0x74, 0xb6, 0x01, 0xef, 0x6c, 0x58,
+	0x4d, 0x7f, 0x29, 0xdf, 0x75, 0x61, 0x99, 0x9f, 0xfe, 0x99, 0x07, 0xdc, 0x5f, 0x4f, 0x45, 0xf8,
+	0xa6, 0xff, 0x14, 0x11, 0x26, 0x17, 0x8b, 0x35, 0x67, 0x78, 0xca, 0x02, 0xb7, 0x29, 0x5f, 0xca,
+	0xb8, 0xa9, 0xbc, 0x4c, 0x0b, 0x92, 0xff, 0xef, 0xc7, 0x00, 0x2b, 0x03, 0xe0, 0xdd, 0x6b, 0x05,
+	0x00, 0x00,
 }
@@ -26,7 +26,9 @@ It has these top-level messages:
 	ChaincodeSpec
 	ChaincodeDeploymentSpec
 	ChaincodeInvocationSpec
+	ChaincodeACL
 	ChaincodeMessage
+	ChaincodeProposalContext
 	PutStateInfo
 	RangeQueryState
 	RangeQueryStateNext
@@ -45,6 +47,7 @@ It has these top-level messages:
 	Rejection
 	Unregister
 	Event
+	SignedEvent
 	PeerAddress
 	PeerID
 	PeerEndpoint
@@ -66,6 +69,9 @@ It has these top-level messages:
 	ServerStatus
 	LogLevelRequest
 	LogLevelResponse
+	ChaincodeRequest
+	ChaincodeStatus
+	ChaincodeStatusList
 */
 package peer
 
@@ -73,6 +79,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+import common1 "github.com/hyperledger/fabric/protos/common"
 
 import (
 	context "golang.org/x/net/context"
@@ -229,7 +236,7 @@ var ChaincodeMessage_Type_value = map[string]int32{
 func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
-func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 0} }
+func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{6, 0} }
 
 // ChaincodeID contains the path as specified by the deploy transaction
 // that created it as well as the hashCode that is generated by the
@@ -244,6 +251,8 @@ type ChaincodeID struct {
 	// all other requests will use the name (really a hashcode) generated by
 	// the deploy transaction
 	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// the version of the chaincode, set by the deploy transaction
+	Version string `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *ChaincodeID) Reset()                    { *m = ChaincodeID{} }
@@ -303,6 +312,10 @@ type ChaincodeDeploymentSpec struct {
 	EffectiveDate *google_protobuf.Timestamp                   `protobuf:"bytes,2,opt,name=effectiveDate" json:"effectiveDate,omitempty"`
 	CodePackage   []byte                                       `protobuf:"bytes,3,opt,name=codePackage,proto3" json:"codePackage,omitempty"`
 	ExecEnv       ChaincodeDeploymentSpec_ExecutionEnvironment `protobuf:"varint,4,opt,name=execEnv,enum=protos.ChaincodeDeploymentSpec_ExecutionEnvironment" json:"execEnv,omitempty"`
+	// The policy the endorsements of a transaction invoking the chaincode
+	// must satisfy for the transaction to be valid. If it isn't set, any
+	// valid endorsement is enough
+	EndorsementPolicy *common1.SignaturePolicyEnvelope `protobuf:"bytes,5,opt,name=endorsementPolicy" json:"endorsementPolicy,omitempty"`
 }
 
 func (m *ChaincodeDeploymentSpec) Reset()                    { *m = ChaincodeDeploymentSpec{} }
@@ -324,6 +337,13 @@ func (m *ChaincodeDeploymentSpec) GetEffectiveDate() *google_protobuf.Timestamp
 	return nil
 }
 
+func (m *ChaincodeDeploymentSpec) GetEndorsementPolicy() *common1.SignaturePolicyEnvelope {
+	if m != nil {
+		return m.EndorsementPolicy
+	}
+	return nil
+}
+
 // Carries the chaincode function and its arguments.
 type ChaincodeInvocationSpec struct {
 	ChaincodeSpec *ChaincodeSpec `protobuf:"bytes,1,opt,name=chaincodeSpec" json:"chaincodeSpec,omitempty"`
@@ -349,11 +369,56 @@ func (m *ChaincodeInvocationSpec) GetChaincodeSpec() *ChaincodeSpec {
 	return nil
 }
 
+// ChaincodeACL is the access control list of a chaincode. It is stored by the
+// lifecycle system chaincode when the chaincode is deployed, and the endorser
+// evaluates the creator of a proposal against it before simulating the proposal.
+type ChaincodeACL struct {
+	// The policies the creator of a proposal invoking a function of the
+	// chaincode must satisfy, by function name
+	Functions map[string]*common1.SignaturePolicyEnvelope `protobuf:"bytes,1,rep,name=functions" json:"functions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The policy the creator of a proposal must satisfy when the invoked
+	// function has no policy of its own. If it isn't set, such functions
+	// can be invoked by anyone
+	Default *common1.SignaturePolicyEnvelope `protobuf:"bytes,2,opt,name=default" json:"default,omitempty"`
+	// The policy the creator of a proposal updating the ACL must satisfy. If
+	// it isn't set, the ACL cannot be updated
+	Admins *common1.SignaturePolicyEnvelope `protobuf:"bytes,3,opt,name=admins" json:"admins,omitempty"`
+}
+
+func (m *ChaincodeACL) Reset()                    { *m = ChaincodeACL{} }
+func (m *ChaincodeACL) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeACL) ProtoMessage()               {}
+func (*ChaincodeACL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *ChaincodeACL) GetFunctions() map[string]*common1.SignaturePolicyEnvelope {
+	if m != nil {
+		return m.Functions
+	}
+	return nil
+}
+
+func (m *ChaincodeACL) GetDefault() *common1.SignaturePolicyEnvelope {
+	if m != nil {
+		return m.Default
+	}
+	return nil
+}
+
+func (m *ChaincodeACL) GetAdmins() *common1.SignaturePolicyEnvelope {
+	if m != nil {
+		return m.Admins
+	}
+	return nil
+}
+
 type ChaincodeMessage struct {
 	Type      ChaincodeMessage_Type      `protobuf:"varint,1,opt,name=type,enum=protos.ChaincodeMessage_Type" json:"type,omitempty"`
 	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
 	Payload   []byte                     `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
 	Txid      string                     `protobuf:"bytes,4,opt,name=txid" json:"txid,omitempty"`
+	// proposal context passed to the chaincode with INIT and TRANSACTION
+	// messages. It is never part of the transaction
+	ProposalContext *ChaincodeProposalContext `protobuf:"bytes,5,opt,name=proposalContext" json:"proposalContext,omitempty"`
 	// event emmited by chaincode. Used only with Init or Invoke.
 	// This event is then stored (currently)
 	// with Block.NonHashData.TransactionResult
@@ -363,7 +428,7 @@ type ChaincodeMessage struct {
 func (m *ChaincodeMessage) Reset()                    { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()               {}
-func (*ChaincodeMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*ChaincodeMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *ChaincodeMessage) GetTimestamp() *google_protobuf.Timestamp {
 	if m != nil {
@@ -372,6 +437,13 @@ func (m *ChaincodeMessage) GetTimestamp() *google_protobuf.Timestamp {
 	return nil
 }
 
+func (m *ChaincodeMessage) GetProposalContext() *ChaincodeProposalContext {
+	if m != nil {
+		return m.ProposalContext
+	}
+	return nil
+}
+
 func (m *ChaincodeMessage) GetChaincodeEvent() *ChaincodeEvent {
 	if m != nil {
 		return m.ChaincodeEvent
@@ -379,6 +451,36 @@ func (m *ChaincodeMessage) GetChaincodeEvent() *ChaincodeEvent {
 	return nil
 }
 
+// ChaincodeProposalContext carries the parts of the proposal which are made
+// available to the chaincode during endorsement
+type ChaincodeProposalContext struct {
+	// transient is the TransientMap of the ChaincodeProposalPayload
+	Transient map[string][]byte `protobuf:"bytes,1,rep,name=transient" json:"transient,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// signedProposal is the proposal the chaincode is invoked for, as signed
+	// by its creator. The chaincode reads the creator identity and the
+	// timestamp of the proposal from its header
+	SignedProposal *SignedProposal `protobuf:"bytes,2,opt,name=signedProposal" json:"signedProposal,omitempty"`
+}
+
+func (m *ChaincodeProposalContext) Reset()                    { *m = ChaincodeProposalContext{} }
+func (m *ChaincodeProposalContext) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeProposalContext) ProtoMessage()               {}
+func (*ChaincodeProposalContext) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *ChaincodeProposalContext) GetTransient() map[string][]byte {
+	if m != nil {
+		return m.Transient
+	}
+	return nil
+}
+
+func (m *ChaincodeProposalContext) GetSignedProposal() *SignedProposal {
+	if m != nil {
+		return m.SignedProposal
+	}
+	return nil
+}
+
 type PutStateInfo struct {
 	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
 	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -387,7 +489,7 @@ type PutStateInfo struct {
 func (m *PutStateInfo) Reset()                    { *m = PutStateInfo{} }
 func (m *PutStateInfo) String() string            { return proto.CompactTextString(m) }
 func (*PutStateInfo) ProtoMessage()               {}
-func (*PutStateInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*PutStateInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 type RangeQueryState struct {
 	StartKey string `protobuf:"bytes,1,opt,name=startKey" json:"startKey,omitempty"`
@@ -397,7 +499,7 @@ type RangeQueryState struct {
 func (m *RangeQueryState) Reset()                    { *m = RangeQueryState{} }
 func (m *RangeQueryState) String() string            { return proto.CompactTextString(m) }
 func (*RangeQueryState) ProtoMessage()               {}
-func (*RangeQueryState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*RangeQueryState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 type RangeQueryStateNext struct {
 	ID string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
@@ -406,7 +508,7 @@ type RangeQueryStateNext struct {
 func (m *RangeQueryStateNext) Reset()                    { *m = RangeQueryStateNext{} }
 func (m *RangeQueryStateNext) String() string            { return proto.CompactTextString(m) }
 func (*RangeQueryStateNext) ProtoMessage()               {}
-func (*RangeQueryStateNext) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*RangeQueryStateNext) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 type RangeQueryStateClose struct {
 	ID string `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
@@ -415,7 +517,7 @@ type RangeQueryStateClose struct {
 func (m *RangeQueryStateClose) Reset()                    { *m = RangeQueryStateClose{} }
 func (m *RangeQueryStateClose) String() string            { return proto.CompactTextString(m) }
 func (*RangeQueryStateClose) ProtoMessage()               {}
-func (*RangeQueryStateClose) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*RangeQueryStateClose) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 type RangeQueryStateKeyValue struct {
 	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
@@ -425,7 +527,7 @@ type RangeQueryStateKeyValue struct {
 func (m *RangeQueryStateKeyValue) Reset()                    { *m = RangeQueryStateKeyValue{} }
 func (m *RangeQueryStateKeyValue) String() string            { return proto.CompactTextString(m) }
 func (*RangeQueryStateKeyValue) ProtoMessage()               {}
-func (*RangeQueryStateKeyValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*RangeQueryStateKeyValue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 type RangeQueryStateResponse struct {
 	KeysAndValues []*RangeQueryStateKeyValue `protobuf:"bytes,1,rep,name=keysAndValues" json:"keysAndValues,omitempty"`
@@ -436,7 +538,7 @@ type RangeQueryStateResponse struct {
 func (m *RangeQueryStateResponse) Reset()                    { *m = RangeQueryStateResponse{} }
 func (m *RangeQueryStateResponse) String() string            { return proto.CompactTextString(m) }
 func (*RangeQueryStateResponse) ProtoMessage()               {}
-func (*RangeQueryStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*RangeQueryStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *RangeQueryStateResponse) GetKeysAndValues() []*RangeQueryStateKeyValue {
 	if m != nil {
@@ -451,7 +553,9 @@ func init() {
 	proto.RegisterType((*ChaincodeSpec)(nil), "protos.ChaincodeSpec")
 	proto.RegisterType((*ChaincodeDeploymentSpec)(nil), "protos.ChaincodeDeploymentSpec")
 	proto.RegisterType((*ChaincodeInvocationSpec)(nil), "protos.ChaincodeInvocationSpec")
+	proto.RegisterType((*ChaincodeACL)(nil), "protos.ChaincodeACL")
 	proto.RegisterType((*ChaincodeMessage)(nil), "protos.ChaincodeMessage")
+	proto.RegisterType((*ChaincodeProposalContext)(nil), "protos.ChaincodeProposalContext")
 	proto.RegisterType((*PutStateInfo)(nil), "protos.PutStateInfo")
 	proto.RegisterType((*RangeQueryState)(nil), "protos.RangeQueryState")
 	proto.RegisterType((*RangeQueryStateNext)(nil), "protos.RangeQueryStateNext")
@@ -571,73 +675,88 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1073 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x56, 0x5f, 0x6f, 0xda, 0x56,
-	0x14, 0xaf, 0x81, 0x24, 0x70, 0xf8, 0x93, 0xdb, 0x5b, 0x9a, 0x32, 0xf6, 0xa7, 0x91, 0xd5, 0x4d,
-	0x6c, 0x0f, 0xa4, 0xcb, 0xda, 0x69, 0xd2, 0xa4, 0x6a, 0xae, 0x7d, 0xcb, 0xbc, 0x10, 0x43, 0x2f,
-	0x4e, 0xd4, 0xee, 0x25, 0x72, 0xcc, 0x81, 0x58, 0x05, 0xdb, 0xb2, 0x2f, 0x28, 0xbc, 0xed, 0x79,
-	0xaf, 0xfb, 0x08, 0xfb, 0x0e, 0x7b, 0xd8, 0xa7, 0x9b, 0xae, 0x8d, 0x29, 0x04, 0x22, 0x55, 0xda,
-	0x13, 0xf7, 0x9c, 0xf3, 0xfb, 0x9d, 0x7b, 0xee, 0xf9, 0x87, 0xa1, 0x1e, 0x22, 0x46, 0x27, 0xee,
-	0x8d, 0xe3, 0xf9, 0x6e, 0x30, 0xc4, 0x76, 0x18, 0x05, 0x22, 0xa0, 0xfb, 0xc9, 0x4f, 0xdc, 0xfc,
-	0x6c, 0xd3, 0x8a, 0x73, 0xf4, 0x45, 0x0a, 0x69, 0x3e, 0x1d, 0x07, 0xc1, 0x78, 0x82, 0x27, 0x89,
-	0x74, 0x3d, 0x1b, 0x9d, 0x08, 0x6f, 0x8a, 0xb1, 0x70, 0xa6, 0x61, 0x0a, 0x50, 0x5f, 0x42, 0x59,
-	0xcf, 0x88, 0xa6, 0x41, 0x29, 0x14, 0x42, 0x47, 0xdc, 0x34, 0x94, 0x63, 0xa5, 0x55, 0xe2, 0xc9,
-	0x59, 0xea, 0x7c, 0x67, 0x8a, 0x8d, 0x5c, 0xaa, 0x93, 0x67, 0xf5, 0x19, 0xd4, 0x3e, 0xd2, 0xfc,
-	0x70, 0x26, 0x24, 0xca, 0x89, 0xc6, 0x71, 0x43, 0x39, 0xce, 0xb7, 0x2a, 0x3c, 0x39, 0xab, 0xff,
-	0xe4, 0xa1, 0xba, 0x82, 0x0d, 0x42, 0x74, 0x69, 0x1b, 0x0a, 0x62, 0x11, 0x62, 0xe2, 0xbf, 0x76,
-	0xda, 0x4c, 0x83, 0x88, 0xdb, 0x1b, 0xa0, 0xb6, 0xbd, 0x08, 0x91, 0x27, 0x38, 0xfa, 0x12, 0xca,
-	0xee, 0xc7, 0xf0, 0x92, 0x10, 0xca, 0xa7, 0x8f, 0xb6, 0x68, 0xa6, 0xc1, 0xd7, 0x71, 0xf4, 0x39,
-	0x1c, 0xb8, 0x22, 0x88, 0xce, 0xe3, 0x71, 0x23, 0x9f, 0x50, 0x8e, 0xb6, 0x29, 0x32, 0x6a, 0x9e,
-	0xc1, 0x68, 0x03, 0x0e, 0x64, 0x6a, 0x82, 0x99, 0x68, 0x14, 0x8e, 0x95, 0xd6, 0x1e, 0xcf, 0x44,
-	0xfa, 0x0c, 0xaa, 0x31, 0xba, 0xb3, 0x08, 0xf5, 0xc0, 0x17, 0x78, 0x2b, 0x1a, 0x7b, 0x49, 0x1e,
-	0x36, 0x95, 0xb4, 0x0f, 0x75, 0x37, 0xf0, 0x47, 0xde, 0x10, 0x7d, 0xe1, 0x39, 0x13, 0x4f, 0x2c,
-	0xba, 0x38, 0xc7, 0x49, 0x63, 0x3f, 0x79, 0xe8, 0x17, 0xab, 0xeb, 0x77, 0x60, 0xf8, 0x4e, 0x26,
-	0x6d, 0x42, 0x71, 0x8a, 0xc2, 0x19, 0x3a, 0xc2, 0x69, 0x1c, 0x1c, 0x2b, 0xad, 0x0a, 0x5f, 0xc9,
-	0xf4, 0x2b, 0x00, 0x47, 0x88, 0xc8, 0xbb, 0x9e, 0x09, 0x8c, 0x1b, 0xc5, 0xe3, 0x7c, 0xab, 0xc4,
-	0xd7, 0x34, 0xea, 0x2b, 0x28, 0xc8, 0x24, 0xd2, 0x2a, 0x94, 0x2e, 0x2c, 0x83, 0xbd, 0x31, 0x2d,
-	0x66, 0x90, 0x07, 0x14, 0x60, 0xbf, 0xd3, 0xeb, 0x6a, 0x56, 0x87, 0x28, 0xb4, 0x08, 0x05, 0xab,
-	0x67, 0x30, 0x92, 0xa3, 0x07, 0x90, 0xd7, 0x35, 0x4e, 0xf2, 0x52, 0xf5, 0x9b, 0x76, 0xa9, 0x91,
-	0x82, 0xfa, 0x6f, 0x0e, 0x9e, 0xac, 0x32, 0x65, 0x60, 0x38, 0x09, 0x16, 0x53, 0xf4, 0x45, 0x52,
-	0xc2, 0x9f, 0xa1, 0xea, 0xae, 0x97, 0x2b, 0xa9, 0x65, 0xf9, 0xf4, 0xf1, 0xce, 0x5a, 0xf2, 0x4d,
-	0x2c, 0xfd, 0x05, 0xaa, 0x38, 0x1a, 0xa1, 0x2b, 0xbc, 0x39, 0x1a, 0x8e, 0xc0, 0x65, 0x45, 0x9b,
-	0xed, 0xb4, 0x4f, 0xdb, 0x59, 0x9f, 0xb6, 0xed, 0xac, 0x4f, 0xf9, 0x26, 0x81, 0x1e, 0x43, 0x59,
-	0x7a, 0xeb, 0x3b, 0xee, 0x07, 0x67, 0x8c, 0x49, 0x79, 0x2b, 0x7c, 0x5d, 0x45, 0x2d, 0x38, 0xc0,
-	0x5b, 0x74, 0x99, 0x3f, 0x4f, 0x4a, 0x59, 0x3b, 0x7d, 0xb1, 0x15, 0xda, 0xe6, 0x93, 0xda, 0xec,
-	0x16, 0xdd, 0x99, 0xf0, 0x02, 0x9f, 0xf9, 0x73, 0x2f, 0x0a, 0x7c, 0x69, 0xe0, 0x99, 0x13, 0xb5,
-	0x0d, 0xf5, 0x5d, 0x00, 0x99, 0x4d, 0xa3, 0xa7, 0x9f, 0x31, 0x9e, 0x66, 0x76, 0xf0, 0x7e, 0x60,
-	0xb3, 0x73, 0xa2, 0xa8, 0x7f, 0x28, 0x6b, 0xc9, 0x33, 0xfd, 0x79, 0xe0, 0x3a, 0x92, 0xfa, 0xff,
-	0x93, 0xd7, 0x82, 0x43, 0x6f, 0xd8, 0x41, 0x1f, 0xa3, 0xc4, 0xa1, 0x36, 0x19, 0x2f, 0x67, 0xf2,
-	0xae, 0x5a, 0xfd, 0xab, 0x00, 0x64, 0xe5, 0xea, 0x1c, 0xe3, 0x58, 0xe6, 0xe5, 0xfb, 0x8d, 0xd9,
-	0xfb, 0x72, 0xeb, 0xca, 0x25, 0x6e, 0x7d, 0xfc, 0x7e, 0x82, 0xd2, 0x6a, 0x61, 0x7c, 0x42, 0xa9,
-	0x3e, 0x82, 0xe5, 0x3c, 0x85, 0xce, 0x62, 0x12, 0x38, 0xc3, 0x65, 0x89, 0x32, 0x51, 0x2e, 0x0a,
-	0x71, 0xeb, 0x0d, 0x93, 0xda, 0x94, 0x78, 0x72, 0xa6, 0xaf, 0xa0, 0xb6, 0x7a, 0x2a, 0x93, 0xeb,
-	0x2b, 0x99, 0x9b, 0x5d, 0x63, 0x9b, 0x58, 0xf9, 0x1d, 0xb4, 0xfa, 0x77, 0x6e, 0x77, 0xc3, 0x57,
-	0xa0, 0xc8, 0x59, 0xc7, 0x1c, 0xd8, 0x8c, 0x13, 0x85, 0xd6, 0x00, 0x32, 0x89, 0x19, 0x24, 0x27,
-	0xfb, 0xdd, 0xb4, 0x4c, 0x9b, 0xe4, 0x69, 0x09, 0xf6, 0x38, 0xd3, 0x8c, 0xf7, 0xa4, 0x40, 0x0f,
-	0xa1, 0x6c, 0x73, 0xcd, 0x1a, 0x68, 0xba, 0x6d, 0xf6, 0x2c, 0xb2, 0x27, 0x5d, 0xea, 0xbd, 0xf3,
-	0x7e, 0x97, 0xd9, 0xcc, 0x20, 0xfb, 0x12, 0xca, 0x38, 0xef, 0x71, 0x72, 0x20, 0x2d, 0x1d, 0x66,
-	0x5f, 0x0d, 0x6c, 0xcd, 0x66, 0xa4, 0x28, 0xc5, 0xfe, 0x45, 0x26, 0x96, 0xa4, 0x68, 0xb0, 0xee,
-	0x52, 0x04, 0x5a, 0x07, 0x62, 0x5a, 0x97, 0xbd, 0x33, 0x76, 0xa5, 0xff, 0xaa, 0x99, 0x96, 0x2e,
-	0x67, 0xaf, 0x9c, 0x06, 0x38, 0xe8, 0xf7, 0xac, 0x01, 0x23, 0x55, 0xfa, 0x18, 0x1e, 0x72, 0xcd,
-	0xea, 0xb0, 0xab, 0xb7, 0x17, 0x8c, 0xbf, 0x5f, 0x52, 0x6b, 0xb4, 0x09, 0x47, 0x5b, 0xea, 0x2b,
-	0x8b, 0xbd, 0xb3, 0xc9, 0x21, 0xfd, 0x1c, 0x9e, 0x6c, 0xdb, 0xf4, 0x6e, 0x6f, 0xc0, 0x08, 0x91,
-	0x21, 0x9c, 0x31, 0xd6, 0xd7, 0xba, 0xe6, 0x25, 0x23, 0x0f, 0xd5, 0x1f, 0xa1, 0xd2, 0x9f, 0x89,
-	0x81, 0x70, 0x04, 0x9a, 0xfe, 0x28, 0xa0, 0x04, 0xf2, 0x1f, 0x70, 0xb1, 0xdc, 0xf5, 0xf2, 0x48,
-	0xeb, 0xb0, 0x37, 0x77, 0x26, 0xb3, 0x74, 0x2c, 0x2b, 0x3c, 0x15, 0x54, 0x06, 0x87, 0xdc, 0xf1,
-	0xc7, 0xf8, 0x76, 0x86, 0xd1, 0x22, 0xa1, 0xcb, 0xe5, 0x14, 0x0b, 0x27, 0x12, 0x67, 0x2b, 0xfe,
-	0x4a, 0xa6, 0x47, 0xb0, 0x8f, 0xfe, 0x50, 0x5a, 0xd2, 0xee, 0x5c, 0x4a, 0xea, 0xd7, 0xf0, 0xe8,
-	0x8e, 0x1b, 0x4b, 0x6e, 0xce, 0x1a, 0xe4, 0x4c, 0x63, 0xe9, 0x24, 0x67, 0x1a, 0xea, 0x37, 0x50,
-	0xbf, 0x03, 0xd3, 0x27, 0x41, 0x8c, 0x5b, 0x38, 0x0d, 0x9e, 0xdc, 0xc1, 0x9d, 0xe1, 0xe2, 0x52,
-	0x06, 0xfc, 0xc9, 0x0f, 0xfb, 0x53, 0xd9, 0xf2, 0xc1, 0x31, 0x0e, 0x03, 0x3f, 0x46, 0xca, 0xa0,
-	0xfa, 0x01, 0x17, 0xb1, 0xe6, 0x0f, 0x13, 0x9f, 0xe9, 0x1f, 0x5b, 0xf9, 0xf4, 0x69, 0xd6, 0x91,
-	0xf7, 0xdc, 0xcd, 0x37, 0x59, 0x72, 0x0e, 0x6e, 0x9c, 0xf8, 0x3c, 0x88, 0xd2, 0xab, 0x8b, 0x3c,
-	0x13, 0x97, 0xef, 0xc9, 0x67, 0xef, 0xf9, 0xee, 0x05, 0xd4, 0x77, 0xfd, 0x3b, 0xc8, 0xd5, 0xd2,
-	0xbf, 0x78, 0xdd, 0x35, 0x75, 0xf2, 0x80, 0x12, 0xa8, 0xe8, 0x3d, 0xeb, 0x8d, 0x69, 0x30, 0xcb,
-	0x36, 0xb5, 0x2e, 0x51, 0x4e, 0xdf, 0xad, 0x0d, 0xfa, 0x60, 0x16, 0x86, 0x41, 0x24, 0xa8, 0x01,
-	0x45, 0x8e, 0x63, 0x2f, 0x16, 0x18, 0xd1, 0xc6, 0x7d, 0x63, 0xde, 0xbc, 0xd7, 0xa2, 0x3e, 0x68,
-	0x29, 0xcf, 0x95, 0xd7, 0x3a, 0x1c, 0x05, 0xd1, 0xb8, 0x7d, 0xb3, 0x08, 0x31, 0x9a, 0xe0, 0x70,
-	0x8c, 0xd1, 0x92, 0xf0, 0xfb, 0xb7, 0x63, 0x4f, 0xdc, 0xcc, 0xae, 0xdb, 0x6e, 0x30, 0x3d, 0x59,
-	0x33, 0x9f, 0x8c, 0x9c, 0xeb, 0xc8, 0x73, 0xd3, 0x6f, 0x8d, 0xf8, 0x44, 0x7e, 0x94, 0x5c, 0xa7,
-	0x9f, 0x28, 0x3f, 0xfc, 0x17, 0x00, 0x00, 0xff, 0xff, 0x3c, 0xd3, 0x09, 0x7d, 0xc1, 0x08, 0x00,
+	// 1313 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x56, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0x0e, 0x25, 0xf9, 0xa0, 0x91, 0x2c, 0x33, 0x1b, 0xc7, 0xe1, 0xaf, 0xff, 0x10, 0x83, 0x7f,
+	0x5a, 0xb8, 0xbd, 0x90, 0x53, 0x35, 0x69, 0xd3, 0x03, 0x82, 0x32, 0xe4, 0xc6, 0x65, 0x2c, 0x53,
+	0xca, 0x4a, 0x36, 0x92, 0x02, 0x85, 0x41, 0x53, 0x2b, 0x9a, 0x88, 0xb4, 0x4b, 0x90, 0x4b, 0xc1,
+	0xba, 0xeb, 0x6d, 0xfb, 0x1a, 0xed, 0x33, 0xf4, 0x75, 0x7a, 0xd9, 0xd7, 0x28, 0x96, 0x07, 0x59,
+	0xb2, 0x6c, 0xd4, 0x40, 0xaf, 0xb8, 0x33, 0xf3, 0xcd, 0xec, 0xec, 0xcc, 0xce, 0xc7, 0x85, 0x9d,
+	0x90, 0xd2, 0xe8, 0xc0, 0xbb, 0x70, 0x03, 0xe6, 0xf1, 0x21, 0x6d, 0x85, 0x11, 0x17, 0x1c, 0xad,
+	0xa7, 0x9f, 0xb8, 0xf9, 0xaf, 0x65, 0x2b, 0x9d, 0x52, 0x26, 0x32, 0x48, 0xb3, 0x99, 0x9a, 0x46,
+	0xee, 0x79, 0x14, 0x78, 0x67, 0x61, 0xc4, 0x43, 0x1e, 0xbb, 0xe3, 0xdc, 0xf6, 0xd8, 0xe7, 0xdc,
+	0x1f, 0xd3, 0x83, 0x54, 0x3a, 0x4f, 0x46, 0x07, 0x22, 0x98, 0xd0, 0x58, 0xb8, 0x93, 0xb0, 0x70,
+	0xf6, 0xf8, 0x64, 0xc2, 0xd9, 0x81, 0xc7, 0xd9, 0x28, 0xf0, 0x93, 0xc8, 0x15, 0x01, 0x67, 0x99,
+	0x4d, 0xef, 0x42, 0xcd, 0x2c, 0x36, 0xb4, 0x2d, 0x84, 0xa0, 0x12, 0xba, 0xe2, 0x42, 0x53, 0xf6,
+	0x94, 0xfd, 0x2a, 0x49, 0xd7, 0x52, 0xc7, 0xdc, 0x09, 0xd5, 0x4a, 0x99, 0x4e, 0xae, 0x91, 0x06,
+	0x1b, 0x53, 0x1a, 0xc5, 0x01, 0x67, 0x5a, 0x39, 0x55, 0x17, 0xa2, 0xfe, 0x04, 0x1a, 0x57, 0x01,
+	0x59, 0x98, 0x08, 0xe9, 0xef, 0x46, 0x7e, 0xac, 0x29, 0x7b, 0xe5, 0xfd, 0x3a, 0x49, 0xd7, 0xfa,
+	0xef, 0x65, 0xd8, 0x9a, 0xc3, 0xfa, 0x21, 0xf5, 0x50, 0x0b, 0x2a, 0x62, 0x16, 0xd2, 0x74, 0xe7,
+	0x46, 0xbb, 0x99, 0xa5, 0x17, 0xb7, 0x96, 0x40, 0xad, 0xc1, 0x2c, 0xa4, 0x24, 0xc5, 0xa1, 0xe7,
+	0x50, 0xf3, 0xae, 0x12, 0x4f, 0x93, 0xab, 0xb5, 0x1f, 0xac, 0xb8, 0xd9, 0x16, 0x59, 0xc4, 0xa1,
+	0xa7, 0xb0, 0xe1, 0x09, 0x1e, 0x1d, 0xc7, 0x7e, 0x9a, 0x78, 0xad, 0xbd, 0xbb, 0xea, 0x22, 0xb3,
+	0x26, 0x05, 0x4c, 0x1e, 0x55, 0x16, 0x94, 0x27, 0x42, 0xab, 0xec, 0x29, 0xfb, 0x6b, 0xa4, 0x10,
+	0xd1, 0x13, 0xd8, 0x8a, 0xa9, 0x97, 0x44, 0xd4, 0xe4, 0x4c, 0xd0, 0x4b, 0xa1, 0xad, 0xa5, 0xa5,
+	0x58, 0x56, 0xa2, 0x1e, 0xec, 0xa4, 0x85, 0x1f, 0x52, 0x26, 0x02, 0x77, 0x1c, 0x88, 0x59, 0x87,
+	0x4e, 0xe9, 0x58, 0x5b, 0x4f, 0x0f, 0xfa, 0x9f, 0xf9, 0xf6, 0x37, 0x60, 0xc8, 0x8d, 0x9e, 0xa8,
+	0x09, 0x9b, 0x13, 0x2a, 0xdc, 0xa1, 0x2b, 0x5c, 0x6d, 0x63, 0x4f, 0xd9, 0xaf, 0x93, 0xb9, 0x8c,
+	0xfe, 0x07, 0xe0, 0x0a, 0x11, 0x05, 0xe7, 0x89, 0xa0, 0xb1, 0xb6, 0xb9, 0x57, 0xde, 0xaf, 0x92,
+	0x05, 0x8d, 0xfe, 0x12, 0x2a, 0xb2, 0x88, 0x68, 0x0b, 0xaa, 0x27, 0x8e, 0x85, 0x5f, 0xdb, 0x0e,
+	0xb6, 0xd4, 0x7b, 0x08, 0x60, 0xfd, 0xb0, 0xdb, 0x31, 0x9c, 0x43, 0x55, 0x41, 0x9b, 0x50, 0x71,
+	0xba, 0x16, 0x56, 0x4b, 0x68, 0x03, 0xca, 0xa6, 0x41, 0xd4, 0xb2, 0x54, 0xbd, 0x31, 0x4e, 0x0d,
+	0xb5, 0xa2, 0xff, 0x5c, 0x86, 0x47, 0xf3, 0x4a, 0x59, 0x34, 0x1c, 0xf3, 0xd9, 0x84, 0x32, 0x91,
+	0xb6, 0xf0, 0x1b, 0xd8, 0xf2, 0x16, 0xdb, 0x95, 0xf6, 0xb2, 0xd6, 0x7e, 0x78, 0x63, 0x2f, 0xc9,
+	0x32, 0x16, 0x7d, 0x07, 0x5b, 0x74, 0x34, 0xa2, 0x9e, 0x08, 0xa6, 0xd4, 0x72, 0x05, 0xcd, 0x3b,
+	0xda, 0x6c, 0x65, 0xb7, 0xbb, 0x55, 0xdc, 0xee, 0xd6, 0xa0, 0xb8, 0xdd, 0x64, 0xd9, 0x01, 0xed,
+	0x41, 0x4d, 0x46, 0xeb, 0xb9, 0xde, 0x07, 0xd7, 0xa7, 0x69, 0x7b, 0xeb, 0x64, 0x51, 0x85, 0x1c,
+	0xd8, 0xa0, 0x97, 0xd4, 0xc3, 0x6c, 0x9a, 0xb6, 0xb2, 0xd1, 0x7e, 0xb6, 0x92, 0xda, 0xf2, 0x91,
+	0x5a, 0xf8, 0x92, 0x7a, 0x89, 0x1c, 0x17, 0xcc, 0xa6, 0x41, 0xc4, 0x99, 0x34, 0x90, 0x22, 0x08,
+	0x3a, 0x86, 0xfb, 0x94, 0x0d, 0x79, 0x14, 0x53, 0xa9, 0xef, 0xf1, 0x71, 0xe0, 0xcd, 0xd2, 0x4b,
+	0x50, 0x6b, 0x3f, 0x6e, 0x65, 0x43, 0xd7, 0xea, 0x07, 0x3e, 0x73, 0x45, 0x12, 0xd1, 0xcc, 0x8c,
+	0xd9, 0x94, 0x8e, 0x79, 0x48, 0xc9, 0xaa, 0xa7, 0xde, 0x82, 0x9d, 0x9b, 0xf6, 0x93, 0xcd, 0xb1,
+	0xba, 0xe6, 0x11, 0x26, 0x59, 0xa3, 0xfa, 0xef, 0xfb, 0x03, 0x7c, 0xac, 0x2a, 0xfa, 0x4f, 0xca,
+	0x42, 0x2f, 0x6c, 0x36, 0xe5, 0x5e, 0x3a, 0xd9, 0xff, 0xbc, 0x17, 0xfb, 0xb0, 0x1d, 0x0c, 0x0f,
+	0x29, 0xa3, 0x19, 0x55, 0x18, 0x63, 0x3f, 0x1f, 0xfe, 0xeb, 0x6a, 0xfd, 0xb7, 0x12, 0xd4, 0xe7,
+	0xa1, 0x0c, 0xb3, 0x83, 0x0c, 0xa8, 0x8e, 0x12, 0xe6, 0x49, 0x7b, 0x36, 0xf1, 0xb5, 0xf6, 0xff,
+	0x57, 0xf6, 0x34, 0xcc, 0x4e, 0xeb, 0x75, 0x81, 0xc2, 0x4c, 0x44, 0x33, 0x72, 0xe5, 0x85, 0xbe,
+	0x82, 0x8d, 0x21, 0x1d, 0xb9, 0xc9, 0x58, 0x68, 0xa5, 0xbb, 0xd5, 0xb2, 0xc0, 0xa3, 0x2f, 0x61,
+	0xdd, 0x1d, 0x4e, 0x02, 0x16, 0x6b, 0xe5, 0xbb, 0x79, 0xe6, 0xf0, 0xe6, 0x8f, 0xd0, 0x58, 0x4e,
+	0x08, 0xa9, 0x50, 0xfe, 0x40, 0x67, 0x39, 0x11, 0xca, 0x25, 0x7a, 0x0e, 0x6b, 0x53, 0x77, 0x9c,
+	0xd0, 0xbb, 0x66, 0x95, 0xa1, 0xbf, 0x2e, 0xbd, 0x50, 0xf4, 0x3f, 0x2a, 0xa0, 0xce, 0x4f, 0x7f,
+	0x4c, 0xe3, 0x58, 0xde, 0xc6, 0xcf, 0x96, 0x18, 0xef, 0xbf, 0x2b, 0x55, 0xca, 0x71, 0x8b, 0xa4,
+	0xf7, 0x02, 0xaa, 0x73, 0x72, 0xbf, 0xc3, 0x80, 0x5c, 0x81, 0x25, 0x8b, 0x85, 0xee, 0x6c, 0xcc,
+	0xdd, 0x61, 0x3e, 0x18, 0x85, 0x28, 0xe9, 0x59, 0x5c, 0x06, 0xc3, 0x74, 0x22, 0xaa, 0x24, 0x5d,
+	0xa3, 0x37, 0xb0, 0x5d, 0xfc, 0x64, 0x16, 0xb9, 0xad, 0xd6, 0xde, 0x5b, 0xc9, 0xb2, 0xb7, 0x8c,
+	0x23, 0xd7, 0x1d, 0xd1, 0x4b, 0x68, 0xcc, 0x6f, 0x17, 0x96, 0xbf, 0x34, 0x6d, 0xfd, 0x16, 0xe2,
+	0x4d, 0xad, 0xe4, 0x1a, 0x5a, 0xff, 0xb5, 0x74, 0x33, 0x65, 0xd5, 0x61, 0x93, 0xe0, 0x43, 0xbb,
+	0x3f, 0xc0, 0x44, 0x55, 0x50, 0x03, 0xa0, 0x90, 0xb0, 0xa5, 0x96, 0x24, 0x63, 0xd9, 0x8e, 0x3d,
+	0x50, 0xcb, 0xa8, 0x0a, 0x6b, 0x04, 0x1b, 0xd6, 0x7b, 0xb5, 0x82, 0xb6, 0xa1, 0x36, 0x20, 0x86,
+	0xd3, 0x37, 0xcc, 0x81, 0xdd, 0x75, 0xd4, 0x35, 0x19, 0xd2, 0xec, 0x1e, 0xf7, 0x3a, 0x78, 0x80,
+	0x2d, 0x75, 0x5d, 0x42, 0x31, 0x21, 0x5d, 0xa2, 0x6e, 0x48, 0xcb, 0x21, 0x1e, 0x9c, 0xf5, 0x07,
+	0xc6, 0x00, 0xab, 0x9b, 0x52, 0xec, 0x9d, 0x14, 0x62, 0x55, 0x8a, 0x16, 0xee, 0xe4, 0x22, 0xa0,
+	0x1d, 0x50, 0x6d, 0xe7, 0xb4, 0x7b, 0x84, 0xcf, 0xcc, 0xef, 0x0d, 0xdb, 0x31, 0x25, 0x7b, 0xd6,
+	0xb2, 0x04, 0xfb, 0xbd, 0xae, 0xd3, 0xc7, 0xea, 0x16, 0x7a, 0x08, 0xf7, 0x89, 0xe1, 0x1c, 0xe2,
+	0xb3, 0xb7, 0x27, 0x98, 0xbc, 0xcf, 0x5d, 0x1b, 0xa8, 0x09, 0xbb, 0x2b, 0xea, 0x33, 0x07, 0xbf,
+	0x1b, 0xa8, 0xdb, 0xe8, 0xdf, 0xf0, 0x68, 0xd5, 0x66, 0x76, 0xba, 0x7d, 0xac, 0xaa, 0x32, 0x85,
+	0x23, 0x8c, 0x7b, 0x46, 0xc7, 0x3e, 0xc5, 0xea, 0x7d, 0xfd, 0x4f, 0x05, 0xb4, 0xdb, 0x7a, 0x82,
+	0x8e, 0xa1, 0x2a, 0x22, 0x97, 0xc5, 0x81, 0xac, 0x7e, 0x36, 0x94, 0x07, 0x7f, 0xd7, 0xc8, 0xd6,
+	0xa0, 0xf0, 0xc8, 0x07, 0x74, 0x1e, 0x41, 0x76, 0x34, 0x0e, 0x7c, 0x46, 0x87, 0x85, 0x8b, 0x56,
+	0x5a, 0xee, 0x68, 0x7f, 0xc9, 0x4a, 0xae, 0xa1, 0x9b, 0xdf, 0x42, 0x63, 0x39, 0xf8, 0x0d, 0xc3,
+	0xb6, 0xb3, 0x38, 0x6c, 0xf5, 0xc5, 0x59, 0xfa, 0x02, 0xea, 0xbd, 0x44, 0xf4, 0x85, 0x2b, 0xa8,
+	0xcd, 0x46, 0xfc, 0xae, 0xbe, 0x3a, 0x86, 0x6d, 0xe2, 0x32, 0x9f, 0xbe, 0x4d, 0x68, 0x34, 0x4b,
+	0xdd, 0xe5, 0x8f, 0x34, 0x16, 0x6e, 0x24, 0x8e, 0xe6, 0xfe, 0x73, 0x19, 0xed, 0xc2, 0x3a, 0x65,
+	0x43, 0x69, 0xc9, 0xa8, 0x2f, 0x97, 0xf4, 0x8f, 0xe0, 0xc1, 0xb5, 0x30, 0x8e, 0x2c, 0x71, 0x03,
+	0x4a, 0xb6, 0x95, 0x07, 0x29, 0xd9, 0x96, 0xfe, 0x31, 0xec, 0x5c, 0x83, 0x99, 0x63, 0x1e, 0xd3,
+	0x15, 0x9c, 0x01, 0x8f, 0xae, 0xe1, 0x8e, 0xe8, 0xec, 0x54, 0x26, 0x7c, 0xe7, 0x83, 0xfd, 0xa2,
+	0xac, 0xc4, 0x20, 0x34, 0x0e, 0x39, 0x8b, 0x29, 0xc2, 0xb0, 0xf5, 0x81, 0xce, 0x62, 0x83, 0x0d,
+	0xd3, 0x98, 0x05, 0x25, 0x3f, 0x2e, 0x3a, 0x75, 0xcb, 0xde, 0x64, 0xd9, 0x4b, 0xb2, 0xc7, 0x85,
+	0x1b, 0x1f, 0xf3, 0x28, 0xdb, 0x7a, 0x93, 0x14, 0x62, 0x7e, 0x9e, 0x72, 0x71, 0x9e, 0x4f, 0x9f,
+	0xc1, 0xce, 0x4d, 0x2f, 0x19, 0xf9, 0xdf, 0xea, 0x9d, 0xbc, 0xea, 0xd8, 0xa6, 0x7a, 0x0f, 0xa9,
+	0x50, 0x37, 0xbb, 0xce, 0x6b, 0xdb, 0xc2, 0xce, 0xc0, 0x36, 0x3a, 0xaa, 0xd2, 0x7e, 0xb7, 0x40,
+	0x8f, 0xfd, 0x24, 0x0c, 0x79, 0x24, 0x90, 0x05, 0x9b, 0x84, 0xfa, 0x41, 0x2c, 0x68, 0x84, 0xb4,
+	0xdb, 0xc8, 0xb1, 0x79, 0xab, 0x45, 0xbf, 0xb7, 0xaf, 0x3c, 0x55, 0x5e, 0x99, 0xb0, 0xcb, 0x23,
+	0xbf, 0x75, 0x31, 0x0b, 0x69, 0x34, 0xa6, 0x43, 0x9f, 0x46, 0xb9, 0xc3, 0x0f, 0x9f, 0xf8, 0x81,
+	0xb8, 0x48, 0xce, 0x25, 0x8b, 0x1f, 0x2c, 0x98, 0xf3, 0x47, 0x76, 0xf6, 0x9a, 0x8e, 0x0f, 0xe4,
+	0xbb, 0xfb, 0x3c, 0x7b, 0xa0, 0x7f, 0xfe, 0xd7, 0x00, 0x67, 0xac, 0x1a, 0x77, 0xbf, 0x0b, 0x00,
 	0x00,
 }
@@ -52,11 +52,12 @@ type ChaincodeProposalPayload struct {
 	// Input contains the arguments for this invocation. If this invocation
 	// deploys a new chaincode, ESCC/VSCC are part of this field.
 	Input []byte `protobuf:"bytes,1,opt,name=Input,proto3" json:"Input,omitempty"`
-	// Transient contains data (e.g. cryptographic material) that might be used
+	// TransientMap contains data (e.g. cryptographic material) that might be used
 	// to implement some form of application-level confidentiality. The contents
-	// of this field are supposed to always be omitted from the transaction and
-	// excluded from the ledger.
-	Transient []byte `protobuf:"bytes,2,opt,name=Transient,proto3" json:"Transient,omitempty"`
+	// of this field are passed to the chaincode but are always omitted from the
+	// transaction and excluded from the ledger. The proposal hash binds them
+	// through their digest.
+	TransientMap map[string][]byte `protobuf:"bytes,2,rep,name=TransientMap" json:"TransientMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *ChaincodeProposalPayload) Reset()                    { *m = ChaincodeProposalPayload{} }
@@ -64,6 +65,13 @@ func (m *ChaincodeProposalPayload) String() string            { return proto.Com
 func (*ChaincodeProposalPayload) ProtoMessage()               {}
 func (*ChaincodeProposalPayload) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
 
+func (m *ChaincodeProposalPayload) GetTransientMap() map[string][]byte {
+	if m != nil {
+		return m.TransientMap
+	}
+	return nil
+}
+
 // ChaincodeAction contains the actions the events generated by the execution
 // of the chaincode.
 type ChaincodeAction struct {
@@ -73,6 +81,12 @@ type ChaincodeAction struct {
 	// This field contains the events generated by the chaincode executing this
 	// invocation.
 	Events []byte `protobuf:"bytes,2,opt,name=events,proto3" json:"events,omitempty"`
+	// The ID, with the version, of the chaincode executing this invocation. An
+	// endorser only endorses a ChaincodeAction it did not compute itself if it
+	// is endorsed by the peer that computed it and if this version, or else the
+	// version of the proposal, is the version of the chaincode deployed on the
+	// chain.
+	ChaincodeID *ChaincodeID `protobuf:"bytes,3,opt,name=chaincodeID" json:"chaincodeID,omitempty"`
 }
 
 func (m *ChaincodeAction) Reset()                    { *m = ChaincodeAction{} }
@@ -80,6 +94,13 @@ func (m *ChaincodeAction) String() string            { return proto.CompactTextS
 func (*ChaincodeAction) ProtoMessage()               {}
 func (*ChaincodeAction) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
 
+func (m *ChaincodeAction) GetChaincodeID() *ChaincodeID {
+	if m != nil {
+		return m.ChaincodeID
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeHeaderExtension)(nil), "protos.ChaincodeHeaderExtension")
 	proto.RegisterType((*ChaincodeProposalPayload)(nil), "protos.ChaincodeProposalPayload")
@@ -89,22 +110,26 @@ func init() {
 func init() { proto.RegisterFile("peer/chaincode_proposal.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 264 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x64, 0x90, 0xd1, 0x4b, 0xc3, 0x30,
-	0x10, 0xc6, 0xa9, 0xe0, 0xc4, 0x6c, 0x20, 0xc6, 0x21, 0x45, 0x14, 0x46, 0x9f, 0x26, 0x4a, 0x03,
-	0x8a, 0x7f, 0x80, 0x56, 0xc1, 0xbd, 0xc8, 0x28, 0xe2, 0x83, 0x2f, 0x92, 0xa6, 0xe7, 0x1a, 0x88,
-	0x49, 0x48, 0xae, 0x62, 0x9f, 0xfc, 0xd7, 0x65, 0x4b, 0xb6, 0x55, 0xf6, 0x74, 0x7c, 0xf7, 0x1d,
-	0xbf, 0xef, 0xee, 0xc8, 0x85, 0x05, 0x70, 0x4c, 0x34, 0x5c, 0x6a, 0x61, 0x6a, 0xf8, 0xb0, 0xce,
-	0x58, 0xe3, 0xb9, 0xca, 0xad, 0x33, 0x68, 0xe8, 0x60, 0x55, 0xfc, 0xd9, 0xf8, 0xff, 0x58, 0x70,
-	0xb3, 0x5f, 0x92, 0x16, 0xeb, 0xd6, 0x33, 0xf0, 0x1a, 0xdc, 0xd3, 0x0f, 0x82, 0xf6, 0xd2, 0x68,
-	0x7a, 0x4d, 0x8e, 0x2d, 0xef, 0x94, 0xe1, 0xf5, 0x9b, 0xf4, 0xb2, 0x92, 0x4a, 0x62, 0x97, 0x26,
-	0x93, 0x64, 0x3a, 0x2a, 0x77, 0x0d, 0x7a, 0x47, 0x86, 0x1b, 0xf8, 0xec, 0x31, 0xdd, 0x9b, 0x24,
-	0xd3, 0xe1, 0xcd, 0x49, 0x88, 0xf1, 0x79, 0xb1, 0xb5, 0xca, 0xfe, 0x5c, 0xf6, 0xd2, 0x5b, 0x60,
-	0x1e, 0x37, 0x9f, 0x07, 0x38, 0x1d, 0x93, 0xfd, 0x99, 0xb6, 0x2d, 0xc6, 0xd0, 0x20, 0xe8, 0x39,
-	0x39, 0x7c, 0x75, 0x5c, 0x7b, 0x09, 0x1a, 0x57, 0x31, 0xa3, 0x72, 0xdb, 0xc8, 0x0a, 0x72, 0xb4,
-	0xe1, 0xdd, 0x0b, 0x5c, 0xde, 0x91, 0x92, 0x03, 0x07, 0xbe, 0x55, 0xe8, 0x23, 0x68, 0x2d, 0xe9,
-	0x29, 0x19, 0xc0, 0x37, 0x68, 0xf4, 0x91, 0x13, 0xd5, 0xc3, 0xd5, 0xfb, 0xe5, 0x42, 0x62, 0xd3,
-	0x56, 0xb9, 0x30, 0x5f, 0xac, 0xe9, 0x2c, 0x38, 0x05, 0xf5, 0x02, 0x1c, 0xfb, 0xe4, 0x95, 0x93,
-	0x82, 0x85, 0xab, 0xd8, 0xf2, 0xa5, 0x55, 0x78, 0xf0, 0xed, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x97, 0xb9, 0x42, 0xce, 0x88, 0x01, 0x00, 0x00,
+	// 322 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x92, 0x41, 0x4b, 0xc3, 0x30,
+	0x14, 0xc7, 0xe9, 0x86, 0x13, 0xb3, 0x81, 0x2e, 0x0e, 0x29, 0x03, 0x61, 0xec, 0x34, 0x51, 0x5a,
+	0xa8, 0x08, 0xe2, 0x45, 0x74, 0x0e, 0xdc, 0x41, 0x18, 0x45, 0x76, 0xf0, 0x22, 0x69, 0xfb, 0x5c,
+	0x83, 0x31, 0x09, 0x49, 0x3a, 0xac, 0x17, 0x3f, 0x9f, 0xdf, 0x4a, 0xba, 0x74, 0xb3, 0x5b, 0x2f,
+	0x9e, 0x92, 0x7f, 0xde, 0xcb, 0xef, 0xff, 0xde, 0xe3, 0xa1, 0x53, 0x09, 0xa0, 0xfc, 0x38, 0x25,
+	0x94, 0xc7, 0x22, 0x81, 0x57, 0xa9, 0x84, 0x14, 0x9a, 0x30, 0x4f, 0x2a, 0x61, 0x04, 0x6e, 0xad,
+	0x0e, 0xdd, 0xef, 0x6d, 0xa7, 0xd9, 0xe8, 0xf0, 0x1b, 0xb9, 0xe3, 0xf5, 0xd3, 0x23, 0x90, 0x04,
+	0xd4, 0xe4, 0xd3, 0x00, 0xd7, 0x54, 0x70, 0x7c, 0x81, 0xba, 0x92, 0xe4, 0x4c, 0x90, 0x64, 0x4e,
+	0x35, 0x8d, 0x28, 0xa3, 0x26, 0x77, 0x9d, 0x81, 0x33, 0xea, 0x84, 0xf5, 0x00, 0xbe, 0x42, 0xed,
+	0x0d, 0x7c, 0xfa, 0xe0, 0x36, 0x06, 0xce, 0xa8, 0x1d, 0x1c, 0x5b, 0x1b, 0xed, 0x8d, 0xff, 0x42,
+	0x61, 0x35, 0x6f, 0xf8, 0xe3, 0x54, 0x2a, 0x98, 0x95, 0xa5, 0xcf, 0x2c, 0x1d, 0xf7, 0xd0, 0xde,
+	0x94, 0xcb, 0xcc, 0x94, 0xae, 0x56, 0xe0, 0x39, 0xea, 0x3c, 0x2b, 0xc2, 0x35, 0x05, 0x6e, 0x9e,
+	0x88, 0x74, 0x1b, 0x83, 0xe6, 0xa8, 0x1d, 0x04, 0x35, 0xab, 0x1d, 0x9a, 0x57, 0xfd, 0x34, 0xe1,
+	0x46, 0xe5, 0xe1, 0x16, 0xa7, 0x7f, 0x8b, 0xba, 0xb5, 0x14, 0x7c, 0x84, 0x9a, 0xef, 0x60, 0xdb,
+	0x3e, 0x08, 0x8b, 0x6b, 0x51, 0xd4, 0x92, 0xb0, 0x0c, 0x56, 0x2d, 0x76, 0x42, 0x2b, 0x6e, 0x1a,
+	0xd7, 0xce, 0xf0, 0x0b, 0x1d, 0x6e, 0xcc, 0xef, 0x62, 0x53, 0xcc, 0xd0, 0x45, 0xfb, 0x0a, 0x74,
+	0xc6, 0x8c, 0x2e, 0x7b, 0x58, 0x4b, 0x7c, 0x82, 0x5a, 0xb0, 0x04, 0x6e, 0x74, 0xc9, 0x29, 0xd5,
+	0xee, 0x1c, 0x9b, 0xff, 0x9b, 0xe3, 0xfd, 0xf9, 0xcb, 0xd9, 0x82, 0x9a, 0x34, 0x8b, 0xbc, 0x58,
+	0x7c, 0xf8, 0x69, 0x2e, 0x41, 0x31, 0x48, 0x16, 0xa0, 0xfc, 0x37, 0x12, 0x29, 0x1a, 0xfb, 0x16,
+	0xe0, 0x17, 0x5b, 0x10, 0xd9, 0x9d, 0xb8, 0xfc, 0x1d, 0x00, 0x78, 0x3a, 0x28, 0x34, 0x3b, 0x02,
+	0x00, 0x00,
 }
@@ -7,6 +7,7 @@ package peer
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -219,6 +220,10 @@ type Event struct {
 	//	*Event_Rejection
 	//	*Event_Unregister
 	Event isEvent_Event `protobuf_oneof:"Event"`
+	// creator is the serialized MSP identity of the consumer sending the event
+	Creator []byte `protobuf:"bytes,6,opt,name=creator,proto3" json:"creator,omitempty"`
+	// timestamp is the time at which the consumer created the event
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,7,opt,name=timestamp" json:"timestamp,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
@@ -294,6 +299,13 @@ func (m *Event) GetUnregister() *Unregister {
 	return nil
 }
 
+func (m *Event) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Event) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Event_OneofMarshaler, _Event_OneofUnmarshaler, _Event_OneofSizer, []interface{}{
@@ -425,6 +437,20 @@ func _Event_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// SignedEvent is used by consumers to send an Event to the producer.
+// The producer checks the signature against the creator in the event
+type SignedEvent struct {
+	// signature over eventBytes by the creator
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	// eventBytes is the marshalled Event
+	EventBytes []byte `protobuf:"bytes,2,opt,name=eventBytes,proto3" json:"eventBytes,omitempty"`
+}
+
+func (m *SignedEvent) Reset()                    { *m = SignedEvent{} }
+func (m *SignedEvent) String() string            { return proto.CompactTextString(m) }
+func (*SignedEvent) ProtoMessage()               {}
+func (*SignedEvent) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{6} }
+
 func init() {
 	proto.RegisterType((*ChaincodeReg)(nil), "protos.ChaincodeReg")
 	proto.RegisterType((*Interest)(nil), "protos.Interest")
@@ -432,6 +458,7 @@ func init() {
 	proto.RegisterType((*Rejection)(nil), "protos.Rejection")
 	proto.RegisterType((*Unregister)(nil), "protos.Unregister")
 	proto.RegisterType((*Event)(nil), "protos.Event")
+	proto.RegisterType((*SignedEvent)(nil), "protos.SignedEvent")
 	proto.RegisterEnum("protos.EventType", EventType_name, EventType_value)
 }
 
@@ -468,7 +495,7 @@ func (c *eventsClient) Chat(ctx context.Context, opts ...grpc.CallOption) (Event
 }
 
 type Events_ChatClient interface {
-	Send(*Event) error
+	Send(*SignedEvent) error
 	Recv() (*Event, error)
 	grpc.ClientStream
 }
@@ -477,7 +504,7 @@ type eventsChatClient struct {
 	grpc.ClientStream
 }
 
-func (x *eventsChatClient) Send(m *Event) error {
+func (x *eventsChatClient) Send(m *SignedEvent) error {
 	return x.ClientStream.SendMsg(m)
 }
 
@@ -506,7 +533,7 @@ func _Events_Chat_Handler(srv interface{}, stream grpc.ServerStream) error {
 
 type Events_ChatServer interface {
 	Send(*Event) error
-	Recv() (*Event, error)
+	Recv() (*SignedEvent, error)
 	grpc.ServerStream
 }
 
@@ -518,8 +545,8 @@ func (x *eventsChatServer) Send(m *Event) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *eventsChatServer) Recv() (*Event, error) {
-	m := new(Event)
+func (x *eventsChatServer) Recv() (*SignedEvent, error) {
+	m := new(SignedEvent)
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -544,37 +571,43 @@ var _Events_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/events.proto", fileDescriptor4) }
 
 var fileDescriptor4 = []byte{
-	// 499 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x53, 0x51, 0x8f, 0x93, 0x4c,
-	0x14, 0x05, 0xba, 0xed, 0xc2, 0xed, 0x6e, 0x43, 0xef, 0xf7, 0x45, 0xb1, 0xd1, 0xa4, 0xc1, 0xc4,
-	0xd4, 0x35, 0x29, 0x8a, 0x8d, 0xcf, 0x0a, 0x4b, 0x04, 0x5d, 0xdb, 0x64, 0xac, 0x2f, 0xbe, 0x18,
-	0xca, 0xce, 0x52, 0x74, 0x17, 0x9a, 0x61, 0xd6, 0xec, 0xfe, 0x05, 0x5f, 0xfd, 0xc3, 0xa6, 0x03,
-	0x03, 0xac, 0xfb, 0xe4, 0x13, 0xcc, 0x3d, 0xe7, 0xdc, 0x7b, 0x38, 0xcc, 0x85, 0xf1, 0x8e, 0x52,
-	0xe6, 0xd0, 0x9f, 0x34, 0xe7, 0xe5, 0x7c, 0xc7, 0x0a, 0x5e, 0xe0, 0x40, 0x3c, 0xca, 0xc9, 0x23,
-	0x01, 0x25, 0xdb, 0x38, 0xcb, 0x93, 0xe2, 0x9c, 0x0a, 0x4e, 0x45, 0x99, 0x3c, 0x11, 0xd0, 0x45,
-	0xbc, 0x61, 0x59, 0xf2, 0x8d, 0xb3, 0x38, 0x2f, 0xe3, 0x84, 0x67, 0x45, 0x5e, 0xc3, 0x0f, 0xbb,
-	0xf0, 0xe6, 0xb2, 0x48, 0x7e, 0x54, 0x80, 0xbd, 0x84, 0x23, 0x5f, 0xf6, 0x23, 0x34, 0xc5, 0x29,
-	0x0c, 0x9b, 0xfe, 0xd1, 0xa9, 0xa5, 0x4e, 0xd5, 0x99, 0x41, 0xba, 0x25, 0x7c, 0x0c, 0x86, 0x18,
-	0xbc, 0x8c, 0xaf, 0xa8, 0xa5, 0x09, 0xbc, 0x2d, 0xd8, 0xbf, 0x54, 0xd0, 0xa3, 0x9c, 0x53, 0x46,
-	0x4b, 0x8e, 0x4e, 0x4d, 0x5d, 0xdf, 0xee, 0xa8, 0x68, 0x35, 0x72, 0xc7, 0xd5, 0xdc, 0x72, 0x1e,
-	0x48, 0x80, 0xb4, 0x1c, 0xf4, 0xc0, 0x4c, 0x3a, 0x6e, 0xa2, 0xfc, 0xa2, 0x10, 0x23, 0x86, 0xee,
-	0xff, 0x52, 0xd7, 0x75, 0x1b, 0x2a, 0xe4, 0x1e, 0xdf, 0x33, 0xe0, 0xb0, 0x7e, 0xb5, 0x17, 0xa0,
-	0x13, 0x9a, 0x66, 0x25, 0xa7, 0x0c, 0x67, 0x30, 0xa8, 0x32, 0xb5, 0xd4, 0x69, 0x6f, 0x36, 0x74,
-	0x4d, 0xd9, 0x50, 0xba, 0x25, 0x35, 0x6e, 0x9f, 0x81, 0x41, 0xe8, 0x77, 0x2a, 0xe2, 0xc3, 0xa7,
-	0xa0, 0xf1, 0x1b, 0xe1, 0x7d, 0xe8, 0xfe, 0x27, 0x25, 0xeb, 0x36, 0x5f, 0xa2, 0xf1, 0x1b, 0x9c,
-	0x80, 0x4e, 0x19, 0x2b, 0xd8, 0xa7, 0x32, 0xad, 0x13, 0x69, 0xce, 0xf6, 0x1b, 0x80, 0x2f, 0x39,
-	0xfb, 0x77, 0x17, 0xbf, 0x35, 0xe8, 0x8b, 0x8c, 0x70, 0x0e, 0xba, 0xd4, 0xd7, 0x46, 0x1a, 0x95,
-	0xfc, 0xba, 0x50, 0x21, 0x0d, 0x07, 0x9f, 0x41, 0x5f, 0xfc, 0xe1, 0x3a, 0xb9, 0x91, 0x24, 0x7b,
-	0xfb, 0xa2, 0x1b, 0x2a, 0xa4, 0x82, 0xf1, 0x2d, 0x8c, 0x9a, 0xf0, 0xc4, 0x24, 0xab, 0x27, 0x04,
-	0x0f, 0xee, 0x45, 0x2d, 0xd0, 0x50, 0x21, 0x7f, 0xf1, 0xf1, 0x15, 0x18, 0x4c, 0x26, 0x65, 0x1d,
-	0x08, 0xf1, 0xb8, 0xb5, 0x56, 0x03, 0xa1, 0x42, 0x5a, 0x16, 0x2e, 0x00, 0xae, 0x9b, 0x38, 0xac,
-	0xbe, 0xd0, 0xa0, 0xd4, 0xb4, 0x41, 0x85, 0x0a, 0xe9, 0xf0, 0xbc, 0xc3, 0x3a, 0x8b, 0x13, 0x0f,
-	0x8c, 0xe6, 0xe2, 0xe0, 0x11, 0xe8, 0x24, 0x78, 0x1f, 0x7d, 0x5e, 0x07, 0xc4, 0x54, 0xd0, 0x80,
-	0xbe, 0x77, 0xb6, 0xf2, 0x3f, 0x9a, 0x2a, 0x1e, 0x83, 0xe1, 0x87, 0xef, 0xa2, 0xa5, 0xbf, 0x3a,
-	0x0d, 0x4c, 0x6d, 0x7f, 0x24, 0xc1, 0x87, 0xc0, 0x5f, 0x47, 0xab, 0xa5, 0xd9, 0x73, 0x17, 0x30,
-	0x10, 0x3d, 0x4a, 0x3c, 0x81, 0x03, 0x7f, 0x1b, 0x73, 0x3c, 0xbe, 0x73, 0x29, 0x27, 0x77, 0x8f,
-	0xb6, 0x32, 0x53, 0x5f, 0xaa, 0xde, 0x8b, 0xaf, 0xcf, 0xd3, 0x8c, 0x6f, 0xaf, 0x37, 0xf3, 0xa4,
-	0xb8, 0x72, 0xb6, 0xb7, 0x3b, 0xca, 0x2e, 0xe9, 0x79, 0xda, 0x6c, 0x95, 0x53, 0x69, 0x9c, 0xfd,
-	0xa2, 0x6d, 0xaa, 0x85, 0x7d, 0xfd, 0x27, 0x00, 0x00, 0xff, 0xff, 0x44, 0x56, 0x27, 0xe8, 0xcc,
-	0x03, 0x00, 0x00,
+	// 598 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x54, 0xdd, 0x6e, 0xd3, 0x4c,
+	0x10, 0xb5, 0xd3, 0xe6, 0xc7, 0x93, 0xb4, 0x4a, 0xf7, 0xfb, 0x04, 0x26, 0x2a, 0x10, 0x19, 0x09,
+	0x05, 0x90, 0x9c, 0x12, 0x2a, 0x84, 0xb8, 0x02, 0xbb, 0x16, 0x36, 0x2d, 0xa9, 0xb4, 0x0d, 0x37,
+	0xdc, 0x20, 0xc7, 0x9d, 0x3a, 0x86, 0xc6, 0xb6, 0x76, 0x37, 0xa8, 0x79, 0x05, 0x5e, 0x94, 0xd7,
+	0x40, 0x5d, 0x7b, 0x6d, 0x43, 0xaf, 0xb8, 0x4a, 0x76, 0xce, 0x39, 0x33, 0xc7, 0xc7, 0x3b, 0x86,
+	0x83, 0x1c, 0x91, 0x4d, 0xf1, 0x07, 0xa6, 0x82, 0xdb, 0x39, 0xcb, 0x44, 0x46, 0x3a, 0xf2, 0x87,
+	0x8f, 0x1e, 0xc7, 0x59, 0x16, 0x5f, 0xe3, 0x54, 0x1e, 0x97, 0x9b, 0xab, 0xa9, 0x48, 0xd6, 0xc8,
+	0x45, 0xb8, 0xce, 0x0b, 0xe2, 0xe8, 0x81, 0xd4, 0x46, 0xab, 0x30, 0x49, 0xa3, 0xec, 0x12, 0x65,
+	0x93, 0x12, 0x7a, 0x28, 0xa1, 0xab, 0x70, 0xc9, 0x92, 0xe8, 0xab, 0x60, 0x61, 0xca, 0xc3, 0x48,
+	0x24, 0x59, 0x5a, 0xc2, 0xf7, 0x9b, 0xf0, 0xf2, 0x3a, 0x8b, 0xbe, 0x17, 0x80, 0x35, 0x87, 0x81,
+	0xab, 0xfa, 0x51, 0x8c, 0xc9, 0x18, 0xfa, 0x55, 0xff, 0xe0, 0xc4, 0xd4, 0xc7, 0xfa, 0xc4, 0xa0,
+	0xcd, 0x12, 0x39, 0x04, 0x43, 0x0e, 0x9e, 0x87, 0x6b, 0x34, 0x5b, 0x12, 0xaf, 0x0b, 0xd6, 0x4f,
+	0x1d, 0x7a, 0x41, 0x2a, 0x90, 0x21, 0x17, 0x64, 0x5a, 0x52, 0x17, 0xdb, 0x1c, 0x65, 0xab, 0xfd,
+	0xd9, 0x41, 0x31, 0x97, 0xdb, 0x9e, 0x02, 0x68, 0xcd, 0x21, 0x0e, 0x0c, 0xa3, 0x86, 0x9b, 0x20,
+	0xbd, 0xca, 0xe4, 0x88, 0xfe, 0xec, 0x7f, 0xa5, 0x6b, 0xba, 0xf5, 0x35, 0x7a, 0x87, 0xef, 0x18,
+	0xd0, 0x2d, 0xff, 0x5a, 0xc7, 0xd0, 0xa3, 0x18, 0x27, 0x5c, 0x20, 0x23, 0x13, 0xe8, 0x14, 0xa1,
+	0x9b, 0xfa, 0x78, 0x67, 0xd2, 0x9f, 0x0d, 0x55, 0x43, 0xe5, 0x96, 0x96, 0xb8, 0x75, 0x06, 0x06,
+	0xc5, 0x6f, 0x28, 0xe3, 0x23, 0x4f, 0xa0, 0x25, 0x6e, 0xa4, 0xf7, 0xfe, 0xec, 0x3f, 0x25, 0x59,
+	0xd4, 0xf9, 0xd2, 0x96, 0xb8, 0x21, 0x23, 0xe8, 0x21, 0x63, 0x19, 0xfb, 0xc4, 0xe3, 0x32, 0x91,
+	0xea, 0x6c, 0xbd, 0x06, 0xf8, 0x9c, 0xb2, 0x7f, 0x77, 0xf1, 0xab, 0x05, 0x6d, 0x99, 0x11, 0xb1,
+	0xa1, 0xa7, 0xf4, 0xa5, 0x91, 0x4a, 0xa5, 0x9e, 0xce, 0xd7, 0x68, 0xc5, 0x21, 0x4f, 0xa1, 0x2d,
+	0xdf, 0x70, 0x99, 0xdc, 0xbe, 0x22, 0x3b, 0xb7, 0xc5, 0x99, 0xaf, 0xd1, 0x02, 0x26, 0xef, 0x60,
+	0xbf, 0x0a, 0x4f, 0x4e, 0x32, 0x77, 0xa4, 0xe0, 0xde, 0x9d, 0xa8, 0x25, 0xea, 0x6b, 0xf4, 0x2f,
+	0x3e, 0x79, 0x09, 0x06, 0x53, 0x49, 0x99, 0xbb, 0x52, 0x7c, 0x50, 0x5b, 0x2b, 0x01, 0x5f, 0xa3,
+	0x35, 0x8b, 0x1c, 0x03, 0x6c, 0xaa, 0x38, 0xcc, 0xb6, 0xd4, 0x10, 0xa5, 0xa9, 0x83, 0xf2, 0x35,
+	0xda, 0xe0, 0x11, 0x13, 0xba, 0x11, 0xc3, 0x50, 0x64, 0xcc, 0xec, 0x8c, 0xf5, 0xc9, 0x80, 0xaa,
+	0x23, 0x79, 0x03, 0x46, 0xb5, 0x25, 0x66, 0x57, 0xb6, 0x1b, 0xd9, 0xc5, 0x1e, 0xd9, 0x6a, 0x8f,
+	0xec, 0x85, 0x62, 0xd0, 0x9a, 0xec, 0x74, 0xcb, 0x7c, 0xad, 0x53, 0xe8, 0x5f, 0x24, 0x71, 0x8a,
+	0x97, 0xc5, 0x43, 0x1d, 0x82, 0xc1, 0x93, 0x38, 0x0d, 0xc5, 0x86, 0x15, 0x97, 0x76, 0x40, 0xeb,
+	0x02, 0x79, 0x04, 0x20, 0x5f, 0x90, 0xb3, 0x15, 0xc8, 0x65, 0xc2, 0x03, 0xda, 0xa8, 0x3c, 0x77,
+	0xc0, 0xa8, 0x6e, 0x36, 0x19, 0x40, 0x8f, 0x7a, 0x1f, 0x82, 0x8b, 0x85, 0x47, 0x87, 0x1a, 0x31,
+	0xa0, 0xed, 0x9c, 0x9d, 0xbb, 0xa7, 0x43, 0x9d, 0xec, 0x81, 0xe1, 0xfa, 0xef, 0x83, 0xb9, 0x7b,
+	0x7e, 0xe2, 0x0d, 0x5b, 0xb7, 0x47, 0xea, 0x7d, 0xf4, 0xdc, 0x45, 0x70, 0x3e, 0x1f, 0xee, 0xcc,
+	0xde, 0x42, 0x47, 0xf6, 0xe0, 0xe4, 0x08, 0x76, 0xdd, 0x55, 0x28, 0x48, 0x75, 0xf3, 0x1a, 0x46,
+	0x47, 0x7b, 0x7f, 0xac, 0x92, 0xa5, 0x4d, 0xf4, 0x23, 0xdd, 0x79, 0xf1, 0xe5, 0x59, 0x9c, 0x88,
+	0xd5, 0x66, 0x69, 0x47, 0xd9, 0x7a, 0xba, 0xda, 0xe6, 0xc8, 0xae, 0xf1, 0x32, 0xae, 0x96, 0xbf,
+	0xf8, 0xb8, 0xf0, 0x69, 0x8e, 0xc8, 0x96, 0xc5, 0x87, 0xe7, 0xd5, 0xef, 0x01, 0x00, 0x99, 0x87,
+	0x85, 0x5c, 0x94, 0x04, 0x00, 0x00,
 }
@@ -69,7 +69,9 @@ func (*SignedProposal) Descriptor() ([]byte, []int) { return fileDescriptor8, []
 // 2. The payload is a ChaincodeProposalPayload message.
 // 3. The extension is a ChaincodeAction that might be used to ask the
 //    endorsers to endorse a specific ChaincodeAction, thus emulating the
-//    submitting peer model.
+//    submitting peer model. The simulationResponse is then the
+//    ProposalResponse in which the peer that simulated the proposal
+//    endorsed that ChaincodeAction.
 type Proposal struct {
 	// The header of the proposal. It is the bytes of the Header
 	Header []byte `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -80,6 +82,9 @@ type Proposal struct {
 	// type field.  For the type CHAINCODE, it might be the bytes of a
 	// ChaincodeAction message.
 	Extension []byte `protobuf:"bytes,3,opt,name=extension,proto3" json:"extension,omitempty"`
+	// The bytes of the ProposalResponse message of the peer that simulated
+	// the proposal, endorsing the ChaincodeAction of the extension.
+	SimulationResponse []byte `protobuf:"bytes,4,opt,name=simulationResponse,proto3" json:"simulationResponse,omitempty"`
 }
 
 func (m *Proposal) Reset()                    { *m = Proposal{} }
@@ -95,18 +100,19 @@ func init() {
 func init() { proto.RegisterFile("peer/fabric_proposal.proto", fileDescriptor8) }
 
 var fileDescriptor8 = []byte{
-	// 196 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xe2, 0x92, 0x2a, 0x48, 0x4d, 0x2d,
-	0xd2, 0x4f, 0x4b, 0x4c, 0x2a, 0xca, 0x4c, 0x8e, 0x2f, 0x28, 0xca, 0x2f, 0xc8, 0x2f, 0x4e, 0xcc,
-	0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x03, 0x53, 0xc5, 0x4a, 0x21, 0x5c, 0x7c, 0xc1,
-	0x99, 0xe9, 0x79, 0xa9, 0x29, 0x01, 0x50, 0x79, 0x21, 0x15, 0x2e, 0x5e, 0x98, 0x5a, 0xa7, 0xca,
-	0x92, 0xd4, 0x62, 0x09, 0x46, 0x05, 0x46, 0x0d, 0x9e, 0x20, 0x54, 0x41, 0x21, 0x19, 0x2e, 0xce,
-	0xe2, 0xcc, 0xf4, 0xbc, 0xc4, 0x92, 0xd2, 0xa2, 0x54, 0x09, 0x26, 0xb0, 0x0a, 0x84, 0x80, 0x52,
-	0x14, 0x17, 0x07, 0xdc, 0x3c, 0x31, 0x2e, 0xb6, 0x8c, 0xd4, 0xc4, 0x94, 0xd4, 0x22, 0xa8, 0x41,
-	0x50, 0x9e, 0x90, 0x04, 0x17, 0x7b, 0x41, 0x62, 0x65, 0x4e, 0x7e, 0x62, 0x0a, 0x54, 0x3f, 0x8c,
-	0x0b, 0x32, 0x3b, 0xb5, 0xa2, 0x24, 0x35, 0xaf, 0x38, 0x33, 0x3f, 0x4f, 0x82, 0x19, 0x62, 0x36,
-	0x5c, 0xc0, 0x49, 0x3b, 0x4a, 0x33, 0x3d, 0xb3, 0x24, 0xa3, 0x34, 0x49, 0x2f, 0x39, 0x3f, 0x57,
-	0x3f, 0xa3, 0xb2, 0x20, 0xb5, 0x28, 0x27, 0x35, 0x25, 0x1d, 0xee, 0x53, 0x7d, 0x88, 0xcf, 0xf4,
-	0x41, 0x9e, 0x4f, 0x82, 0x78, 0xd3, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0x9b, 0x5c, 0x58, 0xb9,
-	0x0b, 0x01, 0x00, 0x00,
+	// 218 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x90, 0xc1, 0x4a, 0xc4, 0x30,
+	0x10, 0x86, 0xa9, 0xca, 0xaa, 0x83, 0x7a, 0xc8, 0x41, 0x82, 0x78, 0x90, 0xc5, 0x83, 0x22, 0x34,
+	0x07, 0xdf, 0x60, 0x9f, 0x40, 0x56, 0x4f, 0x5e, 0x24, 0xdd, 0x8c, 0x69, 0x20, 0xcd, 0x84, 0x4c,
+	0x0a, 0xf6, 0x15, 0x7c, 0x6a, 0x69, 0x9b, 0x56, 0x04, 0x4f, 0xc3, 0xfc, 0xff, 0xcf, 0x77, 0xf8,
+	0xe0, 0x26, 0x22, 0x26, 0xf5, 0xa9, 0x9b, 0xe4, 0x0e, 0x1f, 0x31, 0x51, 0x24, 0xd6, 0xbe, 0x8e,
+	0x89, 0x32, 0x89, 0xcd, 0x74, 0x78, 0xfb, 0x06, 0x57, 0xaf, 0xce, 0x06, 0x34, 0x2f, 0xa5, 0x17,
+	0xf7, 0x70, 0xb9, 0x6c, 0x77, 0x43, 0x46, 0x96, 0xd5, 0x5d, 0xf5, 0x70, 0xb1, 0xff, 0x1b, 0x8a,
+	0x5b, 0x38, 0x67, 0x67, 0x83, 0xce, 0x7d, 0x42, 0x79, 0x34, 0x2d, 0x7e, 0x83, 0xed, 0x77, 0x05,
+	0x67, 0x2b, 0xf0, 0x1a, 0x36, 0x2d, 0x6a, 0x83, 0xa9, 0x90, 0xca, 0x27, 0x24, 0x9c, 0x46, 0x3d,
+	0x78, 0xd2, 0xa6, 0x00, 0x96, 0x77, 0x84, 0xe3, 0x57, 0xc6, 0xc0, 0x8e, 0x82, 0x3c, 0x9e, 0xe1,
+	0x6b, 0x20, 0x6a, 0x10, 0xec, 0xba, 0xde, 0xeb, 0xec, 0x28, 0xec, 0x91, 0x23, 0x05, 0x46, 0x79,
+	0x32, 0xcd, 0xfe, 0x69, 0x76, 0x4f, 0xef, 0x8f, 0xd6, 0xe5, 0xb6, 0x6f, 0xea, 0x03, 0x75, 0xaa,
+	0x1d, 0x22, 0x26, 0x8f, 0xc6, 0xae, 0x6a, 0xd4, 0xac, 0x42, 0x8d, 0xb6, 0x9a, 0xd9, 0xcb, 0xf3,
+	0xcf, 0x00, 0xff, 0x42, 0x93, 0x0e, 0x3c, 0x01, 0x00, 0x00,
 }
@@ -17,17 +17,23 @@ var _ = math.Inf
 type InvalidTransaction_Cause int32
 
 const (
-	InvalidTransaction_TxIdAlreadyExists      InvalidTransaction_Cause = 0
-	InvalidTransaction_RWConflictDuringCommit InvalidTransaction_Cause = 1
+	InvalidTransaction_TxIdAlreadyExists        InvalidTransaction_Cause = 0
+	InvalidTransaction_RWConflictDuringCommit   InvalidTransaction_Cause = 1
+	InvalidTransaction_BadCreatorSignature      InvalidTransaction_Cause = 2
+	InvalidTransaction_EndorsementPolicyFailure InvalidTransaction_Cause = 3
 )
 
 var InvalidTransaction_Cause_name = map[int32]string{
 	0: "TxIdAlreadyExists",
 	1: "RWConflictDuringCommit",
+	2: "BadCreatorSignature",
+	3: "EndorsementPolicyFailure",
 }
 var InvalidTransaction_Cause_value = map[string]int32{
-	"TxIdAlreadyExists":      0,
-	"RWConflictDuringCommit": 1,
+	"TxIdAlreadyExists":        0,
+	"RWConflictDuringCommit":   1,
+	"BadCreatorSignature":      2,
+	"EndorsementPolicyFailure": 3,
 }
 
 func (x InvalidTransaction_Cause) String() string {
@@ -139,29 +145,31 @@ func init() {
 func init() { proto.RegisterFile("peer/fabric_transaction.proto", fileDescriptor11) }
 
 var fileDescriptor11 = []byte{
-	// 371 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x92, 0x51, 0x6b, 0xea, 0x30,
-	0x1c, 0xc5, 0x6f, 0xaf, 0xa8, 0xf8, 0xef, 0xe5, 0xa2, 0x19, 0x93, 0x4e, 0x36, 0x26, 0x7d, 0x72,
-	0x1b, 0xb4, 0x50, 0xd9, 0x18, 0x7b, 0x53, 0xe7, 0x83, 0xaf, 0x9d, 0x30, 0x18, 0x8c, 0x91, 0xb6,
-	0xb1, 0x06, 0xda, 0xa6, 0x24, 0xa9, 0xd8, 0x2f, 0xb2, 0xaf, 0xb3, 0xaf, 0x36, 0x6c, 0x8c, 0x76,
-	0xb8, 0xa7, 0x72, 0xd2, 0x5f, 0xce, 0xff, 0x70, 0xf2, 0x87, 0xab, 0x9c, 0x10, 0xee, 0xae, 0x70,
-	0xc0, 0x69, 0xf8, 0x21, 0x39, 0xce, 0x04, 0x0e, 0x25, 0x65, 0x99, 0x93, 0x73, 0x26, 0x19, 0x6a,
-	0x55, 0x1f, 0x31, 0xb8, 0x8e, 0x19, 0x8b, 0x13, 0xe2, 0x56, 0x32, 0x28, 0x56, 0xae, 0xa4, 0x29,
-	0x11, 0x12, 0xa7, 0xb9, 0x02, 0xed, 0x77, 0xe8, 0xbd, 0xd0, 0x38, 0x23, 0xd1, 0xf2, 0xe8, 0x81,
-	0x6e, 0xa1, 0x5b, 0xb3, 0x9c, 0x96, 0x92, 0x08, 0xcb, 0x18, 0x1a, 0xa3, 0x7f, 0xfe, 0xc9, 0x39,
-	0xba, 0x84, 0x8e, 0xa0, 0x71, 0x86, 0x65, 0xc1, 0x89, 0xf5, 0xb7, 0x82, 0x8e, 0x07, 0xf6, 0x97,
-	0x01, 0x68, 0x91, 0x6d, 0x70, 0x42, 0x7f, 0x0c, 0xb8, 0x07, 0xb3, 0x66, 0x54, 0x79, 0x9b, 0xde,
-	0x99, 0x8a, 0x24, 0x9c, 0x1a, 0xe9, 0xd7, 0x39, 0xf4, 0x00, 0xcd, 0x10, 0x17, 0x42, 0xcd, 0xf9,
-	0xef, 0x0d, 0xf5, 0x85, 0xd3, 0x09, 0xce, 0x6c, 0xc7, 0xf9, 0x0a, 0xb7, 0x9f, 0xa0, 0x59, 0x69,
-	0x74, 0x0e, 0xbd, 0xe5, 0x76, 0x11, 0x4d, 0x12, 0x4e, 0x70, 0x54, 0xce, 0xb7, 0x54, 0x48, 0xd1,
-	0xfd, 0x83, 0x06, 0xd0, 0xf7, 0x5f, 0x67, 0x2c, 0x5b, 0x25, 0x34, 0x94, 0xcf, 0x05, 0xa7, 0x59,
-	0x3c, 0x63, 0x69, 0x4a, 0x65, 0xd7, 0xb0, 0x3f, 0x0d, 0x30, 0xeb, 0xd1, 0x2d, 0x68, 0x6f, 0x08,
-	0x17, 0x3a, 0x76, 0xd3, 0xd7, 0x12, 0x3d, 0x42, 0xe7, 0xd0, 0x6e, 0x95, 0xd0, 0xf4, 0x06, 0x8e,
-	0xea, 0xdf, 0xd1, 0xfd, 0x3b, 0x4b, 0x4d, 0xf8, 0x47, 0x18, 0x8d, 0xa1, 0xad, 0xdc, 0x85, 0xd5,
-	0x18, 0x36, 0x46, 0xa6, 0x77, 0xf1, 0x4b, 0x15, 0x13, 0x55, 0x88, 0x26, 0xed, 0x39, 0xf4, 0x4e,
-	0xfe, 0xa2, 0x3e, 0xb4, 0xd6, 0x04, 0x47, 0x84, 0xef, 0xdf, 0x6b, 0xaf, 0x76, 0xa9, 0x73, 0x5c,
-	0x26, 0x0c, 0x47, 0xfb, 0x37, 0xd2, 0x72, 0x7a, 0xf7, 0x76, 0x13, 0x53, 0xb9, 0x2e, 0x02, 0x27,
-	0x64, 0xa9, 0xbb, 0x2e, 0x73, 0xc2, 0x13, 0x12, 0xc5, 0x87, 0xe5, 0x52, 0xab, 0x23, 0xdc, 0xdd,
-	0xbe, 0x05, 0x6a, 0xad, 0xc6, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xc4, 0x48, 0x72, 0x86, 0x7e,
-	0x02, 0x00, 0x00,
+	// 407 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x92, 0xd1, 0x6b, 0xdb, 0x30,
+	0x10, 0xc6, 0xe7, 0x86, 0xb4, 0xf4, 0x3c, 0x46, 0xa2, 0xb2, 0xce, 0x0b, 0x1d, 0x0b, 0x7e, 0xea,
+	0x36, 0xb0, 0x21, 0x65, 0x63, 0xaf, 0x4d, 0x96, 0x41, 0xdf, 0x86, 0x1b, 0x18, 0x0c, 0xc6, 0x50,
+	0xac, 0x8b, 0x23, 0x90, 0x25, 0x4f, 0x92, 0x4b, 0xfd, 0x8f, 0xec, 0x4f, 0xdd, 0xf3, 0xb0, 0x15,
+	0x25, 0x1e, 0xe9, 0x8b, 0xcd, 0x27, 0xfd, 0xfc, 0xdd, 0x9d, 0xbf, 0x83, 0x37, 0x15, 0xa2, 0x4e,
+	0x37, 0x74, 0xad, 0x79, 0xfe, 0xcb, 0x6a, 0x2a, 0x0d, 0xcd, 0x2d, 0x57, 0x32, 0xa9, 0xb4, 0xb2,
+	0x8a, 0x9c, 0x76, 0x2f, 0x33, 0x79, 0x5b, 0x28, 0x55, 0x08, 0x4c, 0x3b, 0xb9, 0xae, 0x37, 0xa9,
+	0xe5, 0x25, 0x1a, 0x4b, 0xcb, 0xca, 0x81, 0xf1, 0x4f, 0x18, 0xdf, 0xf3, 0x42, 0x22, 0x5b, 0x1d,
+	0x3c, 0xc8, 0x7b, 0x18, 0xf5, 0x2c, 0xe7, 0x8d, 0x45, 0x13, 0x05, 0xd3, 0xe0, 0xfa, 0x79, 0x76,
+	0x74, 0x4e, 0xae, 0xe0, 0xdc, 0xf0, 0x42, 0x52, 0x5b, 0x6b, 0x8c, 0x4e, 0x3a, 0xe8, 0x70, 0x10,
+	0xff, 0x0d, 0x80, 0xdc, 0xc9, 0x07, 0x2a, 0xf8, 0x7f, 0x05, 0x3e, 0x42, 0xd8, 0x33, 0xea, 0xbc,
+	0xc3, 0xd9, 0x85, 0x6b, 0xc9, 0x24, 0x3d, 0x32, 0xeb, 0x73, 0xe4, 0x13, 0x0c, 0x73, 0x5a, 0x1b,
+	0x57, 0xe7, 0xc5, 0x6c, 0xea, 0x3f, 0x38, 0xae, 0x90, 0x2c, 0x5a, 0x2e, 0x73, 0x78, 0xfc, 0x1b,
+	0x86, 0x9d, 0x26, 0x2f, 0x61, 0xbc, 0x7a, 0xbc, 0x63, 0xb7, 0x42, 0x23, 0x65, 0xcd, 0xf2, 0x91,
+	0x1b, 0x6b, 0x46, 0xcf, 0xc8, 0x04, 0x2e, 0xb3, 0xef, 0x0b, 0x25, 0x37, 0x82, 0xe7, 0xf6, 0x4b,
+	0xad, 0xb9, 0x2c, 0x16, 0xaa, 0x2c, 0xb9, 0x1d, 0x05, 0xe4, 0x15, 0x5c, 0xcc, 0x29, 0x5b, 0x68,
+	0xa4, 0x56, 0xe9, 0x7b, 0x3f, 0xd8, 0xe8, 0x84, 0x5c, 0x41, 0xb4, 0x94, 0x4c, 0x69, 0x83, 0x25,
+	0x4a, 0xfb, 0x4d, 0x09, 0x9e, 0x37, 0x5f, 0x29, 0x17, 0xed, 0xed, 0x20, 0xfe, 0x13, 0x40, 0xd8,
+	0x9f, 0x38, 0x82, 0xb3, 0x07, 0xd4, 0xc6, 0x4f, 0x3b, 0xcc, 0xbc, 0x24, 0x9f, 0xe1, 0x7c, 0x1f,
+	0x4a, 0x37, 0x58, 0x38, 0x9b, 0x24, 0x2e, 0xb6, 0xc4, 0xc7, 0x96, 0xac, 0x3c, 0x91, 0x1d, 0x60,
+	0x72, 0x03, 0x67, 0xce, 0xdd, 0x44, 0x83, 0xe9, 0xe0, 0x3a, 0x9c, 0xbd, 0x7e, 0xe2, 0x0f, 0xde,
+	0x76, 0xcf, 0xcc, 0x93, 0xf1, 0x12, 0xc6, 0x47, 0xb7, 0xe4, 0x12, 0x4e, 0xb7, 0x48, 0x19, 0xea,
+	0x5d, 0xcc, 0x3b, 0xd5, 0x76, 0x5d, 0xd1, 0x46, 0x28, 0xca, 0x76, 0xd1, 0x7a, 0x39, 0xff, 0xf0,
+	0xe3, 0x5d, 0xc1, 0xed, 0xb6, 0x5e, 0x27, 0xb9, 0x2a, 0xd3, 0x6d, 0x53, 0xa1, 0x16, 0xc8, 0x8a,
+	0xfd, 0x4e, 0xba, 0x8d, 0x33, 0x69, 0xbb, 0xa6, 0x6b, 0xb7, 0x8d, 0x37, 0xff, 0x06, 0x00, 0xb0,
+	0x0b, 0x34, 0xba, 0xb5, 0x02, 0x00, 0x00,
 }
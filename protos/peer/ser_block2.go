@@ -37,6 +37,9 @@ func ConstructSerBlock2(block *Block2) (*SerBlock2, error) {
 	if err := buf.EncodeRawBytes(block.PreviousBlockHash); err != nil {
 		return nil, err
 	}
+	if err := buf.EncodeRawBytes(block.OrdererBlock); err != nil {
+		return nil, err
+	}
 	blockBytes := buf.Bytes()
 	numTxs := len(block.Transactions)
 	for i := 0; i < numTxs; i++ {
@@ -110,14 +113,21 @@ func (serBlock *SerBlock2) ToBlock2() (*Block2, error) {
 	buf := proto.NewBuffer(serBlock.blockBytes)
 	var err error
 	var previousBlockHash []byte
+	var ordererBlock []byte
 	var txOffsets []int
 	if previousBlockHash, err = serBlock.extractPreviousBlockHash(buf); err != nil {
 		return nil, err
 	}
+	if ordererBlock, err = buf.DecodeRawBytes(false); err != nil {
+		return nil, err
+	}
 	if txOffsets, err = serBlock.extractTxOffsets(); err != nil {
 		return nil, err
 	}
 	block.PreviousBlockHash = previousBlockHash
+	if len(ordererBlock) > 0 {
+		block.OrdererBlock = ordererBlock
+	}
 	block.Transactions = make([][]byte, len(txOffsets)-1)
 	for i := 0; i < len(txOffsets)-1; i++ {
 		block.Transactions[i] = serBlock.blockBytes[txOffsets[i]:txOffsets[i+1]]
@@ -48,6 +48,9 @@ func TestSerBlock2(t *testing.T) {
 	block.PreviousBlockHash = []byte("PreviousBlockHash")
 	block.Transactions = [][]byte{tx1Bytes, tx2Bytes}
 	testSerBlock2(t, block)
+
+	block.OrdererBlock = []byte("OrdererBlock")
+	testSerBlock2(t, block)
 }
 
 func testSerBlock2(t *testing.T, block *Block2) {
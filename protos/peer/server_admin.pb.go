@@ -52,6 +52,42 @@ func (x ServerStatus_StatusCode) String() string {
 }
 func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) { return fileDescriptor12, []int{0, 0} }
 
+type ChaincodeStatus_State int32
+
+const (
+	ChaincodeStatus_UNKNOWN ChaincodeStatus_State = 0
+	// the container is started and the chaincode has not registered yet
+	ChaincodeStatus_LAUNCHING ChaincodeStatus_State = 1
+	ChaincodeStatus_RUNNING   ChaincodeStatus_State = 2
+	// the chaincode has not answered the keepalives, it is going to be restarted
+	ChaincodeStatus_UNHEALTHY ChaincodeStatus_State = 3
+	// the container died, the chaincode is relaunched after a backoff
+	ChaincodeStatus_RESTARTING ChaincodeStatus_State = 4
+	ChaincodeStatus_STOPPED    ChaincodeStatus_State = 5
+)
+
+var ChaincodeStatus_State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "LAUNCHING",
+	2: "RUNNING",
+	3: "UNHEALTHY",
+	4: "RESTARTING",
+	5: "STOPPED",
+}
+var ChaincodeStatus_State_value = map[string]int32{
+	"UNKNOWN":    0,
+	"LAUNCHING":  1,
+	"RUNNING":    2,
+	"UNHEALTHY":  3,
+	"RESTARTING": 4,
+	"STOPPED":    5,
+}
+
+func (x ChaincodeStatus_State) String() string {
+	return proto.EnumName(ChaincodeStatus_State_name, int32(x))
+}
+func (ChaincodeStatus_State) EnumDescriptor() ([]byte, []int) { return fileDescriptor12, []int{4, 0} }
+
 type ServerStatus struct {
 	Status ServerStatus_StatusCode `protobuf:"varint,1,opt,name=status,enum=protos.ServerStatus_StatusCode" json:"status,omitempty"`
 }
@@ -81,11 +117,58 @@ func (m *LogLevelResponse) String() string            { return proto.CompactText
 func (*LogLevelResponse) ProtoMessage()               {}
 func (*LogLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{2} }
 
+type ChaincodeRequest struct {
+	// the chain of the chaincode, the default chain if empty
+	ChainID string `protobuf:"bytes,1,opt,name=chainID" json:"chainID,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ChaincodeRequest) Reset()                    { *m = ChaincodeRequest{} }
+func (m *ChaincodeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeRequest) ProtoMessage()               {}
+func (*ChaincodeRequest) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{3} }
+
+type ChaincodeStatus struct {
+	ChainID string                `protobuf:"bytes,1,opt,name=chainID" json:"chainID,omitempty"`
+	Name    string                `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Version string                `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	State   ChaincodeStatus_State `protobuf:"varint,4,opt,name=state,enum=protos.ChaincodeStatus_State" json:"state,omitempty"`
+	// number of times the chaincode was restarted after its container died
+	Restarts int32 `protobuf:"varint,5,opt,name=restarts" json:"restarts,omitempty"`
+	// unix time, in seconds, of the last transaction executed by the chaincode
+	LastActivity int64 `protobuf:"varint,6,opt,name=lastActivity" json:"lastActivity,omitempty"`
+}
+
+func (m *ChaincodeStatus) Reset()                    { *m = ChaincodeStatus{} }
+func (m *ChaincodeStatus) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeStatus) ProtoMessage()               {}
+func (*ChaincodeStatus) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{4} }
+
+type ChaincodeStatusList struct {
+	Chaincodes []*ChaincodeStatus `protobuf:"bytes,1,rep,name=chaincodes" json:"chaincodes,omitempty"`
+}
+
+func (m *ChaincodeStatusList) Reset()                    { *m = ChaincodeStatusList{} }
+func (m *ChaincodeStatusList) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeStatusList) ProtoMessage()               {}
+func (*ChaincodeStatusList) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{5} }
+
+func (m *ChaincodeStatusList) GetChaincodes() []*ChaincodeStatus {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
 	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
+	proto.RegisterType((*ChaincodeRequest)(nil), "protos.ChaincodeRequest")
+	proto.RegisterType((*ChaincodeStatus)(nil), "protos.ChaincodeStatus")
+	proto.RegisterType((*ChaincodeStatusList)(nil), "protos.ChaincodeStatusList")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
+	proto.RegisterEnum("protos.ChaincodeStatus_State", ChaincodeStatus_State_name, ChaincodeStatus_State_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -105,6 +188,10 @@ type AdminClient interface {
 	StopServer(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ServerStatus, error)
 	GetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	SetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
+	// Return the chaincodes running on the peer, or being restarted.
+	ListChaincodes(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ChaincodeStatusList, error)
+	StopChaincode(ctx context.Context, in *ChaincodeRequest, opts ...grpc.CallOption) (*ChaincodeStatus, error)
+	StartChaincode(ctx context.Context, in *ChaincodeRequest, opts ...grpc.CallOption) (*ChaincodeStatus, error)
 }
 
 type adminClient struct {
@@ -160,6 +247,33 @@ func (c *adminClient) SetModuleLogLevel(ctx context.Context, in *LogLevelRequest
 	return out, nil
 }
 
+func (c *adminClient) ListChaincodes(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ChaincodeStatusList, error) {
+	out := new(ChaincodeStatusList)
+	err := grpc.Invoke(ctx, "/protos.Admin/ListChaincodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) StopChaincode(ctx context.Context, in *ChaincodeRequest, opts ...grpc.CallOption) (*ChaincodeStatus, error) {
+	out := new(ChaincodeStatus)
+	err := grpc.Invoke(ctx, "/protos.Admin/StopChaincode", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) StartChaincode(ctx context.Context, in *ChaincodeRequest, opts ...grpc.CallOption) (*ChaincodeStatus, error) {
+	out := new(ChaincodeStatus)
+	err := grpc.Invoke(ctx, "/protos.Admin/StartChaincode", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
@@ -169,6 +283,10 @@ type AdminServer interface {
 	StopServer(context.Context, *google_protobuf1.Empty) (*ServerStatus, error)
 	GetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	SetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
+	// Return the chaincodes running on the peer, or being restarted.
+	ListChaincodes(context.Context, *google_protobuf1.Empty) (*ChaincodeStatusList, error)
+	StopChaincode(context.Context, *ChaincodeRequest) (*ChaincodeStatus, error)
+	StartChaincode(context.Context, *ChaincodeRequest) (*ChaincodeStatus, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -265,6 +383,60 @@ func _Admin_SetModuleLogLevel_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_ListChaincodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf1.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListChaincodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/ListChaincodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListChaincodes(ctx, req.(*google_protobuf1.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_StopChaincode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChaincodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).StopChaincode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/StopChaincode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).StopChaincode(ctx, req.(*ChaincodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_StartChaincode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChaincodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).StartChaincode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/StartChaincode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).StartChaincode(ctx, req.(*ChaincodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -289,6 +461,18 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetModuleLogLevel",
 			Handler:    _Admin_SetModuleLogLevel_Handler,
 		},
+		{
+			MethodName: "ListChaincodes",
+			Handler:    _Admin_ListChaincodes_Handler,
+		},
+		{
+			MethodName: "StopChaincode",
+			Handler:    _Admin_StopChaincode_Handler,
+		},
+		{
+			MethodName: "StartChaincode",
+			Handler:    _Admin_StartChaincode_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: fileDescriptor12,
@@ -297,29 +481,43 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/server_admin.proto", fileDescriptor12) }
 
 var fileDescriptor12 = []byte{
-	// 384 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x92, 0xcf, 0x4e, 0xe2, 0x50,
-	0x14, 0xc6, 0x29, 0x33, 0x30, 0xd3, 0xc3, 0xfc, 0xe9, 0xdc, 0x4c, 0x06, 0xd2, 0x99, 0x64, 0x26,
-	0x5d, 0x8d, 0x31, 0x69, 0x13, 0x5c, 0xb8, 0x50, 0x17, 0x68, 0x2b, 0x1a, 0xb0, 0x90, 0x16, 0x62,
-	0x74, 0x63, 0x5a, 0x7a, 0x28, 0x24, 0x85, 0x5b, 0xef, 0xbd, 0x25, 0xe1, 0x75, 0x7c, 0x27, 0xdf,
-	0xc7, 0xb4, 0x17, 0xc4, 0xa8, 0x1b, 0x89, 0xab, 0xd3, 0x73, 0xce, 0xf7, 0x7d, 0xe9, 0xfd, 0xe5,
-	0x40, 0x3d, 0x45, 0x64, 0x16, 0x47, 0xb6, 0x40, 0x76, 0x13, 0x44, 0xb3, 0xe9, 0xdc, 0x4c, 0x19,
-	0x15, 0x94, 0x54, 0x8b, 0xc2, 0xf5, 0xdf, 0x31, 0xa5, 0x71, 0x82, 0x56, 0xd1, 0x86, 0xd9, 0xd8,
-	0xc2, 0x59, 0x2a, 0x96, 0x52, 0x64, 0xdc, 0x29, 0xf0, 0xc5, 0x2f, 0xbc, 0xbe, 0x08, 0x44, 0xc6,
-	0xc9, 0x3e, 0x54, 0x79, 0xf1, 0xd5, 0x50, 0xfe, 0x29, 0xff, 0xbf, 0x35, 0xff, 0x4a, 0x21, 0x37,
-	0x9f, 0xaa, 0x4c, 0x59, 0x4e, 0x68, 0x84, 0xde, 0x4a, 0x6e, 0x5c, 0x01, 0x6c, 0xa6, 0xe4, 0x2b,
-	0xa8, 0x43, 0xd7, 0x76, 0x4e, 0xcf, 0x5d, 0xc7, 0xd6, 0x4a, 0xa4, 0x06, 0x9f, 0xfc, 0x41, 0xcb,
-	0x1b, 0x38, 0xb6, 0xa6, 0xc8, 0xa6, 0xd7, 0xef, 0x3b, 0xb6, 0x56, 0x26, 0x00, 0xd5, 0x7e, 0x6b,
-	0xe8, 0x3b, 0xb6, 0xf6, 0x81, 0xa8, 0x50, 0x71, 0x3c, 0xaf, 0xe7, 0x69, 0x1f, 0x73, 0xcd, 0xd0,
-	0xed, 0xb8, 0xbd, 0x4b, 0x57, 0xab, 0x18, 0x1d, 0xf8, 0xde, 0xa5, 0x71, 0x17, 0x17, 0x98, 0x78,
-	0x78, 0x9b, 0x21, 0x17, 0xe4, 0x0f, 0xa8, 0x09, 0x8d, 0x2f, 0x68, 0x94, 0x25, 0x58, 0xfc, 0xa9,
-	0xea, 0x6d, 0x06, 0x44, 0x87, 0xcf, 0xc9, 0xca, 0xd0, 0x28, 0x17, 0xcb, 0xc7, 0xde, 0xe8, 0x82,
-	0xb6, 0x09, 0xe3, 0x29, 0x9d, 0x73, 0xdc, 0x3e, 0xad, 0x79, 0x5f, 0x86, 0x4a, 0x2b, 0x87, 0x4e,
-	0x0e, 0x40, 0x6d, 0xa3, 0x58, 0x51, 0xfc, 0x65, 0x4a, 0xe8, 0xe6, 0x1a, 0xba, 0xe9, 0xe4, 0xd0,
-	0xf5, 0x9f, 0xaf, 0xd1, 0x34, 0x4a, 0xe4, 0x08, 0x6a, 0xbe, 0x08, 0x98, 0x90, 0xe3, 0x37, 0xdb,
-	0x0f, 0x73, 0xf6, 0x34, 0xdd, 0xd2, 0x7d, 0x06, 0x3f, 0xda, 0x28, 0xe4, 0x63, 0xd7, 0x68, 0x48,
-	0x7d, 0x2d, 0x7e, 0x46, 0x5e, 0x6f, 0xbc, 0x5c, 0x48, 0x8a, 0x32, 0xc9, 0x7f, 0x97, 0xa4, 0xe3,
-	0xdd, 0xeb, 0x9d, 0x78, 0x2a, 0x26, 0x59, 0x68, 0x8e, 0xe8, 0xcc, 0x9a, 0x2c, 0x53, 0x64, 0x09,
-	0x46, 0x31, 0x32, 0x6b, 0x1c, 0x84, 0x6c, 0x3a, 0x92, 0xd7, 0xcc, 0xad, 0xfc, 0xf8, 0x43, 0x79,
-	0xe9, 0x7b, 0x0f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xa2, 0xc9, 0xe8, 0x56, 0x0b, 0x03, 0x00, 0x00,
+	// 606 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x54, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0xed, 0x57, 0x3a, 0x7a, 0xb7, 0x75, 0xc1, 0x20, 0x16, 0x75, 0x20, 0xa6, 0x3c, 0x15, 0x21,
+	0xa5, 0xd2, 0xf6, 0xb0, 0x07, 0x40, 0xa2, 0x2c, 0xa1, 0x9d, 0x16, 0xb2, 0xc9, 0x59, 0x85, 0x86,
+	0x90, 0x50, 0x9a, 0x7a, 0x59, 0xa4, 0xb4, 0x0e, 0xb1, 0x5b, 0x69, 0x7f, 0x87, 0x57, 0xf8, 0x91,
+	0xc8, 0x76, 0x92, 0x95, 0x8e, 0x3e, 0x8c, 0xf1, 0x14, 0xdf, 0xeb, 0x73, 0x4f, 0xec, 0x7b, 0xee,
+	0x31, 0xec, 0xa6, 0x84, 0x64, 0x3d, 0x46, 0xb2, 0x05, 0xc9, 0xbe, 0x05, 0x93, 0x69, 0x3c, 0xb3,
+	0xd2, 0x8c, 0x72, 0x8a, 0x9a, 0xf2, 0xc3, 0x3a, 0x7b, 0x11, 0xa5, 0x51, 0x42, 0x7a, 0x32, 0x1c,
+	0xcf, 0xaf, 0x7a, 0x64, 0x9a, 0xf2, 0x1b, 0x05, 0x32, 0x7f, 0x54, 0x61, 0xcb, 0x97, 0xb5, 0x3e,
+	0x0f, 0xf8, 0x9c, 0xa1, 0x23, 0x68, 0x32, 0xb9, 0x32, 0xaa, 0xfb, 0xd5, 0x6e, 0xfb, 0xe0, 0xa5,
+	0x02, 0x32, 0x6b, 0x19, 0x65, 0xa9, 0xcf, 0x31, 0x9d, 0x10, 0x9c, 0xc3, 0xcd, 0x4b, 0x80, 0xdb,
+	0x2c, 0xda, 0x86, 0xd6, 0xc8, 0xb3, 0x9d, 0x8f, 0x27, 0x9e, 0x63, 0xeb, 0x15, 0xb4, 0x09, 0x1b,
+	0xfe, 0x45, 0x1f, 0x5f, 0x38, 0xb6, 0x5e, 0x55, 0xc1, 0xd9, 0xf9, 0xb9, 0x63, 0xeb, 0x35, 0x04,
+	0xd0, 0x3c, 0xef, 0x8f, 0x7c, 0xc7, 0xd6, 0xeb, 0xa8, 0x05, 0x9a, 0x83, 0xf1, 0x19, 0xd6, 0x1b,
+	0x02, 0x33, 0xf2, 0x4e, 0xbd, 0xb3, 0xcf, 0x9e, 0xae, 0x99, 0xa7, 0xb0, 0xe3, 0xd2, 0xc8, 0x25,
+	0x0b, 0x92, 0x60, 0xf2, 0x7d, 0x4e, 0x18, 0x47, 0xcf, 0xa1, 0x95, 0xd0, 0xe8, 0x13, 0x9d, 0xcc,
+	0x13, 0x22, 0x4f, 0xda, 0xc2, 0xb7, 0x09, 0xd4, 0x81, 0x47, 0x49, 0x5e, 0x60, 0xd4, 0xe4, 0x66,
+	0x19, 0x9b, 0x2e, 0xe8, 0xb7, 0x64, 0x2c, 0xa5, 0x33, 0x46, 0x1e, 0xc0, 0xf6, 0x1e, 0xf4, 0xe3,
+	0xeb, 0x20, 0x9e, 0x85, 0xa2, 0x15, 0xf9, 0xd9, 0x0c, 0xd8, 0x08, 0x45, 0xee, 0xc4, 0xce, 0xb9,
+	0x8a, 0x10, 0x21, 0x68, 0xcc, 0x82, 0x29, 0xc9, 0x59, 0xe4, 0xda, 0xfc, 0x55, 0x83, 0x9d, 0x92,
+	0x22, 0x17, 0xe1, 0x5e, 0x0c, 0x02, 0xbd, 0x20, 0x19, 0x8b, 0xe9, 0xcc, 0xa8, 0x2b, 0x74, 0x1e,
+	0xa2, 0x43, 0xd0, 0x84, 0x3a, 0xc4, 0x68, 0x48, 0x2d, 0x5f, 0x14, 0x5a, 0xae, 0xfc, 0x4f, 0xca,
+	0x49, 0xb0, 0xc2, 0x8a, 0xeb, 0x66, 0x84, 0xf1, 0x20, 0xe3, 0xcc, 0xd0, 0xf6, 0xab, 0x5d, 0x0d,
+	0x97, 0x31, 0x32, 0x61, 0x2b, 0x09, 0x18, 0xef, 0x87, 0x3c, 0x5e, 0xc4, 0xfc, 0xc6, 0x68, 0xee,
+	0x57, 0xbb, 0x75, 0xfc, 0x47, 0xce, 0xfc, 0x0a, 0x9a, 0xe4, 0x5b, 0xd6, 0xb0, 0x22, 0x06, 0xc2,
+	0xed, 0x8f, 0xbc, 0xe3, 0xe1, 0x89, 0x37, 0x50, 0x33, 0x80, 0x47, 0x9e, 0x27, 0x82, 0x9a, 0x1a,
+	0x96, 0xa1, 0xd3, 0x77, 0x2f, 0x86, 0x97, 0x7a, 0x1d, 0xb5, 0x01, 0xb0, 0x23, 0xc7, 0x45, 0x6c,
+	0x37, 0x96, 0xe7, 0x45, 0x33, 0x3d, 0x78, 0xb2, 0x72, 0x7a, 0x37, 0x66, 0x1c, 0x1d, 0x01, 0x84,
+	0x45, 0x5a, 0x8c, 0x6e, 0xbd, 0xbb, 0x79, 0xb0, 0xbb, 0xe6, 0xba, 0x78, 0x09, 0x7a, 0xf0, 0xb3,
+	0x01, 0x5a, 0x5f, 0xb8, 0x06, 0xbd, 0x81, 0xd6, 0x80, 0xf0, 0x5c, 0x81, 0x67, 0x96, 0x72, 0x8d,
+	0x55, 0xb8, 0xc6, 0x72, 0x84, 0x6b, 0x3a, 0x4f, 0xff, 0x66, 0x07, 0xb3, 0x82, 0xde, 0xc1, 0xa6,
+	0x2f, 0x5a, 0xa4, 0xd2, 0xf7, 0x2e, 0x7f, 0x2b, 0xcc, 0x43, 0xd3, 0x7f, 0xac, 0x1e, 0xc2, 0xe3,
+	0x01, 0xe1, 0x6a, 0x5a, 0x8b, 0xd9, 0x46, 0xe5, 0xed, 0x57, 0xac, 0xd3, 0x31, 0xee, 0x6e, 0x28,
+	0x1b, 0x28, 0x26, 0xff, 0xff, 0x30, 0x0d, 0xa0, 0x2d, 0x84, 0x29, 0x5b, 0xbf, 0xbe, 0xa5, 0x7b,
+	0x6b, 0x64, 0x12, 0xe5, 0x66, 0x05, 0xd9, 0xb0, 0x2d, 0x5a, 0x53, 0x6e, 0x22, 0xe3, 0x0e, 0xbe,
+	0x38, 0xcf, 0x3a, 0xc1, 0xcd, 0x0a, 0x72, 0xa0, 0x2d, 0xf5, 0x79, 0x18, 0xcd, 0x87, 0xd7, 0x5f,
+	0x5e, 0x45, 0x31, 0xbf, 0x9e, 0x8f, 0xad, 0x90, 0x4e, 0x7b, 0xd7, 0x37, 0x29, 0xc9, 0x12, 0x32,
+	0x89, 0x48, 0xd6, 0xbb, 0x0a, 0xc6, 0x59, 0x1c, 0xaa, 0x47, 0x96, 0xf5, 0xc4, 0x9b, 0x3c, 0x56,
+	0x0f, 0xf0, 0xe1, 0xef, 0x01, 0x00, 0x37, 0x63, 0xe9, 0x5b, 0xa2, 0x05, 0x00, 0x00,
 }
@@ -197,6 +197,18 @@ func GetEnvelope(bytes []byte) (*common.Envelope, error) {
 
 // CreateChaincodeProposal creates a proposal from given input
 func CreateChaincodeProposal(txid string, cis *peer.ChaincodeInvocationSpec, creator []byte) (*peer.Proposal, error) {
+	return CreateChaincodeProposalWithTransient(txid, cis, creator, nil)
+}
+
+// CreateChaincodeProposalWithTransient creates a proposal from given input. The
+// transient map is passed to the chaincode but never included in the transaction
+func CreateChaincodeProposalWithTransient(txid string, cis *peer.ChaincodeInvocationSpec, creator []byte, transientMap map[string][]byte) (*peer.Proposal, error) {
+	return CreateChaincodeProposalForChain(txid, "", cis, creator, transientMap)
+}
+
+// CreateChaincodeProposalForChain creates a proposal from given input for the given chain. The
+// proposal is for the default chain of the peer if chainID is empty
+func CreateChaincodeProposalForChain(txid string, chainID string, cis *peer.ChaincodeInvocationSpec, creator []byte, transientMap map[string][]byte) (*peer.Proposal, error) {
 	ccHdrExt := &peer.ChaincodeHeaderExtension{ChaincodeID: cis.ChaincodeSpec.ChaincodeID}
 	ccHdrExtBytes, err := proto.Marshal(ccHdrExt)
 	if err != nil {
@@ -208,7 +220,7 @@ func CreateChaincodeProposal(txid string, cis *peer.ChaincodeInvocationSpec, cre
 		return nil, err
 	}
 
-	ccPropPayload := &peer.ChaincodeProposalPayload{Input: cisBytes}
+	ccPropPayload := &peer.ChaincodeProposalPayload{Input: cisBytes, TransientMap: transientMap}
 	ccPropPayloadBytes, err := proto.Marshal(ccPropPayload)
 	if err != nil {
 		return nil, err
@@ -221,6 +233,7 @@ func CreateChaincodeProposal(txid string, cis *peer.ChaincodeInvocationSpec, cre
 	}
 
 	hdr := &common.Header{ChainHeader: &common.ChainHeader{Type: int32(common.HeaderType_ENDORSER_TRANSACTION),
+		ChainID:   []byte(chainID),
 		TxID:      txid,
 		Extension: ccHdrExtBytes},
 		SignatureHeader: &common.SignatureHeader{Nonce: nonce, Creator: creator}}
@@ -233,6 +246,23 @@ func CreateChaincodeProposal(txid string, cis *peer.ChaincodeInvocationSpec, cre
 	return &peer.Proposal{Header: hdrBytes, Payload: ccPropPayloadBytes}, nil
 }
 
+// CreateProposalWithEndorsement returns a copy of prop carrying in its extension the ChaincodeAction
+// endorsed by resp, the ProposalResponse of the peer that simulated it; an endorser verifies and
+// endorses that action instead of simulating the proposal
+func CreateProposalWithEndorsement(prop *peer.Proposal, resp *peer.ProposalResponse) (*peer.Proposal, error) {
+	prp, err := GetProposalResponsePayload(resp.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	respBytes, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &peer.Proposal{Header: prop.Header, Payload: prop.Payload, Extension: prp.Extension, SimulationResponse: respBytes}, nil
+}
+
 // GetBytesProposalResponsePayload gets proposal response payload
 func GetBytesProposalResponsePayload(hash []byte, result []byte, event []byte) ([]byte, error) {
 	cAct := &peer.ChaincodeAction{Events: event, Results: result}
@@ -378,18 +408,65 @@ func CreateProposalFromCIS(txid string, cis *peer.ChaincodeInvocationSpec, creat
 
 // CreateProposalFromCDS returns a proposal given a serialized identity and a ChaincodeDeploymentSpec
 func CreateProposalFromCDS(txid string, cds *peer.ChaincodeDeploymentSpec, creator []byte) (*peer.Proposal, error) {
+	return CreateProposalFromCDSAndACL(txid, cds, nil, creator)
+}
+
+// CreateProposalFromCDSAndACL returns a proposal given a serialized identity, a ChaincodeDeploymentSpec
+// and the ACL of the chaincode. The chaincode has no ACL if acl is nil
+func CreateProposalFromCDSAndACL(txid string, cds *peer.ChaincodeDeploymentSpec, acl *peer.ChaincodeACL, creator []byte) (*peer.Proposal, error) {
+	return CreateDeployProposalForChain(txid, "", cds, acl, creator)
+}
+
+// CreateDeployProposalForChain returns a proposal given a serialized identity to deploy a chaincode
+// with the given ACL on the given chain. The chain is the default chain of the peer if chainID is empty
+func CreateDeployProposalForChain(txid string, chainID string, cds *peer.ChaincodeDeploymentSpec, acl *peer.ChaincodeACL, creator []byte) (*peer.Proposal, error) {
 	b, err := proto.Marshal(cds)
 	if err != nil {
 		return nil, err
 	}
 
+	deployChain := chainID
+	if deployChain == "" {
+		deployChain = "default"
+	}
+	args := [][]byte{[]byte("deploy"), []byte(deployChain), b}
+	if acl != nil {
+		aclBytes, err := proto.Marshal(acl)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, aclBytes)
+	}
+
 	//wrap the deployment in an invocation spec to lccc...
 	lcccSpec := &peer.ChaincodeInvocationSpec{
 		ChaincodeSpec: &peer.ChaincodeSpec{
 			Type:        peer.ChaincodeSpec_GOLANG,
 			ChaincodeID: &peer.ChaincodeID{Name: "lccc"},
-			CtorMsg:     &peer.ChaincodeInput{Args: [][]byte{[]byte("deploy"), []byte("default"), b}}}}
+			CtorMsg:     &peer.ChaincodeInput{Args: args}}}
 
 	//...and get the proposal for it
-	return CreateProposalFromCIS(txid, lcccSpec, creator)
+	return CreateChaincodeProposalForChain(txid, chainID, lcccSpec, creator, nil)
+}
+
+// CreateSetACLProposal returns a proposal given a serialized identity to replace the ACL of a chaincode
+// on the given chain. The chain is the default chain of the peer if chainID is empty
+func CreateSetACLProposal(txid string, chainID string, chaincodeName string, acl *peer.ChaincodeACL, creator []byte) (*peer.Proposal, error) {
+	aclBytes, err := proto.Marshal(acl)
+	if err != nil {
+		return nil, err
+	}
+
+	aclChain := chainID
+	if aclChain == "" {
+		aclChain = "default"
+	}
+
+	lcccSpec := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_GOLANG,
+			ChaincodeID: &peer.ChaincodeID{Name: "lccc"},
+			CtorMsg:     &peer.ChaincodeInput{Args: [][]byte{[]byte("setacl"), []byte(aclChain), []byte(chaincodeName), aclBytes}}}}
+
+	return CreateChaincodeProposalForChain(txid, chainID, lcccSpec, creator, nil)
 }
@@ -289,6 +289,102 @@ func TestEnvelope(t *testing.T) {
 	}
 }
 
+func TestTransientMap(t *testing.T) {
+	transient := map[string][]byte{"key1": []byte("secret value 1"), "key2": []byte("secret value 2")}
+	prop, err := CreateChaincodeProposalWithTransient(util.GenerateUUID(), createCIS(), signerSerialized, transient)
+	if err != nil {
+		t.Fatalf("Could not create chaincode proposal, err %s\n", err)
+	}
+
+	cpp, err := GetChaincodeProposalPayload(prop.Payload)
+	if err != nil {
+		t.Fatalf("Could not unmarshal the chaincode proposal payload, err %s\n", err)
+	}
+	assert.Equal(t, transient, cpp.TransientMap)
+
+	presp, err := CreateProposalResponse(prop.Header, prop.Payload, []byte("res"), nil, nil, signer)
+	if err != nil {
+		t.Fatalf("Could not create proposal response, err %s\n", err)
+	}
+	tx, err := CreateSignedTx(prop, signer, presp)
+	if err != nil {
+		t.Fatalf("Could not create signed tx, err %s\n", err)
+	}
+
+	// the transient map does not reach the transaction
+	envBytes, err := GetBytesEnvelope(tx)
+	if err != nil {
+		t.Fatalf("Could not marshal envelope, err %s\n", err)
+	}
+	for _, v := range transient {
+		assert.False(t, bytes.Contains(envBytes, v), "The transaction must not contain the transient map")
+	}
+
+	// the proposal hash computed from the transaction matches the endorsed one
+	txpayl, _ := GetPayload(tx)
+	tx2, _ := GetTransaction(txpayl.Data)
+	cap, _ := GetChaincodeActionPayload(tx2.Actions[0].Payload)
+	prp, _ := GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+	pHash, err := GetProposalHash2(prop.Header, cap.ChaincodeProposalPayload)
+	if err != nil {
+		t.Fatalf("Could not compute the proposal hash, err %s\n", err)
+	}
+	assert.Equal(t, prp.ProposalHash, pHash)
+
+	// the proposal hash binds the transient map
+	cpp.TransientMap = map[string][]byte{"key1": []byte("secret value 1"), "key2": []byte("another value")}
+	tamperedPayload, _ := GetBytesChaincodeProposalPayload(cpp)
+	tamperedHash, err := GetProposalHash1(prop.Header, tamperedPayload, nil)
+	if err != nil {
+		t.Fatalf("Could not compute the proposal hash, err %s\n", err)
+	}
+	assert.NotEqual(t, prp.ProposalHash, tamperedHash)
+}
+
+func TestProposalForChain(t *testing.T) {
+	prop, err := CreateChaincodeProposalForChain(util.GenerateUUID(), "chain1", createCIS(), []byte("creator"), nil)
+	if err != nil {
+		t.Fatalf("Could not create chaincode proposal, err %s\n", err)
+	}
+	hdr, err := GetHeader(prop.Header)
+	if err != nil {
+		t.Fatalf("Could not unmarshal the header, err %s\n", err)
+	}
+	assert.Equal(t, []byte("chain1"), hdr.ChainHeader.ChainID)
+
+	// the chaincode is deployed on the chain of the proposal
+	cds := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: createCIS().ChaincodeSpec}
+	prop, err = CreateDeployProposalForChain(util.GenerateUUID(), "chain1", cds, nil, []byte("creator"))
+	if err != nil {
+		t.Fatalf("Could not create deploy proposal, err %s\n", err)
+	}
+	hdr, _ = GetHeader(prop.Header)
+	assert.Equal(t, []byte("chain1"), hdr.ChainHeader.ChainID)
+	cis, err := GetChaincodeInvocationSpec(prop)
+	if err != nil {
+		t.Fatalf("Could not get the invocation spec, err %s\n", err)
+	}
+	assert.Equal(t, "chain1", string(cis.ChaincodeSpec.CtorMsg.Args[1]))
+
+	// without a chain, the proposal is for the default chain
+	prop, _ = CreateProposalFromCDS(util.GenerateUUID(), cds, []byte("creator"))
+	hdr, _ = GetHeader(prop.Header)
+	assert.Empty(t, hdr.ChainHeader.ChainID)
+	cis, _ = GetChaincodeInvocationSpec(prop)
+	assert.Equal(t, "default", string(cis.ChaincodeSpec.CtorMsg.Args[1]))
+
+	// the ACL is replaced on the chain of the proposal
+	prop, err = CreateSetACLProposal(util.GenerateUUID(), "chain1", "mycc", &pb.ChaincodeACL{}, []byte("creator"))
+	if err != nil {
+		t.Fatalf("Could not create setacl proposal, err %s\n", err)
+	}
+	hdr, _ = GetHeader(prop.Header)
+	assert.Equal(t, []byte("chain1"), hdr.ChainHeader.ChainID)
+	cis, _ = GetChaincodeInvocationSpec(prop)
+	assert.Equal(t, "chain1", string(cis.ChaincodeSpec.CtorMsg.Args[1]))
+	assert.Equal(t, "mycc", string(cis.ChaincodeSpec.CtorMsg.Args[2]))
+}
+
 var signer msp.SigningIdentity
 var signerSerialized []byte
 
@@ -17,8 +17,10 @@ limitations under the License.
 package utils
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"sort"
 
 	"bytes"
 
@@ -73,7 +75,8 @@ func GetEnvelopeFromBlock(data []byte) (*common.Envelope, error) {
 
 // CreateSignedTx assembles an Envelope message from proposal, endorsements and a signer.
 // This function should be called by a client when it has collected enough endorsements
-// for a proposal to create a transaction and submit it to peers for ordering
+// for a proposal to create a transaction and submit it to peers for ordering.
+// The transient map of the proposal payload is never included in the transaction
 func CreateSignedTx(proposal *peer.Proposal, signer msp.SigningIdentity, resps ...*peer.ProposalResponse) (*common.Envelope, error) {
 	// the original header
 	hdr, err := GetHeader(proposal.Header)
@@ -267,8 +270,8 @@ func GetBytesProposalPayloadForTx(payload *peer.ChaincodeProposalPayload, visibi
 		return nil, fmt.Errorf("Nil arguments")
 	}
 
-	// strip the transient bytes off the payload - this needs to be done no matter the visibility mode
-	cppNoTransient := &peer.ChaincodeProposalPayload{Input: payload.Input, Transient: nil}
+	// strip the transient map off the payload - this needs to be done no matter the visibility mode
+	cppNoTransient := &peer.ChaincodeProposalPayload{Input: payload.Input, TransientMap: nil}
 	cppBytes, err := GetBytesChaincodeProposalPayload(cppNoTransient)
 	if err != nil {
 		return nil, errors.New("Failure while marshalling the ChaincodeProposalPayload!")
@@ -280,11 +283,39 @@ func GetBytesProposalPayloadForTx(payload *peer.ChaincodeProposalPayload, visibi
 
 	// TODO: use bccsp interfaces and providers as soon as they are ready!
 	hash := primitives.GetDefaultHash()()
-	hash.Write(cppBytes) // hash the serialized ChaincodeProposalPayload object (stripped of the transient bytes)
+	hash.Write(cppBytes) // hash the serialized ChaincodeProposalPayload object (stripped of the transient map)
+	if len(payload.TransientMap) > 0 {
+		// bind the transient map to the proposal through its digest only
+		hash.Write(GetTransientMapHash(payload.TransientMap))
+	}
 
 	return hash.Sum(nil), nil
 }
 
+// GetTransientMapHash returns the digest of a transient map. Entries are
+// hashed in key order, so the digest does not depend on the map iteration order
+func GetTransientMapHash(transientMap map[string][]byte) []byte {
+	keys := make([]string, 0, len(transientMap))
+	for k := range transientMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// TODO: use bccsp interfaces and providers as soon as they are ready!
+	hash := primitives.GetDefaultHash()()
+	lenBytes := make([]byte, 8)
+	for _, k := range keys {
+		// length-prefix keys and values so that entries cannot be confused
+		binary.BigEndian.PutUint64(lenBytes, uint64(len(k)))
+		hash.Write(lenBytes)
+		hash.Write([]byte(k))
+		binary.BigEndian.PutUint64(lenBytes, uint64(len(transientMap[k])))
+		hash.Write(lenBytes)
+		hash.Write(transientMap[k])
+	}
+	return hash.Sum(nil)
+}
+
 // GetProposalHash2 gets the proposal hash - this version
 // is called by the committer where the visibility policy
 // has already been enforced and so we already get what
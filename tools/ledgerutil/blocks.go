@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/core/ledger/blkstorage"
+	"github.com/hyperledger/fabric/core/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt"
+	putils "github.com/hyperledger/fabric/protos/utils"
+)
+
+// errStopScan is returned by the scan callbacks to stop scanning the block files early
+var errStopScan = errors.New("stop scan")
+
+func blockStorageConf(conf *kvledger.Conf) *fsblkstorage.Conf {
+	return fsblkstorage.NewConf(conf.BlockStorageDir(), 0)
+}
+
+func printSummary(out io.Writer, summary *fsblkstorage.BlockfilesSummary) {
+	fmt.Fprintf(out, "blocks=[%d], lastFile=[%d], lastFileSize=[%d]\n",
+		summary.NumBlocks, summary.LastFileNum, summary.LastFileSize)
+	if summary.PartialBlock {
+		fmt.Fprintln(out, "The last block file ends with a partially written block")
+	}
+}
+
+func verifyCmd(args []string, out io.Writer) int {
+	flagSet, ledgerDir := newFlagSet("verify")
+	conf, code := parseFlags(flagSet, ledgerDir, args)
+	if code != exitOK {
+		return code
+	}
+	report, err := fsblkstorage.VerifyHashChain(blockStorageConf(conf))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error while reading the block files: %s\n", err)
+		return exitFailed
+	}
+	printSummary(out, report.Summary)
+	for _, num := range report.Unlinked {
+		fmt.Fprintf(out, "Block [%d] does not record the hash of the previous block\n", num)
+	}
+	for _, num := range report.Broken {
+		fmt.Fprintf(out, "Block [%d] does not match the hash of the previous block\n", num)
+	}
+	if len(report.Broken) > 0 {
+		fmt.Fprintf(out, "Hash chain broken at %d block(s)\n", len(report.Broken))
+		return exitFailed
+	}
+	fmt.Fprintln(out, "Hash chain verified")
+	return exitOK
+}
+
+// blockJSON is the JSON representation of a block dumped by the `blocks` command
+type blockJSON struct {
+	Number       uint64   `json:"number"`
+	File         int      `json:"file"`
+	Offset       int64    `json:"offset"`
+	Hash         string   `json:"hash"`
+	PreviousHash string   `json:"previousHash"`
+	Transactions []txJSON `json:"transactions"`
+}
+
+type txJSON struct {
+	TxID      string       `json:"txID,omitempty"`
+	ChainID   string       `json:"chainID,omitempty"`
+	Type      int32        `json:"type"`
+	Timestamp string       `json:"timestamp,omitempty"`
+	Creator   []byte       `json:"creator,omitempty"`
+	Actions   []actionJSON `json:"actions,omitempty"`
+	// Error is set if the transaction could not be decoded
+	Error string `json:"error,omitempty"`
+}
+
+type actionJSON struct {
+	Endorsements int                    `json:"endorsements"`
+	RWSet        *txmgmt.TxReadWriteSet `json:"rwset,omitempty"`
+	Events       []byte                 `json:"events,omitempty"`
+}
+
+func decodeTx(txBytes []byte) txJSON {
+	tx := txJSON{}
+	env, err := putils.GetEnvelope(txBytes)
+	if err != nil {
+		tx.Error = err.Error()
+		return tx
+	}
+	payload, err := putils.GetPayload(env)
+	if err != nil {
+		tx.Error = err.Error()
+		return tx
+	}
+	if payload.Header != nil && payload.Header.ChainHeader != nil {
+		chainHeader := payload.Header.ChainHeader
+		tx.TxID = chainHeader.TxID
+		tx.ChainID = string(chainHeader.ChainID)
+		tx.Type = chainHeader.Type
+		if ts, err := ptypes.Timestamp(chainHeader.Timestamp); err == nil {
+			tx.Timestamp = ts.Format(time.RFC3339Nano)
+		}
+	}
+	if payload.Header != nil && payload.Header.SignatureHeader != nil {
+		tx.Creator = payload.Header.SignatureHeader.Creator
+	}
+	transaction, err := putils.GetTransaction(payload.Data)
+	if err != nil {
+		tx.Error = err.Error()
+		return tx
+	}
+	for _, action := range transaction.Actions {
+		ccActionPayload, ccAction, err := putils.GetPayloads(action)
+		if err != nil {
+			tx.Error = err.Error()
+			return tx
+		}
+		a := actionJSON{}
+		if ccActionPayload != nil && ccActionPayload.Action != nil {
+			a.Endorsements = len(ccActionPayload.Action.Endorsements)
+		}
+		if ccAction != nil {
+			a.RWSet = &txmgmt.TxReadWriteSet{}
+			if err = a.RWSet.Unmarshal(ccAction.Results); err != nil {
+				tx.Error = err.Error()
+				return tx
+			}
+			a.Events = ccAction.Events
+		}
+		tx.Actions = append(tx.Actions, a)
+	}
+	return tx
+}
+
+func blocksCmd(args []string, out io.Writer) int {
+	flagSet, ledgerDir := newFlagSet("blocks")
+	start := flagSet.Uint64("start", 1, "number of the first block to dump")
+	end := flagSet.Uint64("end", 0, "number of the last block to dump (0 for the last block of the ledger)")
+	conf, code := parseFlags(flagSet, ledgerDir, args)
+	if code != exitOK {
+		return code
+	}
+
+	encoder := json.NewEncoder(out)
+	_, err := fsblkstorage.ScanBlockfiles(blockStorageConf(conf), func(entry *fsblkstorage.BlockfileEntry) error {
+		if entry.Number < *start {
+			return nil
+		}
+		if *end != 0 && entry.Number > *end {
+			return errStopScan
+		}
+		block := &blockJSON{
+			Number:       entry.Number,
+			File:         entry.FileNum,
+			Offset:       entry.Offset,
+			Hash:         hex.EncodeToString(entry.Hash),
+			PreviousHash: hex.EncodeToString(entry.Block.PreviousBlockHash),
+			Transactions: []txJSON{},
+		}
+		for _, txBytes := range entry.Block.Transactions {
+			block.Transactions = append(block.Transactions, decodeTx(txBytes))
+		}
+		return encoder.Encode(block)
+	})
+	if err != nil && err != errStopScan {
+		fmt.Fprintf(os.Stderr, "Error while dumping the blocks: %s\n", err)
+		return exitFailed
+	}
+	return exitOK
+}
+
+func rebuildCmd(args []string, out io.Writer) int {
+	flagSet, ledgerDir := newFlagSet("rebuild")
+	conf, code := parseFlags(flagSet, ledgerDir, args)
+	if code != exitOK {
+		return code
+	}
+	// index everything, as the ledger does
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockHash,
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrTxID,
+	}}
+	summary, err := fsblkstorage.RebuildIndex(blockStorageConf(conf), indexConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error while rebuilding the index: %s\n", err)
+		return exitFailed
+	}
+	printSummary(out, summary)
+	fmt.Fprintln(out, "Block index and checkpoint info rebuilt")
+	return exitOK
+}
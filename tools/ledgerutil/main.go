@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+)
+
+// defaultMaxValueSize is the size above which a state value is reported as oversized
+const defaultMaxValueSize = 1024 * 1024
+
+// exit codes
+const (
+	exitOK = iota
+	exitFailed
+	exitUsage
+	exitNoLedger
+)
+
+// command is a sub-command of the utility. It returns the exit code of the process
+type command struct {
+	description string
+	run         func(args []string, out io.Writer) int
+}
+
+var commands = map[string]*command{
+	"verify":    {"verify the hash chain across all the block files", verifyCmd},
+	"blocks":    {"dump blocks and their transactions as JSON", blocksCmd},
+	"state":     {"list the keys of a namespace in the state database", stateCmd},
+	"oversized": {"report the values of a namespace larger than a given size", oversizedCmd},
+	"rebuild":   {"rebuild the block index and the checkpoint info from the block files", rebuildCmd},
+}
+
+var commandOrder = []string{"verify", "blocks", "state", "oversized", "rebuild"}
+
+func usage(out io.Writer) {
+	fmt.Fprintf(out, "Usage: %s <command> -ledgerDir <path to the ledger of a stopped peer> [options]\n\nCommands:\n", os.Args[0])
+	for _, name := range commandOrder {
+		fmt.Fprintf(out, "  %-10s %s\n", name, commands[name].description)
+	}
+	fmt.Fprintf(out, "\nRun '%s <command> -h' for the options of a command\n", os.Args[0])
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(exitUsage)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage(os.Stderr)
+		os.Exit(exitUsage)
+	}
+	os.Exit(cmd.run(os.Args[2:], os.Stdout))
+}
+
+// newFlagSet creates the flag set of a command, with the `-ledgerDir` flag common to all the commands
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	ledgerDir := flagSet.String("ledgerDir", "", "directory of the ledger, i.e., <peer.fileSystemPath>/ledger/<ledger name>")
+	return flagSet, ledgerDir
+}
+
+// parseFlags parses the arguments of a command and returns the configuration of the ledger.
+// It returns a non-zero exit code if the arguments are invalid or the ledger does not exist
+func parseFlags(flagSet *flag.FlagSet, ledgerDir *string, args []string) (*kvledger.Conf, int) {
+	if err := flagSet.Parse(args); err != nil {
+		return nil, exitUsage
+	}
+	if *ledgerDir == "" {
+		fmt.Fprintln(os.Stderr, "-ledgerDir is required")
+		flagSet.PrintDefaults()
+		return nil, exitUsage
+	}
+	if _, err := os.Stat(*ledgerDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot access the ledger directory: %s\n", err)
+		return nil, exitNoLedger
+	}
+	return kvledger.NewConf(*ledgerDir, 0), exitOK
+}
@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/testutil"
+)
+
+const testLedgerDir = "/tmp/tests/tools/ledgerutil"
+
+// createTestLedger commits two blocks to a new ledger and closes it
+func createTestLedger(t *testing.T) {
+	os.RemoveAll(testLedgerDir)
+	ledger, err := kvledger.NewKVLedger(kvledger.NewConf(testLedgerDir, 0))
+	testutil.AssertNoError(t, err, "Error while creating the ledger")
+	defer ledger.Close()
+
+	values := [][]byte{[]byte("value1"), make([]byte, 2048)}
+	for _, value := range values {
+		simulator, _ := ledger.NewTxSimulator()
+		simulator.SetState("ns1", "key1", []byte("small"))
+		simulator.SetState("ns1", "key2", value)
+		simulator.SetState("ns10", "key1", []byte("other namespace"))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		block := testutil.ConstructBlockForSimulationResults(t, [][]byte{simRes}, false)
+		ledger.RemoveInvalidTransactionsAndPrepare(block)
+		testutil.AssertNoError(t, ledger.Commit(), "Error while committing a block")
+	}
+}
+
+func runCmd(t *testing.T, name string, args ...string) (int, string) {
+	out := &bytes.Buffer{}
+	code := commands[name].run(append([]string{"-ledgerDir", testLedgerDir}, args...), out)
+	return code, out.String()
+}
+
+func TestVerifyCmd(t *testing.T) {
+	createTestLedger(t)
+	defer os.RemoveAll(testLedgerDir)
+	code, out := runCmd(t, "verify")
+	testutil.AssertEquals(t, code, exitOK)
+	if !strings.HasPrefix(out, "blocks=[2], lastFile=[0]") || !strings.Contains(out, "Hash chain verified") {
+		t.Fatalf("Unexpected output: %s", out)
+	}
+}
+
+func TestBlocksCmd(t *testing.T) {
+	createTestLedger(t)
+	defer os.RemoveAll(testLedgerDir)
+	code, out := runCmd(t, "blocks", "-start", "2")
+	testutil.AssertEquals(t, code, exitOK)
+
+	block := &blockJSON{}
+	decoder := json.NewDecoder(strings.NewReader(out))
+	testutil.AssertNoError(t, decoder.Decode(block), "Error while decoding the dumped block")
+	testutil.AssertEquals(t, block.Number, uint64(2))
+	testutil.AssertEquals(t, len(block.Transactions), 1)
+	tx := block.Transactions[0]
+	testutil.AssertEquals(t, tx.Error, "")
+	testutil.AssertEquals(t, len(tx.Actions), 1)
+	testutil.AssertEquals(t, len(tx.Actions[0].RWSet.NsRWs), 2)
+	testutil.AssertEquals(t, decoder.More(), false)
+}
+
+func TestStateCmds(t *testing.T) {
+	createTestLedger(t)
+	defer os.RemoveAll(testLedgerDir)
+	code, out := runCmd(t, "state", "-ns", "ns1")
+	testutil.AssertEquals(t, code, exitOK)
+	testutil.AssertEquals(t, out, "key1\tversion=2\tsize=5\nkey2\tversion=2\tsize=2048\n2 key(s) in namespace [ns1]\n")
+
+	code, out = runCmd(t, "oversized", "-ns", "ns1", "-maxValueSize", "1024")
+	testutil.AssertEquals(t, code, exitOK)
+	testutil.AssertEquals(t, out, "key2\tversion=2\tsize=2048\n1 of 2 key(s) in namespace [ns1] are larger than 1024 bytes (2048 of 2053 bytes)\n")
+}
+
+func TestRebuildCmd(t *testing.T) {
+	createTestLedger(t)
+	defer os.RemoveAll(testLedgerDir)
+	conf := kvledger.NewConf(testLedgerDir, 0)
+	os.RemoveAll(filepath.Join(conf.BlockStorageDir(), "db"))
+
+	code, out := runCmd(t, "rebuild")
+	testutil.AssertEquals(t, code, exitOK)
+	if !strings.HasPrefix(out, "blocks=[2], lastFile=[0]") {
+		t.Fatalf("Unexpected output: %s", out)
+	}
+
+	ledger, err := kvledger.NewKVLedger(conf)
+	testutil.AssertNoError(t, err, "Error while opening the repaired ledger")
+	defer ledger.Close()
+	bcInfo, _ := ledger.GetBlockchainInfo()
+	testutil.AssertEquals(t, bcInfo.Height, uint64(2))
+	_, err = ledger.GetBlockByNumber(2)
+	testutil.AssertNoError(t, err, "Error while retrieving a block from the repaired ledger")
+}
+
+func TestMissingLedger(t *testing.T) {
+	os.RemoveAll(testLedgerDir)
+	code, _ := runCmd(t, "verify")
+	testutil.AssertEquals(t, code, exitNoLedger)
+	code = verifyCmd([]string{}, &bytes.Buffer{})
+	testutil.AssertEquals(t, code, exitUsage)
+}
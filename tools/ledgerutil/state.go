@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/lockbasedtxmgmt"
+)
+
+// openState opens the state database of the ledger. The database is not created if it does not exist
+func openState(conf *kvledger.Conf) (*lockbasedtxmgmt.LockBasedTxMgr, int) {
+	dbPath := conf.TxMgrDBPath()
+	if _, err := os.Stat(dbPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot access the state database: %s\n", err)
+		return nil, exitNoLedger
+	}
+	return lockbasedtxmgmt.NewLockBasedTxMgr(&lockbasedtxmgmt.Conf{DBPath: dbPath}), exitOK
+}
+
+func stateCmd(args []string, out io.Writer) int {
+	flagSet, ledgerDir := newFlagSet("state")
+	ns := flagSet.String("ns", "", "namespace (chaincode name) to list")
+	conf, code := parseFlags(flagSet, ledgerDir, args)
+	if code != exitOK {
+		return code
+	}
+	txMgr, code := openState(conf)
+	if code != exitOK {
+		return code
+	}
+	defer txMgr.Shutdown()
+
+	numKeys := 0
+	err := txMgr.ScanCommittedState(*ns, func(key string, value []byte, version uint64) error {
+		numKeys++
+		if value == nil {
+			fmt.Fprintf(out, "%s\tversion=%d\tdeleted\n", key, version)
+			return nil
+		}
+		fmt.Fprintf(out, "%s\tversion=%d\tsize=%d\n", key, version, len(value))
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error while scanning the state database: %s\n", err)
+		return exitFailed
+	}
+	fmt.Fprintf(out, "%d key(s) in namespace [%s]\n", numKeys, *ns)
+	return exitOK
+}
+
+func oversizedCmd(args []string, out io.Writer) int {
+	flagSet, ledgerDir := newFlagSet("oversized")
+	ns := flagSet.String("ns", "", "namespace (chaincode name) to inspect")
+	maxValueSize := flagSet.Int("maxValueSize", defaultMaxValueSize, "values larger than this many bytes are reported")
+	conf, code := parseFlags(flagSet, ledgerDir, args)
+	if code != exitOK {
+		return code
+	}
+	txMgr, code := openState(conf)
+	if code != exitOK {
+		return code
+	}
+	defer txMgr.Shutdown()
+
+	numKeys, numOversized, totalSize, oversizedSize := 0, 0, 0, 0
+	err := txMgr.ScanCommittedState(*ns, func(key string, value []byte, version uint64) error {
+		numKeys++
+		totalSize += len(value)
+		if len(value) > *maxValueSize {
+			numOversized++
+			oversizedSize += len(value)
+			fmt.Fprintf(out, "%s\tversion=%d\tsize=%d\n", key, version, len(value))
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error while scanning the state database: %s\n", err)
+		return exitFailed
+	}
+	fmt.Fprintf(out, "%d of %d key(s) in namespace [%s] are larger than %d bytes (%d of %d bytes)\n",
+		numOversized, numKeys, *ns, *maxValueSize, oversizedSize, totalSize)
+	return exitOK
+}